@@ -31,6 +31,11 @@ func main() {
 	}
 	defer serviceContainer.Close()
 
+	// Start background workers (alert delivery)
+	if err := serviceContainer.StartWorkers(); err != nil {
+		log.Printf("Warning: failed to start background workers: %v", err)
+	}
+
 	// Initialize handlers
 	handlerContainer := handlers.NewContainer(serviceContainer)
 
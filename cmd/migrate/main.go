@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/phantom-sage/bankgo/internal/config"
+	"github.com/phantom-sage/bankgo/internal/database"
+)
+
+const usage = `Usage: migrate [flags] <command> [arguments]
+
+Commands:
+  up            apply all pending migrations
+  down [N]      roll back the last N applied migrations (default 1)
+  goto V        apply or roll back migrations until version V is the latest applied;
+                goto 0 rolls back everything
+  status        list migrations and whether they are applied
+  create NAME   create empty up and down files for a new migration in -dir
+
+Migrations embedded in this binary are run against the database configured
+by the DB_* environment variables. A file whose first line is
+"` + database.NoTransactionDirective + `" runs outside a transaction, one
+statement at a time.
+
+Flags:
+`
+
+func main() {
+	dir := flag.String("dir", "internal/database/migrations", "directory new migration files are created in")
+	timeout := flag.Duration("timeout", 30*time.Minute, "maximum time to wait for the migration lock and run migrations")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// create only writes files and needs no database
+	if args[0] == "create" {
+		if len(args) != 2 {
+			log.Fatalf("Usage: migrate create NAME")
+		}
+		upPath, downPath, err := database.CreateMigration(*dir, args[1])
+		if err != nil {
+			log.Fatalf("Failed to create migration: %v", err)
+		}
+		fmt.Printf("Created %s\nCreated %s\n", upPath, downPath)
+		return
+	}
+
+	dbConfig, err := config.LoadDatabaseConfig()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	ctx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+
+	db, err := database.New(dbConfig)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	runner := database.NewMigrationRunner(db)
+
+	if err := run(ctx, runner, args); err != nil {
+		db.Close()
+		log.Fatalf("Migration failed: %v", err)
+	}
+}
+
+// run executes a database command
+func run(ctx context.Context, runner *database.MigrationRunner, args []string) error {
+	command, args := args[0], args[1:]
+
+	switch command {
+	case "up":
+		if len(args) != 0 {
+			return fmt.Errorf("usage: migrate up")
+		}
+		return runner.Up(ctx)
+
+	case "down":
+		n := 1
+		if len(args) > 1 {
+			return fmt.Errorf("usage: migrate down [N]")
+		}
+		if len(args) == 1 {
+			var err error
+			if n, err = strconv.Atoi(args[0]); err != nil || n <= 0 {
+				return fmt.Errorf("invalid number of migrations %q", args[0])
+			}
+		}
+		return runner.DownN(ctx, n)
+
+	case "goto":
+		if len(args) != 1 {
+			return fmt.Errorf("usage: migrate goto V")
+		}
+		version, err := strconv.Atoi(args[0])
+		if err != nil || version < 0 {
+			return fmt.Errorf("invalid version %q", args[0])
+		}
+		return runner.Goto(ctx, version)
+
+	case "status":
+		statuses, err := runner.Status(ctx)
+		if err != nil {
+			return err
+		}
+		return printStatus(statuses)
+
+	default:
+		return fmt.Errorf("unknown command %q, run migrate -h for usage", command)
+	}
+}
+
+// printStatus prints migration statuses as a table
+func printStatus(statuses []database.MigrationStatus) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATE\tAPPLIED AT")
+	for _, status := range statuses {
+		appliedAt := "-"
+		if status.AppliedAt != nil {
+			appliedAt = status.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%03d\t%s\t%s\t%s\n", status.Version, status.Name, status.State, appliedAt)
+	}
+	return w.Flush()
+}
@@ -10,11 +10,20 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/hibiken/asynq"
+	admininterfaces "github.com/phantom-sage/bankgo/internal/admin/interfaces"
+	adminservices "github.com/phantom-sage/bankgo/internal/admin/services"
+	"github.com/phantom-sage/bankgo/internal/alerts"
 	"github.com/phantom-sage/bankgo/internal/config"
 	"github.com/phantom-sage/bankgo/internal/database"
+	"github.com/phantom-sage/bankgo/internal/events"
 	"github.com/phantom-sage/bankgo/internal/logging"
+	"github.com/phantom-sage/bankgo/internal/outbox"
 	"github.com/phantom-sage/bankgo/internal/queue"
+	"github.com/phantom-sage/bankgo/internal/repository"
 	"github.com/phantom-sage/bankgo/internal/router"
+	"github.com/phantom-sage/bankgo/internal/services"
+	"github.com/phantom-sage/bankgo/pkg/email"
 )
 
 const version = "v1.0.0"
@@ -86,7 +95,38 @@ func main() {
 	}
 
 	// Setup router with logger manager
-	r := router.SetupRouter(db, queueManager, cfg, loggerManager, version)
+	r := router.SetupRouter(db, queueManager, cfg, loggerManager, newAlertPublisher(db, queueManager), version)
+
+	// Process queued welcome and security emails and transfer batches; the
+	// router has registered the batch handler by now
+	if queueManager != nil {
+		emailService := email.NewService(cfg.Email)
+		queueManager.RegisterHandlers(emailService)
+		queueManager.RegisterSecurityEmailHandlers(emailService)
+		if err := queueManager.StartServer(); err != nil {
+			logger.Warn().Err(err).Msg("Failed to start queue worker")
+		} else {
+			defer queueManager.ShutdownServer()
+			logger.Info().Msg("Queue worker started")
+		}
+	}
+
+	// Relay the outbox the services write domain events and emails to
+	if db != nil && queueManager != nil {
+		relayCtx, stopRelay := context.WithCancel(context.Background())
+		defer stopRelay()
+		repo := repository.New(db, logger)
+		bus := events.NewRedisBus(queueManager.RedisClient())
+		relay := outbox.NewRelay(repo, queueManager, bus, cfg.Outbox, logger)
+		relay.Start(relayCtx)
+		logger.Info().Msg("Outbox relay started")
+
+		// Turn relayed domain events into customer webhook deliveries
+		webhookService := services.NewWebhookService(repo, cfg.Webhooks, logger)
+		if err := bus.Consume(relayCtx, services.WebhookConsumerGroup, webhookService.HandleEvent); err != nil {
+			logger.Warn().Err(err).Msg("Failed to start webhook dispatcher")
+		}
+	}
 
 	// Create HTTP server
 	srv := &http.Server{
@@ -117,4 +157,26 @@ func main() {
 	}
 
 	logger.Info().Msg("Server exited")
-}
\ No newline at end of file
+}
+
+// newAlertPublisher raises the banking API's alerts on the shared admin alerts
+// table. With Redis they also reach admins connected to the admin API and the
+// email, Slack and webhook channels its alert worker routes them to; the admin
+// API must use the same Redis database to replay and route them.
+func newAlertPublisher(db *database.DB, queueManager *queue.QueueManager) alerts.Publisher {
+	if db == nil {
+		return nil
+	}
+
+	notifications := adminservices.NewNotificationService()
+	var alertRouter admininterfaces.AlertRouter
+	if queueManager != nil {
+		notifications = adminservices.NewNotificationServiceWithBus(
+			adminservices.NewRedisNotificationBus(queueManager.RedisClient(), adminservices.DefaultReplayBufferSize),
+		)
+		alertRouter = adminservices.NewQueuedAlertRouter(asynq.NewClientFromRedisClient(queueManager.RedisClient()))
+	}
+	return adminservices.NewAlertGeneratorService(
+		adminservices.NewAlertServiceWithDispatcher(db.Pool, notifications, alertRouter),
+	)
+}
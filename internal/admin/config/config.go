@@ -1,10 +1,15 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/netip"
 	"os"
 	"strconv"
+	"strings"
 	"time"
+
+	appconfig "github.com/phantom-sage/bankgo/internal/config"
 )
 
 // Config holds all configuration for the admin API server
@@ -14,11 +19,16 @@ type Config struct {
 	Environment string `json:"environment"`
 
 	// Database configuration
-	DatabaseURL string `json:"database_url"`
+	DatabaseURL string             `json:"database_url"`
+	Database    DatabasePoolConfig `json:"database"`
 
 	// Redis configuration
-	RedisURL      string `json:"redis_url"`
-	RedisPassword string `json:"redis_password"`
+	RedisURL      string            `json:"redis_url"`
+	RedisPassword string            `json:"redis_password"`
+	Redis         RedisClientConfig `json:"redis"`
+
+	// Dependency checks at startup
+	Startup StartupConfig `json:"startup"`
 
 	// Authentication configuration
 	PasetoSecretKey   string        `json:"-"` // Hidden from JSON
@@ -32,9 +42,281 @@ type Config struct {
 	// CORS configuration
 	AllowedOrigins []string `json:"allowed_origins"`
 
+	// TrustedProxies are the proxy addresses or CIDR ranges allowed to name
+	// the client in X-Forwarded-For; empty trusts none. The client IP keys
+	// sign-in lockouts.
+	TrustedProxies []string `json:"trusted_proxies"`
+
 	// WebSocket configuration
 	WSReadTimeout  time.Duration `json:"ws_read_timeout"`
 	WSWriteTimeout time.Duration `json:"ws_write_timeout"`
+
+	// NotificationReplayBuffer is how many recent notifications are kept for
+	// WebSocket clients that reconnect with the last sequence they saw
+	NotificationReplayBuffer int `json:"notification_replay_buffer"`
+
+	// Alert notification channel configuration
+	Alerts AlertChannelConfig `json:"alerts"`
+
+	// Alert lifecycle worker configuration
+	AlertLifecycle AlertLifecycleConfig `json:"alert_lifecycle"`
+
+	// Data browser table allowlist and column permissions
+	DataBrowser DataBrowserConfig `json:"data_browser"`
+
+	// Read-only SQL console guardrails
+	SQLConsole SQLConsoleConfig `json:"sql_console"`
+
+	// Brute-force protection for admin sign-ins
+	LoginProtection appconfig.LoginProtectionConfig `json:"login_protection"`
+}
+
+// DatabasePoolConfig sizes the admin connection pool. TLS is configured
+// through the sslmode, sslrootcert, sslcert and sslkey parameters of the
+// database URL.
+type DatabasePoolConfig struct {
+	MaxConns        int           `json:"max_conns"`
+	MinConns        int           `json:"min_conns"`
+	MaxConnLifetime time.Duration `json:"max_conn_lifetime"`
+	MaxConnIdleTime time.Duration `json:"max_conn_idle_time"`
+
+	// Optional read replica for searches and the SQL console. Reads fall
+	// back to the primary while its lag exceeds ReplicaMaxLag.
+	ReplicaURL              string        `json:"-"`
+	ReplicaMaxLag           time.Duration `json:"replica_max_lag"`
+	ReplicaLagCheckInterval time.Duration `json:"replica_lag_check_interval"`
+}
+
+// RedisClientConfig sizes the Redis client pool. TLS is used for rediss://
+// URLs, or for any URL when TLS is set.
+type RedisClientConfig struct {
+	PoolSize     int           `json:"pool_size"`
+	MinIdleConns int           `json:"min_idle_conns"`
+	DialTimeout  time.Duration `json:"dial_timeout"`
+	TLS          bool          `json:"tls"`
+
+	// TLSCAFile is a PEM bundle used instead of the system roots
+	TLSCAFile string `json:"tls_ca_file"`
+}
+
+// StartupConfig controls how dependencies are checked at startup. The
+// database is always required; without Redis the server can run degraded,
+// delivering alerts inline and reporting Redis as unavailable in /health.
+type StartupConfig struct {
+	// Retries is the number of extra connection attempts per dependency
+	Retries int `json:"retries"`
+
+	// RetryDelay is the wait before the first retry; it doubles on each retry
+	RetryDelay time.Duration `json:"retry_delay"`
+
+	// RequireRedis fails startup when Redis is unreachable instead of degrading
+	RequireRedis bool `json:"require_redis"`
+}
+
+// SQLConsoleConfig holds the guardrails of the admin SQL console. Columns hidden
+// or masked in the data browser are masked in console results as well.
+type SQLConsoleConfig struct {
+	// StatementTimeout aborts queries running longer than this
+	StatementTimeout time.Duration `json:"statement_timeout"`
+
+	// MaxRows caps the rows returned; longer results are truncated
+	MaxRows int `json:"max_rows"`
+
+	// MaskedColumns are masked wherever they appear, as "column" for any
+	// table or "table.column" for one table
+	MaskedColumns []string `json:"masked_columns"`
+
+	// PartiallyMaskedColumns are masked like MaskedColumns but keep their last
+	// four characters, for values such as account numbers
+	PartiallyMaskedColumns []string `json:"partially_masked_columns"`
+
+	// Role, when set, is assumed for every console query. Grant it SELECT on
+	// everything but the masked columns so the database enforces the masking
+	// the console checks cannot.
+	Role string `json:"role"`
+}
+
+// IsMasked reports whether a console result column is masked, fully or
+// partially. table is empty when the column is computed rather than read from
+// a table.
+func (c SQLConsoleConfig) IsMasked(table, column string) bool {
+	return matchesColumn(c.MaskedColumns, table, column) || matchesColumn(c.PartiallyMaskedColumns, table, column)
+}
+
+// IsPartiallyMasked reports whether a console result column keeps its last
+// characters when masked. Full masking wins when a column is listed as both.
+func (c SQLConsoleConfig) IsPartiallyMasked(table, column string) bool {
+	return matchesColumn(c.PartiallyMaskedColumns, table, column) && !matchesColumn(c.MaskedColumns, table, column)
+}
+
+// matchesColumn reports whether names lists a column as "column" or "table.column"
+func matchesColumn(names []string, table, column string) bool {
+	if containsString(names, column) {
+		return true
+	}
+	return table != "" && containsString(names, table+"."+column)
+}
+
+// DataBrowserConfig controls which tables the admin data browser exposes and
+// what may be done with their columns. Tables not listed are invisible.
+type DataBrowserConfig struct {
+	Tables map[string]TablePolicy `json:"tables"`
+}
+
+// TablePolicy holds the permissions for one browsable table
+type TablePolicy struct {
+	// ReadOnly rejects creates, updates and deletes on the whole table
+	ReadOnly bool `json:"read_only"`
+
+	// ReadOnlyColumns are shown but cannot be written
+	ReadOnlyColumns []string `json:"read_only_columns"`
+
+	// HiddenColumns are never selected, filtered, sorted or written
+	HiddenColumns []string `json:"hidden_columns"`
+
+	// MaskedColumns are returned masked and cannot be filtered or sorted on
+	MaskedColumns []string `json:"masked_columns"`
+
+	// PartiallyMaskedColumns are treated like MaskedColumns but keep their
+	// last four characters, for values such as account numbers
+	PartiallyMaskedColumns []string `json:"partially_masked_columns"`
+}
+
+// Table returns the policy for a table and whether the table is browsable
+func (c DataBrowserConfig) Table(name string) (TablePolicy, bool) {
+	policy, ok := c.Tables[name]
+	return policy, ok
+}
+
+// IsHidden reports whether a column is hidden
+func (p TablePolicy) IsHidden(column string) bool {
+	return containsString(p.HiddenColumns, column)
+}
+
+// IsMasked reports whether a column is masked, fully or partially
+func (p TablePolicy) IsMasked(column string) bool {
+	return containsString(p.MaskedColumns, column) || containsString(p.PartiallyMaskedColumns, column)
+}
+
+// IsPartiallyMasked reports whether a masked column keeps its last
+// characters. Full masking wins when a column is listed as both.
+func (p TablePolicy) IsPartiallyMasked(column string) bool {
+	return containsString(p.PartiallyMaskedColumns, column) && !containsString(p.MaskedColumns, column)
+}
+
+// IsReadOnly reports whether a column cannot be written
+func (p TablePolicy) IsReadOnly(column string) bool {
+	return p.ReadOnly || containsString(p.ReadOnlyColumns, column)
+}
+
+// DefaultDataBrowserConfig returns the tables browsable when no data browser
+// configuration file is given. Ledger tables are read-only so balances only
+// change through transfers.
+func DefaultDataBrowserConfig() DataBrowserConfig {
+	audit := []string{"created_at", "updated_at"}
+	return DataBrowserConfig{
+		Tables: map[string]TablePolicy{
+			"users": {
+				HiddenColumns:   []string{"password_hash"},
+				ReadOnlyColumns: append([]string{"id", "last_login_at"}, audit...),
+			},
+			"accounts": {
+				ReadOnlyColumns: append([]string{"id", "user_id", "balance"}, audit...),
+			},
+			"transfers":                 {ReadOnly: true},
+			"transfer_fees":             {ReadOnly: true},
+			"transfer_batches":          {ReadOnly: true},
+			"transfer_batch_items":      {ReadOnly: true},
+			"transfer_risk_assessments": {ReadOnly: true},
+			"user_transfer_limits": {
+				ReadOnlyColumns: append([]string{"id", "user_id"}, audit...),
+			},
+			"fee_schedules": {
+				ReadOnlyColumns: append([]string{"id"}, audit...),
+			},
+			"alerts": {
+				ReadOnlyColumns: append([]string{"id", "timestamp"}, audit...),
+			},
+			"import_jobs":     {ReadOnly: true},
+			"import_job_rows": {ReadOnly: true},
+			"admin_query_log": {ReadOnly: true},
+			"login_events":    {ReadOnly: true},
+		},
+	}
+}
+
+// LoadDataBrowserConfig reads a data browser configuration from a JSON file
+func LoadDataBrowserConfig(path string) (DataBrowserConfig, error) {
+	var cfg DataBrowserConfig
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, fmt.Errorf("failed to read data browser config: %w", err)
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("failed to parse data browser config: %w", err)
+	}
+
+	for table, policy := range cfg.Tables {
+		for _, column := range policy.HiddenColumns {
+			if policy.IsMasked(column) {
+				return cfg, fmt.Errorf("table %s: column %s cannot be both hidden and masked", table, column)
+			}
+		}
+	}
+
+	return cfg, nil
+}
+
+// AlertChannelConfig holds outbound alert channel and routing settings
+type AlertChannelConfig struct {
+	// SMTP email channel
+	SMTPHost     string   `json:"smtp_host"`
+	SMTPPort     int      `json:"smtp_port"`
+	SMTPUsername string   `json:"smtp_username"`
+	SMTPPassword string   `json:"-"` // Hidden from JSON
+	EmailFrom    string   `json:"email_from"`
+	EmailTo      []string `json:"email_to"`
+
+	// Generic webhook channel
+	WebhookURL    string `json:"webhook_url"`
+	WebhookSecret string `json:"-"` // Hidden from JSON
+
+	// Slack-compatible webhook channel
+	SlackWebhookURL string `json:"slack_webhook_url"`
+	SlackChannel    string `json:"slack_channel"`
+
+	// Routes maps a severity to the channel names it is delivered to
+	Routes map[string][]string `json:"routes"`
+
+	// MaxRetry is the number of delivery retries before giving up
+	MaxRetry int `json:"max_retry"`
+}
+
+// AlertLifecycleConfig holds escalation, auto-resolution and retention settings
+type AlertLifecycleConfig struct {
+	// CheckInterval is how often escalation and auto-resolution run
+	CheckInterval time.Duration `json:"check_interval"`
+
+	// EscalationTiers are applied in order as unacknowledged alerts age
+	EscalationTiers []EscalationTier `json:"escalation_tiers"`
+
+	// AutoResolveIntervals is the number of consecutive normal metric
+	// readings required before a metric alert is resolved automatically
+	AutoResolveIntervals int `json:"auto_resolve_intervals"`
+
+	// Retention is how long resolved alerts are kept
+	Retention time.Duration `json:"retention"`
+
+	// RetentionInterval is how often old resolved alerts are cleaned up
+	RetentionInterval time.Duration `json:"retention_interval"`
+}
+
+// EscalationTier describes one escalation step for unacknowledged alerts
+type EscalationTier struct {
+	After    time.Duration `json:"after"`
+	Severity string        `json:"severity"`
+	Channels []string      `json:"channels"`
 }
 
 // Load loads configuration from environment variables
@@ -49,6 +331,61 @@ func Load() (*Config, error) {
 		AllowedOrigins:   []string{"http://localhost:3000"},
 		WSReadTimeout:    60 * time.Second,
 		WSWriteTimeout:   10 * time.Second,
+		NotificationReplayBuffer: 500,
+		Alerts: AlertChannelConfig{
+			SMTPPort:  587,
+			EmailFrom: "alerts@bankgo.local",
+			Routes: map[string][]string{
+				"critical": {"email", "slack", "webhook"},
+				"warning":  {"slack", "webhook"},
+				"info":     {},
+			},
+			MaxRetry: 5,
+		},
+		AlertLifecycle: AlertLifecycleConfig{
+			CheckInterval: time.Minute,
+			EscalationTiers: []EscalationTier{
+				{After: 15 * time.Minute, Severity: "warning", Channels: []string{"slack"}},
+				{After: 30 * time.Minute, Severity: "critical", Channels: []string{"email", "slack"}},
+			},
+			AutoResolveIntervals: 3,
+			Retention:            30 * 24 * time.Hour,
+			RetentionInterval:    24 * time.Hour,
+		},
+		Database: DatabasePoolConfig{
+			MaxConns:                10,
+			MinConns:                2,
+			MaxConnLifetime:         30 * time.Minute,
+			MaxConnIdleTime:         5 * time.Minute,
+			ReplicaMaxLag:           10 * time.Second,
+			ReplicaLagCheckInterval: 5 * time.Second,
+		},
+		Redis: RedisClientConfig{
+			PoolSize:     10,
+			MinIdleConns: 2,
+			DialTimeout:  5 * time.Second,
+		},
+		Startup: StartupConfig{
+			Retries:    5,
+			RetryDelay: 2 * time.Second,
+		},
+		DataBrowser: DefaultDataBrowserConfig(),
+		SQLConsole: SQLConsoleConfig{
+			StatementTimeout: 10 * time.Second,
+			MaxRows:          1000,
+			MaskedColumns:    []string{"password_hash"},
+		},
+		LoginProtection: appconfig.LoginProtectionConfig{
+			Enabled:            true,
+			FailureWindow:      time.Hour,
+			DelayAfter:         2,
+			BaseDelay:          time.Second,
+			MaxDelay:           30 * time.Second,
+			LockoutThreshold:   5,
+			IPLockoutThreshold: 10,
+			LockoutDuration:    15 * time.Minute,
+			MaxLockoutDuration: 24 * time.Hour,
+		},
 	}
 
 	// Load from environment variables
@@ -97,9 +434,323 @@ func Load() (*Config, error) {
 		cfg.AllowedOrigins = []string{origins}
 	}
 
+	cfg.TrustedProxies = splitList(os.Getenv("ADMIN_TRUSTED_PROXIES"))
+	for _, proxy := range cfg.TrustedProxies {
+		if _, err := netip.ParsePrefix(proxy); err != nil {
+			if _, err := netip.ParseAddr(proxy); err != nil {
+				return nil, fmt.Errorf("invalid ADMIN_TRUSTED_PROXIES: %q is not an IP address or CIDR range", proxy)
+			}
+		}
+	}
+
+	if err := loadDependencyConfig(cfg); err != nil {
+		return nil, err
+	}
+
+	if err := positiveIntEnv("ADMIN_NOTIFICATION_REPLAY_BUFFER", &cfg.NotificationReplayBuffer); err != nil {
+		return nil, err
+	}
+
+	loadAlertChannelConfig(&cfg.Alerts)
+
+	if err := loadAlertLifecycleConfig(&cfg.AlertLifecycle); err != nil {
+		return nil, err
+	}
+
+	// Data browser allowlist, replacing the defaults entirely when given
+	if path := os.Getenv("ADMIN_DATA_BROWSER_CONFIG"); path != "" {
+		browser, err := LoadDataBrowserConfig(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ADMIN_DATA_BROWSER_CONFIG: %w", err)
+		}
+		cfg.DataBrowser = browser
+	}
+
+	loadSQLConsoleConfig(&cfg.SQLConsole)
+
+	if err := loadLoginProtectionConfig(&cfg.LoginProtection); err != nil {
+		return nil, err
+	}
+
 	return cfg, nil
 }
 
+// loadDependencyConfig loads database, Redis and startup settings from
+// environment variables. Redis is required by default in production.
+func loadDependencyConfig(cfg *Config) error {
+	db := &cfg.Database
+	if err := positiveIntEnv("ADMIN_DB_MAX_CONNS", &db.MaxConns); err != nil {
+		return err
+	}
+	if err := nonNegativeIntEnv("ADMIN_DB_MIN_CONNS", &db.MinConns); err != nil {
+		return err
+	}
+	if db.MinConns > db.MaxConns {
+		return fmt.Errorf("ADMIN_DB_MIN_CONNS must not exceed ADMIN_DB_MAX_CONNS")
+	}
+	if err := durationEnv("ADMIN_DB_CONN_MAX_LIFETIME", &db.MaxConnLifetime); err != nil {
+		return err
+	}
+	if err := durationEnv("ADMIN_DB_CONN_MAX_IDLE_TIME", &db.MaxConnIdleTime); err != nil {
+		return err
+	}
+	db.ReplicaURL = os.Getenv("ADMIN_DB_REPLICA_URL")
+	if err := durationEnv("ADMIN_DB_REPLICA_MAX_LAG", &db.ReplicaMaxLag); err != nil {
+		return err
+	}
+	if err := durationEnv("ADMIN_DB_REPLICA_LAG_CHECK_INTERVAL", &db.ReplicaLagCheckInterval); err != nil {
+		return err
+	}
+
+	redisCfg := &cfg.Redis
+	if err := positiveIntEnv("ADMIN_REDIS_POOL_SIZE", &redisCfg.PoolSize); err != nil {
+		return err
+	}
+	if err := nonNegativeIntEnv("ADMIN_REDIS_MIN_IDLE_CONNS", &redisCfg.MinIdleConns); err != nil {
+		return err
+	}
+	if err := durationEnv("ADMIN_REDIS_DIAL_TIMEOUT", &redisCfg.DialTimeout); err != nil {
+		return err
+	}
+	if err := boolEnv("ADMIN_REDIS_TLS", &redisCfg.TLS); err != nil {
+		return err
+	}
+	redisCfg.TLSCAFile = os.Getenv("ADMIN_REDIS_TLS_CA_FILE")
+
+	startup := &cfg.Startup
+	startup.RequireRedis = cfg.IsProduction()
+	if err := nonNegativeIntEnv("ADMIN_STARTUP_RETRIES", &startup.Retries); err != nil {
+		return err
+	}
+	if err := durationEnv("ADMIN_STARTUP_RETRY_DELAY", &startup.RetryDelay); err != nil {
+		return err
+	}
+	return boolEnv("ADMIN_REDIS_REQUIRED", &startup.RequireRedis)
+}
+
+// positiveIntEnv sets target from a positive integer environment variable
+func positiveIntEnv(name string, target *int) error {
+	if value := os.Getenv(name); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			return fmt.Errorf("%s must be a positive integer", name)
+		}
+		*target = n
+	}
+	return nil
+}
+
+// nonNegativeIntEnv sets target from a non-negative integer environment variable
+func nonNegativeIntEnv(name string, target *int) error {
+	if value := os.Getenv(name); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return fmt.Errorf("%s must be a non-negative integer", name)
+		}
+		*target = n
+	}
+	return nil
+}
+
+// durationEnv sets target from a positive duration environment variable
+func durationEnv(name string, target *time.Duration) error {
+	if value := os.Getenv(name); value != "" {
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("%s must be a positive duration", name)
+		}
+		*target = d
+	}
+	return nil
+}
+
+// boolEnv sets target from a boolean environment variable
+func boolEnv(name string, target *bool) error {
+	if value := os.Getenv(name); value != "" {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%s must be true or false", name)
+		}
+		*target = b
+	}
+	return nil
+}
+
+// loadSQLConsoleConfig loads SQL console guardrails from environment variables
+func loadSQLConsoleConfig(console *SQLConsoleConfig) {
+	if timeout := os.Getenv("ADMIN_SQL_CONSOLE_TIMEOUT"); timeout != "" {
+		if d, err := time.ParseDuration(timeout); err == nil && d > 0 {
+			console.StatementTimeout = d
+		}
+	}
+
+	if maxRows := os.Getenv("ADMIN_SQL_CONSOLE_MAX_ROWS"); maxRows != "" {
+		if n, err := strconv.Atoi(maxRows); err == nil && n > 0 {
+			console.MaxRows = n
+		}
+	}
+
+	// Extra masked columns are added to the defaults, never replacing them
+	console.MaskedColumns = append(console.MaskedColumns, splitList(os.Getenv("ADMIN_SQL_CONSOLE_MASKED_COLUMNS"))...)
+	console.PartiallyMaskedColumns = append(console.PartiallyMaskedColumns, splitList(os.Getenv("ADMIN_SQL_CONSOLE_PARTIALLY_MASKED_COLUMNS"))...)
+
+	if role := os.Getenv("ADMIN_SQL_CONSOLE_ROLE"); role != "" {
+		console.Role = role
+	}
+}
+
+// loadLoginProtectionConfig loads admin brute-force protection settings from
+// environment variables. Delays keep their defaults; the thresholds and
+// lockout duration can be tightened.
+func loadLoginProtectionConfig(login *appconfig.LoginProtectionConfig) error {
+	if err := boolEnv("ADMIN_LOGIN_PROTECTION_ENABLED", &login.Enabled); err != nil {
+		return err
+	}
+	if err := positiveIntEnv("ADMIN_LOGIN_LOCKOUT_THRESHOLD", &login.LockoutThreshold); err != nil {
+		return err
+	}
+	if err := positiveIntEnv("ADMIN_LOGIN_IP_LOCKOUT_THRESHOLD", &login.IPLockoutThreshold); err != nil {
+		return err
+	}
+	if err := durationEnv("ADMIN_LOGIN_LOCKOUT_DURATION", &login.LockoutDuration); err != nil {
+		return err
+	}
+	if login.MaxLockoutDuration < login.LockoutDuration {
+		login.MaxLockoutDuration = login.LockoutDuration
+	}
+	if err := login.Validate(); err != nil {
+		return fmt.Errorf("invalid admin login protection config: %w", err)
+	}
+	return nil
+}
+
+// loadAlertChannelConfig loads alert channel settings from environment variables
+func loadAlertChannelConfig(alerts *AlertChannelConfig) {
+	alerts.SMTPHost = os.Getenv("ALERT_SMTP_HOST")
+	if port := os.Getenv("ALERT_SMTP_PORT"); port != "" {
+		if p, err := strconv.Atoi(port); err == nil {
+			alerts.SMTPPort = p
+		}
+	}
+	alerts.SMTPUsername = os.Getenv("ALERT_SMTP_USERNAME")
+	alerts.SMTPPassword = os.Getenv("ALERT_SMTP_PASSWORD")
+	if from := os.Getenv("ALERT_EMAIL_FROM"); from != "" {
+		alerts.EmailFrom = from
+	}
+	alerts.EmailTo = splitList(os.Getenv("ALERT_EMAIL_TO"))
+
+	alerts.WebhookURL = os.Getenv("ALERT_WEBHOOK_URL")
+	alerts.WebhookSecret = os.Getenv("ALERT_WEBHOOK_SECRET")
+
+	alerts.SlackWebhookURL = os.Getenv("ALERT_SLACK_WEBHOOK_URL")
+	alerts.SlackChannel = os.Getenv("ALERT_SLACK_CHANNEL")
+
+	// Per-severity routing, e.g. ALERT_ROUTE_CRITICAL=email,slack
+	for _, severity := range []string{"critical", "warning", "info"} {
+		if route, ok := os.LookupEnv("ALERT_ROUTE_" + strings.ToUpper(severity)); ok {
+			alerts.Routes[severity] = splitList(route)
+		}
+	}
+
+	if retry := os.Getenv("ALERT_DELIVERY_MAX_RETRY"); retry != "" {
+		if r, err := strconv.Atoi(retry); err == nil && r >= 0 {
+			alerts.MaxRetry = r
+		}
+	}
+}
+
+// loadAlertLifecycleConfig loads alert lifecycle settings from environment variables
+func loadAlertLifecycleConfig(lifecycle *AlertLifecycleConfig) error {
+	if interval := os.Getenv("ALERT_LIFECYCLE_INTERVAL"); interval != "" {
+		if d, err := time.ParseDuration(interval); err == nil && d > 0 {
+			lifecycle.CheckInterval = d
+		}
+	}
+
+	// Tiers are comma-separated "after:severity:channel|channel" entries,
+	// e.g. ALERT_ESCALATION_TIERS=15m:warning:slack,30m:critical:email|slack
+	if tiers, ok := os.LookupEnv("ALERT_ESCALATION_TIERS"); ok {
+		parsed, err := ParseEscalationTiers(tiers)
+		if err != nil {
+			return fmt.Errorf("invalid ALERT_ESCALATION_TIERS: %w", err)
+		}
+		lifecycle.EscalationTiers = parsed
+	}
+
+	if intervals := os.Getenv("ALERT_AUTO_RESOLVE_INTERVALS"); intervals != "" {
+		if n, err := strconv.Atoi(intervals); err == nil && n >= 0 {
+			lifecycle.AutoResolveIntervals = n
+		}
+	}
+
+	if retention := os.Getenv("ALERT_RETENTION"); retention != "" {
+		if d, err := time.ParseDuration(retention); err == nil && d > 0 {
+			lifecycle.Retention = d
+		}
+	}
+
+	if interval := os.Getenv("ALERT_RETENTION_INTERVAL"); interval != "" {
+		if d, err := time.ParseDuration(interval); err == nil && d > 0 {
+			lifecycle.RetentionInterval = d
+		}
+	}
+
+	return nil
+}
+
+// ParseEscalationTiers parses escalation tiers in "after:severity:channel|channel" form
+func ParseEscalationTiers(value string) ([]EscalationTier, error) {
+	tiers := []EscalationTier{}
+	for _, entry := range splitList(value) {
+		parts := strings.SplitN(entry, ":", 3)
+		if len(parts) < 2 {
+			return nil, fmt.Errorf("tier %q must be in after:severity[:channels] form", entry)
+		}
+
+		after, err := time.ParseDuration(parts[0])
+		if err != nil || after <= 0 {
+			return nil, fmt.Errorf("tier %q has invalid delay", entry)
+		}
+
+		severity := strings.TrimSpace(parts[1])
+		if severity != "critical" && severity != "warning" && severity != "info" {
+			return nil, fmt.Errorf("tier %q has invalid severity", entry)
+		}
+
+		tier := EscalationTier{After: after, Severity: severity, Channels: []string{}}
+		if len(parts) == 3 {
+			tier.Channels = splitList(strings.ReplaceAll(parts[2], "|", ","))
+		}
+
+		if len(tiers) > 0 && after <= tiers[len(tiers)-1].After {
+			return nil, fmt.Errorf("tier %q must come after the previous tier", entry)
+		}
+		tiers = append(tiers, tier)
+	}
+	return tiers, nil
+}
+
+// splitList splits a comma-separated list, dropping empty entries
+func splitList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
 // IsDevelopment returns true if running in development mode
 func (c *Config) IsDevelopment() bool {
 	return c.Environment == "development"
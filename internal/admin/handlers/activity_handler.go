@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
+)
+
+// ActivityHandlerImpl implements the banking activity counter endpoints
+type ActivityHandlerImpl struct {
+	activityService interfaces.ActivityFeedService
+}
+
+// NewActivityHandler creates a new activity handler
+func NewActivityHandler(activityService interfaces.ActivityFeedService) interfaces.ActivityHandler {
+	return &ActivityHandlerImpl{
+		activityService: activityService,
+	}
+}
+
+// RegisterRoutes registers HTTP routes for banking activity counters
+func (h *ActivityHandlerImpl) RegisterRoutes(router gin.IRouter) {
+	router.GET("/activity/counters", h.GetCounters)
+}
+
+// GetCounters handles GET /api/admin/activity/counters?date=YYYY-MM-DD,
+// defaulting to today (UTC)
+func (h *ActivityHandlerImpl) GetCounters(c *gin.Context) {
+	date := time.Now()
+	if value := c.Query("date"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "invalid_date",
+				"message": "Date must be in YYYY-MM-DD format",
+			})
+			return
+		}
+		date = parsed
+	}
+
+	counters, err := h.activityService.GetCounters(c.Request.Context(), date)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "failed_to_get_activity",
+			"message": "Failed to retrieve activity counters",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, counters)
+}
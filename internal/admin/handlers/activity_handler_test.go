@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockActivityFeedService is a mock implementation of ActivityFeedService
+type MockActivityFeedService struct {
+	mock.Mock
+}
+
+func (m *MockActivityFeedService) Start(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockActivityFeedService) GetCounters(ctx context.Context, date time.Time) (*interfaces.ActivityCounters, error) {
+	args := m.Called(ctx, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*interfaces.ActivityCounters), args.Error(1)
+}
+
+func setupActivityHandler() (*gin.Engine, *MockActivityFeedService) {
+	gin.SetMode(gin.TestMode)
+	mockService := &MockActivityFeedService{}
+	handler := NewActivityHandler(mockService)
+
+	router := gin.New()
+	handler.RegisterRoutes(router.Group("/api/admin"))
+	return router, mockService
+}
+
+func TestActivityHandler_GetCounters(t *testing.T) {
+	router, mockService := setupActivityHandler()
+
+	date := time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC)
+	mockService.On("GetCounters", mock.Anything, date).Return(&interfaces.ActivityCounters{
+		Date:               "2026-03-14",
+		TransfersCompleted: 12,
+		TransferVolume:     map[string]string{"USD": "1200.00"},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/activity/counters?date=2026-03-14", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var counters interfaces.ActivityCounters
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &counters))
+	assert.Equal(t, int64(12), counters.TransfersCompleted)
+	assert.Equal(t, "1200.00", counters.TransferVolume["USD"])
+	mockService.AssertExpectations(t)
+}
+
+func TestActivityHandler_GetCounters_InvalidDate(t *testing.T) {
+	router, mockService := setupActivityHandler()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/activity/counters?date=14-03-2026", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "GetCounters", mock.Anything, mock.Anything)
+}
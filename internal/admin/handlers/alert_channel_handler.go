@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
+)
+
+// AlertChannelHandlerImpl implements alert notification channel endpoints
+type AlertChannelHandlerImpl struct {
+	dispatcher interfaces.AlertDispatcher
+}
+
+// NewAlertChannelHandler creates a new alert channel handler
+func NewAlertChannelHandler(dispatcher interfaces.AlertDispatcher) interfaces.AlertChannelHandler {
+	return &AlertChannelHandlerImpl{
+		dispatcher: dispatcher,
+	}
+}
+
+// RegisterRoutes registers HTTP routes for alert channel management
+func (h *AlertChannelHandlerImpl) RegisterRoutes(router gin.IRouter) {
+	channelGroup := router.Group("/alert-channels")
+	{
+		channelGroup.GET("", h.ListChannels)
+		channelGroup.POST("/:name/test", h.TestSend)
+	}
+}
+
+// ListChannels returns configured notification channels and their routing
+func (h *AlertChannelHandlerImpl) ListChannels(c *gin.Context) {
+	channels := h.dispatcher.ListChannels()
+
+	c.JSON(http.StatusOK, gin.H{
+		"channels": channels,
+		"total":    len(channels),
+	})
+}
+
+// TestSend sends a synthetic alert through a single channel
+func (h *AlertChannelHandlerImpl) TestSend(c *gin.Context) {
+	name := c.Param("name")
+	if name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "missing_channel_name",
+			"message": "Channel name is required",
+		})
+		return
+	}
+
+	result, err := h.dispatcher.TestSend(c.Request.Context(), name)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error":   "channel_not_found",
+				"message": "Notification channel not found",
+				"details": err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "failed_to_test_channel",
+			"message": "Failed to send test notification",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	status := http.StatusOK
+	if !result.Success {
+		status = http.StatusBadGateway
+	}
+
+	c.JSON(status, result)
+}
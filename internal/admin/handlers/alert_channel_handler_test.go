@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockAlertDispatcher is a mock implementation of AlertDispatcher
+type MockAlertDispatcher struct {
+	mock.Mock
+}
+
+func (m *MockAlertDispatcher) Dispatch(ctx context.Context, alert *interfaces.Alert) error {
+	args := m.Called(ctx, alert)
+	return args.Error(0)
+}
+
+func (m *MockAlertDispatcher) DispatchTo(ctx context.Context, alert *interfaces.Alert, channelNames []string) error {
+	args := m.Called(ctx, alert, channelNames)
+	return args.Error(0)
+}
+
+func (m *MockAlertDispatcher) TestSend(ctx context.Context, channelName string) (*interfaces.ChannelTestResult, error) {
+	args := m.Called(ctx, channelName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*interfaces.ChannelTestResult), args.Error(1)
+}
+
+func (m *MockAlertDispatcher) ListChannels() []interfaces.ChannelInfo {
+	args := m.Called()
+	return args.Get(0).([]interfaces.ChannelInfo)
+}
+
+func setupAlertChannelHandler() (*gin.Engine, *MockAlertDispatcher) {
+	gin.SetMode(gin.TestMode)
+	mockDispatcher := &MockAlertDispatcher{}
+	handler := NewAlertChannelHandler(mockDispatcher)
+
+	router := gin.New()
+	handler.RegisterRoutes(router.Group("/api/admin"))
+	return router, mockDispatcher
+}
+
+func TestAlertChannelHandler_ListChannels(t *testing.T) {
+	router, mockDispatcher := setupAlertChannelHandler()
+
+	mockDispatcher.On("ListChannels").Return([]interfaces.ChannelInfo{
+		{Name: "slack", Type: "slack", Severities: []string{"critical", "warning"}},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/alert-channels", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, float64(1), response["total"])
+	mockDispatcher.AssertExpectations(t)
+}
+
+func TestAlertChannelHandler_TestSend(t *testing.T) {
+	tests := []struct {
+		name           string
+		channel        string
+		result         *interfaces.ChannelTestResult
+		err            error
+		expectedStatus int
+	}{
+		{
+			name:           "successful delivery",
+			channel:        "slack",
+			result:         &interfaces.ChannelTestResult{Channel: "slack", Type: "slack", Success: true, SentAt: time.Now()},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "failed delivery",
+			channel:        "webhook",
+			result:         &interfaces.ChannelTestResult{Channel: "webhook", Type: "webhook", Success: false, Error: "timeout"},
+			expectedStatus: http.StatusBadGateway,
+		},
+		{
+			name:           "unknown channel",
+			channel:        "pager",
+			err:            fmt.Errorf("channel pager not found"),
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, mockDispatcher := setupAlertChannelHandler()
+
+			if tt.err != nil {
+				mockDispatcher.On("TestSend", mock.Anything, tt.channel).Return(nil, tt.err)
+			} else {
+				mockDispatcher.On("TestSend", mock.Anything, tt.channel).Return(tt.result, nil)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/admin/alert-channels/"+tt.channel+"/test", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockDispatcher.AssertExpectations(t)
+		})
+	}
+}
@@ -70,6 +70,14 @@ func (m *MockAlertService) ResolveAlert(ctx context.Context, alertID, resolvedBy
 	return args.Get(0).(*interfaces.Alert), args.Error(1)
 }
 
+func (m *MockAlertService) EscalateAlert(ctx context.Context, alertID, severity string, metadata map[string]interface{}) (*interfaces.Alert, error) {
+	args := m.Called(ctx, alertID, severity, metadata)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*interfaces.Alert), args.Error(1)
+}
+
 func (m *MockAlertService) GetAlertStatistics(ctx context.Context, timeRange *interfaces.TimeRange) (*interfaces.AlertStatistics, error) {
 	args := m.Called(ctx, timeRange)
 	if args.Get(0) == nil {
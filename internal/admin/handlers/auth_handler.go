@@ -1,17 +1,30 @@
 package handlers
 
 import (
+	"context"
+	"errors"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
 	"github.com/phantom-sage/bankgo/internal/admin/middleware"
+	"github.com/phantom-sage/bankgo/internal/lockout"
 )
 
 // AuthHandlerImpl implements the AuthHandler interface
 type AuthHandlerImpl struct {
 	authService interfaces.AdminAuthService
+	loginGuard  LoginGuard
+}
+
+// LoginGuard counts failed admin sign-ins and refuses attempts while the
+// username or client IP has to wait; *lockout.Guard implements it
+type LoginGuard interface {
+	Check(ctx context.Context, account, ipAddress string) error
+	RecordFailure(ctx context.Context, account, ipAddress string) error
+	RecordSuccess(ctx context.Context, account string) error
 }
 
 // NewAuthHandler creates a new authentication handler
@@ -21,6 +34,15 @@ func NewAuthHandler(authService interfaces.AdminAuthService) interfaces.AuthHand
 	}
 }
 
+// NewAuthHandlerWithLoginGuard creates an authentication handler whose
+// logins are protected against brute force by guard
+func NewAuthHandlerWithLoginGuard(authService interfaces.AdminAuthService, guard LoginGuard) interfaces.AuthHandler {
+	return &AuthHandlerImpl{
+		authService: authService,
+		loginGuard:  guard,
+	}
+}
+
 // RegisterRoutes registers authentication routes
 func (h *AuthHandlerImpl) RegisterRoutes(router gin.IRouter) {
 	auth := router.Group("/auth")
@@ -65,9 +87,30 @@ func (h *AuthHandlerImpl) Login(c *gin.Context) {
 		return
 	}
 
+	// Refuse attempts while the username or client IP is delayed or locked
+	// out, before the password is checked
+	ctx := c.Request.Context()
+	if h.loginGuard != nil {
+		if err := h.loginGuard.Check(ctx, req.Username, c.ClientIP()); err != nil {
+			var locked *lockout.LockedError
+			if errors.As(err, &locked) {
+				c.Header("Retry-After", strconv.Itoa(int(locked.RetryAfter(time.Now()).Seconds())))
+			}
+			c.JSON(http.StatusTooManyRequests, gin.H{
+				"error":   "too_many_failed_logins",
+				"message": "Too many failed login attempts, try again later",
+			})
+			return
+		}
+	}
+
 	// Authenticate user
-	session, err := h.authService.Login(c.Request.Context(), req.Username, req.Password)
+	session, err := h.authService.Login(ctx, req.Username, req.Password)
 	if err != nil {
+		if h.loginGuard != nil {
+			// The guard logs failures to count; the login fails either way
+			_ = h.loginGuard.RecordFailure(ctx, req.Username, c.ClientIP())
+		}
 		c.JSON(http.StatusUnauthorized, gin.H{
 			"error":   "authentication_failed",
 			"message": "Invalid credentials",
@@ -75,6 +118,10 @@ func (h *AuthHandlerImpl) Login(c *gin.Context) {
 		return
 	}
 
+	if h.loginGuard != nil {
+		_ = h.loginGuard.RecordSuccess(ctx, req.Username)
+	}
+
 	// Calculate expires in seconds
 	expiresIn := time.Until(session.ExpiresAt).Seconds()
 
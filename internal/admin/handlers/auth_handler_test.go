@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
+	"github.com/phantom-sage/bankgo/internal/lockout"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAdminAuthService is a mock implementation of AdminAuthService
+type MockAdminAuthService struct {
+	mock.Mock
+}
+
+func (m *MockAdminAuthService) Login(ctx context.Context, username, password string) (*interfaces.AdminSession, error) {
+	args := m.Called(ctx, username, password)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*interfaces.AdminSession), args.Error(1)
+}
+
+func (m *MockAdminAuthService) ValidateSession(ctx context.Context, token string) (*interfaces.AdminSession, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*interfaces.AdminSession), args.Error(1)
+}
+
+func (m *MockAdminAuthService) RefreshSession(ctx context.Context, token string) (*interfaces.AdminSession, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*interfaces.AdminSession), args.Error(1)
+}
+
+func (m *MockAdminAuthService) Logout(ctx context.Context, token string) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *MockAdminAuthService) UpdateCredentials(ctx context.Context, username, oldPassword, newPassword string) error {
+	args := m.Called(ctx, username, oldPassword, newPassword)
+	return args.Error(0)
+}
+
+// MockLoginGuard is a mock implementation of LoginGuard
+type MockLoginGuard struct {
+	mock.Mock
+}
+
+func (m *MockLoginGuard) Check(ctx context.Context, account, ipAddress string) error {
+	args := m.Called(ctx, account, ipAddress)
+	return args.Error(0)
+}
+
+func (m *MockLoginGuard) RecordFailure(ctx context.Context, account, ipAddress string) error {
+	args := m.Called(ctx, account, ipAddress)
+	return args.Error(0)
+}
+
+func (m *MockLoginGuard) RecordSuccess(ctx context.Context, account string) error {
+	args := m.Called(ctx, account)
+	return args.Error(0)
+}
+
+func setupAuthHandlerTest() (*MockAdminAuthService, *MockLoginGuard, *gin.Engine) {
+	gin.SetMode(gin.TestMode)
+
+	authService := &MockAdminAuthService{}
+	guard := &MockLoginGuard{}
+	handler := NewAuthHandlerWithLoginGuard(authService, guard)
+
+	router := gin.New()
+	handler.RegisterRoutes(router)
+
+	return authService, guard, router
+}
+
+func sendAdminLogin(router *gin.Engine, password string) *httptest.ResponseRecorder {
+	body := []byte(`{"username":"admin","password":"` + password + `"}`)
+	req, _ := http.NewRequest(http.MethodPost, "/auth/login", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.RemoteAddr = "10.0.0.1:1234"
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAuthHandler_LoginGuard(t *testing.T) {
+	t.Run("successful login clears failures", func(t *testing.T) {
+		authService, guard, router := setupAuthHandlerTest()
+		session := &interfaces.AdminSession{ID: "session", Username: "admin", PasetoToken: "token", ExpiresAt: time.Now().Add(time.Hour)}
+		guard.On("Check", mock.Anything, "admin", "10.0.0.1").Return(nil)
+		authService.On("Login", mock.Anything, "admin", "secret").Return(session, nil)
+		guard.On("RecordSuccess", mock.Anything, "admin").Return(nil)
+
+		w := sendAdminLogin(router, "secret")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		authService.AssertExpectations(t)
+		guard.AssertExpectations(t)
+	})
+
+	t.Run("failed login is counted", func(t *testing.T) {
+		authService, guard, router := setupAuthHandlerTest()
+		guard.On("Check", mock.Anything, "admin", "10.0.0.1").Return(nil)
+		authService.On("Login", mock.Anything, "admin", "wrong").Return(nil, errors.New("invalid credentials"))
+		guard.On("RecordFailure", mock.Anything, "admin", "10.0.0.1").Return(nil)
+
+		w := sendAdminLogin(router, "wrong")
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		guard.AssertExpectations(t)
+		guard.AssertNotCalled(t, "RecordSuccess", mock.Anything, mock.Anything)
+	})
+
+	t.Run("locked out login is refused before the password is checked", func(t *testing.T) {
+		authService, guard, router := setupAuthHandlerTest()
+		until := time.Now().Add(15 * time.Minute)
+		guard.On("Check", mock.Anything, "admin", "10.0.0.1").Return(&lockout.LockedError{Reason: lockout.ReasonAccountLocked, Until: until})
+
+		w := sendAdminLogin(router, "secret")
+
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.NotEmpty(t, w.Header().Get("Retry-After"))
+		assert.Contains(t, w.Body.String(), `"error":"too_many_failed_logins"`)
+		authService.AssertNotCalled(t, "Login", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -10,13 +10,21 @@ type Container struct {
 	services *services.Container
 
 	// Handlers
-	AuthHandler         interfaces.AuthHandler
-	UserHandler         interfaces.UserHandler
-	SystemHandler       interfaces.SystemHandler
-	DatabaseHandler     interfaces.DatabaseHandler
-	WebSocketHandler    interfaces.WebSocketHandler
-	TransactionHandler  interfaces.TransactionHandler
-	AccountHandler      interfaces.AccountHandler
+	AuthHandler          interfaces.AuthHandler
+	UserHandler          interfaces.UserHandler
+	SystemHandler        interfaces.SystemHandler
+	DatabaseHandler      interfaces.DatabaseHandler
+	WebSocketHandler     interfaces.WebSocketHandler
+	TransactionHandler   interfaces.TransactionHandler
+	AccountHandler       interfaces.AccountHandler
+	AlertHandler         interfaces.AlertHandler
+	AlertChannelHandler  interfaces.AlertChannelHandler
+	RiskReviewHandler    interfaces.RiskReviewHandler
+	TransferLimitHandler interfaces.TransferLimitHandler
+	FeeScheduleHandler   interfaces.FeeScheduleHandler
+	ImportHandler        interfaces.ImportHandler
+	SQLConsoleHandler    interfaces.SQLConsoleHandler
+	ActivityHandler      interfaces.ActivityHandler
 }
 
 // NewContainer creates a new handler container with service dependencies
@@ -35,6 +43,9 @@ func NewContainer(services *services.Container) *Container {
 func (c *Container) initHandlers() {
 	// Initialize auth handler
 	c.AuthHandler = NewAuthHandler(c.services.AuthService)
+	if c.services.LoginGuard != nil {
+		c.AuthHandler = NewAuthHandlerWithLoginGuard(c.services.AuthService, c.services.LoginGuard)
+	}
 
 	// Initialize user handler
 	c.UserHandler = NewUserHandler(c.services.UserService)
@@ -43,7 +54,7 @@ func (c *Container) initHandlers() {
 	c.SystemHandler = NewSystemHandler(c.services.SystemService)
 	
 	// Initialize WebSocket handler
-	c.WebSocketHandler = NewWebSocketHandler(c.services.NotificationService)
+	c.WebSocketHandler = NewWebSocketHandlerWithAlerts(c.services.NotificationService, c.services.AlertService)
 	
 	// Initialize database handler
 	c.DatabaseHandler = NewDatabaseHandler(c.services.DatabaseService)
@@ -53,6 +64,34 @@ func (c *Container) initHandlers() {
 	
 	// Initialize account handler
 	c.AccountHandler = NewAccountHandler(c.services.AccountService)
+
+	// Initialize alert management handler
+	c.AlertHandler = NewAlertHandler(c.services.AlertService)
+
+	// Initialize alert channel handler
+	if c.services.AlertDispatcher != nil {
+		c.AlertChannelHandler = NewAlertChannelHandler(c.services.AlertDispatcher)
+	}
+
+	// Initialize transfer risk review handler
+	c.RiskReviewHandler = NewRiskReviewHandler(c.services.RiskReviewService)
+
+	// Initialize transfer limit handler
+	c.TransferLimitHandler = NewTransferLimitHandler(c.services.TransferLimitService)
+
+	// Initialize fee schedule handler
+	c.FeeScheduleHandler = NewFeeScheduleHandler(c.services.FeeScheduleService)
+
+	// Initialize bulk import handler
+	c.ImportHandler = NewImportHandler(c.services.ImportService)
+
+	// Initialize read-only SQL console handler
+	c.SQLConsoleHandler = NewSQLConsoleHandler(c.services.SQLConsoleService)
+
+	// Initialize banking activity counter handler
+	if c.services.ActivityFeedService != nil {
+		c.ActivityHandler = NewActivityHandler(c.services.ActivityFeedService)
+	}
 }
 
 // GetServices returns the service container
@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -33,6 +35,7 @@ func (h *DatabaseHandler) RegisterRoutes(router gin.IRouter) {
 		db.PUT("/tables/:table/records/:id", h.UpdateRecord)
 		db.DELETE("/tables/:table/records/:id", h.DeleteRecord)
 		db.POST("/tables/:table/bulk", h.BulkOperation)
+		db.GET("/tables/:table/records/:id/related/:related", h.ListRelatedRecords)
 	}
 }
 
@@ -67,11 +70,7 @@ func (h *DatabaseHandler) GetTableSchema(c *gin.Context) {
 
 	schema, err := h.databaseService.GetTableSchema(c.Request.Context(), tableName)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "failed_to_get_schema",
-			"message": "Failed to retrieve table schema",
-			"details": err.Error(),
-		})
+		writeDatabaseError(c, err, "failed_to_get_schema", "Failed to retrieve table schema")
 		return
 	}
 
@@ -101,33 +100,18 @@ func (h *DatabaseHandler) ListRecords(c *gin.Context) {
 	}
 
 	// Parse filters from query parameters
-	params.Filters = make(map[string]interface{})
-	for key, values := range c.Request.URL.Query() {
-		if len(values) > 0 && !isReservedParam(key) {
-			// Try to parse as different types
-			value := values[0]
-			if value != "" {
-				// Try boolean
-				if boolVal, err := strconv.ParseBool(value); err == nil {
-					params.Filters[key] = boolVal
-				} else if intVal, err := strconv.Atoi(value); err == nil {
-					// Try integer
-					params.Filters[key] = intVal
-				} else {
-					// Default to string
-					params.Filters[key] = value
-				}
-			}
-		}
+	if err := parseRecordFilters(c, &params); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_parameters",
+			"message": "Invalid filter",
+			"details": err.Error(),
+		})
+		return
 	}
 
 	records, err := h.databaseService.ListRecords(c.Request.Context(), tableName, params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "failed_to_list_records",
-			"message": "Failed to retrieve table records",
-			"details": err.Error(),
-		})
+		writeDatabaseError(c, err, "failed_to_list_records", "Failed to retrieve table records")
 		return
 	}
 
@@ -157,22 +141,11 @@ func (h *DatabaseHandler) GetRecord(c *gin.Context) {
 
 	record, err := h.databaseService.GetRecord(c.Request.Context(), tableName, recordID)
 	if err != nil {
-		if err.Error() == "record not found" {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "record_not_found",
-				"message": "Record not found",
-			})
-			return
-		}
-		
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "failed_to_get_record",
-			"message": "Failed to retrieve record",
-			"details": err.Error(),
-		})
+		writeDatabaseError(c, err, "failed_to_get_record", "Failed to retrieve record")
 		return
 	}
 
+	setETag(c, record.Metadata.Version)
 	c.JSON(http.StatusOK, record)
 }
 
@@ -199,20 +172,7 @@ func (h *DatabaseHandler) CreateRecord(c *gin.Context) {
 
 	record, err := h.databaseService.CreateRecord(c.Request.Context(), tableName, data)
 	if err != nil {
-		if isValidationError(err) {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error":   "validation_error",
-				"message": "Data validation failed",
-				"details": err.Error(),
-			})
-			return
-		}
-		
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "failed_to_create_record",
-			"message": "Failed to create record",
-			"details": err.Error(),
-		})
+		writeDatabaseError(c, err, "failed_to_create_record", "Failed to create record")
 		return
 	}
 
@@ -240,6 +200,12 @@ func (h *DatabaseHandler) UpdateRecord(c *gin.Context) {
 		recordID = recordIDStr
 	}
 
+	expectedVersion, err := parseIfMatch(c)
+	if err != nil {
+		writeIfMatchError(c, err)
+		return
+	}
+
 	var data map[string]interface{}
 	if err := c.ShouldBindJSON(&data); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
@@ -250,33 +216,13 @@ func (h *DatabaseHandler) UpdateRecord(c *gin.Context) {
 		return
 	}
 
-	record, err := h.databaseService.UpdateRecord(c.Request.Context(), tableName, recordID, data)
+	record, err := h.databaseService.UpdateRecord(c.Request.Context(), tableName, recordID, data, expectedVersion)
 	if err != nil {
-		if err.Error() == "record not found" {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "record_not_found",
-				"message": "Record not found",
-			})
-			return
-		}
-		
-		if isValidationError(err) {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error":   "validation_error",
-				"message": "Data validation failed",
-				"details": err.Error(),
-			})
-			return
-		}
-		
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "failed_to_update_record",
-			"message": "Failed to update record",
-			"details": err.Error(),
-		})
+		writeDatabaseError(c, err, "failed_to_update_record", "Failed to update record")
 		return
 	}
 
+	setETag(c, record.Metadata.Version)
 	c.JSON(http.StatusOK, record)
 }
 
@@ -301,21 +247,15 @@ func (h *DatabaseHandler) DeleteRecord(c *gin.Context) {
 		recordID = recordIDStr
 	}
 
-	err := h.databaseService.DeleteRecord(c.Request.Context(), tableName, recordID)
+	expectedVersion, err := parseIfMatch(c)
 	if err != nil {
-		if err.Error() == "record not found" {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "record_not_found",
-				"message": "Record not found",
-			})
-			return
-		}
-		
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "failed_to_delete_record",
-			"message": "Failed to delete record",
-			"details": err.Error(),
-		})
+		writeIfMatchError(c, err)
+		return
+	}
+
+	err = h.databaseService.DeleteRecord(c.Request.Context(), tableName, recordID, expectedVersion)
+	if err != nil {
+		writeDatabaseError(c, err, "failed_to_delete_record", "Failed to delete record")
 		return
 	}
 
@@ -374,24 +314,65 @@ func (h *DatabaseHandler) BulkOperation(c *gin.Context) {
 
 	result, err := h.databaseService.BulkOperation(c.Request.Context(), tableName, operation)
 	if err != nil {
-		if isValidationError(err) {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error":   "validation_error",
-				"message": "Bulk operation validation failed",
-				"details": err.Error(),
-			})
-			return
-		}
-		
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "bulk_operation_failed",
-			"message": "Failed to execute bulk operation",
+		writeDatabaseError(c, err, "bulk_operation_failed", "Failed to execute bulk operation")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ListRelatedRecords handles GET /api/admin/database/tables/:table/records/:id/related/:related
+//
+// Lists the records of the related table linked to this record by a foreign
+// key, in either direction. The via query parameter names the foreign key
+// column when the tables are linked by more than one key.
+func (h *DatabaseHandler) ListRelatedRecords(c *gin.Context) {
+	tableName := c.Param("table")
+	recordIDStr := c.Param("id")
+	relatedTable := c.Param("related")
+
+	if tableName == "" || recordIDStr == "" || relatedTable == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_parameters",
+			"message": "Table name, record ID and related table are required",
+		})
+		return
+	}
+
+	// Try to parse ID as integer first, then as string
+	var recordID interface{}
+	if intID, err := strconv.Atoi(recordIDStr); err == nil {
+		recordID = intID
+	} else {
+		recordID = recordIDStr
+	}
+
+	var params interfaces.ListRecordsParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_parameters",
+			"message": "Invalid query parameters",
 			"details": err.Error(),
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, result)
+	if err := parseRecordFilters(c, &params); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_parameters",
+			"message": "Invalid filter",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	records, err := h.databaseService.ListRelatedRecords(c.Request.Context(), tableName, recordID, relatedTable, c.Query("via"), params)
+	if err != nil {
+		writeDatabaseError(c, err, "failed_to_list_related_records", "Failed to retrieve related records")
+		return
+	}
+
+	c.JSON(http.StatusOK, records)
 }
 
 // Helper functions
@@ -404,15 +385,125 @@ func isReservedParam(param string) bool {
 		"search":    true,
 		"sort_by":   true,
 		"sort_desc": true,
+		"via":       true,
 	}
 	return reserved[param]
 }
 
+// filterOperators are the operators accepted as "op:value" filter values
+var filterOperators = map[string]interfaces.FilterOperator{
+	"eq":      interfaces.FilterEq,
+	"ne":      interfaces.FilterNe,
+	"gt":      interfaces.FilterGt,
+	"gte":     interfaces.FilterGte,
+	"lt":      interfaces.FilterLt,
+	"lte":     interfaces.FilterLte,
+	"in":      interfaces.FilterIn,
+	"is_null": interfaces.FilterIsNull,
+}
+
+// parseRecordFilters reads column filters from the query string. A value of
+// the form "op:operand" (e.g. amount=gte:10, status=in:a,b, deleted_at=is_null:true)
+// becomes a typed condition and a column may be given several; any other
+// value is an equality filter.
+func parseRecordFilters(c *gin.Context, params *interfaces.ListRecordsParams) error {
+	params.Filters = make(map[string]interface{})
+	for key, values := range c.Request.URL.Query() {
+		if len(values) == 0 || isReservedParam(key) {
+			continue
+		}
+
+		for i, value := range values {
+			if value == "" {
+				continue
+			}
+
+			if op, operand, ok := strings.Cut(value, ":"); ok {
+				if operator, known := filterOperators[op]; known {
+					if operand == "" && operator != interfaces.FilterIsNull {
+						return fmt.Errorf("filter %s=%s has no value", key, value)
+					}
+					params.Conditions = append(params.Conditions, interfaces.RecordFilter{
+						Column:   key,
+						Operator: operator,
+						Value:    operand,
+					})
+					continue
+				}
+			}
+
+			// Plain values keep the first-value equality behaviour
+			if i > 0 {
+				continue
+			}
+			// Try to parse as different types
+			if boolVal, err := strconv.ParseBool(value); err == nil {
+				params.Filters[key] = boolVal
+			} else if intVal, err := strconv.Atoi(value); err == nil {
+				// Try integer
+				params.Filters[key] = intVal
+			} else {
+				// Default to string
+				params.Filters[key] = value
+			}
+		}
+	}
+
+	return nil
+}
+
+// writeDatabaseError maps data browser errors to responses, falling back to a
+// 500 with the given code and message
+func writeDatabaseError(c *gin.Context, err error, code, message string) {
+	if writeVersionConflict(c, err) {
+		return
+	}
+
+	errMsg := err.Error()
+	switch {
+	case errors.Is(err, interfaces.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "record_not_found",
+			"message": "Record not found",
+		})
+	case strings.HasPrefix(errMsg, "invalid table name"):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "table_not_found",
+			"message": "Table not found or not browsable",
+			"details": errMsg,
+		})
+	case strings.Contains(errMsg, "is read-only"):
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "read_only",
+			"message": "The table or column is read-only",
+			"details": errMsg,
+		})
+	case isValidationError(err):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "validation_error",
+			"message": "Data validation failed",
+			"details": errMsg,
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   code,
+			"message": message,
+			"details": errMsg,
+		})
+	}
+}
+
 // isValidationError checks if an error is a validation error
 func isValidationError(err error) bool {
 	errMsg := err.Error()
 	return strings.Contains(errMsg, "validation failed") ||
 		strings.Contains(errMsg, "invalid column") ||
 		strings.Contains(errMsg, "cannot be null") ||
-		strings.Contains(errMsg, "must be")
+		strings.Contains(errMsg, "must be") ||
+		strings.Contains(errMsg, "invalid filter") ||
+		strings.Contains(errMsg, "invalid sort column") ||
+		strings.Contains(errMsg, "invalid record id") ||
+		strings.Contains(errMsg, "invalid relation") ||
+		strings.Contains(errMsg, "composite primary key") ||
+		strings.Contains(errMsg, "has no primary key")
 }
\ No newline at end of file
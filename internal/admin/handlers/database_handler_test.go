@@ -58,19 +58,27 @@ func (m *MockDatabaseService) CreateRecord(ctx context.Context, tableName string
 	return args.Get(0).(*interfaces.TableRecord), args.Error(1)
 }
 
-func (m *MockDatabaseService) UpdateRecord(ctx context.Context, tableName string, recordID interface{}, data map[string]interface{}) (*interfaces.TableRecord, error) {
-	args := m.Called(ctx, tableName, recordID, data)
+func (m *MockDatabaseService) UpdateRecord(ctx context.Context, tableName string, recordID interface{}, data map[string]interface{}, expectedVersion *int) (*interfaces.TableRecord, error) {
+	args := m.Called(ctx, tableName, recordID, data, expectedVersion)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*interfaces.TableRecord), args.Error(1)
 }
 
-func (m *MockDatabaseService) DeleteRecord(ctx context.Context, tableName string, recordID interface{}) error {
-	args := m.Called(ctx, tableName, recordID)
+func (m *MockDatabaseService) DeleteRecord(ctx context.Context, tableName string, recordID interface{}, expectedVersion *int) error {
+	args := m.Called(ctx, tableName, recordID, expectedVersion)
 	return args.Error(0)
 }
 
+func (m *MockDatabaseService) ListRelatedRecords(ctx context.Context, tableName string, recordID interface{}, relatedTable string, via string, params interfaces.ListRecordsParams) (*interfaces.PaginatedRecords, error) {
+	args := m.Called(ctx, tableName, recordID, relatedTable, via, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*interfaces.PaginatedRecords), args.Error(1)
+}
+
 func (m *MockDatabaseService) BulkOperation(ctx context.Context, tableName string, operation interfaces.BulkOperation) (*interfaces.BulkOperationResult, error) {
 	args := m.Called(ctx, tableName, operation)
 	if args.Get(0) == nil {
@@ -313,7 +321,7 @@ func TestDatabaseHandler_GetRecord(t *testing.T) {
 			tableName:      "users",
 			recordID:       "999",
 			mockRecord:     nil,
-			mockError:      fmt.Errorf("record not found"),
+			mockError:      interfaces.ErrRecordNotFound,
 			expectedStatus: http.StatusNotFound,
 		},
 	}
@@ -462,7 +470,7 @@ func TestDatabaseHandler_UpdateRecord(t *testing.T) {
 			recordID:    "999",
 			requestData: map[string]interface{}{"first_name": "Updated"},
 			mockRecord:  nil,
-			mockError:   fmt.Errorf("record not found"),
+			mockError:   interfaces.ErrRecordNotFound,
 			expectedStatus: http.StatusNotFound,
 		},
 	}
@@ -479,7 +487,8 @@ func TestDatabaseHandler_UpdateRecord(t *testing.T) {
 				recordID = intID
 			}
 			
-			mockService.On("UpdateRecord", mock.Anything, tt.tableName, recordID, tt.requestData).Return(tt.mockRecord, tt.mockError)
+			version := 3
+			mockService.On("UpdateRecord", mock.Anything, tt.tableName, recordID, tt.requestData, &version).Return(tt.mockRecord, tt.mockError)
 
 			handler := NewDatabaseHandler(mockService)
 			router := setupTestRouter()
@@ -489,6 +498,7 @@ func TestDatabaseHandler_UpdateRecord(t *testing.T) {
 			url := fmt.Sprintf("/api/admin/database/tables/%s/records/%s", tt.tableName, tt.recordID)
 			req, _ := http.NewRequest("PUT", url, bytes.NewBuffer(jsonData))
 			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("If-Match", `"3"`)
 			w := httptest.NewRecorder()
 			router.ServeHTTP(w, req)
 
@@ -525,7 +535,7 @@ func TestDatabaseHandler_DeleteRecord(t *testing.T) {
 			name:           "record not found",
 			tableName:      "users",
 			recordID:       "999",
-			mockError:      fmt.Errorf("record not found"),
+			mockError:      interfaces.ErrRecordNotFound,
 			expectedStatus: http.StatusNotFound,
 		},
 	}
@@ -542,7 +552,8 @@ func TestDatabaseHandler_DeleteRecord(t *testing.T) {
 				recordID = intID
 			}
 			
-			mockService.On("DeleteRecord", mock.Anything, tt.tableName, recordID).Return(tt.mockError)
+			version := 3
+			mockService.On("DeleteRecord", mock.Anything, tt.tableName, recordID, &version).Return(tt.mockError)
 
 			handler := NewDatabaseHandler(mockService)
 			router := setupTestRouter()
@@ -550,6 +561,7 @@ func TestDatabaseHandler_DeleteRecord(t *testing.T) {
 
 			url := fmt.Sprintf("/api/admin/database/tables/%s/records/%s", tt.tableName, tt.recordID)
 			req, _ := http.NewRequest("DELETE", url, nil)
+			req.Header.Set("If-Match", `"3"`)
 			w := httptest.NewRecorder()
 			router.ServeHTTP(w, req)
 
@@ -745,4 +757,186 @@ func TestDatabaseHandler_ErrorClassification(t *testing.T) {
 			assert.Equal(t, tt.isValidation, result)
 		})
 	}
-}
\ No newline at end of file
+}
+func TestDatabaseHandler_ListRecordsTypedFilters(t *testing.T) {
+	mockService := new(MockDatabaseService)
+	mockService.On("ListRecords", mock.Anything, "transfers", mock.MatchedBy(func(params interfaces.ListRecordsParams) bool {
+		conditions := map[string]interfaces.RecordFilter{}
+		for _, condition := range params.Conditions {
+			conditions[string(condition.Operator)] = condition
+		}
+		return len(params.Conditions) == 4 &&
+			conditions["gte"].Column == "amount" && conditions["gte"].Value == "10" &&
+			conditions["lte"].Column == "amount" && conditions["lte"].Value == "100" &&
+			conditions["in"].Column == "status" && conditions["in"].Value == "completed,failed" &&
+			conditions["is_null"].Column == "description" &&
+			params.Filters["currency"] == "USD"
+	})).Return(&interfaces.PaginatedRecords{Records: []interfaces.TableRecord{}}, nil)
+
+	handler := NewDatabaseHandler(mockService)
+	router := setupTestRouter()
+	handler.RegisterRoutes(router.Group("/api/admin"))
+
+	url := "/api/admin/database/tables/transfers/records?amount=gte:10&amount=lte:100&status=in:completed,failed&description=is_null:&currency=USD"
+	req, _ := http.NewRequest("GET", url, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+
+	// An operator without an operand is rejected before reaching the service
+	req, _ = http.NewRequest("GET", "/api/admin/database/tables/transfers/records?amount=gte:", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestDatabaseHandler_ListRelatedRecords(t *testing.T) {
+	mockService := new(MockDatabaseService)
+	mockService.On("ListRelatedRecords", mock.Anything, "accounts", 5, "transfers", "to_account_id", mock.MatchedBy(func(params interfaces.ListRecordsParams) bool {
+		return params.Page == 2 && len(params.Conditions) == 1
+	})).Return(&interfaces.PaginatedRecords{
+		Records: []interfaces.TableRecord{{TableName: "transfers", Data: map[string]interface{}{"id": 1}}},
+	}, nil)
+	mockService.On("ListRelatedRecords", mock.Anything, "accounts", 5, "transfers", "", mock.Anything).
+		Return(nil, fmt.Errorf("invalid relation: accounts is linked to transfers by more than one foreign key, set via to one of from_account_id, to_account_id"))
+
+	handler := NewDatabaseHandler(mockService)
+	router := setupTestRouter()
+	handler.RegisterRoutes(router.Group("/api/admin"))
+
+	req, _ := http.NewRequest("GET", "/api/admin/database/tables/accounts/records/5/related/transfers?via=to_account_id&page=2&amount=gt:50", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var result interfaces.PaginatedRecords
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	assert.Len(t, result.Records, 1)
+
+	req, _ = http.NewRequest("GET", "/api/admin/database/tables/accounts/records/5/related/transfers", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestDatabaseHandler_PermissionErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedCode   string
+	}{
+		{"read-only table", fmt.Errorf("table transfers is read-only"), http.StatusForbidden, "read_only"},
+		{"read-only column", fmt.Errorf("validation failed: column balance is read-only"), http.StatusForbidden, "read_only"},
+		{"table outside the allowlist", fmt.Errorf("invalid table name: pg_authid"), http.StatusNotFound, "table_not_found"},
+		{"masked filter", fmt.Errorf("invalid filter: column tax_id is masked"), http.StatusBadRequest, "validation_error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockDatabaseService)
+			version := 3
+			mockService.On("UpdateRecord", mock.Anything, "accounts", 1, mock.Anything, &version).Return(nil, tt.err)
+
+			handler := NewDatabaseHandler(mockService)
+			router := setupTestRouter()
+			handler.RegisterRoutes(router.Group("/api/admin"))
+
+			req, _ := http.NewRequest("PUT", "/api/admin/database/tables/accounts/records/1", bytes.NewBufferString(`{"balance": 10}`))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("If-Match", `"3"`)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			var response map[string]interface{}
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedCode, response["error"])
+		})
+	}
+}
+
+func TestDatabaseHandler_OptimisticConcurrency(t *testing.T) {
+	version := 7
+	record := &interfaces.TableRecord{
+		Data:     map[string]interface{}{"id": 1, "currency": "USD"},
+		Metadata: interfaces.RecordMetadata{Version: &version},
+	}
+
+	t.Run("get returns the version as an ETag", func(t *testing.T) {
+		mockService := new(MockDatabaseService)
+		mockService.On("GetRecord", mock.Anything, "accounts", 1).Return(record, nil)
+
+		handler := NewDatabaseHandler(mockService)
+		router := setupTestRouter()
+		handler.RegisterRoutes(router.Group("/api/admin"))
+
+		req, _ := http.NewRequest("GET", "/api/admin/database/tables/accounts/records/1", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `"7"`, w.Header().Get("ETag"))
+	})
+
+	t.Run("stale update returns 409 with the current record", func(t *testing.T) {
+		stale := 6
+		mockService := new(MockDatabaseService)
+		mockService.On("UpdateRecord", mock.Anything, "accounts", 1, mock.Anything, &stale).
+			Return(nil, &interfaces.VersionConflictError{Current: record, Version: version})
+
+		handler := NewDatabaseHandler(mockService)
+		router := setupTestRouter()
+		handler.RegisterRoutes(router.Group("/api/admin"))
+
+		req, _ := http.NewRequest("PUT", "/api/admin/database/tables/accounts/records/1", bytes.NewBufferString(`{"currency": "EUR"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", `W/"6"`)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Equal(t, `"7"`, w.Header().Get("ETag"))
+		var response map[string]interface{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "version_conflict", response["error"])
+		assert.NotNil(t, response["current"])
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("writes without a concrete If-Match return 428", func(t *testing.T) {
+		mockService := new(MockDatabaseService)
+
+		handler := NewDatabaseHandler(mockService)
+		router := setupTestRouter()
+		handler.RegisterRoutes(router.Group("/api/admin"))
+
+		for _, ifMatch := range []string{"", "*"} {
+			req, _ := http.NewRequest("DELETE", "/api/admin/database/tables/accounts/records/1", nil)
+			if ifMatch != "" {
+				req.Header.Set("If-Match", ifMatch)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusPreconditionRequired, w.Code, "If-Match %q", ifMatch)
+
+			req, _ = http.NewRequest("PUT", "/api/admin/database/tables/accounts/records/1", bytes.NewBufferString(`{"currency": "EUR"}`))
+			req.Header.Set("Content-Type", "application/json")
+			if ifMatch != "" {
+				req.Header.Set("If-Match", ifMatch)
+			}
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusPreconditionRequired, w.Code, "If-Match %q", ifMatch)
+		}
+
+		mockService.AssertNotCalled(t, "DeleteRecord", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockService.AssertNotCalled(t, "UpdateRecord", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
+)
+
+// setETag sets the ETag header for a versioned resource
+func setETag(c *gin.Context, version *int) {
+	if version != nil {
+		c.Header("ETag", strconv.Quote(strconv.Itoa(*version)))
+	}
+}
+
+// errIfMatchRequired is returned by parseIfMatch when a write does not name
+// the version it was based on
+var errIfMatchRequired = errors.New("If-Match header with the ETag of a previous read is required")
+
+// parseIfMatch returns the version named by the If-Match header. Writes must
+// name a concrete version, so an absent header or "*" is rejected with
+// errIfMatchRequired rather than allowing a blind overwrite.
+func parseIfMatch(c *gin.Context) (*int, error) {
+	value := strings.TrimSpace(c.GetHeader("If-Match"))
+	if value == "" || value == "*" {
+		return nil, errIfMatchRequired
+	}
+
+	// Versions are compared exactly, so weak tags are accepted as-is
+	value = strings.TrimPrefix(value, "W/")
+	unquoted, err := strconv.Unquote(value)
+	if err != nil {
+		unquoted = value
+	}
+
+	version, err := strconv.Atoi(unquoted)
+	if err != nil || version < 0 {
+		return nil, fmt.Errorf("If-Match must be a single ETag returned by a previous read")
+	}
+
+	return &version, nil
+}
+
+// writeIfMatchError rejects a missing (428) or malformed (400) If-Match header
+func writeIfMatchError(c *gin.Context, err error) {
+	if errors.Is(err, errIfMatchRequired) {
+		c.JSON(http.StatusPreconditionRequired, gin.H{
+			"error":   "precondition_required",
+			"message": "Read the record first and send its ETag in If-Match",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusBadRequest, gin.H{
+		"error":   "invalid_if_match",
+		"message": "Invalid If-Match header",
+		"details": err.Error(),
+	})
+}
+
+// writeVersionConflict responds 409 with the current resource when err is a
+// version conflict, reporting whether it did
+func writeVersionConflict(c *gin.Context, err error) bool {
+	var conflict *interfaces.VersionConflictError
+	if !errors.As(err, &conflict) {
+		return false
+	}
+
+	version := conflict.Version
+	setETag(c, &version)
+	c.JSON(http.StatusConflict, gin.H{
+		"error":   "version_conflict",
+		"message": "The record was modified by someone else; review the current version and retry",
+		"current": conflict.Current,
+	})
+	return true
+}
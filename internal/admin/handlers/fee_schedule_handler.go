@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
+)
+
+// FeeScheduleHandlerImpl implements transfer fee schedule management endpoints
+type FeeScheduleHandlerImpl struct {
+	feeScheduleService interfaces.FeeScheduleService
+}
+
+// NewFeeScheduleHandler creates a new fee schedule handler
+func NewFeeScheduleHandler(feeScheduleService interfaces.FeeScheduleService) interfaces.FeeScheduleHandler {
+	return &FeeScheduleHandlerImpl{
+		feeScheduleService: feeScheduleService,
+	}
+}
+
+// RegisterRoutes registers HTTP routes for fee schedules
+func (h *FeeScheduleHandlerImpl) RegisterRoutes(router gin.IRouter) {
+	feeGroup := router.Group("/fee-schedules")
+	{
+		feeGroup.GET("", h.ListFeeSchedules)
+		feeGroup.POST("", h.CreateFeeSchedule)
+		feeGroup.GET("/preview", h.PreviewFee)
+		feeGroup.GET("/:id", h.GetFeeSchedule)
+		feeGroup.PUT("/:id", h.UpdateFeeSchedule)
+		feeGroup.DELETE("/:id", h.DeleteFeeSchedule)
+	}
+}
+
+// ListFeeSchedules handles GET /api/admin/fee-schedules
+func (h *FeeScheduleHandlerImpl) ListFeeSchedules(c *gin.Context) {
+	schedules, err := h.feeScheduleService.ListFeeSchedules(c.Request.Context())
+	if err != nil {
+		h.handleError(c, err, "failed_to_list_fee_schedules", "Failed to list fee schedules")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"fee_schedules": schedules,
+		"total":         len(schedules),
+	})
+}
+
+// GetFeeSchedule handles GET /api/admin/fee-schedules/:id
+func (h *FeeScheduleHandlerImpl) GetFeeSchedule(c *gin.Context) {
+	schedule, err := h.feeScheduleService.GetFeeSchedule(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.handleError(c, err, "failed_to_get_fee_schedule", "Failed to get fee schedule")
+		return
+	}
+
+	c.JSON(http.StatusOK, schedule)
+}
+
+// CreateFeeSchedule handles POST /api/admin/fee-schedules
+func (h *FeeScheduleHandlerImpl) CreateFeeSchedule(c *gin.Context) {
+	var req interfaces.FeeScheduleRequest
+	if !h.bindRequest(c, &req) {
+		return
+	}
+
+	schedule, err := h.feeScheduleService.CreateFeeSchedule(c.Request.Context(), req, adminUsernameFromContext(c))
+	if err != nil {
+		h.handleError(c, err, "failed_to_create_fee_schedule", "Failed to create fee schedule")
+		return
+	}
+
+	c.JSON(http.StatusCreated, schedule)
+}
+
+// UpdateFeeSchedule handles PUT /api/admin/fee-schedules/:id
+func (h *FeeScheduleHandlerImpl) UpdateFeeSchedule(c *gin.Context) {
+	var req interfaces.FeeScheduleRequest
+	if !h.bindRequest(c, &req) {
+		return
+	}
+
+	schedule, err := h.feeScheduleService.UpdateFeeSchedule(c.Request.Context(), c.Param("id"), req, adminUsernameFromContext(c))
+	if err != nil {
+		h.handleError(c, err, "failed_to_update_fee_schedule", "Failed to update fee schedule")
+		return
+	}
+
+	c.JSON(http.StatusOK, schedule)
+}
+
+// DeleteFeeSchedule handles DELETE /api/admin/fee-schedules/:id
+func (h *FeeScheduleHandlerImpl) DeleteFeeSchedule(c *gin.Context) {
+	if err := h.feeScheduleService.DeleteFeeSchedule(c.Request.Context(), c.Param("id")); err != nil {
+		h.handleError(c, err, "failed_to_delete_fee_schedule", "Failed to delete fee schedule")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Fee schedule deleted",
+	})
+}
+
+// PreviewFee handles GET /api/admin/fee-schedules/preview?currency=USD&amount=100
+func (h *FeeScheduleHandlerImpl) PreviewFee(c *gin.Context) {
+	currency := c.Query("currency")
+	amount := c.Query("amount")
+	if currency == "" || amount == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "currency and amount query parameters are required",
+		})
+		return
+	}
+
+	preview, err := h.feeScheduleService.PreviewFee(c.Request.Context(), currency, amount)
+	if err != nil {
+		h.handleError(c, err, "failed_to_preview_fee", "Failed to preview fee")
+		return
+	}
+
+	c.JSON(http.StatusOK, preview)
+}
+
+// bindRequest binds a fee schedule body, writing a 400 on invalid JSON
+func (h *FeeScheduleHandlerImpl) bindRequest(c *gin.Context, req *interfaces.FeeScheduleRequest) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "Invalid request data",
+			"details": err.Error(),
+		})
+		return false
+	}
+	return true
+}
+
+// handleError maps fee schedule service errors to HTTP responses
+func (h *FeeScheduleHandlerImpl) handleError(c *gin.Context, err error, code, message string) {
+	switch {
+	case strings.Contains(err.Error(), "invalid fee schedule ID"):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_fee_schedule_id",
+			"message": "Fee schedule ID must be a number",
+			"details": err.Error(),
+		})
+	case strings.Contains(err.Error(), "invalid fee schedule"):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_fee_schedule",
+			"message": err.Error(),
+		})
+	case strings.Contains(err.Error(), "fee schedule not found"):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "fee_schedule_not_found",
+			"message": "Fee schedule not found",
+		})
+	case strings.Contains(err.Error(), "fee schedule conflict"):
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "fee_schedule_conflict",
+			"message": err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   code,
+			"message": message,
+			"details": err.Error(),
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockFeeScheduleService is a mock implementation of FeeScheduleService
+type MockFeeScheduleService struct {
+	mock.Mock
+}
+
+func (m *MockFeeScheduleService) ListFeeSchedules(ctx context.Context) ([]interfaces.FeeSchedule, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]interfaces.FeeSchedule), args.Error(1)
+}
+
+func (m *MockFeeScheduleService) GetFeeSchedule(ctx context.Context, scheduleID string) (*interfaces.FeeSchedule, error) {
+	args := m.Called(ctx, scheduleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*interfaces.FeeSchedule), args.Error(1)
+}
+
+func (m *MockFeeScheduleService) CreateFeeSchedule(ctx context.Context, req interfaces.FeeScheduleRequest, updatedBy string) (*interfaces.FeeSchedule, error) {
+	args := m.Called(ctx, req, updatedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*interfaces.FeeSchedule), args.Error(1)
+}
+
+func (m *MockFeeScheduleService) UpdateFeeSchedule(ctx context.Context, scheduleID string, req interfaces.FeeScheduleRequest, updatedBy string) (*interfaces.FeeSchedule, error) {
+	args := m.Called(ctx, scheduleID, req, updatedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*interfaces.FeeSchedule), args.Error(1)
+}
+
+func (m *MockFeeScheduleService) DeleteFeeSchedule(ctx context.Context, scheduleID string) error {
+	args := m.Called(ctx, scheduleID)
+	return args.Error(0)
+}
+
+func (m *MockFeeScheduleService) PreviewFee(ctx context.Context, currency, amount string) (*interfaces.FeePreview, error) {
+	args := m.Called(ctx, currency, amount)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*interfaces.FeePreview), args.Error(1)
+}
+
+func setupFeeScheduleHandler() (*gin.Engine, *MockFeeScheduleService) {
+	gin.SetMode(gin.TestMode)
+	mockService := &MockFeeScheduleService{}
+	handler := NewFeeScheduleHandler(mockService)
+
+	router := gin.New()
+	group := router.Group("/api/admin")
+	group.Use(func(c *gin.Context) {
+		c.Set("admin_session", &interfaces.AdminSession{Username: "ops"})
+		c.Next()
+	})
+	handler.RegisterRoutes(group)
+	return router, mockService
+}
+
+func TestFeeScheduleHandler_CreateFeeSchedule(t *testing.T) {
+	router, mockService := setupFeeScheduleHandler()
+
+	expectedReq := interfaces.FeeScheduleRequest{
+		Name:           "Standard USD",
+		Currency:       "USD",
+		FeeType:        "percentage",
+		Percentage:     "1.5",
+		HouseAccountID: "1",
+	}
+	mockService.On("CreateFeeSchedule", mock.Anything, expectedReq, "ops").
+		Return(&interfaces.FeeSchedule{ID: "3", Name: "Standard USD", Currency: "USD", FeeType: "percentage"}, nil)
+
+	body := bytes.NewBufferString(`{"name":"Standard USD","currency":"USD","fee_type":"percentage","percentage":"1.5","house_account_id":"1"}`)
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/fee-schedules", body)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestFeeScheduleHandler_CreateFeeScheduleErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+	}{
+		{"invalid schedule", fmt.Errorf("invalid fee schedule: fee percentage must be between 0 and 100"), http.StatusBadRequest, "invalid_fee_schedule"},
+		{"active conflict", fmt.Errorf("fee schedule conflict: schedule 2 is already active for USD"), http.StatusConflict, "fee_schedule_conflict"},
+		{"database failure", fmt.Errorf("connection refused"), http.StatusInternalServerError, "failed_to_create_fee_schedule"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, mockService := setupFeeScheduleHandler()
+			mockService.On("CreateFeeSchedule", mock.Anything, mock.Anything, "ops").Return(nil, tt.err)
+
+			body := bytes.NewBufferString(`{"name":"USD","currency":"USD","fee_type":"flat","house_account_id":"1"}`)
+			req := httptest.NewRequest(http.MethodPost, "/api/admin/fee-schedules", body)
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+			assert.Contains(t, w.Body.String(), tt.code)
+		})
+	}
+}
+
+func TestFeeScheduleHandler_GetFeeScheduleNotFound(t *testing.T) {
+	router, mockService := setupFeeScheduleHandler()
+
+	mockService.On("GetFeeSchedule", mock.Anything, "42").Return(nil, fmt.Errorf("fee schedule not found"))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/fee-schedules/42", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestFeeScheduleHandler_PreviewFee(t *testing.T) {
+	router, mockService := setupFeeScheduleHandler()
+
+	mockService.On("PreviewFee", mock.Anything, "USD", "250").
+		Return(&interfaces.FeePreview{Currency: "USD", Amount: "250.00", Fee: "3.75", TotalDebit: "253.75"}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/fee-schedules/preview?currency=USD&amount=250", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var preview interfaces.FeePreview
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &preview))
+	assert.Equal(t, "3.75", preview.Fee)
+	assert.Equal(t, "253.75", preview.TotalDebit)
+	mockService.AssertExpectations(t)
+}
+
+func TestFeeScheduleHandler_PreviewFeeMissingParams(t *testing.T) {
+	router, _ := setupFeeScheduleHandler()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/fee-schedules/preview?currency=USD", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
+)
+
+// maxImportUploadSize caps the size of an uploaded import file
+const maxImportUploadSize = 10 << 20
+
+// ImportHandlerImpl implements the CSV bulk import endpoints
+type ImportHandlerImpl struct {
+	importService interfaces.ImportService
+}
+
+// NewImportHandler creates a new import handler
+func NewImportHandler(importService interfaces.ImportService) interfaces.ImportHandler {
+	return &ImportHandlerImpl{
+		importService: importService,
+	}
+}
+
+// RegisterRoutes registers HTTP routes for bulk imports
+func (h *ImportHandlerImpl) RegisterRoutes(router gin.IRouter) {
+	importGroup := router.Group("/imports")
+	{
+		importGroup.GET("", h.ListImportJobs)
+		importGroup.POST("", h.ValidateImport)
+		importGroup.GET("/:id", h.GetImportJob)
+		importGroup.POST("/:id/execute", h.ExecuteImport)
+		importGroup.GET("/:id/errors.csv", h.DownloadErrorReport)
+	}
+}
+
+// ValidateImport handles POST /api/admin/imports. It takes a multipart form with
+// the CSV in "file" and the import type in "type", and returns the dry-run report.
+// Nothing is booked until the job is executed.
+func (h *ImportHandlerImpl) ValidateImport(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportUploadSize)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": fmt.Sprintf("A CSV file of at most %d MB is required in the file field", maxImportUploadSize>>20),
+			"details": err.Error(),
+		})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "Failed to read uploaded file",
+			"details": err.Error(),
+		})
+		return
+	}
+	defer file.Close()
+
+	job, err := h.importService.ValidateImport(c.Request.Context(), c.PostForm("type"), fileHeader.Filename, file, adminUsernameFromContext(c))
+	if err != nil {
+		h.handleError(c, err, "failed_to_validate_import", "Failed to validate import")
+		return
+	}
+
+	c.JSON(http.StatusCreated, job)
+}
+
+// ExecuteImport handles POST /api/admin/imports/:id/execute
+func (h *ImportHandlerImpl) ExecuteImport(c *gin.Context) {
+	var req interfaces.ExecuteImportRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "invalid_request",
+				"message": "Invalid request body",
+				"details": err.Error(),
+			})
+			return
+		}
+	}
+
+	job, err := h.importService.ExecuteImport(c.Request.Context(), c.Param("id"), req, adminUsernameFromContext(c))
+	if err != nil {
+		h.handleError(c, err, "failed_to_execute_import", "Failed to execute import")
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+// GetImportJob handles GET /api/admin/imports/:id
+func (h *ImportHandlerImpl) GetImportJob(c *gin.Context) {
+	job, err := h.importService.GetImportJob(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.handleError(c, err, "failed_to_get_import", "Failed to get import job")
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+// ListImportJobs handles GET /api/admin/imports
+func (h *ImportHandlerImpl) ListImportJobs(c *gin.Context) {
+	var params interfaces.PaginationParams
+	if page, err := strconv.Atoi(c.DefaultQuery("page", "1")); err == nil {
+		params.Page = page
+	}
+	if pageSize, err := strconv.Atoi(c.DefaultQuery("page_size", "20")); err == nil {
+		params.PageSize = pageSize
+	}
+
+	result, err := h.importService.ListImportJobs(c.Request.Context(), params)
+	if err != nil {
+		h.handleError(c, err, "failed_to_list_imports", "Failed to list import jobs")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// DownloadErrorReport handles GET /api/admin/imports/:id/errors.csv
+func (h *ImportHandlerImpl) DownloadErrorReport(c *gin.Context) {
+	// Build the report before writing headers so failures still get a JSON error
+	var report bytes.Buffer
+	if err := h.importService.WriteErrorReport(c.Request.Context(), c.Param("id"), &report); err != nil {
+		h.handleError(c, err, "failed_to_get_error_report", "Failed to build import error report")
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="import-%s-errors.csv"`, c.Param("id")))
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", report.Bytes())
+}
+
+// handleError maps import service errors to HTTP responses
+func (h *ImportHandlerImpl) handleError(c *gin.Context, err error, code, message string) {
+	switch {
+	case strings.Contains(err.Error(), "invalid import job ID"):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_import_id",
+			"message": "Import job ID must be a number",
+			"details": err.Error(),
+		})
+	case strings.Contains(err.Error(), "invalid import type"), strings.Contains(err.Error(), "invalid import file"):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_import_file",
+			"message": err.Error(),
+		})
+	case strings.Contains(err.Error(), "import job not found"):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "import_not_found",
+			"message": "Import job not found",
+		})
+	case strings.Contains(err.Error(), "already executed"), strings.Contains(err.Error(), "valid rows"):
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "import_conflict",
+			"message": err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   code,
+			"message": message,
+			"details": err.Error(),
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockImportService is a mock implementation of ImportService
+type MockImportService struct {
+	mock.Mock
+}
+
+func (m *MockImportService) ValidateImport(ctx context.Context, importType, fileName string, file io.Reader, createdBy string) (*interfaces.ImportJob, error) {
+	content, _ := io.ReadAll(file)
+	args := m.Called(ctx, importType, fileName, string(content), createdBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*interfaces.ImportJob), args.Error(1)
+}
+
+func (m *MockImportService) ExecuteImport(ctx context.Context, jobID string, req interfaces.ExecuteImportRequest, executedBy string) (*interfaces.ImportJob, error) {
+	args := m.Called(ctx, jobID, req, executedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*interfaces.ImportJob), args.Error(1)
+}
+
+func (m *MockImportService) GetImportJob(ctx context.Context, jobID string) (*interfaces.ImportJob, error) {
+	args := m.Called(ctx, jobID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*interfaces.ImportJob), args.Error(1)
+}
+
+func (m *MockImportService) ListImportJobs(ctx context.Context, params interfaces.PaginationParams) (*interfaces.PaginatedImportJobs, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*interfaces.PaginatedImportJobs), args.Error(1)
+}
+
+func (m *MockImportService) WriteErrorReport(ctx context.Context, jobID string, w io.Writer) error {
+	args := m.Called(ctx, jobID)
+	if report := args.String(0); report != "" {
+		_, _ = io.WriteString(w, report)
+	}
+	return args.Error(1)
+}
+
+func setupImportHandler() (*gin.Engine, *MockImportService) {
+	gin.SetMode(gin.TestMode)
+	mockService := &MockImportService{}
+	handler := NewImportHandler(mockService)
+
+	router := gin.New()
+	group := router.Group("/api/admin")
+	group.Use(func(c *gin.Context) {
+		c.Set("admin_session", &interfaces.AdminSession{Username: "ops"})
+		c.Next()
+	})
+	handler.RegisterRoutes(group)
+	return router, mockService
+}
+
+func newImportUpload(t *testing.T, importType, content string) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	require.NoError(t, writer.WriteField("type", importType))
+	part, err := writer.CreateFormFile("file", "corrections.csv")
+	require.NoError(t, err)
+	_, err = io.WriteString(part, content)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/imports", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestImportHandler_ValidateImport(t *testing.T) {
+	router, mockService := setupImportHandler()
+
+	content := "account_id,amount,reason\n3,-10,Chargeback\n"
+	mockService.On("ValidateImport", mock.Anything, "balance_adjustments", "corrections.csv", content, "ops").
+		Return(&interfaces.ImportJob{ID: "5", Status: "validated", TotalRows: 1, ValidRows: 1}, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newImportUpload(t, "balance_adjustments", content))
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"validated"`)
+	mockService.AssertExpectations(t)
+}
+
+func TestImportHandler_ValidateImportErrors(t *testing.T) {
+	router, mockService := setupImportHandler()
+
+	mockService.On("ValidateImport", mock.Anything, "refunds", "corrections.csv", mock.Anything, "ops").
+		Return(nil, fmt.Errorf("invalid import type: must be transfers or balance_adjustments"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newImportUpload(t, "refunds", "id\n1\n"))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid_import_file")
+
+	// A request without a file never reaches the service
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/imports", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestImportHandler_ExecuteImport(t *testing.T) {
+	router, mockService := setupImportHandler()
+
+	mockService.On("ExecuteImport", mock.Anything, "5", interfaces.ExecuteImportRequest{AllowPartial: true}, "ops").
+		Return(&interfaces.ImportJob{ID: "5", Status: "completed_with_errors", SucceededRows: 2, InvalidRows: 1}, nil)
+	mockService.On("ExecuteImport", mock.Anything, "6", interfaces.ExecuteImportRequest{}, "ops").
+		Return(nil, fmt.Errorf("import job has 1 invalid rows; set allow_partial to execute the valid rows only"))
+	mockService.On("ExecuteImport", mock.Anything, "7", interfaces.ExecuteImportRequest{}, "ops").
+		Return(nil, fmt.Errorf("import job already executed"))
+	mockService.On("ExecuteImport", mock.Anything, "8", interfaces.ExecuteImportRequest{}, "ops").
+		Return(nil, fmt.Errorf("import job not found"))
+
+	tests := []struct {
+		path   string
+		body   string
+		status int
+	}{
+		{"/api/admin/imports/5/execute", `{"allow_partial":true}`, http.StatusOK},
+		{"/api/admin/imports/6/execute", "", http.StatusConflict},
+		{"/api/admin/imports/7/execute", "", http.StatusConflict},
+		{"/api/admin/imports/8/execute", "", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, tt.path, bytes.NewBufferString(tt.body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, tt.status, w.Code, tt.path)
+	}
+
+	mockService.AssertExpectations(t)
+}
+
+func TestImportHandler_ListImportJobs(t *testing.T) {
+	router, mockService := setupImportHandler()
+
+	mockService.On("ListImportJobs", mock.Anything, interfaces.PaginationParams{Page: 2, PageSize: 10}).
+		Return(&interfaces.PaginatedImportJobs{
+			Jobs:       []interfaces.ImportJob{{ID: "5", Status: "completed"}},
+			Pagination: interfaces.PaginationInfo{Page: 2, PageSize: 10, TotalItems: 11, TotalPages: 2},
+		}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/imports?page=2&page_size=10", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"jobs"`)
+	mockService.AssertExpectations(t)
+}
+
+func TestImportHandler_DownloadErrorReport(t *testing.T) {
+	router, mockService := setupImportHandler()
+
+	report := "row_number,status,error,account_id,amount,reason\n3,invalid,unknown account 4,4,10,Credit\n"
+	mockService.On("WriteErrorReport", mock.Anything, "5").Return(report, nil)
+	mockService.On("WriteErrorReport", mock.Anything, "x").Return("", fmt.Errorf("invalid import job ID: strconv.Atoi: parsing \"x\": invalid syntax"))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/imports/5/errors.csv", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "import-5-errors.csv")
+	assert.Equal(t, report, w.Body.String())
+
+	req = httptest.NewRequest(http.MethodGet, "/api/admin/imports/x/errors.csv", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	mockService.AssertExpectations(t)
+}
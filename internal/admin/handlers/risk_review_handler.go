@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
+)
+
+// RiskReviewHandlerImpl implements the transfer risk review queue endpoints
+type RiskReviewHandlerImpl struct {
+	riskReviewService interfaces.RiskReviewService
+}
+
+// NewRiskReviewHandler creates a new risk review handler
+func NewRiskReviewHandler(riskReviewService interfaces.RiskReviewService) interfaces.RiskReviewHandler {
+	return &RiskReviewHandlerImpl{
+		riskReviewService: riskReviewService,
+	}
+}
+
+// ReviewDecisionRequest represents the request body for approving or rejecting a review
+type ReviewDecisionRequest struct {
+	Notes           string `json:"notes"`
+	ReverseTransfer bool   `json:"reverse_transfer"`
+}
+
+// RegisterRoutes registers HTTP routes for the risk review queue
+func (h *RiskReviewHandlerImpl) RegisterRoutes(router gin.IRouter) {
+	reviewGroup := router.Group("/risk-reviews")
+	{
+		reviewGroup.GET("", h.ListReviews)
+		reviewGroup.GET("/:id", h.GetReview)
+		reviewGroup.POST("/:id/approve", h.ApproveReview)
+		reviewGroup.POST("/:id/reject", h.RejectReview)
+	}
+}
+
+// ListReviews returns risk assessments, defaulting to the pending review queue
+func (h *RiskReviewHandlerImpl) ListReviews(c *gin.Context) {
+	params := interfaces.RiskReviewParams{
+		ReviewStatus: c.DefaultQuery("review_status", "pending"),
+		Decision:     c.Query("decision"),
+	}
+	if params.ReviewStatus == "all" {
+		params.ReviewStatus = ""
+	}
+
+	if page, err := strconv.Atoi(c.DefaultQuery("page", "1")); err == nil {
+		params.Page = page
+	}
+	if pageSize, err := strconv.Atoi(c.DefaultQuery("page_size", "20")); err == nil {
+		params.PageSize = pageSize
+	}
+
+	result, err := h.riskReviewService.ListReviews(c.Request.Context(), params)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "failed_to_list_reviews",
+			"message": "Failed to list risk reviews",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetReview returns a single risk assessment
+func (h *RiskReviewHandlerImpl) GetReview(c *gin.Context) {
+	review, err := h.riskReviewService.GetReview(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.handleError(c, err, "failed_to_get_review", "Failed to get risk review")
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
+}
+
+// ApproveReview clears a flagged transfer
+func (h *RiskReviewHandlerImpl) ApproveReview(c *gin.Context) {
+	var req ReviewDecisionRequest
+	if !h.bindDecision(c, &req) {
+		return
+	}
+
+	review, err := h.riskReviewService.ApproveReview(c.Request.Context(), c.Param("id"), adminUsernameFromContext(c), req.Notes)
+	if err != nil {
+		h.handleError(c, err, "failed_to_approve_review", "Failed to approve risk review")
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
+}
+
+// RejectReview confirms a flagged transfer as fraudulent
+func (h *RiskReviewHandlerImpl) RejectReview(c *gin.Context) {
+	var req ReviewDecisionRequest
+	if !h.bindDecision(c, &req) {
+		return
+	}
+
+	review, err := h.riskReviewService.RejectReview(c.Request.Context(), c.Param("id"), adminUsernameFromContext(c), req.Notes, req.ReverseTransfer)
+	if err != nil {
+		h.handleError(c, err, "failed_to_reject_review", "Failed to reject risk review")
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
+}
+
+// bindDecision binds an optional decision body, writing a 400 on malformed JSON
+func (h *RiskReviewHandlerImpl) bindDecision(c *gin.Context, req *ReviewDecisionRequest) bool {
+	if c.Request.ContentLength == 0 {
+		return true
+	}
+
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "Invalid request body",
+			"details": err.Error(),
+		})
+		return false
+	}
+
+	return true
+}
+
+// handleError maps risk review service errors to HTTP responses
+func (h *RiskReviewHandlerImpl) handleError(c *gin.Context, err error, code, message string) {
+	switch {
+	case strings.Contains(err.Error(), "invalid review ID"):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_review_id",
+			"message": "Review ID must be a number",
+			"details": err.Error(),
+		})
+	case strings.Contains(err.Error(), "not found"):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "review_not_found",
+			"message": "Risk review not found",
+			"details": err.Error(),
+		})
+	case strings.Contains(err.Error(), "already completed"), strings.Contains(err.Error(), "no transfer to reverse"),
+		strings.Contains(err.Error(), "already reversed"):
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "review_conflict",
+			"message": err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   code,
+			"message": message,
+			"details": err.Error(),
+		})
+	}
+}
+
+// adminUsernameFromContext returns the username of the authenticated admin
+func adminUsernameFromContext(c *gin.Context) string {
+	if session, exists := c.Get("admin_session"); exists {
+		if adminSession, ok := session.(*interfaces.AdminSession); ok {
+			return adminSession.Username
+		}
+	}
+	return "admin"
+}
@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockRiskReviewService is a mock implementation of RiskReviewService
+type MockRiskReviewService struct {
+	mock.Mock
+}
+
+func (m *MockRiskReviewService) ListReviews(ctx context.Context, params interfaces.RiskReviewParams) (*interfaces.PaginatedRiskReviews, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*interfaces.PaginatedRiskReviews), args.Error(1)
+}
+
+func (m *MockRiskReviewService) GetReview(ctx context.Context, reviewID string) (*interfaces.TransferRiskReview, error) {
+	args := m.Called(ctx, reviewID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*interfaces.TransferRiskReview), args.Error(1)
+}
+
+func (m *MockRiskReviewService) ApproveReview(ctx context.Context, reviewID, reviewer, notes string) (*interfaces.TransferRiskReview, error) {
+	args := m.Called(ctx, reviewID, reviewer, notes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*interfaces.TransferRiskReview), args.Error(1)
+}
+
+func (m *MockRiskReviewService) RejectReview(ctx context.Context, reviewID, reviewer, notes string, reverseTransfer bool) (*interfaces.TransferRiskReview, error) {
+	args := m.Called(ctx, reviewID, reviewer, notes, reverseTransfer)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*interfaces.TransferRiskReview), args.Error(1)
+}
+
+func setupRiskReviewHandler() (*gin.Engine, *MockRiskReviewService) {
+	gin.SetMode(gin.TestMode)
+	mockService := &MockRiskReviewService{}
+	handler := NewRiskReviewHandler(mockService)
+
+	router := gin.New()
+	group := router.Group("/api/admin")
+	group.Use(func(c *gin.Context) {
+		c.Set("admin_session", &interfaces.AdminSession{Username: "reviewer"})
+		c.Next()
+	})
+	handler.RegisterRoutes(group)
+	return router, mockService
+}
+
+func TestRiskReviewHandler_ListReviewsDefaultsToPending(t *testing.T) {
+	router, mockService := setupRiskReviewHandler()
+
+	expected := &interfaces.PaginatedRiskReviews{
+		Reviews: []interfaces.TransferRiskReview{
+			{ID: "1", Decision: "review", ReviewStatus: "pending", RiskScore: 40},
+		},
+		Pagination: interfaces.PaginationInfo{Page: 1, PageSize: 20, TotalItems: 1, TotalPages: 1},
+	}
+	mockService.On("ListReviews", mock.Anything, mock.MatchedBy(func(p interfaces.RiskReviewParams) bool {
+		return p.ReviewStatus == "pending" && p.Page == 1 && p.PageSize == 20
+	})).Return(expected, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/risk-reviews", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response interfaces.PaginatedRiskReviews
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response.Reviews, 1)
+	assert.Equal(t, "pending", response.Reviews[0].ReviewStatus)
+	mockService.AssertExpectations(t)
+}
+
+func TestRiskReviewHandler_ListReviewsAllStatuses(t *testing.T) {
+	router, mockService := setupRiskReviewHandler()
+
+	mockService.On("ListReviews", mock.Anything, mock.MatchedBy(func(p interfaces.RiskReviewParams) bool {
+		return p.ReviewStatus == "" && p.Decision == "block"
+	})).Return(&interfaces.PaginatedRiskReviews{}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/risk-reviews?review_status=all&decision=block", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestRiskReviewHandler_GetReviewNotFound(t *testing.T) {
+	router, mockService := setupRiskReviewHandler()
+
+	mockService.On("GetReview", mock.Anything, "99").Return(nil, fmt.Errorf("risk review not found"))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/risk-reviews/99", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestRiskReviewHandler_ApproveReview(t *testing.T) {
+	router, mockService := setupRiskReviewHandler()
+
+	mockService.On("ApproveReview", mock.Anything, "1", "reviewer", "customer confirmed").
+		Return(&interfaces.TransferRiskReview{ID: "1", ReviewStatus: "approved"}, nil)
+
+	body := bytes.NewBufferString(`{"notes":"customer confirmed"}`)
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/risk-reviews/1/approve", body)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response interfaces.TransferRiskReview
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "approved", response.ReviewStatus)
+	mockService.AssertExpectations(t)
+}
+
+func TestRiskReviewHandler_RejectReviewWithReversal(t *testing.T) {
+	router, mockService := setupRiskReviewHandler()
+
+	mockService.On("RejectReview", mock.Anything, "1", "reviewer", "", true).
+		Return(&interfaces.TransferRiskReview{ID: "1", ReviewStatus: "rejected"}, nil)
+
+	body := bytes.NewBufferString(`{"reverse_transfer":true}`)
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/risk-reviews/1/reject", body)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestRiskReviewHandler_ReviewAlreadyCompleted(t *testing.T) {
+	router, mockService := setupRiskReviewHandler()
+
+	mockService.On("ApproveReview", mock.Anything, "1", "reviewer", "").
+		Return(nil, fmt.Errorf("risk review already completed"))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/risk-reviews/1/approve", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	mockService.AssertExpectations(t)
+}
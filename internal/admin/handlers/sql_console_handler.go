@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
+)
+
+// SQLConsoleHandlerImpl implements the read-only SQL console endpoints
+type SQLConsoleHandlerImpl struct {
+	consoleService interfaces.SQLConsoleService
+}
+
+// NewSQLConsoleHandler creates a new SQL console handler
+func NewSQLConsoleHandler(consoleService interfaces.SQLConsoleService) interfaces.SQLConsoleHandler {
+	return &SQLConsoleHandlerImpl{
+		consoleService: consoleService,
+	}
+}
+
+// RegisterRoutes registers HTTP routes for the SQL console
+func (h *SQLConsoleHandlerImpl) RegisterRoutes(router gin.IRouter) {
+	sqlGroup := router.Group("/sql")
+	{
+		sqlGroup.POST("/query", h.ExecuteQuery)
+		sqlGroup.GET("/history", h.ListQueryLog)
+	}
+}
+
+// ExecuteQuery handles POST /api/admin/sql/query. The body holds a single
+// SELECT in "query"; with "format": "csv" the result is downloaded as CSV.
+func (h *SQLConsoleHandlerImpl) ExecuteQuery(c *gin.Context) {
+	var req interfaces.SQLQueryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+	req.ClientIP = c.ClientIP()
+
+	result, err := h.consoleService.ExecuteQuery(c.Request.Context(), req, adminUsernameFromContext(c))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	if strings.EqualFold(strings.TrimSpace(req.Format), "csv") {
+		var body bytes.Buffer
+		if err := writeQueryResultCSV(&body, result); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":   "failed_to_export_query",
+				"message": "Failed to export query result",
+				"details": err.Error(),
+			})
+			return
+		}
+
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="query-%s.csv"`, result.QueryLogID))
+		c.Header("X-Result-Truncated", strconv.FormatBool(result.Truncated))
+		c.Data(http.StatusOK, "text/csv; charset=utf-8", body.Bytes())
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ListQueryLog handles GET /api/admin/sql/history
+func (h *SQLConsoleHandlerImpl) ListQueryLog(c *gin.Context) {
+	params := interfaces.QueryLogParams{AdminUsername: c.Query("admin")}
+	if page, err := strconv.Atoi(c.DefaultQuery("page", "1")); err == nil {
+		params.Page = page
+	}
+	if pageSize, err := strconv.Atoi(c.DefaultQuery("page_size", "20")); err == nil {
+		params.PageSize = pageSize
+	}
+
+	result, err := h.consoleService.ListQueryLog(c.Request.Context(), params)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "failed_to_list_query_log",
+			"message": "Failed to list SQL console query log",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// handleError maps SQL console service errors to HTTP responses
+func (h *SQLConsoleHandlerImpl) handleError(c *gin.Context, err error) {
+	switch {
+	case strings.HasPrefix(err.Error(), "invalid query"):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_query",
+			"message": err.Error(),
+		})
+	case strings.HasPrefix(err.Error(), "query timed out"):
+		c.JSON(http.StatusRequestTimeout, gin.H{
+			"error":   "query_timeout",
+			"message": err.Error(),
+		})
+	case strings.HasPrefix(err.Error(), "query failed"):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error":   "query_failed",
+			"message": err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "failed_to_execute_query",
+			"message": "Failed to execute query",
+			"details": err.Error(),
+		})
+	}
+}
+
+// writeQueryResultCSV writes a console result as CSV with a header row
+func writeQueryResultCSV(buf *bytes.Buffer, result *interfaces.SQLQueryResult) error {
+	writer := csv.NewWriter(buf)
+
+	header := make([]string, len(result.Columns))
+	for i, column := range result.Columns {
+		header[i] = column.Name
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	record := make([]string, len(result.Columns))
+	for _, row := range result.Rows {
+		for i, value := range row {
+			formatted, err := formatCSVValue(value)
+			if err != nil {
+				return err
+			}
+			record[i] = formatted
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// formatCSVValue formats a result value as a CSV field; NULL is an empty field
+func formatCSVValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	case map[string]interface{}, []interface{}:
+		encoded, err := json.Marshal(v)
+		return string(encoded), err
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockSQLConsoleService is a mock implementation of SQLConsoleService
+type MockSQLConsoleService struct {
+	mock.Mock
+}
+
+func (m *MockSQLConsoleService) ExecuteQuery(ctx context.Context, req interfaces.SQLQueryRequest, executedBy string) (*interfaces.SQLQueryResult, error) {
+	args := m.Called(ctx, req.Query, req.Format, executedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*interfaces.SQLQueryResult), args.Error(1)
+}
+
+func (m *MockSQLConsoleService) ListQueryLog(ctx context.Context, params interfaces.QueryLogParams) (*interfaces.PaginatedQueryLog, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*interfaces.PaginatedQueryLog), args.Error(1)
+}
+
+func setupSQLConsoleHandler() (*gin.Engine, *MockSQLConsoleService) {
+	gin.SetMode(gin.TestMode)
+	mockService := &MockSQLConsoleService{}
+	handler := NewSQLConsoleHandler(mockService)
+
+	router := gin.New()
+	group := router.Group("/api/admin")
+	group.Use(func(c *gin.Context) {
+		c.Set("admin_session", &interfaces.AdminSession{Username: "support"})
+		c.Next()
+	})
+	handler.RegisterRoutes(group)
+	return router, mockService
+}
+
+func consoleResult() *interfaces.SQLQueryResult {
+	return &interfaces.SQLQueryResult{
+		QueryLogID: "12",
+		Columns: []interfaces.SQLResultColumn{
+			{Name: "email", Type: "varchar"},
+			{Name: "password_hash", Type: "varchar", Masked: true},
+			{Name: "created_at", Type: "timestamp"},
+			{Name: "note", Type: "text"},
+		},
+		Rows: [][]interface{}{
+			{"john@example.com", "****abcd", time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC), nil},
+			{"jane@example.com", "****wxyz", time.Date(2024, 3, 2, 8, 30, 0, 0, time.UTC), "says \"hi\", twice"},
+		},
+		RowCount:  2,
+		Truncated: true,
+	}
+}
+
+func TestSQLConsoleHandler_ExecuteQuery(t *testing.T) {
+	router, mockService := setupSQLConsoleHandler()
+	query := "SELECT email, password_hash, created_at, note FROM users"
+	mockService.On("ExecuteQuery", mock.Anything, query, "", "support").Return(consoleResult(), nil)
+
+	body, _ := json.Marshal(map[string]string{"query": query})
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/sql/query", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response interfaces.SQLQueryResult
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "12", response.QueryLogID)
+	assert.True(t, response.Columns[1].Masked)
+	assert.True(t, response.Truncated)
+	mockService.AssertExpectations(t)
+}
+
+func TestSQLConsoleHandler_ExportCSV(t *testing.T) {
+	router, mockService := setupSQLConsoleHandler()
+	query := "SELECT email, password_hash, created_at, note FROM users"
+	mockService.On("ExecuteQuery", mock.Anything, query, "csv", "support").Return(consoleResult(), nil)
+
+	body, _ := json.Marshal(map[string]string{"query": query, "format": "csv"})
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/sql/query", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="query-12.csv"`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "true", w.Header().Get("X-Result-Truncated"))
+	assert.Equal(t, "email,password_hash,created_at,note\n"+
+		"john@example.com,****abcd,2024-03-01T12:00:00Z,\n"+
+		"jane@example.com,****wxyz,2024-03-02T08:30:00Z,\"says \"\"hi\"\", twice\"\n", w.Body.String())
+}
+
+func TestSQLConsoleHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedCode   string
+	}{
+		{"rejected statement", fmt.Errorf("invalid query: only a single statement is allowed"), http.StatusBadRequest, "invalid_query"},
+		{"statement timeout", fmt.Errorf("query timed out after 10s"), http.StatusRequestTimeout, "query_timeout"},
+		{"database error", fmt.Errorf("query failed: relation \"nope\" does not exist"), http.StatusUnprocessableEntity, "query_failed"},
+		{"audit log unavailable", fmt.Errorf("failed to record query in audit log: connection refused"), http.StatusInternalServerError, "failed_to_execute_query"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, mockService := setupSQLConsoleHandler()
+			mockService.On("ExecuteQuery", mock.Anything, "SELECT 1", "", "support").Return(nil, tt.err)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/admin/sql/query", bytes.NewBufferString(`{"query": "SELECT 1"}`))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			var response map[string]interface{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedCode, response["error"])
+		})
+	}
+
+	t.Run("missing query", func(t *testing.T) {
+		router, _ := setupSQLConsoleHandler()
+		req := httptest.NewRequest(http.MethodPost, "/api/admin/sql/query", bytes.NewBufferString(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestSQLConsoleHandler_ListQueryLog(t *testing.T) {
+	router, mockService := setupSQLConsoleHandler()
+	params := interfaces.QueryLogParams{
+		PaginationParams: interfaces.PaginationParams{Page: 2, PageSize: 10},
+		AdminUsername:    "support",
+	}
+	mockService.On("ListQueryLog", mock.Anything, params).Return(&interfaces.PaginatedQueryLog{
+		Entries: []interfaces.QueryLogEntry{{ID: "3", AdminUsername: "support", Query: "SELECT 1", Status: "succeeded", Format: "json"}},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/sql/history?admin=support&page=2&page_size=10", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response interfaces.PaginatedQueryLog
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Entries, 1)
+	assert.Equal(t, "succeeded", response.Entries[0].Status)
+	mockService.AssertExpectations(t)
+}
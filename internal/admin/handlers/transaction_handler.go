@@ -3,6 +3,7 @@ package handlers
 import (
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
@@ -42,8 +43,9 @@ func (h *TransactionHandler) RegisterRoutes(router gin.IRouter) {
 // @Tags transactions
 // @Accept json
 // @Produce json
-// @Param page query int false "Page number" default(1)
+// @Param page query int false "Page number; omit to page by cursor"
 // @Param page_size query int false "Page size" default(20)
+// @Param cursor query string false "next_cursor or prev_cursor from a previous response"
 // @Param user_id query string false "Filter by user ID"
 // @Param account_id query string false "Filter by account ID"
 // @Param currency query string false "Filter by currency"
@@ -53,6 +55,8 @@ func (h *TransactionHandler) RegisterRoutes(router gin.IRouter) {
 // @Param date_from query string false "Start date filter (RFC3339)"
 // @Param date_to query string false "End date filter (RFC3339)"
 // @Param description query string false "Description search"
+// @Param direction query string false "incoming or outgoing, relative to account_id or user_id"
+// @Param counterparty_account_id query string false "Filter by the other account, relative to account_id or user_id"
 // @Success 200 {object} interfaces.PaginatedTransactions
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
@@ -78,6 +82,9 @@ func (h *TransactionHandler) SearchTransactions(c *gin.Context) {
 	params.Currency = c.Query("currency")
 	params.Status = c.Query("status")
 	params.Description = c.Query("description")
+	params.Direction = c.Query("direction")
+	params.CounterpartyAccountID = c.Query("counterparty_account_id")
+	params.Cursor = c.Query("cursor")
 
 	// Parse amount filters
 	if amountMin := c.Query("amount_min"); amountMin != "" {
@@ -117,6 +124,14 @@ func (h *TransactionHandler) SearchTransactions(c *gin.Context) {
 	// Search transactions
 	result, err := h.transactionService.SearchTransactions(c.Request.Context(), params)
 	if err != nil {
+		if strings.Contains(err.Error(), "invalid") {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "validation_error",
+				Message: err.Error(),
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_error",
 			Message: "Failed to search transactions: " + err.Error(),
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -99,6 +100,47 @@ func TestTransactionHandler_SearchTransactions(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestTransactionHandler_SearchTransactionsByCursor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(MockTransactionService)
+	handler := NewTransactionHandler(mockService)
+
+	expectedParams := interfaces.SearchTransactionParams{
+		AccountID:             "7",
+		Direction:             "incoming",
+		CounterpartyAccountID: "9",
+		Cursor:                "abc",
+	}
+	mockService.On("SearchTransactions", mock.Anything, expectedParams).Return(&interfaces.PaginatedTransactions{
+		Transactions: []interfaces.TransactionDetail{{ID: "3"}},
+		Pagination:   interfaces.PaginationInfo{PageSize: 20, HasNext: true, HasPrev: true},
+		NextCursor:   "next",
+		PrevCursor:   "prev",
+	}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/transactions?account_id=7&direction=incoming&counterparty_account_id=9&cursor=abc", nil)
+	handler.SearchTransactions(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"next_cursor":"next"`)
+	assert.Contains(t, w.Body.String(), `"prev_cursor":"prev"`)
+
+	// Malformed cursors and filters are client errors
+	mockService.On("SearchTransactions", mock.Anything, interfaces.SearchTransactionParams{Cursor: "bogus"}).
+		Return((*interfaces.PaginatedTransactions)(nil), errors.New("invalid cursor: invalid cursor"))
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/transactions?cursor=bogus", nil)
+	handler.SearchTransactions(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertExpectations(t)
+}
+
 func TestTransactionHandler_GetTransactionDetail(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
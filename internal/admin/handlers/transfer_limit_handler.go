@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
+)
+
+// TransferLimitHandlerImpl implements per-user transfer limit override endpoints
+type TransferLimitHandlerImpl struct {
+	transferLimitService interfaces.TransferLimitService
+}
+
+// NewTransferLimitHandler creates a new transfer limit handler
+func NewTransferLimitHandler(transferLimitService interfaces.TransferLimitService) interfaces.TransferLimitHandler {
+	return &TransferLimitHandlerImpl{
+		transferLimitService: transferLimitService,
+	}
+}
+
+// RegisterRoutes registers HTTP routes for transfer limit overrides
+func (h *TransferLimitHandlerImpl) RegisterRoutes(router gin.IRouter) {
+	limitGroup := router.Group("/users/:id/transfer-limits")
+	{
+		limitGroup.GET("", h.ListUserLimits)
+		limitGroup.PUT("/:currency", h.SetUserLimit)
+		limitGroup.DELETE("/:currency", h.DeleteUserLimit)
+	}
+}
+
+// ListUserLimits handles GET /api/admin/users/:id/transfer-limits
+func (h *TransferLimitHandlerImpl) ListUserLimits(c *gin.Context) {
+	limits, err := h.transferLimitService.ListUserLimits(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.handleError(c, err, "failed_to_list_limits", "Failed to list transfer limits")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"limits": limits,
+		"total":  len(limits),
+	})
+}
+
+// SetUserLimit handles PUT /api/admin/users/:id/transfer-limits/:currency
+func (h *TransferLimitHandlerImpl) SetUserLimit(c *gin.Context) {
+	var req interfaces.SetTransferLimitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": "Invalid request data",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	limit, err := h.transferLimitService.SetUserLimit(c.Request.Context(), c.Param("id"), c.Param("currency"), req, adminUsernameFromContext(c))
+	if err != nil {
+		h.handleError(c, err, "failed_to_set_limit", "Failed to save transfer limit")
+		return
+	}
+
+	c.JSON(http.StatusOK, limit)
+}
+
+// DeleteUserLimit handles DELETE /api/admin/users/:id/transfer-limits/:currency
+func (h *TransferLimitHandlerImpl) DeleteUserLimit(c *gin.Context) {
+	if err := h.transferLimitService.DeleteUserLimit(c.Request.Context(), c.Param("id"), c.Param("currency")); err != nil {
+		h.handleError(c, err, "failed_to_delete_limit", "Failed to delete transfer limit")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Transfer limit override removed, defaults apply",
+	})
+}
+
+// handleError maps transfer limit service errors to HTTP responses
+func (h *TransferLimitHandlerImpl) handleError(c *gin.Context, err error, code, message string) {
+	switch {
+	case strings.Contains(err.Error(), "invalid user ID"):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_user_id",
+			"message": "User ID must be a number",
+			"details": err.Error(),
+		})
+	case strings.Contains(err.Error(), "invalid limit"):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_limit",
+			"message": err.Error(),
+		})
+	case strings.Contains(err.Error(), "user not found"):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "user_not_found",
+			"message": "User not found",
+		})
+	case strings.Contains(err.Error(), "transfer limit not found"):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "limit_not_found",
+			"message": "No transfer limit override for this currency",
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   code,
+			"message": message,
+			"details": err.Error(),
+		})
+	}
+}
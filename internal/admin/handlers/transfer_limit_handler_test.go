@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockTransferLimitService is a mock implementation of TransferLimitService
+type MockTransferLimitService struct {
+	mock.Mock
+}
+
+func (m *MockTransferLimitService) ListUserLimits(ctx context.Context, userID string) ([]interfaces.UserTransferLimit, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]interfaces.UserTransferLimit), args.Error(1)
+}
+
+func (m *MockTransferLimitService) SetUserLimit(ctx context.Context, userID, currency string, req interfaces.SetTransferLimitRequest, updatedBy string) (*interfaces.UserTransferLimit, error) {
+	args := m.Called(ctx, userID, currency, req, updatedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*interfaces.UserTransferLimit), args.Error(1)
+}
+
+func (m *MockTransferLimitService) DeleteUserLimit(ctx context.Context, userID, currency string) error {
+	args := m.Called(ctx, userID, currency)
+	return args.Error(0)
+}
+
+func setupTransferLimitHandler() (*gin.Engine, *MockTransferLimitService) {
+	gin.SetMode(gin.TestMode)
+	mockService := &MockTransferLimitService{}
+	handler := NewTransferLimitHandler(mockService)
+
+	router := gin.New()
+	group := router.Group("/api/admin")
+	group.Use(func(c *gin.Context) {
+		c.Set("admin_session", &interfaces.AdminSession{Username: "ops"})
+		c.Next()
+	})
+	handler.RegisterRoutes(group)
+	return router, mockService
+}
+
+func TestTransferLimitHandler_ListUserLimits(t *testing.T) {
+	router, mockService := setupTransferLimitHandler()
+
+	dailyAmount := "2500.00"
+	mockService.On("ListUserLimits", mock.Anything, "7").Return([]interfaces.UserTransferLimit{
+		{UserID: "7", Currency: "USD", DailyAmount: &dailyAmount},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/users/7/transfer-limits", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Limits []interfaces.UserTransferLimit `json:"limits"`
+		Total  int                            `json:"total"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.Total)
+	assert.Equal(t, "2500.00", *response.Limits[0].DailyAmount)
+	mockService.AssertExpectations(t)
+}
+
+func TestTransferLimitHandler_SetUserLimit(t *testing.T) {
+	router, mockService := setupTransferLimitHandler()
+
+	dailyAmount := "2500"
+	dailyCount := 10
+	expectedReq := interfaces.SetTransferLimitRequest{DailyAmount: &dailyAmount, DailyCount: &dailyCount}
+	mockService.On("SetUserLimit", mock.Anything, "7", "usd", expectedReq, "ops").
+		Return(&interfaces.UserTransferLimit{UserID: "7", Currency: "USD", DailyCount: &dailyCount}, nil)
+
+	body := bytes.NewBufferString(`{"daily_amount":"2500","daily_count":10}`)
+	req := httptest.NewRequest(http.MethodPut, "/api/admin/users/7/transfer-limits/usd", body)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestTransferLimitHandler_SetUserLimitInvalid(t *testing.T) {
+	router, mockService := setupTransferLimitHandler()
+
+	mockService.On("SetUserLimit", mock.Anything, "7", "USD", mock.Anything, "ops").
+		Return(nil, fmt.Errorf("invalid limit: daily_amount cannot be negative"))
+
+	body := bytes.NewBufferString(`{"daily_amount":"-1"}`)
+	req := httptest.NewRequest(http.MethodPut, "/api/admin/users/7/transfer-limits/USD", body)
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid_limit")
+	mockService.AssertExpectations(t)
+}
+
+func TestTransferLimitHandler_DeleteUserLimit(t *testing.T) {
+	router, mockService := setupTransferLimitHandler()
+
+	mockService.On("DeleteUserLimit", mock.Anything, "7", "USD").Return(nil)
+	mockService.On("DeleteUserLimit", mock.Anything, "7", "EUR").Return(fmt.Errorf("transfer limit not found"))
+	mockService.On("DeleteUserLimit", mock.Anything, "99", "USD").Return(fmt.Errorf("user not found"))
+
+	tests := []struct {
+		path   string
+		status int
+	}{
+		{"/api/admin/users/7/transfer-limits/USD", http.StatusOK},
+		{"/api/admin/users/7/transfer-limits/EUR", http.StatusNotFound},
+		{"/api/admin/users/99/transfer-limits/USD", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodDelete, tt.path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, tt.status, w.Code, tt.path)
+	}
+
+	mockService.AssertExpectations(t)
+}
@@ -31,6 +31,7 @@ func (h *UserHandler) RegisterRoutes(router gin.IRouter) {
 		userGroup.DELETE("/:id", h.DeleteUser)
 		userGroup.POST("/:id/disable", h.DisableUser)
 		userGroup.POST("/:id/enable", h.EnableUser)
+		userGroup.POST("/:id/unlock", h.UnlockUser)
 	}
 }
 
@@ -103,6 +104,7 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 		return
 	}
 
+	setETag(c, user.Version)
 	c.JSON(http.StatusOK, user)
 }
 
@@ -162,8 +164,19 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 		return
 	}
 
+	expectedVersion, err := parseIfMatch(c)
+	if err != nil {
+		writeIfMatchError(c, err)
+		return
+	}
+	req.ExpectedVersion = expectedVersion
+
 	user, err := h.userService.UpdateUser(c.Request.Context(), userID, req)
 	if err != nil {
+		if writeVersionConflict(c, err) {
+			return
+		}
+
 		if contains(err.Error(), "not found") {
 			c.JSON(http.StatusNotFound, gin.H{
 				"error":   "user_not_found",
@@ -180,6 +193,7 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 		return
 	}
 
+	setETag(c, user.Version)
 	c.JSON(http.StatusOK, user)
 }
 
@@ -251,6 +265,41 @@ func (h *UserHandler) EnableUser(c *gin.Context) {
 	})
 }
 
+// UnlockUser handles POST /api/admin/users/:id/unlock, lifting a lockout
+// after repeated failed sign-ins
+func (h *UserHandler) UnlockUser(c *gin.Context) {
+	userID := c.Param("id")
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_user_id",
+			"message": "User ID is required",
+		})
+		return
+	}
+
+	err := h.userService.UnlockUser(c.Request.Context(), userID)
+	if err != nil {
+		if contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error":   "user_not_found",
+				"message": "User not found",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "failed_to_unlock_user",
+			"message": "Failed to unlock user",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "User unlocked successfully",
+	})
+}
+
 // DeleteUser handles DELETE /api/admin/users/:id
 func (h *UserHandler) DeleteUser(c *gin.Context) {
 	userID := c.Param("id")
@@ -262,8 +311,18 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 		return
 	}
 
-	err := h.userService.DeleteUser(c.Request.Context(), userID)
+	expectedVersion, err := parseIfMatch(c)
+	if err != nil {
+		writeIfMatchError(c, err)
+		return
+	}
+
+	err = h.userService.DeleteUser(c.Request.Context(), userID, expectedVersion)
 	if err != nil {
+		if writeVersionConflict(c, err) {
+			return
+		}
+
 		if contains(err.Error(), "not found") {
 			c.JSON(http.StatusNotFound, gin.H{
 				"error":   "user_not_found",
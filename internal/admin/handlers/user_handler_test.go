@@ -60,11 +60,16 @@ func (m *MockUserManagementService) EnableUser(ctx context.Context, userID strin
 	return args.Error(0)
 }
 
-func (m *MockUserManagementService) DeleteUser(ctx context.Context, userID string) error {
+func (m *MockUserManagementService) UnlockUser(ctx context.Context, userID string) error {
 	args := m.Called(ctx, userID)
 	return args.Error(0)
 }
 
+func (m *MockUserManagementService) DeleteUser(ctx context.Context, userID string, expectedVersion *int) error {
+	args := m.Called(ctx, userID, expectedVersion)
+	return args.Error(0)
+}
+
 func setupUserHandlerTest() (*UserHandler, *MockUserManagementService, *gin.Engine) {
 	gin.SetMode(gin.TestMode)
 	
@@ -375,10 +380,12 @@ func TestUserHandler_UpdateUser(t *testing.T) {
 		firstName := "Updated"
 		lastName := "Name"
 		isActive := false
+		version := 5
 		updateReq := interfaces.UpdateUserRequest{
-			FirstName: &firstName,
-			LastName:  &lastName,
-			IsActive:  &isActive,
+			FirstName:       &firstName,
+			LastName:        &lastName,
+			IsActive:        &isActive,
+			ExpectedVersion: &version,
 		}
 
 		// Setup mock
@@ -388,6 +395,7 @@ func TestUserHandler_UpdateUser(t *testing.T) {
 		reqBody, _ := json.Marshal(updateReq)
 		req, _ := http.NewRequest("PUT", "/users/1", bytes.NewBuffer(reqBody))
 		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", `"5"`)
 		w := httptest.NewRecorder()
 
 		// Execute
@@ -407,7 +415,8 @@ func TestUserHandler_UpdateUser(t *testing.T) {
 	})
 
 	t.Run("user not found", func(t *testing.T) {
-		updateReq := interfaces.UpdateUserRequest{}
+		version := 5
+		updateReq := interfaces.UpdateUserRequest{ExpectedVersion: &version}
 
 		// Setup mock
 		mockService.On("UpdateUser", mock.Anything, "999", updateReq).Return((*interfaces.UserDetail)(nil), fmt.Errorf("user not found"))
@@ -416,6 +425,7 @@ func TestUserHandler_UpdateUser(t *testing.T) {
 		reqBody, _ := json.Marshal(updateReq)
 		req, _ := http.NewRequest("PUT", "/users/999", bytes.NewBuffer(reqBody))
 		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", `"5"`)
 		w := httptest.NewRecorder()
 
 		// Execute
@@ -428,6 +438,55 @@ func TestUserHandler_UpdateUser(t *testing.T) {
 	})
 }
 
+func TestUserHandler_UpdateUserIfMatch(t *testing.T) {
+	_, mockService, router := setupUserHandlerTest()
+
+	firstName := "Updated"
+	stale := 41
+	current := 42
+	currentUser := &interfaces.UserDetail{ID: "2", FirstName: "Someone else", Version: &current}
+
+	mockService.On("UpdateUser", mock.Anything, "2", interfaces.UpdateUserRequest{FirstName: &firstName, ExpectedVersion: &stale}).
+		Return((*interfaces.UserDetail)(nil), &interfaces.VersionConflictError{Current: currentUser, Version: current})
+
+	req, _ := http.NewRequest("PUT", "/users/2", bytes.NewBufferString(`{"first_name": "Updated"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"41"`)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, `"42"`, w.Header().Get("ETag"))
+
+	var response map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "version_conflict", response["error"])
+	assert.Equal(t, "Someone else", response["current"].(map[string]interface{})["first_name"])
+	mockService.AssertExpectations(t)
+
+	req, _ = http.NewRequest("PUT", "/users/2", bytes.NewBufferString(`{"first_name": "Updated"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", "not-a-version")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// A blind overwrite is refused before the service is called
+	for _, ifMatch := range []string{"", "*"} {
+		req, _ = http.NewRequest("PUT", "/users/2", bytes.NewBufferString(`{"first_name": "Updated"}`))
+		req.Header.Set("Content-Type", "application/json")
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusPreconditionRequired, w.Code, "If-Match %q", ifMatch)
+	}
+	mockService.AssertNumberOfCalls(t, "UpdateUser", 1)
+}
+
 func TestUserHandler_DisableUser(t *testing.T) {
 	_, mockService, router := setupUserHandlerTest()
 
@@ -497,15 +556,49 @@ func TestUserHandler_EnableUser(t *testing.T) {
 	})
 }
 
+func TestUserHandler_UnlockUser(t *testing.T) {
+	_, mockService, router := setupUserHandlerTest()
+
+	t.Run("successful unlock user", func(t *testing.T) {
+		mockService.On("UnlockUser", mock.Anything, "1").Return(nil)
+
+		req, _ := http.NewRequest("POST", "/users/1/unlock", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response map[string]interface{}
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, "User unlocked successfully", response["message"])
+
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		mockService.On("UnlockUser", mock.Anything, "999").Return(fmt.Errorf("user not found"))
+
+		req, _ := http.NewRequest("POST", "/users/999/unlock", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		mockService.AssertExpectations(t)
+	})
+}
+
 func TestUserHandler_DeleteUser(t *testing.T) {
 	_, mockService, router := setupUserHandlerTest()
 
 	t.Run("successful delete user", func(t *testing.T) {
 		// Setup mock
-		mockService.On("DeleteUser", mock.Anything, "1").Return(nil)
+		version := 5
+		mockService.On("DeleteUser", mock.Anything, "1", &version).Return(nil)
 
 		// Create request
 		req, _ := http.NewRequest("DELETE", "/users/1", nil)
+		req.Header.Set("If-Match", `"5"`)
 		w := httptest.NewRecorder()
 
 		// Execute
@@ -530,10 +623,12 @@ func TestUserHandler_DeleteUser(t *testing.T) {
 		freshHandler.RegisterRoutes(freshRouter)
 		
 		// Setup mock
-		freshMockService.On("DeleteUser", mock.Anything, "1").Return(fmt.Errorf("cannot delete user with existing accounts"))
+		version := 5
+		freshMockService.On("DeleteUser", mock.Anything, "1", &version).Return(fmt.Errorf("cannot delete user with existing accounts"))
 
 		// Create request
 		req, _ := http.NewRequest("DELETE", "/users/1", nil)
+		req.Header.Set("If-Match", `"5"`)
 		w := httptest.NewRecorder()
 
 		// Execute
@@ -549,4 +644,45 @@ func TestUserHandler_DeleteUser(t *testing.T) {
 
 		freshMockService.AssertExpectations(t)
 	})
-}
\ No newline at end of file
+
+	t.Run("stale delete returns 409 with the current user", func(t *testing.T) {
+		freshMockService := &MockUserManagementService{}
+		freshHandler := NewUserHandler(freshMockService).(*UserHandler)
+		freshRouter := gin.New()
+		freshHandler.RegisterRoutes(freshRouter)
+
+		stale := 41
+		current := 42
+		currentUser := &interfaces.UserDetail{ID: "2", FirstName: "Someone else", Version: &current}
+		freshMockService.On("DeleteUser", mock.Anything, "2", &stale).
+			Return(&interfaces.VersionConflictError{Current: currentUser, Version: current})
+
+		req, _ := http.NewRequest("DELETE", "/users/2", nil)
+		req.Header.Set("If-Match", `"41"`)
+		w := httptest.NewRecorder()
+		freshRouter.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Equal(t, `"42"`, w.Header().Get("ETag"))
+		freshMockService.AssertExpectations(t)
+	})
+
+	t.Run("missing If-Match returns 428", func(t *testing.T) {
+		freshMockService := &MockUserManagementService{}
+		freshHandler := NewUserHandler(freshMockService).(*UserHandler)
+		freshRouter := gin.New()
+		freshHandler.RegisterRoutes(freshRouter)
+
+		for _, ifMatch := range []string{"", "*"} {
+			req, _ := http.NewRequest("DELETE", "/users/1", nil)
+			if ifMatch != "" {
+				req.Header.Set("If-Match", ifMatch)
+			}
+			w := httptest.NewRecorder()
+			freshRouter.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusPreconditionRequired, w.Code, "If-Match %q", ifMatch)
+		}
+		freshMockService.AssertNotCalled(t, "DeleteUser", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -142,10 +142,12 @@ func (suite *UserIntegrationTestSuite) TestCompleteUserManagementWorkflow() {
 	firstName := "Updated"
 	lastName := "Name"
 	isActive := false
+	readVersion := 1
 	updateReq := interfaces.UpdateUserRequest{
-		FirstName: &firstName,
-		LastName:  &lastName,
-		IsActive:  &isActive,
+		FirstName:       &firstName,
+		LastName:        &lastName,
+		IsActive:        &isActive,
+		ExpectedVersion: &readVersion,
 	}
 
 	suite.mockService.On("UpdateUser", ctx, "1", updateReq).Return(updatedUser, nil)
@@ -153,6 +155,7 @@ func (suite *UserIntegrationTestSuite) TestCompleteUserManagementWorkflow() {
 	reqBody, _ = json.Marshal(updateReq)
 	req, _ = http.NewRequest("PUT", "/users/1", bytes.NewBuffer(reqBody))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"1"`)
 	w = httptest.NewRecorder()
 
 	suite.router.ServeHTTP(w, req)
@@ -194,9 +197,11 @@ func (suite *UserIntegrationTestSuite) TestCompleteUserManagementWorkflow() {
 	suite.Equal("User enabled successfully", enableResponse["message"])
 
 	// Step 7: Delete user (should succeed since no accounts/transfers)
-	suite.mockService.On("DeleteUser", ctx, "1").Return(nil)
+	deleteVersion := 2
+	suite.mockService.On("DeleteUser", ctx, "1", &deleteVersion).Return(nil)
 
 	req, _ = http.NewRequest("DELETE", "/users/1", nil)
+	req.Header.Set("If-Match", `"2"`)
 	w = httptest.NewRecorder()
 
 	suite.router.ServeHTTP(w, req)
@@ -238,9 +243,11 @@ func (suite *UserIntegrationTestSuite) TestUserManagementErrorScenarios() {
 	suite.Equal("email_already_exists", response["error"])
 
 	// Test delete user with dependencies
-	suite.mockService.On("DeleteUser", ctx, "2").Return(fmt.Errorf("cannot delete user with existing accounts"))
+	version := 1
+	suite.mockService.On("DeleteUser", ctx, "2", &version).Return(fmt.Errorf("cannot delete user with existing accounts"))
 
 	req, _ = http.NewRequest("DELETE", "/users/2", nil)
+	req.Header.Set("If-Match", `"1"`)
 	w = httptest.NewRecorder()
 
 	suite.router.ServeHTTP(w, req)
@@ -358,6 +365,6 @@ func TestUserManagementServiceIntegration(t *testing.T) {
 	assert.Equal(t, 5, userDetail.TransferCount)
 	assert.Equal(t, now, userDetail.CreatedAt)
 	assert.Equal(t, now, userDetail.UpdatedAt)
-	assert.Nil(t, userDetail.LastLogin) // Not implemented yet
+	assert.Nil(t, userDetail.LastLogin) // Never signed in
 	assert.NotNil(t, userDetail.Metadata)
 }
\ No newline at end of file
@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -15,12 +17,20 @@ import (
 // WebSocketHandlerImpl implements the WebSocketHandler interface
 type WebSocketHandlerImpl struct {
 	notificationService interfaces.NotificationService
+	alertService        interfaces.AlertService
 	upgrader           websocket.Upgrader
 }
 
 // NewWebSocketHandler creates a new WebSocket handler
 func NewWebSocketHandler(notificationService interfaces.NotificationService) interfaces.WebSocketHandler {
+	return NewWebSocketHandlerWithAlerts(notificationService, nil)
+}
+
+// NewWebSocketHandlerWithAlerts creates a WebSocket handler whose clients can
+// acknowledge alerts over the socket
+func NewWebSocketHandlerWithAlerts(notificationService interfaces.NotificationService, alertService interfaces.AlertService) interfaces.WebSocketHandler {
 	return &WebSocketHandlerImpl{
+		alertService:        alertService,
 		notificationService: notificationService,
 		upgrader: websocket.Upgrader{
 			ReadBufferSize:  1024,
@@ -93,6 +103,13 @@ func (h *WebSocketHandlerImpl) HandleConnection(c *gin.Context) {
 		Str("admin_username", adminSession.Username).
 		Msg("WebSocket connection established")
 
+	// Clients reconnecting with ?last_seen=<seq> get what they missed first
+	if lastSeen, err := strconv.ParseInt(c.Query("last_seen"), 10, 64); err == nil && lastSeen > 0 {
+		reply := h.replay(conn, lastSeen)
+		reply.Action = interfaces.WebSocketActionReplay
+		h.sendReply(conn, adminSession, reply)
+	}
+
 	// Handle connection lifecycle
 	h.handleConnectionLifecycle(conn, adminSession)
 }
@@ -114,6 +131,20 @@ func (h *WebSocketHandlerImpl) HandleNotifications(c *gin.Context) {
 			"user_activity",
 			"transaction",
 		},
+		"topics": []string{
+			interfaces.TopicAlerts,
+			interfaces.TopicTransactions,
+			interfaces.TopicUserActivity,
+			interfaces.TopicSystem,
+		},
+		"commands": []string{
+			interfaces.WebSocketActionSubscribe,
+			interfaces.WebSocketActionUnsubscribe,
+			interfaces.WebSocketActionListSubscriptions,
+			interfaces.WebSocketActionAckAlert,
+			interfaces.WebSocketActionPing,
+			interfaces.WebSocketActionReplay,
+		},
 	})
 }
 
@@ -139,8 +170,8 @@ func (h *WebSocketHandlerImpl) handleConnectionLifecycle(conn *websocket.Conn, s
 		for {
 			select {
 			case <-ticker.C:
-				conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
-				if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				// WriteControl may run concurrently with notification writes
+				if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(10*time.Second)); err != nil {
 					log.Warn().
 						Err(err).
 						Str("admin_id", session.ID).
@@ -187,28 +218,127 @@ func (h *WebSocketHandlerImpl) handleConnectionLifecycle(conn *websocket.Conn, s
 	}
 }
 
-// handleTextMessage processes text messages from the WebSocket client
+// handleTextMessage processes text messages from the WebSocket client. Besides
+// the plain "ping" health check, clients send JSON commands to manage their
+// topic subscriptions and acknowledge alerts; each command gets one reply.
 func (h *WebSocketHandlerImpl) handleTextMessage(conn *websocket.Conn, session *interfaces.AdminSession, message []byte) {
 	log.Debug().
 		Str("admin_id", session.ID).
 		Str("message", string(message)).
 		Msg("Received WebSocket text message")
 
-	// For now, we mainly use WebSocket for server-to-client notifications
-	// Client messages could be used for:
-	// - Acknowledgment of notifications
-	// - Subscription preferences
-	// - Connection health checks
-	
 	// Simple echo for connection testing
 	if string(message) == "ping" {
-		conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
-		if err := conn.WriteMessage(websocket.TextMessage, []byte("pong")); err != nil {
+		if err := h.notificationService.SendToConnection(conn, []byte("pong")); err != nil {
 			log.Warn().
 				Err(err).
 				Str("admin_id", session.ID).
 				Msg("Failed to send pong response")
 		}
+		return
+	}
+
+	var command interfaces.WebSocketCommand
+	if err := json.Unmarshal(message, &command); err != nil {
+		h.sendReply(conn, session, interfaces.WebSocketReply{
+			Type:  "error",
+			Error: "messages must be JSON commands with an action",
+		})
+		return
+	}
+
+	reply := h.handleCommand(conn, session, command)
+	reply.ID = command.ID
+	reply.Action = command.Action
+	h.sendReply(conn, session, reply)
+}
+
+// handleCommand runs a client command and builds its reply
+func (h *WebSocketHandlerImpl) handleCommand(conn *websocket.Conn, session *interfaces.AdminSession, command interfaces.WebSocketCommand) interfaces.WebSocketReply {
+	switch command.Action {
+	case interfaces.WebSocketActionSubscribe:
+		subscription := interfaces.Subscription{Topic: command.Topic, Filter: command.Filter}
+		if err := h.notificationService.SubscribeTopic(conn, subscription); err != nil {
+			return interfaces.WebSocketReply{Type: "error", Error: err.Error()}
+		}
+	case interfaces.WebSocketActionUnsubscribe:
+		if err := h.notificationService.UnsubscribeTopic(conn, command.Topic); err != nil {
+			return interfaces.WebSocketReply{Type: "error", Error: err.Error()}
+		}
+	case interfaces.WebSocketActionListSubscriptions, interfaces.WebSocketActionPing:
+	case interfaces.WebSocketActionAckAlert:
+		return h.acknowledgeAlert(session, command.AlertID)
+	case interfaces.WebSocketActionReplay:
+		return h.replay(conn, command.LastSeen)
+	default:
+		return interfaces.WebSocketReply{Type: "error", Error: fmt.Sprintf("unknown action %q", command.Action)}
+	}
+
+	return interfaces.WebSocketReply{
+		Type:          "reply",
+		Subscriptions: h.notificationService.GetSubscriptions(conn),
+	}
+}
+
+// acknowledgeAlert acknowledges an alert on behalf of the connected admin
+func (h *WebSocketHandlerImpl) acknowledgeAlert(session *interfaces.AdminSession, alertID string) interfaces.WebSocketReply {
+	if h.alertService == nil {
+		return interfaces.WebSocketReply{Type: "error", Error: "alert acknowledgement is not available"}
+	}
+	if alertID == "" {
+		return interfaces.WebSocketReply{Type: "error", Error: "alert_id is required"}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	alert, err := h.alertService.AcknowledgeAlert(ctx, alertID, session.Username)
+	if err != nil {
+		return interfaces.WebSocketReply{Type: "error", Error: err.Error()}
+	}
+
+	log.Info().
+		Str("admin_id", session.ID).
+		Str("alert_id", alertID).
+		Msg("Alert acknowledged over WebSocket")
+
+	return interfaces.WebSocketReply{Type: "reply", Alert: alert}
+}
+
+// replay resends the notifications published after lastSeen that the
+// connection's subscriptions match
+func (h *WebSocketHandlerImpl) replay(conn *websocket.Conn, lastSeen int64) interfaces.WebSocketReply {
+	if lastSeen < 0 {
+		return interfaces.WebSocketReply{Type: "error", Error: "last_seen must not be negative"}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result, err := h.notificationService.Replay(ctx, conn, lastSeen)
+	if err != nil {
+		return interfaces.WebSocketReply{Type: "error", Error: err.Error()}
+	}
+
+	return interfaces.WebSocketReply{Type: "reply", Replay: result}
+}
+
+// sendReply writes a command reply to the client
+func (h *WebSocketHandlerImpl) sendReply(conn *websocket.Conn, session *interfaces.AdminSession, reply interfaces.WebSocketReply) {
+	message, err := json.Marshal(reply)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("admin_id", session.ID).
+			Msg("Failed to encode WebSocket reply")
+		return
+	}
+
+	if err := h.notificationService.SendToConnection(conn, message); err != nil {
+		log.Warn().
+			Err(err).
+			Str("admin_id", session.ID).
+			Msg("Failed to send WebSocket reply")
 	}
 }
 
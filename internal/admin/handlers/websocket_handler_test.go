@@ -14,6 +14,7 @@ import (
 	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
 	"github.com/phantom-sage/bankgo/internal/admin/services"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -412,4 +413,153 @@ func TestWebSocketHandler_NotificationBroadcast_Integration(t *testing.T) {
 	assert.Equal(t, testNotification.Title, receivedNotification.Title)
 	assert.Equal(t, testNotification.Message, receivedNotification.Message)
 	assert.Equal(t, testNotification.Severity, receivedNotification.Severity)
-}
\ No newline at end of file
+}
+func TestWebSocketHandler_SubscriptionCommands(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	notificationService := services.NewNotificationService()
+	alertService := &MockAlertService{}
+	handler := NewWebSocketHandlerWithAlerts(notificationService, alertService)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("admin_session", &interfaces.AdminSession{ID: "admin-7", Username: "oncall"})
+		c.Next()
+	})
+	router.GET("/ws", handler.HandleConnection)
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/ws", nil)
+	require.NoError(t, err)
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	var welcome interfaces.Notification
+	require.NoError(t, conn.ReadJSON(&welcome))
+
+	// Subscribe to critical alerts only
+	require.NoError(t, conn.WriteJSON(interfaces.WebSocketCommand{
+		ID:     "1",
+		Action: interfaces.WebSocketActionSubscribe,
+		Topic:  interfaces.TopicAlerts,
+		Filter: interfaces.SubscriptionFilter{Severities: []string{"critical"}},
+	}))
+	var reply interfaces.WebSocketReply
+	require.NoError(t, conn.ReadJSON(&reply))
+	assert.Equal(t, "reply", reply.Type)
+	assert.Equal(t, "1", reply.ID)
+	assert.Equal(t, []interfaces.Subscription{
+		{Topic: interfaces.TopicAlerts, Filter: interfaces.SubscriptionFilter{Severities: []string{"critical"}}},
+	}, reply.Subscriptions)
+
+	// Only the critical alert reaches the connection
+	ctx := context.Background()
+	require.NoError(t, notificationService.Broadcast(ctx, &interfaces.Notification{ID: "warn", Type: "alert", Severity: "warning"}))
+	require.NoError(t, notificationService.Broadcast(ctx, &interfaces.Notification{ID: "tx", Type: "transaction", Severity: "info"}))
+	require.NoError(t, notificationService.Broadcast(ctx, &interfaces.Notification{ID: "crit", Type: "alert", Severity: "critical"}))
+
+	var notification interfaces.Notification
+	require.NoError(t, conn.ReadJSON(&notification))
+	assert.Equal(t, "crit", notification.ID)
+
+	// Acknowledge an alert from the socket
+	alertService.On("AcknowledgeAlert", mock.Anything, "alert-1", "oncall").
+		Return(&interfaces.Alert{ID: "alert-1", Acknowledged: true, AcknowledgedBy: "oncall"}, nil)
+	require.NoError(t, conn.WriteJSON(interfaces.WebSocketCommand{ID: "2", Action: interfaces.WebSocketActionAckAlert, AlertID: "alert-1"}))
+	reply = interfaces.WebSocketReply{}
+	require.NoError(t, conn.ReadJSON(&reply))
+	assert.Equal(t, "reply", reply.Type)
+	require.NotNil(t, reply.Alert)
+	assert.True(t, reply.Alert.Acknowledged)
+	alertService.AssertExpectations(t)
+
+	// Errors are replied with the command ID
+	for _, command := range []interfaces.WebSocketCommand{
+		{ID: "3", Action: interfaces.WebSocketActionSubscribe, Topic: "everything"},
+		{ID: "4", Action: interfaces.WebSocketActionSubscribe, Topic: interfaces.TopicTransactions, Filter: interfaces.SubscriptionFilter{MinAmount: "lots"}},
+		{ID: "5", Action: "shutdown"},
+		{ID: "6", Action: interfaces.WebSocketActionAckAlert},
+	} {
+		require.NoError(t, conn.WriteJSON(command))
+		reply = interfaces.WebSocketReply{}
+		require.NoError(t, conn.ReadJSON(&reply))
+		assert.Equal(t, "error", reply.Type, "command %s", command.ID)
+		assert.Equal(t, command.ID, reply.ID)
+		assert.NotEmpty(t, reply.Error)
+	}
+
+	// Unsubscribing from the last topic stops all notifications
+	require.NoError(t, conn.WriteJSON(interfaces.WebSocketCommand{ID: "7", Action: interfaces.WebSocketActionUnsubscribe, Topic: interfaces.TopicAlerts}))
+	reply = interfaces.WebSocketReply{}
+	require.NoError(t, conn.ReadJSON(&reply))
+	assert.Equal(t, "reply", reply.Type)
+	assert.Empty(t, reply.Subscriptions)
+
+	require.NoError(t, notificationService.Broadcast(ctx, &interfaces.Notification{ID: "crit-2", Type: "alert", Severity: "critical"}))
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte("ping")))
+	_, message, err := conn.ReadMessage()
+	require.NoError(t, err)
+	assert.Equal(t, "pong", string(message))
+}
+
+func TestWebSocketHandler_ReplayOnReconnect(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	notificationService := services.NewNotificationService()
+	handler := NewWebSocketHandler(notificationService)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("admin_session", &interfaces.AdminSession{ID: "admin-7", Username: "oncall"})
+		c.Next()
+	})
+	router.GET("/ws", handler.HandleConnection)
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	// Published while the admin was disconnected
+	ctx := context.Background()
+	for _, id := range []string{"n1", "n2", "n3"} {
+		require.NoError(t, notificationService.Broadcast(ctx, &interfaces.Notification{ID: id, Type: "alert", Severity: "warning"}))
+	}
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/ws?last_seen=1", nil)
+	require.NoError(t, err)
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	var welcome interfaces.Notification
+	require.NoError(t, conn.ReadJSON(&welcome))
+
+	// Missed notifications arrive in order, followed by the replay summary
+	for _, expected := range []struct {
+		id       string
+		sequence int64
+	}{{"n2", 2}, {"n3", 3}} {
+		var notification interfaces.Notification
+		require.NoError(t, conn.ReadJSON(&notification))
+		assert.Equal(t, expected.id, notification.ID)
+		assert.Equal(t, expected.sequence, notification.Sequence)
+	}
+
+	var reply interfaces.WebSocketReply
+	require.NoError(t, conn.ReadJSON(&reply))
+	assert.Equal(t, "reply", reply.Type)
+	assert.Equal(t, interfaces.WebSocketActionReplay, reply.Action)
+	assert.Equal(t, &interfaces.ReplayResult{Replayed: 2, Complete: true, LastSequence: 3}, reply.Replay)
+
+	// Replay can also be requested as a command
+	require.NoError(t, conn.WriteJSON(interfaces.WebSocketCommand{ID: "1", Action: interfaces.WebSocketActionReplay, LastSeen: 3}))
+	reply = interfaces.WebSocketReply{}
+	require.NoError(t, conn.ReadJSON(&reply))
+	assert.Equal(t, "1", reply.ID)
+	assert.Equal(t, &interfaces.ReplayResult{Replayed: 0, Complete: true, LastSequence: 3}, reply.Replay)
+
+	require.NoError(t, conn.WriteJSON(interfaces.WebSocketCommand{ID: "2", Action: interfaces.WebSocketActionReplay, LastSeen: -1}))
+	reply = interfaces.WebSocketReply{}
+	require.NoError(t, conn.ReadJSON(&reply))
+	assert.Equal(t, "error", reply.Type)
+}
@@ -2,6 +2,9 @@ package interfaces
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -45,9 +48,12 @@ type UserManagementService interface {
 	
 	// EnableUser enables a user account
 	EnableUser(ctx context.Context, userID string) error
+
+	// UnlockUser clears a user's failed sign-ins and any lockout
+	UnlockUser(ctx context.Context, userID string) error
 	
-	// DeleteUser deletes a user account
-	DeleteUser(ctx context.Context, userID string) error
+	// DeleteUser deletes a user account. expectedVersion works as in UpdateUser.
+	DeleteUser(ctx context.Context, userID string, expectedVersion *int) error
 }
 
 // SystemMonitoringService defines the interface for system monitoring
@@ -85,11 +91,15 @@ type DatabaseService interface {
 	// CreateRecord creates a new record in a table
 	CreateRecord(ctx context.Context, tableName string, data map[string]interface{}) (*TableRecord, error)
 	
-	// UpdateRecord updates an existing record
-	UpdateRecord(ctx context.Context, tableName string, recordID interface{}, data map[string]interface{}) (*TableRecord, error)
+	// UpdateRecord updates an existing record. A non-nil expectedVersion must
+	// match the record's current version or a *VersionConflictError is returned.
+	UpdateRecord(ctx context.Context, tableName string, recordID interface{}, data map[string]interface{}, expectedVersion *int) (*TableRecord, error)
+	
+	// DeleteRecord deletes a record from a table, checking expectedVersion like UpdateRecord
+	DeleteRecord(ctx context.Context, tableName string, recordID interface{}, expectedVersion *int) error
 	
-	// DeleteRecord deletes a record from a table
-	DeleteRecord(ctx context.Context, tableName string, recordID interface{}) error
+	// ListRelatedRecords returns records of relatedTable linked to a record by a foreign key
+	ListRelatedRecords(ctx context.Context, tableName string, recordID interface{}, relatedTable string, via string, params ListRecordsParams) (*PaginatedRecords, error)
 	
 	// BulkOperation performs bulk operations on records
 	BulkOperation(ctx context.Context, tableName string, operation BulkOperation) (*BulkOperationResult, error)
@@ -111,6 +121,38 @@ type NotificationService interface {
 	
 	// GetConnectionCount returns the number of active connections
 	GetConnectionCount() int
+
+	// SubscribeTopic adds or replaces a connection's subscription to a topic. A
+	// connection receives every notification until its first subscription.
+	SubscribeTopic(conn *websocket.Conn, subscription Subscription) error
+
+	// UnsubscribeTopic removes a connection's subscription to a topic
+	UnsubscribeTopic(conn *websocket.Conn, topic string) error
+
+	// GetSubscriptions returns a connection's topic subscriptions
+	GetSubscriptions(conn *websocket.Conn) []Subscription
+
+	// SendToConnection writes a text message to one connection, serialized
+	// with the notifications sent to it
+	SendToConnection(conn *websocket.Conn, message []byte) error
+
+	// Replay resends the buffered notifications published after sequence
+	// lastSeen that match the connection's subscriptions, oldest first
+	Replay(ctx context.Context, conn *websocket.Conn, lastSeen int64) (*ReplayResult, error)
+}
+
+// NotificationBus carries notifications from the processes that raise them to
+// every admin API replica holding WebSocket connections
+type NotificationBus interface {
+	// Publish assigns the notification its sequence number, keeps it in the
+	// replay buffer and delivers it to every subscriber
+	Publish(ctx context.Context, notification *Notification) error
+
+	// Subscribe calls handler for each published notification until ctx is done
+	Subscribe(ctx context.Context, handler func(*Notification)) error
+
+	// Recent returns the replay buffer, oldest first
+	Recent(ctx context.Context) ([]*Notification, error)
 }
 
 // AlertService defines the interface for alert management
@@ -133,6 +175,9 @@ type AlertService interface {
 	// ResolveAlert marks an alert as resolved
 	ResolveAlert(ctx context.Context, alertID, resolvedBy, notes string) (*Alert, error)
 	
+	// EscalateAlert raises an unresolved alert's severity and merges escalation metadata
+	EscalateAlert(ctx context.Context, alertID, severity string, metadata map[string]interface{}) (*Alert, error)
+	
 	// GetAlertStatistics returns alert statistics for a time range
 	GetAlertStatistics(ctx context.Context, timeRange *TimeRange) (*AlertStatistics, error)
 	
@@ -146,6 +191,39 @@ type AlertService interface {
 	CleanupOldResolvedAlerts(ctx context.Context, olderThan time.Time) error
 }
 
+// NotificationChannel defines an outbound destination for alert notifications
+type NotificationChannel interface {
+	// Name returns the unique channel name referenced by routing rules
+	Name() string
+
+	// Type returns the channel implementation type (email, webhook, slack)
+	Type() string
+
+	// Send delivers an alert through the channel
+	Send(ctx context.Context, alert *Alert) error
+}
+
+// AlertRouter hands new alerts to the notification channels routed for
+// their severity
+type AlertRouter interface {
+	// Dispatch delivers an alert to every channel routed for its severity
+	Dispatch(ctx context.Context, alert *Alert) error
+}
+
+// AlertDispatcher defines the interface for routing alerts to outbound channels
+type AlertDispatcher interface {
+	AlertRouter
+
+	// DispatchTo delivers an alert to the named channels regardless of routing
+	DispatchTo(ctx context.Context, alert *Alert, channelNames []string) error
+
+	// TestSend sends a synthetic alert through a single channel
+	TestSend(ctx context.Context, channelName string) (*ChannelTestResult, error)
+
+	// ListChannels returns configured channels with their routed severities
+	ListChannels() []ChannelInfo
+}
+
 // TransactionService defines the interface for transaction management
 type TransactionService interface {
 	// SearchTransactions returns transactions based on search criteria
@@ -179,6 +257,93 @@ type AccountService interface {
 	AdjustBalance(ctx context.Context, accountID string, adjustment string, reason string) (*AccountDetail, error)
 }
 
+// RiskReviewService defines the interface for the transfer risk review queue
+type RiskReviewService interface {
+	// ListReviews returns risk assessments matching the given filters
+	ListReviews(ctx context.Context, params RiskReviewParams) (*PaginatedRiskReviews, error)
+
+	// GetReview returns a single risk assessment
+	GetReview(ctx context.Context, reviewID string) (*TransferRiskReview, error)
+
+	// ApproveReview clears a flagged transfer
+	ApproveReview(ctx context.Context, reviewID, reviewer, notes string) (*TransferRiskReview, error)
+
+	// RejectReview confirms a flagged transfer as fraudulent, optionally reversing it
+	RejectReview(ctx context.Context, reviewID, reviewer, notes string, reverseTransfer bool) (*TransferRiskReview, error)
+}
+
+// TransferLimitService defines the interface for per-user transfer limit overrides
+type TransferLimitService interface {
+	// ListUserLimits returns a user's transfer limit overrides
+	ListUserLimits(ctx context.Context, userID string) ([]UserTransferLimit, error)
+
+	// SetUserLimit creates or replaces a user's override for a currency
+	SetUserLimit(ctx context.Context, userID, currency string, req SetTransferLimitRequest, updatedBy string) (*UserTransferLimit, error)
+
+	// DeleteUserLimit removes an override so the configured defaults apply again
+	DeleteUserLimit(ctx context.Context, userID, currency string) error
+}
+
+// FeeScheduleService defines the interface for transfer fee schedule management
+type FeeScheduleService interface {
+	// ListFeeSchedules returns all fee schedules, active first within each currency
+	ListFeeSchedules(ctx context.Context) ([]FeeSchedule, error)
+
+	// GetFeeSchedule returns a single fee schedule
+	GetFeeSchedule(ctx context.Context, scheduleID string) (*FeeSchedule, error)
+
+	// CreateFeeSchedule creates a fee schedule
+	CreateFeeSchedule(ctx context.Context, req FeeScheduleRequest, updatedBy string) (*FeeSchedule, error)
+
+	// UpdateFeeSchedule replaces a fee schedule's configuration
+	UpdateFeeSchedule(ctx context.Context, scheduleID string, req FeeScheduleRequest, updatedBy string) (*FeeSchedule, error)
+
+	// DeleteFeeSchedule removes a fee schedule; fees already collected are kept
+	DeleteFeeSchedule(ctx context.Context, scheduleID string) error
+
+	// PreviewFee computes the fee the active schedule charges on an amount
+	PreviewFee(ctx context.Context, currency, amount string) (*FeePreview, error)
+}
+
+// ImportService defines the interface for CSV bulk imports of transfers and balance adjustments
+type ImportService interface {
+	// ValidateImport parses a CSV file and dry-runs every row, storing the report
+	// as an import job that waits for an admin to execute it
+	ValidateImport(ctx context.Context, importType, fileName string, file io.Reader, createdBy string) (*ImportJob, error)
+
+	// ExecuteImport books the valid rows of a validated import job, one transaction per row
+	ExecuteImport(ctx context.Context, jobID string, req ExecuteImportRequest, executedBy string) (*ImportJob, error)
+
+	// GetImportJob returns an import job with the outcome of every row
+	GetImportJob(ctx context.Context, jobID string) (*ImportJob, error)
+
+	// ListImportJobs returns import jobs, most recent first, without their rows
+	ListImportJobs(ctx context.Context, params PaginationParams) (*PaginatedImportJobs, error)
+
+	// WriteErrorReport writes the invalid and failed rows of an import job as CSV
+	WriteErrorReport(ctx context.Context, jobID string, w io.Writer) error
+}
+
+// SQLConsoleService defines the interface for the read-only admin SQL console
+type SQLConsoleService interface {
+	// ExecuteQuery runs a single read statement in a read-only transaction with
+	// the configured timeout and row cap, and records it in the query log
+	ExecuteQuery(ctx context.Context, req SQLQueryRequest, executedBy string) (*SQLQueryResult, error)
+
+	// ListQueryLog returns console queries, most recent first
+	ListQueryLog(ctx context.Context, params QueryLogParams) (*PaginatedQueryLog, error)
+}
+
+// ActivityFeedService turns banking domain events into live WebSocket feeds
+// and the dashboard's activity counters
+type ActivityFeedService interface {
+	// Start consumes domain events in the background until ctx is done
+	Start(ctx context.Context) error
+
+	// GetCounters returns the activity counters for the UTC day containing date
+	GetCounters(ctx context.Context, date time.Time) (*ActivityCounters, error)
+}
+
 // AdminHandler defines the interface for HTTP handlers
 type AdminHandler interface {
 	// RegisterRoutes registers HTTP routes for this handler
@@ -227,6 +392,7 @@ type DatabaseHandler interface {
 	UpdateRecord(c *gin.Context)
 	DeleteRecord(c *gin.Context)
 	BulkOperation(c *gin.Context)
+	ListRelatedRecords(c *gin.Context)
 }
 
 // WebSocketHandler defines WebSocket connection handlers
@@ -269,6 +435,64 @@ type AlertHandler interface {
 	CleanupOldResolvedAlerts(c *gin.Context)
 }
 
+// AlertChannelHandler defines alert notification channel HTTP handlers
+type AlertChannelHandler interface {
+	AdminHandler
+	ListChannels(c *gin.Context)
+	TestSend(c *gin.Context)
+}
+
+// RiskReviewHandler defines transfer risk review queue HTTP handlers
+type RiskReviewHandler interface {
+	AdminHandler
+	ListReviews(c *gin.Context)
+	GetReview(c *gin.Context)
+	ApproveReview(c *gin.Context)
+	RejectReview(c *gin.Context)
+}
+
+// TransferLimitHandler defines per-user transfer limit HTTP handlers
+type TransferLimitHandler interface {
+	AdminHandler
+	ListUserLimits(c *gin.Context)
+	SetUserLimit(c *gin.Context)
+	DeleteUserLimit(c *gin.Context)
+}
+
+// FeeScheduleHandler defines transfer fee schedule HTTP handlers
+type FeeScheduleHandler interface {
+	AdminHandler
+	ListFeeSchedules(c *gin.Context)
+	GetFeeSchedule(c *gin.Context)
+	CreateFeeSchedule(c *gin.Context)
+	UpdateFeeSchedule(c *gin.Context)
+	DeleteFeeSchedule(c *gin.Context)
+	PreviewFee(c *gin.Context)
+}
+
+// ImportHandler defines CSV bulk import HTTP handlers
+type ImportHandler interface {
+	AdminHandler
+	ValidateImport(c *gin.Context)
+	ExecuteImport(c *gin.Context)
+	GetImportJob(c *gin.Context)
+	ListImportJobs(c *gin.Context)
+	DownloadErrorReport(c *gin.Context)
+}
+
+// ActivityHandler defines banking activity counter HTTP handlers
+type ActivityHandler interface {
+	AdminHandler
+	GetCounters(c *gin.Context)
+}
+
+// SQLConsoleHandler defines read-only SQL console HTTP handlers
+type SQLConsoleHandler interface {
+	AdminHandler
+	ExecuteQuery(c *gin.Context)
+	ListQueryLog(c *gin.Context)
+}
+
 // AdminMiddleware defines the interface for admin-specific middleware
 type AdminMiddleware interface {
 	// Handler returns the Gin middleware handler function
@@ -330,6 +554,7 @@ type UserDetail struct {
 	TransferCount   int                    `json:"transfer_count"`
 	WelcomeEmailSent bool                  `json:"welcome_email_sent"`
 	Metadata        map[string]interface{} `json:"metadata"`
+	Version         *int                   `json:"version,omitempty"`
 }
 
 // SystemHealth represents system health status
@@ -384,6 +609,10 @@ type UpdateUserRequest struct {
 	FirstName *string `json:"first_name"`
 	LastName  *string `json:"last_name"`
 	IsActive  *bool   `json:"is_active"`
+
+	// ExpectedVersion is taken from If-Match; when set the update only applies
+	// to that version of the user
+	ExpectedVersion *int `json:"-"`
 }
 
 // Paginated response types
@@ -431,6 +660,83 @@ type Notification struct {
 	Severity  string                 `json:"severity"`
 	Timestamp time.Time              `json:"timestamp"`
 	Data      map[string]interface{} `json:"data,omitempty"`
+
+	// Sequence orders notifications across admin API replicas; clients pass
+	// the last one they saw to replay what they missed while disconnected
+	Sequence int64 `json:"seq,omitempty"`
+}
+
+// ReplayResult reports the outcome of replaying missed notifications
+type ReplayResult struct {
+	Replayed int `json:"replayed"`
+	// Complete is false when older notifications than the buffer holds were missed
+	Complete bool `json:"complete"`
+	// LastSequence is the newest sequence in the buffer
+	LastSequence int64 `json:"last_seq"`
+}
+
+// Notification topics admins subscribe to over the WebSocket
+const (
+	TopicAlerts       = "alerts"        // alert and alert_update notifications
+	TopicTransactions = "transactions"  // transaction notifications
+	TopicUserActivity = "user_activity" // user_activity notifications
+	TopicSystem       = "system"        // system and metrics notifications
+)
+
+// Subscription is a connection's interest in one notification topic
+type Subscription struct {
+	Topic  string             `json:"topic"`
+	Filter SubscriptionFilter `json:"filter"`
+}
+
+// SubscriptionFilter narrows the notifications of a topic. Empty fields match
+// everything; fields that do not apply to the topic are ignored.
+type SubscriptionFilter struct {
+	// Severities of new alerts to receive (alerts)
+	Severities []string `json:"severities,omitempty"`
+
+	// MinAmount is the smallest transaction amount to receive (transactions)
+	MinAmount string `json:"min_amount,omitempty"`
+
+	// Currency of transactions to receive (transactions)
+	Currency string `json:"currency,omitempty"`
+
+	// UserID and Actions select the user activity to receive (user_activity)
+	UserID  string   `json:"user_id,omitempty"`
+	Actions []string `json:"actions,omitempty"`
+}
+
+// WebSocket command actions sent by admin clients
+const (
+	WebSocketActionSubscribe         = "subscribe"
+	WebSocketActionUnsubscribe       = "unsubscribe"
+	WebSocketActionListSubscriptions = "list_subscriptions"
+	WebSocketActionAckAlert          = "ack_alert"
+	WebSocketActionPing              = "ping"
+	WebSocketActionReplay            = "replay"
+)
+
+// WebSocketCommand is a JSON message sent by an admin client over the WebSocket
+type WebSocketCommand struct {
+	// ID is echoed in the reply so clients can match it to the command
+	ID      string             `json:"id,omitempty"`
+	Action  string             `json:"action"`
+	Topic   string             `json:"topic,omitempty"`
+	Filter  SubscriptionFilter `json:"filter"`
+	AlertID string             `json:"alert_id,omitempty"`
+	// LastSeen is the sequence of the last notification seen, for replay
+	LastSeen int64 `json:"last_seen,omitempty"`
+}
+
+// WebSocketReply answers a WebSocketCommand; Type is "reply" or "error"
+type WebSocketReply struct {
+	Type          string         `json:"type"`
+	ID            string         `json:"id,omitempty"`
+	Action        string         `json:"action,omitempty"`
+	Subscriptions []Subscription `json:"subscriptions,omitempty"`
+	Alert         *Alert         `json:"alert,omitempty"`
+	Replay        *ReplayResult  `json:"replay,omitempty"`
+	Error         string         `json:"error,omitempty"`
 }
 
 // TableInfo represents database table information
@@ -449,6 +755,9 @@ type TableSchema struct {
 	PrimaryKeys []string     `json:"primary_keys"`
 	ForeignKeys []ForeignKey `json:"foreign_keys"`
 	Indexes     []Index      `json:"indexes"`
+	// ReferencedBy lists foreign keys in other tables that point at this table
+	ReferencedBy []ForeignKey `json:"referenced_by"`
+	ReadOnly     bool         `json:"read_only"`
 }
 
 // Column represents a database column
@@ -460,10 +769,13 @@ type Column struct {
 	IsPrimaryKey bool        `json:"is_primary_key"`
 	IsForeignKey bool        `json:"is_foreign_key"`
 	MaxLength    *int        `json:"max_length,omitempty"`
+	ReadOnly     bool        `json:"read_only"`
+	Masked       bool        `json:"masked"`
 }
 
 // ForeignKey represents a foreign key constraint
 type ForeignKey struct {
+	TableName           string `json:"table_name,omitempty"`
 	ColumnName          string `json:"column_name"`
 	ReferencedTable     string `json:"referenced_table"`
 	ReferencedColumn    string `json:"referenced_column"`
@@ -493,6 +805,29 @@ type RecordMetadata struct {
 	CreatedAt  *time.Time             `json:"created_at,omitempty"`
 	UpdatedAt  *time.Time             `json:"updated_at,omitempty"`
 	Version    *int                   `json:"version,omitempty"`
+	References []RecordReference      `json:"references,omitempty"`
+}
+
+// ErrRecordNotFound is returned when a database record does not exist
+var ErrRecordNotFound = errors.New("record not found")
+
+// VersionConflictError is returned when an edit names a version the row no
+// longer has. Current holds the row as it is now so the client can merge.
+type VersionConflictError struct {
+	Current interface{}
+	Version int
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("version conflict: record was modified, current version is %d", e.Version)
+}
+
+// RecordReference points from a record's foreign key value to the referenced record
+type RecordReference struct {
+	Column           string      `json:"column"`
+	ReferencedTable  string      `json:"referenced_table"`
+	ReferencedColumn string      `json:"referenced_column"`
+	Value            interface{} `json:"value"`
 }
 
 // TransactionDetail represents detailed transaction information
@@ -552,6 +887,31 @@ type ListRecordsParams struct {
 	Filters   map[string]interface{} `json:"filters" form:"filters"`
 	SortBy    string                 `json:"sort_by" form:"sort_by"`
 	SortDesc  bool                   `json:"sort_desc" form:"sort_desc"`
+	// Conditions are typed filters; Filters entries are treated as equality
+	Conditions []RecordFilter `json:"conditions" form:"-"`
+}
+
+// FilterOperator is a comparison applied by a RecordFilter
+type FilterOperator string
+
+// Supported record filter operators
+const (
+	FilterEq     FilterOperator = "eq"
+	FilterNe     FilterOperator = "ne"
+	FilterGt     FilterOperator = "gt"
+	FilterGte    FilterOperator = "gte"
+	FilterLt     FilterOperator = "lt"
+	FilterLte    FilterOperator = "lte"
+	FilterIn     FilterOperator = "in"
+	FilterIsNull FilterOperator = "is_null"
+)
+
+// RecordFilter is one typed condition on a column. Value holds the raw
+// operand, or a list of operands for in; it is converted using the column type.
+type RecordFilter struct {
+	Column   string         `json:"column"`
+	Operator FilterOperator `json:"operator"`
+	Value    interface{}    `json:"value"`
 }
 
 type SearchTransactionParams struct {
@@ -565,6 +925,12 @@ type SearchTransactionParams struct {
 	DateFrom      *time.Time `json:"date_from" form:"date_from"`
 	DateTo        *time.Time `json:"date_to" form:"date_to"`
 	Description   string     `json:"description" form:"description"`
+	// Direction (incoming or outgoing) and CounterpartyAccountID are relative
+	// to AccountID when set, otherwise to UserID
+	Direction             string `json:"direction" form:"direction"`
+	CounterpartyAccountID string `json:"counterparty_account_id" form:"counterparty_account_id"`
+	// Cursor continues a cursor-paged search; searches without a page number are cursor-paged
+	Cursor string `json:"cursor" form:"cursor"`
 }
 
 // Bulk operations
@@ -617,6 +983,8 @@ type PaginatedRecords struct {
 type PaginatedTransactions struct {
 	Transactions []TransactionDetail `json:"transactions"`
 	Pagination   PaginationInfo      `json:"pagination"`
+	NextCursor   string              `json:"next_cursor,omitempty"`
+	PrevCursor   string              `json:"prev_cursor,omitempty"`
 }
 
 // Account management types
@@ -663,4 +1031,240 @@ type AlertStatistics struct {
 	AcknowledgedCount int `json:"acknowledged_count"`
 	ResolvedCount     int `json:"resolved_count"`
 	UnresolvedCount   int `json:"unresolved_count"`
-}
\ No newline at end of file
+}
+
+// ChannelInfo describes a configured alert notification channel
+type ChannelInfo struct {
+	Name       string   `json:"name"`
+	Type       string   `json:"type"`
+	Severities []string `json:"severities"`
+}
+
+// ChannelTestResult represents the outcome of a test notification
+type ChannelTestResult struct {
+	Channel  string        `json:"channel"`
+	Type     string        `json:"type"`
+	Success  bool          `json:"success"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration"`
+	SentAt   time.Time     `json:"sent_at"`
+}
+
+// RiskReason describes a risk rule triggered by a transfer
+type RiskReason struct {
+	Rule   string `json:"rule"`
+	Score  int    `json:"score"`
+	Detail string `json:"detail"`
+}
+
+// TransferRiskReview represents a transfer risk assessment in the review queue
+type TransferRiskReview struct {
+	ID            string       `json:"id"`
+	TransferID    *string      `json:"transfer_id,omitempty"` // Nil for blocked transfers
+	UserID        string       `json:"user_id"`
+	FromAccountID string       `json:"from_account_id"`
+	ToAccountID   string       `json:"to_account_id"`
+	Amount        string       `json:"amount"` // Decimal as string
+	RiskScore     int          `json:"risk_score"`
+	Decision      string       `json:"decision"`
+	Reasons       []RiskReason `json:"reasons"`
+	ReviewStatus  string       `json:"review_status"`
+	ReviewedBy    *string      `json:"reviewed_by,omitempty"`
+	ReviewedAt    *time.Time   `json:"reviewed_at,omitempty"`
+	ReviewNotes   *string      `json:"review_notes,omitempty"`
+	CreatedAt     time.Time    `json:"created_at"`
+}
+
+type RiskReviewParams struct {
+	PaginationParams
+	ReviewStatus string `json:"review_status" form:"review_status"`
+	Decision     string `json:"decision" form:"decision"`
+}
+
+type PaginatedRiskReviews struct {
+	Reviews    []TransferRiskReview `json:"reviews"`
+	Pagination PaginationInfo       `json:"pagination"`
+}
+
+// UserTransferLimit represents an admin override of a user's transfer limits.
+// Nil fields inherit the configured default; zero means unlimited.
+type UserTransferLimit struct {
+	UserID        string    `json:"user_id"`
+	Currency      string    `json:"currency"`
+	DailyAmount   *string   `json:"daily_amount"`   // Decimal as string
+	MonthlyAmount *string   `json:"monthly_amount"` // Decimal as string
+	DailyCount    *int      `json:"daily_count"`
+	MonthlyCount  *int      `json:"monthly_count"`
+	UpdatedBy     *string   `json:"updated_by,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type SetTransferLimitRequest struct {
+	DailyAmount   *string `json:"daily_amount"`
+	MonthlyAmount *string `json:"monthly_amount"`
+	DailyCount    *int    `json:"daily_count"`
+	MonthlyCount  *int    `json:"monthly_count"`
+}
+
+// FeeSchedule represents a per-currency transfer fee configuration
+type FeeSchedule struct {
+	ID             string    `json:"id"`
+	Name           string    `json:"name"`
+	Currency       string    `json:"currency"`
+	FeeType        string    `json:"fee_type"`    // flat, percentage or tiered
+	FlatAmount     string    `json:"flat_amount"` // Decimal as string
+	Percentage     string    `json:"percentage"`  // Decimal as string
+	MinFee         *string   `json:"min_fee,omitempty"`
+	MaxFee         *string   `json:"max_fee,omitempty"`
+	Tiers          []FeeTier `json:"tiers,omitempty"`
+	HouseAccountID string    `json:"house_account_id"`
+	IsActive       bool      `json:"is_active"`
+	UpdatedBy      *string   `json:"updated_by,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// FeeTier is one band of a tiered fee schedule; a nil UpTo is open-ended
+type FeeTier struct {
+	UpTo       *string `json:"up_to,omitempty"`
+	FlatAmount string  `json:"flat_amount"`
+	Percentage string  `json:"percentage"`
+}
+
+type FeeScheduleRequest struct {
+	Name           string    `json:"name" binding:"required"`
+	Currency       string    `json:"currency" binding:"required"`
+	FeeType        string    `json:"fee_type" binding:"required"`
+	FlatAmount     string    `json:"flat_amount"`
+	Percentage     string    `json:"percentage"`
+	MinFee         *string   `json:"min_fee"`
+	MaxFee         *string   `json:"max_fee"`
+	Tiers          []FeeTier `json:"tiers"`
+	HouseAccountID string    `json:"house_account_id" binding:"required"`
+	IsActive       *bool     `json:"is_active"`
+}
+
+type FeePreview struct {
+	Currency        string  `json:"currency"`
+	Amount          string  `json:"amount"`
+	Fee             string  `json:"fee"`
+	TotalDebit      string  `json:"total_debit"`
+	FeeScheduleID   *string `json:"fee_schedule_id,omitempty"`
+	FeeScheduleName string  `json:"fee_schedule_name,omitempty"`
+}
+
+// ImportJob represents an admin CSV bulk import and its dry-run or execution outcome
+type ImportJob struct {
+	ID            string         `json:"id"`
+	ImportType    string         `json:"import_type"` // transfers or balance_adjustments
+	FileName      string         `json:"file_name"`
+	Status        string         `json:"status"`
+	TotalRows     int            `json:"total_rows"`
+	ValidRows     int            `json:"valid_rows"`
+	InvalidRows   int            `json:"invalid_rows"`
+	SucceededRows int            `json:"succeeded_rows"`
+	FailedRows    int            `json:"failed_rows"`
+	CreatedBy     string         `json:"created_by"`
+	ExecutedBy    *string        `json:"executed_by,omitempty"`
+	ErrorMessage  *string        `json:"error_message,omitempty"`
+	CreatedAt     time.Time      `json:"created_at"`
+	StartedAt     *time.Time     `json:"started_at,omitempty"`
+	CompletedAt   *time.Time     `json:"completed_at,omitempty"`
+	Rows          []ImportJobRow `json:"rows,omitempty"`
+}
+
+// ImportJobRow represents one data row of an import file; RowNumber is the
+// line in the uploaded file, counting the header as line 1
+type ImportJobRow struct {
+	RowNumber     int     `json:"row_number"`
+	AccountID     *string `json:"account_id,omitempty"`
+	FromAccountID *string `json:"from_account_id,omitempty"`
+	ToAccountID   *string `json:"to_account_id,omitempty"`
+	Amount        *string `json:"amount,omitempty"` // Decimal as string
+	Currency      *string `json:"currency,omitempty"`
+	Description   string  `json:"description,omitempty"`
+	Status        string  `json:"status"` // valid, invalid, succeeded or failed
+	Error         *string `json:"error,omitempty"`
+	TransferID    *string `json:"transfer_id,omitempty"`
+}
+
+type ExecuteImportRequest struct {
+	// AllowPartial executes the valid rows of a job that also has invalid rows
+	AllowPartial bool `json:"allow_partial"`
+}
+
+type PaginatedImportJobs struct {
+	Jobs       []ImportJob    `json:"jobs"`
+	Pagination PaginationInfo `json:"pagination"`
+}
+
+// SQLQueryRequest is a query submitted to the SQL console
+type SQLQueryRequest struct {
+	Query string `json:"query" binding:"required"`
+
+	// Format is json (default) or csv; it is recorded in the query log
+	Format string `json:"format"`
+
+	// MaxRows lowers the configured row cap for this query
+	MaxRows int `json:"max_rows"`
+
+	// ClientIP is recorded in the query log
+	ClientIP string `json:"-"`
+}
+
+// SQLQueryResult holds the rows returned by a console query. Values of masked
+// columns are replaced before they leave the service.
+type SQLQueryResult struct {
+	QueryLogID string            `json:"query_log_id"`
+	Columns    []SQLResultColumn `json:"columns"`
+	Rows       [][]interface{}   `json:"rows"`
+	RowCount   int               `json:"row_count"`
+	Truncated  bool              `json:"truncated"` // more rows existed than the cap
+	DurationMs int64             `json:"duration_ms"`
+}
+
+// SQLResultColumn describes a column of a console result
+type SQLResultColumn struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Masked bool   `json:"masked,omitempty"`
+}
+
+// QueryLogParams filters the SQL console query log
+type QueryLogParams struct {
+	PaginationParams
+	AdminUsername string `json:"admin_username,omitempty"`
+}
+
+// QueryLogEntry is one recorded SQL console query
+type QueryLogEntry struct {
+	ID            string    `json:"id"`
+	AdminUsername string    `json:"admin_username"`
+	Query         string    `json:"query"`
+	Status        string    `json:"status"` // succeeded, rejected or failed
+	Format        string    `json:"format"`
+	RowCount      int       `json:"row_count"`
+	Truncated     bool      `json:"truncated"`
+	DurationMs    int       `json:"duration_ms"`
+	Error         *string   `json:"error,omitempty"`
+	ClientIP      *string   `json:"client_ip,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type PaginatedQueryLog struct {
+	Entries    []QueryLogEntry `json:"entries"`
+	Pagination PaginationInfo  `json:"pagination"`
+}
+
+// ActivityCounters counts banking activity over one UTC day
+type ActivityCounters struct {
+	Date               string `json:"date"`
+	TransfersCompleted int64  `json:"transfers_completed"`
+	TransfersFailed    int64  `json:"transfers_failed"`
+	// TransferVolume is the amount transferred per currency
+	TransferVolume  map[string]string `json:"transfer_volume"`
+	AccountsCreated int64             `json:"accounts_created"`
+	AccountsDeleted int64             `json:"accounts_deleted"`
+	UsersRegistered int64             `json:"users_registered"`
+}
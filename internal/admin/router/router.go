@@ -7,6 +7,7 @@ import (
 	"github.com/phantom-sage/bankgo/internal/admin/handlers"
 	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
 	"github.com/phantom-sage/bankgo/internal/admin/middleware"
+	"github.com/phantom-sage/bankgo/internal/admin/services"
 )
 
 // Setup configures and returns the Gin router for the admin API
@@ -16,8 +17,10 @@ func Setup(handlers *handlers.Container, middleware *middleware.Container) *gin.
 		gin.SetMode(gin.ReleaseMode)
 	}
 
-	// Create router
+	// Create router. Only configured proxies may name the client in
+	// X-Forwarded-For; their entries are validated when the config loads.
 	r := gin.New()
+	_ = r.SetTrustedProxies(middleware.GetConfig().TrustedProxies)
 
 	// Add global middleware
 	r.Use(gin.Recovery())
@@ -28,12 +31,25 @@ func Setup(handlers *handlers.Container, middleware *middleware.Container) *gin.
 		r.Use(middleware.CORSMiddleware.Handler())
 	}
 
-	// Health check endpoint (no auth required)
+	// Health check endpoint (no auth required). A server running without an
+	// optional dependency reports itself as degraded.
 	r.GET("/health", func(c *gin.Context) {
+		status := "healthy"
+		var dependencies map[string]string
+		if serviceContainer := handlers.GetServices(); serviceContainer != nil {
+			dependencies = serviceContainer.Dependencies()
+			for _, state := range dependencies {
+				if state != services.DependencyAvailable {
+					status = "degraded"
+				}
+			}
+		}
+
 		c.JSON(http.StatusOK, gin.H{
-			"status":    "healthy",
-			"service":   "admin-api",
-			"timestamp": gin.H{"now": "timestamp"},
+			"status":       status,
+			"service":      "admin-api",
+			"dependencies": dependencies,
+			"timestamp":    gin.H{"now": "timestamp"},
 		})
 	})
 
@@ -85,8 +101,60 @@ func Setup(handlers *handlers.Container, middleware *middleware.Container) *gin.
 		handlers.WebSocketHandler.RegisterRoutes(protected)
 	}
 
-	// TODO: Register other protected routes when handlers are implemented
-	// protected.GET("/database/tables", handlers.DatabaseHandler.ListTables)
+	// Register alert notification channel routes
+	if handlers.AlertChannelHandler != nil {
+		handlers.AlertChannelHandler.RegisterRoutes(protected)
+	}
+
+	// Register transfer risk review queue routes
+	if handlers.RiskReviewHandler != nil {
+		handlers.RiskReviewHandler.RegisterRoutes(protected)
+	}
+
+	// Register per-user transfer limit routes
+	if handlers.TransferLimitHandler != nil {
+		handlers.TransferLimitHandler.RegisterRoutes(protected)
+	}
+
+	// Register transfer fee schedule routes
+	if handlers.FeeScheduleHandler != nil {
+		handlers.FeeScheduleHandler.RegisterRoutes(protected)
+	}
+
+	// Register CSV bulk import routes
+	if handlers.ImportHandler != nil {
+		handlers.ImportHandler.RegisterRoutes(protected)
+	}
+
+	// Register read-only SQL console routes
+	if handlers.SQLConsoleHandler != nil {
+		handlers.SQLConsoleHandler.RegisterRoutes(protected)
+	}
+
+	// Register data browser routes
+	if handlers.DatabaseHandler != nil {
+		handlers.DatabaseHandler.RegisterRoutes(protected)
+	}
+
+	// Register transaction search and reversal routes
+	if handlers.TransactionHandler != nil {
+		handlers.TransactionHandler.RegisterRoutes(protected)
+	}
+
+	// Register account management routes
+	if handlers.AccountHandler != nil {
+		handlers.AccountHandler.RegisterRoutes(protected)
+	}
+
+	// Register alert management routes
+	if handlers.AlertHandler != nil {
+		handlers.AlertHandler.RegisterRoutes(protected)
+	}
+
+	// Register banking activity counter routes
+	if handlers.ActivityHandler != nil {
+		handlers.ActivityHandler.RegisterRoutes(protected)
+	}
 
 	return r
 }
@@ -0,0 +1,82 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/phantom-sage/bankgo/internal/admin/config"
+	"github.com/phantom-sage/bankgo/internal/admin/handlers"
+	"github.com/phantom-sage/bankgo/internal/admin/middleware"
+	"github.com/phantom-sage/bankgo/internal/admin/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetup_RegistersAllHandlers(t *testing.T) {
+	handlerContainer := &handlers.Container{
+		UserHandler:        handlers.NewUserHandler(nil),
+		SystemHandler:      handlers.NewSystemHandler(nil),
+		DatabaseHandler:    handlers.NewDatabaseHandler(nil),
+		TransactionHandler: handlers.NewTransactionHandler(nil),
+		AccountHandler:     handlers.NewAccountHandler(nil),
+		AlertHandler:       handlers.NewAlertHandler(nil),
+		SQLConsoleHandler:  handlers.NewSQLConsoleHandler(nil),
+	}
+	middlewareContainer := middleware.NewContainer(&config.Config{}, &services.Container{})
+
+	r := Setup(handlerContainer, middlewareContainer)
+
+	routes := map[string]bool{}
+	for _, route := range r.Routes() {
+		routes[route.Method+" "+route.Path] = true
+	}
+	for _, route := range []string{
+		"GET /api/admin/database/tables",
+		"GET /api/admin/transactions",
+		"POST /api/admin/transactions/:id/reverse",
+		"GET /api/admin/accounts",
+		"GET /api/admin/accounts/:id/transactions",
+		"POST /api/admin/accounts/:id/freeze",
+		"GET /api/admin/alerts",
+		"GET /api/admin/alerts/statistics",
+	} {
+		assert.True(t, routes[route], "expected route %s", route)
+	}
+}
+
+func TestSetup_HealthWithoutServices(t *testing.T) {
+	middlewareContainer := middleware.NewContainer(&config.Config{}, &services.Container{})
+	r := Setup(&handlers.Container{}, middlewareContainer)
+
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"healthy"`)
+}
+
+func TestSetup_TrustedProxies(t *testing.T) {
+	clientIP := func(cfg *config.Config, remoteAddr string) string {
+		r := Setup(&handlers.Container{}, middleware.NewContainer(cfg, &services.Container{}))
+		r.GET("/test/client-ip", func(c *gin.Context) {
+			c.String(http.StatusOK, c.ClientIP())
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/test/client-ip", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("X-Forwarded-For", "198.51.100.1")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Body.String()
+	}
+
+	// A spoofed header cannot move sign-in failures to another IP
+	assert.Equal(t, "203.0.113.7", clientIP(&config.Config{}, "203.0.113.7:5000"))
+
+	trusting := &config.Config{TrustedProxies: []string{"10.0.0.0/8"}}
+	assert.Equal(t, "198.51.100.1", clientIP(trusting, "10.1.2.3:5000"))
+	assert.Equal(t, "203.0.113.7", clientIP(trusting, "203.0.113.7:5000"))
+}
@@ -15,16 +15,31 @@ import (
 
 // accountService implements account management operations
 type accountService struct {
-	db      *pgxpool.Pool
-	queries *queries.Queries
+	db       *pgxpool.Pool
+	queries  *queries.Queries
+	readPool ReadPoolFunc
 }
 
 // NewAccountService creates a new account service
 func NewAccountService(db *pgxpool.Pool) interfaces.AccountService {
+	return NewAccountServiceWithReadPool(db, nil)
+}
+
+// NewAccountServiceWithReadPool creates an account service that runs searches on readPool
+func NewAccountServiceWithReadPool(db *pgxpool.Pool, readPool ReadPoolFunc) interfaces.AccountService {
 	return &accountService{
-		db:      db,
-		queries: queries.New(db),
+		db:       db,
+		queries:  queries.New(db),
+		readPool: readPool,
+	}
+}
+
+// readQueries returns queries bound to the read pool
+func (s *accountService) readQueries() *queries.Queries {
+	if s.readPool == nil {
+		return s.queries
 	}
+	return queries.New(readPoolOrPrimary(s.db, s.readPool))
 }
 
 // SearchAccounts returns accounts based on search criteria
@@ -97,7 +112,7 @@ func (s *accountService) SearchAccounts(ctx context.Context, params interfaces.S
 	}
 
 	// Search accounts
-	accountRows, err := s.queries.SearchAccounts(ctx, queries.SearchAccountsParams{
+	accountRows, err := s.readQueries().SearchAccounts(ctx, queries.SearchAccountsParams{
 		Column1: searchParam,
 		Column2: currencyParam,
 		Column3: balanceMinParam,
@@ -0,0 +1,352 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
+	"github.com/phantom-sage/bankgo/internal/events"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// activityConsumerGroup is shared by every admin API replica, so each
+	// domain event is counted and broadcast once
+	activityConsumerGroup = "admin-dashboard"
+	// activityCountersKeyPrefix prefixes the Redis hash of each day's counters
+	activityCountersKeyPrefix = "admin:activity:"
+	// activityCountersRetention is how long daily counters are kept
+	activityCountersRetention = 8 * 24 * time.Hour
+	// activityMetricsInterval is how often changed counters are pushed to the dashboard
+	activityMetricsInterval = 5 * time.Second
+	// activityDayLayout formats the UTC day counters are kept under
+	activityDayLayout = "2006-01-02"
+)
+
+// Activity counter fields. Transfer volume is counted per currency in minor
+// units under activityVolumePrefix followed by the currency code.
+const (
+	activityTransfersCompleted = "transfers_completed"
+	activityTransfersFailed    = "transfers_failed"
+	activityAccountsCreated    = "accounts_created"
+	activityAccountsDeleted    = "accounts_deleted"
+	activityUsersRegistered    = "users_registered"
+	activityVolumePrefix       = "volume:"
+)
+
+// activityCounterStore keeps the daily activity counters
+type activityCounterStore interface {
+	Increment(ctx context.Context, day string, deltas map[string]int64) error
+	Get(ctx context.Context, day string) (map[string]int64, error)
+}
+
+// ActivityFeedServiceImpl implements the ActivityFeedService interface
+type ActivityFeedServiceImpl struct {
+	subscriber    events.Subscriber
+	notifications interfaces.NotificationService
+	counters      activityCounterStore
+	// changed is set when counters change and cleared when they are pushed
+	changed atomic.Bool
+}
+
+// NewActivityFeedService creates an activity feed consuming events from
+// subscriber. Counters are kept in Redis so every replica reports the same
+// totals; with a nil client they are kept in memory.
+func NewActivityFeedService(subscriber events.Subscriber, notifications interfaces.NotificationService, redisClient *redis.Client) interfaces.ActivityFeedService {
+	var counters activityCounterStore = newMemoryActivityCounters()
+	if redisClient != nil {
+		counters = &redisActivityCounters{client: redisClient}
+	}
+	return &ActivityFeedServiceImpl{
+		subscriber:    subscriber,
+		notifications: notifications,
+		counters:      counters,
+	}
+}
+
+// Start consumes domain events and pushes changed counters to the dashboard
+// until ctx is done
+func (s *ActivityFeedServiceImpl) Start(ctx context.Context) error {
+	if err := s.subscriber.Consume(ctx, activityConsumerGroup, s.handleEvent); err != nil {
+		return fmt.Errorf("failed to consume domain events: %w", err)
+	}
+
+	go s.pushCounters(ctx)
+	return nil
+}
+
+// GetCounters returns the activity counters for the UTC day containing date
+func (s *ActivityFeedServiceImpl) GetCounters(ctx context.Context, date time.Time) (*interfaces.ActivityCounters, error) {
+	day := date.UTC().Format(activityDayLayout)
+	values, err := s.counters.Get(ctx, day)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get activity counters: %w", err)
+	}
+	return activityCounters(day, values), nil
+}
+
+// handleEvent counts an event and broadcasts it to the live feeds. Only a
+// counter failure is returned, so the event is retried without being counted
+// twice; a missed broadcast is left to WebSocket replay.
+func (s *ActivityFeedServiceImpl) handleEvent(ctx context.Context, event events.Event) error {
+	notification, deltas, err := activityNotification(event)
+	if err != nil {
+		log.Warn().Err(err).Str("event_id", event.ID).Msg("Dropped undecodable domain event")
+		return nil
+	}
+	if notification == nil {
+		return nil
+	}
+
+	day := event.OccurredAt.UTC().Format(activityDayLayout)
+	if err := s.counters.Increment(ctx, day, deltas); err != nil {
+		return fmt.Errorf("failed to count %s event: %w", event.Type, err)
+	}
+	s.changed.Store(true)
+
+	if err := s.notifications.Broadcast(ctx, notification); err != nil {
+		log.Warn().Err(err).Str("event_id", event.ID).Msg("Failed to broadcast activity notification")
+	}
+	return nil
+}
+
+// pushCounters broadcasts today's counters as a metrics notification whenever
+// they have changed
+func (s *ActivityFeedServiceImpl) pushCounters(ctx context.Context) {
+	ticker := time.NewTicker(activityMetricsInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !s.changed.Swap(false) {
+				continue
+			}
+			counters, err := s.GetCounters(ctx, time.Now())
+			if err != nil {
+				log.Warn().Err(err).Msg("Failed to read activity counters")
+				continue
+			}
+			notification := &interfaces.Notification{
+				ID:        fmt.Sprintf("metrics_%d", time.Now().UnixNano()),
+				Type:      "metrics",
+				Title:     "Activity Counters",
+				Message:   fmt.Sprintf("%d transfers completed today", counters.TransfersCompleted),
+				Severity:  "info",
+				Timestamp: time.Now(),
+				Data:      map[string]interface{}{"activity": counters},
+			}
+			if err := s.notifications.Broadcast(ctx, notification); err != nil {
+				log.Warn().Err(err).Msg("Failed to broadcast activity counters")
+			}
+		}
+	}
+}
+
+// activityNotification maps a domain event to the notification shown on the
+// live feeds and the counter increments it causes. Events of other types
+// return a nil notification.
+func activityNotification(event events.Event) (*interfaces.Notification, map[string]int64, error) {
+	notification := &interfaces.Notification{
+		ID:        "event_" + event.ID,
+		Severity:  "info",
+		Timestamp: event.OccurredAt,
+	}
+
+	switch event.Type {
+	case events.TypeTransferCompleted, events.TypeTransferFailed:
+		var payload events.TransferPayload
+		if err := event.Decode(&payload); err != nil {
+			return nil, nil, err
+		}
+
+		notification.Type = "transaction"
+		notification.Data = map[string]interface{}{
+			"transfer_id":     payload.TransferID,
+			"from_account_id": payload.FromAccountID,
+			"to_account_id":   payload.ToAccountID,
+			"amount":          payload.Amount,
+			"currency":        payload.Currency,
+		}
+		if payload.UserID != 0 {
+			notification.Data["user_id"] = strconv.Itoa(int(payload.UserID))
+		}
+		if payload.Fee != "" {
+			notification.Data["fee"] = payload.Fee
+		}
+		if payload.BatchID != 0 {
+			notification.Data["batch_id"] = payload.BatchID
+		}
+
+		if event.Type == events.TypeTransferFailed {
+			notification.Title = "Transfer Failed"
+			notification.Message = fmt.Sprintf("Transfer of %s from account %d to account %d failed: %s",
+				payload.Amount, payload.FromAccountID, payload.ToAccountID, payload.Reason)
+			notification.Severity = "warning"
+			notification.Data["status"] = "failed"
+			notification.Data["reason"] = payload.Reason
+			return notification, map[string]int64{activityTransfersFailed: 1}, nil
+		}
+
+		notification.Title = "Transfer Completed"
+		notification.Message = fmt.Sprintf("Transfer of %s %s from account %d to account %d",
+			payload.Amount, payload.Currency, payload.FromAccountID, payload.ToAccountID)
+		notification.Data["status"] = "completed"
+
+		deltas := map[string]int64{activityTransfersCompleted: 1}
+		if amount, err := decimal.NewFromString(payload.Amount); err == nil && payload.Currency != "" {
+			deltas[activityVolumePrefix+payload.Currency] = amount.Shift(2).IntPart()
+		}
+		return notification, deltas, nil
+
+	case events.TypeAccountCreated, events.TypeAccountDeleted:
+		var payload events.AccountPayload
+		if err := event.Decode(&payload); err != nil {
+			return nil, nil, err
+		}
+
+		action, counter := "account_created", activityAccountsCreated
+		if event.Type == events.TypeAccountDeleted {
+			action, counter = "account_deleted", activityAccountsDeleted
+		}
+		userID := strconv.Itoa(int(payload.UserID))
+		notification.Type = "user_activity"
+		notification.Title = "User Activity"
+		notification.Message = fmt.Sprintf("User %s: %s", userID, action)
+		notification.Data = map[string]interface{}{
+			"user_id":    userID,
+			"action":     action,
+			"account_id": payload.AccountID,
+			"currency":   payload.Currency,
+		}
+		return notification, map[string]int64{counter: 1}, nil
+
+	case events.TypeUserRegistered:
+		var payload events.UserPayload
+		if err := event.Decode(&payload); err != nil {
+			return nil, nil, err
+		}
+
+		userID := strconv.Itoa(int(payload.UserID))
+		notification.Type = "user_activity"
+		notification.Title = "User Activity"
+		notification.Message = fmt.Sprintf("User %s: user_registered", userID)
+		notification.Data = map[string]interface{}{
+			"user_id": userID,
+			"action":  "user_registered",
+		}
+		return notification, map[string]int64{activityUsersRegistered: 1}, nil
+	}
+
+	return nil, nil, nil
+}
+
+// activityCounters converts stored counter fields into the counters of a day
+func activityCounters(day string, values map[string]int64) *interfaces.ActivityCounters {
+	counters := &interfaces.ActivityCounters{
+		Date:               day,
+		TransfersCompleted: values[activityTransfersCompleted],
+		TransfersFailed:    values[activityTransfersFailed],
+		TransferVolume:     make(map[string]string),
+		AccountsCreated:    values[activityAccountsCreated],
+		AccountsDeleted:    values[activityAccountsDeleted],
+		UsersRegistered:    values[activityUsersRegistered],
+	}
+	for field, value := range values {
+		if currency, ok := strings.CutPrefix(field, activityVolumePrefix); ok {
+			counters.TransferVolume[currency] = decimal.New(value, -2).StringFixed(2)
+		}
+	}
+	return counters
+}
+
+// memoryActivityCounters keeps counters in process when Redis is unavailable
+type memoryActivityCounters struct {
+	mu   sync.Mutex
+	days map[string]map[string]int64
+}
+
+func newMemoryActivityCounters() *memoryActivityCounters {
+	return &memoryActivityCounters{days: make(map[string]map[string]int64)}
+}
+
+// Increment adds deltas to a day's counters and drops days past retention
+func (m *memoryActivityCounters) Increment(ctx context.Context, day string, deltas map[string]int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	values, ok := m.days[day]
+	if !ok {
+		values = make(map[string]int64)
+		m.days[day] = values
+
+		cutoff := time.Now().UTC().Add(-activityCountersRetention).Format(activityDayLayout)
+		for stored := range m.days {
+			if stored < cutoff {
+				delete(m.days, stored)
+			}
+		}
+	}
+	for field, delta := range deltas {
+		values[field] += delta
+	}
+	return nil
+}
+
+// Get returns a copy of a day's counters
+func (m *memoryActivityCounters) Get(ctx context.Context, day string) (map[string]int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	values := make(map[string]int64, len(m.days[day]))
+	for field, value := range m.days[day] {
+		values[field] = value
+	}
+	return values, nil
+}
+
+// redisActivityCounters keeps each day's counters in a Redis hash shared by
+// every admin API replica
+type redisActivityCounters struct {
+	client *redis.Client
+}
+
+// Increment adds deltas to a day's hash in one transaction
+func (r *redisActivityCounters) Increment(ctx context.Context, day string, deltas map[string]int64) error {
+	key := activityCountersKeyPrefix + day
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for field, delta := range deltas {
+			pipe.HIncrBy(ctx, key, field, delta)
+		}
+		pipe.Expire(ctx, key, activityCountersRetention)
+		return nil
+	})
+	return err
+}
+
+// Get returns a day's counters
+func (r *redisActivityCounters) Get(ctx context.Context, day string) (map[string]int64, error) {
+	stored, err := r.client.HGetAll(ctx, activityCountersKeyPrefix+day).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]int64, len(stored))
+	for field, value := range stored {
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			continue
+		}
+		values[field] = n
+	}
+	return values, nil
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
+	"github.com/phantom-sage/bankgo/internal/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func publishTestEvent(t *testing.T, bus events.Bus, eventType string, payload interface{}) {
+	t.Helper()
+	event, err := events.New(eventType, payload)
+	require.NoError(t, err)
+	require.NoError(t, bus.Publish(context.Background(), event))
+}
+
+func TestActivityFeed_BroadcastsAndCountsEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	notificationBus := NewMemoryNotificationBus(10)
+	var mu sync.Mutex
+	var received []*interfaces.Notification
+	require.NoError(t, notificationBus.Subscribe(ctx, func(notification *interfaces.Notification) {
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, notification)
+	}))
+
+	eventBus := events.NewMemoryBus()
+	feed := NewActivityFeedService(eventBus, NewNotificationServiceWithBus(notificationBus), nil)
+	require.NoError(t, feed.Start(ctx))
+
+	publishTestEvent(t, eventBus, events.TypeTransferCompleted, events.TransferPayload{
+		TransferID: 1, FromAccountID: 10, ToAccountID: 20, UserID: 5, Amount: "100.50", Fee: "1.00", Currency: "USD",
+	})
+	publishTestEvent(t, eventBus, events.TypeTransferCompleted, events.TransferPayload{
+		TransferID: 2, FromAccountID: 10, ToAccountID: 20, UserID: 5, Amount: "0.25", Currency: "USD",
+	})
+	publishTestEvent(t, eventBus, events.TypeTransferFailed, events.TransferPayload{
+		FromAccountID: 10, ToAccountID: 20, Amount: "5000.00", Reason: "limit_exceeded",
+	})
+	publishTestEvent(t, eventBus, events.TypeAccountCreated, events.AccountPayload{AccountID: 30, UserID: 6, Currency: "EUR"})
+	publishTestEvent(t, eventBus, events.TypeUserRegistered, events.UserPayload{UserID: 6})
+
+	counters, err := feed.GetCounters(context.Background(), time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, time.Now().UTC().Format("2006-01-02"), counters.Date)
+	assert.Equal(t, int64(2), counters.TransfersCompleted)
+	assert.Equal(t, int64(1), counters.TransfersFailed)
+	assert.Equal(t, map[string]string{"USD": "100.75"}, counters.TransferVolume)
+	assert.Equal(t, int64(1), counters.AccountsCreated)
+	assert.Equal(t, int64(0), counters.AccountsDeleted)
+	assert.Equal(t, int64(1), counters.UsersRegistered)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, received, 5)
+
+	completed := received[0]
+	assert.Equal(t, "transaction", completed.Type)
+	assert.Equal(t, "completed", completed.Data["status"])
+	assert.Equal(t, "USD", completed.Data["currency"])
+	assert.Equal(t, "5", completed.Data["user_id"])
+
+	failed := received[2]
+	assert.Equal(t, "warning", failed.Severity)
+	assert.Equal(t, "limit_exceeded", failed.Data["reason"])
+
+	assert.Equal(t, "user_activity", received[3].Type)
+	assert.Equal(t, "account_created", received[3].Data["action"])
+	assert.Equal(t, "user_registered", received[4].Data["action"])
+}
+
+func TestActivityNotification_MatchesTopicFilters(t *testing.T) {
+	event, err := events.New(events.TypeTransferCompleted, events.TransferPayload{
+		TransferID: 1, FromAccountID: 10, ToAccountID: 20, Amount: "250.00", Currency: "EUR",
+	})
+	require.NoError(t, err)
+
+	notification, _, err := activityNotification(event)
+	require.NoError(t, err)
+
+	assert.True(t, filterMatches(interfaces.TopicTransactions, interfaces.SubscriptionFilter{MinAmount: "100", Currency: "eur"}, notification))
+	assert.False(t, filterMatches(interfaces.TopicTransactions, interfaces.SubscriptionFilter{MinAmount: "500"}, notification))
+	assert.False(t, filterMatches(interfaces.TopicTransactions, interfaces.SubscriptionFilter{Currency: "USD"}, notification))
+
+	event, err = events.New(events.TypeAccountDeleted, events.AccountPayload{AccountID: 30, UserID: 6, Currency: "EUR"})
+	require.NoError(t, err)
+	notification, _, err = activityNotification(event)
+	require.NoError(t, err)
+
+	assert.True(t, filterMatches(interfaces.TopicUserActivity, interfaces.SubscriptionFilter{UserID: "6", Actions: []string{"account_deleted"}}, notification))
+	assert.False(t, filterMatches(interfaces.TopicUserActivity, interfaces.SubscriptionFilter{UserID: "7"}, notification))
+}
+
+func TestActivityNotification_IgnoresUnknownEvents(t *testing.T) {
+	notification, deltas, err := activityNotification(events.Event{Type: "loan.approved"})
+	assert.NoError(t, err)
+	assert.Nil(t, notification)
+	assert.Nil(t, deltas)
+}
@@ -0,0 +1,315 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
+	"github.com/rs/zerolog/log"
+)
+
+// TypeAlertDelivery is the asynq task type for a single alert channel delivery
+const TypeAlertDelivery = "alert:deliver"
+
+// TypeAlertDispatch is the asynq task type for an alert raised outside the
+// admin API, routed to channels by the admin API's dispatcher
+const TypeAlertDispatch = "alert:dispatch"
+
+// AlertDeliveryQueue is the asynq queue used for alert deliveries
+const AlertDeliveryQueue = "alerts"
+
+// alertDeliveryTimeout bounds a single delivery attempt
+const alertDeliveryTimeout = 30 * time.Second
+
+// alertDispatchMaxRetry is how often routing a queued alert is retried
+const alertDispatchMaxRetry = 5
+
+// AlertDeliveryPayload is the payload of an alert delivery task
+type AlertDeliveryPayload struct {
+	Channel string            `json:"channel"`
+	Alert   *interfaces.Alert `json:"alert"`
+}
+
+// AlertDispatcherImpl routes alerts to notification channels by severity
+type AlertDispatcherImpl struct {
+	channels map[string]interfaces.NotificationChannel
+	routes   map[string][]string
+	client   *asynq.Client
+	maxRetry int
+}
+
+// NewAlertDispatcher creates a new alert dispatcher.
+// routes maps a severity to the names of the channels it is delivered to.
+// When client is nil deliveries are sent inline without retries.
+func NewAlertDispatcher(channels []interfaces.NotificationChannel, routes map[string][]string, client *asynq.Client, maxRetry int) *AlertDispatcherImpl {
+	channelMap := make(map[string]interfaces.NotificationChannel, len(channels))
+	for _, channel := range channels {
+		channelMap[channel.Name()] = channel
+	}
+
+	// Drop routes that point at channels which are not configured
+	routeMap := make(map[string][]string, len(routes))
+	for severity, names := range routes {
+		for _, name := range names {
+			if _, ok := channelMap[name]; !ok {
+				log.Warn().
+					Str("severity", severity).
+					Str("channel", name).
+					Msg("Alert route references unconfigured channel, skipping")
+				continue
+			}
+			routeMap[severity] = append(routeMap[severity], name)
+		}
+	}
+
+	return &AlertDispatcherImpl{
+		channels: channelMap,
+		routes:   routeMap,
+		client:   client,
+		maxRetry: maxRetry,
+	}
+}
+
+// Dispatch delivers an alert to every channel routed for its severity
+func (d *AlertDispatcherImpl) Dispatch(ctx context.Context, alert *interfaces.Alert) error {
+	if alert == nil {
+		return fmt.Errorf("alert cannot be nil")
+	}
+
+	var errs []error
+	for _, name := range d.routes[alert.Severity] {
+		if err := d.enqueue(ctx, name, alert); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to dispatch alert %s: %v", alert.ID, errs)
+	}
+
+	return nil
+}
+
+// DispatchTo delivers an alert to the named channels regardless of severity routing
+func (d *AlertDispatcherImpl) DispatchTo(ctx context.Context, alert *interfaces.Alert, channelNames []string) error {
+	if alert == nil {
+		return fmt.Errorf("alert cannot be nil")
+	}
+
+	var errs []error
+	for _, name := range channelNames {
+		if _, ok := d.channels[name]; !ok {
+			errs = append(errs, fmt.Errorf("channel %s not found", name))
+			continue
+		}
+		if err := d.enqueue(ctx, name, alert); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to dispatch alert %s: %v", alert.ID, errs)
+	}
+
+	return nil
+}
+
+// enqueue schedules a delivery through asynq, falling back to an inline send
+func (d *AlertDispatcherImpl) enqueue(ctx context.Context, channelName string, alert *interfaces.Alert) error {
+	if d.client != nil {
+		payload, err := json.Marshal(AlertDeliveryPayload{Channel: channelName, Alert: alert})
+		if err != nil {
+			return fmt.Errorf("failed to marshal delivery payload: %w", err)
+		}
+
+		task := asynq.NewTask(TypeAlertDelivery, payload)
+		_, err = d.client.EnqueueContext(ctx, task,
+			asynq.Queue(AlertDeliveryQueue),
+			asynq.MaxRetry(d.maxRetry),
+			asynq.Timeout(alertDeliveryTimeout),
+		)
+		if err == nil {
+			return nil
+		}
+
+		log.Warn().
+			Err(err).
+			Str("channel", channelName).
+			Str("alert_id", alert.ID).
+			Msg("Failed to enqueue alert delivery, sending inline")
+	}
+
+	return d.send(ctx, channelName, alert)
+}
+
+// send delivers an alert through a named channel immediately
+func (d *AlertDispatcherImpl) send(ctx context.Context, channelName string, alert *interfaces.Alert) error {
+	channel, ok := d.channels[channelName]
+	if !ok {
+		return fmt.Errorf("channel %s not found", channelName)
+	}
+
+	if err := channel.Send(ctx, alert); err != nil {
+		return err
+	}
+
+	log.Info().
+		Str("channel", channelName).
+		Str("alert_id", alert.ID).
+		Msg("Alert delivered")
+
+	return nil
+}
+
+// HandleDeliveryTask processes an alert delivery task; returned errors trigger asynq retries
+func (d *AlertDispatcherImpl) HandleDeliveryTask(ctx context.Context, task *asynq.Task) error {
+	var payload AlertDeliveryPayload
+	if err := json.Unmarshal(task.Payload(), &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal delivery payload: %v: %w", err, asynq.SkipRetry)
+	}
+
+	if _, ok := d.channels[payload.Channel]; !ok {
+		return fmt.Errorf("channel %s not found: %w", payload.Channel, asynq.SkipRetry)
+	}
+
+	if err := d.send(ctx, payload.Channel, payload.Alert); err != nil {
+		retryCount, _ := asynq.GetRetryCount(ctx)
+		log.Warn().
+			Err(err).
+			Str("channel", payload.Channel).
+			Int("retry_count", retryCount).
+			Msg("Alert delivery failed")
+		return err
+	}
+
+	return nil
+}
+
+// HandleDispatchTask routes an alert raised outside the admin API to the
+// channels configured for its severity
+func (d *AlertDispatcherImpl) HandleDispatchTask(ctx context.Context, task *asynq.Task) error {
+	var alert interfaces.Alert
+	if err := json.Unmarshal(task.Payload(), &alert); err != nil {
+		return fmt.Errorf("failed to unmarshal alert: %v: %w", err, asynq.SkipRetry)
+	}
+
+	return d.Dispatch(ctx, &alert)
+}
+
+// RegisterHandlers registers the delivery and dispatch task handlers on an asynq mux
+func (d *AlertDispatcherImpl) RegisterHandlers(mux *asynq.ServeMux) {
+	mux.HandleFunc(TypeAlertDelivery, d.HandleDeliveryTask)
+	mux.HandleFunc(TypeAlertDispatch, d.HandleDispatchTask)
+}
+
+// AlertTaskEnqueuer enqueues asynq tasks; *asynq.Client implements it
+type AlertTaskEnqueuer interface {
+	EnqueueContext(ctx context.Context, task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error)
+}
+
+// QueuedAlertRouter hands alerts to the admin API's alert worker through the
+// alert delivery queue. It lets processes without channel configuration, such
+// as the banking API, reach the channels the admin API routes alerts to; both
+// must use the same Redis database.
+type QueuedAlertRouter struct {
+	client AlertTaskEnqueuer
+}
+
+// NewQueuedAlertRouter creates an alert router that enqueues alerts on client
+func NewQueuedAlertRouter(client AlertTaskEnqueuer) *QueuedAlertRouter {
+	return &QueuedAlertRouter{client: client}
+}
+
+// Dispatch enqueues an alert for the admin API to route by severity
+func (r *QueuedAlertRouter) Dispatch(ctx context.Context, alert *interfaces.Alert) error {
+	if alert == nil {
+		return fmt.Errorf("alert cannot be nil")
+	}
+
+	payload, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("failed to marshal alert: %w", err)
+	}
+
+	// The alert ID keeps a retried enqueue from routing the alert twice
+	_, err = r.client.EnqueueContext(ctx, asynq.NewTask(TypeAlertDispatch, payload),
+		asynq.Queue(AlertDeliveryQueue),
+		asynq.MaxRetry(alertDispatchMaxRetry),
+		asynq.Timeout(alertDeliveryTimeout),
+		asynq.TaskID(TypeAlertDispatch+":"+alert.ID),
+	)
+	if err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+		return fmt.Errorf("failed to enqueue alert %s: %w", alert.ID, err)
+	}
+
+	return nil
+}
+
+// TestSend sends a synthetic alert through a single channel
+func (d *AlertDispatcherImpl) TestSend(ctx context.Context, channelName string) (*interfaces.ChannelTestResult, error) {
+	channel, ok := d.channels[channelName]
+	if !ok {
+		return nil, fmt.Errorf("channel %s not found", channelName)
+	}
+
+	now := time.Now()
+	alert := &interfaces.Alert{
+		ID:        fmt.Sprintf("test-%d", now.UnixNano()),
+		Severity:  "info",
+		Title:     "Test notification",
+		Message:   fmt.Sprintf("This is a test notification for channel %s", channelName),
+		Source:    "admin_test_send",
+		Timestamp: now,
+		Metadata:  map[string]interface{}{"test": true},
+	}
+
+	start := time.Now()
+	err := channel.Send(ctx, alert)
+
+	result := &interfaces.ChannelTestResult{
+		Channel:  channelName,
+		Type:     channel.Type(),
+		Success:  err == nil,
+		Duration: time.Since(start),
+		SentAt:   now,
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+
+	return result, nil
+}
+
+// ListChannels returns configured channels with their routed severities
+func (d *AlertDispatcherImpl) ListChannels() []interfaces.ChannelInfo {
+	infos := make([]interfaces.ChannelInfo, 0, len(d.channels))
+	for name, channel := range d.channels {
+		severities := []string{}
+		for severity, names := range d.routes {
+			for _, routed := range names {
+				if routed == name {
+					severities = append(severities, severity)
+					break
+				}
+			}
+		}
+		sort.Strings(severities)
+
+		infos = append(infos, interfaces.ChannelInfo{
+			Name:       name,
+			Type:       channel.Type(),
+			Severities: severities,
+		})
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Name < infos[j].Name
+	})
+
+	return infos
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
+	"github.com/phantom-sage/bankgo/internal/database/queries"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingChannel is a NotificationChannel that records delivered alerts
+type recordingChannel struct {
+	name string
+	err  error
+	sent []*interfaces.Alert
+}
+
+func (c *recordingChannel) Name() string { return c.name }
+
+func (c *recordingChannel) Type() string { return "recording" }
+
+func (c *recordingChannel) Send(ctx context.Context, alert *interfaces.Alert) error {
+	c.sent = append(c.sent, alert)
+	return c.err
+}
+
+func TestAlertDispatcher_RoutesBySeverity(t *testing.T) {
+	email := &recordingChannel{name: "email"}
+	slack := &recordingChannel{name: "slack"}
+
+	dispatcher := NewAlertDispatcher(
+		[]interfaces.NotificationChannel{email, slack},
+		map[string][]string{
+			"critical": {"email", "slack"},
+			"warning":  {"slack"},
+		},
+		nil,
+		3,
+	)
+
+	require.NoError(t, dispatcher.Dispatch(context.Background(), newTestAlert("critical")))
+	require.NoError(t, dispatcher.Dispatch(context.Background(), newTestAlert("warning")))
+	require.NoError(t, dispatcher.Dispatch(context.Background(), newTestAlert("info")))
+
+	assert.Len(t, email.sent, 1)
+	assert.Len(t, slack.sent, 2)
+}
+
+func TestAlertDispatcher_SkipsUnconfiguredRoutes(t *testing.T) {
+	slack := &recordingChannel{name: "slack"}
+
+	dispatcher := NewAlertDispatcher(
+		[]interfaces.NotificationChannel{slack},
+		map[string][]string{"critical": {"email", "slack"}},
+		nil,
+		3,
+	)
+
+	require.NoError(t, dispatcher.Dispatch(context.Background(), newTestAlert("critical")))
+	assert.Len(t, slack.sent, 1)
+
+	channels := dispatcher.ListChannels()
+	require.Len(t, channels, 1)
+	assert.Equal(t, "slack", channels[0].Name)
+	assert.Equal(t, []string{"critical"}, channels[0].Severities)
+}
+
+func TestAlertDispatcher_DispatchReportsChannelErrors(t *testing.T) {
+	failing := &recordingChannel{name: "webhook", err: errors.New("connection refused")}
+
+	dispatcher := NewAlertDispatcher(
+		[]interfaces.NotificationChannel{failing},
+		map[string][]string{"critical": {"webhook"}},
+		nil,
+		3,
+	)
+
+	err := dispatcher.Dispatch(context.Background(), newTestAlert("critical"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "connection refused")
+}
+
+func TestAlertDispatcher_TestSend(t *testing.T) {
+	slack := &recordingChannel{name: "slack"}
+	failing := &recordingChannel{name: "webhook", err: errors.New("timeout")}
+
+	dispatcher := NewAlertDispatcher([]interfaces.NotificationChannel{slack, failing}, nil, nil, 3)
+
+	result, err := dispatcher.TestSend(context.Background(), "slack")
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, "slack", result.Channel)
+	require.Len(t, slack.sent, 1)
+	assert.Equal(t, "info", slack.sent[0].Severity)
+
+	result, err = dispatcher.TestSend(context.Background(), "webhook")
+	require.NoError(t, err)
+	assert.False(t, result.Success)
+	assert.Equal(t, "timeout", result.Error)
+
+	_, err = dispatcher.TestSend(context.Background(), "missing")
+	assert.Error(t, err)
+}
+
+// recordingEnqueuer is an AlertTaskEnqueuer that records enqueued tasks
+type recordingEnqueuer struct {
+	tasks []*asynq.Task
+}
+
+func (e *recordingEnqueuer) EnqueueContext(ctx context.Context, task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error) {
+	e.tasks = append(e.tasks, task)
+	return &asynq.TaskInfo{Queue: AlertDeliveryQueue}, nil
+}
+
+// alertRowDB is a DBTX whose CreateAlert returns the inserted alert
+type alertRowDB struct{}
+
+func (alertRowDB) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, nil
+}
+
+func (alertRowDB) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (alertRowDB) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	return alertRow{args: args}
+}
+
+// alertRow scans the severity, title, message, source and timestamp inserted
+type alertRow struct {
+	args []interface{}
+}
+
+func (r alertRow) Scan(dest ...interface{}) error {
+	*dest[0].(*pgtype.UUID) = pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	for i := 0; i < 4; i++ {
+		*dest[i+1].(*string) = r.args[i].(string)
+	}
+	*dest[5].(*pgtype.Timestamptz) = r.args[4].(pgtype.Timestamptz)
+	return nil
+}
+
+func TestAlertService_DispatchesCriticalAnomalyAlert(t *testing.T) {
+	email := &recordingChannel{name: "email"}
+	dispatcher := NewAlertDispatcher(
+		[]interfaces.NotificationChannel{email},
+		map[string][]string{"critical": {"email"}},
+		nil,
+		3,
+	)
+
+	// The banking API queues alerts for the admin API's alert worker
+	enqueuer := &recordingEnqueuer{}
+	alertService := &AlertServiceImpl{
+		queries:             queries.New(alertRowDB{}),
+		notificationService: NewNotificationService(),
+		dispatcher:          NewQueuedAlertRouter(enqueuer),
+	}
+	generator := NewAlertGeneratorService(alertService)
+
+	require.NoError(t, generator.TransactionAnomalyAlert(context.Background(), "1", "2", 5000, 1000, "large_amount"))
+	require.Len(t, enqueuer.tasks, 1)
+	assert.Equal(t, TypeAlertDispatch, enqueuer.tasks[0].Type())
+
+	// The admin API's worker routes it to the channels for its severity
+	require.NoError(t, dispatcher.HandleDispatchTask(context.Background(), enqueuer.tasks[0]))
+	require.Len(t, email.sent, 1)
+	assert.Equal(t, "critical", email.sent[0].Severity)
+	assert.Equal(t, "transaction_monitor", email.sent[0].Source)
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
+	"github.com/phantom-sage/bankgo/internal/alerts"
 	"github.com/rs/zerolog/log"
 )
 
@@ -15,6 +16,9 @@ type AlertGeneratorService struct {
 	alertService interfaces.AlertService
 }
 
+// The banking API raises its alerts through the generator
+var _ alerts.Publisher = (*AlertGeneratorService)(nil)
+
 // NewAlertGeneratorService creates a new alert generator service
 func NewAlertGeneratorService(alertService interfaces.AlertService) *AlertGeneratorService {
 	return &AlertGeneratorService{
@@ -275,6 +279,45 @@ func (s *AlertGeneratorService) SecurityAlert(ctx context.Context, eventType, de
 	return nil
 }
 
+// RateLimitAlert generates an alert when a client goes over an API rate limit.
+// Going over the login limit suggests password guessing, so it is critical.
+func (s *AlertGeneratorService) RateLimitAlert(ctx context.Context, policy, identifier, ipAddress string, limit int, window time.Duration) error {
+	severity := "warning"
+	if policy == "login" {
+		severity = "critical"
+	}
+
+	metadata := map[string]interface{}{
+		"policy":     policy,
+		"identifier": identifier,
+		"ip_address": ipAddress,
+		"limit":      limit,
+		"window":     window.String(),
+		"timestamp":  time.Now().Unix(),
+		"alert_type": "rate_limit",
+	}
+
+	_, err := s.alertService.CreateAlert(
+		ctx,
+		severity,
+		"Rate Limit Exceeded",
+		fmt.Sprintf("%s went over the %s rate limit of %d requests per %s from IP %s", identifier, policy, limit, window, ipAddress),
+		"rate_limiter",
+		metadata,
+	)
+
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("policy", policy).
+			Str("ip_address", ipAddress).
+			Msg("Failed to create rate limit alert")
+		return err
+	}
+
+	return nil
+}
+
 // PerformanceAlert generates an alert for performance degradation
 func (s *AlertGeneratorService) PerformanceAlert(ctx context.Context, component string, metric string, currentValue, threshold float64, unit string) error {
 	severity := "warning"
@@ -371,6 +371,38 @@ func TestAlertGeneratorService_SecurityAlert(t *testing.T) {
 	mockAlertService.AssertExpectations(t)
 }
 
+func TestAlertGeneratorService_RateLimitAlert(t *testing.T) {
+	mockAlertService := &MockAlertService{}
+	generator := NewAlertGeneratorService(mockAlertService)
+	ctx := context.Background()
+
+	tests := []struct {
+		name             string
+		policy           string
+		expectedSeverity string
+	}{
+		{name: "Login limit", policy: "login", expectedSeverity: "critical"},
+		{name: "Transfer limit", policy: "transfers", expectedSeverity: "warning"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAlertService.On("CreateAlert",
+				ctx,
+				tt.expectedSeverity,
+				"Rate Limit Exceeded",
+				mock.AnythingOfType("string"),
+				"rate_limiter",
+				mock.AnythingOfType("map[string]interface {}")).Return(&interfaces.Alert{ID: "test-alert-id"}, nil).Once()
+
+			err := generator.RateLimitAlert(ctx, tt.policy, "ip:10.0.0.1", "10.0.0.1", 5, time.Minute)
+			require.NoError(t, err)
+		})
+	}
+
+	mockAlertService.AssertExpectations(t)
+}
+
 func TestAlertGeneratorService_PerformanceAlert(t *testing.T) {
 	mockAlertService := &MockAlertService{}
 	generator := NewAlertGeneratorService(mockAlertService)
@@ -0,0 +1,338 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/phantom-sage/bankgo/internal/admin/config"
+	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
+	"github.com/rs/zerolog/log"
+)
+
+// autoResolvedBy identifies the lifecycle worker in resolved_by
+const autoResolvedBy = "system:auto-resolve"
+
+// metricAlertSource is the source used by metric threshold alerts
+const metricAlertSource = "system_monitor"
+
+// severityRank orders severities so escalation never lowers an alert
+var severityRank = map[string]int{
+	"info":     1,
+	"warning":  2,
+	"critical": 3,
+}
+
+// AlertLifecycleWorker escalates stale alerts, auto-resolves recovered metric
+// alerts and enforces alert retention in the background
+type AlertLifecycleWorker struct {
+	lifecycle     *AlertLifecycleService
+	alertService  interfaces.AlertService
+	dispatcher    interfaces.AlertDispatcher
+	systemService interfaces.SystemMonitoringService
+	config        config.AlertLifecycleConfig
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewAlertLifecycleWorker creates a new alert lifecycle worker.
+// dispatcher and systemService are optional; without them escalation does not
+// notify channels and metric alerts are never auto-resolved.
+func NewAlertLifecycleWorker(alertService interfaces.AlertService, dispatcher interfaces.AlertDispatcher, systemService interfaces.SystemMonitoringService, cfg config.AlertLifecycleConfig) *AlertLifecycleWorker {
+	return &AlertLifecycleWorker{
+		lifecycle:     NewAlertLifecycleService(alertService),
+		alertService:  alertService,
+		dispatcher:    dispatcher,
+		systemService: systemService,
+		config:        cfg,
+	}
+}
+
+// Start runs the worker loops until Stop is called
+func (w *AlertLifecycleWorker) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+
+	w.wg.Add(2)
+	go w.runEvery(ctx, w.config.CheckInterval, func(ctx context.Context) {
+		if err := w.RunEscalation(ctx); err != nil {
+			log.Error().Err(err).Msg("Alert escalation run failed")
+		}
+		if err := w.RunAutoResolution(ctx); err != nil {
+			log.Error().Err(err).Msg("Alert auto-resolution run failed")
+		}
+	})
+	go w.runEvery(ctx, w.config.RetentionInterval, func(ctx context.Context) {
+		if err := w.RunRetention(ctx); err != nil {
+			log.Error().Err(err).Msg("Alert retention run failed")
+		}
+	})
+
+	log.Info().
+		Dur("check_interval", w.config.CheckInterval).
+		Dur("retention", w.config.Retention).
+		Int("escalation_tiers", len(w.config.EscalationTiers)).
+		Msg("Alert lifecycle worker started")
+}
+
+// Stop stops the worker loops and waits for in-flight runs to finish
+func (w *AlertLifecycleWorker) Stop() {
+	if w.cancel == nil {
+		return
+	}
+	w.cancel()
+	w.wg.Wait()
+}
+
+// runEvery invokes fn on every tick until the context is cancelled
+func (w *AlertLifecycleWorker) runEvery(ctx context.Context, interval time.Duration, fn func(ctx context.Context)) {
+	defer w.wg.Done()
+
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			runCtx, cancel := context.WithTimeout(ctx, interval)
+			fn(runCtx)
+			cancel()
+		}
+	}
+}
+
+// RunEscalation escalates unacknowledged alerts that have crossed a tier delay
+func (w *AlertLifecycleWorker) RunEscalation(ctx context.Context) error {
+	tiers := w.config.EscalationTiers
+	if len(tiers) == 0 {
+		return nil
+	}
+
+	candidates, err := w.lifecycle.GetAlertEscalationCandidates(ctx, tiers[0].After)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	escalated := 0
+	for i := range candidates {
+		alert := &candidates[i]
+		if alert.Acknowledged || alert.Resolved {
+			continue
+		}
+
+		// Find the highest tier this alert's age has reached
+		level := 0
+		for idx, tier := range tiers {
+			if now.Sub(alert.Timestamp) >= tier.After {
+				level = idx + 1
+			}
+		}
+
+		if level == 0 || level <= escalationLevel(alert) {
+			continue
+		}
+
+		if err := w.escalate(ctx, alert, level, tiers[level-1], now); err != nil {
+			log.Error().
+				Err(err).
+				Str("alert_id", alert.ID).
+				Int("escalation_level", level).
+				Msg("Failed to escalate alert")
+			continue
+		}
+		escalated++
+	}
+
+	if escalated > 0 {
+		log.Info().
+			Int("escalated_count", escalated).
+			Msg("Alert escalation run completed")
+	}
+
+	return nil
+}
+
+// escalate raises an alert to the given tier and notifies the tier's channels
+func (w *AlertLifecycleWorker) escalate(ctx context.Context, alert *interfaces.Alert, level int, tier config.EscalationTier, now time.Time) error {
+	severity := alert.Severity
+	if severityRank[tier.Severity] > severityRank[severity] {
+		severity = tier.Severity
+	}
+
+	updated, err := w.alertService.EscalateAlert(ctx, alert.ID, severity, map[string]interface{}{
+		"escalation_level": level,
+		"escalated_at":     now.UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return err
+	}
+
+	if w.dispatcher == nil {
+		return nil
+	}
+
+	if len(tier.Channels) > 0 {
+		err = w.dispatcher.DispatchTo(ctx, updated, tier.Channels)
+	} else {
+		err = w.dispatcher.Dispatch(ctx, updated)
+	}
+	if err != nil {
+		log.Warn().
+			Err(err).
+			Str("alert_id", alert.ID).
+			Msg("Failed to notify escalation tier")
+	}
+
+	return nil
+}
+
+// escalationLevel reads the current escalation level from alert metadata
+func escalationLevel(alert *interfaces.Alert) int {
+	if alert.Metadata == nil {
+		return 0
+	}
+
+	switch v := alert.Metadata["escalation_level"].(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	default:
+		return 0
+	}
+}
+
+// RunAutoResolution resolves metric alerts whose metric has stayed below its
+// threshold for the configured number of consecutive readings
+func (w *AlertLifecycleWorker) RunAutoResolution(ctx context.Context) error {
+	intervals := w.config.AutoResolveIntervals
+	if w.systemService == nil || intervals <= 0 {
+		return nil
+	}
+
+	alerts, err := w.alertService.GetAlertsBySource(ctx, metricAlertSource, 100)
+	if err != nil {
+		return fmt.Errorf("failed to get metric alerts: %w", err)
+	}
+	if len(alerts) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	metrics, err := w.systemService.GetMetrics(ctx, interfaces.TimeRange{
+		Start: now.Add(-time.Hour),
+		End:   now,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get system metrics: %w", err)
+	}
+	if len(metrics.DataPoints) < intervals {
+		return nil
+	}
+	recent := metrics.DataPoints[len(metrics.DataPoints)-intervals:]
+
+	resolved := 0
+	for _, alert := range alerts {
+		if alert.Resolved {
+			continue
+		}
+
+		metricType, threshold, ok := metricAlertThreshold(&alert)
+		if !ok {
+			continue
+		}
+
+		if !belowThreshold(recent, metricType, threshold) {
+			continue
+		}
+
+		notes := fmt.Sprintf("Auto-resolved: %s stayed below %.1f for %d consecutive readings", metricType, threshold, intervals)
+		if _, err := w.alertService.ResolveAlert(ctx, alert.ID, autoResolvedBy, notes); err != nil {
+			log.Error().
+				Err(err).
+				Str("alert_id", alert.ID).
+				Msg("Failed to auto-resolve alert")
+			continue
+		}
+		resolved++
+	}
+
+	if resolved > 0 {
+		log.Info().
+			Int("resolved_count", resolved).
+			Msg("Alert auto-resolution run completed")
+	}
+
+	return nil
+}
+
+// metricAlertThreshold extracts the metric type and threshold from a metric alert
+func metricAlertThreshold(alert *interfaces.Alert) (string, float64, bool) {
+	if alert.Metadata == nil {
+		return "", 0, false
+	}
+
+	metricType, _ := alert.Metadata["metric_type"].(string)
+	if metricType == "" {
+		resourceType, _ := alert.Metadata["resource_type"].(string)
+		metricType = strings.ToLower(resourceType)
+	}
+
+	threshold, ok := alert.Metadata["threshold"].(float64)
+	if metricType == "" || !ok {
+		return "", 0, false
+	}
+
+	return metricType, threshold, true
+}
+
+// belowThreshold reports whether every snapshot is below the threshold for the metric
+func belowThreshold(snapshots []interfaces.SystemMetricsSnapshot, metricType string, threshold float64) bool {
+	for _, snapshot := range snapshots {
+		var value float64
+		switch metricType {
+		case "cpu":
+			value = snapshot.CPUUsage
+		case "memory":
+			value = snapshot.MemoryUsage
+		case "api_response_time":
+			value = snapshot.APIResponseTime
+		default:
+			return false
+		}
+
+		if value >= threshold {
+			return false
+		}
+	}
+
+	return true
+}
+
+// RunRetention archives resolved alerts older than the retention period
+func (w *AlertLifecycleWorker) RunRetention(ctx context.Context) error {
+	if w.config.Retention <= 0 {
+		return nil
+	}
+
+	cutoff := time.Now().Add(-w.config.Retention)
+	if err := w.lifecycle.ArchiveOldAlerts(ctx, cutoff); err != nil {
+		return fmt.Errorf("failed to archive old alerts: %w", err)
+	}
+
+	log.Info().
+		Time("cutoff", cutoff).
+		Msg("Alert retention run completed")
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/phantom-sage/bankgo/internal/admin/config"
+	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// stubSystemService returns a fixed metrics history
+type stubSystemService struct {
+	dataPoints []interfaces.SystemMetricsSnapshot
+}
+
+func (s *stubSystemService) GetSystemHealth(ctx context.Context) (*interfaces.SystemHealth, error) {
+	return &interfaces.SystemHealth{}, nil
+}
+
+func (s *stubSystemService) GetMetrics(ctx context.Context, timeRange interfaces.TimeRange) (*interfaces.SystemMetrics, error) {
+	return &interfaces.SystemMetrics{TimeRange: timeRange, DataPoints: s.dataPoints}, nil
+}
+
+func (s *stubSystemService) GetAlerts(ctx context.Context, params interfaces.AlertParams) (*interfaces.PaginatedAlerts, error) {
+	return &interfaces.PaginatedAlerts{}, nil
+}
+
+func (s *stubSystemService) AcknowledgeAlert(ctx context.Context, alertID string) error {
+	return nil
+}
+
+func (s *stubSystemService) ResolveAlert(ctx context.Context, alertID string, notes string) error {
+	return nil
+}
+
+func testLifecycleConfig() config.AlertLifecycleConfig {
+	return config.AlertLifecycleConfig{
+		CheckInterval: time.Minute,
+		EscalationTiers: []config.EscalationTier{
+			{After: 15 * time.Minute, Severity: "warning", Channels: []string{"slack"}},
+			{After: 30 * time.Minute, Severity: "critical", Channels: []string{"email"}},
+		},
+		AutoResolveIntervals: 3,
+		Retention:            30 * 24 * time.Hour,
+		RetentionInterval:    24 * time.Hour,
+	}
+}
+
+func TestAlertLifecycleWorker_RunEscalation(t *testing.T) {
+	mockAlertService := &MockAlertService{}
+	slack := &recordingChannel{name: "slack"}
+	email := &recordingChannel{name: "email"}
+	dispatcher := NewAlertDispatcher([]interfaces.NotificationChannel{slack, email}, nil, nil, 0)
+	worker := NewAlertLifecycleWorker(mockAlertService, dispatcher, nil, testLifecycleConfig())
+	ctx := context.Background()
+
+	now := time.Now()
+	mockAlertService.On("ListAlerts", ctx, mock.Anything).Return(&interfaces.PaginatedAlerts{
+		Alerts: []interfaces.Alert{
+			// Reached the first tier
+			{ID: "alert-1", Severity: "info", Timestamp: now.Add(-20 * time.Minute)},
+			// Reached the second tier, already escalated once
+			{ID: "alert-2", Severity: "warning", Timestamp: now.Add(-45 * time.Minute), Metadata: map[string]interface{}{"escalation_level": float64(1)}},
+			// Already escalated to the highest tier it has reached
+			{ID: "alert-3", Severity: "warning", Timestamp: now.Add(-20 * time.Minute), Metadata: map[string]interface{}{"escalation_level": float64(1)}},
+			// Acknowledged critical alerts are not escalated
+			{ID: "alert-4", Severity: "critical", Acknowledged: true, Timestamp: now.Add(-time.Hour)},
+		},
+	}, nil)
+
+	mockAlertService.On("EscalateAlert", ctx, "alert-1", "warning", mock.MatchedBy(func(m map[string]interface{}) bool {
+		return m["escalation_level"] == 1
+	})).Return(&interfaces.Alert{ID: "alert-1", Severity: "warning"}, nil)
+	mockAlertService.On("EscalateAlert", ctx, "alert-2", "critical", mock.MatchedBy(func(m map[string]interface{}) bool {
+		return m["escalation_level"] == 2
+	})).Return(&interfaces.Alert{ID: "alert-2", Severity: "critical"}, nil)
+
+	err := worker.RunEscalation(ctx)
+	require.NoError(t, err)
+
+	mockAlertService.AssertExpectations(t)
+	mockAlertService.AssertNumberOfCalls(t, "EscalateAlert", 2)
+	require.Len(t, slack.sent, 1)
+	assert.Equal(t, "alert-1", slack.sent[0].ID)
+	require.Len(t, email.sent, 1)
+	assert.Equal(t, "alert-2", email.sent[0].ID)
+}
+
+func TestAlertLifecycleWorker_EscalationNeverLowersSeverity(t *testing.T) {
+	mockAlertService := &MockAlertService{}
+	worker := NewAlertLifecycleWorker(mockAlertService, nil, nil, testLifecycleConfig())
+	ctx := context.Background()
+
+	mockAlertService.On("ListAlerts", ctx, mock.Anything).Return(&interfaces.PaginatedAlerts{
+		Alerts: []interfaces.Alert{
+			{ID: "alert-1", Severity: "critical", Timestamp: time.Now().Add(-20 * time.Minute)},
+		},
+	}, nil)
+	mockAlertService.On("EscalateAlert", ctx, "alert-1", "critical", mock.Anything).
+		Return(&interfaces.Alert{ID: "alert-1", Severity: "critical"}, nil)
+
+	require.NoError(t, worker.RunEscalation(ctx))
+	mockAlertService.AssertExpectations(t)
+}
+
+func TestAlertLifecycleWorker_RunAutoResolution(t *testing.T) {
+	mockAlertService := &MockAlertService{}
+	systemService := &stubSystemService{
+		dataPoints: []interfaces.SystemMetricsSnapshot{
+			{CPUUsage: 95, MemoryUsage: 95},
+			{CPUUsage: 50, MemoryUsage: 80},
+			{CPUUsage: 45, MemoryUsage: 60},
+			{CPUUsage: 40, MemoryUsage: 85},
+		},
+	}
+	worker := NewAlertLifecycleWorker(mockAlertService, nil, systemService, testLifecycleConfig())
+	ctx := context.Background()
+
+	mockAlertService.On("GetAlertsBySource", ctx, "system_monitor", 100).Return([]interfaces.Alert{
+		{ID: "cpu-alert", Metadata: map[string]interface{}{"metric_type": "cpu", "threshold": 70.0}},
+		{ID: "memory-alert", Metadata: map[string]interface{}{"metric_type": "memory", "threshold": 70.0}},
+		{ID: "untyped-alert", Metadata: map[string]interface{}{"note": "no metric"}},
+	}, nil)
+	mockAlertService.On("ResolveAlert", ctx, "cpu-alert", "system:auto-resolve", mock.AnythingOfType("string")).
+		Return(&interfaces.Alert{ID: "cpu-alert", Resolved: true}, nil)
+
+	err := worker.RunAutoResolution(ctx)
+	require.NoError(t, err)
+
+	mockAlertService.AssertExpectations(t)
+	mockAlertService.AssertNumberOfCalls(t, "ResolveAlert", 1)
+}
+
+func TestAlertLifecycleWorker_AutoResolutionNeedsEnoughReadings(t *testing.T) {
+	mockAlertService := &MockAlertService{}
+	systemService := &stubSystemService{
+		dataPoints: []interfaces.SystemMetricsSnapshot{{CPUUsage: 10}},
+	}
+	worker := NewAlertLifecycleWorker(mockAlertService, nil, systemService, testLifecycleConfig())
+	ctx := context.Background()
+
+	mockAlertService.On("GetAlertsBySource", ctx, "system_monitor", 100).Return([]interfaces.Alert{
+		{ID: "cpu-alert", Metadata: map[string]interface{}{"metric_type": "cpu", "threshold": 70.0}},
+	}, nil)
+
+	require.NoError(t, worker.RunAutoResolution(ctx))
+	mockAlertService.AssertNotCalled(t, "ResolveAlert", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAlertLifecycleWorker_RunRetention(t *testing.T) {
+	mockAlertService := &MockAlertService{}
+	worker := NewAlertLifecycleWorker(mockAlertService, nil, nil, testLifecycleConfig())
+	ctx := context.Background()
+
+	mockAlertService.On("CleanupOldResolvedAlerts", ctx, mock.MatchedBy(func(cutoff time.Time) bool {
+		expected := time.Now().Add(-30 * 24 * time.Hour)
+		return cutoff.Sub(expected).Abs() < time.Minute
+	})).Return(nil)
+
+	require.NoError(t, worker.RunRetention(ctx))
+	mockAlertService.AssertExpectations(t)
+}
+
+func TestAlertLifecycleWorker_StartStop(t *testing.T) {
+	cfg := testLifecycleConfig()
+	cfg.CheckInterval = time.Hour
+	worker := NewAlertLifecycleWorker(&MockAlertService{}, nil, nil, cfg)
+
+	worker.Start()
+	worker.Stop()
+}
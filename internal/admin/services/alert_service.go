@@ -19,14 +19,22 @@ type AlertServiceImpl struct {
 	db      *pgxpool.Pool
 	queries *queries.Queries
 	notificationService interfaces.NotificationService
+	dispatcher          interfaces.AlertRouter
 }
 
 // NewAlertService creates a new alert service
 func NewAlertService(db *pgxpool.Pool, notificationService interfaces.NotificationService) interfaces.AlertService {
+	return NewAlertServiceWithDispatcher(db, notificationService, nil)
+}
+
+// NewAlertServiceWithDispatcher creates a new alert service that also delivers
+// new alerts to outbound notification channels
+func NewAlertServiceWithDispatcher(db *pgxpool.Pool, notificationService interfaces.NotificationService, dispatcher interfaces.AlertRouter) interfaces.AlertService {
 	return &AlertServiceImpl{
 		db:      db,
 		queries: queries.New(db),
 		notificationService: notificationService,
+		dispatcher:          dispatcher,
 	}
 }
 
@@ -87,6 +95,16 @@ func (s *AlertServiceImpl) CreateAlert(ctx context.Context, severity, title, mes
 			Msg("Failed to broadcast alert notification")
 	}
 
+	// Deliver to outbound channels routed for this severity
+	if s.dispatcher != nil {
+		if err := s.dispatcher.Dispatch(ctx, alert); err != nil {
+			log.Warn().
+				Err(err).
+				Str("alert_id", alert.ID).
+				Msg("Failed to dispatch alert to notification channels")
+		}
+	}
+
 	log.Info().
 		Str("alert_id", alert.ID).
 		Str("severity", severity).
@@ -370,6 +388,68 @@ func (s *AlertServiceImpl) ResolveAlert(ctx context.Context, alertID, resolvedBy
 	return alert, nil
 }
 
+// EscalateAlert raises an unresolved alert's severity and merges escalation metadata
+func (s *AlertServiceImpl) EscalateAlert(ctx context.Context, alertID, severity string, metadata map[string]interface{}) (*interfaces.Alert, error) {
+	if !isValidSeverity(severity) {
+		return nil, fmt.Errorf("invalid severity: %s. Must be one of: critical, warning, info", severity)
+	}
+
+	alertUUID, err := uuid.Parse(alertID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid alert ID format: %w", err)
+	}
+
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+	}
+	metadataBytes, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+
+	params := queries.EscalateAlertParams{
+		ID:       pgtype.UUID{Bytes: alertUUID, Valid: true},
+		Severity: severity,
+		Column3:  metadataBytes,
+	}
+
+	dbAlert, err := s.queries.EscalateAlert(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to escalate alert: %w", err)
+	}
+
+	alert := s.convertDBAlertToInterface(dbAlert)
+
+	// Broadcast escalation notification
+	notification := &interfaces.Notification{
+		ID:        fmt.Sprintf("alert_escalate_%s_%d", alert.ID, time.Now().Unix()),
+		Type:      "alert_update",
+		Title:     "Alert Escalated",
+		Message:   fmt.Sprintf("Alert '%s' has been escalated to %s", alert.Title, severity),
+		Severity:  severity,
+		Timestamp: time.Now(),
+		Data: map[string]interface{}{
+			"alert_id": alert.ID,
+			"action":   "escalated",
+			"severity": severity,
+		},
+	}
+
+	if err := s.notificationService.Broadcast(ctx, notification); err != nil {
+		log.Warn().
+			Err(err).
+			Str("alert_id", alert.ID).
+			Msg("Failed to broadcast alert escalation notification")
+	}
+
+	log.Info().
+		Str("alert_id", alert.ID).
+		Str("severity", severity).
+		Msg("Alert escalated successfully")
+
+	return alert, nil
+}
+
 // GetAlertStatistics returns alert statistics for a time range
 func (s *AlertServiceImpl) GetAlertStatistics(ctx context.Context, timeRange *interfaces.TimeRange) (*interfaces.AlertStatistics, error) {
 	params := queries.GetAlertStatisticsParams{}
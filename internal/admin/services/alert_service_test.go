@@ -67,6 +67,14 @@ func (m *MockAlertService) ResolveAlert(ctx context.Context, alertID, resolvedBy
 	return args.Get(0).(*interfaces.Alert), args.Error(1)
 }
 
+func (m *MockAlertService) EscalateAlert(ctx context.Context, alertID, severity string, metadata map[string]interface{}) (*interfaces.Alert, error) {
+	args := m.Called(ctx, alertID, severity, metadata)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*interfaces.Alert), args.Error(1)
+}
+
 func (m *MockAlertService) GetAlertStatistics(ctx context.Context, timeRange *interfaces.TimeRange) (*interfaces.AlertStatistics, error) {
 	args := m.Called(ctx, timeRange)
 	if args.Get(0) == nil {
@@ -118,6 +126,37 @@ func (m *MockNotificationService) SendToAdmin(ctx context.Context, adminID strin
 	return args.Error(0)
 }
 
+func (m *MockNotificationService) SubscribeTopic(conn *websocket.Conn, subscription interfaces.Subscription) error {
+	args := m.Called(conn, subscription)
+	return args.Error(0)
+}
+
+func (m *MockNotificationService) UnsubscribeTopic(conn *websocket.Conn, topic string) error {
+	args := m.Called(conn, topic)
+	return args.Error(0)
+}
+
+func (m *MockNotificationService) GetSubscriptions(conn *websocket.Conn) []interfaces.Subscription {
+	args := m.Called(conn)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).([]interfaces.Subscription)
+}
+
+func (m *MockNotificationService) SendToConnection(conn *websocket.Conn, message []byte) error {
+	args := m.Called(conn, message)
+	return args.Error(0)
+}
+
+func (m *MockNotificationService) Replay(ctx context.Context, conn *websocket.Conn, lastSeen int64) (*interfaces.ReplayResult, error) {
+	args := m.Called(ctx, conn, lastSeen)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*interfaces.ReplayResult), args.Error(1)
+}
+
 func (m *MockNotificationService) GetConnectionCount() int {
 	args := m.Called()
 	return args.Int(0)
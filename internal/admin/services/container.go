@@ -1,29 +1,65 @@
 package services
 
 import (
+	"context"
 	"fmt"
+	"log"
+	"time"
 
+	"github.com/hibiken/asynq"
 	"github.com/phantom-sage/bankgo/internal/admin/config"
 	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
+	"github.com/phantom-sage/bankgo/internal/database"
+	"github.com/phantom-sage/bankgo/internal/database/queries"
+	"github.com/phantom-sage/bankgo/internal/events"
+	"github.com/phantom-sage/bankgo/internal/lockout"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/redis/go-redis/v9"
+	zlog "github.com/rs/zerolog/log"
 )
 
 // Container holds all admin services
 type Container struct {
-	config *config.Config
-	db     *pgxpool.Pool
-	redis  *redis.Client
+	config   *config.Config
+	database *database.DB
+	db       *pgxpool.Pool
+	redis    *redis.Client
+
+	// redisOptions is set when Redis is reachable; nil means running degraded
+	redisOptions *redis.Options
+
+	// readPool picks the pool for admin searches; nil routes them to db
+	readPool ReadPoolFunc
+
+	// Background workers
+	notificationRelay *NotificationServiceImpl
+	stopRelay         context.CancelFunc
+	stopActivityFeed  context.CancelFunc
+	asynqClient       *asynq.Client
+	asynqServer       *asynq.Server
+	workerMux         *asynq.ServeMux
 
 	// Services
-	AuthService         interfaces.AdminAuthService
-	UserService         interfaces.UserManagementService
-	SystemService       interfaces.SystemMonitoringService
-	DatabaseService     interfaces.DatabaseService
-	NotificationService interfaces.NotificationService
-	AlertService        interfaces.AlertService
-	TransactionService  interfaces.TransactionService
-	AccountService      interfaces.AccountService
+	AuthService          interfaces.AdminAuthService
+	UserService          interfaces.UserManagementService
+	SystemService        interfaces.SystemMonitoringService
+	DatabaseService      interfaces.DatabaseService
+	NotificationService  interfaces.NotificationService
+	AlertService         interfaces.AlertService
+	TransactionService   interfaces.TransactionService
+	AccountService       interfaces.AccountService
+	RiskReviewService    interfaces.RiskReviewService
+	TransferLimitService interfaces.TransferLimitService
+	FeeScheduleService   interfaces.FeeScheduleService
+	ImportService        interfaces.ImportService
+	SQLConsoleService    interfaces.SQLConsoleService
+	ActivityFeedService  interfaces.ActivityFeedService
+	AlertDispatcher      *AlertDispatcherImpl
+	LifecycleWorker      *AlertLifecycleWorker
+
+	// LoginGuard protects admin sign-ins against brute force; nil when
+	// login protection is disabled
+	LoginGuard *lockout.Guard
 }
 
 // NewContainer creates a new service container with all dependencies
@@ -50,17 +86,52 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 	return container, nil
 }
 
-// initDatabase initializes the database connection pool
+// initDatabase connects the database pools, retrying while the database
+// starts up. The admin server cannot work without it, so failure is fatal.
 func (c *Container) initDatabase() error {
-	// TODO: Initialize pgxpool connection
-	// This will be implemented in a later task
+	dbConfig := databaseConfig(c.config)
+	err := connectWithRetry("database", c.config.Startup.Retries, c.config.Startup.RetryDelay, func(ctx context.Context) error {
+		db, err := database.New(dbConfig)
+		if err != nil {
+			return err
+		}
+		c.database = db
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	c.db = c.database.Pool
+	c.readPool = c.database.ReadPool
 	return nil
 }
 
-// initRedis initializes the Redis client
+// initRedis connects the Redis client, retrying while Redis starts up. When
+// Redis stays unreachable and is not required the server runs degraded:
+// alerts are delivered inline without retries and /health reports Redis
+// as unavailable.
 func (c *Container) initRedis() error {
-	// TODO: Initialize Redis client
-	// This will be implemented in a later task
+	opts, err := redisOptions(c.config)
+	if err != nil {
+		return err
+	}
+
+	client := redis.NewClient(opts)
+	err = connectWithRetry("Redis", c.config.Startup.Retries, c.config.Startup.RetryDelay, func(ctx context.Context) error {
+		return client.Ping(ctx).Err()
+	})
+	if err != nil {
+		client.Close()
+		if c.config.Startup.RequireRedis {
+			return err
+		}
+		log.Printf("Warning: running without Redis (set ADMIN_REDIS_REQUIRED=true to fail instead): %v", err)
+		return nil
+	}
+
+	c.redis = client
+	c.redisOptions = opts
 	return nil
 }
 
@@ -82,23 +153,153 @@ func (c *Container) initServices() error {
 	// Initialize user management service
 	c.UserService = NewUserManagementService(c.db)
 
-	// Initialize notification service
-	c.NotificationService = NewNotificationService()
+	// Initialize notification service; with Redis, notifications raised on any
+	// replica or by the banking API reach admins connected to every replica
+	if c.redis != nil {
+		c.notificationRelay = NewNotificationServiceWithBus(NewRedisNotificationBus(c.redis, c.config.NotificationReplayBuffer))
+		c.NotificationService = c.notificationRelay
+	} else {
+		c.NotificationService = NewNotificationService()
+	}
 	
+	// Initialize outbound alert channels
+	if err := c.initAlertDispatcher(); err != nil {
+		return fmt.Errorf("failed to initialize alert dispatcher: %w", err)
+	}
+
 	// Initialize alert service
-	c.AlertService = NewAlertService(c.db, c.NotificationService)
+	c.AlertService = NewAlertServiceWithDispatcher(c.db, c.NotificationService, c.AlertDispatcher)
 
 	// Initialize system monitoring service (depends on alert service)
 	c.SystemService = NewSystemMonitoringService(c.db, c.redis, c.config.BankingAPIURL, c.AlertService)
+
+	// Initialize admin sign-in brute-force protection; lockouts raise
+	// authentication failure alerts
+	if c.config.LoginProtection.Enabled {
+		c.LoginGuard = lockout.NewGuard(queries.New(c.db), lockout.Admins, c.config.LoginProtection, zlog.Logger,
+			lockout.WithAlerter(NewAlertGeneratorService(c.AlertService)))
+	}
 	
 	// Initialize database service
-	c.DatabaseService = NewDatabaseService(c.db)
+	c.DatabaseService = NewDatabaseServiceWithConfig(c.db, c.readPool, c.config.DataBrowser)
 	
 	// Initialize transaction service
-	c.TransactionService = NewTransactionService(c.db)
+	c.TransactionService = NewTransactionServiceWithReadPool(c.db, c.readPool)
 	
 	// Initialize account service
-	c.AccountService = NewAccountService(c.db)
+	c.AccountService = NewAccountServiceWithReadPool(c.db, c.readPool)
+
+	// Initialize transfer risk review queue (reverses rejected transfers)
+	c.RiskReviewService = NewRiskReviewService(c.db)
+
+	// Initialize per-user transfer limit overrides
+	c.TransferLimitService = NewTransferLimitService(c.db)
+
+	// Initialize transfer fee schedules
+	c.FeeScheduleService = NewFeeScheduleService(c.db)
+
+	// Initialize CSV bulk imports of transfers and balance adjustments
+	c.ImportService = NewImportService(c.db)
+
+	// Initialize read-only SQL console
+	c.SQLConsoleService = NewSQLConsoleService(c.db, c.readPool, c.config.SQLConsole, c.config.DataBrowser)
+
+	// Initialize the banking activity feed. Domain events reach the admin API
+	// through Redis only; without it the feed stays idle.
+	var subscriber events.Subscriber = events.NewMemoryBus()
+	if c.redis != nil {
+		subscriber = events.NewRedisBus(c.redis)
+	}
+	c.ActivityFeedService = NewActivityFeedService(subscriber, c.NotificationService, c.redis)
+
+	// Initialize alert lifecycle worker (escalation, auto-resolution, retention)
+	c.LifecycleWorker = NewAlertLifecycleWorker(c.AlertService, c.AlertDispatcher, c.SystemService, c.config.AlertLifecycle)
+
+	return nil
+}
+
+// initAlertDispatcher builds the configured notification channels and the
+// asynq client and server used to deliver alerts with retries
+func (c *Container) initAlertDispatcher() error {
+	alertCfg := c.config.Alerts
+
+	var channels []interfaces.NotificationChannel
+	if alertCfg.SMTPHost != "" && len(alertCfg.EmailTo) > 0 {
+		channels = append(channels, NewEmailChannel(
+			ChannelTypeEmail,
+			alertCfg.SMTPHost,
+			alertCfg.SMTPPort,
+			alertCfg.SMTPUsername,
+			alertCfg.SMTPPassword,
+			alertCfg.EmailFrom,
+			alertCfg.EmailTo,
+		))
+	}
+	if alertCfg.WebhookURL != "" {
+		channels = append(channels, NewWebhookChannel(ChannelTypeWebhook, alertCfg.WebhookURL, alertCfg.WebhookSecret))
+	}
+	if alertCfg.SlackWebhookURL != "" {
+		channels = append(channels, NewSlackChannel(ChannelTypeSlack, alertCfg.SlackWebhookURL, alertCfg.SlackChannel))
+	}
+
+	if c.redisOptions != nil {
+		redisOpt := asynqRedisOpt(c.redisOptions)
+		c.asynqClient = asynq.NewClient(redisOpt)
+		c.asynqServer = asynq.NewServer(redisOpt, asynq.Config{
+			Concurrency: 5,
+			Queues: map[string]int{
+				AlertDeliveryQueue: 1,
+			},
+			RetryDelayFunc: func(n int, err error, task *asynq.Task) time.Duration {
+				// Exponential backoff: 1s, 2s, 4s, 8s, ... capped at 5m
+				delay := time.Duration(1<<uint(n)) * time.Second
+				if delay > 5*time.Minute {
+					delay = 5 * time.Minute
+				}
+				return delay
+			},
+		})
+	}
+
+	c.AlertDispatcher = NewAlertDispatcher(channels, alertCfg.Routes, c.asynqClient, alertCfg.MaxRetry)
+
+	c.workerMux = asynq.NewServeMux()
+	c.AlertDispatcher.RegisterHandlers(c.workerMux)
+
+	return nil
+}
+
+// StartWorkers starts background task processing
+func (c *Container) StartWorkers() error {
+	if c.notificationRelay != nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		if err := c.notificationRelay.StartRelay(ctx); err != nil {
+			cancel()
+			return fmt.Errorf("failed to start notification relay: %w", err)
+		}
+		c.stopRelay = cancel
+	}
+
+	if c.ActivityFeedService != nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		if err := c.ActivityFeedService.Start(ctx); err != nil {
+			cancel()
+			return fmt.Errorf("failed to start activity feed: %w", err)
+		}
+		c.stopActivityFeed = cancel
+	}
+
+	if c.LifecycleWorker != nil {
+		c.LifecycleWorker.Start()
+	}
+
+	if c.asynqServer == nil {
+		return nil
+	}
+
+	if err := c.asynqServer.Start(c.workerMux); err != nil {
+		return fmt.Errorf("failed to start alert delivery worker: %w", err)
+	}
 
 	return nil
 }
@@ -107,8 +308,30 @@ func (c *Container) initServices() error {
 func (c *Container) Close() error {
 	var errors []error
 
-	if c.db != nil {
-		c.db.Close()
+	if c.LifecycleWorker != nil {
+		c.LifecycleWorker.Stop()
+	}
+
+	if c.stopActivityFeed != nil {
+		c.stopActivityFeed()
+	}
+
+	if c.stopRelay != nil {
+		c.stopRelay()
+	}
+
+	if c.asynqServer != nil {
+		c.asynqServer.Shutdown()
+	}
+
+	if c.asynqClient != nil {
+		if err := c.asynqClient.Close(); err != nil {
+			errors = append(errors, fmt.Errorf("failed to close task client: %w", err))
+		}
+	}
+
+	if c.database != nil {
+		c.database.Close()
 	}
 
 	if c.redis != nil {
@@ -129,6 +352,27 @@ func (c *Container) GetDB() *pgxpool.Pool {
 	return c.db
 }
 
+// Dependencies reports whether each external dependency is available
+func (c *Container) Dependencies() map[string]string {
+	dependencies := map[string]string{
+		"database": DependencyUnavailable,
+		"redis":    DependencyUnavailable,
+	}
+	if c.database != nil {
+		dependencies["database"] = DependencyAvailable
+		if c.database.HasReplica() {
+			dependencies["database_replica"] = DependencyUnavailable
+			if c.database.ReplicaStatus().Healthy {
+				dependencies["database_replica"] = DependencyAvailable
+			}
+		}
+	}
+	if c.redis != nil {
+		dependencies["redis"] = DependencyAvailable
+	}
+	return dependencies
+}
+
 // GetRedis returns the Redis client
 func (c *Container) GetRedis() *redis.Client {
 	return c.redis
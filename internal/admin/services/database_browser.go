@@ -0,0 +1,578 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/phantom-sage/bankgo/internal/admin/config"
+	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
+)
+
+// maxInFilterValues bounds the operands of an in filter
+const maxInFilterValues = 100
+
+// recordVersionColumn is the alias the row version is selected under. The
+// version is the row's xmin, which PostgreSQL changes on every write.
+const recordVersionColumn = "__version"
+
+// recordVersionExpr reads the row version in a form that can be compared
+const recordVersionExpr = "xmin::text::bigint"
+
+// maskedValue replaces masked column values that are too short to keep a suffix
+const maskedValue = "****"
+
+// tableView is a browsable table: its catalog schema and the configured policy
+type tableView struct {
+	name   string
+	schema *interfaces.TableSchema
+	policy config.TablePolicy
+}
+
+// loadTable checks the table against the allowlist and reads its schema
+func (s *DatabaseService) loadTable(ctx context.Context, tableName string) (*tableView, error) {
+	policy, ok := s.browser.Table(tableName)
+	if !ok {
+		return nil, fmt.Errorf("invalid table name: %s", tableName)
+	}
+
+	schema, err := s.describeTable(ctx, tableName)
+	if err != nil {
+		return nil, err
+	}
+	if len(schema.Columns) == 0 {
+		return nil, fmt.Errorf("invalid table name: %s", tableName)
+	}
+
+	return &tableView{name: tableName, schema: schema, policy: policy}, nil
+}
+
+// column returns a column of the table that is not hidden
+func (v *tableView) column(name string) (*interfaces.Column, bool) {
+	if v.policy.IsHidden(name) {
+		return nil, false
+	}
+	for i := range v.schema.Columns {
+		if v.schema.Columns[i].Name == name {
+			return &v.schema.Columns[i], true
+		}
+	}
+	return nil, false
+}
+
+// visibleColumns returns the columns that may be selected
+func (v *tableView) visibleColumns() []interfaces.Column {
+	columns := make([]interfaces.Column, 0, len(v.schema.Columns))
+	for _, col := range v.schema.Columns {
+		if !v.policy.IsHidden(col.Name) {
+			columns = append(columns, col)
+		}
+	}
+	return columns
+}
+
+// selectList returns the quoted visible column names and the row version for
+// SELECT and RETURNING
+func (v *tableView) selectList() string {
+	names := []string{}
+	for _, col := range v.visibleColumns() {
+		names = append(names, quoteIdent(col.Name))
+	}
+	names = append(names, recordVersionExpr+" AS "+recordVersionColumn)
+	return strings.Join(names, ", ")
+}
+
+// primaryKey returns the single primary key column used to address records
+func (v *tableView) primaryKey() (*interfaces.Column, error) {
+	switch len(v.schema.PrimaryKeys) {
+	case 0:
+		return nil, fmt.Errorf("table %s has no primary key", v.name)
+	case 1:
+	default:
+		return nil, fmt.Errorf("table %s has a composite primary key", v.name)
+	}
+
+	for i := range v.schema.Columns {
+		if v.schema.Columns[i].Name == v.schema.PrimaryKeys[0] {
+			return &v.schema.Columns[i], nil
+		}
+	}
+	return nil, fmt.Errorf("table %s has no primary key", v.name)
+}
+
+// checkWritable rejects writes to read-only tables
+func (v *tableView) checkWritable() error {
+	if v.policy.ReadOnly {
+		return fmt.Errorf("table %s is read-only", v.name)
+	}
+	return nil
+}
+
+// publicSchema returns the schema as admins see it: hidden columns removed and
+// column permissions filled in
+func (v *tableView) publicSchema(browser config.DataBrowserConfig) *interfaces.TableSchema {
+	schema := *v.schema
+	schema.ReadOnly = v.policy.ReadOnly
+
+	schema.Columns = []interfaces.Column{}
+	for _, col := range v.visibleColumns() {
+		col.ReadOnly = v.policy.IsReadOnly(col.Name)
+		col.Masked = v.policy.IsMasked(col.Name)
+		schema.Columns = append(schema.Columns, col)
+	}
+
+	schema.ForeignKeys = []interfaces.ForeignKey{}
+	for _, fk := range v.schema.ForeignKeys {
+		if !v.policy.IsHidden(fk.ColumnName) {
+			schema.ForeignKeys = append(schema.ForeignKeys, fk)
+		}
+	}
+
+	// Only links an admin can follow are listed
+	schema.ReferencedBy = []interfaces.ForeignKey{}
+	for _, fk := range v.schema.ReferencedBy {
+		if policy, ok := browser.Table(fk.TableName); ok && !policy.IsHidden(fk.ColumnName) {
+			schema.ReferencedBy = append(schema.ReferencedBy, fk)
+		}
+	}
+
+	return &schema
+}
+
+// collectRecords reads all rows of a query over the table's select list
+func (s *DatabaseService) collectRecords(rows pgx.Rows, view *tableView) ([]interfaces.TableRecord, error) {
+	defer rows.Close()
+
+	fieldDescriptions := rows.FieldDescriptions()
+	records := []interfaces.TableRecord{}
+	for rows.Next() {
+		values, err := rows.Values()
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row values: %w", err)
+		}
+
+		raw := make(map[string]interface{}, len(values))
+		var version *int
+		for i, value := range values {
+			if fieldDescriptions[i].Name == recordVersionColumn {
+				if v, ok := value.(int64); ok {
+					n := int(v)
+					version = &n
+				}
+				continue
+			}
+			raw[fieldDescriptions[i].Name] = s.convertValue(value)
+		}
+
+		record := s.buildRecord(view, raw)
+		record.Metadata.Version = version
+		records = append(records, record)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// buildRecord applies masking to a row and fills in its metadata
+func (s *DatabaseService) buildRecord(view *tableView, raw map[string]interface{}) interfaces.TableRecord {
+	data := make(map[string]interface{}, len(raw))
+	for column, value := range raw {
+		if view.policy.IsPartiallyMasked(column) {
+			value = partiallyMaskValue(value)
+		} else if view.policy.IsMasked(column) {
+			value = maskValue(value)
+		}
+		data[column] = value
+	}
+
+	record := interfaces.TableRecord{
+		TableName: view.name,
+		Data:      data,
+		Metadata: interfaces.RecordMetadata{
+			PrimaryKey: make(map[string]interface{}),
+		},
+	}
+
+	for _, pkCol := range view.schema.PrimaryKeys {
+		if value, ok := data[pkCol]; ok {
+			record.Metadata.PrimaryKey[pkCol] = value
+		}
+	}
+
+	// Foreign key values become links to the referenced record
+	for _, fk := range view.schema.ForeignKeys {
+		value, ok := raw[fk.ColumnName]
+		if !ok || value == nil || view.policy.IsMasked(fk.ColumnName) {
+			continue
+		}
+		if _, ok := s.browser.Table(fk.ReferencedTable); !ok {
+			continue
+		}
+		record.Metadata.References = append(record.Metadata.References, interfaces.RecordReference{
+			Column:           fk.ColumnName,
+			ReferencedTable:  fk.ReferencedTable,
+			ReferencedColumn: fk.ReferencedColumn,
+			Value:            value,
+		})
+	}
+
+	if t, ok := data["created_at"].(time.Time); ok {
+		record.Metadata.CreatedAt = &t
+	}
+	if t, ok := data["updated_at"].(time.Time); ok {
+		record.Metadata.UpdatedAt = &t
+	}
+
+	return record
+}
+
+// buildWhereClause turns search text and filters into a WHERE clause whose
+// placeholders start after argOffset. Filters are checked against the column
+// permissions; scope conditions are set by the service itself and are not.
+func (s *DatabaseService) buildWhereClause(view *tableView, search string, filters []interfaces.RecordFilter, scope []interfaces.RecordFilter, argOffset int) (string, []interface{}, error) {
+	var conditions []string
+	var args []interface{}
+
+	placeholder := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", argOffset+len(args))
+	}
+
+	if columns := searchColumns(view); search != "" && len(columns) > 0 {
+		pattern := placeholder("%" + search + "%")
+		parts := make([]string, len(columns))
+		for i, column := range columns {
+			parts[i] = fmt.Sprintf("%s ILIKE %s", column, pattern)
+		}
+		conditions = append(conditions, "("+strings.Join(parts, " OR ")+")")
+	}
+
+	for _, filter := range filters {
+		col, ok := view.column(filter.Column)
+		if !ok {
+			return "", nil, fmt.Errorf("invalid filter: unknown column %s", filter.Column)
+		}
+		if view.policy.IsMasked(col.Name) {
+			return "", nil, fmt.Errorf("invalid filter: column %s is masked", col.Name)
+		}
+		condition, err := filterCondition(col, filter, placeholder)
+		if err != nil {
+			return "", nil, err
+		}
+		conditions = append(conditions, condition)
+	}
+
+	for _, filter := range scope {
+		col, ok := view.schemaColumn(filter.Column)
+		if !ok {
+			return "", nil, fmt.Errorf("unknown column %s", filter.Column)
+		}
+		condition, err := filterCondition(col, filter, placeholder)
+		if err != nil {
+			return "", nil, err
+		}
+		conditions = append(conditions, condition)
+	}
+
+	if len(conditions) == 0 {
+		return "", args, nil
+	}
+
+	return "WHERE " + strings.Join(conditions, " AND "), args, nil
+}
+
+// schemaColumn returns any column of the table, hidden or not
+func (v *tableView) schemaColumn(name string) (*interfaces.Column, bool) {
+	for i := range v.schema.Columns {
+		if v.schema.Columns[i].Name == name {
+			return &v.schema.Columns[i], true
+		}
+	}
+	return nil, false
+}
+
+// searchColumns returns the expressions search text is matched against: the
+// primary key and the visible, unmasked text columns
+func searchColumns(view *tableView) []string {
+	var columns []string
+	for _, col := range view.visibleColumns() {
+		if view.policy.IsMasked(col.Name) {
+			continue
+		}
+		switch {
+		case col.IsPrimaryKey:
+			columns = append(columns, fmt.Sprintf("CAST(%s AS TEXT)", quoteIdent(col.Name)))
+		case isTextType(col.Type):
+			columns = append(columns, quoteIdent(col.Name))
+		}
+	}
+	return columns
+}
+
+// filterCondition renders one typed filter, converting operands to the column type
+func filterCondition(col *interfaces.Column, filter interfaces.RecordFilter, placeholder func(interface{}) string) (string, error) {
+	name := quoteIdent(col.Name)
+
+	switch filter.Operator {
+	case interfaces.FilterEq, interfaces.FilterNe, "":
+		value, err := convertColumnValue(col, filter.Value)
+		if err != nil {
+			return "", fmt.Errorf("invalid filter: %w", err)
+		}
+		if filter.Operator == interfaces.FilterNe {
+			return fmt.Sprintf("%s <> %s", name, placeholder(value)), nil
+		}
+		return fmt.Sprintf("%s = %s", name, placeholder(value)), nil
+
+	case interfaces.FilterGt, interfaces.FilterGte, interfaces.FilterLt, interfaces.FilterLte:
+		if !isOrderedType(col.Type) {
+			return "", fmt.Errorf("invalid filter: operator %s is not supported on column %s of type %s", filter.Operator, col.Name, col.Type)
+		}
+		value, err := convertColumnValue(col, filter.Value)
+		if err != nil {
+			return "", fmt.Errorf("invalid filter: %w", err)
+		}
+		operators := map[interfaces.FilterOperator]string{
+			interfaces.FilterGt:  ">",
+			interfaces.FilterGte: ">=",
+			interfaces.FilterLt:  "<",
+			interfaces.FilterLte: "<=",
+		}
+		return fmt.Sprintf("%s %s %s", name, operators[filter.Operator], placeholder(value)), nil
+
+	case interfaces.FilterIn:
+		operands := filterOperands(filter.Value)
+		if len(operands) == 0 || len(operands) > maxInFilterValues {
+			return "", fmt.Errorf("invalid filter: in on column %s needs between 1 and %d values", col.Name, maxInFilterValues)
+		}
+		placeholders := make([]string, 0, len(operands))
+		for _, operand := range operands {
+			value, err := convertColumnValue(col, operand)
+			if err != nil {
+				return "", fmt.Errorf("invalid filter: %w", err)
+			}
+			placeholders = append(placeholders, placeholder(value))
+		}
+		return fmt.Sprintf("%s IN (%s)", name, strings.Join(placeholders, ", ")), nil
+
+	case interfaces.FilterIsNull:
+		isNull := true
+		if filter.Value != nil && filter.Value != "" {
+			b, err := convertColumnValue(&interfaces.Column{Name: col.Name, Type: "boolean"}, filter.Value)
+			if err != nil {
+				return "", fmt.Errorf("invalid filter: %w", err)
+			}
+			isNull = b.(bool)
+		}
+		if isNull {
+			return fmt.Sprintf("%s IS NULL", name), nil
+		}
+		return fmt.Sprintf("%s IS NOT NULL", name), nil
+	}
+
+	return "", fmt.Errorf("invalid filter: unknown operator %s", filter.Operator)
+}
+
+// filterOperands splits an in operand given as a list or comma-separated string
+func filterOperands(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		return v
+	case []string:
+		operands := make([]interface{}, len(v))
+		for i, s := range v {
+			operands[i] = s
+		}
+		return operands
+	case string:
+		operands := []interface{}{}
+		for _, s := range strings.Split(v, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				operands = append(operands, s)
+			}
+		}
+		return operands
+	case nil:
+		return nil
+	}
+	return []interface{}{value}
+}
+
+// equalityFilters turns a legacy column => value filter map into eq filters,
+// skipping nil values. Columns are sorted so placeholders are stable.
+func equalityFilters(filters map[string]interface{}) []interfaces.RecordFilter {
+	columns := make([]string, 0, len(filters))
+	for column, value := range filters {
+		if value != nil {
+			columns = append(columns, column)
+		}
+	}
+	sort.Strings(columns)
+
+	result := make([]interfaces.RecordFilter, 0, len(columns))
+	for _, column := range columns {
+		result = append(result, interfaces.RecordFilter{Column: column, Operator: interfaces.FilterEq, Value: filters[column]})
+	}
+	return result
+}
+
+// buildOrderClause sorts by a visible, unmasked column and breaks ties on the
+// primary key so pages are stable
+func buildOrderClause(view *tableView, sortBy string, sortDesc bool) (string, error) {
+	direction := "ASC"
+	if sortDesc {
+		direction = "DESC"
+	}
+
+	var parts []string
+	if sortBy != "" {
+		col, ok := view.column(sortBy)
+		if !ok || view.policy.IsMasked(col.Name) {
+			return "", fmt.Errorf("invalid sort column: %s", sortBy)
+		}
+		parts = append(parts, fmt.Sprintf("%s %s", quoteIdent(col.Name), direction))
+	}
+
+	for _, pkCol := range view.schema.PrimaryKeys {
+		if pkCol != sortBy {
+			parts = append(parts, fmt.Sprintf("%s %s", quoteIdent(pkCol), direction))
+		}
+	}
+
+	if len(parts) == 0 {
+		return "", nil
+	}
+	return "ORDER BY " + strings.Join(parts, ", "), nil
+}
+
+// convertColumnValue converts a request value to the Go type for a column.
+// Numeric and unknown types are passed to PostgreSQL as text.
+func convertColumnValue(col *interfaces.Column, value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	switch {
+	case isIntegerType(col.Type):
+		switch v := value.(type) {
+		case int:
+			return int64(v), nil
+		case int32:
+			return int64(v), nil
+		case int64:
+			return v, nil
+		case float64:
+			if v == float64(int64(v)) {
+				return int64(v), nil
+			}
+		case string:
+			if n, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64); err == nil {
+				return n, nil
+			}
+		}
+		return nil, fmt.Errorf("column %s must be an integer", col.Name)
+
+	case isDecimalType(col.Type):
+		switch v := value.(type) {
+		case int, int32, int64:
+			return fmt.Sprint(v), nil
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64), nil
+		case string:
+			if _, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+				return strings.TrimSpace(v), nil
+			}
+		}
+		return nil, fmt.Errorf("column %s must be a number", col.Name)
+
+	case col.Type == "boolean":
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			if b, err := strconv.ParseBool(v); err == nil {
+				return b, nil
+			}
+		}
+		return nil, fmt.Errorf("column %s must be a boolean", col.Name)
+
+	case isTimeType(col.Type):
+		switch v := value.(type) {
+		case time.Time:
+			return v, nil
+		case string:
+			if t, err := time.Parse(time.RFC3339, v); err == nil {
+				return t, nil
+			}
+			if t, err := time.Parse("2006-01-02", v); err == nil {
+				return t, nil
+			}
+		}
+		return nil, fmt.Errorf("column %s must be an RFC3339 timestamp or YYYY-MM-DD date", col.Name)
+
+	case isTextType(col.Type):
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case int, int32, int64, float64, bool:
+			return fmt.Sprint(v), nil
+		}
+		return nil, fmt.Errorf("column %s must be a string", col.Name)
+	}
+
+	return value, nil
+}
+
+// maskValue hides a value entirely
+func maskValue(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	return maskedValue
+}
+
+// partiallyMaskValue hides a value but keeps the last four characters of long
+// strings, for columns configured as partially masked
+func partiallyMaskValue(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	s, ok := value.(string)
+	if !ok || len(s) <= 8 {
+		return maskedValue
+	}
+	return maskedValue + s[len(s)-4:]
+}
+
+// quoteIdent quotes a column or table name taken from the catalog
+func quoteIdent(name string) string {
+	return pgx.Identifier{name}.Sanitize()
+}
+
+func isIntegerType(dataType string) bool {
+	return dataType == "integer" || dataType == "bigint" || dataType == "smallint"
+}
+
+func isDecimalType(dataType string) bool {
+	return dataType == "numeric" || dataType == "real" || dataType == "double precision"
+}
+
+func isTimeType(dataType string) bool {
+	return strings.HasPrefix(dataType, "timestamp") || dataType == "date"
+}
+
+func isTextType(dataType string) bool {
+	return dataType == "character varying" || dataType == "text" || dataType == "character"
+}
+
+// isOrderedType reports whether range operators make sense on a column type
+func isOrderedType(dataType string) bool {
+	return isIntegerType(dataType) || isDecimalType(dataType) || isTimeType(dataType) || isTextType(dataType)
+}
@@ -0,0 +1,260 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/phantom-sage/bankgo/internal/admin/config"
+	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testUsersView() *tableView {
+	return &tableView{
+		name: "users",
+		schema: &interfaces.TableSchema{
+			Name: "users",
+			Columns: []interfaces.Column{
+				{Name: "id", Type: "integer", IsPrimaryKey: true},
+				{Name: "email", Type: "character varying"},
+				{Name: "password_hash", Type: "character varying"},
+				{Name: "tax_id", Type: "text", Nullable: true},
+				{Name: "is_active", Type: "boolean"},
+				{Name: "created_at", Type: "timestamp without time zone"},
+			},
+			PrimaryKeys: []string{"id"},
+			ReferencedBy: []interfaces.ForeignKey{
+				{TableName: "accounts", ColumnName: "user_id", ReferencedTable: "users", ReferencedColumn: "id"},
+				{TableName: "audit_log", ColumnName: "user_id", ReferencedTable: "users", ReferencedColumn: "id"},
+			},
+		},
+		policy: config.TablePolicy{
+			HiddenColumns:   []string{"password_hash"},
+			MaskedColumns:   []string{"tax_id"},
+			ReadOnlyColumns: []string{"id", "email"},
+		},
+	}
+}
+
+func testTransfersView() *tableView {
+	return &tableView{
+		name: "transfers",
+		schema: &interfaces.TableSchema{
+			Name: "transfers",
+			Columns: []interfaces.Column{
+				{Name: "id", Type: "integer", IsPrimaryKey: true},
+				{Name: "from_account_id", Type: "integer", IsForeignKey: true},
+				{Name: "to_account_id", Type: "integer", IsForeignKey: true},
+				{Name: "amount", Type: "numeric"},
+			},
+			PrimaryKeys: []string{"id"},
+			ForeignKeys: []interfaces.ForeignKey{
+				{ColumnName: "from_account_id", ReferencedTable: "accounts", ReferencedColumn: "id"},
+				{ColumnName: "to_account_id", ReferencedTable: "accounts", ReferencedColumn: "id"},
+			},
+		},
+		policy: config.TablePolicy{ReadOnly: true},
+	}
+}
+
+func TestDatabaseBrowser_BuildWhereClause(t *testing.T) {
+	s := &DatabaseService{}
+	view := testUsersView()
+
+	_, _, err := s.buildWhereClause(view, "", []interfaces.RecordFilter{
+		{Column: "tax_id", Operator: interfaces.FilterIsNull, Value: "false"},
+	}, nil, 0)
+	require.Error(t, err, "masked columns cannot be filtered, not even on null")
+	assert.Contains(t, err.Error(), "invalid filter")
+
+	where, args, err := s.buildWhereClause(view, "john", []interfaces.RecordFilter{
+		{Column: "id", Operator: interfaces.FilterIn, Value: "1, 2,3"},
+		{Column: "is_active", Operator: interfaces.FilterEq, Value: "true"},
+		{Column: "created_at", Operator: interfaces.FilterGte, Value: "2024-01-01"},
+	}, nil, 2)
+	require.NoError(t, err)
+
+	// Search skips hidden and masked columns; placeholders start after the offset
+	assert.Equal(t, `WHERE (CAST("id" AS TEXT) ILIKE $3 OR "email" ILIKE $3) AND "id" IN ($4, $5, $6) AND "is_active" = $7 AND "created_at" >= $8`, where)
+	require.Len(t, args, 6)
+	assert.Equal(t, "%john%", args[0])
+	assert.Equal(t, []interface{}{int64(1), int64(2), int64(3)}, args[1:4])
+	assert.Equal(t, true, args[4])
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), args[5])
+
+	// Scope conditions may use hidden columns
+	where, args, err = s.buildWhereClause(view, "", nil, []interfaces.RecordFilter{
+		{Column: "password_hash", Operator: interfaces.FilterIsNull},
+	}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, `WHERE "password_hash" IS NULL`, where)
+	assert.Empty(t, args)
+
+	invalid := []interfaces.RecordFilter{
+		{Column: "password_hash", Operator: interfaces.FilterEq, Value: "x"},
+		{Column: "missing", Operator: interfaces.FilterEq, Value: "x"},
+		{Column: "is_active", Operator: interfaces.FilterGt, Value: "true"},
+		{Column: "id", Operator: interfaces.FilterEq, Value: "abc"},
+		{Column: "id", Operator: interfaces.FilterIn, Value: ""},
+		{Column: "id", Operator: "like", Value: "1"},
+	}
+	for _, filter := range invalid {
+		_, _, err := s.buildWhereClause(view, "", []interfaces.RecordFilter{filter}, nil, 0)
+		if assert.Error(t, err, "expected %+v to be rejected", filter) {
+			assert.Contains(t, err.Error(), "invalid filter")
+		}
+	}
+}
+
+func TestDatabaseBrowser_EqualityFilters(t *testing.T) {
+	filters := equalityFilters(map[string]interface{}{"is_active": true, "email": "a@b.c", "skipped": nil})
+	assert.Equal(t, []interfaces.RecordFilter{
+		{Column: "email", Operator: interfaces.FilterEq, Value: "a@b.c"},
+		{Column: "is_active", Operator: interfaces.FilterEq, Value: true},
+	}, filters)
+}
+
+func TestDatabaseBrowser_BuildOrderClause(t *testing.T) {
+	view := testUsersView()
+
+	order, err := buildOrderClause(view, "", false)
+	require.NoError(t, err)
+	assert.Equal(t, `ORDER BY "id" ASC`, order)
+
+	order, err = buildOrderClause(view, "created_at", true)
+	require.NoError(t, err)
+	assert.Equal(t, `ORDER BY "created_at" DESC, "id" DESC`, order)
+
+	for _, column := range []string{"password_hash", "tax_id", "id; DROP TABLE users"} {
+		_, err := buildOrderClause(view, column, false)
+		assert.Error(t, err, "expected sorting on %q to be rejected", column)
+	}
+}
+
+func TestDatabaseBrowser_PublicSchemaAndRecords(t *testing.T) {
+	browser := config.DataBrowserConfig{Tables: map[string]config.TablePolicy{"users": {}, "accounts": {}}}
+	s := &DatabaseService{browser: browser}
+	view := testUsersView()
+
+	schema := view.publicSchema(browser)
+	names := []string{}
+	for _, col := range schema.Columns {
+		names = append(names, col.Name)
+	}
+	assert.Equal(t, []string{"id", "email", "tax_id", "is_active", "created_at"}, names)
+	assert.True(t, schema.Columns[1].ReadOnly)
+	assert.True(t, schema.Columns[2].Masked)
+	assert.Len(t, schema.ReferencedBy, 1, "links into tables outside the allowlist are dropped")
+	assert.Len(t, view.schema.Columns, 6, "the catalog schema is left untouched")
+
+	assert.Equal(t, `"id", "email", "tax_id", "is_active", "created_at", xmin::text::bigint AS __version`, view.selectList())
+
+	createdAt := time.Now()
+	record := s.buildRecord(view, map[string]interface{}{
+		"id":         int32(7),
+		"email":      "john@example.com",
+		"tax_id":     "123-45-6789",
+		"is_active":  true,
+		"created_at": createdAt,
+	})
+	assert.Equal(t, "****", record.Data["tax_id"])
+	assert.Equal(t, map[string]interface{}{"id": int32(7)}, record.Metadata.PrimaryKey)
+	assert.Equal(t, &createdAt, record.Metadata.CreatedAt)
+
+	// Partially masked columns keep their last characters
+	view.policy.MaskedColumns = nil
+	view.policy.PartiallyMaskedColumns = []string{"tax_id"}
+	record = s.buildRecord(view, map[string]interface{}{"id": int32(7), "tax_id": "123-45-6789"})
+	assert.Equal(t, "****6789", record.Data["tax_id"])
+}
+
+func TestDatabaseBrowser_RecordReferences(t *testing.T) {
+	s := &DatabaseService{browser: config.DataBrowserConfig{Tables: map[string]config.TablePolicy{"accounts": {}}}}
+
+	record := s.buildRecord(testTransfersView(), map[string]interface{}{
+		"id":              int32(1),
+		"from_account_id": int32(10),
+		"to_account_id":   int32(20),
+	})
+	assert.Equal(t, []interfaces.RecordReference{
+		{Column: "from_account_id", ReferencedTable: "accounts", ReferencedColumn: "id", Value: int32(10)},
+		{Column: "to_account_id", ReferencedTable: "accounts", ReferencedColumn: "id", Value: int32(20)},
+	}, record.Metadata.References)
+}
+
+func TestDatabaseBrowser_FindRelation(t *testing.T) {
+	accounts := &tableView{
+		name: "accounts",
+		schema: &interfaces.TableSchema{
+			Columns:     []interfaces.Column{{Name: "id", Type: "integer", IsPrimaryKey: true}, {Name: "user_id", Type: "integer"}},
+			PrimaryKeys: []string{"id"},
+			ForeignKeys: []interfaces.ForeignKey{{ColumnName: "user_id", ReferencedTable: "users", ReferencedColumn: "id"}},
+		},
+	}
+	transfers := testTransfersView()
+
+	// Outgoing: a transfer's account is read through from_account_id
+	link, err := findRelation(transfers, accounts, "from_account_id")
+	require.NoError(t, err)
+	assert.Equal(t, relation{localColumn: "from_account_id", relatedColumn: "id", via: "from_account_id"}, *link)
+
+	// Incoming: an account's transfers are those whose to_account_id is its id
+	link, err = findRelation(accounts, transfers, "to_account_id")
+	require.NoError(t, err)
+	assert.Equal(t, relation{localColumn: "id", relatedColumn: "to_account_id", via: "to_account_id"}, *link)
+
+	_, err = findRelation(accounts, transfers, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "set via to one of from_account_id, to_account_id")
+
+	_, err = findRelation(transfers, testUsersView(), "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid relation")
+}
+
+func TestDatabaseBrowser_ValidateRecordData(t *testing.T) {
+	s := &DatabaseService{}
+	view := testUsersView()
+
+	data, err := s.validateRecordData(view, map[string]interface{}{
+		"id":         float64(7),
+		"is_active":  "false",
+		"tax_id":     nil,
+		"created_at": "ignored",
+	}, true)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"is_active": false, "tax_id": nil}, data)
+
+	_, err = s.validateRecordData(view, map[string]interface{}{"email": "new@example.com"}, true)
+	assert.EqualError(t, err, "column email is read-only")
+
+	_, err = s.validateRecordData(view, map[string]interface{}{"password_hash": "x"}, false)
+	assert.EqualError(t, err, "invalid column: password_hash")
+
+	assert.EqualError(t, testTransfersView().checkWritable(), "table transfers is read-only")
+}
+
+func TestDatabaseBrowser_MaskValue(t *testing.T) {
+	assert.Nil(t, maskValue(nil))
+	assert.Equal(t, "****", maskValue("short"))
+	assert.Equal(t, "****", maskValue(12345678901))
+	assert.Equal(t, "****", maskValue("0123456789abcdef"))
+
+	assert.Nil(t, partiallyMaskValue(nil))
+	assert.Equal(t, "****", partiallyMaskValue("short"))
+	assert.Equal(t, "****", partiallyMaskValue(12345678901))
+	assert.Equal(t, "****cdef", partiallyMaskValue("0123456789abcdef"))
+}
+
+func TestTablePolicy_PartiallyMasked(t *testing.T) {
+	policy := config.TablePolicy{
+		MaskedColumns:          []string{"tax_id"},
+		PartiallyMaskedColumns: []string{"account_number", "tax_id"},
+	}
+
+	assert.True(t, policy.IsMasked("account_number"), "partially masked columns are masked")
+	assert.True(t, policy.IsPartiallyMasked("account_number"))
+	assert.False(t, policy.IsPartiallyMasked("tax_id"), "full masking wins")
+	assert.False(t, policy.IsMasked("email"))
+}
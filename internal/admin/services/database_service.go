@@ -3,11 +3,12 @@ package services
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
-	"strconv"
 	"strings"
 	"time"
 
+	"github.com/phantom-sage/bankgo/internal/admin/config"
 	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -15,13 +16,29 @@ import (
 
 // DatabaseService implements the DatabaseService interface
 type DatabaseService struct {
-	db *pgxpool.Pool
+	db       *pgxpool.Pool
+	readPool ReadPoolFunc
+	browser  config.DataBrowserConfig
 }
 
 // NewDatabaseService creates a new database service
 func NewDatabaseService(db *pgxpool.Pool) interfaces.DatabaseService {
+	return NewDatabaseServiceWithReadPool(db, nil)
+}
+
+// NewDatabaseServiceWithReadPool creates a database service that lists records from readPool.
+// Single-record reads stay on the primary so edits are visible immediately.
+func NewDatabaseServiceWithReadPool(db *pgxpool.Pool, readPool ReadPoolFunc) interfaces.DatabaseService {
+	return NewDatabaseServiceWithConfig(db, readPool, config.DefaultDataBrowserConfig())
+}
+
+// NewDatabaseServiceWithConfig creates a database service exposing only the
+// tables and columns allowed by browser
+func NewDatabaseServiceWithConfig(db *pgxpool.Pool, readPool ReadPoolFunc, browser config.DataBrowserConfig) interfaces.DatabaseService {
 	return &DatabaseService{
-		db: db,
+		db:       db,
+		readPool: readPool,
+		browser:  browser,
 	}
 }
 
@@ -59,6 +76,11 @@ func (s *DatabaseService) ListTables(ctx context.Context) ([]interfaces.TableInf
 		if description.Valid {
 			table.Description = description.String
 		}
+
+		// Only tables in the data browser allowlist are listed
+		if _, ok := s.browser.Table(table.Name); !ok {
+			continue
+		}
 		
 		tables = append(tables, table)
 	}
@@ -72,6 +94,15 @@ func (s *DatabaseService) ListTables(ctx context.Context) ([]interfaces.TableInf
 
 // GetTableSchema returns table structure information
 func (s *DatabaseService) GetTableSchema(ctx context.Context, tableName string) (*interfaces.TableSchema, error) {
+	view, err := s.loadTable(ctx, tableName)
+	if err != nil {
+		return nil, err
+	}
+	return view.publicSchema(s.browser), nil
+}
+
+// describeTable reads a table's columns, keys and indexes from the catalog
+func (s *DatabaseService) describeTable(ctx context.Context, tableName string) (*interfaces.TableSchema, error) {
 	// Get columns information
 	columnsQuery := `
 		SELECT 
@@ -183,6 +214,47 @@ func (s *DatabaseService) GetTableSchema(ctx context.Context, tableName string)
 		foreignKeys = append(foreignKeys, fk)
 	}
 
+	// Get foreign keys in other tables referencing this one
+	referencedByQuery := `
+		SELECT 
+			tc.table_name,
+			kcu.column_name,
+			ccu.table_name AS referenced_table,
+			ccu.column_name AS referenced_column,
+			tc.constraint_name,
+			rc.delete_rule,
+			rc.update_rule
+		FROM information_schema.table_constraints tc
+		JOIN information_schema.key_column_usage kcu ON tc.constraint_name = kcu.constraint_name
+		JOIN information_schema.constraint_column_usage ccu ON tc.constraint_name = ccu.constraint_name
+		JOIN information_schema.referential_constraints rc ON tc.constraint_name = rc.constraint_name
+		WHERE ccu.table_name = $1 AND tc.constraint_type = 'FOREIGN KEY' AND tc.table_schema = 'public'
+		ORDER BY tc.table_name, kcu.column_name`
+
+	refRows, err := s.db.Query(ctx, referencedByQuery, tableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query referencing foreign keys: %w", err)
+	}
+	defer refRows.Close()
+
+	referencedBy := []interfaces.ForeignKey{}
+	for refRows.Next() {
+		var fk interfaces.ForeignKey
+		err := refRows.Scan(
+			&fk.TableName,
+			&fk.ColumnName,
+			&fk.ReferencedTable,
+			&fk.ReferencedColumn,
+			&fk.ConstraintName,
+			&fk.OnDelete,
+			&fk.OnUpdate,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan referencing foreign key info: %w", err)
+		}
+		referencedBy = append(referencedBy, fk)
+	}
+
 	// Get indexes information
 	indexesQuery := `
 		SELECT 
@@ -225,6 +297,8 @@ func (s *DatabaseService) GetTableSchema(ctx context.Context, tableName string)
 		PrimaryKeys: primaryKeys,
 		ForeignKeys: foreignKeys,
 		Indexes:     indexes,
+
+		ReferencedBy: referencedBy,
 	}
 
 	return schema, nil
@@ -232,11 +306,17 @@ func (s *DatabaseService) GetTableSchema(ctx context.Context, tableName string)
 
 // ListRecords returns paginated records from a table
 func (s *DatabaseService) ListRecords(ctx context.Context, tableName string, params interfaces.ListRecordsParams) (*interfaces.PaginatedRecords, error) {
-	// Validate table name to prevent SQL injection
-	if !s.isValidTableName(tableName) {
-		return nil, fmt.Errorf("invalid table name: %s", tableName)
+	view, err := s.loadTable(ctx, tableName)
+	if err != nil {
+		return nil, err
 	}
 
+	return s.listRecords(ctx, view, params, nil)
+}
+
+// listRecords pages through a table. scope conditions are added by the service
+// and bypass the column permissions applied to admin filters.
+func (s *DatabaseService) listRecords(ctx context.Context, view *tableView, params interfaces.ListRecordsParams, scope []interfaces.RecordFilter) (*interfaces.PaginatedRecords, error) {
 	// Set default pagination
 	if params.Page <= 0 {
 		params.Page = 1
@@ -246,15 +326,23 @@ func (s *DatabaseService) ListRecords(ctx context.Context, tableName string, par
 	}
 
 	// Build WHERE clause for search and filters
-	whereClause, args := s.buildWhereClause(params.Search, params.Filters)
-	
+	filters := append(equalityFilters(params.Filters), params.Conditions...)
+	whereClause, args, err := s.buildWhereClause(view, params.Search, filters, scope, 0)
+	if err != nil {
+		return nil, err
+	}
+
 	// Build ORDER BY clause
-	orderClause := s.buildOrderClause(params.SortBy, params.SortDesc)
+	orderClause, err := buildOrderClause(view, params.SortBy, params.SortDesc)
+	if err != nil {
+		return nil, err
+	}
 
 	// Count total records
-	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM %s %s", tableName, whereClause)
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM %s %s", quoteIdent(view.name), whereClause)
 	var totalCount int
-	err := s.db.QueryRow(ctx, countQuery, args...).Scan(&totalCount)
+	readDB := readPoolOrPrimary(s.db, s.readPool)
+	err = readDB.QueryRow(ctx, countQuery, args...).Scan(&totalCount)
 	if err != nil {
 		return nil, fmt.Errorf("failed to count records: %w", err)
 	}
@@ -265,74 +353,19 @@ func (s *DatabaseService) ListRecords(ctx context.Context, tableName string, par
 
 	// Query records
 	query := fmt.Sprintf(
-		"SELECT * FROM %s %s %s LIMIT $%d OFFSET $%d",
-		tableName, whereClause, orderClause, len(args)+1, len(args)+2,
+		"SELECT %s FROM %s %s %s LIMIT $%d OFFSET $%d",
+		view.selectList(), quoteIdent(view.name), whereClause, orderClause, len(args)+1, len(args)+2,
 	)
 	args = append(args, params.PageSize, offset)
 
-	rows, err := s.db.Query(ctx, query, args...)
+	rows, err := readDB.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query records: %w", err)
 	}
-	defer rows.Close()
-
-	// Get column descriptions
-	fieldDescriptions := rows.FieldDescriptions()
-	
-	var records []interfaces.TableRecord
-	for rows.Next() {
-		values, err := rows.Values()
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan row values: %w", err)
-		}
-
-		// Convert values to map
-		data := make(map[string]interface{})
-		primaryKey := make(map[string]interface{})
-		
-		for i, value := range values {
-			columnName := fieldDescriptions[i].Name
-			data[columnName] = s.convertValue(value)
-			
-			// Assume first column is primary key for now
-			// In a real implementation, we'd get this from schema
-			if i == 0 {
-				primaryKey[columnName] = s.convertValue(value)
-			}
-		}
-
-		record := interfaces.TableRecord{
-			TableName: tableName,
-			Data:      data,
-			Metadata: interfaces.RecordMetadata{
-				PrimaryKey: primaryKey,
-			},
-		}
-
-		// Set timestamps if they exist
-		if createdAt, ok := data["created_at"]; ok {
-			if t, ok := createdAt.(time.Time); ok {
-				record.Metadata.CreatedAt = &t
-			}
-		}
-		if updatedAt, ok := data["updated_at"]; ok {
-			if t, ok := updatedAt.(time.Time); ok {
-				record.Metadata.UpdatedAt = &t
-			}
-		}
-
-		records = append(records, record)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating record rows: %w", err)
-	}
 
-	// Get table schema for response
-	schema, err := s.GetTableSchema(ctx, tableName)
+	records, err := s.collectRecords(rows, view)
 	if err != nil {
-		// Don't fail the request if schema fetch fails
-		schema = nil
+		return nil, fmt.Errorf("error iterating record rows: %w", err)
 	}
 
 	pagination := interfaces.PaginationInfo{
@@ -347,107 +380,55 @@ func (s *DatabaseService) ListRecords(ctx context.Context, tableName string, par
 	return &interfaces.PaginatedRecords{
 		Records:    records,
 		Pagination: pagination,
-		Schema:     schema,
+		Schema:     view.publicSchema(s.browser),
 	}, nil
 }
 
 // GetRecord returns a specific record by ID
 func (s *DatabaseService) GetRecord(ctx context.Context, tableName string, recordID interface{}) (*interfaces.TableRecord, error) {
-	if !s.isValidTableName(tableName) {
-		return nil, fmt.Errorf("invalid table name: %s", tableName)
-	}
-
-	// Get table schema to find primary key
-	schema, err := s.GetTableSchema(ctx, tableName)
+	view, err := s.loadTable(ctx, tableName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get table schema: %w", err)
-	}
-
-	if len(schema.PrimaryKeys) == 0 {
-		return nil, fmt.Errorf("table %s has no primary key", tableName)
-	}
-
-	// For simplicity, assume single column primary key
-	primaryKeyColumn := schema.PrimaryKeys[0]
-	
-	query := fmt.Sprintf("SELECT * FROM %s WHERE %s = $1", tableName, primaryKeyColumn)
-	row := s.db.QueryRow(ctx, query, recordID)
-
-	// Get column names from schema
-	columnNames := make([]string, len(schema.Columns))
-	for i, col := range schema.Columns {
-		columnNames[i] = col.Name
+		return nil, err
 	}
 
-	// Scan into interface slice
-	values := make([]interface{}, len(columnNames))
-	valuePtrs := make([]interface{}, len(columnNames))
-	for i := range values {
-		valuePtrs[i] = &values[i]
-	}
+	return s.getRecord(ctx, view, recordID)
+}
 
-	err = row.Scan(valuePtrs...)
+// getRecord reads a record by primary key from the primary, so edits are
+// visible immediately
+func (s *DatabaseService) getRecord(ctx context.Context, view *tableView, recordID interface{}) (*interfaces.TableRecord, error) {
+	pk, id, err := s.recordKey(view, recordID)
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("record not found")
-		}
-		return nil, fmt.Errorf("failed to scan record: %w", err)
-	}
-
-	// Convert to map
-	data := make(map[string]interface{})
-	primaryKey := make(map[string]interface{})
-	
-	for i, value := range values {
-		columnName := columnNames[i]
-		convertedValue := s.convertValue(value)
-		data[columnName] = convertedValue
-		
-		if columnName == primaryKeyColumn {
-			primaryKey[columnName] = convertedValue
-		}
-	}
-
-	record := &interfaces.TableRecord{
-		TableName: tableName,
-		Data:      data,
-		Metadata: interfaces.RecordMetadata{
-			PrimaryKey: primaryKey,
-		},
+		return nil, err
 	}
 
-	// Set timestamps if they exist
-	if createdAt, ok := data["created_at"]; ok {
-		if t, ok := createdAt.(time.Time); ok {
-			record.Metadata.CreatedAt = &t
-		}
-	}
-	if updatedAt, ok := data["updated_at"]; ok {
-		if t, ok := updatedAt.(time.Time); ok {
-			record.Metadata.UpdatedAt = &t
-		}
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s = $1", view.selectList(), quoteIdent(view.name), quoteIdent(pk.Name))
+	rows, err := s.db.Query(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query record: %w", err)
 	}
 
-	return record, nil
+	return s.singleRecord(rows, view, "failed to scan record")
 }
 
 // CreateRecord creates a new record in a table
 func (s *DatabaseService) CreateRecord(ctx context.Context, tableName string, data map[string]interface{}) (*interfaces.TableRecord, error) {
-	if !s.isValidTableName(tableName) {
-		return nil, fmt.Errorf("invalid table name: %s", tableName)
-	}
-
-	// Get table schema for validation
-	schema, err := s.GetTableSchema(ctx, tableName)
+	view, err := s.loadTable(ctx, tableName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get table schema: %w", err)
+		return nil, err
+	}
+	if err := view.checkWritable(); err != nil {
+		return nil, err
 	}
 
 	// Validate and prepare data
-	validatedData, err := s.validateRecordData(schema, data, false)
+	validatedData, err := s.validateRecordData(view, data, false)
 	if err != nil {
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
+	if len(validatedData) == 0 {
+		return nil, fmt.Errorf("validation failed: no writable columns provided")
+	}
 
 	// Build INSERT query
 	columns := make([]string, 0, len(validatedData))
@@ -456,103 +437,55 @@ func (s *DatabaseService) CreateRecord(ctx context.Context, tableName string, da
 	
 	i := 1
 	for column, value := range validatedData {
-		columns = append(columns, column)
+		columns = append(columns, quoteIdent(column))
 		placeholders = append(placeholders, fmt.Sprintf("$%d", i))
 		values = append(values, value)
 		i++
 	}
 
 	query := fmt.Sprintf(
-		"INSERT INTO %s (%s) VALUES (%s) RETURNING *",
-		tableName,
+		"INSERT INTO %s (%s) VALUES (%s) RETURNING %s",
+		quoteIdent(view.name),
 		strings.Join(columns, ", "),
 		strings.Join(placeholders, ", "),
+		view.selectList(),
 	)
 
-	row := s.db.QueryRow(ctx, query, values...)
-
-	// Scan the returned record
-	columnNames := make([]string, len(schema.Columns))
-	for i, col := range schema.Columns {
-		columnNames[i] = col.Name
-	}
-
-	scanValues := make([]interface{}, len(columnNames))
-	scanValuePtrs := make([]interface{}, len(columnNames))
-	for i := range scanValues {
-		scanValuePtrs[i] = &scanValues[i]
-	}
-
-	err = row.Scan(scanValuePtrs...)
+	rows, err := s.db.Query(ctx, query, values...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to scan created record: %w", err)
+		return nil, fmt.Errorf("failed to create record: %w", err)
 	}
 
-	// Convert to record
-	recordData := make(map[string]interface{})
-	primaryKey := make(map[string]interface{})
-	
-	for i, value := range scanValues {
-		columnName := columnNames[i]
-		convertedValue := s.convertValue(value)
-		recordData[columnName] = convertedValue
-		
-		// Check if this is a primary key column
-		for _, pkCol := range schema.PrimaryKeys {
-			if columnName == pkCol {
-				primaryKey[columnName] = convertedValue
-				break
-			}
-		}
-	}
-
-	record := &interfaces.TableRecord{
-		TableName: tableName,
-		Data:      recordData,
-		Metadata: interfaces.RecordMetadata{
-			PrimaryKey: primaryKey,
-		},
-	}
-
-	// Set timestamps
-	if createdAt, ok := recordData["created_at"]; ok {
-		if t, ok := createdAt.(time.Time); ok {
-			record.Metadata.CreatedAt = &t
-		}
-	}
-	if updatedAt, ok := recordData["updated_at"]; ok {
-		if t, ok := updatedAt.(time.Time); ok {
-			record.Metadata.UpdatedAt = &t
-		}
-	}
-
-	return record, nil
+	return s.singleRecord(rows, view, "failed to scan created record")
 }
 
-// UpdateRecord updates an existing record
-func (s *DatabaseService) UpdateRecord(ctx context.Context, tableName string, recordID interface{}, data map[string]interface{}) (*interfaces.TableRecord, error) {
-	if !s.isValidTableName(tableName) {
-		return nil, fmt.Errorf("invalid table name: %s", tableName)
-	}
-
-	// Get table schema
-	schema, err := s.GetTableSchema(ctx, tableName)
+// UpdateRecord updates an existing record. With an expected version the update
+// only applies if nobody changed the row since it was read.
+func (s *DatabaseService) UpdateRecord(ctx context.Context, tableName string, recordID interface{}, data map[string]interface{}, expectedVersion *int) (*interfaces.TableRecord, error) {
+	view, err := s.loadTable(ctx, tableName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get table schema: %w", err)
+		return nil, err
+	}
+	if err := view.checkWritable(); err != nil {
+		return nil, err
 	}
 
-	if len(schema.PrimaryKeys) == 0 {
-		return nil, fmt.Errorf("table %s has no primary key", tableName)
+	pk, id, err := s.recordKey(view, recordID)
+	if err != nil {
+		return nil, err
 	}
 
 	// Validate data
-	validatedData, err := s.validateRecordData(schema, data, true)
+	validatedData, err := s.validateRecordData(view, data, true)
 	if err != nil {
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
+	if len(validatedData) == 0 {
+		return nil, fmt.Errorf("validation failed: no writable columns provided")
+	}
 
 	// Add updated_at if column exists
-	if s.hasColumn(schema, "updated_at") {
+	if s.hasColumn(view.schema, "updated_at") {
 		validatedData["updated_at"] = time.Now()
 	}
 
@@ -562,119 +495,218 @@ func (s *DatabaseService) UpdateRecord(ctx context.Context, tableName string, re
 	
 	i := 1
 	for column, value := range validatedData {
-		setParts = append(setParts, fmt.Sprintf("%s = $%d", column, i))
+		setParts = append(setParts, fmt.Sprintf("%s = $%d", quoteIdent(column), i))
 		values = append(values, value)
 		i++
 	}
 
-	primaryKeyColumn := schema.PrimaryKeys[0] // Assume single column PK
-	values = append(values, recordID)
+	values = append(values, id)
+	whereClause := fmt.Sprintf("%s = $%d", quoteIdent(pk.Name), i)
+	if expectedVersion != nil {
+		values = append(values, int64(*expectedVersion))
+		whereClause += fmt.Sprintf(" AND %s = $%d", recordVersionExpr, i+1)
+	}
 
 	query := fmt.Sprintf(
-		"UPDATE %s SET %s WHERE %s = $%d RETURNING *",
-		tableName,
+		"UPDATE %s SET %s WHERE %s RETURNING %s",
+		quoteIdent(view.name),
 		strings.Join(setParts, ", "),
-		primaryKeyColumn,
-		i,
+		whereClause,
+		view.selectList(),
 	)
 
-	row := s.db.QueryRow(ctx, query, values...)
+	rows, err := s.db.Query(ctx, query, values...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update record: %w", err)
+	}
 
-	// Scan the updated record
-	columnNames := make([]string, len(schema.Columns))
-	for i, col := range schema.Columns {
-		columnNames[i] = col.Name
+	record, err := s.singleRecord(rows, view, "failed to scan updated record")
+	if errors.Is(err, interfaces.ErrRecordNotFound) && expectedVersion != nil {
+		return nil, s.versionConflict(ctx, view, recordID)
 	}
+	return record, err
+}
 
-	scanValues := make([]interface{}, len(columnNames))
-	scanValuePtrs := make([]interface{}, len(columnNames))
-	for i := range scanValues {
-		scanValuePtrs[i] = &scanValues[i]
+// DeleteRecord deletes a record from a table, checking the expected version
+// like UpdateRecord
+func (s *DatabaseService) DeleteRecord(ctx context.Context, tableName string, recordID interface{}, expectedVersion *int) error {
+	view, err := s.loadTable(ctx, tableName)
+	if err != nil {
+		return err
+	}
+	if err := view.checkWritable(); err != nil {
+		return err
 	}
 
-	err = row.Scan(scanValuePtrs...)
+	pk, id, err := s.recordKey(view, recordID)
 	if err != nil {
-		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("record not found")
+		return err
+	}
+	
+	query := fmt.Sprintf("DELETE FROM %s WHERE %s = $1", quoteIdent(view.name), quoteIdent(pk.Name))
+	args := []interface{}{id}
+	if expectedVersion != nil {
+		query += fmt.Sprintf(" AND %s = $2", recordVersionExpr)
+		args = append(args, int64(*expectedVersion))
+	}
+
+	result, err := s.db.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to delete record: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		if expectedVersion != nil {
+			return s.versionConflict(ctx, view, recordID)
 		}
-		return nil, fmt.Errorf("failed to scan updated record: %w", err)
+		return interfaces.ErrRecordNotFound
 	}
 
-	// Convert to record
-	recordData := make(map[string]interface{})
-	primaryKey := make(map[string]interface{})
-	
-	for i, value := range scanValues {
-		columnName := columnNames[i]
-		convertedValue := s.convertValue(value)
-		recordData[columnName] = convertedValue
-		
-		for _, pkCol := range schema.PrimaryKeys {
-			if columnName == pkCol {
-				primaryKey[columnName] = convertedValue
-				break
-			}
+	return nil
+}
+
+// versionConflict explains why a versioned write matched no row: either the
+// record is gone or it has moved on to another version
+func (s *DatabaseService) versionConflict(ctx context.Context, view *tableView, recordID interface{}) error {
+	current, err := s.getRecord(ctx, view, recordID)
+	if err != nil {
+		return err
+	}
+
+	conflict := &interfaces.VersionConflictError{Current: current}
+	if current.Metadata.Version != nil {
+		conflict.Version = *current.Metadata.Version
+	}
+	return conflict
+}
+
+// ListRelatedRecords returns records of relatedTable linked to a record by a
+// foreign key in either direction. via names the foreign key column and is
+// only needed when the tables are linked by more than one key.
+func (s *DatabaseService) ListRelatedRecords(ctx context.Context, tableName string, recordID interface{}, relatedTable string, via string, params interfaces.ListRecordsParams) (*interfaces.PaginatedRecords, error) {
+	view, err := s.loadTable(ctx, tableName)
+	if err != nil {
+		return nil, err
+	}
+	related, err := s.loadTable(ctx, relatedTable)
+	if err != nil {
+		return nil, err
+	}
+
+	link, err := findRelation(view, related, via)
+	if err != nil {
+		return nil, err
+	}
+
+	pk, id, err := s.recordKey(view, recordID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The linking value is read unmasked; it never leaves the service
+	var key interface{}
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s = $1", quoteIdent(link.localColumn), quoteIdent(view.name), quoteIdent(pk.Name))
+	if err := s.db.QueryRow(ctx, query, id).Scan(&key); err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, interfaces.ErrRecordNotFound
 		}
+		return nil, fmt.Errorf("failed to read related key: %w", err)
 	}
 
-	record := &interfaces.TableRecord{
-		TableName: tableName,
-		Data:      recordData,
-		Metadata: interfaces.RecordMetadata{
-			PrimaryKey: primaryKey,
-		},
+	// A NULL foreign key links to nothing
+	if key == nil {
+		return &interfaces.PaginatedRecords{
+			Records:    []interfaces.TableRecord{},
+			Pagination: interfaces.PaginationInfo{Page: 1, PageSize: params.PageSize},
+			Schema:     related.publicSchema(s.browser),
+		}, nil
 	}
 
-	// Set timestamps
-	if createdAt, ok := recordData["created_at"]; ok {
-		if t, ok := createdAt.(time.Time); ok {
-			record.Metadata.CreatedAt = &t
+	scope := []interfaces.RecordFilter{{Column: link.relatedColumn, Operator: interfaces.FilterEq, Value: key}}
+	return s.listRecords(ctx, related, params, scope)
+}
+
+// relation is a foreign key link between a record and rows of another table
+type relation struct {
+	localColumn   string
+	relatedColumn string
+	via           string
+}
+
+// findRelation finds the foreign key linking view to related, in either
+// direction, optionally narrowed to the foreign key column via
+func findRelation(view, related *tableView, via string) (*relation, error) {
+	var candidates []relation
+	for _, fk := range view.schema.ForeignKeys {
+		if fk.ReferencedTable == related.name {
+			candidates = append(candidates, relation{localColumn: fk.ColumnName, relatedColumn: fk.ReferencedColumn, via: fk.ColumnName})
 		}
 	}
-	if updatedAt, ok := recordData["updated_at"]; ok {
-		if t, ok := updatedAt.(time.Time); ok {
-			record.Metadata.UpdatedAt = &t
+	for _, fk := range related.schema.ForeignKeys {
+		if fk.ReferencedTable == view.name {
+			candidates = append(candidates, relation{localColumn: fk.ReferencedColumn, relatedColumn: fk.ColumnName, via: fk.ColumnName})
 		}
 	}
 
-	return record, nil
-}
+	// Links through hidden columns cannot be followed
+	var matches []relation
+	var names []string
+	for _, candidate := range candidates {
+		if view.policy.IsHidden(candidate.localColumn) || related.policy.IsHidden(candidate.relatedColumn) {
+			continue
+		}
+		if via != "" && candidate.via != via {
+			continue
+		}
+		matches = append(matches, candidate)
+		names = append(names, candidate.via)
+	}
 
-// DeleteRecord deletes a record from a table
-func (s *DatabaseService) DeleteRecord(ctx context.Context, tableName string, recordID interface{}) error {
-	if !s.isValidTableName(tableName) {
-		return fmt.Errorf("invalid table name: %s", tableName)
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("invalid relation: %s is not linked to %s", view.name, related.name)
+	case 1:
+		return &matches[0], nil
 	}
+	return nil, fmt.Errorf("invalid relation: %s is linked to %s by more than one foreign key, set via to one of %s", view.name, related.name, strings.Join(names, ", "))
+}
 
-	// Get table schema
-	schema, err := s.GetTableSchema(ctx, tableName)
+// recordKey returns the primary key column and the record ID converted to its type
+func (s *DatabaseService) recordKey(view *tableView, recordID interface{}) (*interfaces.Column, interface{}, error) {
+	pk, err := view.primaryKey()
 	if err != nil {
-		return fmt.Errorf("failed to get table schema: %w", err)
+		return nil, nil, err
 	}
 
-	if len(schema.PrimaryKeys) == 0 {
-		return fmt.Errorf("table %s has no primary key", tableName)
+	id, err := convertColumnValue(pk, recordID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid record id: %w", err)
 	}
 
-	primaryKeyColumn := schema.PrimaryKeys[0] // Assume single column PK
-	
-	query := fmt.Sprintf("DELETE FROM %s WHERE %s = $1", tableName, primaryKeyColumn)
-	result, err := s.db.Exec(ctx, query, recordID)
+	return pk, id, nil
+}
+
+// singleRecord reads the one row a query by primary key returns
+func (s *DatabaseService) singleRecord(rows pgx.Rows, view *tableView, failure string) (*interfaces.TableRecord, error) {
+	records, err := s.collectRecords(rows, view)
 	if err != nil {
-		return fmt.Errorf("failed to delete record: %w", err)
+		return nil, fmt.Errorf("%s: %w", failure, err)
 	}
-
-	if result.RowsAffected() == 0 {
-		return fmt.Errorf("record not found")
+	if len(records) == 0 {
+		return nil, interfaces.ErrRecordNotFound
 	}
 
-	return nil
+	return &records[0], nil
 }
 
 // BulkOperation performs bulk operations on records
 func (s *DatabaseService) BulkOperation(ctx context.Context, tableName string, operation interfaces.BulkOperation) (*interfaces.BulkOperationResult, error) {
-	if !s.isValidTableName(tableName) {
-		return nil, fmt.Errorf("invalid table name: %s", tableName)
+	view, err := s.loadTable(ctx, tableName)
+	if err != nil {
+		return nil, err
+	}
+	if err := view.checkWritable(); err != nil {
+		return nil, err
 	}
 
 	result := &interfaces.BulkOperationResult{
@@ -690,9 +722,9 @@ func (s *DatabaseService) BulkOperation(ctx context.Context, tableName string, o
 
 	switch operation.Operation {
 	case "update":
-		err = s.performBulkUpdate(ctx, tx, tableName, operation, result)
+		err = s.performBulkUpdate(ctx, tx, view, operation, result)
 	case "delete":
-		err = s.performBulkDelete(ctx, tx, tableName, operation, result)
+		err = s.performBulkDelete(ctx, tx, view, operation, result)
 	default:
 		return nil, fmt.Errorf("unsupported bulk operation: %s", operation.Operation)
 	}
@@ -714,60 +746,6 @@ func (s *DatabaseService) BulkOperation(ctx context.Context, tableName string, o
 
 // Helper methods
 
-func (s *DatabaseService) isValidTableName(tableName string) bool {
-	// Simple validation - in production, you'd want more robust validation
-	validTables := map[string]bool{
-		"users":     true,
-		"accounts":  true,
-		"transfers": true,
-	}
-	return validTables[tableName]
-}
-
-func (s *DatabaseService) buildWhereClause(search string, filters map[string]interface{}) (string, []interface{}) {
-	var conditions []string
-	var args []interface{}
-	argIndex := 1
-
-	// Add search condition (simple text search across all text columns)
-	if search != "" {
-		// This is a simplified search - in production you'd want more sophisticated search
-		searchCondition := fmt.Sprintf("(CAST(id AS TEXT) ILIKE $%d OR email ILIKE $%d OR first_name ILIKE $%d OR last_name ILIKE $%d)", 
-			argIndex, argIndex, argIndex, argIndex)
-		conditions = append(conditions, searchCondition)
-		args = append(args, "%"+search+"%")
-		argIndex++
-	}
-
-	// Add filter conditions
-	for column, value := range filters {
-		if value != nil {
-			conditions = append(conditions, fmt.Sprintf("%s = $%d", column, argIndex))
-			args = append(args, value)
-			argIndex++
-		}
-	}
-
-	if len(conditions) == 0 {
-		return "", args
-	}
-
-	return "WHERE " + strings.Join(conditions, " AND "), args
-}
-
-func (s *DatabaseService) buildOrderClause(sortBy string, sortDesc bool) string {
-	if sortBy == "" {
-		sortBy = "id" // Default sort by id
-	}
-
-	direction := "ASC"
-	if sortDesc {
-		direction = "DESC"
-	}
-
-	return fmt.Sprintf("ORDER BY %s %s", sortBy, direction)
-}
-
 func (s *DatabaseService) convertValue(value interface{}) interface{} {
 	if value == nil {
 		return nil
@@ -777,6 +755,8 @@ func (s *DatabaseService) convertValue(value interface{}) interface{} {
 	switch v := value.(type) {
 	case []byte:
 		return string(v)
+	case [16]byte:
+		return fmt.Sprintf("%x-%x-%x-%x-%x", v[0:4], v[4:6], v[6:8], v[8:10], v[10:16])
 	case time.Time:
 		return v
 	default:
@@ -784,21 +764,14 @@ func (s *DatabaseService) convertValue(value interface{}) interface{} {
 	}
 }
 
-func (s *DatabaseService) validateRecordData(schema *interfaces.TableSchema, data map[string]interface{}, isUpdate bool) (map[string]interface{}, error) {
+func (s *DatabaseService) validateRecordData(view *tableView, data map[string]interface{}, isUpdate bool) (map[string]interface{}, error) {
 	validatedData := make(map[string]interface{})
 
 	for column, value := range data {
-		// Find column in schema
-		var columnInfo *interfaces.Column
-		for _, col := range schema.Columns {
-			if col.Name == column {
-				columnInfo = &col
-				break
-			}
-		}
-
-		if columnInfo == nil {
-			return nil, fmt.Errorf("unknown column: %s", column)
+		// Find column in schema; hidden columns cannot be written
+		columnInfo, ok := view.column(column)
+		if !ok {
+			return nil, fmt.Errorf("invalid column: %s", column)
 		}
 
 		// Skip primary key columns in updates
@@ -811,46 +784,21 @@ func (s *DatabaseService) validateRecordData(schema *interfaces.TableSchema, dat
 			continue
 		}
 
+		if view.policy.IsReadOnly(column) {
+			return nil, fmt.Errorf("column %s is read-only", column)
+		}
+
 		// Validate nullable
 		if !columnInfo.Nullable && value == nil {
 			return nil, fmt.Errorf("column %s cannot be null", column)
 		}
 
-		// Basic type validation (simplified)
-		if value != nil {
-			switch columnInfo.Type {
-			case "integer", "bigint":
-				if _, ok := value.(int); !ok {
-					if str, ok := value.(string); ok {
-						if intVal, err := strconv.Atoi(str); err == nil {
-							value = intVal
-						} else {
-							return nil, fmt.Errorf("column %s must be an integer", column)
-						}
-					} else {
-						return nil, fmt.Errorf("column %s must be an integer", column)
-					}
-				}
-			case "character varying", "text":
-				if _, ok := value.(string); !ok {
-					return nil, fmt.Errorf("column %s must be a string", column)
-				}
-			case "boolean":
-				if _, ok := value.(bool); !ok {
-					if str, ok := value.(string); ok {
-						if boolVal, err := strconv.ParseBool(str); err == nil {
-							value = boolVal
-						} else {
-							return nil, fmt.Errorf("column %s must be a boolean", column)
-						}
-					} else {
-						return nil, fmt.Errorf("column %s must be a boolean", column)
-					}
-				}
-			}
+		converted, err := convertColumnValue(columnInfo, value)
+		if err != nil {
+			return nil, err
 		}
 
-		validatedData[column] = value
+		validatedData[column] = converted
 	}
 
 	return validatedData, nil
@@ -865,31 +813,22 @@ func (s *DatabaseService) hasColumn(schema *interfaces.TableSchema, columnName s
 	return false
 }
 
-func (s *DatabaseService) performBulkUpdate(ctx context.Context, tx pgx.Tx, tableName string, operation interfaces.BulkOperation, result *interfaces.BulkOperationResult) error {
+func (s *DatabaseService) performBulkUpdate(ctx context.Context, tx pgx.Tx, view *tableView, operation interfaces.BulkOperation, result *interfaces.BulkOperationResult) error {
 	if len(operation.Data) == 0 {
 		return fmt.Errorf("no data provided for bulk update")
 	}
 
-	// Get table schema
-	schema, err := s.GetTableSchema(ctx, tableName)
-	if err != nil {
-		return fmt.Errorf("failed to get table schema: %w", err)
-	}
-
-	if len(schema.PrimaryKeys) == 0 {
-		return fmt.Errorf("table %s has no primary key", tableName)
-	}
-
-	primaryKeyColumn := schema.PrimaryKeys[0]
-
 	// Validate update data
-	validatedData, err := s.validateRecordData(schema, operation.Data, true)
+	validatedData, err := s.validateRecordData(view, operation.Data, true)
 	if err != nil {
 		return fmt.Errorf("validation failed: %w", err)
 	}
+	if len(validatedData) == 0 {
+		return fmt.Errorf("validation failed: no writable columns provided")
+	}
 
 	// Add updated_at if column exists
-	if s.hasColumn(schema, "updated_at") {
+	if s.hasColumn(view.schema, "updated_at") {
 		validatedData["updated_at"] = time.Now()
 	}
 
@@ -899,7 +838,7 @@ func (s *DatabaseService) performBulkUpdate(ctx context.Context, tx pgx.Tx, tabl
 	
 	i := 1
 	for column, value := range validatedData {
-		setParts = append(setParts, fmt.Sprintf("%s = $%d", column, i))
+		setParts = append(setParts, fmt.Sprintf("%s = $%d", quoteIdent(column), i))
 		setValues = append(setValues, value)
 		i++
 	}
@@ -908,15 +847,20 @@ func (s *DatabaseService) performBulkUpdate(ctx context.Context, tx pgx.Tx, tabl
 	if len(operation.RecordIDs) > 0 {
 		// Update specific records
 		for _, recordID := range operation.RecordIDs {
+			pk, id, err := s.recordKey(view, recordID)
+			if err != nil {
+				return err
+			}
+
 			query := fmt.Sprintf(
 				"UPDATE %s SET %s WHERE %s = $%d",
-				tableName,
+				quoteIdent(view.name),
 				strings.Join(setParts, ", "),
-				primaryKeyColumn,
+				quoteIdent(pk.Name),
 				i,
 			)
 			
-			args := append(setValues, recordID)
+			args := append(append([]interface{}{}, setValues...), id)
 			execResult, err := tx.Exec(ctx, query, args...)
 			if err != nil {
 				result.Errors = append(result.Errors, interfaces.BulkOperationError{
@@ -929,12 +873,15 @@ func (s *DatabaseService) performBulkUpdate(ctx context.Context, tx pgx.Tx, tabl
 			}
 		}
 	} else if len(operation.Filters) > 0 {
-		// Update records matching filters
-		whereClause, whereArgs := s.buildWhereClause("", operation.Filters)
+		// Update records matching filters; placeholders follow the SET values
+		whereClause, whereArgs, err := s.buildWhereClause(view, "", equalityFilters(operation.Filters), nil, len(setValues))
+		if err != nil {
+			return err
+		}
 		
 		query := fmt.Sprintf(
 			"UPDATE %s SET %s %s",
-			tableName,
+			quoteIdent(view.name),
 			strings.Join(setParts, ", "),
 			whereClause,
 		)
@@ -950,25 +897,18 @@ func (s *DatabaseService) performBulkUpdate(ctx context.Context, tx pgx.Tx, tabl
 	return nil
 }
 
-func (s *DatabaseService) performBulkDelete(ctx context.Context, tx pgx.Tx, tableName string, operation interfaces.BulkOperation, result *interfaces.BulkOperationResult) error {
-	// Get table schema
-	schema, err := s.GetTableSchema(ctx, tableName)
-	if err != nil {
-		return fmt.Errorf("failed to get table schema: %w", err)
-	}
-
-	if len(schema.PrimaryKeys) == 0 {
-		return fmt.Errorf("table %s has no primary key", tableName)
-	}
-
-	primaryKeyColumn := schema.PrimaryKeys[0]
-
+func (s *DatabaseService) performBulkDelete(ctx context.Context, tx pgx.Tx, view *tableView, operation interfaces.BulkOperation, result *interfaces.BulkOperationResult) error {
 	if len(operation.RecordIDs) > 0 {
 		// Delete specific records
 		for _, recordID := range operation.RecordIDs {
-			query := fmt.Sprintf("DELETE FROM %s WHERE %s = $1", tableName, primaryKeyColumn)
+			pk, id, err := s.recordKey(view, recordID)
+			if err != nil {
+				return err
+			}
+
+			query := fmt.Sprintf("DELETE FROM %s WHERE %s = $1", quoteIdent(view.name), quoteIdent(pk.Name))
 			
-			execResult, err := tx.Exec(ctx, query, recordID)
+			execResult, err := tx.Exec(ctx, query, id)
 			if err != nil {
 				result.Errors = append(result.Errors, interfaces.BulkOperationError{
 					RecordID: recordID,
@@ -981,9 +921,12 @@ func (s *DatabaseService) performBulkDelete(ctx context.Context, tx pgx.Tx, tabl
 		}
 	} else if len(operation.Filters) > 0 {
 		// Delete records matching filters
-		whereClause, args := s.buildWhereClause("", operation.Filters)
+		whereClause, args, err := s.buildWhereClause(view, "", equalityFilters(operation.Filters), nil, 0)
+		if err != nil {
+			return err
+		}
 		
-		query := fmt.Sprintf("DELETE FROM %s %s", tableName, whereClause)
+		query := fmt.Sprintf("DELETE FROM %s %s", quoteIdent(view.name), whereClause)
 		
 		execResult, err := tx.Exec(ctx, query, args...)
 		if err != nil {
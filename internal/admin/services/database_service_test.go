@@ -40,16 +40,21 @@ func (m *MockDatabaseService) CreateRecord(ctx context.Context, tableName string
 	return args.Get(0).(*interfaces.TableRecord), args.Error(1)
 }
 
-func (m *MockDatabaseService) UpdateRecord(ctx context.Context, tableName string, recordID interface{}, data map[string]interface{}) (*interfaces.TableRecord, error) {
-	args := m.Called(ctx, tableName, recordID, data)
+func (m *MockDatabaseService) UpdateRecord(ctx context.Context, tableName string, recordID interface{}, data map[string]interface{}, expectedVersion *int) (*interfaces.TableRecord, error) {
+	args := m.Called(ctx, tableName, recordID, data, expectedVersion)
 	return args.Get(0).(*interfaces.TableRecord), args.Error(1)
 }
 
-func (m *MockDatabaseService) DeleteRecord(ctx context.Context, tableName string, recordID interface{}) error {
-	args := m.Called(ctx, tableName, recordID)
+func (m *MockDatabaseService) DeleteRecord(ctx context.Context, tableName string, recordID interface{}, expectedVersion *int) error {
+	args := m.Called(ctx, tableName, recordID, expectedVersion)
 	return args.Error(0)
 }
 
+func (m *MockDatabaseService) ListRelatedRecords(ctx context.Context, tableName string, recordID interface{}, relatedTable string, via string, params interfaces.ListRecordsParams) (*interfaces.PaginatedRecords, error) {
+	args := m.Called(ctx, tableName, recordID, relatedTable, via, params)
+	return args.Get(0).(*interfaces.PaginatedRecords), args.Error(1)
+}
+
 func (m *MockDatabaseService) BulkOperation(ctx context.Context, tableName string, operation interfaces.BulkOperation) (*interfaces.BulkOperationResult, error) {
 	args := m.Called(ctx, tableName, operation)
 	return args.Get(0).(*interfaces.BulkOperationResult), args.Error(1)
@@ -362,10 +367,10 @@ func TestDatabaseService_UpdateRecord(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockService := new(MockDatabaseService)
-			mockService.On("UpdateRecord", mock.Anything, tt.tableName, tt.recordID, tt.data).Return(tt.expectedRecord, tt.expectedError)
+			mockService.On("UpdateRecord", mock.Anything, tt.tableName, tt.recordID, tt.data, (*int)(nil)).Return(tt.expectedRecord, tt.expectedError)
 
 			ctx := context.Background()
-			record, err := mockService.UpdateRecord(ctx, tt.tableName, tt.recordID, tt.data)
+			record, err := mockService.UpdateRecord(ctx, tt.tableName, tt.recordID, tt.data, nil)
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
@@ -399,10 +404,10 @@ func TestDatabaseService_DeleteRecord(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockService := new(MockDatabaseService)
-			mockService.On("DeleteRecord", mock.Anything, tt.tableName, tt.recordID).Return(tt.expectedError)
+			mockService.On("DeleteRecord", mock.Anything, tt.tableName, tt.recordID, (*int)(nil)).Return(tt.expectedError)
 
 			ctx := context.Background()
-			err := mockService.DeleteRecord(ctx, tt.tableName, tt.recordID)
+			err := mockService.DeleteRecord(ctx, tt.tableName, tt.recordID, nil)
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
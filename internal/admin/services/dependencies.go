@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/phantom-sage/bankgo/internal/admin/config"
+	appconfig "github.com/phantom-sage/bankgo/internal/config"
+	"github.com/redis/go-redis/v9"
+)
+
+// Dependency states reported by Container.Dependencies
+const (
+	DependencyAvailable   = "available"
+	DependencyUnavailable = "unavailable"
+)
+
+// databaseConfig translates the admin pool settings into the shared database
+// configuration so the admin server gets the same replica routing as the API
+func databaseConfig(cfg *config.Config) appconfig.DatabaseConfig {
+	return appconfig.DatabaseConfig{
+		URL:                     cfg.DatabaseURL,
+		MaxOpenConns:            cfg.Database.MaxConns,
+		MaxIdleConns:            cfg.Database.MinConns,
+		ConnMaxLifetime:         cfg.Database.MaxConnLifetime,
+		ConnMaxIdleTime:         cfg.Database.MaxConnIdleTime,
+		ReplicaURL:              cfg.Database.ReplicaURL,
+		ReplicaMaxLag:           cfg.Database.ReplicaMaxLag,
+		ReplicaLagCheckInterval: cfg.Database.ReplicaLagCheckInterval,
+	}
+}
+
+// redisOptions builds Redis client options from the admin configuration. A
+// password given separately overrides one embedded in the URL.
+func redisOptions(cfg *config.Config) (*redis.Options, error) {
+	opts, err := redis.ParseURL(cfg.RedisURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Redis URL: %w", err)
+	}
+	if cfg.RedisPassword != "" {
+		opts.Password = cfg.RedisPassword
+	}
+
+	opts.PoolSize = cfg.Redis.PoolSize
+	opts.MinIdleConns = cfg.Redis.MinIdleConns
+	opts.DialTimeout = cfg.Redis.DialTimeout
+
+	if cfg.Redis.TLS && opts.TLSConfig == nil {
+		opts.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+	if cfg.Redis.TLSCAFile != "" {
+		if opts.TLSConfig == nil {
+			return nil, fmt.Errorf("ADMIN_REDIS_TLS_CA_FILE requires TLS; use a rediss:// URL or set ADMIN_REDIS_TLS")
+		}
+		pem, err := os.ReadFile(cfg.Redis.TLSCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read Redis CA file: %w", err)
+		}
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("Redis CA file %s contains no certificates", cfg.Redis.TLSCAFile)
+		}
+		opts.TLSConfig.RootCAs = roots
+	}
+
+	return opts, nil
+}
+
+// asynqRedisOpt gives the task queue the same Redis connection settings as
+// the Redis client, including TLS
+func asynqRedisOpt(opts *redis.Options) asynq.RedisClientOpt {
+	return asynq.RedisClientOpt{
+		Network:      opts.Network,
+		Addr:         opts.Addr,
+		Username:     opts.Username,
+		Password:     opts.Password,
+		DB:           opts.DB,
+		DialTimeout:  opts.DialTimeout,
+		PoolSize:     opts.PoolSize,
+		TLSConfig:    opts.TLSConfig,
+		ReadTimeout:  opts.ReadTimeout,
+		WriteTimeout: opts.WriteTimeout,
+	}
+}
+
+// connectWithRetry calls connect until it succeeds, retrying up to retries
+// times with a delay that doubles after each failure
+func connectWithRetry(name string, retries int, delay time.Duration, connect func(ctx context.Context) error) error {
+	var err error
+	for attempt := 0; ; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		err = connect(ctx)
+		cancel()
+		if err == nil {
+			return nil
+		}
+		if attempt >= retries {
+			return fmt.Errorf("%s unreachable after %d attempts: %w", name, attempt+1, err)
+		}
+
+		log.Printf("Warning: %s unreachable (attempt %d of %d), retrying in %s: %v", name, attempt+1, retries+1, delay, err)
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
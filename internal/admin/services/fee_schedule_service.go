@@ -0,0 +1,418 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
+	"github.com/phantom-sage/bankgo/internal/database/queries"
+	"github.com/phantom-sage/bankgo/internal/models"
+	"github.com/phantom-sage/bankgo/internal/utils"
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+)
+
+// feeScheduleService implements the FeeScheduleService interface
+type feeScheduleService struct {
+	db      *pgxpool.Pool
+	queries *queries.Queries
+}
+
+// NewFeeScheduleService creates a new fee schedule service
+func NewFeeScheduleService(db *pgxpool.Pool) interfaces.FeeScheduleService {
+	return &feeScheduleService{
+		db:      db,
+		queries: queries.New(db),
+	}
+}
+
+// ListFeeSchedules returns all fee schedules, active first within each currency
+func (s *feeScheduleService) ListFeeSchedules(ctx context.Context) ([]interfaces.FeeSchedule, error) {
+	rows, err := s.queries.ListFeeSchedules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list fee schedules: %w", err)
+	}
+
+	schedules := make([]interfaces.FeeSchedule, 0, len(rows))
+	for _, row := range rows {
+		schedule, err := convertFeeSchedule(row)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, *schedule)
+	}
+
+	return schedules, nil
+}
+
+// GetFeeSchedule returns a single fee schedule
+func (s *feeScheduleService) GetFeeSchedule(ctx context.Context, scheduleID string) (*interfaces.FeeSchedule, error) {
+	row, err := s.getFeeSchedule(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertFeeSchedule(row)
+}
+
+// CreateFeeSchedule creates a fee schedule
+func (s *feeScheduleService) CreateFeeSchedule(ctx context.Context, req interfaces.FeeScheduleRequest, updatedBy string) (*interfaces.FeeSchedule, error) {
+	params, err := s.buildFeeScheduleParams(ctx, 0, req, updatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := s.queries.CreateFeeSchedule(ctx, queries.CreateFeeScheduleParams{
+		Name:           params.Name,
+		Currency:       params.Currency,
+		FeeType:        params.FeeType,
+		FlatAmount:     params.FlatAmount,
+		Percentage:     params.Percentage,
+		MinFee:         params.MinFee,
+		MaxFee:         params.MaxFee,
+		Tiers:          params.Tiers,
+		HouseAccountID: params.HouseAccountID,
+		IsActive:       params.IsActive,
+		UpdatedBy:      params.UpdatedBy,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create fee schedule: %w", err)
+	}
+
+	log.Info().
+		Int32("fee_schedule_id", row.ID).
+		Str("currency", row.Currency).
+		Str("fee_type", row.FeeType).
+		Str("updated_by", updatedBy).
+		Msg("Fee schedule created")
+
+	return convertFeeSchedule(row)
+}
+
+// UpdateFeeSchedule replaces a fee schedule's configuration
+func (s *feeScheduleService) UpdateFeeSchedule(ctx context.Context, scheduleID string, req interfaces.FeeScheduleRequest, updatedBy string) (*interfaces.FeeSchedule, error) {
+	existing, err := s.getFeeSchedule(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := s.buildFeeScheduleParams(ctx, existing.ID, req, updatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := s.queries.UpdateFeeSchedule(ctx, *params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update fee schedule: %w", err)
+	}
+
+	log.Info().
+		Int32("fee_schedule_id", row.ID).
+		Str("currency", row.Currency).
+		Str("fee_type", row.FeeType).
+		Str("updated_by", updatedBy).
+		Msg("Fee schedule updated")
+
+	return convertFeeSchedule(row)
+}
+
+// DeleteFeeSchedule removes a fee schedule; fees already collected are kept
+func (s *feeScheduleService) DeleteFeeSchedule(ctx context.Context, scheduleID string) error {
+	existing, err := s.getFeeSchedule(ctx, scheduleID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.queries.DeleteFeeSchedule(ctx, existing.ID); err != nil {
+		return fmt.Errorf("failed to delete fee schedule: %w", err)
+	}
+
+	log.Info().
+		Int32("fee_schedule_id", existing.ID).
+		Str("currency", existing.Currency).
+		Msg("Fee schedule deleted")
+
+	return nil
+}
+
+// PreviewFee computes the fee the active schedule charges on an amount
+func (s *feeScheduleService) PreviewFee(ctx context.Context, currency, amount string) (*interfaces.FeePreview, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	value, err := decimal.NewFromString(amount)
+	if err != nil || !value.IsPositive() {
+		return nil, fmt.Errorf("invalid fee schedule: amount must be a positive decimal")
+	}
+
+	preview := &interfaces.FeePreview{
+		Currency: currency,
+		Amount:   value.StringFixed(2),
+		Fee:      decimal.Zero.StringFixed(2),
+	}
+	fee := decimal.Zero
+
+	row, err := s.queries.GetActiveFeeSchedule(ctx, currency)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, fmt.Errorf("failed to get fee schedule: %w", err)
+	}
+	if err == nil {
+		schedule, err := feeScheduleModel(row)
+		if err != nil {
+			return nil, err
+		}
+
+		fee = schedule.Calculate(value)
+		id := strconv.Itoa(int(row.ID))
+		preview.Fee = fee.StringFixed(2)
+		preview.FeeScheduleID = &id
+		preview.FeeScheduleName = row.Name
+	}
+
+	preview.TotalDebit = value.Add(fee).StringFixed(2)
+	return preview, nil
+}
+
+// getFeeSchedule parses a schedule ID and loads the schedule
+func (s *feeScheduleService) getFeeSchedule(ctx context.Context, scheduleID string) (queries.FeeSchedule, error) {
+	id, err := strconv.ParseInt(scheduleID, 10, 32)
+	if err != nil {
+		return queries.FeeSchedule{}, fmt.Errorf("invalid fee schedule ID: %w", err)
+	}
+
+	row, err := s.queries.GetFeeSchedule(ctx, int32(id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return queries.FeeSchedule{}, fmt.Errorf("fee schedule not found")
+		}
+		return queries.FeeSchedule{}, fmt.Errorf("failed to get fee schedule: %w", err)
+	}
+
+	return row, nil
+}
+
+// buildFeeScheduleParams validates a request and converts it to database parameters.
+// scheduleID is the schedule being updated, or 0 when creating.
+func (s *feeScheduleService) buildFeeScheduleParams(ctx context.Context, scheduleID int32, req interfaces.FeeScheduleRequest, updatedBy string) (*queries.UpdateFeeScheduleParams, error) {
+	schedule, err := parseFeeScheduleRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := schedule.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid fee schedule: %w", err)
+	}
+
+	// Collected fees are credited to the house account, so it must hold the schedule's currency
+	account, err := s.queries.GetAccount(ctx, int32(schedule.HouseAccountID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("invalid fee schedule: house account not found")
+		}
+		return nil, fmt.Errorf("failed to get house account: %w", err)
+	}
+	if account.Currency != schedule.Currency {
+		return nil, fmt.Errorf("invalid fee schedule: house account currency %s does not match %s", account.Currency, schedule.Currency)
+	}
+
+	if schedule.IsActive {
+		active, err := s.queries.GetActiveFeeSchedule(ctx, schedule.Currency)
+		if err != nil && err != pgx.ErrNoRows {
+			return nil, fmt.Errorf("failed to get active fee schedule: %w", err)
+		}
+		if err == nil && active.ID != scheduleID {
+			return nil, fmt.Errorf("fee schedule conflict: schedule %d is already active for %s", active.ID, schedule.Currency)
+		}
+	}
+
+	tiers, err := json.Marshal(schedule.Tiers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode fee tiers: %w", err)
+	}
+
+	params := &queries.UpdateFeeScheduleParams{
+		ID:             scheduleID,
+		Name:           strings.TrimSpace(schedule.Name),
+		Currency:       schedule.Currency,
+		FeeType:        schedule.FeeType,
+		FlatAmount:     utils.ConvertDecimalToPgNumeric(schedule.FlatAmount),
+		Percentage:     utils.ConvertDecimalToPgNumeric(schedule.Percentage),
+		Tiers:          tiers,
+		HouseAccountID: int32(schedule.HouseAccountID),
+		IsActive:       schedule.IsActive,
+		UpdatedBy:      pgtype.Text{String: updatedBy, Valid: updatedBy != ""},
+	}
+	if schedule.MinFee != nil {
+		params.MinFee = utils.ConvertDecimalToPgNumeric(*schedule.MinFee)
+	}
+	if schedule.MaxFee != nil {
+		params.MaxFee = utils.ConvertDecimalToPgNumeric(*schedule.MaxFee)
+	}
+
+	return params, nil
+}
+
+// parseFeeScheduleRequest converts the string fields of a request to a fee schedule model
+func parseFeeScheduleRequest(req interfaces.FeeScheduleRequest) (*models.FeeSchedule, error) {
+	schedule := &models.FeeSchedule{
+		Name:     req.Name,
+		Currency: strings.ToUpper(strings.TrimSpace(req.Currency)),
+		FeeType:  strings.ToLower(strings.TrimSpace(req.FeeType)),
+		IsActive: req.IsActive == nil || *req.IsActive,
+	}
+
+	houseAccountID, err := strconv.Atoi(req.HouseAccountID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fee schedule: house_account_id must be a number")
+	}
+	schedule.HouseAccountID = houseAccountID
+
+	if schedule.FlatAmount, err = parseFeeDecimal("flat_amount", req.FlatAmount); err != nil {
+		return nil, err
+	}
+	if schedule.Percentage, err = parseFeeDecimal("percentage", req.Percentage); err != nil {
+		return nil, err
+	}
+	if req.MinFee != nil {
+		minFee, err := parseFeeDecimal("min_fee", *req.MinFee)
+		if err != nil {
+			return nil, err
+		}
+		schedule.MinFee = &minFee
+	}
+	if req.MaxFee != nil {
+		maxFee, err := parseFeeDecimal("max_fee", *req.MaxFee)
+		if err != nil {
+			return nil, err
+		}
+		schedule.MaxFee = &maxFee
+	}
+
+	for i, tier := range req.Tiers {
+		var parsed models.FeeTier
+		field := fmt.Sprintf("tiers[%d]", i)
+		if tier.UpTo != nil {
+			upTo, err := parseFeeDecimal(field+".up_to", *tier.UpTo)
+			if err != nil {
+				return nil, err
+			}
+			parsed.UpTo = &upTo
+		}
+		if parsed.FlatAmount, err = parseFeeDecimal(field+".flat_amount", tier.FlatAmount); err != nil {
+			return nil, err
+		}
+		if parsed.Percentage, err = parseFeeDecimal(field+".percentage", tier.Percentage); err != nil {
+			return nil, err
+		}
+		schedule.Tiers = append(schedule.Tiers, parsed)
+	}
+
+	return schedule, nil
+}
+
+// parseFeeDecimal parses an optional decimal field, treating empty as zero
+func parseFeeDecimal(field, raw string) (decimal.Decimal, error) {
+	if strings.TrimSpace(raw) == "" {
+		return decimal.Zero, nil
+	}
+
+	value, err := decimal.NewFromString(raw)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("invalid fee schedule: %s must be a decimal", field)
+	}
+	return value, nil
+}
+
+// feeScheduleModel converts a database fee schedule to the shared fee model
+func feeScheduleModel(row queries.FeeSchedule) (*models.FeeSchedule, error) {
+	schedule := &models.FeeSchedule{
+		ID:             int(row.ID),
+		Name:           row.Name,
+		Currency:       row.Currency,
+		FeeType:        row.FeeType,
+		HouseAccountID: int(row.HouseAccountID),
+		IsActive:       row.IsActive,
+	}
+
+	var err error
+	if schedule.FlatAmount, err = utils.ConvertPgNumericToDecimal(row.FlatAmount); err != nil {
+		return nil, fmt.Errorf("failed to convert flat amount: %w", err)
+	}
+	if schedule.Percentage, err = utils.ConvertPgNumericToDecimal(row.Percentage); err != nil {
+		return nil, fmt.Errorf("failed to convert percentage: %w", err)
+	}
+	if row.MinFee.Valid {
+		minFee, err := utils.ConvertPgNumericToDecimal(row.MinFee)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert minimum fee: %w", err)
+		}
+		schedule.MinFee = &minFee
+	}
+	if row.MaxFee.Valid {
+		maxFee, err := utils.ConvertPgNumericToDecimal(row.MaxFee)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert maximum fee: %w", err)
+		}
+		schedule.MaxFee = &maxFee
+	}
+	if len(row.Tiers) > 0 {
+		if err := json.Unmarshal(row.Tiers, &schedule.Tiers); err != nil {
+			return nil, fmt.Errorf("failed to decode fee tiers: %w", err)
+		}
+	}
+
+	return schedule, nil
+}
+
+// convertFeeSchedule converts a database fee schedule to its API representation
+func convertFeeSchedule(row queries.FeeSchedule) (*interfaces.FeeSchedule, error) {
+	model, err := feeScheduleModel(row)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := &interfaces.FeeSchedule{
+		ID:             strconv.Itoa(int(row.ID)),
+		Name:           row.Name,
+		Currency:       row.Currency,
+		FeeType:        row.FeeType,
+		FlatAmount:     model.FlatAmount.StringFixed(2),
+		Percentage:     model.Percentage.String(),
+		HouseAccountID: strconv.Itoa(int(row.HouseAccountID)),
+		IsActive:       row.IsActive,
+	}
+
+	if model.MinFee != nil {
+		value := model.MinFee.StringFixed(2)
+		schedule.MinFee = &value
+	}
+	if model.MaxFee != nil {
+		value := model.MaxFee.StringFixed(2)
+		schedule.MaxFee = &value
+	}
+	for _, tier := range model.Tiers {
+		converted := interfaces.FeeTier{
+			FlatAmount: tier.FlatAmount.StringFixed(2),
+			Percentage: tier.Percentage.String(),
+		}
+		if tier.UpTo != nil {
+			value := tier.UpTo.StringFixed(2)
+			converted.UpTo = &value
+		}
+		schedule.Tiers = append(schedule.Tiers, converted)
+	}
+	if row.UpdatedBy.Valid {
+		schedule.UpdatedBy = &row.UpdatedBy.String
+	}
+	if row.CreatedAt.Valid {
+		schedule.CreatedAt = row.CreatedAt.Time
+	}
+	if row.UpdatedAt.Valid {
+		schedule.UpdatedAt = row.UpdatedAt.Time
+	}
+
+	return schedule, nil
+}
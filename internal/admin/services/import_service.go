@@ -0,0 +1,911 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
+	"github.com/phantom-sage/bankgo/internal/database/queries"
+	"github.com/phantom-sage/bankgo/internal/utils"
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+)
+
+// Import types accepted by the bulk import endpoint
+const (
+	importTypeTransfers          = "transfers"
+	importTypeBalanceAdjustments = "balance_adjustments"
+)
+
+// Statuses of import jobs and their rows
+const (
+	importJobValidated           = "validated"
+	importJobCompleted           = "completed"
+	importJobCompletedWithErrors = "completed_with_errors"
+	importJobFailed              = "failed"
+
+	importRowValid     = "valid"
+	importRowInvalid   = "invalid"
+	importRowSucceeded = "succeeded"
+	importRowFailed    = "failed"
+)
+
+// maxImportRows caps the number of data rows in a single import file
+const maxImportRows = 10000
+
+// maxImportAmount is the largest absolute amount a DECIMAL(15,2) column holds
+var maxImportAmount = decimal.New(1, 13)
+
+// importLayout lists the CSV columns of an import type
+type importLayout struct {
+	required []string
+	optional []string
+}
+
+var importLayouts = map[string]importLayout{
+	importTypeTransfers: {
+		required: []string{"from_account_id", "to_account_id", "amount"},
+		optional: []string{"currency", "description"},
+	},
+	importTypeBalanceAdjustments: {
+		required: []string{"account_id", "amount", "reason"},
+		optional: []string{"currency"},
+	},
+}
+
+// importRow is a parsed data row of an import file. Account IDs are zero when
+// absent or unparseable; err holds the first problem found with the row.
+type importRow struct {
+	id            int32
+	number        int32
+	raw           string
+	accountID     int32
+	fromAccountID int32
+	toAccountID   int32
+	amount        decimal.Decimal
+	hasAmount     bool
+	currency      string
+	description   string
+	status        string
+	err           string
+}
+
+// reject marks the row invalid, keeping the first reason given
+func (r *importRow) reject(format string, args ...interface{}) {
+	if r.status == importRowInvalid {
+		return
+	}
+	r.status = importRowInvalid
+	r.err = fmt.Sprintf(format, args...)
+}
+
+// accountIDs returns the accounts the row touches
+func (r *importRow) accountIDs() []int32 {
+	ids := []int32{}
+	for _, id := range []int32{r.accountID, r.fromAccountID, r.toAccountID} {
+		if id > 0 {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// importRowError is a business rule failure of a single row during execution.
+// The row is marked failed and the import carries on with the next one.
+type importRowError struct {
+	message string
+}
+
+func (e *importRowError) Error() string {
+	return e.message
+}
+
+// importService implements the ImportService interface
+type importService struct {
+	db      *pgxpool.Pool
+	queries *queries.Queries
+}
+
+// NewImportService creates a new CSV bulk import service
+func NewImportService(db *pgxpool.Pool) interfaces.ImportService {
+	return &importService{
+		db:      db,
+		queries: queries.New(db),
+	}
+}
+
+// ValidateImport parses a CSV file and dry-runs every row against current balances
+func (s *importService) ValidateImport(ctx context.Context, importType, fileName string, file io.Reader, createdBy string) (*interfaces.ImportJob, error) {
+	header, rows, err := parseImportFile(importType, file)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []int32{}
+	for i := range rows {
+		ids = append(ids, rows[i].accountIDs()...)
+	}
+	accounts, err := s.queries.ListAccountsByIDs(ctx, uniqueImportAccountIDs(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load accounts: %w", err)
+	}
+	if err := validateImportRows(importType, rows, importAccountMap(accounts)); err != nil {
+		return nil, err
+	}
+
+	validRows := 0
+	params := queries.CreateImportJobRowsParams{
+		RowNumbers:     make([]int32, len(rows)),
+		RawLines:       make([]string, len(rows)),
+		AccountIds:     make([]int32, len(rows)),
+		FromAccountIds: make([]int32, len(rows)),
+		ToAccountIds:   make([]int32, len(rows)),
+		Amounts:        make([]pgtype.Numeric, len(rows)),
+		Currencies:     make([]string, len(rows)),
+		Descriptions:   make([]string, len(rows)),
+		Statuses:       make([]string, len(rows)),
+		ErrorMessages:  make([]string, len(rows)),
+	}
+	for i, row := range rows {
+		if row.status == importRowValid {
+			validRows++
+		}
+		params.RowNumbers[i] = row.number
+		params.RawLines[i] = row.raw
+		params.AccountIds[i] = row.accountID
+		params.FromAccountIds[i] = row.fromAccountID
+		params.ToAccountIds[i] = row.toAccountID
+		if row.hasAmount {
+			params.Amounts[i] = utils.ConvertDecimalToPgNumeric(row.amount)
+		}
+		params.Currencies[i] = row.currency
+		params.Descriptions[i] = row.description
+		params.Statuses[i] = row.status
+		params.ErrorMessages[i] = row.err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.queries.WithTx(tx)
+
+	job, err := qtx.CreateImportJob(ctx, queries.CreateImportJobParams{
+		ImportType:  importType,
+		FileName:    importFileName(fileName),
+		Header:      header,
+		TotalRows:   int32(len(rows)),
+		ValidRows:   int32(validRows),
+		InvalidRows: int32(len(rows) - validRows),
+		CreatedBy:   createdBy,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create import job: %w", err)
+	}
+
+	params.JobID = job.ID
+	if err := qtx.CreateImportJobRows(ctx, params); err != nil {
+		return nil, fmt.Errorf("failed to create import job rows: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit import job: %w", err)
+	}
+
+	log.Info().
+		Int32("job_id", job.ID).
+		Str("import_type", importType).
+		Int("total_rows", len(rows)).
+		Int("invalid_rows", len(rows)-validRows).
+		Str("created_by", createdBy).
+		Msg("Import file validated")
+
+	return s.getImportJob(ctx, job.ID)
+}
+
+// ExecuteImport books the valid rows of a validated import job. Each row is
+// re-checked under lock and booked in its own transaction, so a row that no
+// longer fits fails on its own without undoing the rows before it.
+func (s *importService) ExecuteImport(ctx context.Context, jobID string, req interfaces.ExecuteImportRequest, executedBy string) (*interfaces.ImportJob, error) {
+	id, err := strconv.Atoi(jobID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid import job ID: %w", err)
+	}
+
+	job, err := s.queries.GetImportJob(ctx, int32(id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("import job not found")
+		}
+		return nil, fmt.Errorf("failed to get import job: %w", err)
+	}
+	if job.Status != importJobValidated {
+		return nil, fmt.Errorf("import job already executed")
+	}
+	if job.ValidRows == 0 {
+		return nil, fmt.Errorf("import job has no valid rows to execute")
+	}
+	if job.InvalidRows > 0 && !req.AllowPartial {
+		return nil, fmt.Errorf("import job has %d invalid rows; set allow_partial to execute the valid rows only", job.InvalidRows)
+	}
+
+	job, err = s.queries.ClaimImportJob(ctx, queries.ClaimImportJobParams{
+		ID:         job.ID,
+		ExecutedBy: pgtype.Text{String: executedBy, Valid: executedBy != ""},
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("import job already executed")
+		}
+		return nil, fmt.Errorf("failed to claim import job: %w", err)
+	}
+
+	// The job is now claimed; finish it even if the admin's request goes away
+	// so that it is never left processing
+	ctx = context.WithoutCancel(ctx)
+
+	rows, err := s.queries.ListImportJobRows(ctx, job.ID)
+	if err != nil {
+		return nil, s.failImportJob(ctx, job.ID, fmt.Errorf("failed to list import job rows: %w", err))
+	}
+
+	var succeeded, failed int32
+	var stopErr error
+	for _, row := range rows {
+		if row.Status != importRowValid {
+			continue
+		}
+
+		err := s.executeImportRow(ctx, job, row)
+		var rowErr *importRowError
+		switch {
+		case err == nil:
+			succeeded++
+			continue
+		case errors.As(err, &rowErr):
+			err = s.queries.UpdateImportJobRow(ctx, queries.UpdateImportJobRowParams{
+				ID:           row.ID,
+				Status:       importRowFailed,
+				ErrorMessage: pgtype.Text{String: rowErr.message, Valid: true},
+			})
+			if err == nil {
+				failed++
+				continue
+			}
+		}
+
+		stopErr = fmt.Errorf("execution stopped at row %d: %w", row.RowNumber, err)
+		break
+	}
+
+	status := importJobStatus(job.InvalidRows, succeeded, failed)
+	errorMessage := pgtype.Text{}
+	if stopErr != nil {
+		status = importJobFailed
+		errorMessage = pgtype.Text{String: stopErr.Error(), Valid: true}
+		log.Error().Err(stopErr).Int32("job_id", job.ID).Msg("Import execution stopped")
+	}
+
+	if _, err := s.queries.CompleteImportJob(ctx, queries.CompleteImportJobParams{
+		ID:            job.ID,
+		Status:        status,
+		SucceededRows: succeeded,
+		FailedRows:    failed,
+		ErrorMessage:  errorMessage,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to complete import job: %w", err)
+	}
+
+	log.Info().
+		Int32("job_id", job.ID).
+		Str("status", status).
+		Int32("succeeded_rows", succeeded).
+		Int32("failed_rows", failed).
+		Str("executed_by", executedBy).
+		Msg("Import job executed")
+
+	return s.getImportJob(ctx, job.ID)
+}
+
+// GetImportJob returns an import job with the outcome of every row
+func (s *importService) GetImportJob(ctx context.Context, jobID string) (*interfaces.ImportJob, error) {
+	id, err := strconv.Atoi(jobID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid import job ID: %w", err)
+	}
+
+	return s.getImportJob(ctx, int32(id))
+}
+
+// ListImportJobs returns import jobs, most recent first
+func (s *importService) ListImportJobs(ctx context.Context, params interfaces.PaginationParams) (*interfaces.PaginatedImportJobs, error) {
+	if params.Page <= 0 {
+		params.Page = 1
+	}
+	if params.PageSize <= 0 {
+		params.PageSize = 20
+	}
+	if params.PageSize > 100 {
+		params.PageSize = 100
+	}
+
+	total, err := s.queries.CountImportJobs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count import jobs: %w", err)
+	}
+
+	rows, err := s.queries.ListImportJobs(ctx, queries.ListImportJobsParams{
+		Limit:  int32(params.PageSize),
+		Offset: int32((params.Page - 1) * params.PageSize),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list import jobs: %w", err)
+	}
+
+	jobs := make([]interfaces.ImportJob, 0, len(rows))
+	for _, row := range rows {
+		jobs = append(jobs, *convertImportJob(row, nil))
+	}
+
+	totalPages := int((total + int64(params.PageSize) - 1) / int64(params.PageSize))
+
+	return &interfaces.PaginatedImportJobs{
+		Jobs: jobs,
+		Pagination: interfaces.PaginationInfo{
+			Page:       params.Page,
+			PageSize:   params.PageSize,
+			TotalItems: int(total),
+			TotalPages: totalPages,
+			HasNext:    params.Page < totalPages,
+			HasPrev:    params.Page > 1,
+		},
+	}, nil
+}
+
+// WriteErrorReport writes the invalid and failed rows of an import job as CSV:
+// the row number, outcome and reason followed by the row as uploaded
+func (s *importService) WriteErrorReport(ctx context.Context, jobID string, w io.Writer) error {
+	id, err := strconv.Atoi(jobID)
+	if err != nil {
+		return fmt.Errorf("invalid import job ID: %w", err)
+	}
+
+	job, err := s.queries.GetImportJob(ctx, int32(id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return fmt.Errorf("import job not found")
+		}
+		return fmt.Errorf("failed to get import job: %w", err)
+	}
+
+	rows, err := s.queries.ListImportJobRows(ctx, job.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list import job rows: %w", err)
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(append([]string{"row_number", "status", "error"}, decodeImportLine(job.Header)...)); err != nil {
+		return fmt.Errorf("failed to write error report: %w", err)
+	}
+	for _, row := range rows {
+		if row.Status != importRowInvalid && row.Status != importRowFailed {
+			continue
+		}
+		record := append([]string{strconv.Itoa(int(row.RowNumber)), row.Status, row.ErrorMessage.String}, decodeImportLine(row.RawLine)...)
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write error report: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to write error report: %w", err)
+	}
+
+	return nil
+}
+
+// getImportJob loads an import job together with its rows
+func (s *importService) getImportJob(ctx context.Context, id int32) (*interfaces.ImportJob, error) {
+	job, err := s.queries.GetImportJob(ctx, id)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("import job not found")
+		}
+		return nil, fmt.Errorf("failed to get import job: %w", err)
+	}
+
+	rows, err := s.queries.ListImportJobRows(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list import job rows: %w", err)
+	}
+
+	return convertImportJob(job, rows), nil
+}
+
+// failImportJob marks a claimed job failed before any row was executed
+func (s *importService) failImportJob(ctx context.Context, id int32, cause error) error {
+	if _, err := s.queries.CompleteImportJob(ctx, queries.CompleteImportJobParams{
+		ID:           id,
+		Status:       importJobFailed,
+		ErrorMessage: pgtype.Text{String: cause.Error(), Valid: true},
+	}); err != nil {
+		log.Error().Err(err).Int32("job_id", id).Msg("Failed to mark import job failed")
+	}
+	return cause
+}
+
+// executeImportRow books a single row and records its success in one transaction.
+// Business rule failures are returned as *importRowError.
+func (s *importService) executeImportRow(ctx context.Context, job queries.ImportJob, stored queries.ImportJobRow) error {
+	row, err := importRowFromStored(stored)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.queries.WithTx(tx)
+
+	// Lock every account the row touches in ascending ID order, matching the
+	// transfer service, so imports cannot deadlock against live transfers
+	locked, err := qtx.LockAccountsForUpdate(ctx, uniqueImportAccountIDs(row.accountIDs()))
+	if err != nil {
+		return fmt.Errorf("failed to lock accounts: %w", err)
+	}
+	accounts := importAccountMap(locked)
+	balances, err := importBalances(accounts)
+	if err != nil {
+		return err
+	}
+	if message := checkImportRow(job.ImportType, row, accounts, balances); message != "" {
+		return &importRowError{message: message}
+	}
+
+	transferID := pgtype.Int4{}
+	amount := utils.ConvertDecimalToPgNumeric(row.amount)
+
+	switch job.ImportType {
+	case importTypeTransfers:
+		if _, err := qtx.SubtractFromBalance(ctx, queries.SubtractFromBalanceParams{ID: row.fromAccountID, Balance: amount}); err != nil {
+			return fmt.Errorf("failed to subtract from account %d: %w", row.fromAccountID, err)
+		}
+		if _, err := qtx.AddToBalance(ctx, queries.AddToBalanceParams{ID: row.toAccountID, Balance: amount}); err != nil {
+			return fmt.Errorf("failed to add to account %d: %w", row.toAccountID, err)
+		}
+
+		description := row.description
+		if description == "" {
+			description = fmt.Sprintf("Bulk import #%d row %d", job.ID, row.number)
+		}
+		transfer, err := qtx.CreateTransfer(ctx, queries.CreateTransferParams{
+			FromAccountID: row.fromAccountID,
+			ToAccountID:   row.toAccountID,
+			Amount:        amount,
+			Column4:       description,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create transfer: %w", err)
+		}
+		transferID = pgtype.Int4{Int32: transfer.ID, Valid: true}
+
+	case importTypeBalanceAdjustments:
+		if row.amount.IsPositive() {
+			_, err = qtx.AddToBalance(ctx, queries.AddToBalanceParams{ID: row.accountID, Balance: amount})
+		} else {
+			_, err = qtx.SubtractFromBalance(ctx, queries.SubtractFromBalanceParams{
+				ID:      row.accountID,
+				Balance: utils.ConvertDecimalToPgNumeric(row.amount.Neg()),
+			})
+		}
+		if err != nil {
+			return fmt.Errorf("failed to adjust balance of account %d: %w", row.accountID, err)
+		}
+	}
+
+	if err := qtx.UpdateImportJobRow(ctx, queries.UpdateImportJobRowParams{
+		ID:         row.id,
+		Status:     importRowSucceeded,
+		TransferID: transferID,
+	}); err != nil {
+		return fmt.Errorf("failed to update import job row: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit import row: %w", err)
+	}
+
+	return nil
+}
+
+// parseImportFile reads the header and data rows of an import file. Problems
+// with individual rows mark them invalid; problems with the file as a whole
+// are returned as errors.
+func parseImportFile(importType string, file io.Reader) (string, []importRow, error) {
+	layout, ok := importLayouts[importType]
+	if !ok {
+		return "", nil, fmt.Errorf("invalid import type: must be %s or %s", importTypeTransfers, importTypeBalanceAdjustments)
+	}
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return "", nil, fmt.Errorf("invalid import file: file is empty")
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid import file: %w", err)
+	}
+
+	// Spreadsheet exports often start with a byte order mark
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+
+	allowed := map[string]bool{}
+	for _, name := range append(layout.required, layout.optional...) {
+		allowed[name] = true
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !allowed[name] {
+			return "", nil, fmt.Errorf("invalid import file: unknown column %q", name)
+		}
+		if _, exists := columns[name]; exists {
+			return "", nil, fmt.Errorf("invalid import file: duplicate column %q", name)
+		}
+		columns[name] = i
+	}
+	for _, name := range layout.required {
+		if _, exists := columns[name]; !exists {
+			return "", nil, fmt.Errorf("invalid import file: missing required column %q", name)
+		}
+	}
+
+	rows := []importRow{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid import file: %w", err)
+		}
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+		if len(rows) == maxImportRows {
+			return "", nil, fmt.Errorf("invalid import file: more than %d rows", maxImportRows)
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := importRow{number: int32(line), raw: encodeImportLine(record), status: importRowValid}
+		if len(record) != len(header) {
+			row.reject("expected %d columns, got %d", len(header), len(record))
+		} else {
+			parseImportRow(importType, &row, func(name string) string {
+				if i, ok := columns[name]; ok {
+					return strings.TrimSpace(record[i])
+				}
+				return ""
+			})
+		}
+		rows = append(rows, row)
+	}
+
+	if len(rows) == 0 {
+		return "", nil, fmt.Errorf("invalid import file: no data rows")
+	}
+
+	return encodeImportLine(header), rows, nil
+}
+
+// parseImportRow parses and checks the fields of a single row in isolation
+func parseImportRow(importType string, row *importRow, field func(string) string) {
+	parseAccount := func(name string) int32 {
+		value := field(name)
+		if value == "" {
+			row.reject("%s is required", name)
+			return 0
+		}
+		id, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || id <= 0 {
+			row.reject("invalid %s %q", name, value)
+			return 0
+		}
+		return int32(id)
+	}
+
+	switch importType {
+	case importTypeTransfers:
+		row.fromAccountID = parseAccount("from_account_id")
+		row.toAccountID = parseAccount("to_account_id")
+		row.description = field("description")
+	case importTypeBalanceAdjustments:
+		row.accountID = parseAccount("account_id")
+		row.description = field("reason")
+	}
+
+	if value := field("amount"); value == "" {
+		row.reject("amount is required")
+	} else if amount, err := decimal.NewFromString(value); err != nil {
+		row.reject("invalid amount %q", value)
+	} else {
+		row.amount, row.hasAmount = amount, true
+		switch {
+		case !amount.Equal(amount.Round(2)):
+			row.reject("amount cannot have more than 2 decimal places")
+		case amount.Abs().GreaterThanOrEqual(maxImportAmount):
+			row.reject("amount is too large")
+		case importType == importTypeTransfers && !amount.IsPositive():
+			row.reject("amount must be positive")
+		case importType == importTypeBalanceAdjustments && amount.IsZero():
+			row.reject("amount cannot be zero")
+		}
+	}
+
+	if value := field("currency"); value != "" {
+		row.currency = strings.ToUpper(value)
+		if len(row.currency) != 3 {
+			row.reject("invalid currency %q", value)
+		}
+	}
+
+	if importType == importTypeTransfers && row.fromAccountID != 0 && row.fromAccountID == row.toAccountID {
+		row.reject("from_account_id and to_account_id must be different")
+	}
+	if importType == importTypeBalanceAdjustments && row.description == "" {
+		row.reject("reason is required")
+	}
+}
+
+// validateImportRows dry-runs the rows in file order against the given accounts,
+// carrying each valid row's effect forward so that later rows see the balances
+// the earlier ones would leave behind
+func validateImportRows(importType string, rows []importRow, accounts map[int32]queries.Account) error {
+	balances, err := importBalances(accounts)
+	if err != nil {
+		return err
+	}
+
+	for i := range rows {
+		row := &rows[i]
+		if row.status != importRowValid {
+			continue
+		}
+		if message := checkImportRow(importType, row, accounts, balances); message != "" {
+			row.reject("%s", message)
+		}
+	}
+
+	return nil
+}
+
+// checkImportRow checks a parsed row against account state and, when it passes,
+// applies its effect to balances. It returns the reason the row cannot be booked.
+func checkImportRow(importType string, row *importRow, accounts map[int32]queries.Account, balances map[int32]decimal.Decimal) string {
+	for _, id := range row.accountIDs() {
+		if _, ok := accounts[id]; !ok {
+			return fmt.Sprintf("unknown account %d", id)
+		}
+	}
+
+	debitAccount, creditAccount := row.fromAccountID, row.toAccountID
+	if importType == importTypeBalanceAdjustments {
+		debitAccount, creditAccount = row.accountID, row.accountID
+		if row.amount.IsPositive() {
+			debitAccount = 0
+		} else {
+			creditAccount = 0
+		}
+	}
+
+	currency := accounts[row.accountIDs()[0]].Currency
+	for _, id := range row.accountIDs() {
+		if accounts[id].Currency != currency {
+			return fmt.Sprintf("currency mismatch: account %d is %s, account %d is %s",
+				row.fromAccountID, accounts[row.fromAccountID].Currency, row.toAccountID, accounts[row.toAccountID].Currency)
+		}
+	}
+	if row.currency != "" && row.currency != currency {
+		return fmt.Sprintf("currency mismatch: row is %s, account is %s", row.currency, currency)
+	}
+
+	amount := row.amount.Abs()
+	if debitAccount != 0 && balances[debitAccount].LessThan(amount) {
+		return fmt.Sprintf("insufficient funds: account %d has %s %s available", debitAccount, balances[debitAccount].StringFixed(2), currency)
+	}
+
+	if debitAccount != 0 {
+		balances[debitAccount] = balances[debitAccount].Sub(amount)
+	}
+	if creditAccount != 0 {
+		balances[creditAccount] = balances[creditAccount].Add(amount)
+	}
+
+	return ""
+}
+
+// importJobStatus derives the final status of an executed job from its row counts
+func importJobStatus(invalidRows, succeededRows, failedRows int32) string {
+	switch {
+	case succeededRows == 0:
+		return importJobFailed
+	case invalidRows == 0 && failedRows == 0:
+		return importJobCompleted
+	default:
+		return importJobCompletedWithErrors
+	}
+}
+
+// importRowFromStored rebuilds a parsed row from its stored form
+func importRowFromStored(stored queries.ImportJobRow) (*importRow, error) {
+	amount, err := utils.ConvertPgNumericToDecimal(stored.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert amount of row %d: %w", stored.RowNumber, err)
+	}
+
+	return &importRow{
+		id:            stored.ID,
+		number:        stored.RowNumber,
+		accountID:     stored.AccountID.Int32,
+		fromAccountID: stored.FromAccountID.Int32,
+		toAccountID:   stored.ToAccountID.Int32,
+		amount:        amount,
+		hasAmount:     stored.Amount.Valid,
+		currency:      stored.Currency.String,
+		description:   stored.Description,
+		status:        stored.Status,
+	}, nil
+}
+
+// importAccountMap indexes accounts by ID
+func importAccountMap(accounts []queries.Account) map[int32]queries.Account {
+	byID := make(map[int32]queries.Account, len(accounts))
+	for _, account := range accounts {
+		byID[account.ID] = account
+	}
+	return byID
+}
+
+// importBalances returns the current balance of each account
+func importBalances(accounts map[int32]queries.Account) (map[int32]decimal.Decimal, error) {
+	balances := make(map[int32]decimal.Decimal, len(accounts))
+	for id, account := range accounts {
+		balance, err := utils.ConvertPgNumericToDecimal(account.Balance)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert balance of account %d: %w", id, err)
+		}
+		balances[id] = balance
+	}
+	return balances, nil
+}
+
+// uniqueImportAccountIDs returns the distinct account IDs in ascending order
+func uniqueImportAccountIDs(ids []int32) []int32 {
+	seen := make(map[int32]bool, len(ids))
+	unique := []int32{}
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	sort.Slice(unique, func(i, j int) bool { return unique[i] < unique[j] })
+	return unique
+}
+
+// importFileName keeps the base name of an uploaded file, within the column size
+func importFileName(fileName string) string {
+	name := filepath.Base(strings.ReplaceAll(fileName, "\\", "/"))
+	if name == "." || name == "/" {
+		return ""
+	}
+	if len(name) > 255 {
+		name = name[:255]
+	}
+	return name
+}
+
+// encodeImportLine re-encodes a CSV record as a single line
+func encodeImportLine(record []string) string {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	_ = writer.Write(record)
+	writer.Flush()
+	return strings.TrimRight(buf.String(), "\r\n")
+}
+
+// decodeImportLine splits a line stored by encodeImportLine back into fields
+func decodeImportLine(line string) []string {
+	record, err := csv.NewReader(strings.NewReader(line)).Read()
+	if err != nil {
+		return []string{line}
+	}
+	return record
+}
+
+// convertImportJob converts a database import job and its rows to the API representation
+func convertImportJob(job queries.ImportJob, rows []queries.ImportJobRow) *interfaces.ImportJob {
+	converted := &interfaces.ImportJob{
+		ID:            strconv.Itoa(int(job.ID)),
+		ImportType:    job.ImportType,
+		FileName:      job.FileName,
+		Status:        job.Status,
+		TotalRows:     int(job.TotalRows),
+		ValidRows:     int(job.ValidRows),
+		InvalidRows:   int(job.InvalidRows),
+		SucceededRows: int(job.SucceededRows),
+		FailedRows:    int(job.FailedRows),
+		CreatedBy:     job.CreatedBy,
+	}
+
+	if job.ExecutedBy.Valid {
+		converted.ExecutedBy = &job.ExecutedBy.String
+	}
+	if job.ErrorMessage.Valid {
+		converted.ErrorMessage = &job.ErrorMessage.String
+	}
+	if job.CreatedAt.Valid {
+		converted.CreatedAt = job.CreatedAt.Time
+	}
+	if job.StartedAt.Valid {
+		converted.StartedAt = &job.StartedAt.Time
+	}
+	if job.CompletedAt.Valid {
+		converted.CompletedAt = &job.CompletedAt.Time
+	}
+
+	optionalID := func(id pgtype.Int4) *string {
+		if !id.Valid {
+			return nil
+		}
+		value := strconv.Itoa(int(id.Int32))
+		return &value
+	}
+
+	for _, row := range rows {
+		convertedRow := interfaces.ImportJobRow{
+			RowNumber:     int(row.RowNumber),
+			AccountID:     optionalID(row.AccountID),
+			FromAccountID: optionalID(row.FromAccountID),
+			ToAccountID:   optionalID(row.ToAccountID),
+			Description:   row.Description,
+			Status:        row.Status,
+			TransferID:    optionalID(row.TransferID),
+		}
+		if row.Amount.Valid {
+			if amount, err := utils.ConvertPgNumericToDecimal(row.Amount); err == nil {
+				value := amount.StringFixed(2)
+				convertedRow.Amount = &value
+			}
+		}
+		if row.Currency.Valid {
+			convertedRow.Currency = &row.Currency.String
+		}
+		if row.ErrorMessage.Valid {
+			convertedRow.Error = &row.ErrorMessage.String
+		}
+		converted.Rows = append(converted.Rows, convertedRow)
+	}
+
+	return converted
+}
@@ -0,0 +1,159 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/phantom-sage/bankgo/internal/database/queries"
+	"github.com/phantom-sage/bankgo/internal/utils"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func importTestAccounts() map[int32]queries.Account {
+	account := func(id int32, currency string, balance int64) queries.Account {
+		return queries.Account{ID: id, Currency: currency, Balance: utils.ConvertDecimalToPgNumeric(decimal.NewFromInt(balance))}
+	}
+	return importAccountMap([]queries.Account{
+		account(1, "USD", 100),
+		account(2, "USD", 0),
+		account(3, "EUR", 50),
+	})
+}
+
+func TestParseImportFile_FileErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		importType string
+		file       string
+		message    string
+	}{
+		{"unknown type", "refunds", "account_id,amount\n1,10\n", "invalid import type"},
+		{"empty file", importTypeTransfers, "", "file is empty"},
+		{"unknown column", importTypeTransfers, "from_account_id,to_account_id,amount,memo\n1,2,10,x\n", `unknown column "memo"`},
+		{"missing column", importTypeBalanceAdjustments, "account_id,amount\n1,10\n", `missing required column "reason"`},
+		{"no data rows", importTypeTransfers, "from_account_id,to_account_id,amount\n\n", "no data rows"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := parseImportFile(tt.importType, strings.NewReader(tt.file))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.message)
+		})
+	}
+}
+
+func TestParseImportFile_RowErrors(t *testing.T) {
+	file := "\ufeffFrom_Account_ID, to_account_id, amount, currency, description\n" +
+		"1,2,10.50,usd,Refund\n" +
+		"1,1,10,,\n" +
+		"1,2,-5,,\n" +
+		"1,2,1.005,,\n" +
+		"x,2,10,,\n" +
+		"1,2,10\n" +
+		"\n" +
+		"1,2,10,DOLLARS,\n"
+
+	header, rows, err := parseImportFile(importTypeTransfers, strings.NewReader(file))
+	require.NoError(t, err)
+	assert.Equal(t, "From_Account_ID,to_account_id,amount,currency,description", header)
+	require.Len(t, rows, 7)
+
+	assert.Equal(t, importRowValid, rows[0].status)
+	assert.Equal(t, int32(2), rows[0].number)
+	assert.Equal(t, "USD", rows[0].currency)
+	assert.Equal(t, "Refund", rows[0].description)
+	assert.True(t, rows[0].amount.Equal(decimal.RequireFromString("10.50")))
+
+	errs := []string{}
+	for _, row := range rows[1:] {
+		assert.Equal(t, importRowInvalid, row.status)
+		errs = append(errs, row.err)
+	}
+	assert.Equal(t, []string{
+		"from_account_id and to_account_id must be different",
+		"amount must be positive",
+		"amount cannot have more than 2 decimal places",
+		`invalid from_account_id "x"`,
+		"expected 5 columns, got 3",
+		`invalid currency "DOLLARS"`,
+	}, errs)
+
+	// Blank lines are skipped but still counted in row numbers
+	assert.Equal(t, int32(9), rows[6].number)
+}
+
+func TestValidateImportRows_Transfers(t *testing.T) {
+	file := "from_account_id,to_account_id,amount,currency\n" +
+		"1,2,60,\n" +
+		"1,2,60,\n" +
+		"2,1,30,\n" +
+		"1,3,10,\n" +
+		"1,9,10,\n" +
+		"1,2,10,EUR\n"
+
+	_, rows, err := parseImportFile(importTypeTransfers, strings.NewReader(file))
+	require.NoError(t, err)
+	require.NoError(t, validateImportRows(importTypeTransfers, rows, importTestAccounts()))
+
+	results := []string{}
+	for _, row := range rows {
+		results = append(results, row.status+": "+row.err)
+	}
+	assert.Equal(t, []string{
+		"valid: ",
+		"invalid: insufficient funds: account 1 has 40.00 USD available",
+		// Account 2 was credited by the first row
+		"valid: ",
+		"invalid: currency mismatch: account 1 is USD, account 3 is EUR",
+		"invalid: unknown account 9",
+		"invalid: currency mismatch: row is EUR, account is USD",
+	}, results)
+}
+
+func TestValidateImportRows_BalanceAdjustments(t *testing.T) {
+	file := "account_id,amount,reason\n" +
+		"3,-50,Chargeback\n" +
+		"3,-0.01,Fee correction\n" +
+		"3,25,Goodwill credit\n" +
+		"3,-25,Fee correction\n" +
+		"4,10,Missing account\n" +
+		"3,0,Nothing\n" +
+		"3,5,\n"
+
+	_, rows, err := parseImportFile(importTypeBalanceAdjustments, strings.NewReader(file))
+	require.NoError(t, err)
+	require.NoError(t, validateImportRows(importTypeBalanceAdjustments, rows, importTestAccounts()))
+
+	results := []string{}
+	for _, row := range rows {
+		results = append(results, row.status+": "+row.err)
+	}
+	assert.Equal(t, []string{
+		"valid: ",
+		"invalid: insufficient funds: account 3 has 0.00 EUR available",
+		"valid: ",
+		"valid: ",
+		"invalid: unknown account 4",
+		"invalid: amount cannot be zero",
+		"invalid: reason is required",
+	}, results)
+}
+
+func TestImportJobStatus(t *testing.T) {
+	assert.Equal(t, importJobCompleted, importJobStatus(0, 3, 0))
+	assert.Equal(t, importJobCompletedWithErrors, importJobStatus(1, 3, 0))
+	assert.Equal(t, importJobCompletedWithErrors, importJobStatus(0, 2, 1))
+	assert.Equal(t, importJobFailed, importJobStatus(0, 0, 3))
+}
+
+func TestImportLineRoundTrip(t *testing.T) {
+	record := []string{"1", "2", "10.00", `Invoice "42", part 1`}
+	line := encodeImportLine(record)
+
+	assert.NotContains(t, line, "\n")
+	assert.Equal(t, record, decodeImportLine(line))
+	assert.Equal(t, "import.csv", importFileName(`C:\Users\ops\import.csv`))
+}
@@ -0,0 +1,199 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+)
+
+// DefaultReplayBufferSize is the number of recent notifications kept for
+// clients that reconnect
+const DefaultReplayBufferSize = 500
+
+// Redis keys shared by every process publishing admin notifications
+const (
+	notificationChannel     = "admin:notifications"
+	notificationSequenceKey = "admin:notifications:seq"
+	notificationBufferKey   = "admin:notifications:buffer"
+)
+
+// memoryNotificationBus delivers notifications within one process. It is used
+// when there is a single admin API replica or Redis is unavailable.
+type memoryNotificationBus struct {
+	mu         sync.RWMutex
+	sequence   int64
+	buffer     []*interfaces.Notification
+	bufferSize int
+	handlers   map[int]func(*interfaces.Notification)
+	nextID     int
+}
+
+// NewMemoryNotificationBus creates an in-process notification bus
+func NewMemoryNotificationBus(bufferSize int) interfaces.NotificationBus {
+	if bufferSize <= 0 {
+		bufferSize = DefaultReplayBufferSize
+	}
+	return &memoryNotificationBus{
+		bufferSize: bufferSize,
+		handlers:   make(map[int]func(*interfaces.Notification)),
+	}
+}
+
+// Publish buffers the notification and delivers it to subscribers before returning
+func (b *memoryNotificationBus) Publish(ctx context.Context, notification *interfaces.Notification) error {
+	b.mu.Lock()
+	b.sequence++
+	notification.Sequence = b.sequence
+	b.buffer = append(b.buffer, notification)
+	if len(b.buffer) > b.bufferSize {
+		b.buffer = b.buffer[len(b.buffer)-b.bufferSize:]
+	}
+	handlers := make([]func(*interfaces.Notification), 0, len(b.handlers))
+	for _, handler := range b.handlers {
+		handlers = append(handlers, handler)
+	}
+	b.mu.Unlock()
+
+	for _, handler := range handlers {
+		handler(notification)
+	}
+	return nil
+}
+
+// Subscribe registers handler for the lifetime of ctx
+func (b *memoryNotificationBus) Subscribe(ctx context.Context, handler func(*interfaces.Notification)) error {
+	b.mu.Lock()
+	id := b.nextID
+	b.nextID++
+	b.handlers[id] = handler
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		b.mu.Lock()
+		delete(b.handlers, id)
+		b.mu.Unlock()
+	}()
+	return nil
+}
+
+// Recent returns the replay buffer, oldest first
+func (b *memoryNotificationBus) Recent(ctx context.Context) ([]*interfaces.Notification, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	recent := make([]*interfaces.Notification, len(b.buffer))
+	copy(recent, b.buffer)
+	return recent, nil
+}
+
+// redisNotificationBus fans notifications out to every admin API replica over
+// Redis pub/sub. A Redis counter orders them across publishers and a capped
+// list holds the most recent ones for replay.
+type redisNotificationBus struct {
+	client     *redis.Client
+	bufferSize int
+}
+
+// NewRedisNotificationBus creates a notification bus on Redis. Publishers
+// that do not hold WebSocket connections, like the banking API, only publish.
+func NewRedisNotificationBus(client *redis.Client, bufferSize int) interfaces.NotificationBus {
+	if bufferSize <= 0 {
+		bufferSize = DefaultReplayBufferSize
+	}
+	return &redisNotificationBus{client: client, bufferSize: bufferSize}
+}
+
+// Publish numbers the notification, appends it to the replay buffer and
+// publishes it in one transaction so replicas never see it out of the buffer
+func (b *redisNotificationBus) Publish(ctx context.Context, notification *interfaces.Notification) error {
+	sequence, err := b.client.Incr(ctx, notificationSequenceKey).Result()
+	if err != nil {
+		return fmt.Errorf("failed to assign notification sequence: %w", err)
+	}
+	notification.Sequence = sequence
+
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+
+	_, err = b.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.LPush(ctx, notificationBufferKey, payload)
+		pipe.LTrim(ctx, notificationBufferKey, 0, int64(b.bufferSize-1))
+		pipe.Publish(ctx, notificationChannel, payload)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to publish notification: %w", err)
+	}
+
+	return nil
+}
+
+// Subscribe listens on the notification channel until ctx is done. It returns
+// once the subscription is confirmed; the client reconnects on its own after
+// connection errors, and notifications published meanwhile can be replayed.
+func (b *redisNotificationBus) Subscribe(ctx context.Context, handler func(*interfaces.Notification)) error {
+	pubsub := b.client.Subscribe(ctx, notificationChannel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return fmt.Errorf("failed to subscribe to notifications: %w", err)
+	}
+
+	go func() {
+		defer pubsub.Close()
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case message, ok := <-messages:
+				if !ok {
+					return
+				}
+				notification, err := decodeNotification(message.Payload)
+				if err != nil {
+					log.Warn().Err(err).Msg("Dropped undecodable notification")
+					continue
+				}
+				handler(notification)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Recent returns the replay buffer, oldest first
+func (b *redisNotificationBus) Recent(ctx context.Context) ([]*interfaces.Notification, error) {
+	payloads, err := b.client.LRange(ctx, notificationBufferKey, 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read notification buffer: %w", err)
+	}
+
+	// The list is newest first
+	recent := make([]*interfaces.Notification, 0, len(payloads))
+	for i := len(payloads) - 1; i >= 0; i-- {
+		notification, err := decodeNotification(payloads[i])
+		if err != nil {
+			continue
+		}
+		recent = append(recent, notification)
+	}
+	return recent, nil
+}
+
+// decodeNotification decodes a notification published on the bus
+func decodeNotification(payload string) (*interfaces.Notification, error) {
+	var notification interfaces.Notification
+	if err := json.Unmarshal([]byte(payload), &notification); err != nil {
+		return nil, fmt.Errorf("failed to decode notification: %w", err)
+	}
+	return &notification, nil
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryNotificationBus(t *testing.T) {
+	bus := NewMemoryNotificationBus(2)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var received []int64
+	require.NoError(t, bus.Subscribe(ctx, func(notification *interfaces.Notification) {
+		received = append(received, notification.Sequence)
+	}))
+
+	for _, id := range []string{"a", "b", "c"} {
+		require.NoError(t, bus.Publish(context.Background(), &interfaces.Notification{ID: id}))
+	}
+	assert.Equal(t, []int64{1, 2, 3}, received)
+
+	// Only the newest notifications are buffered
+	recent, err := bus.Recent(context.Background())
+	require.NoError(t, err)
+	require.Len(t, recent, 2)
+	assert.Equal(t, "b", recent[0].ID)
+	assert.Equal(t, "c", recent[1].ID)
+
+	// Handlers stop receiving once their context is done
+	cancel()
+	require.Eventually(t, func() bool {
+		before := len(received)
+		bus.Publish(context.Background(), &interfaces.Notification{ID: "d"})
+		return len(received) == before
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestNotificationService_Replay(t *testing.T) {
+	service := NewNotificationServiceWithBus(NewMemoryNotificationBus(3))
+	require.NoError(t, service.StartRelay(context.Background()))
+	ctx := context.Background()
+
+	// Sequences 1-4; the buffer keeps 2-4
+	for _, notification := range []*interfaces.Notification{
+		{ID: "alert-1", Type: "alert", Severity: "critical"},
+		{ID: "tx-1", Type: "transaction"},
+		{ID: "alert-2", Type: "alert", Severity: "info"},
+		{ID: "system-1", Type: "system"},
+	} {
+		require.NoError(t, service.Broadcast(ctx, notification))
+	}
+
+	serverConns := make(chan *websocket.Conn, 1)
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+		require.NoError(t, err)
+		defer conn.Close()
+		serverConns <- conn
+		<-done
+	}))
+	defer server.Close()
+	defer close(done)
+
+	client, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	require.NoError(t, err)
+	defer client.Close()
+	client.SetReadDeadline(time.Now().Add(5 * time.Second))
+	conn := <-serverConns
+
+	// Replay before subscribing fails
+	_, err = service.Replay(ctx, conn, 1)
+	assert.EqualError(t, err, "connection is not subscribed")
+
+	require.NoError(t, service.Subscribe(ctx, conn, "admin-1"))
+	var welcome interfaces.Notification
+	require.NoError(t, client.ReadJSON(&welcome))
+
+	require.NoError(t, service.SubscribeTopic(conn, interfaces.Subscription{Topic: interfaces.TopicAlerts}))
+
+	// Only notifications after lastSeen matching the subscriptions are resent
+	result, err := service.Replay(ctx, conn, 1)
+	require.NoError(t, err)
+	assert.Equal(t, &interfaces.ReplayResult{Replayed: 1, Complete: true, LastSequence: 4}, result)
+
+	var replayed interfaces.Notification
+	require.NoError(t, client.ReadJSON(&replayed))
+	assert.Equal(t, "alert-2", replayed.ID)
+	assert.Equal(t, int64(3), replayed.Sequence)
+
+	// Sequence 1 has left the buffer, so replay from 0 is incomplete
+	result, err = service.Replay(ctx, conn, 0)
+	require.NoError(t, err)
+	assert.False(t, result.Complete)
+	assert.Equal(t, 1, result.Replayed)
+}
+
+// redisTestClient connects to a local Redis, skipping the test when none is running
+func redisTestClient(t *testing.T) *redis.Client {
+	client := redis.NewClient(&redis.Options{Addr: "localhost:6379", DB: 15})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		t.Skip("Redis not available for testing")
+	}
+
+	client.Del(context.Background(), notificationSequenceKey, notificationBufferKey)
+	t.Cleanup(func() {
+		client.Del(context.Background(), notificationSequenceKey, notificationBufferKey)
+		client.Close()
+	})
+	return client
+}
+
+func TestRedisNotificationBus_FanOut(t *testing.T) {
+	client := redisTestClient(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Two replicas relay what a third process publishes
+	var mu sync.Mutex
+	received := map[string][]int64{}
+	for _, replica := range []string{"replica-a", "replica-b"} {
+		replica := replica
+		require.NoError(t, NewRedisNotificationBus(client, 2).Subscribe(ctx, func(notification *interfaces.Notification) {
+			mu.Lock()
+			defer mu.Unlock()
+			received[replica] = append(received[replica], notification.Sequence)
+		}))
+	}
+
+	publisher := NewRedisNotificationBus(client, 2)
+	for _, id := range []string{"a", "b", "c"} {
+		require.NoError(t, publisher.Publish(ctx, &interfaces.Notification{ID: id, Type: "alert"}))
+	}
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(received["replica-a"]) == 3 && len(received["replica-b"]) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []int64{1, 2, 3}, received["replica-a"])
+	assert.Equal(t, []int64{1, 2, 3}, received["replica-b"])
+
+	recent, err := publisher.Recent(ctx)
+	require.NoError(t, err)
+	require.Len(t, recent, 2)
+	assert.Equal(t, "b", recent[0].ID)
+	assert.Equal(t, int64(3), recent[1].Sequence)
+}
@@ -0,0 +1,320 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/smtp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
+)
+
+// Channel type identifiers
+const (
+	ChannelTypeEmail   = "email"
+	ChannelTypeWebhook = "webhook"
+	ChannelTypeSlack   = "slack"
+)
+
+// Webhook signature headers
+const (
+	WebhookSignatureHeader = "X-BankGo-Signature"
+	WebhookTimestampHeader = "X-BankGo-Timestamp"
+)
+
+// defaultChannelTimeout bounds every outbound HTTP delivery
+const defaultChannelTimeout = 10 * time.Second
+
+// sendMailFunc matches net/smtp.SendMail so tests can capture outgoing mail
+type sendMailFunc func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+
+// EmailChannel delivers alerts over SMTP
+type EmailChannel struct {
+	name     string
+	host     string
+	port     int
+	username string
+	password string
+	from     string
+	to       []string
+	sendMail sendMailFunc
+}
+
+// NewEmailChannel creates a new SMTP alert channel
+func NewEmailChannel(name, host string, port int, username, password, from string, to []string) *EmailChannel {
+	return &EmailChannel{
+		name:     name,
+		host:     host,
+		port:     port,
+		username: username,
+		password: password,
+		from:     from,
+		to:       to,
+		sendMail: smtp.SendMail,
+	}
+}
+
+// Name returns the channel name
+func (c *EmailChannel) Name() string {
+	return c.name
+}
+
+// Type returns the channel type
+func (c *EmailChannel) Type() string {
+	return ChannelTypeEmail
+}
+
+// Send delivers the alert as a plain text email to all recipients
+func (c *EmailChannel) Send(ctx context.Context, alert *interfaces.Alert) error {
+	if alert == nil {
+		return fmt.Errorf("alert cannot be nil")
+	}
+	if len(c.to) == 0 {
+		return fmt.Errorf("email channel %s has no recipients", c.name)
+	}
+
+	var auth smtp.Auth
+	if c.username != "" {
+		auth = smtp.PlainAuth("", c.username, c.password, c.host)
+	}
+
+	addr := fmt.Sprintf("%s:%d", c.host, c.port)
+	if err := c.sendMail(addr, auth, c.from, c.to, c.buildMessage(alert)); err != nil {
+		return fmt.Errorf("failed to send alert email via %s: %w", c.name, err)
+	}
+
+	return nil
+}
+
+// buildMessage renders the alert as an RFC 822 message
+func (c *EmailChannel) buildMessage(alert *interfaces.Alert) []byte {
+	var msg strings.Builder
+
+	msg.WriteString(fmt.Sprintf("From: %s\r\n", c.from))
+	msg.WriteString(fmt.Sprintf("To: %s\r\n", strings.Join(c.to, ", ")))
+	msg.WriteString(fmt.Sprintf("Subject: [%s] %s\r\n", strings.ToUpper(alert.Severity), alert.Title))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(fmt.Sprintf("%s\r\n\r\n", alert.Message))
+	msg.WriteString(fmt.Sprintf("Severity: %s\r\n", alert.Severity))
+	msg.WriteString(fmt.Sprintf("Source: %s\r\n", alert.Source))
+	msg.WriteString(fmt.Sprintf("Alert ID: %s\r\n", alert.ID))
+	msg.WriteString(fmt.Sprintf("Raised at: %s\r\n", alert.Timestamp.UTC().Format(time.RFC3339)))
+
+	if len(alert.Metadata) > 0 {
+		msg.WriteString("\r\nDetails:\r\n")
+		keys := make([]string, 0, len(alert.Metadata))
+		for key := range alert.Metadata {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			msg.WriteString(fmt.Sprintf("  %s: %v\r\n", key, alert.Metadata[key]))
+		}
+	}
+
+	return []byte(msg.String())
+}
+
+// WebhookChannel delivers alerts as signed JSON to a generic HTTP endpoint
+type WebhookChannel struct {
+	name   string
+	url    string
+	secret string
+	client *http.Client
+}
+
+// NewWebhookChannel creates a new generic webhook alert channel
+func NewWebhookChannel(name, url, secret string) *WebhookChannel {
+	return &WebhookChannel{
+		name:   name,
+		url:    url,
+		secret: secret,
+		client: &http.Client{Timeout: defaultChannelTimeout},
+	}
+}
+
+// Name returns the channel name
+func (c *WebhookChannel) Name() string {
+	return c.name
+}
+
+// Type returns the channel type
+func (c *WebhookChannel) Type() string {
+	return ChannelTypeWebhook
+}
+
+// webhookPayload is the JSON body posted to generic webhooks
+type webhookPayload struct {
+	Event  string            `json:"event"`
+	Alert  *interfaces.Alert `json:"alert"`
+	SentAt time.Time         `json:"sent_at"`
+}
+
+// Send posts the alert to the webhook URL with an HMAC-SHA256 signature
+func (c *WebhookChannel) Send(ctx context.Context, alert *interfaces.Alert) error {
+	if alert == nil {
+		return fmt.Errorf("alert cannot be nil")
+	}
+
+	body, err := json.Marshal(webhookPayload{
+		Event:  "alert.created",
+		Alert:  alert,
+		SentAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	headers := map[string]string{
+		WebhookTimestampHeader: timestamp,
+	}
+	if c.secret != "" {
+		headers[WebhookSignatureHeader] = "sha256=" + SignWebhookPayload(c.secret, timestamp, body)
+	}
+
+	return postJSON(ctx, c.client, c.url, body, headers)
+}
+
+// SignWebhookPayload computes the hex HMAC-SHA256 of "timestamp.body" with the shared secret
+func SignWebhookPayload(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SlackChannel delivers alerts to a Slack-compatible incoming webhook
+type SlackChannel struct {
+	name    string
+	url     string
+	channel string
+	client  *http.Client
+}
+
+// NewSlackChannel creates a new Slack-compatible alert channel
+func NewSlackChannel(name, url, channel string) *SlackChannel {
+	return &SlackChannel{
+		name:    name,
+		url:     url,
+		channel: channel,
+		client:  &http.Client{Timeout: defaultChannelTimeout},
+	}
+}
+
+// Name returns the channel name
+func (c *SlackChannel) Name() string {
+	return c.name
+}
+
+// Type returns the channel type
+func (c *SlackChannel) Type() string {
+	return ChannelTypeSlack
+}
+
+// slackMessage is the incoming webhook message format
+type slackMessage struct {
+	Channel     string            `json:"channel,omitempty"`
+	Text        string            `json:"text"`
+	Attachments []slackAttachment `json:"attachments"`
+}
+
+// slackAttachment is a legacy Slack attachment, also understood by Mattermost and Rocket.Chat
+type slackAttachment struct {
+	Color  string       `json:"color"`
+	Title  string       `json:"title"`
+	Text   string       `json:"text"`
+	Fields []slackField `json:"fields"`
+	Ts     int64        `json:"ts"`
+}
+
+// slackField is a short key/value pair shown in an attachment
+type slackField struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short"`
+}
+
+// Send posts the alert as a colour-coded Slack attachment
+func (c *SlackChannel) Send(ctx context.Context, alert *interfaces.Alert) error {
+	if alert == nil {
+		return fmt.Errorf("alert cannot be nil")
+	}
+
+	msg := slackMessage{
+		Channel: c.channel,
+		Text:    fmt.Sprintf("[%s] %s", strings.ToUpper(alert.Severity), alert.Title),
+		Attachments: []slackAttachment{
+			{
+				Color: slackSeverityColor(alert.Severity),
+				Title: alert.Title,
+				Text:  alert.Message,
+				Fields: []slackField{
+					{Title: "Severity", Value: alert.Severity, Short: true},
+					{Title: "Source", Value: alert.Source, Short: true},
+					{Title: "Alert ID", Value: alert.ID, Short: false},
+				},
+				Ts: alert.Timestamp.Unix(),
+			},
+		},
+	}
+
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal slack payload: %w", err)
+	}
+
+	return postJSON(ctx, c.client, c.url, body, nil)
+}
+
+// slackSeverityColor maps alert severity to an attachment colour
+func slackSeverityColor(severity string) string {
+	switch severity {
+	case "critical":
+		return "#d00000"
+	case "warning":
+		return "#f2c744"
+	default:
+		return "#439fe0"
+	}
+}
+
+// postJSON posts a JSON body and treats any non-2xx response as a failure
+func postJSON(ctx context.Context, client *http.Client, url string, body []byte, headers map[string]string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "bankgo-admin-alerts/1.0")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to deliver to %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("endpoint %s returned status %d: %s", url, resp.StatusCode, strings.TrimSpace(string(snippet)))
+	}
+
+	return nil
+}
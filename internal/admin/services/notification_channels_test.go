@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAlert(severity string) *interfaces.Alert {
+	return &interfaces.Alert{
+		ID:        "alert-123",
+		Severity:  severity,
+		Title:     "Database latency high",
+		Message:   "p99 latency above 500ms",
+		Source:    "system_monitor",
+		Timestamp: time.Now(),
+		Metadata:  map[string]interface{}{"latency_ms": 650},
+	}
+}
+
+func TestWebhookChannel_SendSignsPayload(t *testing.T) {
+	secret := "webhook-secret"
+	var gotBody []byte
+	var gotSignature, gotTimestamp string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = io.ReadAll(r.Body)
+		gotSignature = r.Header.Get(WebhookSignatureHeader)
+		gotTimestamp = r.Header.Get(WebhookTimestampHeader)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	channel := NewWebhookChannel("webhook", server.URL, secret)
+	err := channel.Send(context.Background(), newTestAlert("critical"))
+	require.NoError(t, err)
+
+	require.NotEmpty(t, gotTimestamp)
+	assert.Equal(t, "sha256="+SignWebhookPayload(secret, gotTimestamp, gotBody), gotSignature)
+
+	var payload map[string]interface{}
+	require.NoError(t, json.Unmarshal(gotBody, &payload))
+	assert.Equal(t, "alert.created", payload["event"])
+	alert := payload["alert"].(map[string]interface{})
+	assert.Equal(t, "alert-123", alert["id"])
+}
+
+func TestWebhookChannel_SendFailsOnNon2xx(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("boom"))
+	}))
+	defer server.Close()
+
+	channel := NewWebhookChannel("webhook", server.URL, "")
+	err := channel.Send(context.Background(), newTestAlert("warning"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "500")
+}
+
+func TestSlackChannel_Send(t *testing.T) {
+	var msg slackMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	channel := NewSlackChannel("slack", server.URL, "#ops")
+	err := channel.Send(context.Background(), newTestAlert("critical"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "#ops", msg.Channel)
+	assert.Equal(t, "[CRITICAL] Database latency high", msg.Text)
+	require.Len(t, msg.Attachments, 1)
+	assert.Equal(t, "#d00000", msg.Attachments[0].Color)
+}
+
+func TestEmailChannel_Send(t *testing.T) {
+	channel := NewEmailChannel("email", "smtp.example.com", 587, "", "", "alerts@example.com", []string{"ops@example.com"})
+
+	var gotAddr string
+	var gotTo []string
+	var gotMsg string
+	channel.sendMail = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		gotAddr = addr
+		gotTo = to
+		gotMsg = string(msg)
+		return nil
+	}
+
+	err := channel.Send(context.Background(), newTestAlert("critical"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "smtp.example.com:587", gotAddr)
+	assert.Equal(t, []string{"ops@example.com"}, gotTo)
+	assert.True(t, strings.Contains(gotMsg, "Subject: [CRITICAL] Database latency high"))
+	assert.True(t, strings.Contains(gotMsg, "latency_ms: 650"))
+}
+
+func TestEmailChannel_SendWithoutRecipients(t *testing.T) {
+	channel := NewEmailChannel("email", "smtp.example.com", 587, "", "", "alerts@example.com", nil)
+
+	err := channel.Send(context.Background(), newTestAlert("critical"))
+	assert.Error(t, err)
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -15,19 +16,51 @@ import (
 type NotificationServiceImpl struct {
 	// connections maps admin ID to their WebSocket connections
 	connections map[string][]*websocket.Conn
-	// connectionMutex protects the connections map
+	// clients holds the write lock and topic subscriptions of each connection
+	clients map[*websocket.Conn]*wsClient
+	// connectionMutex protects the connections and clients maps and subscriptions
 	connectionMutex sync.RWMutex
 	// connectionCount tracks total active connections
 	connectionCount int
+	// bus carries notifications to every replica, including this one
+	bus interfaces.NotificationBus
 }
 
-// NewNotificationService creates a new notification service
+// wsClient is the per-connection state of a subscribed WebSocket
+type wsClient struct {
+	// writeMutex serializes writes, which gorilla/websocket requires
+	writeMutex sync.Mutex
+	// subscriptions maps topics to filters; nil until the first subscription,
+	// while the connection still receives every notification
+	subscriptions map[string]interfaces.SubscriptionFilter
+}
+
+// NewNotificationService creates a notification service that delivers to the
+// WebSocket connections of this process only
 func NewNotificationService() interfaces.NotificationService {
+	service := NewNotificationServiceWithBus(NewMemoryNotificationBus(DefaultReplayBufferSize))
+	// The in-process bus cannot fail to subscribe
+	_ = service.StartRelay(context.Background())
+	return service
+}
+
+// NewNotificationServiceWithBus creates a notification service that publishes
+// on bus. Call StartRelay to deliver what is published to this process's
+// WebSocket connections; publish-only processes never do.
+func NewNotificationServiceWithBus(bus interfaces.NotificationBus) *NotificationServiceImpl {
 	return &NotificationServiceImpl{
 		connections: make(map[string][]*websocket.Conn),
+		clients:     make(map[*websocket.Conn]*wsClient),
+		bus:         bus,
 	}
 }
 
+// StartRelay delivers notifications published on the bus, by any process, to
+// the local WebSocket connections until ctx is done
+func (s *NotificationServiceImpl) StartRelay(ctx context.Context) error {
+	return s.bus.Subscribe(ctx, s.deliver)
+}
+
 // Subscribe adds a WebSocket connection to receive notifications
 func (s *NotificationServiceImpl) Subscribe(ctx context.Context, conn *websocket.Conn, adminID string) error {
 	if conn == nil {
@@ -48,6 +81,7 @@ func (s *NotificationServiceImpl) Subscribe(ctx context.Context, conn *websocket
 
 	// Add connection to admin's connection list
 	s.connections[adminID] = append(s.connections[adminID], conn)
+	s.clients[conn] = &wsClient{}
 	s.connectionCount++
 
 	log.Info().
@@ -95,6 +129,7 @@ func (s *NotificationServiceImpl) Unsubscribe(adminID string, conn *websocket.Co
 		if c == conn {
 			// Remove connection from slice
 			s.connections[adminID] = append(connections[:i], connections[i+1:]...)
+			delete(s.clients, conn)
 			s.connectionCount--
 
 			// Clean up empty admin entry
@@ -114,23 +149,44 @@ func (s *NotificationServiceImpl) Unsubscribe(adminID string, conn *websocket.Co
 	return fmt.Errorf("connection not found for admin %s", adminID)
 }
 
-// Broadcast sends a notification to all connected admins
+// Broadcast publishes a notification to the admins connected to every
+// replica whose subscriptions match it
 func (s *NotificationServiceImpl) Broadcast(ctx context.Context, notification *interfaces.Notification) error {
 	if notification == nil {
 		return fmt.Errorf("notification cannot be nil")
 	}
 
+	if err := s.bus.Publish(ctx, notification); err != nil {
+		return fmt.Errorf("failed to broadcast notification: %w", err)
+	}
+
+	return nil
+}
+
+// deliver sends a notification from the bus to the local connections whose
+// subscriptions match it
+func (s *NotificationServiceImpl) deliver(notification *interfaces.Notification) {
 	s.connectionMutex.RLock()
 	defer s.connectionMutex.RUnlock()
 
-	var errors []error
 	sentCount := 0
+	filteredCount := 0
+	errorCount := 0
 
 	// Send to all admin connections
 	for adminID, connections := range s.connections {
 		for _, conn := range connections {
+			if !s.clients[conn].wants(notification) {
+				filteredCount++
+				continue
+			}
 			if err := s.sendToConnection(conn, notification); err != nil {
-				errors = append(errors, fmt.Errorf("failed to send to admin %s: %w", adminID, err))
+				errorCount++
+				log.Warn().
+					Err(err).
+					Str("admin_id", adminID).
+					Str("notification_id", notification.ID).
+					Msg("Failed to send notification")
 				// Remove failed connection
 				go s.removeFailedConnection(adminID, conn)
 			} else {
@@ -142,16 +198,64 @@ func (s *NotificationServiceImpl) Broadcast(ctx context.Context, notification *i
 	log.Info().
 		Str("notification_id", notification.ID).
 		Str("notification_type", notification.Type).
+		Int64("sequence", notification.Sequence).
 		Int("sent_count", sentCount).
-		Int("error_count", len(errors)).
+		Int("filtered_count", filteredCount).
+		Int("error_count", errorCount).
 		Msg("Broadcast notification sent")
+}
 
-	// Return error if all sends failed
-	if len(errors) > 0 && sentCount == 0 {
-		return fmt.Errorf("failed to send notification to any admin: %v", errors)
+// Replay resends the buffered notifications published after lastSeen that the
+// connection's subscriptions match. Live notifications may arrive while it
+// runs, so clients should drop sequences they have already seen.
+func (s *NotificationServiceImpl) Replay(ctx context.Context, conn *websocket.Conn, lastSeen int64) (*interfaces.ReplayResult, error) {
+	recent, err := s.bus.Recent(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	s.connectionMutex.RLock()
+	defer s.connectionMutex.RUnlock()
+
+	client, exists := s.clients[conn]
+	if !exists {
+		return nil, fmt.Errorf("connection is not subscribed")
+	}
+
+	// Nothing was missed only if the buffer reaches back to just after lastSeen
+	result := &interfaces.ReplayResult{Complete: true}
+	if len(recent) > 0 {
+		oldest, newest := sequenceRange(recent)
+		result.Complete = oldest <= lastSeen+1
+		result.LastSequence = newest
+	}
+
+	for _, notification := range recent {
+		if notification.Sequence <= lastSeen || !client.wants(notification) {
+			continue
+		}
+		if err := s.sendToConnection(conn, notification); err != nil {
+			return result, fmt.Errorf("failed to replay notification: %w", err)
+		}
+		result.Replayed++
+	}
+
+	return result, nil
+}
+
+// sequenceRange returns the lowest and highest sequences among notifications;
+// publishers racing on Redis can buffer them slightly out of order
+func sequenceRange(notifications []*interfaces.Notification) (oldest, newest int64) {
+	oldest, newest = notifications[0].Sequence, notifications[0].Sequence
+	for _, notification := range notifications[1:] {
+		if notification.Sequence < oldest {
+			oldest = notification.Sequence
+		}
+		if notification.Sequence > newest {
+			newest = notification.Sequence
+		}
+	}
+	return oldest, newest
 }
 
 // SendToAdmin sends a notification to a specific admin
@@ -165,9 +269,9 @@ func (s *NotificationServiceImpl) SendToAdmin(ctx context.Context, adminID strin
 	}
 
 	s.connectionMutex.RLock()
-	connections, exists := s.connections[adminID]
-	s.connectionMutex.RUnlock()
+	defer s.connectionMutex.RUnlock()
 
+	connections, exists := s.connections[adminID]
 	if !exists || len(connections) == 0 {
 		return fmt.Errorf("admin %s has no active connections", adminID)
 	}
@@ -208,8 +312,14 @@ func (s *NotificationServiceImpl) GetConnectionCount() int {
 	return s.connectionCount
 }
 
-// sendToConnection sends a notification to a specific WebSocket connection
+// sendToConnection sends a notification to a specific WebSocket connection.
+// Callers hold connectionMutex.
 func (s *NotificationServiceImpl) sendToConnection(conn *websocket.Conn, notification *interfaces.Notification) error {
+	if client := s.clients[conn]; client != nil {
+		client.writeMutex.Lock()
+		defer client.writeMutex.Unlock()
+	}
+
 	// Set write deadline to prevent hanging
 	if err := conn.SetWriteDeadline(time.Now().Add(10 * time.Second)); err != nil {
 		return fmt.Errorf("failed to set write deadline: %w", err)
@@ -223,6 +333,96 @@ func (s *NotificationServiceImpl) sendToConnection(conn *websocket.Conn, notific
 	return nil
 }
 
+// SendToConnection writes a text message, such as a command reply, to one
+// connection without interleaving it with notifications
+func (s *NotificationServiceImpl) SendToConnection(conn *websocket.Conn, message []byte) error {
+	s.connectionMutex.RLock()
+	client, exists := s.clients[conn]
+	s.connectionMutex.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("connection is not subscribed")
+	}
+
+	client.writeMutex.Lock()
+	defer client.writeMutex.Unlock()
+
+	if err := conn.SetWriteDeadline(time.Now().Add(10 * time.Second)); err != nil {
+		return fmt.Errorf("failed to set write deadline: %w", err)
+	}
+	if err := conn.WriteMessage(websocket.TextMessage, message); err != nil {
+		return fmt.Errorf("failed to write message to websocket: %w", err)
+	}
+
+	return nil
+}
+
+// SubscribeTopic adds or replaces a connection's subscription to a topic
+func (s *NotificationServiceImpl) SubscribeTopic(conn *websocket.Conn, subscription interfaces.Subscription) error {
+	if err := validateSubscription(subscription); err != nil {
+		return err
+	}
+
+	s.connectionMutex.Lock()
+	defer s.connectionMutex.Unlock()
+
+	client, exists := s.clients[conn]
+	if !exists {
+		return fmt.Errorf("connection is not subscribed")
+	}
+
+	if client.subscriptions == nil {
+		client.subscriptions = make(map[string]interfaces.SubscriptionFilter)
+	}
+	client.subscriptions[subscription.Topic] = subscription.Filter
+
+	return nil
+}
+
+// UnsubscribeTopic removes a connection's subscription to a topic. Once a
+// connection has subscribed, removing its last topic leaves it receiving nothing.
+func (s *NotificationServiceImpl) UnsubscribeTopic(conn *websocket.Conn, topic string) error {
+	if !isNotificationTopic(topic) {
+		return fmt.Errorf("invalid subscription: unknown topic %q", topic)
+	}
+
+	s.connectionMutex.Lock()
+	defer s.connectionMutex.Unlock()
+
+	client, exists := s.clients[conn]
+	if !exists {
+		return fmt.Errorf("connection is not subscribed")
+	}
+
+	if client.subscriptions == nil {
+		client.subscriptions = make(map[string]interfaces.SubscriptionFilter)
+	}
+	delete(client.subscriptions, topic)
+
+	return nil
+}
+
+// GetSubscriptions returns a connection's topic subscriptions sorted by topic
+func (s *NotificationServiceImpl) GetSubscriptions(conn *websocket.Conn) []interfaces.Subscription {
+	s.connectionMutex.RLock()
+	defer s.connectionMutex.RUnlock()
+
+	client, exists := s.clients[conn]
+	if !exists {
+		return nil
+	}
+
+	subscriptions := make([]interfaces.Subscription, 0, len(client.subscriptions))
+	for topic, filter := range client.subscriptions {
+		subscriptions = append(subscriptions, interfaces.Subscription{Topic: topic, Filter: filter})
+	}
+	sort.Slice(subscriptions, func(i, j int) bool {
+		return subscriptions[i].Topic < subscriptions[j].Topic
+	})
+
+	return subscriptions
+}
+
 // removeFailedConnection removes a failed connection from the connections map
 func (s *NotificationServiceImpl) removeFailedConnection(adminID string, failedConn *websocket.Conn) {
 	s.connectionMutex.Lock()
@@ -237,6 +437,7 @@ func (s *NotificationServiceImpl) removeFailedConnection(adminID string, failedC
 	for i, conn := range connections {
 		if conn == failedConn {
 			s.connections[adminID] = append(connections[:i], connections[i+1:]...)
+			delete(s.clients, failedConn)
 			s.connectionCount--
 
 			// Clean up empty admin entry
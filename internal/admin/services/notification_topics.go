@@ -0,0 +1,150 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
+	"github.com/shopspring/decimal"
+)
+
+// notificationTopics maps notification types to the topic they are published on
+var notificationTopics = map[string]string{
+	"alert":         interfaces.TopicAlerts,
+	"alert_update":  interfaces.TopicAlerts,
+	"transaction":   interfaces.TopicTransactions,
+	"user_activity": interfaces.TopicUserActivity,
+	"system":        interfaces.TopicSystem,
+	"metrics":       interfaces.TopicSystem,
+}
+
+// isNotificationTopic reports whether topic can be subscribed to
+func isNotificationTopic(topic string) bool {
+	switch topic {
+	case interfaces.TopicAlerts, interfaces.TopicTransactions, interfaces.TopicUserActivity, interfaces.TopicSystem:
+		return true
+	}
+	return false
+}
+
+// validateSubscription checks a subscription's topic and filter values
+func validateSubscription(subscription interfaces.Subscription) error {
+	if !isNotificationTopic(subscription.Topic) {
+		return fmt.Errorf("invalid subscription: unknown topic %q", subscription.Topic)
+	}
+
+	for _, severity := range subscription.Filter.Severities {
+		switch severity {
+		case "critical", "warning", "info":
+		default:
+			return fmt.Errorf("invalid subscription: unknown severity %q", severity)
+		}
+	}
+
+	if subscription.Filter.MinAmount != "" {
+		amount, err := decimal.NewFromString(subscription.Filter.MinAmount)
+		if err != nil || amount.IsNegative() {
+			return fmt.Errorf("invalid subscription: min_amount must be a non-negative decimal")
+		}
+	}
+
+	return nil
+}
+
+// wants reports whether a connection should receive a notification. Clients
+// that never subscribed receive everything.
+func (c *wsClient) wants(notification *interfaces.Notification) bool {
+	if c == nil || c.subscriptions == nil {
+		return true
+	}
+
+	topic, ok := notificationTopics[notification.Type]
+	if !ok {
+		return false
+	}
+	filter, subscribed := c.subscriptions[topic]
+	return subscribed && filterMatches(topic, filter, notification)
+}
+
+// filterMatches applies a topic's filter to a notification published on it
+func filterMatches(topic string, filter interfaces.SubscriptionFilter, notification *interfaces.Notification) bool {
+	switch topic {
+	case interfaces.TopicAlerts:
+		// Updates to alerts carry no severity of their own and always pass
+		if notification.Type == "alert" && len(filter.Severities) > 0 {
+			return containsValue(filter.Severities, notification.Severity)
+		}
+	case interfaces.TopicTransactions:
+		if filter.MinAmount != "" {
+			minAmount, err := decimal.NewFromString(filter.MinAmount)
+			if err != nil {
+				return false
+			}
+			amount, ok := notificationAmount(notification)
+			if !ok || amount.Abs().LessThan(minAmount) {
+				return false
+			}
+		}
+		if filter.Currency != "" {
+			currency, _ := notificationField(notification, "currency").(string)
+			if !strings.EqualFold(currency, filter.Currency) {
+				return false
+			}
+		}
+	case interfaces.TopicUserActivity:
+		if filter.UserID != "" && fmt.Sprint(notificationField(notification, "user_id")) != filter.UserID {
+			return false
+		}
+		if len(filter.Actions) > 0 {
+			action, _ := notificationField(notification, "action").(string)
+			if !containsValue(filter.Actions, action) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// notificationField returns a field of a notification's data, looking in its
+// metadata when the data does not have it
+func notificationField(notification *interfaces.Notification, key string) interface{} {
+	if value, ok := notification.Data[key]; ok {
+		return value
+	}
+	if metadata, ok := notification.Data["metadata"].(map[string]interface{}); ok {
+		return metadata[key]
+	}
+	return nil
+}
+
+// notificationAmount returns the amount carried by a transaction notification
+func notificationAmount(notification *interfaces.Notification) (decimal.Decimal, bool) {
+	switch v := notificationField(notification, "amount").(type) {
+	case decimal.Decimal:
+		return v, true
+	case string:
+		amount, err := decimal.NewFromString(v)
+		return amount, err == nil
+	case json.Number:
+		amount, err := decimal.NewFromString(v.String())
+		return amount, err == nil
+	case float64:
+		return decimal.NewFromFloat(v), true
+	case int:
+		return decimal.NewFromInt(int64(v)), true
+	case int64:
+		return decimal.NewFromInt(v), true
+	}
+	return decimal.Decimal{}, false
+}
+
+// containsValue reports whether values contains value
+func containsValue(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
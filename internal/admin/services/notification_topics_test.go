@@ -0,0 +1,73 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotificationTopics_ValidateSubscription(t *testing.T) {
+	assert.NoError(t, validateSubscription(interfaces.Subscription{Topic: interfaces.TopicSystem}))
+	assert.NoError(t, validateSubscription(interfaces.Subscription{
+		Topic:  interfaces.TopicTransactions,
+		Filter: interfaces.SubscriptionFilter{MinAmount: "1000.50", Currency: "USD"},
+	}))
+
+	invalid := []interfaces.Subscription{
+		{Topic: "payments"},
+		{Topic: interfaces.TopicAlerts, Filter: interfaces.SubscriptionFilter{Severities: []string{"urgent"}}},
+		{Topic: interfaces.TopicTransactions, Filter: interfaces.SubscriptionFilter{MinAmount: "-1"}},
+		{Topic: interfaces.TopicTransactions, Filter: interfaces.SubscriptionFilter{MinAmount: "ten"}},
+	}
+	for _, subscription := range invalid {
+		err := validateSubscription(subscription)
+		if assert.Error(t, err, "expected %+v to be rejected", subscription) {
+			assert.Contains(t, err.Error(), "invalid subscription")
+		}
+	}
+}
+
+func TestNotificationTopics_Wants(t *testing.T) {
+	alert := func(severity string) *interfaces.Notification {
+		return &interfaces.Notification{Type: "alert", Severity: severity}
+	}
+	transaction := func(amount interface{}, currency string) *interfaces.Notification {
+		return &interfaces.Notification{Type: "transaction", Data: map[string]interface{}{
+			"transaction_id": "1",
+			"metadata":       map[string]interface{}{"amount": amount, "currency": currency},
+		}}
+	}
+	activity := &interfaces.Notification{Type: "user_activity", Data: map[string]interface{}{"user_id": "42", "action": "login"}}
+
+	// Clients that never subscribed receive everything
+	unfiltered := &wsClient{}
+	assert.True(t, unfiltered.wants(alert("info")))
+	assert.True(t, unfiltered.wants(&interfaces.Notification{Type: "custom"}))
+
+	client := &wsClient{subscriptions: map[string]interfaces.SubscriptionFilter{
+		interfaces.TopicAlerts:       {Severities: []string{"critical", "warning"}},
+		interfaces.TopicTransactions: {MinAmount: "1000", Currency: "usd"},
+		interfaces.TopicUserActivity: {UserID: "42", Actions: []string{"login"}},
+	}}
+
+	assert.True(t, client.wants(alert("critical")))
+	assert.False(t, client.wants(alert("info")))
+	assert.True(t, client.wants(&interfaces.Notification{Type: "alert_update", Severity: "info"}), "alert updates pass the severity filter")
+
+	assert.True(t, client.wants(transaction("2500.00", "USD")))
+	assert.True(t, client.wants(transaction(decimal.NewFromInt(-1500), "USD")), "debits count by their absolute amount")
+	assert.True(t, client.wants(transaction(float64(1000), "USD")))
+	assert.False(t, client.wants(transaction("999.99", "USD")))
+	assert.False(t, client.wants(transaction("5000", "EUR")))
+	assert.False(t, client.wants(transaction(nil, "USD")), "transactions without an amount fail a min_amount filter")
+
+	assert.True(t, client.wants(activity))
+	assert.False(t, client.wants(&interfaces.Notification{Type: "user_activity", Data: map[string]interface{}{"user_id": "7", "action": "login"}}))
+	assert.False(t, client.wants(&interfaces.Notification{Type: "user_activity", Data: map[string]interface{}{"user_id": "42", "action": "logout"}}))
+
+	// Topics not subscribed to and unknown types are dropped
+	assert.False(t, client.wants(&interfaces.Notification{Type: "system"}))
+	assert.False(t, client.wants(&interfaces.Notification{Type: "custom"}))
+}
@@ -0,0 +1,22 @@
+package services
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ReadPoolFunc returns the pool that read-only admin queries should use. Wire it
+// to database.DB.ReadPool so heavy searches run on the read replica while it is
+// within its lag limit and fall back to the primary otherwise.
+type ReadPoolFunc func() *pgxpool.Pool
+
+// readPoolOrPrimary resolves the pool for a read, using the primary when no
+// read pool is configured
+func readPoolOrPrimary(db *pgxpool.Pool, readPool ReadPoolFunc) *pgxpool.Pool {
+	if readPool == nil {
+		return db
+	}
+	if pool := readPool(); pool != nil {
+		return pool
+	}
+	return db
+}
@@ -0,0 +1,249 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
+	"github.com/phantom-sage/bankgo/internal/database/queries"
+	"github.com/phantom-sage/bankgo/internal/utils"
+	"github.com/rs/zerolog/log"
+)
+
+// Review statuses of transfer risk assessments
+const (
+	riskReviewPending  = "pending"
+	riskReviewApproved = "approved"
+	riskReviewRejected = "rejected"
+)
+
+// riskReviewService implements the RiskReviewService interface
+type riskReviewService struct {
+	db      *pgxpool.Pool
+	queries *queries.Queries
+}
+
+// NewRiskReviewService creates a new risk review service
+func NewRiskReviewService(db *pgxpool.Pool) interfaces.RiskReviewService {
+	return &riskReviewService{
+		db:      db,
+		queries: queries.New(db),
+	}
+}
+
+// ListReviews returns risk assessments matching the given filters
+func (s *riskReviewService) ListReviews(ctx context.Context, params interfaces.RiskReviewParams) (*interfaces.PaginatedRiskReviews, error) {
+	if params.Page <= 0 {
+		params.Page = 1
+	}
+	if params.PageSize <= 0 {
+		params.PageSize = 20
+	}
+	if params.PageSize > 100 {
+		params.PageSize = 100
+	}
+
+	reviewStatus := pgtype.Text{String: params.ReviewStatus, Valid: params.ReviewStatus != ""}
+	decision := pgtype.Text{String: params.Decision, Valid: params.Decision != ""}
+
+	total, err := s.queries.CountTransferRiskAssessments(ctx, queries.CountTransferRiskAssessmentsParams{
+		ReviewStatus: reviewStatus,
+		Decision:     decision,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count risk reviews: %w", err)
+	}
+
+	rows, err := s.queries.ListTransferRiskAssessments(ctx, queries.ListTransferRiskAssessmentsParams{
+		ReviewStatus: reviewStatus,
+		Decision:     decision,
+		LimitCount:   int32(params.PageSize),
+		OffsetCount:  int32((params.Page - 1) * params.PageSize),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list risk reviews: %w", err)
+	}
+
+	reviews := make([]interfaces.TransferRiskReview, 0, len(rows))
+	for _, row := range rows {
+		reviews = append(reviews, *convertRiskAssessment(row))
+	}
+
+	totalPages := int((total + int64(params.PageSize) - 1) / int64(params.PageSize))
+
+	return &interfaces.PaginatedRiskReviews{
+		Reviews: reviews,
+		Pagination: interfaces.PaginationInfo{
+			Page:       params.Page,
+			PageSize:   params.PageSize,
+			TotalItems: int(total),
+			TotalPages: totalPages,
+			HasNext:    params.Page < totalPages,
+			HasPrev:    params.Page > 1,
+		},
+	}, nil
+}
+
+// GetReview returns a single risk assessment
+func (s *riskReviewService) GetReview(ctx context.Context, reviewID string) (*interfaces.TransferRiskReview, error) {
+	id, err := strconv.Atoi(reviewID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid review ID: %w", err)
+	}
+
+	row, err := s.queries.GetTransferRiskAssessment(ctx, int32(id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("risk review not found")
+		}
+		return nil, fmt.Errorf("failed to get risk review: %w", err)
+	}
+
+	return convertRiskAssessment(row), nil
+}
+
+// ApproveReview clears a flagged transfer
+func (s *riskReviewService) ApproveReview(ctx context.Context, reviewID, reviewer, notes string) (*interfaces.TransferRiskReview, error) {
+	return s.review(ctx, reviewID, riskReviewApproved, reviewer, notes)
+}
+
+// RejectReview confirms a flagged transfer as fraudulent, optionally reversing
+// it. The assessment and transfer rows are locked and the reversal and review
+// outcome are committed together, so a transfer is reversed at most once and
+// never left reversed under a pending review.
+func (s *riskReviewService) RejectReview(ctx context.Context, reviewID, reviewer, notes string, reverse bool) (*interfaces.TransferRiskReview, error) {
+	id, err := strconv.Atoi(reviewID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid review ID: %w", err)
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := s.queries.WithTx(tx)
+
+	current, err := qtx.GetTransferRiskAssessmentForUpdate(ctx, int32(id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("risk review not found")
+		}
+		return nil, fmt.Errorf("failed to get risk review: %w", err)
+	}
+	if current.ReviewStatus != riskReviewPending {
+		return nil, fmt.Errorf("risk review already completed")
+	}
+
+	if reverse {
+		if !current.TransferID.Valid {
+			return nil, fmt.Errorf("risk review has no transfer to reverse")
+		}
+		if err := reverseTransfer(ctx, qtx, current.TransferID.Int32); err != nil {
+			return nil, fmt.Errorf("failed to reverse transfer: %w", err)
+		}
+	}
+
+	row, err := qtx.ReviewTransferRiskAssessment(ctx, queries.ReviewTransferRiskAssessmentParams{
+		ID:           int32(id),
+		ReviewStatus: riskReviewRejected,
+		ReviewedBy:   pgtype.Text{String: reviewer, Valid: reviewer != ""},
+		ReviewNotes:  pgtype.Text{String: notes, Valid: notes != ""},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update risk review: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit risk review: %w", err)
+	}
+
+	log.Info().
+		Str("review_id", reviewID).
+		Str("review_status", riskReviewRejected).
+		Str("reviewed_by", reviewer).
+		Bool("transfer_reversed", reverse).
+		Msg("Transfer risk review completed")
+
+	return convertRiskAssessment(row), nil
+}
+
+// review records a review outcome on a pending assessment
+func (s *riskReviewService) review(ctx context.Context, reviewID, status, reviewer, notes string) (*interfaces.TransferRiskReview, error) {
+	id, err := strconv.Atoi(reviewID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid review ID: %w", err)
+	}
+
+	row, err := s.queries.ReviewTransferRiskAssessment(ctx, queries.ReviewTransferRiskAssessmentParams{
+		ID:           int32(id),
+		ReviewStatus: status,
+		ReviewedBy:   pgtype.Text{String: reviewer, Valid: reviewer != ""},
+		ReviewNotes:  pgtype.Text{String: notes, Valid: notes != ""},
+	})
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			// Distinguish a missing review from one that is no longer pending
+			if _, getErr := s.GetReview(ctx, reviewID); getErr != nil {
+				return nil, getErr
+			}
+			return nil, fmt.Errorf("risk review already completed")
+		}
+		return nil, fmt.Errorf("failed to update risk review: %w", err)
+	}
+
+	log.Info().
+		Str("review_id", reviewID).
+		Str("review_status", status).
+		Str("reviewed_by", reviewer).
+		Msg("Transfer risk review completed")
+
+	return convertRiskAssessment(row), nil
+}
+
+// convertRiskAssessment converts a database risk assessment to its API representation
+func convertRiskAssessment(row queries.TransferRiskAssessment) *interfaces.TransferRiskReview {
+	review := &interfaces.TransferRiskReview{
+		ID:            strconv.Itoa(int(row.ID)),
+		UserID:        strconv.Itoa(int(row.UserID)),
+		FromAccountID: strconv.Itoa(int(row.FromAccountID)),
+		ToAccountID:   strconv.Itoa(int(row.ToAccountID)),
+		RiskScore:     int(row.RiskScore),
+		Decision:      row.Decision,
+		Reasons:       []interfaces.RiskReason{},
+		ReviewStatus:  row.ReviewStatus,
+	}
+
+	if row.TransferID.Valid {
+		transferID := strconv.Itoa(int(row.TransferID.Int32))
+		review.TransferID = &transferID
+	}
+	if amount, err := utils.ConvertPgNumericToDecimal(row.Amount); err == nil {
+		review.Amount = amount.StringFixed(2)
+	}
+	if len(row.Reasons) > 0 {
+		if err := json.Unmarshal(row.Reasons, &review.Reasons); err != nil {
+			log.Warn().Err(err).Int32("review_id", row.ID).Msg("Failed to unmarshal risk reasons")
+		}
+	}
+	if row.ReviewedBy.Valid {
+		review.ReviewedBy = &row.ReviewedBy.String
+	}
+	if row.ReviewedAt.Valid {
+		review.ReviewedAt = &row.ReviewedAt.Time
+	}
+	if row.ReviewNotes.Valid {
+		review.ReviewNotes = &row.ReviewNotes.String
+	}
+	if row.CreatedAt.Valid {
+		review.CreatedAt = row.CreatedAt.Time
+	}
+
+	return review
+}
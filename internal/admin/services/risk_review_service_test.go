@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/phantom-sage/bankgo/internal/database/queries"
+	"github.com/phantom-sage/bankgo/internal/utils"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRiskReviewService_ConcurrentRejectsReverseOnce rejects the same flagged
+// transfer from many admins at once and checks that it is reversed exactly
+// once, its fee is refunded and the review ends up rejected.
+// It needs a migrated database in TEST_DATABASE_URL.
+func TestRiskReviewService_ConcurrentRejectsReverseOnce(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" || testing.Short() {
+		t.Skip("Skipping risk review reversal test: TEST_DATABASE_URL not set")
+	}
+
+	const numReviewers = 8
+
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, dsn)
+	require.NoError(t, err)
+	defer pool.Close()
+
+	q := queries.New(pool)
+	amount := decimal.NewFromInt(50)
+	fee := decimal.NewFromInt(2)
+
+	// Seed the balances as they stand after the transfer and its fee
+	suffix := time.Now().UnixNano()
+	newAccount := func(name string, balance decimal.Decimal) queries.Account {
+		user, err := q.CreateUser(ctx, queries.CreateUserParams{
+			Email:        fmt.Sprintf("risk-%s-%d@example.com", name, suffix),
+			PasswordHash: "not-a-real-hash",
+			FirstName:    "Risk",
+			LastName:     "Test",
+		})
+		require.NoError(t, err)
+
+		account, err := q.CreateAccount(ctx, queries.CreateAccountParams{
+			UserID:   user.ID,
+			Currency: "SEK",
+			Column3:  utils.ConvertDecimalToPgNumeric(balance),
+		})
+		require.NoError(t, err)
+		return account
+	}
+	sender := newAccount("sender", decimal.NewFromInt(100))
+	recipient := newAccount("recipient", amount)
+	house := newAccount("house", fee)
+
+	transfer, err := q.CreateTransfer(ctx, queries.CreateTransferParams{
+		FromAccountID: sender.ID,
+		ToAccountID:   recipient.ID,
+		Amount:        utils.ConvertDecimalToPgNumeric(amount),
+		Column4:       "risk review test",
+		Column5:       "completed",
+	})
+	require.NoError(t, err)
+
+	_, err = q.CreateTransferFee(ctx, queries.CreateTransferFeeParams{
+		TransferID:     transfer.ID,
+		HouseAccountID: house.ID,
+		Amount:         utils.ConvertDecimalToPgNumeric(fee),
+		Currency:       "SEK",
+	})
+	require.NoError(t, err)
+
+	assessment, err := q.CreateTransferRiskAssessment(ctx, queries.CreateTransferRiskAssessmentParams{
+		TransferID:    pgtype.Int4{Int32: transfer.ID, Valid: true},
+		UserID:        sender.UserID,
+		FromAccountID: sender.ID,
+		ToAccountID:   recipient.ID,
+		Amount:        utils.ConvertDecimalToPgNumeric(amount),
+		RiskScore:     70,
+		Decision:      "review",
+		Reasons:       []byte("[]"),
+		ReviewStatus:  riskReviewPending,
+	})
+	require.NoError(t, err)
+
+	service := NewRiskReviewService(pool)
+	reviewID := fmt.Sprint(assessment.ID)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, numReviewers)
+	for i := 0; i < numReviewers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := service.RejectReview(ctx, reviewID, fmt.Sprintf("analyst-%d", i), "fraud", true)
+			errs <- err
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	succeeded := 0
+	for err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		assert.Contains(t, err.Error(), "already completed")
+	}
+	assert.Equal(t, 1, succeeded, "exactly one reject should win")
+
+	balance := func(id int32) string {
+		account, err := q.GetAccount(ctx, id)
+		require.NoError(t, err)
+		value, err := utils.ConvertPgNumericToDecimal(account.Balance)
+		require.NoError(t, err)
+		return value.StringFixed(2)
+	}
+	assert.Equal(t, "152.00", balance(sender.ID), "sender gets the amount and fee back")
+	assert.Equal(t, "0.00", balance(recipient.ID))
+	assert.Equal(t, "0.00", balance(house.ID))
+
+	refunded, err := q.GetTransferFee(ctx, transfer.ID)
+	require.NoError(t, err)
+	assert.True(t, refunded.RefundedAt.Valid)
+
+	review, err := service.GetReview(ctx, reviewID)
+	require.NoError(t, err)
+	assert.Equal(t, riskReviewRejected, review.ReviewStatus)
+}
@@ -0,0 +1,913 @@
+package services
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/phantom-sage/bankgo/internal/admin/config"
+	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
+	"github.com/phantom-sage/bankgo/internal/database/queries"
+	"github.com/rs/zerolog/log"
+)
+
+// Statuses recorded in the SQL console query log
+const (
+	queryLogSucceeded = "succeeded"
+	queryLogRejected  = "rejected"
+	queryLogFailed    = "failed"
+)
+
+// Result formats of the SQL console
+const (
+	consoleFormatJSON = "json"
+	consoleFormatCSV  = "csv"
+)
+
+// consoleTimeoutGrace is added to the statement timeout for the client-side
+// deadline, so PostgreSQL normally cancels the query first and says why
+const consoleTimeoutGrace = 5 * time.Second
+
+// consoleStatements are the statements the console accepts. Each can be
+// wrapped in a subquery, which is how the row cap is applied.
+var consoleStatements = map[string]bool{
+	"select": true,
+	"with":   true,
+	"values": true,
+	"table":  true,
+}
+
+// deniedConsoleFunctions have effects a read-only transaction does not
+// prevent, read files on the database server, or run SQL given as a string,
+// which the masked column check cannot see into
+var deniedConsoleFunctions = map[string]bool{
+	"pg_cancel_backend":       true,
+	"pg_terminate_backend":    true,
+	"pg_reload_conf":          true,
+	"pg_rotate_logfile":       true,
+	"pg_switch_wal":           true,
+	"pg_create_restore_point": true,
+	"pg_notify":               true,
+	"set_config":              true,
+	"pg_read_file":            true,
+	"pg_read_binary_file":     true,
+	"pg_ls_dir":               true,
+	"pg_stat_file":            true,
+	"lo_import":               true,
+	"lo_export":               true,
+	"dblink":                  true,
+	"ts_stat":                 true,
+}
+
+// deniedConsolePrefixes cover function families such as advisory locks and
+// the XML exports, which read whole tables or queries named in a string, in
+// all their _xmlschema and _and_xmlschema variants
+var deniedConsolePrefixes = []string{
+	"pg_advisory_", "pg_try_advisory_", "dblink_",
+	"table_to_xml", "query_to_xml", "cursor_to_xml", "schema_to_xml", "database_to_xml",
+}
+
+// deniedConsoleRelations hold column statistics, whose most common values and
+// histograms are samples of column contents, masked columns included
+var deniedConsoleRelations = map[string]bool{
+	"pg_statistic":          true,
+	"pg_statistic_ext_data": true,
+	"pg_stats":              true,
+	"pg_stats_ext":          true,
+	"pg_stats_ext_exprs":    true,
+}
+
+// sqlConsoleService implements the SQLConsoleService interface
+type sqlConsoleService struct {
+	db       *pgxpool.Pool
+	readPool ReadPoolFunc
+	queries  *queries.Queries
+	console  config.SQLConsoleConfig
+	browser  config.DataBrowserConfig
+}
+
+// NewSQLConsoleService creates a new read-only SQL console service. Queries run
+// on the read pool when one is configured; the query log is always written to
+// the primary.
+func NewSQLConsoleService(db *pgxpool.Pool, readPool ReadPoolFunc, console config.SQLConsoleConfig, browser config.DataBrowserConfig) interfaces.SQLConsoleService {
+	return &sqlConsoleService{
+		db:       db,
+		readPool: readPool,
+		queries:  queries.New(db),
+		console:  console,
+		browser:  browser,
+	}
+}
+
+// ExecuteQuery validates and runs a console query. Every query is recorded in
+// the query log whatever its outcome, and results are withheld when the log
+// entry cannot be written.
+func (s *sqlConsoleService) ExecuteQuery(ctx context.Context, req interfaces.SQLQueryRequest, executedBy string) (*interfaces.SQLQueryResult, error) {
+	entry := queries.CreateAdminQueryLogParams{
+		AdminUsername: executedBy,
+		QueryText:     req.Query,
+		ResultFormat:  consoleFormatJSON,
+		ClientIp:      pgtype.Text{String: req.ClientIP, Valid: req.ClientIP != ""},
+	}
+
+	statement, err := validateConsoleQuery(req.Query)
+	if err == nil {
+		switch format := strings.ToLower(strings.TrimSpace(req.Format)); format {
+		case "", consoleFormatJSON:
+		case consoleFormatCSV:
+			entry.ResultFormat = format
+		default:
+			err = fmt.Errorf("invalid query: format must be json or csv")
+		}
+	}
+	if err == nil {
+		err = s.checkMaskedReferences(ctx, statement)
+	}
+	if err != nil {
+		entry.Status = queryLogRejected
+		entry.ErrorMessage = pgtype.Text{String: err.Error(), Valid: true}
+		if _, logErr := s.recordQuery(ctx, entry); logErr != nil {
+			return nil, logErr
+		}
+		return nil, err
+	}
+
+	maxRows := s.console.MaxRows
+	if req.MaxRows > 0 && req.MaxRows < maxRows {
+		maxRows = req.MaxRows
+	}
+
+	start := time.Now()
+	result, err := s.runQuery(ctx, statement, maxRows)
+	entry.DurationMs = int32(time.Since(start).Milliseconds())
+
+	if err != nil {
+		entry.Status = queryLogFailed
+		entry.ErrorMessage = pgtype.Text{String: err.Error(), Valid: true}
+		if _, logErr := s.recordQuery(ctx, entry); logErr != nil {
+			return nil, logErr
+		}
+		return nil, err
+	}
+
+	entry.Status = queryLogSucceeded
+	entry.RowCount = int32(result.RowCount)
+	entry.Truncated = result.Truncated
+	logged, err := s.recordQuery(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	result.QueryLogID = strconv.Itoa(int(logged.ID))
+	result.DurationMs = int64(entry.DurationMs)
+	return result, nil
+}
+
+// ListQueryLog returns console queries, most recent first
+func (s *sqlConsoleService) ListQueryLog(ctx context.Context, params interfaces.QueryLogParams) (*interfaces.PaginatedQueryLog, error) {
+	if params.Page <= 0 {
+		params.Page = 1
+	}
+	if params.PageSize <= 0 {
+		params.PageSize = 20
+	}
+	if params.PageSize > 100 {
+		params.PageSize = 100
+	}
+
+	adminUsername := pgtype.Text{String: params.AdminUsername, Valid: params.AdminUsername != ""}
+
+	total, err := s.queries.CountAdminQueryLogs(ctx, adminUsername)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count query log: %w", err)
+	}
+
+	rows, err := s.queries.ListAdminQueryLogs(ctx, queries.ListAdminQueryLogsParams{
+		AdminUsername: adminUsername,
+		Limit:         int32(params.PageSize),
+		Offset:        int32((params.Page - 1) * params.PageSize),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list query log: %w", err)
+	}
+
+	entries := make([]interfaces.QueryLogEntry, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, convertQueryLogEntry(row))
+	}
+
+	totalPages := int((total + int64(params.PageSize) - 1) / int64(params.PageSize))
+
+	return &interfaces.PaginatedQueryLog{
+		Entries: entries,
+		Pagination: interfaces.PaginationInfo{
+			Page:       params.Page,
+			PageSize:   params.PageSize,
+			TotalItems: int(total),
+			TotalPages: totalPages,
+			HasNext:    params.Page < totalPages,
+			HasPrev:    params.Page > 1,
+		},
+	}, nil
+}
+
+// recordQuery writes a query log entry. It outlives the request context so
+// queries cancelled by a timeout are still recorded.
+func (s *sqlConsoleService) recordQuery(ctx context.Context, entry queries.CreateAdminQueryLogParams) (queries.AdminQueryLog, error) {
+	logged, err := s.queries.CreateAdminQueryLog(context.WithoutCancel(ctx), entry)
+	if err != nil {
+		log.Error().Err(err).
+			Str("admin", entry.AdminUsername).
+			Str("status", entry.Status).
+			Msg("Failed to record SQL console query")
+		return logged, fmt.Errorf("failed to record query in audit log: %w", err)
+	}
+
+	log.Info().
+		Int32("query_log_id", logged.ID).
+		Str("admin", entry.AdminUsername).
+		Str("status", entry.Status).
+		Int32("row_count", entry.RowCount).
+		Int32("duration_ms", entry.DurationMs).
+		Msg("SQL console query")
+	return logged, nil
+}
+
+// runQuery runs a validated statement in a read-only transaction that is
+// always rolled back, reading at most maxRows rows
+func (s *sqlConsoleService) runQuery(ctx context.Context, statement string, maxRows int) (*interfaces.SQLQueryResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.console.StatementTimeout+consoleTimeoutGrace)
+	defer cancel()
+
+	pool := readPoolOrPrimary(s.db, s.readPool)
+	tx, err := pool.BeginTx(ctx, pgx.TxOptions{AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin read-only transaction: %w", err)
+	}
+	defer tx.Rollback(context.WithoutCancel(ctx))
+
+	// SET does not take parameters; the timeout is a trusted integer
+	if _, err := tx.Exec(ctx, fmt.Sprintf("SET LOCAL statement_timeout = %d", s.console.StatementTimeout.Milliseconds())); err != nil {
+		return nil, fmt.Errorf("failed to set statement timeout: %w", err)
+	}
+	if s.console.Role != "" {
+		if _, err := tx.Exec(ctx, "SET LOCAL ROLE "+quoteIdent(s.console.Role)); err != nil {
+			return nil, fmt.Errorf("failed to assume SQL console role: %w", err)
+		}
+	}
+
+	rows, err := tx.Query(ctx, wrapConsoleQuery(statement, maxRows))
+	if err != nil {
+		return nil, s.queryError(err)
+	}
+
+	fields := append([]pgconn.FieldDescription(nil), rows.FieldDescriptions()...)
+	var values [][]interface{}
+	for rows.Next() {
+		row, err := rows.Values()
+		if err != nil {
+			rows.Close()
+			return nil, s.queryError(err)
+		}
+		values = append(values, row)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, s.queryError(err)
+	}
+
+	truncated := len(values) > maxRows
+	if truncated {
+		values = values[:maxRows]
+	}
+
+	sources, err := resolveSourceColumns(ctx, tx, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	typeMap := tx.Conn().TypeMap()
+	columns := make([]interfaces.SQLResultColumn, len(fields))
+	partial := make([]bool, len(fields))
+	for i, field := range fields {
+		source := sources[sourceKey{field.TableOID, field.TableAttributeNumber}]
+		columns[i] = interfaces.SQLResultColumn{
+			Name:   field.Name,
+			Type:   consoleTypeName(typeMap, field.DataTypeOID),
+			Masked: s.isMasked(field.Name, source),
+		}
+		partial[i] = columns[i].Masked && s.isPartiallyMasked(field.Name, source)
+	}
+
+	rowsOut := make([][]interface{}, len(values))
+	for i, row := range values {
+		converted := make([]interface{}, len(row))
+		for j, value := range row {
+			converted[j] = convertConsoleValue(value)
+			if partial[j] {
+				converted[j] = partiallyMaskValue(converted[j])
+			} else if columns[j].Masked {
+				converted[j] = maskValue(converted[j])
+			}
+		}
+		rowsOut[i] = converted
+	}
+
+	return &interfaces.SQLQueryResult{
+		Columns:   columns,
+		Rows:      rowsOut,
+		RowCount:  len(rowsOut),
+		Truncated: truncated,
+	}, nil
+}
+
+// queryError turns a failed console query into an error fit to show the admin
+func (s *sqlConsoleService) queryError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case "57014": // query_canceled, raised by statement_timeout
+			return fmt.Errorf("query timed out after %s", s.console.StatementTimeout)
+		case "25006": // read_only_sql_transaction
+			return fmt.Errorf("query failed: the SQL console is read-only: %s", pgErr.Message)
+		}
+		return fmt.Errorf("query failed: %s", pgErr.Message)
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("query timed out after %s", s.console.StatementTimeout)
+	}
+	return fmt.Errorf("failed to run query: %w", err)
+}
+
+// isMasked reports whether a result column is masked, either by its name or by
+// the table column it was read from. Queries that compute values from a masked
+// column, such as upper(password_hash), are rejected before they run by
+// checkMaskedReferences, so only plain SELECT * output reaches this check.
+func (s *sqlConsoleService) isMasked(name string, source *sourceColumn) bool {
+	if s.console.IsMasked("", name) {
+		return true
+	}
+	if source == nil {
+		return false
+	}
+	if s.console.IsMasked(source.table, source.column) {
+		return true
+	}
+	policy, ok := s.browser.Table(source.table)
+	return ok && (policy.IsHidden(source.column) || policy.IsMasked(source.column))
+}
+
+// isPartiallyMasked reports whether a masked result column keeps its last
+// characters. Any rule masking the column fully takes precedence.
+func (s *sqlConsoleService) isPartiallyMasked(name string, source *sourceColumn) bool {
+	partially, fully := false, false
+	apply := func(masked, partial bool) {
+		partially = partially || partial
+		fully = fully || (masked && !partial)
+	}
+
+	apply(s.console.IsMasked("", name), s.console.IsPartiallyMasked("", name))
+	if source != nil {
+		apply(s.console.IsMasked(source.table, source.column), s.console.IsPartiallyMasked(source.table, source.column))
+		if policy, ok := s.browser.Table(source.table); ok {
+			apply(policy.IsHidden(source.column), false)
+			apply(policy.IsMasked(source.column), policy.IsPartiallyMasked(source.column))
+		}
+	}
+	return partially && !fully
+}
+
+// sourceKey identifies a table column by the table OID and attribute number
+// PostgreSQL reports for each result field
+type sourceKey struct {
+	tableOID uint32
+	attnum   uint16
+}
+
+// sourceColumn is the table column a result field was read from
+type sourceColumn struct {
+	table  string
+	column string
+}
+
+// resolveSourceColumns looks up the table and column names behind result
+// fields. PostgreSQL follows aliases and subqueries, so a renamed column is
+// still traced to its table.
+func resolveSourceColumns(ctx context.Context, tx pgx.Tx, fields []pgconn.FieldDescription) (map[sourceKey]*sourceColumn, error) {
+	sources := make(map[sourceKey]*sourceColumn)
+
+	var tableOIDs []uint32
+	seen := make(map[uint32]bool)
+	for _, field := range fields {
+		if field.TableOID != 0 && !seen[field.TableOID] {
+			seen[field.TableOID] = true
+			tableOIDs = append(tableOIDs, field.TableOID)
+		}
+	}
+	if len(tableOIDs) == 0 {
+		return sources, nil
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT c.oid, c.relname, a.attnum, a.attname
+		FROM pg_attribute a
+		JOIN pg_class c ON c.oid = a.attrelid
+		WHERE a.attrelid = ANY($1::oid[]) AND a.attnum > 0`, tableOIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve result columns: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tableOID uint32
+		var attnum int16
+		var source sourceColumn
+		if err := rows.Scan(&tableOID, &source.table, &attnum, &source.column); err != nil {
+			return nil, fmt.Errorf("failed to resolve result columns: %w", err)
+		}
+		sources[sourceKey{tableOID, uint16(attnum)}] = &source
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to resolve result columns: %w", err)
+	}
+
+	return sources, nil
+}
+
+// checkMaskedReferences rejects a statement that names a masked column or
+// reads a whole row of a table holding one. Masking works on result columns,
+// so anything that reshapes such a value, like md5(password_hash) or
+// row_to_json(u), would otherwise return it unmasked.
+func (s *sqlConsoleService) checkMaskedReferences(ctx context.Context, statement string) error {
+	columns, tables, anyTable := s.maskedConsoleNames()
+	if len(columns) == 0 {
+		return nil
+	}
+
+	// Columns masked in every table make each table holding them sensitive
+	if len(anyTable) > 0 {
+		rows, err := readPoolOrPrimary(s.db, s.readPool).Query(ctx, `
+			SELECT DISTINCT c.relname
+			FROM pg_attribute a
+			JOIN pg_class c ON c.oid = a.attrelid
+			WHERE a.attname = ANY($1::text[]) AND a.attnum > 0 AND NOT a.attisdropped
+				AND c.relkind IN ('r', 'p', 'v', 'm', 'f')`, anyTable)
+		if err != nil {
+			return fmt.Errorf("failed to look up masked columns: %w", err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var table string
+			if err := rows.Scan(&table); err != nil {
+				return fmt.Errorf("failed to look up masked columns: %w", err)
+			}
+			tables[table] = true
+		}
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("failed to look up masked columns: %w", err)
+		}
+	}
+
+	return findMaskedReference(statement, columns, tables)
+}
+
+// maskedConsoleNames returns every masked or hidden column name, the tables
+// configured to hold one, and the column names masked in any table
+func (s *sqlConsoleService) maskedConsoleNames() (columns, tables map[string]bool, anyTable []string) {
+	columns = make(map[string]bool)
+	tables = make(map[string]bool)
+	for _, name := range append(append([]string(nil), s.console.MaskedColumns...), s.console.PartiallyMaskedColumns...) {
+		if table, column, ok := strings.Cut(name, "."); ok {
+			tables[table] = true
+			columns[column] = true
+			continue
+		}
+		columns[name] = true
+		anyTable = append(anyTable, name)
+	}
+	for table, policy := range s.browser.Tables {
+		for _, column := range append(append(append([]string(nil), policy.HiddenColumns...), policy.MaskedColumns...), policy.PartiallyMaskedColumns...) {
+			tables[table] = true
+			columns[column] = true
+		}
+	}
+	return columns, tables, anyTable
+}
+
+// consoleClauseKeywords start a clause or join, so they never name an alias
+var consoleClauseKeywords = map[string]bool{
+	"select": true, "from": true, "where": true, "group": true, "having": true,
+	"order": true, "limit": true, "offset": true, "window": true, "union": true,
+	"intersect": true, "except": true, "values": true, "table": true, "with": true,
+	"join": true, "inner": true, "left": true, "right": true, "full": true,
+	"cross": true, "natural": true, "on": true, "using": true, "fetch": true,
+	"for": true, "tablesample": true, "lateral": true, "returning": true,
+}
+
+// findMaskedReference checks a validated statement against masked columns and
+// the tables that hold them. It rejects any mention of a masked column, and,
+// once such a table is read, any use of it, its aliases, subqueries or CTEs
+// as a whole row or with a column alias list, which could rename a masked
+// column. Plain SELECT * stays allowed; its output is masked per column.
+func findMaskedReference(statement string, columns, tables map[string]bool) error {
+	tokens, _, err := scanConsoleQuery(statement)
+	if err != nil {
+		return err
+	}
+
+	readsMaskedTable := false
+	for _, token := range tokens {
+		if !token.ident {
+			continue
+		}
+		if columns[token.text] {
+			return fmt.Errorf("invalid query: column %s is masked and cannot be referenced; SELECT * returns it masked", token.text)
+		}
+		if tables[token.text] {
+			readsMaskedTable = true
+		}
+	}
+	if !readsMaskedTable {
+		return nil
+	}
+
+	// Find the names that can stand for a row holding a masked column, and
+	// the tokens where those names are introduced
+	rowNames := make(map[string]bool)
+	ctes := make(map[string]bool)
+	defs := make(map[int]bool)
+	clauses := []string{""}
+	var subqueries []bool
+	expectRelation, aliasPending := false, false
+	aliasListError := func(name string) error {
+		return fmt.Errorf("invalid query: column alias list on %s is not allowed in a query reading masked columns", name)
+	}
+
+	for i := 0; i < len(tokens); i++ {
+		if aliasPending {
+			aliasPending = false
+			j := i
+			if tokens[j].ident && tokens[j].text == "as" {
+				j++
+			}
+			if j < len(tokens) && tokens[j].ident && !consoleClauseKeywords[tokens[j].text] {
+				rowNames[tokens[j].text] = true
+				defs[j] = true
+				if j+1 < len(tokens) && tokens[j+1].text == "(" {
+					return aliasListError(tokens[j].text)
+				}
+				i = j
+				continue
+			}
+		}
+
+		token := tokens[i]
+		depth := len(clauses) - 1
+		switch {
+		case token.text == "(":
+			subqueries = append(subqueries, expectRelation)
+			clauses = append(clauses, "")
+			expectRelation = false
+		case token.text == ")":
+			if depth > 0 {
+				aliasPending = subqueries[len(subqueries)-1]
+				subqueries = subqueries[:len(subqueries)-1]
+				clauses = clauses[:depth]
+			}
+		case token.text == ",":
+			expectRelation = clauses[depth] == "from"
+		case !token.ident:
+			expectRelation = false
+		case expectRelation && (token.text == "lateral" || token.text == "only"):
+		case expectRelation:
+			expectRelation = false
+			j := i
+			for j+2 < len(tokens) && tokens[j+1].text == "." && tokens[j+2].ident {
+				defs[j] = true
+				j += 2
+			}
+			defs[j] = true
+			i = j
+			if j+1 < len(tokens) && tokens[j+1].text == "(" {
+				// A set-returning function, whose alias is not a table row
+				continue
+			}
+			if name := tokens[j].text; tables[name] || ctes[name] {
+				rowNames[name] = true
+				aliasPending = true
+			}
+		case clauses[depth] == "with" && token.text != "recursive" && (tokens[i-1].text == "with" || tokens[i-1].text == "recursive" || tokens[i-1].text == ","):
+			ctes[token.text] = true
+			rowNames[token.text] = true
+			defs[i] = true
+			if i+1 < len(tokens) && tokens[i+1].text == "(" {
+				return aliasListError(token.text)
+			}
+		case token.text == "from" || token.text == "join" || token.text == "table":
+			clauses[depth] = "from"
+			expectRelation = true
+		case consoleClauseKeywords[token.text]:
+			clauses[depth] = token.text
+		}
+	}
+
+	// Anywhere else, such a name may only qualify a column, as in u.email
+	for i, token := range tokens {
+		if !token.ident || !rowNames[token.text] || defs[i] {
+			continue
+		}
+		if i > 0 && tokens[i-1].text == "." {
+			continue
+		}
+		if i+2 < len(tokens) && tokens[i+1].text == "." && tokens[i+2].ident {
+			continue
+		}
+		return fmt.Errorf("invalid query: whole-row reference to %s is not allowed because it holds masked columns; select its columns instead", token.text)
+	}
+
+	return nil
+}
+
+// wrapConsoleQuery applies the row cap, fetching one extra row to detect
+// truncation. The newline keeps a trailing line comment from eating the
+// closing parenthesis.
+func wrapConsoleQuery(statement string, maxRows int) string {
+	return fmt.Sprintf("SELECT * FROM (\n%s\n) AS console_query LIMIT %d", statement, maxRows+1)
+}
+
+// validateConsoleQuery checks that a query is a single read statement and
+// returns it without its trailing semicolon. The read-only transaction is what
+// stops writes; this check keeps the console to one plain query.
+func validateConsoleQuery(query string) (string, error) {
+	statement := strings.TrimSpace(query)
+
+	tokens, end, err := scanConsoleQuery(statement)
+	if err != nil {
+		return "", err
+	}
+
+	var words []string
+	for _, token := range tokens {
+		if token.ident {
+			words = append(words, token.text)
+		}
+	}
+
+	if end >= 0 {
+		statement = strings.TrimSpace(statement[:end])
+	}
+	if len(words) == 0 {
+		return "", fmt.Errorf("invalid query: query is empty")
+	}
+	if !consoleStatements[words[0]] {
+		return "", fmt.Errorf("invalid query: only SELECT, WITH, VALUES and TABLE statements are allowed")
+	}
+
+	for _, word := range words {
+		if isDeniedConsoleFunction(word) {
+			return "", fmt.Errorf("invalid query: function %s is not allowed", word)
+		}
+		if deniedConsoleRelations[word] {
+			return "", fmt.Errorf("invalid query: relation %s is not allowed", word)
+		}
+	}
+
+	return statement, nil
+}
+
+// consoleToken is one token of a console query: a lowercased word, a quoted
+// identifier, a literal (reported as ' or 0) or a punctuation character
+type consoleToken struct {
+	text  string
+	ident bool
+}
+
+// scanConsoleQuery splits a statement into tokens and returns them with the
+// index of the terminating semicolon, or -1. It scans past comments, string
+// literals, quoted identifiers and dollar-quoted bodies so that semicolons and
+// keywords inside them are ignored.
+func scanConsoleQuery(statement string) ([]consoleToken, int, error) {
+	var tokens []consoleToken
+	end := -1
+	for i := 0; i < len(statement); {
+		ch := statement[i]
+
+		// Comments and whitespace may follow the terminating semicolon
+		switch {
+		case ch == '-' && strings.HasPrefix(statement[i:], "--"):
+			if newline := strings.IndexByte(statement[i:], '\n'); newline >= 0 {
+				i += newline + 1
+			} else {
+				i = len(statement)
+			}
+			continue
+		case ch == '/' && strings.HasPrefix(statement[i:], "/*"):
+			next, err := skipBlockComment(statement, i)
+			if err != nil {
+				return nil, 0, err
+			}
+			i = next
+			continue
+		case ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r' || ch == '\f':
+			i++
+			continue
+		case ch == ';':
+			if end < 0 {
+				end = i
+			}
+			i++
+			continue
+		}
+
+		if end >= 0 {
+			return nil, 0, fmt.Errorf("invalid query: only a single statement is allowed")
+		}
+
+		switch {
+		case ch == '\'':
+			// E'...' strings allow backslash escapes; the E is not a word
+			escapes := i > 0 && (statement[i-1] == 'e' || statement[i-1] == 'E') && (i < 2 || !isConsoleIdentChar(statement[i-2]))
+			if escapes {
+				tokens = tokens[:len(tokens)-1]
+			}
+			next, err := skipQuoted(statement, i, '\'', escapes)
+			if err != nil {
+				return nil, 0, err
+			}
+			tokens = append(tokens, consoleToken{text: "'"})
+			i = next
+		case ch == '"':
+			next, err := skipQuoted(statement, i, '"', false)
+			if err != nil {
+				return nil, 0, err
+			}
+			// Quoted identifiers name functions and columns too
+			tokens = append(tokens, consoleToken{text: strings.ReplaceAll(statement[i+1:next-1], `""`, `"`), ident: true})
+			i = next
+		case ch == '$':
+			next, err := skipDollarQuoted(statement, i)
+			if err != nil {
+				return nil, 0, err
+			}
+			tokens = append(tokens, consoleToken{text: "'"})
+			i = next
+		case isConsoleIdentChar(ch):
+			start := i
+			for i < len(statement) && isConsoleIdentChar(statement[i]) {
+				i++
+			}
+			if ch >= '0' && ch <= '9' {
+				tokens = append(tokens, consoleToken{text: "0"})
+			} else {
+				tokens = append(tokens, consoleToken{text: strings.ToLower(statement[start:i]), ident: true})
+			}
+		default:
+			tokens = append(tokens, consoleToken{text: string(ch)})
+			i++
+		}
+	}
+
+	return tokens, end, nil
+}
+
+// isDeniedConsoleFunction reports whether a word names a denied function
+func isDeniedConsoleFunction(word string) bool {
+	if deniedConsoleFunctions[word] {
+		return true
+	}
+	for _, prefix := range deniedConsolePrefixes {
+		if strings.HasPrefix(word, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// skipBlockComment returns the index after a possibly nested /* */ comment
+func skipBlockComment(statement string, start int) (int, error) {
+	depth := 0
+	for i := start; i < len(statement)-1; i++ {
+		switch statement[i : i+2] {
+		case "/*":
+			depth++
+			i++
+		case "*/":
+			depth--
+			i++
+			if depth == 0 {
+				return i + 1, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("invalid query: unterminated comment")
+}
+
+// skipQuoted returns the index after a quoted literal or identifier, where a
+// doubled quote stands for itself
+func skipQuoted(statement string, start int, quote byte, escapes bool) (int, error) {
+	for i := start + 1; i < len(statement); i++ {
+		switch statement[i] {
+		case '\\':
+			if escapes {
+				i++
+			}
+		case quote:
+			if i+1 < len(statement) && statement[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid query: unterminated quoted string")
+}
+
+// skipDollarQuoted returns the index after a $tag$...$tag$ body. A dollar sign
+// that does not open one, such as a $1 placeholder, is skipped on its own.
+func skipDollarQuoted(statement string, start int) (int, error) {
+	i := start + 1
+	if i < len(statement) && statement[i] >= '0' && statement[i] <= '9' {
+		return i, nil
+	}
+	for i < len(statement) && isConsoleIdentChar(statement[i]) && statement[i] != '$' {
+		i++
+	}
+	if i >= len(statement) || statement[i] != '$' {
+		return start + 1, nil
+	}
+
+	tag := statement[start : i+1]
+	closing := strings.Index(statement[i+1:], tag)
+	if closing < 0 {
+		return 0, fmt.Errorf("invalid query: unterminated dollar-quoted string")
+	}
+	return i + 1 + closing + len(tag), nil
+}
+
+// isConsoleIdentChar reports whether ch may appear in an unquoted identifier
+func isConsoleIdentChar(ch byte) bool {
+	return ch == '_' || ch == '$' || ch >= 0x80 ||
+		(ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') || (ch >= '0' && ch <= '9')
+}
+
+// consoleTypeName returns the PostgreSQL type name of a result column
+func consoleTypeName(typeMap *pgtype.Map, oid uint32) string {
+	if t, ok := typeMap.TypeForOID(oid); ok {
+		return t.Name
+	}
+	return strconv.FormatUint(uint64(oid), 10)
+}
+
+// convertConsoleValue converts a decoded value to one that serializes the way
+// PostgreSQL prints it; numerics become strings so no precision is lost
+func convertConsoleValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return fmt.Sprintf("\\x%x", v)
+	case [16]byte:
+		return fmt.Sprintf("%x-%x-%x-%x-%x", v[0:4], v[4:6], v[6:8], v[8:10], v[10:16])
+	case time.Time, string, bool, int16, int32, int64, float32, float64:
+		return v
+	case driver.Valuer:
+		if converted, err := v.Value(); err == nil {
+			return converted
+		}
+	}
+	return value
+}
+
+// convertQueryLogEntry converts a query log row to its API form
+func convertQueryLogEntry(row queries.AdminQueryLog) interfaces.QueryLogEntry {
+	entry := interfaces.QueryLogEntry{
+		ID:            strconv.Itoa(int(row.ID)),
+		AdminUsername: row.AdminUsername,
+		Query:         row.QueryText,
+		Status:        row.Status,
+		Format:        row.ResultFormat,
+		RowCount:      int(row.RowCount),
+		Truncated:     row.Truncated,
+		DurationMs:    int(row.DurationMs),
+	}
+
+	if row.ErrorMessage.Valid {
+		entry.Error = &row.ErrorMessage.String
+	}
+	if row.ClientIp.Valid {
+		entry.ClientIP = &row.ClientIp.String
+	}
+	if row.CreatedAt.Valid {
+		entry.CreatedAt = row.CreatedAt.Time
+	}
+
+	return entry
+}
@@ -0,0 +1,212 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/phantom-sage/bankgo/internal/admin/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLConsole_ValidateQuery(t *testing.T) {
+	accepted := map[string]string{
+		"SELECT 1":                               "SELECT 1",
+		"  select id from users;  ":              "select id from users",
+		"SELECT 1;; -- trailing comment":         "SELECT 1",
+		"WITH t AS (SELECT 1) SELECT * FROM t":   "WITH t AS (SELECT 1) SELECT * FROM t",
+		"(SELECT 1) UNION (SELECT 2)":            "(SELECT 1) UNION (SELECT 2)",
+		"VALUES (1), (2)":                        "VALUES (1), (2)",
+		"TABLE accounts":                         "TABLE accounts",
+		"/* a; b */ SELECT ';' AS semi":          "/* a; b */ SELECT ';' AS semi",
+		"SELECT 'it''s; fine', \"odd;name\"":     "SELECT 'it''s; fine', \"odd;name\"",
+		"SELECT E'\\'; DROP TABLE users; --'":    "SELECT E'\\'; DROP TABLE users; --'",
+		"SELECT $tag$ ; DELETE $tag$, $$x;y$$":   "SELECT $tag$ ; DELETE $tag$, $$x;y$$",
+		"SELECT 'pg_terminate_backend(1)'":       "SELECT 'pg_terminate_backend(1)'",
+		"SELECT a$b FROM t /* /* nested */ ; */": "SELECT a$b FROM t /* /* nested */ ; */",
+	}
+	for query, expected := range accepted {
+		statement, err := validateConsoleQuery(query)
+		if assert.NoError(t, err, "expected %q to be accepted", query) {
+			assert.Equal(t, expected, statement)
+		}
+	}
+
+	rejected := map[string]string{
+		"":                                              "query is empty",
+		"-- only a comment":                             "query is empty",
+		"SELECT 1; SELECT 2":                            "single statement",
+		"SELECT 1; DROP TABLE users":                    "single statement",
+		"DELETE FROM users":                             "only SELECT",
+		"UPDATE accounts SET balance = 0":               "only SELECT",
+		"EXPLAIN ANALYZE SELECT 1":                      "only SELECT",
+		"SET statement_timeout = 0":                     "only SELECT",
+		"SELECT 'unterminated":                          "unterminated quoted string",
+		"SELECT $a$ open":                               "unterminated dollar-quoted",
+		"SELECT 1 /* open":                              "unterminated comment",
+		"SELECT pg_terminate_backend(42)":               "pg_terminate_backend is not allowed",
+		"SELECT PG_CATALOG.PG_READ_FILE('x')":           "pg_read_file is not allowed",
+		"SELECT pg_advisory_lock(1)":                    "pg_advisory_lock is not allowed",
+		`SELECT "set_config"('a', 'b', false)`:          "set_config is not allowed",
+		"SELECT table_to_xml('users', true, false, '')": "table_to_xml is not allowed",
+		"SELECT table_to_xml_and_xmlschema('users', true, false, '')":           "table_to_xml_and_xmlschema is not allowed",
+		"SELECT query_to_xml('select * from users', true, false, '')":           "query_to_xml is not allowed",
+		"SELECT query_to_xmlschema('select 1', true, false, '')":                "query_to_xmlschema is not allowed",
+		"SELECT cursor_to_xml('c', 10, true, false, '')":                        "cursor_to_xml is not allowed",
+		"SELECT pg_catalog.schema_to_xml('public', true, false, '')":            "schema_to_xml is not allowed",
+		"SELECT database_to_xml_and_xmlschema(true, false, '')":                 "database_to_xml_and_xmlschema is not allowed",
+		"SELECT * FROM ts_stat('select to_tsvector(password_hash) from users')": "ts_stat is not allowed",
+		"SELECT most_common_vals FROM pg_stats WHERE attname = 'password_hash'": "relation pg_stats is not allowed",
+		"SELECT stavalues1 FROM pg_catalog.pg_statistic":                        "relation pg_statistic is not allowed",
+	}
+	for query, message := range rejected {
+		_, err := validateConsoleQuery(query)
+		if assert.Error(t, err, "expected %q to be rejected", query) {
+			assert.Contains(t, err.Error(), "invalid query")
+			assert.Contains(t, err.Error(), message)
+		}
+	}
+}
+
+func TestSQLConsole_WrapQuery(t *testing.T) {
+	assert.Equal(t, "SELECT * FROM (\nSELECT 1 -- note\n) AS console_query LIMIT 101", wrapConsoleQuery("SELECT 1 -- note", 100))
+}
+
+func TestSQLConsole_IsMasked(t *testing.T) {
+	s := &sqlConsoleService{
+		console: config.SQLConsoleConfig{MaskedColumns: []string{"password_hash", "accounts.iban"}},
+		browser: config.DataBrowserConfig{Tables: map[string]config.TablePolicy{
+			"users": {MaskedColumns: []string{"tax_id"}, HiddenColumns: []string{"secret"}},
+		}},
+	}
+
+	// Masked by output name, even when computed
+	assert.True(t, s.isMasked("password_hash", nil))
+
+	// Masked through the source column when aliased
+	assert.True(t, s.isMasked("h", &sourceColumn{table: "users", column: "password_hash"}))
+	assert.True(t, s.isMasked("account_iban", &sourceColumn{table: "accounts", column: "iban"}))
+	assert.True(t, s.isMasked("t", &sourceColumn{table: "users", column: "tax_id"}))
+	assert.True(t, s.isMasked("s", &sourceColumn{table: "users", column: "secret"}))
+
+	assert.False(t, s.isMasked("iban", &sourceColumn{table: "transfers", column: "iban"}))
+	assert.False(t, s.isMasked("email", &sourceColumn{table: "users", column: "email"}))
+	assert.False(t, s.isMasked("count", nil))
+}
+
+func TestSQLConsole_IsPartiallyMasked(t *testing.T) {
+	s := &sqlConsoleService{
+		console: config.SQLConsoleConfig{
+			MaskedColumns:          []string{"password_hash"},
+			PartiallyMaskedColumns: []string{"accounts.iban", "password_hash", "users.tax_id"},
+		},
+		browser: config.DataBrowserConfig{Tables: map[string]config.TablePolicy{
+			"users":    {MaskedColumns: []string{"tax_id"}, HiddenColumns: []string{"secret"}},
+			"accounts": {PartiallyMaskedColumns: []string{"account_number"}},
+		}},
+	}
+
+	// Configured in the console or the data browser, even when aliased
+	assert.True(t, s.isPartiallyMasked("iban", &sourceColumn{table: "accounts", column: "iban"}))
+	assert.True(t, s.isPartiallyMasked("n", &sourceColumn{table: "accounts", column: "account_number"}))
+	assert.True(t, s.isMasked("n", &sourceColumn{table: "accounts", column: "account_number"}))
+
+	// Masked fully by default and wherever any rule masks fully
+	assert.False(t, s.isPartiallyMasked("t", &sourceColumn{table: "users", column: "tax_id"}))
+	assert.False(t, s.isPartiallyMasked("s", &sourceColumn{table: "users", column: "secret"}))
+	assert.False(t, s.isPartiallyMasked("password_hash", nil))
+}
+
+func TestSQLConsole_ConvertValue(t *testing.T) {
+	var amount pgtype.Numeric
+	require.NoError(t, amount.Scan("1234.50"))
+
+	now := time.Now()
+	assert.Nil(t, convertConsoleValue(nil))
+	assert.Equal(t, "1234.50", convertConsoleValue(amount))
+	assert.Equal(t, now, convertConsoleValue(now))
+	assert.Equal(t, int64(7), convertConsoleValue(int64(7)))
+	assert.Equal(t, `\xdead`, convertConsoleValue([]byte{0xde, 0xad}))
+	assert.Equal(t, "00112233-4455-6677-8899-aabbccddeeff",
+		convertConsoleValue([16]byte{0x00, 0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff}))
+}
+
+func TestSQLConsole_QueryError(t *testing.T) {
+	s := &sqlConsoleService{console: config.SQLConsoleConfig{StatementTimeout: 10 * time.Second}}
+
+	assert.EqualError(t, s.queryError(&pgconn.PgError{Code: "57014", Message: "canceling statement due to statement timeout"}),
+		"query timed out after 10s")
+	assert.EqualError(t, s.queryError(fmt.Errorf("read: %w", context.DeadlineExceeded)), "query timed out after 10s")
+	assert.EqualError(t, s.queryError(&pgconn.PgError{Code: "25006", Message: "cannot execute nextval() in a read-only transaction"}),
+		"query failed: the SQL console is read-only: cannot execute nextval() in a read-only transaction")
+	assert.EqualError(t, s.queryError(&pgconn.PgError{Code: "42P01", Message: `relation "nope" does not exist`}),
+		`query failed: relation "nope" does not exist`)
+}
+
+func TestSQLConsole_FindMaskedReference(t *testing.T) {
+	columns := map[string]bool{"password_hash": true, "iban": true}
+	tables := map[string]bool{"users": true, "accounts": true}
+
+	accepted := []string{
+		"SELECT * FROM users",
+		"SELECT id, email FROM users WHERE id = 1",
+		"SELECT u.id, u.email FROM users u JOIN transfers t ON t.from_account_id = u.id",
+		"SELECT count(*) FROM users",
+		"TABLE users",
+		"SELECT t FROM transfers t",
+		"SELECT row_to_json(t) FROM transfers AS t",
+		"WITH recent AS (SELECT id FROM transfers) SELECT * FROM users, recent",
+		"SELECT x FROM generate_series(1, 3) AS g(x) JOIN users ON users.id = x",
+		"SELECT 'password_hash' AS label, users.email FROM users",
+	}
+	for _, query := range accepted {
+		assert.NoError(t, findMaskedReference(query, columns, tables), "expected %q to be accepted", query)
+	}
+
+	rejected := map[string]string{
+		"SELECT password_hash FROM users":                                       "column password_hash is masked",
+		"SELECT md5(password_hash) FROM users":                                  "column password_hash is masked",
+		"SELECT string_agg(password_hash, ',') FROM users":                      "column password_hash is masked",
+		`SELECT upper(u."password_hash") FROM users u`:                          "column password_hash is masked",
+		"SELECT id FROM users WHERE password_hash LIKE '$2a$%'":                 "column password_hash is masked",
+		"SELECT * FROM accounts ORDER BY iban":                                  "column iban is masked",
+		"SELECT row_to_json(u) FROM users u":                                    "whole-row reference to u",
+		"SELECT u FROM users u":                                                 "whole-row reference to u",
+		"SELECT users FROM users":                                               "whole-row reference to users",
+		"SELECT to_jsonb(users.*) FROM public.users":                            "whole-row reference to users",
+		"SELECT u.* FROM users AS u":                                            "whole-row reference to u",
+		"SELECT json_agg(t) FROM (SELECT * FROM users) t":                       "whole-row reference to t",
+		"WITH x AS (SELECT * FROM users) SELECT row_to_json(x) FROM x":          "whole-row reference to x",
+		"WITH RECURSIVE x AS (SELECT * FROM users) SELECT (x).* FROM x":         "whole-row reference to x",
+		"SELECT string_agg(c, ',') FROM users u(a, b, c)":                       "column alias list on u",
+		"SELECT md5(c) FROM (SELECT * FROM users) AS t(a, b, c)":                "column alias list on t",
+		"WITH x(a, b, c) AS (SELECT * FROM users) SELECT md5(c) FROM x":         "column alias list on x",
+		"SELECT a.id FROM accounts a, LATERAL (SELECT * FROM users) AS s(x, y)": "column alias list on s",
+	}
+	for query, message := range rejected {
+		err := findMaskedReference(query, columns, tables)
+		if assert.Error(t, err, "expected %q to be rejected", query) {
+			assert.Contains(t, err.Error(), "invalid query")
+			assert.Contains(t, err.Error(), message)
+		}
+	}
+}
+
+func TestSQLConsole_MaskedConsoleNames(t *testing.T) {
+	s := &sqlConsoleService{
+		console: config.SQLConsoleConfig{MaskedColumns: []string{"password_hash", "accounts.iban"}},
+		browser: config.DataBrowserConfig{Tables: map[string]config.TablePolicy{
+			"users":     {MaskedColumns: []string{"tax_id"}, HiddenColumns: []string{"secret"}},
+			"transfers": {ReadOnly: true},
+		}},
+	}
+
+	columns, tables, anyTable := s.maskedConsoleNames()
+	assert.Equal(t, map[string]bool{"password_hash": true, "iban": true, "tax_id": true, "secret": true}, columns)
+	assert.Equal(t, map[string]bool{"accounts": true, "users": true}, tables)
+	assert.Equal(t, []string{"password_hash"}, anyTable)
+}
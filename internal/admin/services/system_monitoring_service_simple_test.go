@@ -64,6 +64,14 @@ func (m *MockAlertServiceForSystemMonitoring) ResolveAlert(ctx context.Context,
 	return args.Get(0).(*interfaces.Alert), args.Error(1)
 }
 
+func (m *MockAlertServiceForSystemMonitoring) EscalateAlert(ctx context.Context, alertID, severity string, metadata map[string]interface{}) (*interfaces.Alert, error) {
+	args := m.Called(ctx, alertID, severity, metadata)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*interfaces.Alert), args.Error(1)
+}
+
 func (m *MockAlertServiceForSystemMonitoring) GetAlertStatistics(ctx context.Context, timeRange *interfaces.TimeRange) (*interfaces.AlertStatistics, error) {
 	args := m.Called(ctx, timeRange)
 	if args.Get(0) == nil {
@@ -3,12 +3,14 @@ package services
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
 	"github.com/phantom-sage/bankgo/internal/database/queries"
+	"github.com/phantom-sage/bankgo/internal/pagination"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -16,16 +18,31 @@ import (
 
 // transactionService implements the TransactionService interface
 type transactionService struct {
-	db      *pgxpool.Pool
-	queries *queries.Queries
+	db       *pgxpool.Pool
+	queries  *queries.Queries
+	readPool ReadPoolFunc
 }
 
 // NewTransactionService creates a new transaction service
 func NewTransactionService(db *pgxpool.Pool) interfaces.TransactionService {
+	return NewTransactionServiceWithReadPool(db, nil)
+}
+
+// NewTransactionServiceWithReadPool creates a transaction service that runs searches on readPool
+func NewTransactionServiceWithReadPool(db *pgxpool.Pool, readPool ReadPoolFunc) interfaces.TransactionService {
 	return &transactionService{
-		db:      db,
-		queries: queries.New(db),
+		db:       db,
+		queries:  queries.New(db),
+		readPool: readPool,
+	}
+}
+
+// readQueries returns queries bound to the read pool
+func (s *transactionService) readQueries() *queries.Queries {
+	if s.readPool == nil {
+		return s.queries
 	}
+	return queries.New(readPoolOrPrimary(s.db, s.readPool))
 }
 
 // SearchTransactions returns transactions based on search criteria
@@ -35,6 +52,9 @@ func (s *transactionService) SearchTransactions(ctx context.Context, params inte
 	var args []interface{}
 	argIndex := 1
 
+	// Predicates for "the searched user or account sent" and "... received"
+	var subjectFrom, subjectTo string
+
 	baseQuery := `
 		SELECT t.id, t.from_account_id, t.to_account_id, t.amount, t.description, t.status, t.created_at,
 		       fa.currency as from_currency, ta.currency as to_currency,
@@ -53,6 +73,7 @@ func (s *transactionService) SearchTransactions(ctx context.Context, params inte
 			return nil, fmt.Errorf("invalid user_id: %w", err)
 		}
 		conditions = append(conditions, fmt.Sprintf("(fa.user_id = $%d OR ta.user_id = $%d)", argIndex, argIndex))
+		subjectFrom, subjectTo = fmt.Sprintf("fa.user_id = $%d", argIndex), fmt.Sprintf("ta.user_id = $%d", argIndex)
 		args = append(args, userID)
 		argIndex++
 	}
@@ -63,10 +84,39 @@ func (s *transactionService) SearchTransactions(ctx context.Context, params inte
 			return nil, fmt.Errorf("invalid account_id: %w", err)
 		}
 		conditions = append(conditions, fmt.Sprintf("(t.from_account_id = $%d OR t.to_account_id = $%d)", argIndex, argIndex))
+		subjectFrom, subjectTo = fmt.Sprintf("t.from_account_id = $%d", argIndex), fmt.Sprintf("t.to_account_id = $%d", argIndex)
 		args = append(args, accountID)
 		argIndex++
 	}
 
+	// Direction and counterparty are relative to the searched account, or else the searched user
+	if params.Direction != "" || params.CounterpartyAccountID != "" {
+		if subjectFrom == "" {
+			return nil, fmt.Errorf("invalid filters: direction and counterparty_account_id require user_id or account_id")
+		}
+	}
+
+	switch params.Direction {
+	case "":
+	case "outgoing":
+		conditions = append(conditions, subjectFrom)
+	case "incoming":
+		conditions = append(conditions, subjectTo)
+	default:
+		return nil, fmt.Errorf("invalid direction: must be incoming or outgoing")
+	}
+
+	if params.CounterpartyAccountID != "" {
+		counterpartyID, err := strconv.Atoi(params.CounterpartyAccountID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid counterparty_account_id: %w", err)
+		}
+		conditions = append(conditions, fmt.Sprintf("((%s AND t.to_account_id = $%d) OR (%s AND t.from_account_id = $%d))",
+			subjectFrom, argIndex, subjectTo, argIndex))
+		args = append(args, counterpartyID)
+		argIndex++
+	}
+
 	if params.Currency != "" {
 		conditions = append(conditions, fmt.Sprintf("fa.currency = $%d", argIndex))
 		args = append(args, params.Currency)
@@ -115,6 +165,22 @@ func (s *transactionService) SearchTransactions(ctx context.Context, params inte
 		whereClause = " WHERE " + strings.Join(conditions, " AND ")
 	}
 
+	readDB := readPoolOrPrimary(s.db, s.readPool)
+
+	if params.PageSize <= 0 {
+		params.PageSize = 20
+	}
+
+	// Without a page number, page by cursor; this skips the COUNT(*) and keeps
+	// pages stable while new transfers arrive
+	cursor, err := pagination.Decode(params.Cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	if cursor != nil || params.Page <= 0 {
+		return s.searchTransactionsByCursor(ctx, readDB, baseQuery, conditions, args, params.PageSize, cursor)
+	}
+
 	// Count total records
 	countQuery := fmt.Sprintf(`
 		SELECT COUNT(*)
@@ -126,37 +192,111 @@ func (s *transactionService) SearchTransactions(ctx context.Context, params inte
 		%s`, whereClause)
 
 	var totalCount int64
-	err := s.db.QueryRow(ctx, countQuery, args...).Scan(&totalCount)
+	err = readDB.QueryRow(ctx, countQuery, args...).Scan(&totalCount)
 	if err != nil {
 		return nil, fmt.Errorf("failed to count transactions: %w", err)
 	}
 
 	// Calculate pagination
-	if params.Page <= 0 {
-		params.Page = 1
-	}
-	if params.PageSize <= 0 {
-		params.PageSize = 20
-	}
 	offset := (params.Page - 1) * params.PageSize
 
 	// Build main query with pagination
 	mainQuery := fmt.Sprintf(`
 		%s
 		%s
-		ORDER BY t.created_at DESC
+		ORDER BY t.created_at DESC, t.id DESC
 		LIMIT $%d OFFSET $%d`,
 		baseQuery, whereClause, argIndex, argIndex+1)
 
 	args = append(args, params.PageSize, offset)
 
 	// Execute query
-	rows, err := s.db.Query(ctx, mainQuery, args...)
+	rows, err := readDB.Query(ctx, mainQuery, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search transactions: %w", err)
 	}
 	defer rows.Close()
 
+	transactions, err := scanTransactionRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	// Calculate pagination info
+	totalPages := int((totalCount + int64(params.PageSize) - 1) / int64(params.PageSize))
+	
+	return &interfaces.PaginatedTransactions{
+		Transactions: transactions,
+		Pagination: interfaces.PaginationInfo{
+			Page:       params.Page,
+			PageSize:   params.PageSize,
+			TotalItems: int(totalCount),
+			TotalPages: totalPages,
+			HasNext:    params.Page < totalPages,
+			HasPrev:    params.Page > 1,
+		},
+	}, nil
+}
+
+// searchTransactionsByCursor returns one keyset page of transactions ordered by (created_at, id)
+func (s *transactionService) searchTransactionsByCursor(ctx context.Context, readDB *pgxpool.Pool, baseQuery string, conditions []string, args []interface{}, pageSize int, cursor *pagination.Cursor) (*interfaces.PaginatedTransactions, error) {
+	argIndex := len(args) + 1
+	order := "DESC"
+	if cursor != nil {
+		// Backward pages walk towards newer rows, oldest first
+		comparison := "<"
+		if cursor.Backward() {
+			comparison, order = ">", "ASC"
+		}
+		conditions = append(conditions, fmt.Sprintf("(t.created_at, t.id) %s ($%d, $%d)", comparison, argIndex, argIndex+1))
+		args = append(args, cursor.CreatedAt.UTC(), cursor.ID)
+		argIndex += 2
+	}
+
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	// Read one row past the page to tell whether another page follows
+	query := fmt.Sprintf(`
+		%s
+		%s
+		ORDER BY t.created_at %s, t.id %s
+		LIMIT $%d`,
+		baseQuery, whereClause, order, order, argIndex)
+	args = append(args, pageSize+1)
+
+	rows, err := readDB.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search transactions: %w", err)
+	}
+	defer rows.Close()
+
+	transactions, err := scanTransactionRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	page := pagination.Build(transactions, pageSize, cursor, func(t interfaces.TransactionDetail) (time.Time, int64) {
+		id, _ := strconv.ParseInt(t.ID, 10, 64)
+		return t.CreatedAt, id
+	})
+
+	return &interfaces.PaginatedTransactions{
+		Transactions: page.Items,
+		Pagination: interfaces.PaginationInfo{
+			PageSize: pageSize,
+			HasNext:  page.NextCursor != "",
+			HasPrev:  page.PrevCursor != "",
+		},
+		NextCursor: page.NextCursor,
+		PrevCursor: page.PrevCursor,
+	}, nil
+}
+
+// scanTransactionRows reads rows selected by the transaction search base query
+func scanTransactionRows(rows pgx.Rows) ([]interfaces.TransactionDetail, error) {
 	var transactions []interfaces.TransactionDetail
 	for rows.Next() {
 		var t interfaces.TransactionDetail
@@ -206,20 +346,8 @@ func (s *transactionService) SearchTransactions(ctx context.Context, params inte
 		return nil, fmt.Errorf("error iterating transaction rows: %w", err)
 	}
 
-	// Calculate pagination info
-	totalPages := int((totalCount + int64(params.PageSize) - 1) / int64(params.PageSize))
-	
-	return &interfaces.PaginatedTransactions{
-		Transactions: transactions,
-		Pagination: interfaces.PaginationInfo{
-			Page:       params.Page,
-			PageSize:   params.PageSize,
-			TotalItems: int(totalCount),
-			TotalPages: totalPages,
-			HasNext:    params.Page < totalPages,
-			HasPrev:    params.Page > 1,
-		},
-	}, nil
+	return transactions, nil
+
 }
 
 // GetTransactionDetail returns detailed transaction information
@@ -334,56 +462,130 @@ func (s *transactionService) ReverseTransaction(ctx context.Context, transaction
 	}
 	defer tx.Rollback(ctx)
 
-	qtx := s.queries.WithTx(tx)
+	if err := reverseTransfer(ctx, s.queries.WithTx(tx), int32(id)); err != nil {
+		return nil, err
+	}
+
+	// Commit transaction
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit reversal transaction: %w", err)
+	}
+
+	// Get updated transaction detail
+	detail, err := s.GetTransactionDetail(ctx, transactionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get updated transaction detail: %w", err)
+	}
+
+	// Update reversal information
+	now := time.Now()
+	detail.ReversedAt = &now
+	detail.ReversalReason = reason
+	detail.Status = "reversed"
+
+	// Add reversal audit entry
+	detail.AuditTrail = append(detail.AuditTrail, interfaces.AuditEntry{
+		ID:        strconv.Itoa(len(detail.AuditTrail) + 1),
+		Action:    "transfer_reversed",
+		Actor:     "admin", // TODO: Get actual admin from context
+		ActorType: "admin",
+		Timestamp: now,
+		Details: map[string]interface{}{
+			"reason":           reason,
+			"reversed_amount":  detail.Amount,
+			"reversal_method":  "admin_action",
+		},
+	})
+
+	return detail, nil
+}
 
-	// Get original transfer
-	transfer, err := qtx.GetTransfer(ctx, int32(id))
+// reverseTransfer moves a completed transfer's amount back to the sender and
+// refunds its fee from the house account, within the caller's transaction.
+// The transfer row is locked first, so concurrent reversals of the same
+// transfer queue up and all but the first see it already reversed.
+func reverseTransfer(ctx context.Context, qtx *queries.Queries, transferID int32) error {
+	transfer, err := qtx.GetTransferForUpdate(ctx, transferID)
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("transaction not found")
+			return fmt.Errorf("transaction not found")
 		}
-		return nil, fmt.Errorf("failed to get transaction: %w", err)
+		return fmt.Errorf("failed to get transaction: %w", err)
 	}
 
 	// Check if already reversed
 	if transfer.Status.Valid && transfer.Status.String == "reversed" {
-		return nil, fmt.Errorf("transaction already reversed")
+		return fmt.Errorf("transaction already reversed")
 	}
 
 	// Check if transaction can be reversed (only completed transactions)
 	if !transfer.Status.Valid || transfer.Status.String != "completed" {
-		return nil, fmt.Errorf("only completed transactions can be reversed")
+		return fmt.Errorf("only completed transactions can be reversed")
 	}
 
-	// Get accounts for update
-	fromAccount, err := qtx.GetAccountForUpdate(ctx, transfer.FromAccountID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get from account: %w", err)
+	// A fee already refunded has no refunded_at left to set
+	var fee *queries.TransferFee
+	if transferFee, err := qtx.GetTransferFee(ctx, transfer.ID); err == nil {
+		if !transferFee.RefundedAt.Valid {
+			fee = &transferFee
+		}
+	} else if err != pgx.ErrNoRows {
+		return fmt.Errorf("failed to get transfer fee: %w", err)
 	}
 
-	toAccount, err := qtx.GetAccountForUpdate(ctx, transfer.ToAccountID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get to account: %w", err)
+	// Lock every account touched in ascending ID order, matching the
+	// transfer service, so reversals cannot deadlock against new transfers
+	accountIDs := []int32{transfer.FromAccountID, transfer.ToAccountID}
+	if fee != nil {
+		accountIDs = append(accountIDs, fee.HouseAccountID)
+	}
+	sort.Slice(accountIDs, func(i, j int) bool { return accountIDs[i] < accountIDs[j] })
+	for i, accountID := range accountIDs {
+		if i > 0 && accountID == accountIDs[i-1] {
+			continue
+		}
+		if _, err := qtx.GetAccountForUpdate(ctx, accountID); err != nil {
+			return fmt.Errorf("failed to lock account %d: %w", accountID, err)
+		}
 	}
 
 	// Reverse the balances
 	if transfer.Amount.Valid {
 		// Add amount back to from account
 		_, err = qtx.AddToBalance(ctx, queries.AddToBalanceParams{
-			ID:      fromAccount.ID,
+			ID:      transfer.FromAccountID,
 			Balance: transfer.Amount,
 		})
 		if err != nil {
-			return nil, fmt.Errorf("failed to add balance to from account: %w", err)
+			return fmt.Errorf("failed to add balance to from account: %w", err)
 		}
 
 		// Subtract amount from to account
 		_, err = qtx.SubtractFromBalance(ctx, queries.SubtractFromBalanceParams{
-			ID:      toAccount.ID,
+			ID:      transfer.ToAccountID,
 			Balance: transfer.Amount,
 		})
 		if err != nil {
-			return nil, fmt.Errorf("failed to subtract balance from to account: %w", err)
+			return fmt.Errorf("failed to subtract balance from to account: %w", err)
+		}
+	}
+
+	// Refund the fee from the house account
+	if fee != nil {
+		if _, err := qtx.SubtractFromBalance(ctx, queries.SubtractFromBalanceParams{
+			ID:      fee.HouseAccountID,
+			Balance: fee.Amount,
+		}); err != nil {
+			return fmt.Errorf("failed to debit house account: %w", err)
+		}
+		if _, err := qtx.AddToBalance(ctx, queries.AddToBalanceParams{
+			ID:      transfer.FromAccountID,
+			Balance: fee.Amount,
+		}); err != nil {
+			return fmt.Errorf("failed to refund fee to from account: %w", err)
+		}
+		if _, err := qtx.RefundTransferFee(ctx, transfer.ID); err != nil {
+			return fmt.Errorf("failed to record fee refund: %w", err)
 		}
 	}
 
@@ -396,41 +598,10 @@ func (s *transactionService) ReverseTransaction(ctx context.Context, transaction
 		},
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to update transfer status: %w", err)
-	}
-
-	// Commit transaction
-	if err := tx.Commit(ctx); err != nil {
-		return nil, fmt.Errorf("failed to commit reversal transaction: %w", err)
-	}
-
-	// Get updated transaction detail
-	detail, err := s.GetTransactionDetail(ctx, transactionID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get updated transaction detail: %w", err)
+		return fmt.Errorf("failed to update transfer status: %w", err)
 	}
 
-	// Update reversal information
-	now := time.Now()
-	detail.ReversedAt = &now
-	detail.ReversalReason = reason
-	detail.Status = "reversed"
-
-	// Add reversal audit entry
-	detail.AuditTrail = append(detail.AuditTrail, interfaces.AuditEntry{
-		ID:        strconv.Itoa(len(detail.AuditTrail) + 1),
-		Action:    "transfer_reversed",
-		Actor:     "admin", // TODO: Get actual admin from context
-		ActorType: "admin",
-		Timestamp: now,
-		Details: map[string]interface{}{
-			"reason":           reason,
-			"reversed_amount":  detail.Amount,
-			"reversal_method":  "admin_action",
-		},
-	})
-
-	return detail, nil
+	return nil
 }
 
 // GetAccountTransactions returns transactions for a specific account
@@ -450,7 +621,8 @@ func (s *transactionService) GetAccountTransactions(ctx context.Context, account
 	offset := (params.Page - 1) * params.PageSize
 
 	// Get transactions for account
-	transfers, err := s.queries.GetTransfersByAccount(ctx, queries.GetTransfersByAccountParams{
+	readQueries := s.readQueries()
+	transfers, err := readQueries.GetTransfersByAccount(ctx, queries.GetTransfersByAccountParams{
 		FromAccountID: int32(id),
 		Limit:         int32(params.PageSize),
 		Offset:        int32(offset),
@@ -460,7 +632,7 @@ func (s *transactionService) GetAccountTransactions(ctx context.Context, account
 	}
 
 	// Count total transactions for account
-	totalCount, err := s.queries.CountTransfersByAccount(ctx, int32(id))
+	totalCount, err := readQueries.CountTransfersByAccount(ctx, int32(id))
 	if err != nil {
 		return nil, fmt.Errorf("failed to count account transactions: %w", err)
 	}
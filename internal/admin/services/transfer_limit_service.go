@@ -0,0 +1,225 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
+	"github.com/phantom-sage/bankgo/internal/database/queries"
+	"github.com/phantom-sage/bankgo/internal/utils"
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+)
+
+// transferLimitService implements the TransferLimitService interface
+type transferLimitService struct {
+	db      *pgxpool.Pool
+	queries *queries.Queries
+}
+
+// NewTransferLimitService creates a new transfer limit service
+func NewTransferLimitService(db *pgxpool.Pool) interfaces.TransferLimitService {
+	return &transferLimitService{
+		db:      db,
+		queries: queries.New(db),
+	}
+}
+
+// ListUserLimits returns a user's transfer limit overrides
+func (s *transferLimitService) ListUserLimits(ctx context.Context, userID string) ([]interfaces.UserTransferLimit, error) {
+	id, err := s.getUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.queries.ListUserTransferLimits(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transfer limits: %w", err)
+	}
+
+	limits := make([]interfaces.UserTransferLimit, 0, len(rows))
+	for _, row := range rows {
+		limits = append(limits, *convertUserTransferLimit(row))
+	}
+
+	return limits, nil
+}
+
+// SetUserLimit creates or replaces a user's override for a currency
+func (s *transferLimitService) SetUserLimit(ctx context.Context, userID, currency string, req interfaces.SetTransferLimitRequest, updatedBy string) (*interfaces.UserTransferLimit, error) {
+	id, err := s.getUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	currency, err = normalizeLimitCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+
+	params := queries.UpsertUserTransferLimitParams{
+		UserID:    id,
+		Currency:  currency,
+		UpdatedBy: pgtype.Text{String: updatedBy, Valid: updatedBy != ""},
+	}
+
+	var dailyAmount, monthlyAmount decimal.Decimal
+	if req.DailyAmount != nil {
+		if dailyAmount, err = parseLimitAmount("daily_amount", *req.DailyAmount); err != nil {
+			return nil, err
+		}
+		params.DailyAmount = utils.ConvertDecimalToPgNumeric(dailyAmount)
+	}
+	if req.MonthlyAmount != nil {
+		if monthlyAmount, err = parseLimitAmount("monthly_amount", *req.MonthlyAmount); err != nil {
+			return nil, err
+		}
+		params.MonthlyAmount = utils.ConvertDecimalToPgNumeric(monthlyAmount)
+	}
+	if req.DailyCount != nil {
+		if *req.DailyCount < 0 {
+			return nil, fmt.Errorf("invalid limit: daily_count cannot be negative")
+		}
+		params.DailyCount = pgtype.Int4{Int32: int32(*req.DailyCount), Valid: true}
+	}
+	if req.MonthlyCount != nil {
+		if *req.MonthlyCount < 0 {
+			return nil, fmt.Errorf("invalid limit: monthly_count cannot be negative")
+		}
+		params.MonthlyCount = pgtype.Int4{Int32: int32(*req.MonthlyCount), Valid: true}
+	}
+
+	if dailyAmount.IsPositive() && monthlyAmount.IsPositive() && dailyAmount.GreaterThan(monthlyAmount) {
+		return nil, fmt.Errorf("invalid limit: daily_amount cannot exceed monthly_amount")
+	}
+	if req.DailyCount != nil && req.MonthlyCount != nil && *req.DailyCount > 0 && *req.MonthlyCount > 0 && *req.DailyCount > *req.MonthlyCount {
+		return nil, fmt.Errorf("invalid limit: daily_count cannot exceed monthly_count")
+	}
+
+	row, err := s.queries.UpsertUserTransferLimit(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save transfer limit: %w", err)
+	}
+
+	log.Info().
+		Str("user_id", userID).
+		Str("currency", currency).
+		Str("updated_by", updatedBy).
+		Msg("Transfer limit override saved")
+
+	return convertUserTransferLimit(row), nil
+}
+
+// DeleteUserLimit removes an override so the configured defaults apply again
+func (s *transferLimitService) DeleteUserLimit(ctx context.Context, userID, currency string) error {
+	id, err := s.getUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	currency, err = normalizeLimitCurrency(currency)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.queries.GetUserTransferLimit(ctx, queries.GetUserTransferLimitParams{UserID: id, Currency: currency}); err != nil {
+		if err == pgx.ErrNoRows {
+			return fmt.Errorf("transfer limit not found")
+		}
+		return fmt.Errorf("failed to get transfer limit: %w", err)
+	}
+
+	if err := s.queries.DeleteUserTransferLimit(ctx, queries.DeleteUserTransferLimitParams{UserID: id, Currency: currency}); err != nil {
+		return fmt.Errorf("failed to delete transfer limit: %w", err)
+	}
+
+	log.Info().
+		Str("user_id", userID).
+		Str("currency", currency).
+		Msg("Transfer limit override removed")
+
+	return nil
+}
+
+// getUserID parses a user ID and checks that the user exists
+func (s *transferLimitService) getUserID(ctx context.Context, userID string) (int32, error) {
+	id, err := strconv.ParseInt(userID, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	if _, err := s.queries.GetUser(ctx, int32(id)); err != nil {
+		if err == pgx.ErrNoRows {
+			return 0, fmt.Errorf("user not found")
+		}
+		return 0, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return int32(id), nil
+}
+
+// normalizeLimitCurrency validates and upper-cases a currency code
+func normalizeLimitCurrency(currency string) (string, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if len(currency) != 3 {
+		return "", fmt.Errorf("invalid limit: currency must be a 3-character code")
+	}
+	return currency, nil
+}
+
+// parseLimitAmount parses a non-negative limit amount
+func parseLimitAmount(field, raw string) (decimal.Decimal, error) {
+	amount, err := decimal.NewFromString(raw)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("invalid limit: %s must be a decimal amount", field)
+	}
+	if amount.IsNegative() {
+		return decimal.Zero, fmt.Errorf("invalid limit: %s cannot be negative", field)
+	}
+	return amount, nil
+}
+
+// convertUserTransferLimit converts a database limit override to its API representation
+func convertUserTransferLimit(row queries.UserTransferLimit) *interfaces.UserTransferLimit {
+	limit := &interfaces.UserTransferLimit{
+		UserID:   strconv.Itoa(int(row.UserID)),
+		Currency: row.Currency,
+	}
+
+	if row.DailyAmount.Valid {
+		if amount, err := utils.ConvertPgNumericToDecimal(row.DailyAmount); err == nil {
+			value := amount.StringFixed(2)
+			limit.DailyAmount = &value
+		}
+	}
+	if row.MonthlyAmount.Valid {
+		if amount, err := utils.ConvertPgNumericToDecimal(row.MonthlyAmount); err == nil {
+			value := amount.StringFixed(2)
+			limit.MonthlyAmount = &value
+		}
+	}
+	if row.DailyCount.Valid {
+		value := int(row.DailyCount.Int32)
+		limit.DailyCount = &value
+	}
+	if row.MonthlyCount.Valid {
+		value := int(row.MonthlyCount.Int32)
+		limit.MonthlyCount = &value
+	}
+	if row.UpdatedBy.Valid {
+		limit.UpdatedBy = &row.UpdatedBy.String
+	}
+	if row.CreatedAt.Valid {
+		limit.CreatedAt = row.CreatedAt.Time
+	}
+	if row.UpdatedAt.Valid {
+		limit.UpdatedAt = row.UpdatedAt.Time
+	}
+
+	return limit
+}
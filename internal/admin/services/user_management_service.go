@@ -8,6 +8,8 @@ import (
 
 	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
 	"github.com/phantom-sage/bankgo/internal/database/queries"
+	"github.com/phantom-sage/bankgo/internal/lockout"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"golang.org/x/crypto/bcrypt"
@@ -22,7 +24,8 @@ type UserQueriesInterface interface {
 	AdminUpdateUser(ctx context.Context, arg queries.AdminUpdateUserParams) (queries.User, error)
 	AdminDisableUser(ctx context.Context, id int32) error
 	AdminEnableUser(ctx context.Context, id int32) error
-	AdminDeleteUser(ctx context.Context, id int32) error
+	AdminDeleteUser(ctx context.Context, arg queries.AdminDeleteUserParams) (int64, error)
+	DeleteLoginFailure(ctx context.Context, arg queries.DeleteLoginFailureParams) error
 }
 
 // UserManagementService implements the UserManagementService interface
@@ -157,7 +160,9 @@ func (s *UserManagementService) CreateUser(ctx context.Context, req interfaces.C
 	return &userDetail, nil
 }
 
-// UpdateUser updates user information
+// UpdateUser updates user information. When req.ExpectedVersion is set and
+// the user has changed since that version, a *VersionConflictError carrying
+// the current user is returned and nothing is written.
 func (s *UserManagementService) UpdateUser(ctx context.Context, userID string, req interfaces.UpdateUserRequest) (*interfaces.UserDetail, error) {
 	id, err := strconv.ParseInt(userID, 10, 32)
 	if err != nil {
@@ -178,8 +183,23 @@ func (s *UserManagementService) UpdateUser(ctx context.Context, userID string, r
 	if req.IsActive != nil {
 		updateParams.IsActive = pgtype.Bool{Bool: *req.IsActive, Valid: true}
 	}
+	if req.ExpectedVersion != nil {
+		updateParams.ExpectedVersion = pgtype.Int8{Int64: int64(*req.ExpectedVersion), Valid: true}
+	}
 
 	_, err = s.queries.AdminUpdateUser(ctx, updateParams)
+	if err == pgx.ErrNoRows && req.ExpectedVersion != nil {
+		// Either the user is gone or another admin got there first
+		current, getErr := s.GetUser(ctx, userID)
+		if getErr != nil {
+			return nil, fmt.Errorf("failed to update user: %w", getErr)
+		}
+		conflict := &interfaces.VersionConflictError{Current: current}
+		if current.Version != nil {
+			conflict.Version = *current.Version
+		}
+		return nil, conflict
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to update user: %w", err)
 	}
@@ -223,8 +243,33 @@ func (s *UserManagementService) EnableUser(ctx context.Context, userID string) e
 	return nil
 }
 
-// DeleteUser deletes a user account
-func (s *UserManagementService) DeleteUser(ctx context.Context, userID string) error {
+// UnlockUser clears a user's failed sign-ins, lifting any lockout or delay
+// on their account. Lockouts of the IPs they signed in from are kept.
+func (s *UserManagementService) UnlockUser(ctx context.Context, userID string) error {
+	id, err := strconv.ParseInt(userID, 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	dbUser, err := s.queries.AdminGetUserDetail(ctx, int32(id))
+	if err == pgx.ErrNoRows {
+		return fmt.Errorf("user not found")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if err := lockout.Unlock(ctx, s.queries, lockout.Customers, dbUser.Email); err != nil {
+		return fmt.Errorf("failed to unlock user: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteUser deletes a user account. When expectedVersion is set and the
+// user has changed since that version, a *VersionConflictError carrying the
+// current user is returned and nothing is deleted.
+func (s *UserManagementService) DeleteUser(ctx context.Context, userID string, expectedVersion *int) error {
 	id, err := strconv.ParseInt(userID, 10, 32)
 	if err != nil {
 		return fmt.Errorf("invalid user ID: %w", err)
@@ -244,20 +289,33 @@ func (s *UserManagementService) DeleteUser(ctx context.Context, userID string) e
 		return fmt.Errorf("cannot delete user with transfer history")
 	}
 
-	err = s.queries.AdminDeleteUser(ctx, int32(id))
+	deleteParams := queries.AdminDeleteUserParams{ID: int32(id)}
+	if expectedVersion != nil {
+		deleteParams.ExpectedVersion = pgtype.Int8{Int64: int64(*expectedVersion), Valid: true}
+	}
+
+	deleted, err := s.queries.AdminDeleteUser(ctx, deleteParams)
 	if err != nil {
 		return fmt.Errorf("failed to delete user: %w", err)
 	}
+	if deleted == 0 && expectedVersion != nil {
+		// Either the user is gone or another admin got there first
+		current, getErr := s.GetUser(ctx, userID)
+		if getErr != nil {
+			return fmt.Errorf("failed to delete user: %w", getErr)
+		}
+		conflict := &interfaces.VersionConflictError{Current: current}
+		if current.Version != nil {
+			conflict.Version = *current.Version
+		}
+		return conflict
+	}
 
 	return nil
 }
 
 // ConvertToUserDetail converts a database User to UserDetail interface type
 func (s *UserManagementService) ConvertToUserDetail(dbUser queries.User, accountCount, transferCount int64) interfaces.UserDetail {
-	var lastLogin *time.Time
-	// Note: We don't have last_login in the current schema, so it's nil for now
-	// This could be added in a future migration if needed
-
 	return interfaces.UserDetail{
 		ID:               strconv.Itoa(int(dbUser.ID)),
 		Email:            dbUser.Email,
@@ -266,7 +324,7 @@ func (s *UserManagementService) ConvertToUserDetail(dbUser queries.User, account
 		IsActive:         dbUser.IsActive.Bool,
 		CreatedAt:        dbUser.CreatedAt.Time,
 		UpdatedAt:        dbUser.UpdatedAt.Time,
-		LastLogin:        lastLogin,
+		LastLogin:        lastLogin(dbUser.LastLoginAt),
 		AccountCount:     int(accountCount),
 		TransferCount:    int(transferCount),
 		WelcomeEmailSent: dbUser.WelcomeEmailSent.Bool,
@@ -276,8 +334,6 @@ func (s *UserManagementService) ConvertToUserDetail(dbUser queries.User, account
 
 // convertRowToUserDetail converts AdminListUsersRow or AdminGetUserDetailRow to UserDetail
 func (s *UserManagementService) convertRowToUserDetail(row interface{}) interfaces.UserDetail {
-	var lastLogin *time.Time
-
 	switch r := row.(type) {
 	case queries.AdminListUsersRow:
 		return interfaces.UserDetail{
@@ -288,13 +344,14 @@ func (s *UserManagementService) convertRowToUserDetail(row interface{}) interfac
 			IsActive:         r.IsActive.Bool,
 			CreatedAt:        r.CreatedAt.Time,
 			UpdatedAt:        r.UpdatedAt.Time,
-			LastLogin:        lastLogin,
+			LastLogin:        lastLogin(r.LastLoginAt),
 			AccountCount:     int(r.AccountCount),
 			TransferCount:    int(r.TransferCount),
 			WelcomeEmailSent: r.WelcomeEmailSent.Bool,
 			Metadata:         make(map[string]interface{}),
 		}
 	case queries.AdminGetUserDetailRow:
+		version := int(r.Version)
 		return interfaces.UserDetail{
 			ID:               strconv.Itoa(int(r.ID)),
 			Email:            r.Email,
@@ -303,11 +360,12 @@ func (s *UserManagementService) convertRowToUserDetail(row interface{}) interfac
 			IsActive:         r.IsActive.Bool,
 			CreatedAt:        r.CreatedAt.Time,
 			UpdatedAt:        r.UpdatedAt.Time,
-			LastLogin:        lastLogin,
+			LastLogin:        lastLogin(r.LastLoginAt),
 			AccountCount:     int(r.AccountCount),
 			TransferCount:    int(r.TransferCount),
 			WelcomeEmailSent: r.WelcomeEmailSent.Bool,
 			Metadata:         make(map[string]interface{}),
+			Version:          &version,
 		}
 	default:
 		// Fallback - should not happen
@@ -315,4 +373,12 @@ func (s *UserManagementService) convertRowToUserDetail(row interface{}) interfac
 			Metadata: make(map[string]interface{}),
 		}
 	}
+}
+
+// lastLogin returns when a user last signed in, or nil if they never have
+func lastLogin(lastLoginAt pgtype.Timestamp) *time.Time {
+	if !lastLoginAt.Valid {
+		return nil
+	}
+	return &lastLoginAt.Time
 }
\ No newline at end of file
@@ -2,14 +2,17 @@ package services
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/phantom-sage/bankgo/internal/admin/interfaces"
 	"github.com/phantom-sage/bankgo/internal/database/queries"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockQueries is a mock implementation of the queries interface
@@ -52,8 +55,13 @@ func (m *MockQueries) AdminEnableUser(ctx context.Context, id int32) error {
 	return args.Error(0)
 }
 
-func (m *MockQueries) AdminDeleteUser(ctx context.Context, id int32) error {
-	args := m.Called(ctx, id)
+func (m *MockQueries) AdminDeleteUser(ctx context.Context, arg queries.AdminDeleteUserParams) (int64, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockQueries) DeleteLoginFailure(ctx context.Context, arg queries.DeleteLoginFailureParams) error {
+	args := m.Called(ctx, arg)
 	return args.Error(0)
 }
 
@@ -194,6 +202,7 @@ func TestUserManagementService_GetUser(t *testing.T) {
 		WelcomeEmailSent: pgtype.Bool{Bool: true, Valid: true},
 		CreatedAt:        pgtype.Timestamp{Time: now, Valid: true},
 		UpdatedAt:        pgtype.Timestamp{Time: now, Valid: true},
+		LastLoginAt:      pgtype.Timestamp{Time: now, Valid: true},
 		AccountCount:     2,
 		TransferCount:    5,
 	}
@@ -217,10 +226,24 @@ func TestUserManagementService_GetUser(t *testing.T) {
 		assert.True(t, result.IsActive)
 		assert.Equal(t, 2, result.AccountCount)
 		assert.Equal(t, 5, result.TransferCount)
+		require.NotNil(t, result.LastLogin)
+		assert.Equal(t, now, *result.LastLogin)
 
 		service.mockQueries.AssertExpectations(t)
 	})
 
+	t.Run("user who never signed in", func(t *testing.T) {
+		service := NewUserManagementServiceWithMock()
+		neverSignedIn := mockUser
+		neverSignedIn.LastLoginAt = pgtype.Timestamp{}
+		service.mockQueries.On("AdminGetUserDetail", ctx, int32(1)).Return(neverSignedIn, nil)
+
+		result, err := service.GetUser(ctx, "1")
+
+		assert.NoError(t, err)
+		assert.Nil(t, result.LastLogin)
+	})
+
 	t.Run("invalid user ID", func(t *testing.T) {
 		service := NewUserManagementServiceWithMock()
 		
@@ -337,6 +360,53 @@ func TestUserManagementService_UpdateUser(t *testing.T) {
 	})
 }
 
+func TestUserManagementService_UpdateUserVersionConflict(t *testing.T) {
+	ctx := context.Background()
+	firstName := "Updated"
+	staleVersion := 100
+
+	current := queries.AdminGetUserDetailRow{
+		ID:        1,
+		Email:     "user@example.com",
+		FirstName: "Changed elsewhere",
+		Version:   101,
+	}
+
+	t.Run("stale version returns the current user", func(t *testing.T) {
+		service := NewUserManagementServiceWithMock()
+
+		service.mockQueries.On("AdminUpdateUser", ctx, mock.MatchedBy(func(arg queries.AdminUpdateUserParams) bool {
+			return arg.ExpectedVersion.Valid && arg.ExpectedVersion.Int64 == 100
+		})).Return(queries.User{}, pgx.ErrNoRows)
+		service.mockQueries.On("AdminGetUserDetail", ctx, int32(1)).Return(current, nil)
+
+		result, err := service.UpdateUser(ctx, "1", interfaces.UpdateUserRequest{FirstName: &firstName, ExpectedVersion: &staleVersion})
+
+		assert.Nil(t, result)
+		var conflict *interfaces.VersionConflictError
+		if assert.True(t, errors.As(err, &conflict)) {
+			assert.Equal(t, 101, conflict.Version)
+			assert.Equal(t, "Changed elsewhere", conflict.Current.(*interfaces.UserDetail).FirstName)
+		}
+		service.mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("matching version updates", func(t *testing.T) {
+		service := NewUserManagementServiceWithMock()
+		version := 101
+
+		service.mockQueries.On("AdminUpdateUser", ctx, mock.AnythingOfType("queries.AdminUpdateUserParams")).Return(queries.User{ID: 1}, nil)
+		service.mockQueries.On("AdminGetUserDetail", ctx, int32(1)).Return(queries.AdminGetUserDetailRow{ID: 1, FirstName: firstName, Version: 102}, nil)
+
+		result, err := service.UpdateUser(ctx, "1", interfaces.UpdateUserRequest{FirstName: &firstName, ExpectedVersion: &version})
+
+		assert.NoError(t, err)
+		if assert.NotNil(t, result.Version) {
+			assert.Equal(t, 102, *result.Version)
+		}
+	})
+}
+
 func TestUserManagementService_DisableUser(t *testing.T) {
 	ctx := context.Background()
 
@@ -384,6 +454,40 @@ func TestUserManagementService_EnableUser(t *testing.T) {
 	})
 }
 
+func TestUserManagementService_UnlockUser(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("successful unlock user", func(t *testing.T) {
+		service := NewUserManagementServiceWithMock()
+
+		service.mockQueries.On("AdminGetUserDetail", ctx, int32(1)).Return(queries.AdminGetUserDetailRow{
+			ID:    1,
+			Email: "User@Example.com",
+		}, nil)
+		service.mockQueries.On("DeleteLoginFailure", ctx, queries.DeleteLoginFailureParams{
+			Scope:   "account",
+			Subject: "user@example.com",
+		}).Return(nil)
+
+		err := service.UnlockUser(ctx, "1")
+
+		assert.NoError(t, err)
+		service.mockQueries.AssertExpectations(t)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		service := NewUserManagementServiceWithMock()
+
+		service.mockQueries.On("AdminGetUserDetail", ctx, int32(999)).Return(queries.AdminGetUserDetailRow{}, pgx.ErrNoRows)
+
+		err := service.UnlockUser(ctx, "999")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "user not found")
+		service.mockQueries.AssertNotCalled(t, "DeleteLoginFailure", mock.Anything, mock.Anything)
+	})
+}
+
 func TestUserManagementService_DeleteUser(t *testing.T) {
 	ctx := context.Background()
 	now := time.Now()
@@ -406,10 +510,10 @@ func TestUserManagementService_DeleteUser(t *testing.T) {
 
 		// Setup mocks
 		service.mockQueries.On("AdminGetUserDetail", ctx, int32(1)).Return(mockUserDetail, nil)
-		service.mockQueries.On("AdminDeleteUser", ctx, int32(1)).Return(nil)
+		service.mockQueries.On("AdminDeleteUser", ctx, queries.AdminDeleteUserParams{ID: 1}).Return(int64(1), nil)
 
 		// Execute
-		err := service.DeleteUser(ctx, "1")
+		err := service.DeleteUser(ctx, "1", nil)
 
 		// Assert
 		assert.NoError(t, err)
@@ -436,7 +540,7 @@ func TestUserManagementService_DeleteUser(t *testing.T) {
 		service.mockQueries.On("AdminGetUserDetail", ctx, int32(1)).Return(mockUserDetail, nil)
 
 		// Execute
-		err := service.DeleteUser(ctx, "1")
+		err := service.DeleteUser(ctx, "1", nil)
 
 		// Assert
 		assert.Error(t, err)
@@ -464,11 +568,39 @@ func TestUserManagementService_DeleteUser(t *testing.T) {
 		service.mockQueries.On("AdminGetUserDetail", ctx, int32(1)).Return(mockUserDetail, nil)
 
 		// Execute
-		err := service.DeleteUser(ctx, "1")
+		err := service.DeleteUser(ctx, "1", nil)
 
 		// Assert
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "cannot delete user with transfer history")
 		service.mockQueries.AssertExpectations(t)
 	})
-}
\ No newline at end of file
+}
+func TestUserManagementService_DeleteUserVersionConflict(t *testing.T) {
+	ctx := context.Background()
+	staleVersion := 100
+
+	current := queries.AdminGetUserDetailRow{
+		ID:        1,
+		Email:     "user@example.com",
+		FirstName: "Changed elsewhere",
+		Version:   101,
+	}
+
+	service := NewUserManagementServiceWithMock()
+
+	service.mockQueries.On("AdminGetUserDetail", ctx, int32(1)).Return(current, nil)
+	service.mockQueries.On("AdminDeleteUser", ctx, queries.AdminDeleteUserParams{
+		ID:              1,
+		ExpectedVersion: pgtype.Int8{Int64: 100, Valid: true},
+	}).Return(int64(0), nil)
+
+	err := service.DeleteUser(ctx, "1", &staleVersion)
+
+	var conflict *interfaces.VersionConflictError
+	if assert.True(t, errors.As(err, &conflict)) {
+		assert.Equal(t, 101, conflict.Version)
+		assert.Equal(t, "Changed elsewhere", conflict.Current.(*interfaces.UserDetail).FirstName)
+	}
+	service.mockQueries.AssertExpectations(t)
+}
@@ -0,0 +1,21 @@
+// Package alerts defines how the banking API raises alerts for admins. The
+// implementation lives with the admin API and is wired in by the server
+// binary, so the banking API does not depend on admin services.
+package alerts
+
+import (
+	"context"
+	"time"
+)
+
+// Publisher raises security and anomaly alerts on the admin alerts table
+type Publisher interface {
+	// TransactionAnomalyAlert reports a flagged or blocked transfer
+	TransactionAnomalyAlert(ctx context.Context, userID, accountID string, amount, threshold float64, anomalyType string) error
+
+	// RateLimitAlert reports a client that keeps hitting a rate limit
+	RateLimitAlert(ctx context.Context, policy, identifier, ipAddress string, limit int, window time.Duration) error
+
+	// AuthenticationFailureAlert reports an account locked after failed sign-ins
+	AuthenticationFailureAlert(ctx context.Context, username, ipAddress string, failureCount int) error
+}
@@ -2,9 +2,13 @@ package config
 
 import (
 	"fmt"
+	"net/netip"
 	"os"
 	"strconv"
+	"strings"
 	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 // DatabaseConfig holds database configuration
@@ -19,6 +23,18 @@ type DatabaseConfig struct {
 	MaxIdleConns    int
 	ConnMaxLifetime time.Duration
 	ConnMaxIdleTime time.Duration
+
+	// Optional read replica. Reads are only routed to it when ReplicaHost is set
+	// and its replication lag is within ReplicaMaxLag.
+	ReplicaHost             string
+	ReplicaPort             int
+	ReplicaMaxLag           time.Duration
+	ReplicaLagCheckInterval time.Duration
+
+	// URL and ReplicaURL, when set, replace the discrete connection fields
+	// above. They accept postgres:// URLs or key/value connection strings.
+	URL        string
+	ReplicaURL string
 }
 
 // PASETOConfig holds PASETO token configuration
@@ -55,6 +71,10 @@ type ServerConfig struct {
 	ReadTimeout time.Duration
 	WriteTimeout time.Duration
 	IdleTimeout time.Duration
+	// TrustedProxies are the proxy addresses or CIDR ranges whose
+	// X-Forwarded-For and X-Real-IP headers name the client. Empty trusts
+	// none, so the client IP is always the connection's peer address.
+	TrustedProxies []string
 }
 
 // LogConfig holds logging configuration
@@ -74,14 +94,192 @@ type LogConfig struct {
 	SamplingThereafter  int    `json:"sampling_thereafter"`
 }
 
+// RiskConfig holds transfer fraud and anomaly detection configuration
+type RiskConfig struct {
+	Enabled bool
+
+	// Velocity rules: outgoing transfers within VelocityWindow
+	VelocityWindow      time.Duration
+	MaxAccountTransfers int
+	MaxUserTransfers    int
+
+	// Amount rule: transfers above AverageMultiplier times the user's
+	// historical average, once the user has MinHistoryCount transfers
+	HistoryWindow     time.Duration
+	AverageMultiplier decimal.Decimal
+	MinHistoryCount   int
+
+	// New recipient rule: first transfer to an account at or above this amount
+	NewRecipientAmount decimal.Decimal
+
+	// Fan-out rule: distinct recipients within FanOutWindow
+	FanOutWindow        time.Duration
+	MaxFanOutRecipients int
+
+	// Decision thresholds on the 0-100 risk score
+	ReviewScore int
+	BlockScore  int
+}
+
+// TransferLimit holds daily and monthly transfer caps; zero means unlimited
+type TransferLimit struct {
+	DailyAmount   decimal.Decimal
+	MonthlyAmount decimal.Decimal
+	DailyCount    int
+	MonthlyCount  int
+}
+
+// TransferLimitConfig holds default transfer limits applied when a user has
+// no admin override
+type TransferLimitConfig struct {
+	Enabled bool
+
+	// Default applies to currencies without an entry in Currencies
+	Default    TransferLimit
+	Currencies map[string]TransferLimit
+}
+
+// ForCurrency returns the default limit for a currency
+func (c TransferLimitConfig) ForCurrency(currency string) TransferLimit {
+	if limit, ok := c.Currencies[strings.ToUpper(currency)]; ok {
+		return limit
+	}
+	return c.Default
+}
+
+// TransferBatchConfig holds batch transfer configuration
+type TransferBatchConfig struct {
+	// MaxItems caps the number of transfers accepted in one batch
+	MaxItems int
+
+	// Batches with more items than AsyncThreshold are processed by the queue
+	AsyncThreshold int
+
+	// StaleAfter is how long a batch may stay processing before another
+	// worker takes it over, as after a crash mid-batch
+	StaleAfter time.Duration
+}
+
+// OutboxConfig holds transactional outbox relay configuration
+type OutboxConfig struct {
+	// PollInterval is how long the relay waits when the outbox is empty
+	PollInterval time.Duration
+
+	// BatchSize caps the messages relayed per transaction
+	BatchSize int
+
+	// Retention is how long delivered messages are kept before cleanup
+	Retention time.Duration
+
+	// CleanupInterval is how often delivered messages past retention are deleted
+	CleanupInterval time.Duration
+
+	// MaxAttempts is how many times a message is tried before it is parked,
+	// so it stops holding back later messages of its aggregate
+	MaxAttempts int
+}
+
+// WebhookConfig holds outgoing webhook configuration
+type WebhookConfig struct {
+	// Timeout bounds each delivery request, including reading the response
+	Timeout time.Duration
+
+	// DisableAfterFailures is how many consecutive failed attempts disable an endpoint
+	DisableAfterFailures int
+
+	// MaxEndpointsPerUser caps the endpoints one user can register
+	MaxEndpointsPerUser int
+
+	// AllowInsecureURLs permits plain http endpoints, for local development
+	AllowInsecureURLs bool
+
+	// AllowPrivateNetworks permits endpoints on loopback and private
+	// addresses, for local development
+	AllowPrivateNetworks bool
+}
+
+// APIKeyConfig holds configuration for API keys and the OAuth2
+// client-credentials tokens issued for them
+type APIKeyConfig struct {
+	// MaxKeysPerUser caps the active keys one user can hold
+	MaxKeysPerUser int
+
+	// MaxLifetime is the longest expiry a key can be created with
+	MaxLifetime time.Duration
+
+	// ClientTokenExpiration is how long client-credentials access tokens last
+	ClientTokenExpiration time.Duration
+}
+
+// RateLimitRule allows Requests requests in any sliding Window
+type RateLimitRule struct {
+	Requests int
+	Window   time.Duration
+}
+
+// RateLimitConfig holds configuration for API rate limiting. Limits are
+// shared by every replica through Redis and kept in memory when it is down.
+type RateLimitConfig struct {
+	Enabled bool
+
+	// Global limits each client IP across the whole API
+	Global RateLimitRule
+
+	// Login limits sign-in attempts from each client IP
+	Login RateLimitRule
+
+	// Transfers limits each user's transfer requests
+	Transfers RateLimitRule
+
+	// RedisRetryInterval is how long limits are kept in memory after a Redis
+	// failure before Redis is tried again
+	RedisRetryInterval time.Duration
+}
+
+// LoginProtectionConfig holds brute-force protection for sign-ins. Failed
+// attempts are counted per account and per client IP; an account's failures
+// first delay its next attempt, then lock it for LockoutDuration, doubling
+// with every further LockoutThreshold failures up to MaxLockoutDuration.
+type LoginProtectionConfig struct {
+	Enabled bool
+
+	// FailureWindow is how long a failed attempt is remembered after the
+	// last failure
+	FailureWindow time.Duration
+
+	// DelayAfter is the number of failures after which an account's next
+	// attempt waits BaseDelay, doubling per failure up to MaxDelay
+	DelayAfter int
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+
+	// LockoutThreshold is the number of failures that locks an account
+	LockoutThreshold int
+
+	// IPLockoutThreshold is the number of failures, across accounts, that
+	// locks out a client IP
+	IPLockoutThreshold int
+
+	LockoutDuration    time.Duration
+	MaxLockoutDuration time.Duration
+}
+
 // Config holds all configuration for the application
 type Config struct {
-	Database DatabaseConfig
-	PASETO   PASETOConfig
-	Redis    RedisConfig
-	Email    EmailConfig
-	Server   ServerConfig
-	Logging  LogConfig
+	Database  DatabaseConfig
+	PASETO    PASETOConfig
+	Redis     RedisConfig
+	Email     EmailConfig
+	Server    ServerConfig
+	Logging   LogConfig
+	Risk      RiskConfig
+	Limits    TransferLimitConfig
+	Batch     TransferBatchConfig
+	Outbox    OutboxConfig
+	Webhooks  WebhookConfig
+	APIKeys   APIKeyConfig
+	RateLimit RateLimitConfig
+	Login     LoginProtectionConfig
 }
 
 // LoadConfig loads configuration from environment variables
@@ -116,13 +314,61 @@ func LoadConfig() (*Config, error) {
 		return nil, fmt.Errorf("failed to load logging config: %w", err)
 	}
 
+	riskConfig, err := loadRiskConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load risk config: %w", err)
+	}
+
+	limitConfig, err := loadTransferLimitConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load transfer limit config: %w", err)
+	}
+
+	batchConfig, err := loadTransferBatchConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load transfer batch config: %w", err)
+	}
+
+	outboxConfig, err := loadOutboxConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load outbox config: %w", err)
+	}
+
+	webhookConfig, err := loadWebhookConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load webhook config: %w", err)
+	}
+
+	apiKeyConfig, err := loadAPIKeyConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load API key config: %w", err)
+	}
+
+	rateLimitConfig, err := loadRateLimitConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load rate limit config: %w", err)
+	}
+
+	loginConfig, err := loadLoginProtectionConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load login protection config: %w", err)
+	}
+
 	config := &Config{
-		Database: dbConfig,
-		PASETO:   pasetoConfig,
-		Redis:    redisConfig,
-		Email:    emailConfig,
-		Server:   serverConfig,
-		Logging:  loggingConfig,
+		Database:  dbConfig,
+		PASETO:    pasetoConfig,
+		Redis:     redisConfig,
+		Email:     emailConfig,
+		Server:    serverConfig,
+		Logging:   loggingConfig,
+		Risk:      riskConfig,
+		Limits:    limitConfig,
+		Batch:     batchConfig,
+		Outbox:    outboxConfig,
+		Webhooks:  webhookConfig,
+		APIKeys:   apiKeyConfig,
+		RateLimit: rateLimitConfig,
+		Login:     loginConfig,
 	}
 
 	// Validate the complete configuration
@@ -133,6 +379,21 @@ func LoadConfig() (*Config, error) {
 	return config, nil
 }
 
+// LoadDatabaseConfig loads and validates only the database configuration,
+// for tools such as the migration command that do not need the rest
+func LoadDatabaseConfig() (DatabaseConfig, error) {
+	cfg, err := loadDatabaseConfig()
+	if err != nil {
+		return DatabaseConfig{}, fmt.Errorf("failed to load database config: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return DatabaseConfig{}, fmt.Errorf("database config validation failed: %w", err)
+	}
+
+	return cfg, nil
+}
+
 // loadDatabaseConfig loads database configuration from environment variables
 func loadDatabaseConfig() (DatabaseConfig, error) {
 	host := getEnvOrDefault("DB_HOST", "localhost")
@@ -145,6 +406,10 @@ func loadDatabaseConfig() (DatabaseConfig, error) {
 	maxIdleConnsStr := getEnvOrDefault("DB_MAX_IDLE_CONNS", "5")
 	connMaxLifetimeStr := getEnvOrDefault("DB_CONN_MAX_LIFETIME", "5m")
 	connMaxIdleTimeStr := getEnvOrDefault("DB_CONN_MAX_IDLE_TIME", "5m")
+	replicaHost := os.Getenv("DB_REPLICA_HOST")
+	replicaPortStr := getEnvOrDefault("DB_REPLICA_PORT", portStr)
+	replicaMaxLagStr := getEnvOrDefault("DB_REPLICA_MAX_LAG", "10s")
+	replicaLagCheckIntervalStr := getEnvOrDefault("DB_REPLICA_LAG_CHECK_INTERVAL", "5s")
 
 	// Validate required fields
 	if password == "" {
@@ -181,6 +446,22 @@ func loadDatabaseConfig() (DatabaseConfig, error) {
 		return DatabaseConfig{}, fmt.Errorf("invalid DB_CONN_MAX_IDLE_TIME: %w", err)
 	}
 
+	// Parse replica settings
+	replicaPort, err := strconv.Atoi(replicaPortStr)
+	if err != nil {
+		return DatabaseConfig{}, fmt.Errorf("invalid DB_REPLICA_PORT: %w", err)
+	}
+
+	replicaMaxLag, err := time.ParseDuration(replicaMaxLagStr)
+	if err != nil {
+		return DatabaseConfig{}, fmt.Errorf("invalid DB_REPLICA_MAX_LAG: %w", err)
+	}
+
+	replicaLagCheckInterval, err := time.ParseDuration(replicaLagCheckIntervalStr)
+	if err != nil {
+		return DatabaseConfig{}, fmt.Errorf("invalid DB_REPLICA_LAG_CHECK_INTERVAL: %w", err)
+	}
+
 	return DatabaseConfig{
 		Host:            host,
 		Port:            port,
@@ -192,6 +473,11 @@ func loadDatabaseConfig() (DatabaseConfig, error) {
 		MaxIdleConns:    maxIdleConns,
 		ConnMaxLifetime: connMaxLifetime,
 		ConnMaxIdleTime: connMaxIdleTime,
+
+		ReplicaHost:             replicaHost,
+		ReplicaPort:             replicaPort,
+		ReplicaMaxLag:           replicaMaxLag,
+		ReplicaLagCheckInterval: replicaLagCheckInterval,
 	}, nil
 }
 
@@ -306,10 +592,28 @@ func loadEmailConfig() (EmailConfig, error) {
 
 // ConnectionString returns the PostgreSQL connection string
 func (db DatabaseConfig) ConnectionString() string {
+	if db.URL != "" {
+		return db.URL
+	}
 	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
 		db.Host, db.Port, db.User, db.Password, db.Name, db.SSLMode)
 }
 
+// HasReplica reports whether a read replica is configured
+func (db DatabaseConfig) HasReplica() bool {
+	return db.ReplicaHost != "" || db.ReplicaURL != ""
+}
+
+// ReplicaConnectionString returns the PostgreSQL connection string for the read
+// replica. The replica shares the primary's database name and credentials.
+func (db DatabaseConfig) ReplicaConnectionString() string {
+	if db.ReplicaURL != "" {
+		return db.ReplicaURL
+	}
+	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		db.ReplicaHost, db.ReplicaPort, db.User, db.Password, db.Name, db.SSLMode)
+}
+
 // Address returns the Redis address
 func (r RedisConfig) Address() string {
 	return fmt.Sprintf("%s:%d", r.Host, r.Port)
@@ -356,16 +660,41 @@ func loadServerConfig() (ServerConfig, error) {
 		return ServerConfig{}, fmt.Errorf("GIN_MODE must be one of: debug, release, test")
 	}
 
+	trustedProxies, err := parseTrustedProxies(os.Getenv("TRUSTED_PROXIES"))
+	if err != nil {
+		return ServerConfig{}, fmt.Errorf("invalid TRUSTED_PROXIES: %w", err)
+	}
+
 	return ServerConfig{
-		Port:         port,
-		Environment:  environment,
-		Host:         host,
-		ReadTimeout:  readTimeout,
-		WriteTimeout: writeTimeout,
-		IdleTimeout:  idleTimeout,
+		Port:           port,
+		Environment:    environment,
+		Host:           host,
+		ReadTimeout:    readTimeout,
+		WriteTimeout:   writeTimeout,
+		IdleTimeout:    idleTimeout,
+		TrustedProxies: trustedProxies,
 	}, nil
 }
 
+// parseTrustedProxies parses a comma-separated list of IP addresses and CIDR
+// ranges
+func parseTrustedProxies(raw string) ([]string, error) {
+	var proxies []string
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if _, err := netip.ParsePrefix(entry); err != nil {
+			if _, err := netip.ParseAddr(entry); err != nil {
+				return nil, fmt.Errorf("%q is not an IP address or CIDR range", entry)
+			}
+		}
+		proxies = append(proxies, entry)
+	}
+	return proxies, nil
+}
+
 // Address returns the server address
 func (s ServerConfig) Address() string {
 	return fmt.Sprintf("%s:%d", s.Host, s.Port)
@@ -451,6 +780,403 @@ func loadLoggingConfig() (LogConfig, error) {
 	}, nil
 }
 
+// DefaultRiskConfig returns the default transfer risk rules
+func DefaultRiskConfig() RiskConfig {
+	return RiskConfig{
+		Enabled:             true,
+		VelocityWindow:      10 * time.Minute,
+		MaxAccountTransfers: 5,
+		MaxUserTransfers:    10,
+		HistoryWindow:       90 * 24 * time.Hour,
+		AverageMultiplier:   decimal.NewFromInt(5),
+		MinHistoryCount:     3,
+		NewRecipientAmount:  decimal.NewFromInt(5000),
+		FanOutWindow:        time.Hour,
+		MaxFanOutRecipients: 5,
+		ReviewScore:         40,
+		BlockScore:          80,
+	}
+}
+
+// loadRiskConfig loads transfer risk configuration from environment variables
+func loadRiskConfig() (RiskConfig, error) {
+	cfg := DefaultRiskConfig()
+	var err error
+
+	if cfg.Enabled, err = strconv.ParseBool(getEnvOrDefault("RISK_ENABLED", "true")); err != nil {
+		return RiskConfig{}, fmt.Errorf("invalid RISK_ENABLED: %w", err)
+	}
+
+	durations := []struct {
+		key   string
+		value *time.Duration
+	}{
+		{"RISK_VELOCITY_WINDOW", &cfg.VelocityWindow},
+		{"RISK_HISTORY_WINDOW", &cfg.HistoryWindow},
+		{"RISK_FAN_OUT_WINDOW", &cfg.FanOutWindow},
+	}
+	for _, d := range durations {
+		if raw := os.Getenv(d.key); raw != "" {
+			if *d.value, err = time.ParseDuration(raw); err != nil {
+				return RiskConfig{}, fmt.Errorf("invalid %s: %w", d.key, err)
+			}
+		}
+	}
+
+	ints := []struct {
+		key   string
+		value *int
+	}{
+		{"RISK_MAX_ACCOUNT_TRANSFERS", &cfg.MaxAccountTransfers},
+		{"RISK_MAX_USER_TRANSFERS", &cfg.MaxUserTransfers},
+		{"RISK_MIN_HISTORY_COUNT", &cfg.MinHistoryCount},
+		{"RISK_MAX_FAN_OUT_RECIPIENTS", &cfg.MaxFanOutRecipients},
+		{"RISK_REVIEW_SCORE", &cfg.ReviewScore},
+		{"RISK_BLOCK_SCORE", &cfg.BlockScore},
+	}
+	for _, i := range ints {
+		if raw := os.Getenv(i.key); raw != "" {
+			if *i.value, err = strconv.Atoi(raw); err != nil {
+				return RiskConfig{}, fmt.Errorf("invalid %s: %w", i.key, err)
+			}
+		}
+	}
+
+	decimals := []struct {
+		key   string
+		value *decimal.Decimal
+	}{
+		{"RISK_AVERAGE_MULTIPLIER", &cfg.AverageMultiplier},
+		{"RISK_NEW_RECIPIENT_AMOUNT", &cfg.NewRecipientAmount},
+	}
+	for _, d := range decimals {
+		if raw := os.Getenv(d.key); raw != "" {
+			if *d.value, err = decimal.NewFromString(raw); err != nil {
+				return RiskConfig{}, fmt.Errorf("invalid %s: %w", d.key, err)
+			}
+		}
+	}
+
+	return cfg, nil
+}
+
+// loadTransferLimitConfig loads default transfer limits from environment variables
+func loadTransferLimitConfig() (TransferLimitConfig, error) {
+	cfg := TransferLimitConfig{}
+	var err error
+
+	if cfg.Enabled, err = strconv.ParseBool(getEnvOrDefault("TRANSFER_LIMITS_ENABLED", "true")); err != nil {
+		return TransferLimitConfig{}, fmt.Errorf("invalid TRANSFER_LIMITS_ENABLED: %w", err)
+	}
+
+	if cfg.Default, err = parseTransferLimit(
+		getEnvOrDefault("TRANSFER_LIMIT_DAILY_AMOUNT", "10000"),
+		getEnvOrDefault("TRANSFER_LIMIT_MONTHLY_AMOUNT", "50000"),
+		getEnvOrDefault("TRANSFER_LIMIT_DAILY_COUNT", "50"),
+		getEnvOrDefault("TRANSFER_LIMIT_MONTHLY_COUNT", "500"),
+	); err != nil {
+		return TransferLimitConfig{}, fmt.Errorf("invalid TRANSFER_LIMIT_*: %w", err)
+	}
+
+	if cfg.Currencies, err = ParseCurrencyTransferLimits(os.Getenv("TRANSFER_LIMITS_BY_CURRENCY")); err != nil {
+		return TransferLimitConfig{}, fmt.Errorf("invalid TRANSFER_LIMITS_BY_CURRENCY: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// loadTransferBatchConfig loads batch transfer configuration from environment variables
+func loadTransferBatchConfig() (TransferBatchConfig, error) {
+	maxItems, err := strconv.Atoi(getEnvOrDefault("TRANSFER_BATCH_MAX_ITEMS", "500"))
+	if err != nil {
+		return TransferBatchConfig{}, fmt.Errorf("invalid TRANSFER_BATCH_MAX_ITEMS: %w", err)
+	}
+
+	asyncThreshold, err := strconv.Atoi(getEnvOrDefault("TRANSFER_BATCH_ASYNC_THRESHOLD", "50"))
+	if err != nil {
+		return TransferBatchConfig{}, fmt.Errorf("invalid TRANSFER_BATCH_ASYNC_THRESHOLD: %w", err)
+	}
+
+	staleAfter, err := time.ParseDuration(getEnvOrDefault("TRANSFER_BATCH_STALE_AFTER", "10m"))
+	if err != nil {
+		return TransferBatchConfig{}, fmt.Errorf("invalid TRANSFER_BATCH_STALE_AFTER: %w", err)
+	}
+
+	return TransferBatchConfig{
+		MaxItems:       maxItems,
+		AsyncThreshold: asyncThreshold,
+		StaleAfter:     staleAfter,
+	}, nil
+}
+
+// loadOutboxConfig loads outbox relay configuration from environment variables
+func loadOutboxConfig() (OutboxConfig, error) {
+	pollInterval, err := time.ParseDuration(getEnvOrDefault("OUTBOX_POLL_INTERVAL", "1s"))
+	if err != nil {
+		return OutboxConfig{}, fmt.Errorf("invalid OUTBOX_POLL_INTERVAL: %w", err)
+	}
+
+	batchSize, err := strconv.Atoi(getEnvOrDefault("OUTBOX_BATCH_SIZE", "100"))
+	if err != nil {
+		return OutboxConfig{}, fmt.Errorf("invalid OUTBOX_BATCH_SIZE: %w", err)
+	}
+
+	retention, err := time.ParseDuration(getEnvOrDefault("OUTBOX_RETENTION", "168h"))
+	if err != nil {
+		return OutboxConfig{}, fmt.Errorf("invalid OUTBOX_RETENTION: %w", err)
+	}
+
+	cleanupInterval, err := time.ParseDuration(getEnvOrDefault("OUTBOX_CLEANUP_INTERVAL", "1h"))
+	if err != nil {
+		return OutboxConfig{}, fmt.Errorf("invalid OUTBOX_CLEANUP_INTERVAL: %w", err)
+	}
+
+	maxAttempts, err := strconv.Atoi(getEnvOrDefault("OUTBOX_MAX_ATTEMPTS", "20"))
+	if err != nil {
+		return OutboxConfig{}, fmt.Errorf("invalid OUTBOX_MAX_ATTEMPTS: %w", err)
+	}
+
+	return OutboxConfig{
+		PollInterval:    pollInterval,
+		BatchSize:       batchSize,
+		Retention:       retention,
+		CleanupInterval: cleanupInterval,
+		MaxAttempts:     maxAttempts,
+	}, nil
+}
+
+// loadWebhookConfig loads outgoing webhook configuration from environment variables
+func loadWebhookConfig() (WebhookConfig, error) {
+	timeout, err := time.ParseDuration(getEnvOrDefault("WEBHOOK_TIMEOUT", "10s"))
+	if err != nil {
+		return WebhookConfig{}, fmt.Errorf("invalid WEBHOOK_TIMEOUT: %w", err)
+	}
+
+	disableAfterFailures, err := strconv.Atoi(getEnvOrDefault("WEBHOOK_DISABLE_AFTER_FAILURES", "20"))
+	if err != nil {
+		return WebhookConfig{}, fmt.Errorf("invalid WEBHOOK_DISABLE_AFTER_FAILURES: %w", err)
+	}
+
+	maxEndpoints, err := strconv.Atoi(getEnvOrDefault("WEBHOOK_MAX_ENDPOINTS_PER_USER", "10"))
+	if err != nil {
+		return WebhookConfig{}, fmt.Errorf("invalid WEBHOOK_MAX_ENDPOINTS_PER_USER: %w", err)
+	}
+
+	allowInsecure, err := strconv.ParseBool(getEnvOrDefault("WEBHOOK_ALLOW_INSECURE_URLS", "false"))
+	if err != nil {
+		return WebhookConfig{}, fmt.Errorf("invalid WEBHOOK_ALLOW_INSECURE_URLS: %w", err)
+	}
+
+	allowPrivate, err := strconv.ParseBool(getEnvOrDefault("WEBHOOK_ALLOW_PRIVATE_NETWORKS", "false"))
+	if err != nil {
+		return WebhookConfig{}, fmt.Errorf("invalid WEBHOOK_ALLOW_PRIVATE_NETWORKS: %w", err)
+	}
+
+	return WebhookConfig{
+		Timeout:              timeout,
+		DisableAfterFailures: disableAfterFailures,
+		MaxEndpointsPerUser:  maxEndpoints,
+		AllowInsecureURLs:    allowInsecure,
+		AllowPrivateNetworks: allowPrivate,
+	}, nil
+}
+
+// loadAPIKeyConfig loads API key configuration from environment variables
+func loadAPIKeyConfig() (APIKeyConfig, error) {
+	maxKeys, err := strconv.Atoi(getEnvOrDefault("API_KEY_MAX_PER_USER", "10"))
+	if err != nil {
+		return APIKeyConfig{}, fmt.Errorf("invalid API_KEY_MAX_PER_USER: %w", err)
+	}
+
+	maxLifetime, err := time.ParseDuration(getEnvOrDefault("API_KEY_MAX_LIFETIME", "8760h"))
+	if err != nil {
+		return APIKeyConfig{}, fmt.Errorf("invalid API_KEY_MAX_LIFETIME: %w", err)
+	}
+
+	tokenExpiration, err := time.ParseDuration(getEnvOrDefault("OAUTH_CLIENT_TOKEN_EXPIRATION", "1h"))
+	if err != nil {
+		return APIKeyConfig{}, fmt.Errorf("invalid OAUTH_CLIENT_TOKEN_EXPIRATION: %w", err)
+	}
+
+	return APIKeyConfig{
+		MaxKeysPerUser:        maxKeys,
+		MaxLifetime:           maxLifetime,
+		ClientTokenExpiration: tokenExpiration,
+	}, nil
+}
+
+// loadRateLimitConfig loads rate limiting configuration from environment variables
+func loadRateLimitConfig() (RateLimitConfig, error) {
+	enabled, err := strconv.ParseBool(getEnvOrDefault("RATE_LIMIT_ENABLED", "true"))
+	if err != nil {
+		return RateLimitConfig{}, fmt.Errorf("invalid RATE_LIMIT_ENABLED: %w", err)
+	}
+
+	global, err := loadRateLimitRule("RATE_LIMIT_GLOBAL", "300", "1m")
+	if err != nil {
+		return RateLimitConfig{}, err
+	}
+
+	login, err := loadRateLimitRule("RATE_LIMIT_LOGIN", "5", "1m")
+	if err != nil {
+		return RateLimitConfig{}, err
+	}
+
+	transfers, err := loadRateLimitRule("RATE_LIMIT_TRANSFER", "30", "1m")
+	if err != nil {
+		return RateLimitConfig{}, err
+	}
+
+	retryInterval, err := time.ParseDuration(getEnvOrDefault("RATE_LIMIT_REDIS_RETRY_INTERVAL", "10s"))
+	if err != nil {
+		return RateLimitConfig{}, fmt.Errorf("invalid RATE_LIMIT_REDIS_RETRY_INTERVAL: %w", err)
+	}
+
+	return RateLimitConfig{
+		Enabled:            enabled,
+		Global:             global,
+		Login:              login,
+		Transfers:          transfers,
+		RedisRetryInterval: retryInterval,
+	}, nil
+}
+
+// loadRateLimitRule loads a rule from PREFIX_REQUESTS and PREFIX_WINDOW
+func loadRateLimitRule(prefix, defaultRequests, defaultWindow string) (RateLimitRule, error) {
+	requests, err := strconv.Atoi(getEnvOrDefault(prefix+"_REQUESTS", defaultRequests))
+	if err != nil {
+		return RateLimitRule{}, fmt.Errorf("invalid %s_REQUESTS: %w", prefix, err)
+	}
+
+	window, err := time.ParseDuration(getEnvOrDefault(prefix+"_WINDOW", defaultWindow))
+	if err != nil {
+		return RateLimitRule{}, fmt.Errorf("invalid %s_WINDOW: %w", prefix, err)
+	}
+
+	return RateLimitRule{Requests: requests, Window: window}, nil
+}
+
+// loadLoginProtectionConfig loads brute-force protection configuration from environment variables
+func loadLoginProtectionConfig() (LoginProtectionConfig, error) {
+	enabled, err := strconv.ParseBool(getEnvOrDefault("LOGIN_PROTECTION_ENABLED", "true"))
+	if err != nil {
+		return LoginProtectionConfig{}, fmt.Errorf("invalid LOGIN_PROTECTION_ENABLED: %w", err)
+	}
+
+	window, err := time.ParseDuration(getEnvOrDefault("LOGIN_FAILURE_WINDOW", "1h"))
+	if err != nil {
+		return LoginProtectionConfig{}, fmt.Errorf("invalid LOGIN_FAILURE_WINDOW: %w", err)
+	}
+
+	delayAfter, err := strconv.Atoi(getEnvOrDefault("LOGIN_DELAY_AFTER", "3"))
+	if err != nil {
+		return LoginProtectionConfig{}, fmt.Errorf("invalid LOGIN_DELAY_AFTER: %w", err)
+	}
+
+	baseDelay, err := time.ParseDuration(getEnvOrDefault("LOGIN_DELAY_BASE", "1s"))
+	if err != nil {
+		return LoginProtectionConfig{}, fmt.Errorf("invalid LOGIN_DELAY_BASE: %w", err)
+	}
+
+	maxDelay, err := time.ParseDuration(getEnvOrDefault("LOGIN_DELAY_MAX", "30s"))
+	if err != nil {
+		return LoginProtectionConfig{}, fmt.Errorf("invalid LOGIN_DELAY_MAX: %w", err)
+	}
+
+	threshold, err := strconv.Atoi(getEnvOrDefault("LOGIN_LOCKOUT_THRESHOLD", "5"))
+	if err != nil {
+		return LoginProtectionConfig{}, fmt.Errorf("invalid LOGIN_LOCKOUT_THRESHOLD: %w", err)
+	}
+
+	ipThreshold, err := strconv.Atoi(getEnvOrDefault("LOGIN_IP_LOCKOUT_THRESHOLD", "20"))
+	if err != nil {
+		return LoginProtectionConfig{}, fmt.Errorf("invalid LOGIN_IP_LOCKOUT_THRESHOLD: %w", err)
+	}
+
+	lockout, err := time.ParseDuration(getEnvOrDefault("LOGIN_LOCKOUT_DURATION", "15m"))
+	if err != nil {
+		return LoginProtectionConfig{}, fmt.Errorf("invalid LOGIN_LOCKOUT_DURATION: %w", err)
+	}
+
+	maxLockout, err := time.ParseDuration(getEnvOrDefault("LOGIN_LOCKOUT_MAX_DURATION", "24h"))
+	if err != nil {
+		return LoginProtectionConfig{}, fmt.Errorf("invalid LOGIN_LOCKOUT_MAX_DURATION: %w", err)
+	}
+
+	return LoginProtectionConfig{
+		Enabled:            enabled,
+		FailureWindow:      window,
+		DelayAfter:         delayAfter,
+		BaseDelay:          baseDelay,
+		MaxDelay:           maxDelay,
+		LockoutThreshold:   threshold,
+		IPLockoutThreshold: ipThreshold,
+		LockoutDuration:    lockout,
+		MaxLockoutDuration: maxLockout,
+	}, nil
+}
+
+// ParseCurrencyTransferLimits parses per-currency default limits in the form
+// "JPY=1500000/7500000/50/500,EUR=9000/45000/50/500", where the values are
+// daily amount, monthly amount, daily count and monthly count
+func ParseCurrencyTransferLimits(raw string) (map[string]TransferLimit, error) {
+	limits := make(map[string]TransferLimit)
+	if strings.TrimSpace(raw) == "" {
+		return limits, nil
+	}
+
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		currency, values, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("entry %q must be CURRENCY=daily/monthly/daily_count/monthly_count", entry)
+		}
+
+		currency = strings.ToUpper(strings.TrimSpace(currency))
+		if len(currency) != 3 {
+			return nil, fmt.Errorf("entry %q has invalid currency code", entry)
+		}
+
+		parts := strings.Split(values, "/")
+		if len(parts) != 4 {
+			return nil, fmt.Errorf("entry %q must have four limit values", entry)
+		}
+
+		limit, err := parseTransferLimit(parts[0], parts[1], parts[2], parts[3])
+		if err != nil {
+			return nil, fmt.Errorf("entry %q: %w", entry, err)
+		}
+		limits[currency] = limit
+	}
+
+	return limits, nil
+}
+
+// parseTransferLimit parses the four limit values of a transfer limit
+func parseTransferLimit(dailyAmount, monthlyAmount, dailyCount, monthlyCount string) (TransferLimit, error) {
+	var limit TransferLimit
+	var err error
+
+	if limit.DailyAmount, err = decimal.NewFromString(strings.TrimSpace(dailyAmount)); err != nil {
+		return TransferLimit{}, fmt.Errorf("invalid daily amount: %w", err)
+	}
+	if limit.MonthlyAmount, err = decimal.NewFromString(strings.TrimSpace(monthlyAmount)); err != nil {
+		return TransferLimit{}, fmt.Errorf("invalid monthly amount: %w", err)
+	}
+	if limit.DailyCount, err = strconv.Atoi(strings.TrimSpace(dailyCount)); err != nil {
+		return TransferLimit{}, fmt.Errorf("invalid daily count: %w", err)
+	}
+	if limit.MonthlyCount, err = strconv.Atoi(strings.TrimSpace(monthlyCount)); err != nil {
+		return TransferLimit{}, fmt.Errorf("invalid monthly count: %w", err)
+	}
+
+	return limit, nil
+}
+
 // Validate validates the entire configuration
 func (c *Config) Validate() error {
 	// Validate database configuration
@@ -483,6 +1209,46 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("logging config validation failed: %w", err)
 	}
 
+	// Validate Risk configuration
+	if err := c.Risk.Validate(); err != nil {
+		return fmt.Errorf("risk config validation failed: %w", err)
+	}
+
+	// Validate Limits configuration
+	if err := c.Limits.Validate(); err != nil {
+		return fmt.Errorf("transfer limit config validation failed: %w", err)
+	}
+
+	// Validate Batch configuration
+	if err := c.Batch.Validate(); err != nil {
+		return fmt.Errorf("transfer batch config validation failed: %w", err)
+	}
+
+	// Validate Outbox configuration
+	if err := c.Outbox.Validate(); err != nil {
+		return fmt.Errorf("outbox config validation failed: %w", err)
+	}
+
+	// Validate Webhook configuration
+	if err := c.Webhooks.Validate(); err != nil {
+		return fmt.Errorf("webhook config validation failed: %w", err)
+	}
+
+	// Validate API key configuration
+	if err := c.APIKeys.Validate(); err != nil {
+		return fmt.Errorf("API key config validation failed: %w", err)
+	}
+
+	// Validate rate limit configuration
+	if err := c.RateLimit.Validate(); err != nil {
+		return fmt.Errorf("rate limit config validation failed: %w", err)
+	}
+
+	// Validate login protection configuration
+	if err := c.Login.Validate(); err != nil {
+		return fmt.Errorf("login protection config validation failed: %w", err)
+	}
+
 	return nil
 }
 
@@ -512,6 +1278,17 @@ func (db DatabaseConfig) Validate() error {
 	if db.MaxIdleConns > db.MaxOpenConns {
 		return fmt.Errorf("max idle connections cannot exceed max open connections")
 	}
+	if db.HasReplica() {
+		if db.ReplicaPort < 1 || db.ReplicaPort > 65535 {
+			return fmt.Errorf("database replica port must be between 1 and 65535")
+		}
+		if db.ReplicaMaxLag <= 0 {
+			return fmt.Errorf("database replica max lag must be positive")
+		}
+		if db.ReplicaLagCheckInterval <= 0 {
+			return fmt.Errorf("database replica lag check interval must be positive")
+		}
+	}
 	return nil
 }
 
@@ -645,4 +1422,170 @@ func (l LogConfig) Validate() error {
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// Validate validates transfer risk configuration
+func (r RiskConfig) Validate() error {
+	if !r.Enabled {
+		return nil
+	}
+	if r.VelocityWindow <= 0 || r.HistoryWindow <= 0 || r.FanOutWindow <= 0 {
+		return fmt.Errorf("risk windows must be positive")
+	}
+	if r.MaxAccountTransfers <= 0 || r.MaxUserTransfers <= 0 || r.MaxFanOutRecipients <= 0 {
+		return fmt.Errorf("risk limits must be positive")
+	}
+	if r.MinHistoryCount < 0 {
+		return fmt.Errorf("risk min history count cannot be negative")
+	}
+	if !r.AverageMultiplier.IsPositive() || !r.NewRecipientAmount.IsPositive() {
+		return fmt.Errorf("risk amount thresholds must be positive")
+	}
+	if r.ReviewScore < 0 || r.BlockScore > 100 || r.ReviewScore >= r.BlockScore {
+		return fmt.Errorf("risk scores must satisfy 0 <= review < block <= 100")
+	}
+	return nil
+}
+
+// Validate validates transfer limit configuration
+func (c TransferLimitConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if err := c.Default.Validate(); err != nil {
+		return fmt.Errorf("default limit: %w", err)
+	}
+	for currency, limit := range c.Currencies {
+		if err := limit.Validate(); err != nil {
+			return fmt.Errorf("%s limit: %w", currency, err)
+		}
+	}
+	return nil
+}
+
+// Validate validates a single transfer limit
+func (l TransferLimit) Validate() error {
+	if l.DailyAmount.IsNegative() || l.MonthlyAmount.IsNegative() {
+		return fmt.Errorf("limit amounts cannot be negative")
+	}
+	if l.DailyCount < 0 || l.MonthlyCount < 0 {
+		return fmt.Errorf("limit counts cannot be negative")
+	}
+	if l.DailyAmount.IsPositive() && l.MonthlyAmount.IsPositive() && l.DailyAmount.GreaterThan(l.MonthlyAmount) {
+		return fmt.Errorf("daily amount limit cannot exceed monthly amount limit")
+	}
+	if l.DailyCount > 0 && l.MonthlyCount > 0 && l.DailyCount > l.MonthlyCount {
+		return fmt.Errorf("daily count limit cannot exceed monthly count limit")
+	}
+	return nil
+}
+
+// Validate validates batch transfer configuration
+func (b TransferBatchConfig) Validate() error {
+	if b.MaxItems <= 0 {
+		return fmt.Errorf("batch max items must be positive")
+	}
+	if b.AsyncThreshold <= 0 {
+		return fmt.Errorf("batch async threshold must be positive")
+	}
+	if b.StaleAfter <= 0 {
+		return fmt.Errorf("batch stale timeout must be positive")
+	}
+	return nil
+}
+
+// Validate validates outbox relay configuration
+func (o OutboxConfig) Validate() error {
+	if o.PollInterval <= 0 {
+		return fmt.Errorf("outbox poll interval must be positive")
+	}
+	if o.BatchSize <= 0 {
+		return fmt.Errorf("outbox batch size must be positive")
+	}
+	if o.Retention <= 0 {
+		return fmt.Errorf("outbox retention must be positive")
+	}
+	if o.CleanupInterval <= 0 {
+		return fmt.Errorf("outbox cleanup interval must be positive")
+	}
+	if o.MaxAttempts <= 0 {
+		return fmt.Errorf("outbox max attempts must be positive")
+	}
+	return nil
+}
+
+// Validate validates outgoing webhook configuration
+func (w WebhookConfig) Validate() error {
+	if w.Timeout <= 0 {
+		return fmt.Errorf("webhook timeout must be positive")
+	}
+	if w.DisableAfterFailures <= 0 {
+		return fmt.Errorf("webhook disable after failures must be positive")
+	}
+	if w.MaxEndpointsPerUser <= 0 {
+		return fmt.Errorf("webhook max endpoints per user must be positive")
+	}
+	return nil
+}
+
+// Validate validates API key configuration
+func (a APIKeyConfig) Validate() error {
+	if a.MaxKeysPerUser <= 0 {
+		return fmt.Errorf("API key max per user must be positive")
+	}
+	if a.MaxLifetime <= 0 {
+		return fmt.Errorf("API key max lifetime must be positive")
+	}
+	if a.ClientTokenExpiration <= 0 {
+		return fmt.Errorf("OAuth client token expiration must be positive")
+	}
+	return nil
+}
+
+// Validate validates rate limit configuration. Disabled limits are not checked.
+func (r RateLimitConfig) Validate() error {
+	if !r.Enabled {
+		return nil
+	}
+	rules := []struct {
+		name string
+		rule RateLimitRule
+	}{
+		{"global", r.Global},
+		{"login", r.Login},
+		{"transfer", r.Transfers},
+	}
+	for _, rule := range rules {
+		if rule.rule.Requests <= 0 {
+			return fmt.Errorf("%s rate limit requests must be positive", rule.name)
+		}
+		if rule.rule.Window <= 0 {
+			return fmt.Errorf("%s rate limit window must be positive", rule.name)
+		}
+	}
+	if r.RedisRetryInterval <= 0 {
+		return fmt.Errorf("rate limit Redis retry interval must be positive")
+	}
+	return nil
+}
+
+// Validate validates login protection configuration. Disabled protection is
+// not checked.
+func (l LoginProtectionConfig) Validate() error {
+	if !l.Enabled {
+		return nil
+	}
+	if l.FailureWindow <= 0 {
+		return fmt.Errorf("login failure window must be positive")
+	}
+	if l.DelayAfter <= 0 || l.LockoutThreshold <= 0 || l.IPLockoutThreshold <= 0 {
+		return fmt.Errorf("login delay and lockout thresholds must be positive")
+	}
+	if l.BaseDelay <= 0 || l.MaxDelay < l.BaseDelay {
+		return fmt.Errorf("login delays must be positive with the maximum no less than the base")
+	}
+	if l.LockoutDuration <= 0 || l.MaxLockoutDuration < l.LockoutDuration {
+		return fmt.Errorf("login lockout durations must be positive with the maximum no less than the base")
+	}
+	return nil
+}
@@ -4,6 +4,8 @@ import (
 	"os"
 	"testing"
 	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -121,6 +123,27 @@ func TestConnectionString(t *testing.T) {
 	}
 }
 
+func TestReplicaConnectionString(t *testing.T) {
+	cfg := DatabaseConfig{
+		Host:        "primary",
+		Port:        5432,
+		Name:        "testdb",
+		User:        "testuser",
+		Password:    "testpass",
+		SSLMode:     "require",
+		ReplicaHost: "replica",
+		ReplicaPort: 5433,
+	}
+
+	expected := "host=replica port=5433 user=testuser password=testpass dbname=testdb sslmode=require"
+	if actual := cfg.ReplicaConnectionString(); actual != expected {
+		t.Errorf("Expected replica connection string %q, got %q", expected, actual)
+	}
+	if !cfg.HasReplica() {
+		t.Error("Expected replica to be configured")
+	}
+}
+
 func TestGetEnvOrDefault(t *testing.T) {
 	// Test with existing environment variable
 	os.Setenv("TEST_VAR", "test_value")
@@ -198,6 +221,39 @@ func TestDatabaseConfigValidation(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid replica",
+			config: DatabaseConfig{
+				Host:                    "localhost",
+				Port:                    5432,
+				Name:                    "testdb",
+				User:                    "testuser",
+				Password:                "testpass",
+				MaxOpenConns:            10,
+				MaxIdleConns:            5,
+				ReplicaHost:             "replica",
+				ReplicaPort:             5432,
+				ReplicaMaxLag:           10 * time.Second,
+				ReplicaLagCheckInterval: 5 * time.Second,
+			},
+			wantErr: false,
+		},
+		{
+			name: "replica without max lag",
+			config: DatabaseConfig{
+				Host:                    "localhost",
+				Port:                    5432,
+				Name:                    "testdb",
+				User:                    "testuser",
+				Password:                "testpass",
+				MaxOpenConns:            10,
+				MaxIdleConns:            5,
+				ReplicaHost:             "replica",
+				ReplicaPort:             5432,
+				ReplicaLagCheckInterval: 5 * time.Second,
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -794,4 +850,339 @@ func cleanupEnvVars() {
 	for _, env := range envVars {
 		os.Unsetenv(env)
 	}
-}
\ No newline at end of file
+}
+func TestRiskConfigValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*RiskConfig)
+		wantErr bool
+	}{
+		{name: "default config", modify: func(c *RiskConfig) {}, wantErr: false},
+		{name: "disabled skips validation", modify: func(c *RiskConfig) { c.Enabled = false; c.VelocityWindow = 0 }, wantErr: false},
+		{name: "zero velocity window", modify: func(c *RiskConfig) { c.VelocityWindow = 0 }, wantErr: true},
+		{name: "zero fan-out limit", modify: func(c *RiskConfig) { c.MaxFanOutRecipients = 0 }, wantErr: true},
+		{name: "non-positive multiplier", modify: func(c *RiskConfig) { c.AverageMultiplier = decimal.Zero }, wantErr: true},
+		{name: "review above block", modify: func(c *RiskConfig) { c.ReviewScore = 90; c.BlockScore = 80 }, wantErr: true},
+		{name: "block above 100", modify: func(c *RiskConfig) { c.BlockScore = 101 }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultRiskConfig()
+			tt.modify(&cfg)
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RiskConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadRiskConfig(t *testing.T) {
+	t.Setenv("RISK_VELOCITY_WINDOW", "5m")
+	t.Setenv("RISK_MAX_ACCOUNT_TRANSFERS", "3")
+	t.Setenv("RISK_AVERAGE_MULTIPLIER", "2.5")
+	t.Setenv("RISK_BLOCK_SCORE", "70")
+
+	cfg, err := loadRiskConfig()
+	if err != nil {
+		t.Fatalf("loadRiskConfig() error = %v", err)
+	}
+
+	if !cfg.Enabled {
+		t.Error("Expected risk checks to be enabled by default")
+	}
+	if cfg.VelocityWindow != 5*time.Minute {
+		t.Errorf("Expected velocity window 5m, got %v", cfg.VelocityWindow)
+	}
+	if cfg.MaxAccountTransfers != 3 {
+		t.Errorf("Expected max account transfers 3, got %d", cfg.MaxAccountTransfers)
+	}
+	if !cfg.AverageMultiplier.Equal(decimal.NewFromFloat(2.5)) {
+		t.Errorf("Expected average multiplier 2.5, got %s", cfg.AverageMultiplier)
+	}
+	if cfg.BlockScore != 70 {
+		t.Errorf("Expected block score 70, got %d", cfg.BlockScore)
+	}
+	if cfg.ReviewScore != DefaultRiskConfig().ReviewScore {
+		t.Errorf("Expected default review score, got %d", cfg.ReviewScore)
+	}
+
+	t.Setenv("RISK_REVIEW_SCORE", "abc")
+	if _, err := loadRiskConfig(); err == nil {
+		t.Error("Expected error for invalid RISK_REVIEW_SCORE")
+	}
+}
+
+func TestParseCurrencyTransferLimits(t *testing.T) {
+	limits, err := ParseCurrencyTransferLimits("jpy=1500000/7500000/50/500, EUR=9000/45000/0/0")
+	if err != nil {
+		t.Fatalf("ParseCurrencyTransferLimits() error = %v", err)
+	}
+
+	if len(limits) != 2 {
+		t.Fatalf("Expected 2 currency limits, got %d", len(limits))
+	}
+	if !limits["JPY"].DailyAmount.Equal(decimal.NewFromInt(1500000)) {
+		t.Errorf("Expected JPY daily amount 1500000, got %s", limits["JPY"].DailyAmount)
+	}
+	if limits["JPY"].MonthlyCount != 500 {
+		t.Errorf("Expected JPY monthly count 500, got %d", limits["JPY"].MonthlyCount)
+	}
+	if limits["EUR"].DailyCount != 0 {
+		t.Errorf("Expected EUR daily count 0 (unlimited), got %d", limits["EUR"].DailyCount)
+	}
+
+	invalid := []string{
+		"USD",
+		"USDX=1/2/3/4",
+		"USD=1/2/3",
+		"USD=abc/2/3/4",
+		"USD=1/2/x/4",
+	}
+	for _, raw := range invalid {
+		if _, err := ParseCurrencyTransferLimits(raw); err == nil {
+			t.Errorf("Expected error for %q", raw)
+		}
+	}
+}
+
+func TestTransferLimitConfig(t *testing.T) {
+	cfg := TransferLimitConfig{
+		Enabled: true,
+		Default: TransferLimit{
+			DailyAmount:   decimal.NewFromInt(10000),
+			MonthlyAmount: decimal.NewFromInt(50000),
+			DailyCount:    50,
+			MonthlyCount:  500,
+		},
+		Currencies: map[string]TransferLimit{
+			"JPY": {DailyAmount: decimal.NewFromInt(1500000), MonthlyAmount: decimal.NewFromInt(7500000)},
+		},
+	}
+
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("TransferLimitConfig.Validate() error = %v", err)
+	}
+	if !cfg.ForCurrency("jpy").DailyAmount.Equal(decimal.NewFromInt(1500000)) {
+		t.Error("Expected JPY to use its currency-specific limit")
+	}
+	if !cfg.ForCurrency("USD").DailyAmount.Equal(decimal.NewFromInt(10000)) {
+		t.Error("Expected USD to fall back to the default limit")
+	}
+
+	cfg.Default.DailyAmount = decimal.NewFromInt(60000)
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected error when daily amount exceeds monthly amount")
+	}
+
+	cfg.Default.DailyAmount = decimal.NewFromInt(10000)
+	cfg.Currencies["EUR"] = TransferLimit{DailyCount: -1}
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected error for negative currency limit count")
+	}
+
+	cfg.Enabled = false
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected disabled limits to skip validation, got %v", err)
+	}
+}
+
+func TestLoadTransferBatchConfig(t *testing.T) {
+	cfg, err := loadTransferBatchConfig()
+	if err != nil {
+		t.Fatalf("loadTransferBatchConfig() error = %v", err)
+	}
+	if cfg.MaxItems != 500 || cfg.AsyncThreshold != 50 || cfg.StaleAfter != 10*time.Minute {
+		t.Errorf("Expected defaults 500/50/10m, got %d/%d/%v", cfg.MaxItems, cfg.AsyncThreshold, cfg.StaleAfter)
+	}
+
+	t.Setenv("TRANSFER_BATCH_MAX_ITEMS", "1000")
+	t.Setenv("TRANSFER_BATCH_ASYNC_THRESHOLD", "100")
+	t.Setenv("TRANSFER_BATCH_STALE_AFTER", "30m")
+	cfg, err = loadTransferBatchConfig()
+	if err != nil {
+		t.Fatalf("loadTransferBatchConfig() error = %v", err)
+	}
+	if cfg.MaxItems != 1000 || cfg.AsyncThreshold != 100 || cfg.StaleAfter != 30*time.Minute {
+		t.Errorf("Expected 1000/100/30m, got %d/%d/%v", cfg.MaxItems, cfg.AsyncThreshold, cfg.StaleAfter)
+	}
+
+	cfg.StaleAfter = 0
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected error for non-positive stale timeout")
+	}
+
+	cfg.StaleAfter = time.Minute
+	cfg.AsyncThreshold = 0
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected error for non-positive async threshold")
+	}
+
+	t.Setenv("TRANSFER_BATCH_STALE_AFTER", "soon")
+	if _, err := loadTransferBatchConfig(); err == nil {
+		t.Error("Expected error for invalid TRANSFER_BATCH_STALE_AFTER")
+	}
+	t.Setenv("TRANSFER_BATCH_STALE_AFTER", "30m")
+
+	t.Setenv("TRANSFER_BATCH_MAX_ITEMS", "many")
+	if _, err := loadTransferBatchConfig(); err == nil {
+		t.Error("Expected error for invalid TRANSFER_BATCH_MAX_ITEMS")
+	}
+}
+
+func TestLoadWebhookConfig(t *testing.T) {
+	cfg, err := loadWebhookConfig()
+	if err != nil {
+		t.Fatalf("loadWebhookConfig() error = %v", err)
+	}
+	if cfg.Timeout != 10*time.Second || cfg.DisableAfterFailures != 20 || cfg.MaxEndpointsPerUser != 10 || cfg.AllowInsecureURLs || cfg.AllowPrivateNetworks {
+		t.Errorf("Unexpected defaults: %+v", cfg)
+	}
+
+	t.Setenv("WEBHOOK_TIMEOUT", "3s")
+	t.Setenv("WEBHOOK_ALLOW_INSECURE_URLS", "true")
+	t.Setenv("WEBHOOK_ALLOW_PRIVATE_NETWORKS", "true")
+	cfg, err = loadWebhookConfig()
+	if err != nil {
+		t.Fatalf("loadWebhookConfig() error = %v", err)
+	}
+	if cfg.Timeout != 3*time.Second || !cfg.AllowInsecureURLs || !cfg.AllowPrivateNetworks {
+		t.Errorf("Expected 3s timeout allowing insecure and private URLs, got %+v", cfg)
+	}
+
+	cfg.DisableAfterFailures = 0
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected error for non-positive disable threshold")
+	}
+
+	t.Setenv("WEBHOOK_MAX_ENDPOINTS_PER_USER", "lots")
+	if _, err := loadWebhookConfig(); err == nil {
+		t.Error("Expected error for invalid WEBHOOK_MAX_ENDPOINTS_PER_USER")
+	}
+}
+
+func TestLoadAPIKeyConfig(t *testing.T) {
+	cfg, err := loadAPIKeyConfig()
+	if err != nil {
+		t.Fatalf("loadAPIKeyConfig() error = %v", err)
+	}
+	if cfg.MaxKeysPerUser != 10 || cfg.MaxLifetime != 365*24*time.Hour || cfg.ClientTokenExpiration != time.Hour {
+		t.Errorf("Unexpected defaults: %+v", cfg)
+	}
+
+	t.Setenv("OAUTH_CLIENT_TOKEN_EXPIRATION", "15m")
+	cfg, err = loadAPIKeyConfig()
+	if err != nil {
+		t.Fatalf("loadAPIKeyConfig() error = %v", err)
+	}
+	if cfg.ClientTokenExpiration != 15*time.Minute {
+		t.Errorf("Expected 15m client token expiration, got %v", cfg.ClientTokenExpiration)
+	}
+
+	cfg.MaxKeysPerUser = 0
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected error for non-positive max keys per user")
+	}
+
+	t.Setenv("API_KEY_MAX_LIFETIME", "forever")
+	if _, err := loadAPIKeyConfig(); err == nil {
+		t.Error("Expected error for invalid API_KEY_MAX_LIFETIME")
+	}
+}
+
+func TestLoadRateLimitConfig(t *testing.T) {
+	cfg, err := loadRateLimitConfig()
+	if err != nil {
+		t.Fatalf("loadRateLimitConfig() error = %v", err)
+	}
+	if !cfg.Enabled || cfg.Login != (RateLimitRule{Requests: 5, Window: time.Minute}) {
+		t.Errorf("Unexpected defaults: %+v", cfg)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected defaults to be valid, got %v", err)
+	}
+
+	t.Setenv("RATE_LIMIT_TRANSFER_REQUESTS", "10")
+	t.Setenv("RATE_LIMIT_TRANSFER_WINDOW", "30s")
+	cfg, err = loadRateLimitConfig()
+	if err != nil {
+		t.Fatalf("loadRateLimitConfig() error = %v", err)
+	}
+	if cfg.Transfers != (RateLimitRule{Requests: 10, Window: 30 * time.Second}) {
+		t.Errorf("Expected 10 transfers per 30s, got %+v", cfg.Transfers)
+	}
+
+	cfg.Global.Requests = 0
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected error for non-positive global requests")
+	}
+	cfg.Enabled = false
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected disabled limits not to be checked, got %v", err)
+	}
+
+	t.Setenv("RATE_LIMIT_LOGIN_WINDOW", "soon")
+	if _, err := loadRateLimitConfig(); err == nil {
+		t.Error("Expected error for invalid RATE_LIMIT_LOGIN_WINDOW")
+	}
+}
+
+func TestLoadLoginProtectionConfig(t *testing.T) {
+	cfg, err := loadLoginProtectionConfig()
+	if err != nil {
+		t.Fatalf("loadLoginProtectionConfig() error = %v", err)
+	}
+	if !cfg.Enabled || cfg.LockoutThreshold != 5 || cfg.IPLockoutThreshold != 20 || cfg.LockoutDuration != 15*time.Minute {
+		t.Errorf("Unexpected defaults: %+v", cfg)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected defaults to be valid, got %v", err)
+	}
+
+	t.Setenv("LOGIN_LOCKOUT_THRESHOLD", "10")
+	t.Setenv("LOGIN_LOCKOUT_DURATION", "1h")
+	cfg, err = loadLoginProtectionConfig()
+	if err != nil {
+		t.Fatalf("loadLoginProtectionConfig() error = %v", err)
+	}
+	if cfg.LockoutThreshold != 10 || cfg.LockoutDuration != time.Hour {
+		t.Errorf("Expected a lockout of an hour after 10 failures, got %+v", cfg)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected config to be valid, got %v", err)
+	}
+
+	cfg.MaxLockoutDuration = 30 * time.Minute
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected error for a lockout longer than the maximum")
+	}
+	cfg.Enabled = false
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected disabled protection not to be checked, got %v", err)
+	}
+
+	t.Setenv("LOGIN_DELAY_AFTER", "three")
+	if _, err := loadLoginProtectionConfig(); err == nil {
+		t.Error("Expected error for invalid LOGIN_DELAY_AFTER")
+	}
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	proxies, err := parseTrustedProxies("")
+	if err != nil || len(proxies) != 0 {
+		t.Errorf("Expected no trusted proxies by default, got %v (%v)", proxies, err)
+	}
+
+	proxies, err = parseTrustedProxies(" 10.0.0.0/8, 192.168.1.10 ,::1")
+	if err != nil {
+		t.Fatalf("parseTrustedProxies() error = %v", err)
+	}
+	if len(proxies) != 3 || proxies[0] != "10.0.0.0/8" || proxies[1] != "192.168.1.10" || proxies[2] != "::1" {
+		t.Errorf("Unexpected trusted proxies: %v", proxies)
+	}
+
+	if _, err := parseTrustedProxies("10.0.0.0/8,proxy.internal"); err == nil {
+		t.Error("Expected error for a host name")
+	}
+}
@@ -17,6 +17,10 @@ type DB struct {
 	Pool   *pgxpool.Pool
 	SqlDB  *sql.DB
 	config config.DatabaseConfig
+
+	// Replica is the optional read replica pool; use ReadPool to pick a pool for reads
+	Replica *pgxpool.Pool
+	replica *replicaMonitor
 }
 
 // New creates a new database connection with connection pooling
@@ -60,6 +64,20 @@ func New(cfg config.DatabaseConfig) (*DB, error) {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
+	// An unreachable replica does not stop startup; reads use the primary
+	// until the lag monitor sees the replica healthy
+	if cfg.HasReplica() {
+		replicaPool, err := newReplicaPool(cfg)
+		if err != nil {
+			db.Close()
+			return nil, err
+		}
+		db.Replica = replicaPool
+		db.replica = newReplicaMonitor(replicaPool, cfg.ReplicaMaxLag, cfg.ReplicaLagCheckInterval)
+		db.replica.check(context.Background())
+		go db.replica.run()
+	}
+
 	return db, nil
 }
 
@@ -104,8 +122,14 @@ func (db *DB) Stats() *pgxpool.Stat {
 	return db.Pool.Stat()
 }
 
-// Close closes the database connection pool
+// Close closes the database connection pools
 func (db *DB) Close() {
+	if db.replica != nil {
+		db.replica.close()
+	}
+	if db.Replica != nil {
+		db.Replica.Close()
+	}
 	if db.SqlDB != nil {
 		db.SqlDB.Close()
 	}
@@ -2,266 +2,756 @@ package database
 
 import (
 	"context"
+	"crypto/sha256"
 	"embed"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io/fs"
+	"os"
+	"path"
 	"path/filepath"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 //go:embed migrations/*.sql
 var migrationFiles embed.FS
 
+// migrationLockKey identifies the Postgres advisory lock held while migrations
+// run, so that replicas starting together apply them one at a time
+const migrationLockKey int64 = 0x62616e6b676f // "bankgo"
+
+// NoTransactionDirective, as the first line of a migration file, runs the file's
+// statements one by one outside a transaction. Statements such as
+// CREATE INDEX CONCURRENTLY cannot run inside one. Such a migration is not
+// atomic, so its statements should be safe to re-run (IF NOT EXISTS).
+const NoTransactionDirective = "-- migrate:no-transaction"
+
+// ErrChecksumMismatch is returned when an applied migration's file no longer
+// matches the checksum recorded when it was applied
+var ErrChecksumMismatch = errors.New("applied migration files have changed")
+
+// migrationFilePattern matches migration file names: 001_create_users_table.up.sql
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
 // Migration represents a database migration
 type Migration struct {
 	Version int
 	Name    string
 	UpSQL   string
 	DownSQL string
+
+	// Checksum is the SHA-256 of UpSQL, recorded when the migration is applied
+	Checksum string
+
+	// UpNoTx and DownNoTx are set by NoTransactionDirective in the respective file
+	UpNoTx   bool
+	DownNoTx bool
+}
+
+// Migration states reported by Status
+const (
+	MigrationPending   = "pending"
+	MigrationApplied   = "applied"
+	MigrationChanged   = "changed"   // applied, but the file differs from what was applied
+	MigrationUntracked = "untracked" // applied before checksums were recorded
+	MigrationMissing   = "missing"   // applied, but no file for it exists
+)
+
+// MigrationStatus describes a known or applied migration
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	State     string
+	AppliedAt *time.Time
+}
+
+// appliedMigration is a row of schema_migrations
+type appliedMigration struct {
+	Version   int
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+// migrationStep applies or rolls back a single migration
+type migrationStep struct {
+	migration Migration
+	down      bool
+}
+
+// dbExecutor is satisfied by pools, pooled connections and transactions
+type dbExecutor interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
 }
 
 // MigrationRunner handles database migrations
 type MigrationRunner struct {
-	db *DB
+	db    *DB
+	files fs.FS
 }
 
-// NewMigrationRunner creates a new migration runner
+// NewMigrationRunner creates a new migration runner using the migrations
+// embedded in the binary
 func NewMigrationRunner(db *DB) *MigrationRunner {
-	return &MigrationRunner{db: db}
+	return &MigrationRunner{db: db, files: migrationFiles}
 }
 
 // CreateMigrationsTable creates the migrations tracking table
 func (mr *MigrationRunner) CreateMigrationsTable(ctx context.Context) error {
+	return createMigrationsTable(ctx, mr.db.Pool)
+}
+
+// LoadMigrations loads all migration files from the embedded filesystem
+func (mr *MigrationRunner) LoadMigrations() ([]Migration, error) {
+	return loadMigrations(mr.files, "migrations")
+}
+
+// GetAppliedMigrations returns list of applied migration versions
+func (mr *MigrationRunner) GetAppliedMigrations(ctx context.Context) ([]int, error) {
+	applied, err := loadAppliedMigrations(ctx, mr.db.Pool)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]int, 0, len(applied))
+	for _, migration := range applied {
+		versions = append(versions, migration.Version)
+	}
+
+	return versions, nil
+}
+
+// Up applies all pending migrations
+func (mr *MigrationRunner) Up(ctx context.Context) error {
+	return mr.run(ctx, func(migrations []Migration, applied []appliedMigration) ([]migrationStep, error) {
+		return planUp(migrations, applied), nil
+	})
+}
+
+// Down rolls back the last applied migration
+func (mr *MigrationRunner) Down(ctx context.Context) error {
+	return mr.DownN(ctx, 1)
+}
+
+// DownN rolls back the last n applied migrations, newest first
+func (mr *MigrationRunner) DownN(ctx context.Context, n int) error {
+	if n <= 0 {
+		return fmt.Errorf("number of migrations to roll back must be positive")
+	}
+
+	return mr.run(ctx, func(migrations []Migration, applied []appliedMigration) ([]migrationStep, error) {
+		return planDown(migrations, applied, n), nil
+	})
+}
+
+// Goto migrates up or down until exactly the migrations up to version are
+// applied. Version 0 rolls back every migration.
+func (mr *MigrationRunner) Goto(ctx context.Context, version int) error {
+	return mr.run(ctx, func(migrations []Migration, applied []appliedMigration) ([]migrationStep, error) {
+		return planGoto(migrations, applied, version)
+	})
+}
+
+// Status reports every known migration and every applied one, in version order
+func (mr *MigrationRunner) Status(ctx context.Context) ([]MigrationStatus, error) {
+	migrations, err := mr.LoadMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := loadAppliedMigrations(ctx, mr.db.Pool)
+	if err != nil {
+		return nil, err
+	}
+
+	return migrationStatuses(migrations, applied), nil
+}
+
+// run plans and executes migration steps on a single connection while holding
+// the migration advisory lock. Nothing runs if an applied migration's file has
+// changed since it was applied.
+func (mr *MigrationRunner) run(ctx context.Context, plan func([]Migration, []appliedMigration) ([]migrationStep, error)) error {
+	migrations, err := mr.LoadMigrations()
+	if err != nil {
+		return err
+	}
+
+	conn, err := mr.db.Pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	if err := lockMigrations(ctx, conn); err != nil {
+		return err
+	}
+	defer unlockMigrations(conn)
+
+	if err := createMigrationsTable(ctx, conn); err != nil {
+		return err
+	}
+
+	applied, err := loadAppliedMigrations(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	if err := verifyChecksums(migrations, applied); err != nil {
+		return err
+	}
+
+	// Migrations applied before checksums were recorded adopt the current file
+	if err := recordMissingChecksums(ctx, conn, migrations, applied); err != nil {
+		return err
+	}
+
+	steps, err := plan(migrations, applied)
+	if err != nil {
+		return err
+	}
+
+	if len(steps) == 0 {
+		fmt.Println("No migrations to run")
+		return nil
+	}
+
+	for _, step := range steps {
+		if err := runMigrationStep(ctx, conn, step); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// lockMigrations takes the migration advisory lock, waiting for any other
+// runner to finish first
+func lockMigrations(ctx context.Context, conn *pgxpool.Conn) error {
+	var locked bool
+	if err := conn.QueryRow(ctx, "SELECT pg_try_advisory_lock($1)", migrationLockKey).Scan(&locked); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	if locked {
+		return nil
+	}
+
+	fmt.Println("Waiting for another migration run to finish...")
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_lock($1)", migrationLockKey); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+
+	return nil
+}
+
+// unlockMigrations releases the migration advisory lock. Session locks outlive
+// the pooled connection, so the connection is closed if the unlock fails.
+func unlockMigrations(conn *pgxpool.Conn) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := conn.Exec(ctx, "SELECT pg_advisory_unlock($1)", migrationLockKey); err != nil {
+		conn.Conn().Close(ctx)
+	}
+}
+
+// runMigrationStep applies or rolls back one migration and records the result
+func runMigrationStep(ctx context.Context, conn *pgxpool.Conn, step migrationStep) error {
+	migration := step.migration
+	sql, noTx := migration.UpSQL, migration.UpNoTx
+	verb, done := "Applying", "Applied"
+	if step.down {
+		sql, noTx = migration.DownSQL, migration.DownNoTx
+		verb, done = "Rolling back", "Rolled back"
+	}
+
+	if step.down && strings.TrimSpace(sql) == "" {
+		return fmt.Errorf("migration %d has no down migration", migration.Version)
+	}
+
+	fmt.Printf("%s migration %d: %s\n", verb, migration.Version, migration.Name)
+
+	if noTx {
+		for _, statement := range splitStatements(sql) {
+			if _, err := conn.Exec(ctx, statement); err != nil {
+				return fmt.Errorf("failed to run migration %d outside a transaction, earlier statements were kept: %w", migration.Version, err)
+			}
+		}
+		if err := recordMigrationStep(ctx, conn, step); err != nil {
+			return err
+		}
+	} else {
+		tx, err := conn.Begin(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		defer tx.Rollback(ctx)
+
+		if _, err := tx.Exec(ctx, sql); err != nil {
+			return fmt.Errorf("failed to run migration %d: %w", migration.Version, err)
+		}
+		if err := recordMigrationStep(ctx, tx, step); err != nil {
+			return err
+		}
+
+		if err := tx.Commit(ctx); err != nil {
+			return fmt.Errorf("failed to commit migration %d: %w", migration.Version, err)
+		}
+	}
+
+	fmt.Printf("%s migration %d: %s\n", done, migration.Version, migration.Name)
+	return nil
+}
+
+// recordMigrationStep adds or removes a migration's schema_migrations row
+func recordMigrationStep(ctx context.Context, db dbExecutor, step migrationStep) error {
+	if step.down {
+		if _, err := db.Exec(ctx, "DELETE FROM schema_migrations WHERE version = $1", step.migration.Version); err != nil {
+			return fmt.Errorf("failed to remove migration record %d: %w", step.migration.Version, err)
+		}
+		return nil
+	}
+
+	query := "INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)"
+	if _, err := db.Exec(ctx, query, step.migration.Version, step.migration.Name, step.migration.Checksum); err != nil {
+		return fmt.Errorf("failed to record migration %d: %w", step.migration.Version, err)
+	}
+	return nil
+}
+
+// createMigrationsTable creates the migrations tracking table, adding the
+// checksum column to tables created before it existed
+func createMigrationsTable(ctx context.Context, db dbExecutor) error {
 	query := `
 		CREATE TABLE IF NOT EXISTS schema_migrations (
 			version INTEGER PRIMARY KEY,
 			name VARCHAR(255) NOT NULL,
+			checksum VARCHAR(64),
 			applied_at TIMESTAMP DEFAULT NOW()
 		);
+		ALTER TABLE schema_migrations ADD COLUMN IF NOT EXISTS checksum VARCHAR(64);
 	`
-	
-	_, err := mr.db.Pool.Exec(ctx, query)
-	if err != nil {
+
+	if _, err := db.Exec(ctx, query); err != nil {
 		return fmt.Errorf("failed to create migrations table: %w", err)
 	}
-	
+
 	return nil
 }
 
-// LoadMigrations loads all migration files from the embedded filesystem
-func (mr *MigrationRunner) LoadMigrations() ([]Migration, error) {
-	var migrations []Migration
-	
-	// Read all migration files
-	entries, err := fs.ReadDir(migrationFiles, "migrations")
+// loadAppliedMigrations returns the rows of schema_migrations, or none if the
+// table does not exist yet
+func loadAppliedMigrations(ctx context.Context, db dbExecutor) ([]appliedMigration, error) {
+	var exists bool
+	if err := db.QueryRow(ctx, "SELECT to_regclass('schema_migrations') IS NOT NULL").Scan(&exists); err != nil {
+		return nil, fmt.Errorf("failed to check migrations table: %w", err)
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	// to_jsonb reads the checksum even from tables that predate the column
+	query := `
+		SELECT version, name, COALESCE(to_jsonb(m)->>'checksum', ''), COALESCE(applied_at, NOW())
+		FROM schema_migrations m
+		ORDER BY version
+	`
+	rows, err := db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	var applied []appliedMigration
+	for rows.Next() {
+		var migration appliedMigration
+		if err := rows.Scan(&migration.Version, &migration.Name, &migration.Checksum, &migration.AppliedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan migration version: %w", err)
+		}
+		applied = append(applied, migration)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+
+	return applied, nil
+}
+
+// recordMissingChecksums stores the current file checksum for applied
+// migrations that have none
+func recordMissingChecksums(ctx context.Context, db dbExecutor, migrations []Migration, applied []appliedMigration) error {
+	byVersion := migrationsByVersion(migrations)
+	for _, a := range applied {
+		migration, ok := byVersion[a.Version]
+		if a.Checksum != "" || !ok {
+			continue
+		}
+
+		query := "UPDATE schema_migrations SET checksum = $2 WHERE version = $1"
+		if _, err := db.Exec(ctx, query, a.Version, migration.Checksum); err != nil {
+			return fmt.Errorf("failed to record checksum of migration %d: %w", a.Version, err)
+		}
+		fmt.Printf("Recorded checksum of migration %d: %s\n", a.Version, a.Name)
+	}
+
+	return nil
+}
+
+// verifyChecksums fails when an applied migration's file was edited or removed
+func verifyChecksums(migrations []Migration, applied []appliedMigration) error {
+	byVersion := migrationsByVersion(migrations)
+
+	var problems []string
+	for _, a := range applied {
+		migration, ok := byVersion[a.Version]
+		switch {
+		case !ok:
+			problems = append(problems, fmt.Sprintf("%d_%s is applied but its file is missing", a.Version, a.Name))
+		case a.Checksum != "" && a.Checksum != migration.Checksum:
+			problems = append(problems, fmt.Sprintf("%d_%s was modified after it was applied", a.Version, a.Name))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s; restore the original files and put new changes in a new migration",
+			ErrChecksumMismatch, strings.Join(problems, ", "))
+	}
+
+	return nil
+}
+
+// planUp returns every pending migration in ascending order
+func planUp(migrations []Migration, applied []appliedMigration) []migrationStep {
+	isApplied := appliedVersions(applied)
+
+	var steps []migrationStep
+	for _, migration := range migrations {
+		if !isApplied[migration.Version] {
+			steps = append(steps, migrationStep{migration: migration})
+		}
+	}
+	return steps
+}
+
+// planDown returns the last n applied migrations, newest first
+func planDown(migrations []Migration, applied []appliedMigration, n int) []migrationStep {
+	byVersion := migrationsByVersion(migrations)
+
+	var steps []migrationStep
+	for i := len(applied) - 1; i >= 0 && len(steps) < n; i-- {
+		steps = append(steps, migrationStep{migration: byVersion[applied[i].Version], down: true})
+	}
+	return steps
+}
+
+// planGoto rolls back applied migrations above version, newest first, then
+// applies pending migrations up to and including it
+func planGoto(migrations []Migration, applied []appliedMigration, version int) ([]migrationStep, error) {
+	byVersion := migrationsByVersion(migrations)
+	if _, ok := byVersion[version]; !ok && version != 0 {
+		return nil, fmt.Errorf("unknown migration version %d", version)
+	}
+
+	var steps []migrationStep
+	for i := len(applied) - 1; i >= 0; i-- {
+		if applied[i].Version > version {
+			steps = append(steps, migrationStep{migration: byVersion[applied[i].Version], down: true})
+		}
+	}
+	for _, step := range planUp(migrations, applied) {
+		if step.migration.Version <= version {
+			steps = append(steps, step)
+		}
+	}
+	return steps, nil
+}
+
+// migrationStatuses merges migration files with applied rows
+func migrationStatuses(migrations []Migration, applied []appliedMigration) []MigrationStatus {
+	byVersion := migrationsByVersion(migrations)
+	appliedByVersion := make(map[int]appliedMigration, len(applied))
+	for _, a := range applied {
+		appliedByVersion[a.Version] = a
+	}
+
+	var statuses []MigrationStatus
+	for _, migration := range migrations {
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name, State: MigrationPending}
+		if a, ok := appliedByVersion[migration.Version]; ok {
+			appliedAt := a.AppliedAt
+			status.AppliedAt = &appliedAt
+			switch {
+			case a.Checksum == "":
+				status.State = MigrationUntracked
+			case a.Checksum != migration.Checksum:
+				status.State = MigrationChanged
+			default:
+				status.State = MigrationApplied
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	for _, a := range applied {
+		if _, ok := byVersion[a.Version]; !ok {
+			appliedAt := a.AppliedAt
+			statuses = append(statuses, MigrationStatus{Version: a.Version, Name: a.Name, State: MigrationMissing, AppliedAt: &appliedAt})
+		}
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Version < statuses[j].Version
+	})
+	return statuses
+}
+
+// loadMigrations reads up and down migration files from dir in fsys
+func loadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read migrations directory: %w", err)
 	}
-	
-	// Group files by version
-	migrationMap := make(map[string]map[string]string)
-	
+
+	byVersion := make(map[int]*Migration)
+	hasUp := make(map[int]bool)
 	for _, entry := range entries {
-		if entry.IsDir() {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
 			continue
 		}
-		
+
 		filename := entry.Name()
-		if !strings.HasSuffix(filename, ".sql") {
-			continue
+		match := migrationFilePattern.FindStringSubmatch(filename)
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %s: expected VERSION_name.up.sql or VERSION_name.down.sql", filename)
 		}
-		
-		// Parse filename: 001_create_users_table.up.sql
-		parts := strings.Split(filename, "_")
-		if len(parts) < 2 {
-			continue
+
+		version, err := strconv.Atoi(match[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in %s: %w", filename, err)
 		}
-		
-		version := parts[0]
-		
-		// Read file content
-		content, err := fs.ReadFile(migrationFiles, filepath.Join("migrations", filename))
+
+		content, err := fs.ReadFile(fsys, path.Join(dir, filename))
 		if err != nil {
 			return nil, fmt.Errorf("failed to read migration file %s: %w", filename, err)
 		}
-		
-		if migrationMap[version] == nil {
-			migrationMap[version] = make(map[string]string)
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
 		}
-		
-		if strings.Contains(filename, ".up.sql") {
-			migrationMap[version]["up"] = string(content)
-			migrationMap[version]["name"] = strings.Join(parts[1:len(parts)-1], "_")
-		} else if strings.Contains(filename, ".down.sql") {
-			migrationMap[version]["down"] = string(content)
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration version %d is used by both %s and %s", version, migration.Name, match[2])
 		}
-	}
-	
-	// Convert to Migration structs
-	for versionStr, files := range migrationMap {
-		var version int
-		if _, err := fmt.Sscanf(versionStr, "%d", &version); err != nil {
-			continue
+
+		if match[3] == "up" {
+			migration.UpSQL = string(content)
+			migration.UpNoTx = hasNoTransactionDirective(migration.UpSQL)
+			migration.Checksum = migrationChecksum(migration.UpSQL)
+			hasUp[version] = true
+		} else {
+			migration.DownSQL = string(content)
+			migration.DownNoTx = hasNoTransactionDirective(migration.DownSQL)
 		}
-		
-		migration := Migration{
-			Version: version,
-			Name:    files["name"],
-			UpSQL:   files["up"],
-			DownSQL: files["down"],
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for version, migration := range byVersion {
+		if !hasUp[version] {
+			return nil, fmt.Errorf("migration %d (%s) has no up file", version, migration.Name)
 		}
-		
-		migrations = append(migrations, migration)
+		migrations = append(migrations, *migration)
 	}
-	
+
 	// Sort by version
 	sort.Slice(migrations, func(i, j int) bool {
 		return migrations[i].Version < migrations[j].Version
 	})
-	
+
 	return migrations, nil
 }
 
-// GetAppliedMigrations returns list of applied migration versions
-func (mr *MigrationRunner) GetAppliedMigrations(ctx context.Context) ([]int, error) {
-	query := "SELECT version FROM schema_migrations ORDER BY version"
-	
-	rows, err := mr.db.Pool.Query(ctx, query)
-	if err != nil {
-		return nil, fmt.Errorf("failed to query applied migrations: %w", err)
+// migrationChecksum returns the hex SHA-256 of a migration file
+func migrationChecksum(sql string) string {
+	sum := sha256.Sum256([]byte(sql))
+	return hex.EncodeToString(sum[:])
+}
+
+// hasNoTransactionDirective reports whether the first non-blank line of a
+// migration file is NoTransactionDirective
+func hasNoTransactionDirective(sql string) bool {
+	for _, line := range strings.Split(sql, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line == NoTransactionDirective
+		}
 	}
-	defer rows.Close()
-	
-	var versions []int
-	for rows.Next() {
-		var version int
-		if err := rows.Scan(&version); err != nil {
-			return nil, fmt.Errorf("failed to scan migration version: %w", err)
+	return false
+}
+
+// splitStatements splits SQL into individual statements on semicolons outside
+// quotes, dollar-quoted bodies and comments. Statements that are only comments
+// are dropped.
+func splitStatements(sql string) []string {
+	var statements []string
+	var current strings.Builder
+	hasCode := false
+
+	flush := func() {
+		if hasCode {
+			statements = append(statements, strings.TrimSpace(current.String()))
 		}
-		versions = append(versions, version)
+		current.Reset()
+		hasCode = false
 	}
-	
-	return versions, nil
+
+	for i := 0; i < len(sql); {
+		c := sql[i]
+		end := i + 1
+
+		switch {
+		case c == '-' && strings.HasPrefix(sql[i:], "--"):
+			if end = strings.IndexByte(sql[i:], '\n'); end < 0 {
+				end = len(sql)
+			} else {
+				end += i + 1
+			}
+		case c == '/' && strings.HasPrefix(sql[i:], "/*"):
+			if end = strings.Index(sql[i+2:], "*/"); end < 0 {
+				end = len(sql)
+			} else {
+				end += i + 4
+			}
+		case c == '\'' || c == '"':
+			end = i + 1
+			for end < len(sql) {
+				if sql[end] == c {
+					// A doubled quote is an escaped quote
+					if end+1 < len(sql) && sql[end+1] == c {
+						end += 2
+						continue
+					}
+					end++
+					break
+				}
+				end++
+			}
+			hasCode = true
+		case c == '$':
+			if tag := dollarQuoteTag(sql[i:]); tag != "" {
+				if closing := strings.Index(sql[i+len(tag):], tag); closing < 0 {
+					end = len(sql)
+				} else {
+					end = i + len(tag) + closing + len(tag)
+				}
+			}
+			hasCode = true
+		case c == ';':
+			flush()
+			i = end
+			continue
+		default:
+			if c != ' ' && c != '\t' && c != '\n' && c != '\r' {
+				hasCode = true
+			}
+		}
+
+		current.WriteString(sql[i:end])
+		i = end
+	}
+	flush()
+
+	return statements
 }
 
-// Up applies all pending migrations
-func (mr *MigrationRunner) Up(ctx context.Context) error {
-	// Ensure migrations table exists
-	if err := mr.CreateMigrationsTable(ctx); err != nil {
-		return err
+// dollarQuoteTag returns the opening tag of a dollar-quoted string such as $$
+// or $body$ at the start of s, or "" if s does not start one
+func dollarQuoteTag(s string) string {
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '$':
+			return s[:i+1]
+		case c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || i > 1 && c >= '0' && c <= '9':
+			continue
+		default:
+			return ""
+		}
 	}
-	
-	// Load all migrations
-	migrations, err := mr.LoadMigrations()
-	if err != nil {
-		return err
+	return ""
+}
+
+// CreateMigration writes empty up and down files for a new migration to dir,
+// numbered after the highest existing version, and returns their paths
+func CreateMigration(dir, name string) (string, string, error) {
+	name = strings.Trim(regexp.MustCompile(`[^a-z0-9]+`).ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" {
+		return "", "", fmt.Errorf("migration name must contain letters or digits")
 	}
-	
-	// Get applied migrations
-	applied, err := mr.GetAppliedMigrations(ctx)
+
+	entries, err := os.ReadDir(dir)
 	if err != nil {
-		return err
+		return "", "", fmt.Errorf("failed to read migrations directory: %w", err)
+	}
+
+	latest := 0
+	for _, entry := range entries {
+		if match := migrationFilePattern.FindStringSubmatch(entry.Name()); match != nil {
+			if version, err := strconv.Atoi(match[1]); err == nil && version > latest {
+				latest = version
+			}
+		}
 	}
-	
-	appliedMap := make(map[int]bool)
-	for _, version := range applied {
-		appliedMap[version] = true
+
+	base := fmt.Sprintf("%03d_%s", latest+1, name)
+	title := strings.ReplaceAll(name, "_", " ")
+	files := []struct {
+		path    string
+		content string
+	}{
+		{filepath.Join(dir, base+".up.sql"), fmt.Sprintf("-- %s\n", title)},
+		{filepath.Join(dir, base+".down.sql"), fmt.Sprintf("-- Revert %s\n", title)},
 	}
-	
-	// Apply pending migrations
-	for _, migration := range migrations {
-		if appliedMap[migration.Version] {
-			continue
+
+	for _, file := range files {
+		f, err := os.OpenFile(file.path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to create migration file: %w", err)
+		}
+		_, err = f.WriteString(file.content)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
 		}
-		
-		fmt.Printf("Applying migration %d: %s\n", migration.Version, migration.Name)
-		
-		// Execute migration in transaction
-		err := mr.db.WithTx(ctx, func(tx pgx.Tx) error {
-			// Execute migration SQL
-			if _, err := mr.db.Pool.Exec(ctx, migration.UpSQL); err != nil {
-				return fmt.Errorf("failed to execute migration %d: %w", migration.Version, err)
-			}
-			
-			// Record migration as applied
-			insertQuery := "INSERT INTO schema_migrations (version, name) VALUES ($1, $2)"
-			if _, err := mr.db.Pool.Exec(ctx, insertQuery, migration.Version, migration.Name); err != nil {
-				return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
-			}
-			
-			return nil
-		})
-		
 		if err != nil {
-			return err
+			return "", "", fmt.Errorf("failed to write migration file: %w", err)
 		}
-		
-		fmt.Printf("Applied migration %d: %s\n", migration.Version, migration.Name)
 	}
-	
-	return nil
+
+	return files[0].path, files[1].path, nil
 }
 
-// Down rolls back the last applied migration
-func (mr *MigrationRunner) Down(ctx context.Context) error {
-	// Get applied migrations
-	applied, err := mr.GetAppliedMigrations(ctx)
-	if err != nil {
-		return err
-	}
-	
-	if len(applied) == 0 {
-		fmt.Println("No migrations to roll back")
-		return nil
-	}
-	
-	// Get the last applied migration
-	lastVersion := applied[len(applied)-1]
-	
-	// Load migrations
-	migrations, err := mr.LoadMigrations()
-	if err != nil {
-		return err
-	}
-	
-	// Find the migration to roll back
-	var targetMigration *Migration
+// migrationsByVersion indexes migrations by version
+func migrationsByVersion(migrations []Migration) map[int]Migration {
+	byVersion := make(map[int]Migration, len(migrations))
 	for _, migration := range migrations {
-		if migration.Version == lastVersion {
-			targetMigration = &migration
-			break
-		}
-	}
-	
-	if targetMigration == nil {
-		return fmt.Errorf("migration %d not found", lastVersion)
-	}
-	
-	fmt.Printf("Rolling back migration %d: %s\n", targetMigration.Version, targetMigration.Name)
-	
-	// Execute rollback in transaction
-	err = mr.db.WithTx(ctx, func(tx pgx.Tx) error {
-		// Execute rollback SQL
-		if _, err := mr.db.Pool.Exec(ctx, targetMigration.DownSQL); err != nil {
-			return fmt.Errorf("failed to rollback migration %d: %w", targetMigration.Version, err)
-		}
-		
-		// Remove migration record
-		deleteQuery := "DELETE FROM schema_migrations WHERE version = $1"
-		if _, err := mr.db.Pool.Exec(ctx, deleteQuery, targetMigration.Version); err != nil {
-			return fmt.Errorf("failed to remove migration record %d: %w", targetMigration.Version, err)
-		}
-		
-		return nil
-	})
-	
-	if err != nil {
-		return err
+		byVersion[migration.Version] = migration
 	}
-	
-	fmt.Printf("Rolled back migration %d: %s\n", targetMigration.Version, targetMigration.Name)
-	return nil
-}
\ No newline at end of file
+	return byVersion
+}
+
+// appliedVersions returns the set of applied versions
+func appliedVersions(applied []appliedMigration) map[int]bool {
+	versions := make(map[int]bool, len(applied))
+	for _, migration := range applied {
+		versions[migration.Version] = true
+	}
+	return versions
+}
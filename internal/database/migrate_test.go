@@ -0,0 +1,264 @@
+package database
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func testMigrationFS() fstest.MapFS {
+	return fstest.MapFS{
+		"migrations/001_create_users.up.sql":       {Data: []byte("CREATE TABLE users (id INT);")},
+		"migrations/001_create_users.down.sql":     {Data: []byte("DROP TABLE users;")},
+		"migrations/002_add_email.up.sql":          {Data: []byte("ALTER TABLE users ADD email TEXT;")},
+		"migrations/002_add_email.down.sql":        {Data: []byte("ALTER TABLE users DROP email;")},
+		"migrations/003_index_email.up.sql":        {Data: []byte("\n" + NoTransactionDirective + "\nCREATE INDEX CONCURRENTLY idx_email ON users(email);")},
+		"migrations/003_index_email.down.sql":      {Data: []byte("DROP INDEX idx_email;")},
+		"migrations/README.md":                     {Data: []byte("not a migration")},
+		"migrations/subdir/004_ignored.up.sql":     {Data: []byte("SELECT 1;")},
+		"migrations/subdir/004_ignored.down.sql":   {Data: []byte("SELECT 1;")},
+		"other/001_not_in_migrations_dir.up.sql":   {Data: []byte("SELECT 1;")},
+		"other/001_not_in_migrations_dir.down.sql": {Data: []byte("SELECT 1;")},
+	}
+}
+
+func TestLoadMigrations(t *testing.T) {
+	migrations, err := loadMigrations(testMigrationFS(), "migrations")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(migrations) != 3 {
+		t.Fatalf("Expected 3 migrations, got %d", len(migrations))
+	}
+	if migrations[0].Name != "create_users" || migrations[0].DownSQL != "DROP TABLE users;" {
+		t.Errorf("Unexpected first migration: %+v", migrations[0])
+	}
+	if migrations[0].Checksum != migrationChecksum("CREATE TABLE users (id INT);") {
+		t.Errorf("Expected checksum of the up file, got %q", migrations[0].Checksum)
+	}
+	if migrations[0].UpNoTx || !migrations[2].UpNoTx || migrations[2].DownNoTx {
+		t.Errorf("Expected only the up file of migration 3 to run outside a transaction")
+	}
+}
+
+func TestLoadMigrations_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		files fstest.MapFS
+		error string
+	}{
+		{
+			name:  "bad file name",
+			files: fstest.MapFS{"migrations/create users.up.sql": {Data: []byte("SELECT 1;")}},
+			error: "invalid migration file name",
+		},
+		{
+			name:  "missing up file",
+			files: fstest.MapFS{"migrations/001_create_users.down.sql": {Data: []byte("SELECT 1;")}},
+			error: "has no up file",
+		},
+		{
+			name: "duplicate version",
+			files: fstest.MapFS{
+				"migrations/001_create_users.up.sql":    {Data: []byte("SELECT 1;")},
+				"migrations/001_create_accounts.up.sql": {Data: []byte("SELECT 1;")},
+			},
+			error: "is used by both",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadMigrations(tt.files, "migrations")
+			if err == nil || !strings.Contains(err.Error(), tt.error) {
+				t.Errorf("Expected error containing %q, got %v", tt.error, err)
+			}
+		})
+	}
+}
+
+func TestEmbeddedMigrationsLoad(t *testing.T) {
+	migrations, err := NewMigrationRunner(nil).LoadMigrations()
+	if err != nil {
+		t.Fatalf("Expected embedded migrations to load, got %v", err)
+	}
+
+	for i, migration := range migrations {
+		if migration.Version != i+1 {
+			t.Errorf("Expected migration versions without gaps, got %d at position %d", migration.Version, i)
+		}
+		if strings.TrimSpace(migration.DownSQL) == "" {
+			t.Errorf("Migration %d (%s) has no down file", migration.Version, migration.Name)
+		}
+	}
+}
+
+func TestVerifyChecksums(t *testing.T) {
+	migrations, err := loadMigrations(testMigrationFS(), "migrations")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	applied := []appliedMigration{
+		{Version: 1, Name: "create_users", Checksum: migrations[0].Checksum},
+		{Version: 2, Name: "add_email"}, // applied before checksums were recorded
+	}
+	if err := verifyChecksums(migrations, applied); err != nil {
+		t.Errorf("Expected matching checksums to pass, got %v", err)
+	}
+
+	applied[0].Checksum = migrationChecksum("CREATE TABLE users (id BIGINT);")
+	applied = append(applied, appliedMigration{Version: 9, Name: "dropped"})
+	err = verifyChecksums(migrations, applied)
+	if !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("Expected ErrChecksumMismatch, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "1_create_users was modified") || !strings.Contains(err.Error(), "9_dropped is applied but its file is missing") {
+		t.Errorf("Expected both problems to be reported, got %v", err)
+	}
+}
+
+func TestPlanMigrations(t *testing.T) {
+	migrations, err := loadMigrations(testMigrationFS(), "migrations")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	describe := func(steps []migrationStep) []string {
+		result := []string{}
+		for _, step := range steps {
+			direction := "up"
+			if step.down {
+				direction = "down"
+			}
+			result = append(result, direction+" "+step.migration.Name)
+		}
+		return result
+	}
+
+	// Migration 2 was skipped, e.g. merged after 3 was applied
+	applied := []appliedMigration{{Version: 1}, {Version: 3}}
+
+	tests := []struct {
+		name  string
+		steps []migrationStep
+		want  []string
+	}{
+		{"up applies every pending migration", planUp(migrations, applied), []string{"up add_email"}},
+		{"down rolls back newest first", planDown(migrations, applied, 5), []string{"down index_email", "down create_users"}},
+		{"down stops after n", planDown(migrations, applied, 1), []string{"down index_email"}},
+	}
+	for _, tt := range tests {
+		if got := describe(tt.steps); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+
+	steps, err := planGoto(migrations, applied, 2)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got, want := describe(steps), []string{"down index_email", "up add_email"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("goto 2: expected %v, got %v", want, got)
+	}
+
+	steps, err = planGoto(migrations, applied, 0)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got, want := describe(steps), []string{"down index_email", "down create_users"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("goto 0: expected %v, got %v", want, got)
+	}
+
+	if _, err := planGoto(migrations, applied, 7); err == nil {
+		t.Error("Expected error for unknown target version")
+	}
+}
+
+func TestMigrationStatuses(t *testing.T) {
+	migrations, err := loadMigrations(testMigrationFS(), "migrations")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	statuses := migrationStatuses(migrations, []appliedMigration{
+		{Version: 1, Name: "create_users", Checksum: migrations[0].Checksum},
+		{Version: 2, Name: "add_email", Checksum: "stale"},
+		{Version: 5, Name: "removed"},
+	})
+
+	states := []string{}
+	for _, status := range statuses {
+		states = append(states, status.State)
+	}
+	want := []string{MigrationApplied, MigrationChanged, MigrationPending, MigrationMissing}
+	if !reflect.DeepEqual(states, want) {
+		t.Errorf("Expected states %v, got %v", want, states)
+	}
+	if statuses[2].AppliedAt != nil {
+		t.Error("Expected pending migration to have no applied time")
+	}
+}
+
+func TestSplitStatements(t *testing.T) {
+	sql := `-- migrate:no-transaction
+CREATE INDEX CONCURRENTLY idx_a ON a(x); -- trailing; comment
+/* block; comment */
+INSERT INTO notes (body) VALUES ('semi;colon ''quoted''');
+CREATE FUNCTION f() RETURNS trigger AS $body$
+BEGIN
+    RETURN NEW; -- inside function
+END;
+$body$ LANGUAGE plpgsql;
+SELECT "odd;name" FROM t
+`
+
+	statements := splitStatements(sql)
+	if len(statements) != 4 {
+		t.Fatalf("Expected 4 statements, got %d: %q", len(statements), statements)
+	}
+	if !strings.HasSuffix(statements[0], "CREATE INDEX CONCURRENTLY idx_a ON a(x)") {
+		t.Errorf("Unexpected first statement %q", statements[0])
+	}
+	if !strings.HasSuffix(statements[1], `VALUES ('semi;colon ''quoted''')`) {
+		t.Errorf("Unexpected second statement %q", statements[1])
+	}
+	if !strings.Contains(statements[2], "RETURN NEW; -- inside function") || !strings.HasSuffix(statements[2], "LANGUAGE plpgsql") {
+		t.Errorf("Unexpected third statement %q", statements[2])
+	}
+	if statements[3] != `SELECT "odd;name" FROM t` {
+		t.Errorf("Unexpected fourth statement %q", statements[3])
+	}
+}
+
+func TestCreateMigration(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "009_existing.up.sql"), []byte("SELECT 1;"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	upPath, downPath, err := CreateMigration(dir, "Add Login-Events table")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if filepath.Base(upPath) != "010_add_login_events_table.up.sql" || filepath.Base(downPath) != "010_add_login_events_table.down.sql" {
+		t.Errorf("Unexpected file names %s, %s", upPath, downPath)
+	}
+
+	migrations, err := loadMigrations(os.DirFS(dir), ".")
+	if err != nil {
+		t.Fatalf("Expected created files to load, got %v", err)
+	}
+	if len(migrations) != 2 || migrations[1].Version != 10 {
+		t.Errorf("Expected new migration 10, got %+v", migrations)
+	}
+
+	if _, _, err := CreateMigration(dir, "--"); err == nil {
+		t.Error("Expected error for a name without letters or digits")
+	}
+}
@@ -0,0 +1,5 @@
+-- Drop transfer_risk_assessments table
+DROP INDEX IF EXISTS idx_risk_assessments_user;
+DROP INDEX IF EXISTS idx_risk_assessments_review_status;
+DROP INDEX IF EXISTS idx_risk_assessments_transfer;
+DROP TABLE IF EXISTS transfer_risk_assessments;
@@ -0,0 +1,26 @@
+-- Create transfer_risk_assessments table for fraud and anomaly scoring
+CREATE TABLE transfer_risk_assessments (
+    id SERIAL PRIMARY KEY,
+    transfer_id INTEGER REFERENCES transfers(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    from_account_id INTEGER NOT NULL REFERENCES accounts(id) ON DELETE RESTRICT,
+    to_account_id INTEGER NOT NULL REFERENCES accounts(id) ON DELETE RESTRICT,
+    amount DECIMAL(15,2) NOT NULL CHECK (amount > 0),
+    risk_score INTEGER NOT NULL CHECK (risk_score >= 0 AND risk_score <= 100),
+    decision VARCHAR(20) NOT NULL CHECK (decision IN ('allow', 'review', 'block')),
+    reasons JSONB NOT NULL DEFAULT '[]'::jsonb,
+    review_status VARCHAR(20) NOT NULL DEFAULT 'none' CHECK (review_status IN ('none', 'pending', 'approved', 'rejected')),
+    reviewed_by VARCHAR(100),
+    reviewed_at TIMESTAMP,
+    review_notes TEXT,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Blocked transfers have no transfer row, so transfer_id is only unique when present
+CREATE UNIQUE INDEX idx_risk_assessments_transfer ON transfer_risk_assessments(transfer_id) WHERE transfer_id IS NOT NULL;
+
+-- Create index for the admin review queue
+CREATE INDEX idx_risk_assessments_review_status ON transfer_risk_assessments(review_status, created_at DESC);
+
+-- Create index for per-user risk history
+CREATE INDEX idx_risk_assessments_user ON transfer_risk_assessments(user_id, created_at DESC);
@@ -0,0 +1,3 @@
+-- Drop user_transfer_limits table
+DROP INDEX IF EXISTS idx_transfers_from_account_status_date;
+DROP TABLE IF EXISTS user_transfer_limits;
@@ -0,0 +1,19 @@
+-- Create user_transfer_limits table for admin overrides of default transfer limits
+CREATE TABLE user_transfer_limits (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    currency VARCHAR(3) NOT NULL,
+    -- NULL inherits the configured default, 0 means unlimited
+    daily_amount DECIMAL(15,2) CHECK (daily_amount >= 0),
+    monthly_amount DECIMAL(15,2) CHECK (monthly_amount >= 0),
+    daily_count INTEGER CHECK (daily_count >= 0),
+    monthly_count INTEGER CHECK (monthly_count >= 0),
+    updated_by VARCHAR(100),
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+
+    CONSTRAINT unique_user_limit_currency UNIQUE(user_id, currency)
+);
+
+-- Create index for aggregating a user's outgoing transfers by date
+CREATE INDEX idx_transfers_from_account_status_date ON transfers(from_account_id, status, created_at);
@@ -0,0 +1,3 @@
+-- Drop transfer_fees and fee_schedules tables
+DROP TABLE IF EXISTS transfer_fees;
+DROP TABLE IF EXISTS fee_schedules;
@@ -0,0 +1,37 @@
+-- Create fee_schedules table for per-currency transfer fee configuration
+CREATE TABLE fee_schedules (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    fee_type VARCHAR(20) NOT NULL CHECK (fee_type IN ('flat', 'percentage', 'tiered')),
+    flat_amount DECIMAL(15,2) NOT NULL DEFAULT 0 CHECK (flat_amount >= 0),
+    percentage DECIMAL(7,4) NOT NULL DEFAULT 0 CHECK (percentage >= 0 AND percentage <= 100),
+    min_fee DECIMAL(15,2) CHECK (min_fee >= 0),
+    max_fee DECIMAL(15,2) CHECK (max_fee >= 0),
+    tiers JSONB NOT NULL DEFAULT '[]'::jsonb,
+    -- Revenue account that collected fees are credited to
+    house_account_id INTEGER NOT NULL REFERENCES accounts(id) ON DELETE RESTRICT,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_by VARCHAR(100),
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+
+    CONSTRAINT fee_bounds CHECK (min_fee IS NULL OR max_fee IS NULL OR min_fee <= max_fee)
+);
+
+-- Only one schedule may be active per currency
+CREATE UNIQUE INDEX idx_fee_schedules_active_currency ON fee_schedules(currency) WHERE is_active;
+
+-- Create transfer_fees table recording the fee collected for each transfer
+CREATE TABLE transfer_fees (
+    id SERIAL PRIMARY KEY,
+    transfer_id INTEGER NOT NULL UNIQUE REFERENCES transfers(id) ON DELETE CASCADE,
+    fee_schedule_id INTEGER REFERENCES fee_schedules(id) ON DELETE SET NULL,
+    house_account_id INTEGER NOT NULL REFERENCES accounts(id) ON DELETE RESTRICT,
+    amount DECIMAL(15,2) NOT NULL CHECK (amount > 0),
+    currency VARCHAR(3) NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Create index for revenue reporting by house account
+CREATE INDEX idx_transfer_fees_house_account ON transfer_fees(house_account_id, created_at DESC);
@@ -0,0 +1,3 @@
+-- Drop transfer_batch_items and transfer_batches tables
+DROP TABLE IF EXISTS transfer_batch_items;
+DROP TABLE IF EXISTS transfer_batches;
@@ -0,0 +1,39 @@
+-- Create transfer_batches table for bulk transfer submissions
+CREATE TABLE transfer_batches (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    -- atomic batches book every item or none; best_effort books each valid item
+    mode VARCHAR(20) NOT NULL CHECK (mode IN ('atomic', 'best_effort')),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'processing', 'completed', 'partially_completed', 'failed')),
+    total_items INTEGER NOT NULL CHECK (total_items > 0),
+    succeeded_items INTEGER NOT NULL DEFAULT 0,
+    failed_items INTEGER NOT NULL DEFAULT 0,
+    error_message TEXT,
+    created_at TIMESTAMP DEFAULT NOW(),
+    started_at TIMESTAMP,
+    completed_at TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Create index for listing a user's batches
+CREATE INDEX idx_transfer_batches_user ON transfer_batches(user_id, created_at DESC);
+
+-- Create transfer_batch_items table holding each requested transfer and its outcome.
+-- Account IDs are not foreign keys so that items naming unknown accounts can be
+-- reported back as failed rather than rejected by the database.
+CREATE TABLE transfer_batch_items (
+    id SERIAL PRIMARY KEY,
+    batch_id INTEGER NOT NULL REFERENCES transfer_batches(id) ON DELETE CASCADE,
+    item_index INTEGER NOT NULL,
+    from_account_id INTEGER NOT NULL,
+    to_account_id INTEGER NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'completed', 'failed', 'skipped')),
+    transfer_id INTEGER REFERENCES transfers(id) ON DELETE SET NULL,
+    error_code VARCHAR(50),
+    error_message TEXT,
+    updated_at TIMESTAMP DEFAULT NOW(),
+
+    UNIQUE (batch_id, item_index)
+);
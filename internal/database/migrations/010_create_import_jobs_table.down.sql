@@ -0,0 +1,3 @@
+-- Drop import_job_rows and import_jobs tables
+DROP TABLE IF EXISTS import_job_rows;
+DROP TABLE IF EXISTS import_jobs;
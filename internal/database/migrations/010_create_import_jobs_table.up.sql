@@ -0,0 +1,47 @@
+-- Create import_jobs table for admin CSV bulk imports
+CREATE TABLE import_jobs (
+    id SERIAL PRIMARY KEY,
+    import_type VARCHAR(30) NOT NULL CHECK (import_type IN ('transfers', 'balance_adjustments')),
+    file_name VARCHAR(255) NOT NULL DEFAULT '',
+    -- header row of the uploaded file, repeated in the error report
+    header TEXT NOT NULL,
+    -- validated jobs hold a dry-run report and wait for an admin to execute them
+    status VARCHAR(30) NOT NULL DEFAULT 'validated' CHECK (status IN ('validated', 'processing', 'completed', 'completed_with_errors', 'failed')),
+    total_rows INTEGER NOT NULL DEFAULT 0,
+    valid_rows INTEGER NOT NULL DEFAULT 0,
+    invalid_rows INTEGER NOT NULL DEFAULT 0,
+    succeeded_rows INTEGER NOT NULL DEFAULT 0,
+    failed_rows INTEGER NOT NULL DEFAULT 0,
+    created_by VARCHAR(100) NOT NULL,
+    executed_by VARCHAR(100),
+    error_message TEXT,
+    created_at TIMESTAMP DEFAULT NOW(),
+    started_at TIMESTAMP,
+    completed_at TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Create index for listing the most recent imports
+CREATE INDEX idx_import_jobs_created_at ON import_jobs(created_at DESC);
+
+-- Create import_job_rows table holding each data row of an import and its outcome.
+-- Account IDs are not foreign keys so that rows naming unknown accounts can be
+-- kept in the report. account_id is set for balance adjustments, from/to for transfers.
+CREATE TABLE import_job_rows (
+    id SERIAL PRIMARY KEY,
+    job_id INTEGER NOT NULL REFERENCES import_jobs(id) ON DELETE CASCADE,
+    row_number INTEGER NOT NULL,
+    raw_line TEXT NOT NULL,
+    account_id INTEGER,
+    from_account_id INTEGER,
+    to_account_id INTEGER,
+    amount DECIMAL(15,2),
+    currency VARCHAR(3),
+    description TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL CHECK (status IN ('valid', 'invalid', 'succeeded', 'failed')),
+    error_message TEXT,
+    transfer_id INTEGER REFERENCES transfers(id) ON DELETE SET NULL,
+    updated_at TIMESTAMP DEFAULT NOW(),
+
+    UNIQUE (job_id, row_number)
+);
@@ -0,0 +1,4 @@
+-- migrate:no-transaction
+DROP INDEX CONCURRENTLY IF EXISTS idx_transfers_created_at_id;
+DROP INDEX CONCURRENTLY IF EXISTS idx_transfers_to_account_keyset;
+DROP INDEX CONCURRENTLY IF EXISTS idx_transfers_from_account_keyset;
@@ -0,0 +1,11 @@
+-- migrate:no-transaction
+-- Indexes for keyset pagination of transfer history on (created_at, id).
+-- Built concurrently so transfers keep booking while they are created.
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_transfers_from_account_keyset
+    ON transfers(from_account_id, created_at DESC, id DESC);
+
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_transfers_to_account_keyset
+    ON transfers(to_account_id, created_at DESC, id DESC);
+
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_transfers_created_at_id
+    ON transfers(created_at DESC, id DESC);
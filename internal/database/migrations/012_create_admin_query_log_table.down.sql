@@ -0,0 +1,2 @@
+-- Drop admin_query_log table
+DROP TABLE IF EXISTS admin_query_log;
@@ -0,0 +1,20 @@
+-- Create admin_query_log table, the audit trail of the admin SQL console.
+-- Every submitted query is recorded, including rejected and failed ones.
+CREATE TABLE admin_query_log (
+    id SERIAL PRIMARY KEY,
+    admin_username VARCHAR(100) NOT NULL,
+    query_text TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('succeeded', 'rejected', 'failed')),
+    -- json or csv
+    result_format VARCHAR(10) NOT NULL DEFAULT 'json',
+    row_count INTEGER NOT NULL DEFAULT 0,
+    truncated BOOLEAN NOT NULL DEFAULT FALSE,
+    duration_ms INTEGER NOT NULL DEFAULT 0,
+    error_message TEXT,
+    client_ip VARCHAR(45),
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Create indexes for reviewing recent queries overall and per admin
+CREATE INDEX idx_admin_query_log_created_at ON admin_query_log(created_at DESC);
+CREATE INDEX idx_admin_query_log_admin_username ON admin_query_log(admin_username, created_at DESC);
@@ -0,0 +1,24 @@
+-- Create outbox table. Messages are written in the same transaction as the
+-- change they describe and relayed to the task queue or the event bus
+-- afterwards, so a message is delivered if and only if its change commits.
+CREATE TABLE outbox (
+    id BIGSERIAL PRIMARY KEY,
+    -- messages of one aggregate are relayed in insertion order
+    aggregate_type VARCHAR(50) NOT NULL,
+    aggregate_id VARCHAR(100) NOT NULL,
+    destination VARCHAR(10) NOT NULL CHECK (destination IN ('task', 'event')),
+    -- task type or event type
+    message_type VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    -- failed messages are retried once available_at has passed
+    available_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP DEFAULT NOW(),
+    delivered_at TIMESTAMP
+);
+
+-- Create indexes for finding each aggregate's next pending message and for
+-- cleaning up delivered messages
+CREATE INDEX idx_outbox_pending ON outbox(aggregate_type, aggregate_id, id) WHERE delivered_at IS NULL;
+CREATE INDEX idx_outbox_delivered_at ON outbox(delivered_at) WHERE delivered_at IS NOT NULL;
@@ -0,0 +1,12 @@
+-- Create login_failures table, counting recent failed sign-ins per account and
+-- per client IP. The scope keeps customer and admin sign-ins apart. A row is
+-- locked until locked_until after too many failures and is deleted when the
+-- account signs in or is unlocked.
+CREATE TABLE login_failures (
+    scope VARCHAR(20) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    failure_count INTEGER NOT NULL DEFAULT 0,
+    last_failed_at TIMESTAMP NOT NULL,
+    locked_until TIMESTAMP,
+    PRIMARY KEY (scope, subject)
+);
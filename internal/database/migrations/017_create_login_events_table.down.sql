@@ -0,0 +1,3 @@
+-- Remove last_login_at column from users table and drop login_events table
+ALTER TABLE users DROP COLUMN IF EXISTS last_login_at;
+DROP TABLE IF EXISTS login_events;
@@ -0,0 +1,18 @@
+-- Create login_events table, recording every sign-in to an existing account
+-- with where it came from and how it ended. ip_range is the /24 (IPv4) or /48
+-- (IPv6) network of the address, used with user_agent to recognize sign-ins
+-- from a new device or network.
+CREATE TABLE login_events (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    ip_address VARCHAR(45) NOT NULL,
+    ip_range VARCHAR(50) NOT NULL,
+    user_agent VARCHAR(512) NOT NULL,
+    outcome VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_login_events_user_id_created_at ON login_events(user_id, created_at DESC);
+
+-- Add last_login_at column to users table, set on every successful sign-in
+ALTER TABLE users ADD COLUMN last_login_at TIMESTAMP;
@@ -0,0 +1,2 @@
+-- Remove refunded_at column from transfer_fees table
+ALTER TABLE transfer_fees DROP COLUMN IF EXISTS refunded_at;
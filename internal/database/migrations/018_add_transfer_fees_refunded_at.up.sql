@@ -0,0 +1,3 @@
+-- Add refunded_at column to transfer_fees, set when a reversed transfer's fee
+-- is returned from the house account to the sender
+ALTER TABLE transfer_fees ADD COLUMN refunded_at TIMESTAMP;
@@ -0,0 +1,5 @@
+-- Remove dead_at column from outbox table
+DROP INDEX IF EXISTS idx_outbox_dead_at;
+DROP INDEX IF EXISTS idx_outbox_pending;
+ALTER TABLE outbox DROP COLUMN IF EXISTS dead_at;
+CREATE INDEX idx_outbox_pending ON outbox(aggregate_type, aggregate_id, id) WHERE delivered_at IS NULL;
@@ -0,0 +1,9 @@
+-- Add dead_at column to outbox, set when a message is parked after its last
+-- allowed attempt. Parked messages are no longer relayed and no longer hold
+-- back later messages of their aggregate.
+ALTER TABLE outbox ADD COLUMN dead_at TIMESTAMP;
+
+-- Rebuild the pending index so it only covers messages still being relayed
+DROP INDEX IF EXISTS idx_outbox_pending;
+CREATE INDEX idx_outbox_pending ON outbox(aggregate_type, aggregate_id, id) WHERE delivered_at IS NULL AND dead_at IS NULL;
+CREATE INDEX idx_outbox_dead_at ON outbox(dead_at) WHERE dead_at IS NOT NULL;
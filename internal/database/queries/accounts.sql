@@ -110,4 +110,13 @@ WHERE ($1::text IS NULL OR u.email ILIKE '%' || $1 || '%' OR u.first_name ILIKE
   AND ($2::text IS NULL OR a.currency = $2)
   AND ($3::numeric IS NULL OR a.balance >= $3)
   AND ($4::numeric IS NULL OR a.balance <= $4)
-  AND ($5::bool IS NULL OR u.is_active = $5);
\ No newline at end of file
+  AND ($5::bool IS NULL OR u.is_active = $5);
+-- name: ListAccountsByIDs :many
+SELECT * FROM accounts
+WHERE id = ANY(@ids::int[]);
+
+-- name: LockAccountsForUpdate :many
+SELECT * FROM accounts
+WHERE id = ANY(@ids::int[])
+ORDER BY id
+FOR UPDATE;
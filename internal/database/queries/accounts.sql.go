@@ -353,6 +353,72 @@ func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]L
 	return items, nil
 }
 
+const listAccountsByIDs = `-- name: ListAccountsByIDs :many
+SELECT id, user_id, currency, balance, created_at, updated_at FROM accounts
+WHERE id = ANY($1::int[])
+`
+
+func (q *Queries) ListAccountsByIDs(ctx context.Context, ids []int32) ([]Account, error) {
+	rows, err := q.db.Query(ctx, listAccountsByIDs, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Account{}
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Currency,
+			&i.Balance,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockAccountsForUpdate = `-- name: LockAccountsForUpdate :many
+SELECT id, user_id, currency, balance, created_at, updated_at FROM accounts
+WHERE id = ANY($1::int[])
+ORDER BY id
+FOR UPDATE
+`
+
+func (q *Queries) LockAccountsForUpdate(ctx context.Context, ids []int32) ([]Account, error) {
+	rows, err := q.db.Query(ctx, lockAccountsForUpdate, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Account{}
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Currency,
+			&i.Balance,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const searchAccounts = `-- name: SearchAccounts :many
 SELECT a.id, a.user_id, a.currency, a.balance, a.created_at, a.updated_at, u.email, u.first_name, u.last_name, u.is_active
 FROM accounts a
@@ -0,0 +1,16 @@
+-- name: CountAdminQueryLogs :one
+SELECT COUNT(*) FROM admin_query_log
+WHERE sqlc.narg(admin_username)::text IS NULL OR admin_username = sqlc.narg(admin_username);
+
+-- name: CreateAdminQueryLog :one
+INSERT INTO admin_query_log (
+    admin_username, query_text, status, result_format, row_count, truncated, duration_ms, error_message, client_ip
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING *;
+
+-- name: ListAdminQueryLogs :many
+SELECT * FROM admin_query_log
+WHERE sqlc.narg(admin_username)::text IS NULL OR admin_username = sqlc.narg(admin_username)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
@@ -70,6 +70,15 @@ DELETE FROM alerts
 WHERE resolved = TRUE 
 AND resolved_at < $1;
 
+-- name: EscalateAlert :one
+UPDATE alerts 
+SET 
+    severity = $2,
+    metadata = COALESCE(metadata, '{}'::jsonb) || $3::jsonb,
+    updated_at = NOW()
+WHERE id = $1 AND resolved = FALSE
+RETURNING *;
+
 -- name: GetAlertStatistics :one
 SELECT 
     COUNT(*) as total_alerts,
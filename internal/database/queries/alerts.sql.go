@@ -149,6 +149,46 @@ func (q *Queries) DeleteOldResolvedAlerts(ctx context.Context, resolvedAt pgtype
 	return err
 }
 
+const escalateAlert = `-- name: EscalateAlert :one
+UPDATE alerts 
+SET 
+    severity = $2,
+    metadata = COALESCE(metadata, '{}'::jsonb) || $3::jsonb,
+    updated_at = NOW()
+WHERE id = $1 AND resolved = FALSE
+RETURNING id, severity, title, message, source, timestamp, acknowledged, acknowledged_by, acknowledged_at, resolved, resolved_by, resolved_at, resolved_notes, metadata, created_at, updated_at
+`
+
+type EscalateAlertParams struct {
+	ID       pgtype.UUID `db:"id" json:"id"`
+	Severity string      `db:"severity" json:"severity"`
+	Column3  []byte      `db:"column_3" json:"column_3"`
+}
+
+func (q *Queries) EscalateAlert(ctx context.Context, arg EscalateAlertParams) (Alert, error) {
+	row := q.db.QueryRow(ctx, escalateAlert, arg.ID, arg.Severity, arg.Column3)
+	var i Alert
+	err := row.Scan(
+		&i.ID,
+		&i.Severity,
+		&i.Title,
+		&i.Message,
+		&i.Source,
+		&i.Timestamp,
+		&i.Acknowledged,
+		&i.AcknowledgedBy,
+		&i.AcknowledgedAt,
+		&i.Resolved,
+		&i.ResolvedBy,
+		&i.ResolvedAt,
+		&i.ResolvedNotes,
+		&i.Metadata,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const getAlert = `-- name: GetAlert :one
 SELECT id, severity, title, message, source, timestamp, acknowledged, acknowledged_by, acknowledged_at, resolved, resolved_by, resolved_at, resolved_notes, metadata, created_at, updated_at FROM alerts WHERE id = $1
 `
@@ -0,0 +1,36 @@
+-- name: CreateAPIKey :one
+INSERT INTO api_keys (
+    user_id, name, prefix, key_hash, scopes, expires_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetAPIKeyCredentials :one
+-- Looks a key up by prefix along with the owner needed to authenticate it
+SELECT k.id, k.user_id, k.key_hash, k.scopes, k.expires_at, k.revoked_at, u.email, u.is_active
+FROM api_keys k
+JOIN users u ON u.id = k.user_id
+WHERE k.prefix = $1 LIMIT 1;
+
+-- name: ListAPIKeysByUser :many
+SELECT * FROM api_keys
+WHERE user_id = $1
+ORDER BY id;
+
+-- name: CountActiveAPIKeysByUser :one
+SELECT COUNT(*) FROM api_keys
+WHERE user_id = $1
+  AND revoked_at IS NULL
+  AND (expires_at IS NULL OR expires_at > NOW());
+
+-- name: RevokeAPIKey :execrows
+UPDATE api_keys
+SET revoked_at = NOW()
+WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL;
+
+-- name: TouchAPIKey :exec
+-- Records use of a key, at most once a minute to spare busy keys a write per request
+UPDATE api_keys
+SET last_used_at = NOW()
+WHERE id = $1
+  AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute');
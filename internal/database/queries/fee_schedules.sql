@@ -0,0 +1,61 @@
+-- name: CreateFeeSchedule :one
+INSERT INTO fee_schedules (
+    name, currency, fee_type, flat_amount, percentage, min_fee, max_fee, tiers, house_account_id, is_active, updated_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+) RETURNING *;
+
+-- name: CreateTransferFee :one
+INSERT INTO transfer_fees (
+    transfer_id, fee_schedule_id, house_account_id, amount, currency
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: DeleteFeeSchedule :exec
+DELETE FROM fee_schedules
+WHERE id = $1;
+
+-- name: GetActiveFeeSchedule :one
+SELECT * FROM fee_schedules
+WHERE currency = $1 AND is_active LIMIT 1;
+
+-- name: GetFeeSchedule :one
+SELECT * FROM fee_schedules
+WHERE id = $1 LIMIT 1;
+
+-- name: GetTransferFee :one
+SELECT * FROM transfer_fees
+WHERE transfer_id = $1 LIMIT 1;
+
+-- name: ListFeeSchedules :many
+SELECT * FROM fee_schedules
+ORDER BY currency, is_active DESC, id;
+
+-- name: ListTransferFeesByTransferIDs :many
+SELECT * FROM transfer_fees
+WHERE transfer_id = ANY(@transfer_ids::int[]);
+
+-- name: RefundTransferFee :one
+UPDATE transfer_fees
+SET refunded_at = NOW()
+WHERE transfer_id = $1 AND refunded_at IS NULL
+RETURNING *;
+
+-- name: UpdateFeeSchedule :one
+UPDATE fee_schedules
+SET
+    name = $2,
+    currency = $3,
+    fee_type = $4,
+    flat_amount = $5,
+    percentage = $6,
+    min_fee = $7,
+    max_fee = $8,
+    tiers = $9,
+    house_account_id = $10,
+    is_active = $11,
+    updated_by = $12,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
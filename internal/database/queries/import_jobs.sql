@@ -0,0 +1,72 @@
+-- name: ClaimImportJob :one
+UPDATE import_jobs
+SET
+    status = 'processing',
+    executed_by = $2,
+    started_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1 AND status = 'validated'
+RETURNING *;
+
+-- name: CompleteImportJob :one
+UPDATE import_jobs
+SET
+    status = $2,
+    succeeded_rows = $3,
+    failed_rows = $4,
+    error_message = $5,
+    completed_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: CountImportJobs :one
+SELECT COUNT(*) FROM import_jobs;
+
+-- name: CreateImportJob :one
+INSERT INTO import_jobs (
+    import_type, file_name, header, total_rows, valid_rows, invalid_rows, created_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: CreateImportJobRows :exec
+INSERT INTO import_job_rows (
+    job_id, row_number, raw_line, account_id, from_account_id, to_account_id,
+    amount, currency, description, status, error_message
+)
+SELECT
+    @job_id::int,
+    unnest(@row_numbers::int[]),
+    unnest(@raw_lines::text[]),
+    NULLIF(unnest(@account_ids::int[]), 0),
+    NULLIF(unnest(@from_account_ids::int[]), 0),
+    NULLIF(unnest(@to_account_ids::int[]), 0),
+    unnest(@amounts::numeric[]),
+    NULLIF(unnest(@currencies::text[]), ''),
+    unnest(@descriptions::text[]),
+    unnest(@statuses::text[]),
+    NULLIF(unnest(@error_messages::text[]), '');
+
+-- name: GetImportJob :one
+SELECT * FROM import_jobs
+WHERE id = $1 LIMIT 1;
+
+-- name: ListImportJobRows :many
+SELECT * FROM import_job_rows
+WHERE job_id = $1
+ORDER BY row_number;
+
+-- name: ListImportJobs :many
+SELECT * FROM import_jobs
+ORDER BY created_at DESC, id DESC
+LIMIT $1 OFFSET $2;
+
+-- name: UpdateImportJobRow :exec
+UPDATE import_job_rows
+SET
+    status = $2,
+    error_message = $3,
+    transfer_id = $4,
+    updated_at = NOW()
+WHERE id = $1;
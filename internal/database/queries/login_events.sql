@@ -0,0 +1,29 @@
+-- name: CreateLoginEvent :one
+INSERT INTO login_events (
+    user_id, ip_address, ip_range, user_agent, outcome
+) VALUES (
+    $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetLoginOrigins :one
+-- Tells whether a user has signed in successfully before, and whether from
+-- the device and IP range given
+SELECT
+    EXISTS (
+        SELECT 1 FROM login_events e
+        WHERE e.user_id = sqlc.arg(user_id) AND e.outcome = 'success'
+    )::boolean AS has_logins,
+    EXISTS (
+        SELECT 1 FROM login_events e
+        WHERE e.user_id = sqlc.arg(user_id) AND e.outcome = 'success' AND e.user_agent = sqlc.arg(user_agent)
+    )::boolean AS known_device,
+    EXISTS (
+        SELECT 1 FROM login_events e
+        WHERE e.user_id = sqlc.arg(user_id) AND e.outcome = 'success' AND e.ip_range = sqlc.arg(ip_range)
+    )::boolean AS known_ip_range;
+
+-- name: ListLoginEventsByUser :many
+SELECT * FROM login_events
+WHERE user_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2;
@@ -0,0 +1,29 @@
+-- name: GetLoginFailures :many
+-- Returns the failure counters of an account and of the IP it is signing in from
+SELECT * FROM login_failures
+WHERE (scope = sqlc.arg(account_scope) AND subject = sqlc.arg(account))
+   OR (scope = sqlc.arg(ip_scope) AND subject = sqlc.arg(ip_address));
+
+-- name: RecordLoginFailure :one
+-- Counts a failed sign-in, starting over when the last one is older than window_start
+INSERT INTO login_failures (
+    scope, subject, failure_count, last_failed_at
+) VALUES (
+    sqlc.arg(scope), sqlc.arg(subject), 1, sqlc.arg(failed_at)
+)
+ON CONFLICT (scope, subject) DO UPDATE
+SET failure_count = CASE
+        WHEN login_failures.last_failed_at < sqlc.arg(window_start) THEN 1
+        ELSE login_failures.failure_count + 1
+    END,
+    last_failed_at = EXCLUDED.last_failed_at
+RETURNING *;
+
+-- name: LockLoginFailure :exec
+UPDATE login_failures
+SET locked_until = $3
+WHERE scope = $1 AND subject = $2;
+
+-- name: DeleteLoginFailure :exec
+DELETE FROM login_failures
+WHERE scope = $1 AND subject = $2;
@@ -17,6 +17,20 @@ type Account struct {
 	UpdatedAt pgtype.Timestamp `db:"updated_at" json:"updated_at"`
 }
 
+type AdminQueryLog struct {
+	ID            int32            `db:"id" json:"id"`
+	AdminUsername string           `db:"admin_username" json:"admin_username"`
+	QueryText     string           `db:"query_text" json:"query_text"`
+	Status        string           `db:"status" json:"status"`
+	ResultFormat  string           `db:"result_format" json:"result_format"`
+	RowCount      int32            `db:"row_count" json:"row_count"`
+	Truncated     bool             `db:"truncated" json:"truncated"`
+	DurationMs    int32            `db:"duration_ms" json:"duration_ms"`
+	ErrorMessage  pgtype.Text      `db:"error_message" json:"error_message"`
+	ClientIp      pgtype.Text      `db:"client_ip" json:"client_ip"`
+	CreatedAt     pgtype.Timestamp `db:"created_at" json:"created_at"`
+}
+
 type Alert struct {
 	ID             pgtype.UUID        `db:"id" json:"id"`
 	Severity       string             `db:"severity" json:"severity"`
@@ -36,6 +50,106 @@ type Alert struct {
 	UpdatedAt      pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
 }
 
+type ApiKey struct {
+	ID         int32            `db:"id" json:"id"`
+	UserID     int32            `db:"user_id" json:"user_id"`
+	Name       string           `db:"name" json:"name"`
+	Prefix     string           `db:"prefix" json:"prefix"`
+	KeyHash    string           `db:"key_hash" json:"key_hash"`
+	Scopes     []string         `db:"scopes" json:"scopes"`
+	ExpiresAt  pgtype.Timestamp `db:"expires_at" json:"expires_at"`
+	LastUsedAt pgtype.Timestamp `db:"last_used_at" json:"last_used_at"`
+	RevokedAt  pgtype.Timestamp `db:"revoked_at" json:"revoked_at"`
+	CreatedAt  pgtype.Timestamp `db:"created_at" json:"created_at"`
+}
+
+type FeeSchedule struct {
+	ID             int32            `db:"id" json:"id"`
+	Name           string           `db:"name" json:"name"`
+	Currency       string           `db:"currency" json:"currency"`
+	FeeType        string           `db:"fee_type" json:"fee_type"`
+	FlatAmount     pgtype.Numeric   `db:"flat_amount" json:"flat_amount"`
+	Percentage     pgtype.Numeric   `db:"percentage" json:"percentage"`
+	MinFee         pgtype.Numeric   `db:"min_fee" json:"min_fee"`
+	MaxFee         pgtype.Numeric   `db:"max_fee" json:"max_fee"`
+	Tiers          []byte           `db:"tiers" json:"tiers"`
+	HouseAccountID int32            `db:"house_account_id" json:"house_account_id"`
+	IsActive       bool             `db:"is_active" json:"is_active"`
+	UpdatedBy      pgtype.Text      `db:"updated_by" json:"updated_by"`
+	CreatedAt      pgtype.Timestamp `db:"created_at" json:"created_at"`
+	UpdatedAt      pgtype.Timestamp `db:"updated_at" json:"updated_at"`
+}
+
+type ImportJob struct {
+	ID            int32            `db:"id" json:"id"`
+	ImportType    string           `db:"import_type" json:"import_type"`
+	FileName      string           `db:"file_name" json:"file_name"`
+	Header        string           `db:"header" json:"header"`
+	Status        string           `db:"status" json:"status"`
+	TotalRows     int32            `db:"total_rows" json:"total_rows"`
+	ValidRows     int32            `db:"valid_rows" json:"valid_rows"`
+	InvalidRows   int32            `db:"invalid_rows" json:"invalid_rows"`
+	SucceededRows int32            `db:"succeeded_rows" json:"succeeded_rows"`
+	FailedRows    int32            `db:"failed_rows" json:"failed_rows"`
+	CreatedBy     string           `db:"created_by" json:"created_by"`
+	ExecutedBy    pgtype.Text      `db:"executed_by" json:"executed_by"`
+	ErrorMessage  pgtype.Text      `db:"error_message" json:"error_message"`
+	CreatedAt     pgtype.Timestamp `db:"created_at" json:"created_at"`
+	StartedAt     pgtype.Timestamp `db:"started_at" json:"started_at"`
+	CompletedAt   pgtype.Timestamp `db:"completed_at" json:"completed_at"`
+	UpdatedAt     pgtype.Timestamp `db:"updated_at" json:"updated_at"`
+}
+
+type ImportJobRow struct {
+	ID            int32            `db:"id" json:"id"`
+	JobID         int32            `db:"job_id" json:"job_id"`
+	RowNumber     int32            `db:"row_number" json:"row_number"`
+	RawLine       string           `db:"raw_line" json:"raw_line"`
+	AccountID     pgtype.Int4      `db:"account_id" json:"account_id"`
+	FromAccountID pgtype.Int4      `db:"from_account_id" json:"from_account_id"`
+	ToAccountID   pgtype.Int4      `db:"to_account_id" json:"to_account_id"`
+	Amount        pgtype.Numeric   `db:"amount" json:"amount"`
+	Currency      pgtype.Text      `db:"currency" json:"currency"`
+	Description   string           `db:"description" json:"description"`
+	Status        string           `db:"status" json:"status"`
+	ErrorMessage  pgtype.Text      `db:"error_message" json:"error_message"`
+	TransferID    pgtype.Int4      `db:"transfer_id" json:"transfer_id"`
+	UpdatedAt     pgtype.Timestamp `db:"updated_at" json:"updated_at"`
+}
+
+type LoginEvent struct {
+	ID        int32            `db:"id" json:"id"`
+	UserID    int32            `db:"user_id" json:"user_id"`
+	IpAddress string           `db:"ip_address" json:"ip_address"`
+	IpRange   string           `db:"ip_range" json:"ip_range"`
+	UserAgent string           `db:"user_agent" json:"user_agent"`
+	Outcome   string           `db:"outcome" json:"outcome"`
+	CreatedAt pgtype.Timestamp `db:"created_at" json:"created_at"`
+}
+
+type LoginFailure struct {
+	Scope        string           `db:"scope" json:"scope"`
+	Subject      string           `db:"subject" json:"subject"`
+	FailureCount int32            `db:"failure_count" json:"failure_count"`
+	LastFailedAt pgtype.Timestamp `db:"last_failed_at" json:"last_failed_at"`
+	LockedUntil  pgtype.Timestamp `db:"locked_until" json:"locked_until"`
+}
+
+type Outbox struct {
+	ID            int64            `db:"id" json:"id"`
+	AggregateType string           `db:"aggregate_type" json:"aggregate_type"`
+	AggregateID   string           `db:"aggregate_id" json:"aggregate_id"`
+	Destination   string           `db:"destination" json:"destination"`
+	MessageType   string           `db:"message_type" json:"message_type"`
+	Payload       []byte           `db:"payload" json:"payload"`
+	Attempts      int32            `db:"attempts" json:"attempts"`
+	LastError     pgtype.Text      `db:"last_error" json:"last_error"`
+	AvailableAt   pgtype.Timestamp `db:"available_at" json:"available_at"`
+	CreatedAt     pgtype.Timestamp `db:"created_at" json:"created_at"`
+	DeliveredAt   pgtype.Timestamp `db:"delivered_at" json:"delivered_at"`
+	DeadAt        pgtype.Timestamp `db:"dead_at" json:"dead_at"`
+}
+
 type Transfer struct {
 	ID            int32            `db:"id" json:"id"`
 	FromAccountID int32            `db:"from_account_id" json:"from_account_id"`
@@ -46,6 +160,64 @@ type Transfer struct {
 	CreatedAt     pgtype.Timestamp `db:"created_at" json:"created_at"`
 }
 
+type TransferBatch struct {
+	ID             int32            `db:"id" json:"id"`
+	UserID         int32            `db:"user_id" json:"user_id"`
+	Mode           string           `db:"mode" json:"mode"`
+	Status         string           `db:"status" json:"status"`
+	TotalItems     int32            `db:"total_items" json:"total_items"`
+	SucceededItems int32            `db:"succeeded_items" json:"succeeded_items"`
+	FailedItems    int32            `db:"failed_items" json:"failed_items"`
+	ErrorMessage   pgtype.Text      `db:"error_message" json:"error_message"`
+	CreatedAt      pgtype.Timestamp `db:"created_at" json:"created_at"`
+	StartedAt      pgtype.Timestamp `db:"started_at" json:"started_at"`
+	CompletedAt    pgtype.Timestamp `db:"completed_at" json:"completed_at"`
+	UpdatedAt      pgtype.Timestamp `db:"updated_at" json:"updated_at"`
+}
+
+type TransferBatchItem struct {
+	ID            int32            `db:"id" json:"id"`
+	BatchID       int32            `db:"batch_id" json:"batch_id"`
+	ItemIndex     int32            `db:"item_index" json:"item_index"`
+	FromAccountID int32            `db:"from_account_id" json:"from_account_id"`
+	ToAccountID   int32            `db:"to_account_id" json:"to_account_id"`
+	Amount        pgtype.Numeric   `db:"amount" json:"amount"`
+	Description   string           `db:"description" json:"description"`
+	Status        string           `db:"status" json:"status"`
+	TransferID    pgtype.Int4      `db:"transfer_id" json:"transfer_id"`
+	ErrorCode     pgtype.Text      `db:"error_code" json:"error_code"`
+	ErrorMessage  pgtype.Text      `db:"error_message" json:"error_message"`
+	UpdatedAt     pgtype.Timestamp `db:"updated_at" json:"updated_at"`
+}
+
+type TransferFee struct {
+	ID             int32            `db:"id" json:"id"`
+	TransferID     int32            `db:"transfer_id" json:"transfer_id"`
+	FeeScheduleID  pgtype.Int4      `db:"fee_schedule_id" json:"fee_schedule_id"`
+	HouseAccountID int32            `db:"house_account_id" json:"house_account_id"`
+	Amount         pgtype.Numeric   `db:"amount" json:"amount"`
+	Currency       string           `db:"currency" json:"currency"`
+	CreatedAt      pgtype.Timestamp `db:"created_at" json:"created_at"`
+	RefundedAt     pgtype.Timestamp `db:"refunded_at" json:"refunded_at"`
+}
+
+type TransferRiskAssessment struct {
+	ID            int32            `db:"id" json:"id"`
+	TransferID    pgtype.Int4      `db:"transfer_id" json:"transfer_id"`
+	UserID        int32            `db:"user_id" json:"user_id"`
+	FromAccountID int32            `db:"from_account_id" json:"from_account_id"`
+	ToAccountID   int32            `db:"to_account_id" json:"to_account_id"`
+	Amount        pgtype.Numeric   `db:"amount" json:"amount"`
+	RiskScore     int32            `db:"risk_score" json:"risk_score"`
+	Decision      string           `db:"decision" json:"decision"`
+	Reasons       []byte           `db:"reasons" json:"reasons"`
+	ReviewStatus  string           `db:"review_status" json:"review_status"`
+	ReviewedBy    pgtype.Text      `db:"reviewed_by" json:"reviewed_by"`
+	ReviewedAt    pgtype.Timestamp `db:"reviewed_at" json:"reviewed_at"`
+	ReviewNotes   pgtype.Text      `db:"review_notes" json:"review_notes"`
+	CreatedAt     pgtype.Timestamp `db:"created_at" json:"created_at"`
+}
+
 type User struct {
 	ID               int32            `db:"id" json:"id"`
 	Email            string           `db:"email" json:"email"`
@@ -56,4 +228,50 @@ type User struct {
 	CreatedAt        pgtype.Timestamp `db:"created_at" json:"created_at"`
 	UpdatedAt        pgtype.Timestamp `db:"updated_at" json:"updated_at"`
 	IsActive         pgtype.Bool      `db:"is_active" json:"is_active"`
+	LastLoginAt      pgtype.Timestamp `db:"last_login_at" json:"last_login_at"`
+}
+
+type UserTransferLimit struct {
+	ID            int32            `db:"id" json:"id"`
+	UserID        int32            `db:"user_id" json:"user_id"`
+	Currency      string           `db:"currency" json:"currency"`
+	DailyAmount   pgtype.Numeric   `db:"daily_amount" json:"daily_amount"`
+	MonthlyAmount pgtype.Numeric   `db:"monthly_amount" json:"monthly_amount"`
+	DailyCount    pgtype.Int4      `db:"daily_count" json:"daily_count"`
+	MonthlyCount  pgtype.Int4      `db:"monthly_count" json:"monthly_count"`
+	UpdatedBy     pgtype.Text      `db:"updated_by" json:"updated_by"`
+	CreatedAt     pgtype.Timestamp `db:"created_at" json:"created_at"`
+	UpdatedAt     pgtype.Timestamp `db:"updated_at" json:"updated_at"`
+}
+
+type WebhookDelivery struct {
+	ID            int64            `db:"id" json:"id"`
+	EndpointID    int32            `db:"endpoint_id" json:"endpoint_id"`
+	EventID       string           `db:"event_id" json:"event_id"`
+	EventType     string           `db:"event_type" json:"event_type"`
+	Payload       []byte           `db:"payload" json:"payload"`
+	Status        string           `db:"status" json:"status"`
+	Attempts      int32            `db:"attempts" json:"attempts"`
+	ResponseCode  pgtype.Int4      `db:"response_code" json:"response_code"`
+	ResponseBody  pgtype.Text      `db:"response_body" json:"response_body"`
+	LastError     pgtype.Text      `db:"last_error" json:"last_error"`
+	DurationMs    pgtype.Int4      `db:"duration_ms" json:"duration_ms"`
+	RedeliveryOf  pgtype.Int8      `db:"redelivery_of" json:"redelivery_of"`
+	CreatedAt     pgtype.Timestamp `db:"created_at" json:"created_at"`
+	LastAttemptAt pgtype.Timestamp `db:"last_attempt_at" json:"last_attempt_at"`
+	DeliveredAt   pgtype.Timestamp `db:"delivered_at" json:"delivered_at"`
+}
+
+type WebhookEndpoint struct {
+	ID                  int32            `db:"id" json:"id"`
+	UserID              int32            `db:"user_id" json:"user_id"`
+	Url                 string           `db:"url" json:"url"`
+	Secret              string           `db:"secret" json:"secret"`
+	EventTypes          []string         `db:"event_types" json:"event_types"`
+	Enabled             bool             `db:"enabled" json:"enabled"`
+	ConsecutiveFailures int32            `db:"consecutive_failures" json:"consecutive_failures"`
+	DisabledReason      pgtype.Text      `db:"disabled_reason" json:"disabled_reason"`
+	DisabledAt          pgtype.Timestamp `db:"disabled_at" json:"disabled_at"`
+	CreatedAt           pgtype.Timestamp `db:"created_at" json:"created_at"`
+	UpdatedAt           pgtype.Timestamp `db:"updated_at" json:"updated_at"`
 }
@@ -0,0 +1,54 @@
+-- name: CreateOutboxMessage :exec
+INSERT INTO outbox (
+    aggregate_type, aggregate_id, destination, message_type, payload
+) VALUES (
+    $1, $2, $3, $4, $5
+);
+
+-- name: ClaimOutboxMessages :many
+-- Locks the next pending message of each aggregate whose earlier messages have
+-- all been delivered or parked, so an aggregate's messages are relayed in
+-- order. SKIP LOCKED lets several relays run side by side.
+SELECT * FROM outbox o
+WHERE o.delivered_at IS NULL
+  AND o.dead_at IS NULL
+  AND o.available_at <= NOW()
+  AND NOT EXISTS (
+      SELECT 1 FROM outbox earlier
+      WHERE earlier.aggregate_type = o.aggregate_type
+        AND earlier.aggregate_id = o.aggregate_id
+        AND earlier.delivered_at IS NULL
+        AND earlier.dead_at IS NULL
+        AND earlier.id < o.id
+  )
+ORDER BY o.id
+LIMIT $1
+FOR UPDATE SKIP LOCKED;
+
+-- name: MarkOutboxMessagesDelivered :exec
+UPDATE outbox
+SET delivered_at = NOW(), last_error = NULL
+WHERE id = ANY(@ids::bigint[]);
+
+-- name: ParkOutboxMessage :exec
+UPDATE outbox
+SET attempts = attempts + 1,
+    last_error = sqlc.arg(last_error),
+    dead_at = NOW()
+WHERE id = sqlc.arg(id);
+
+-- name: RecordOutboxFailure :exec
+UPDATE outbox
+SET attempts = attempts + 1,
+    last_error = sqlc.arg(last_error),
+    available_at = NOW() + sqlc.arg(retry_after_seconds)::int * INTERVAL '1 second'
+WHERE id = sqlc.arg(id);
+
+-- name: DeleteDeliveredOutboxMessages :execrows
+DELETE FROM outbox
+WHERE id IN (
+    SELECT id FROM outbox
+    WHERE delivered_at < NOW() - sqlc.arg(retention_seconds)::int * INTERVAL '1 second'
+    ORDER BY id
+    LIMIT sqlc.arg('limit')
+);
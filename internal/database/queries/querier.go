@@ -15,34 +15,95 @@ type Querier interface {
 	AddToBalance(ctx context.Context, arg AddToBalanceParams) (Account, error)
 	AdminCountUsers(ctx context.Context, arg AdminCountUsersParams) (int64, error)
 	AdminCreateUser(ctx context.Context, arg AdminCreateUserParams) (User, error)
-	AdminDeleteUser(ctx context.Context, id int32) error
+	AdminDeleteUser(ctx context.Context, arg AdminDeleteUserParams) (int64, error)
 	AdminDisableUser(ctx context.Context, id int32) error
 	AdminEnableUser(ctx context.Context, id int32) error
+	// version is the row's xmin, which changes on every write; admins send it
+	// back in If-Match to detect concurrent edits
 	AdminGetUserDetail(ctx context.Context, id int32) (AdminGetUserDetailRow, error)
 	// Admin-specific user management queries
 	AdminListUsers(ctx context.Context, arg AdminListUsersParams) ([]AdminListUsersRow, error)
 	AdminUpdateUser(ctx context.Context, arg AdminUpdateUserParams) (User, error)
+	ClaimImportJob(ctx context.Context, arg ClaimImportJobParams) (ImportJob, error)
+	// Locks the next pending message of each aggregate whose earlier messages have
+	// all been delivered, so an aggregate's messages are relayed in order. SKIP
+	// LOCKED lets several relays run side by side.
+	ClaimOutboxMessages(ctx context.Context, limit int32) ([]Outbox, error)
+	ClaimTransferBatch(ctx context.Context, arg ClaimTransferBatchParams) (TransferBatch, error)
+	// Marks the welcome email as sent unless it already was; a claimed email is
+	// queued in the same transaction
+	ClaimWelcomeEmail(ctx context.Context, id int32) (int64, error)
+	CompleteImportJob(ctx context.Context, arg CompleteImportJobParams) (ImportJob, error)
+	CompleteTransferBatch(ctx context.Context, arg CompleteTransferBatchParams) (TransferBatch, error)
 	CountAccounts(ctx context.Context, arg CountAccountsParams) (int64, error)
+	CountActiveAPIKeysByUser(ctx context.Context, userID int32) (int64, error)
+	CountAdminQueryLogs(ctx context.Context, adminUsername pgtype.Text) (int64, error)
 	CountAlerts(ctx context.Context, arg CountAlertsParams) (int64, error)
+	CountImportJobs(ctx context.Context) (int64, error)
+	CountTransferRiskAssessments(ctx context.Context, arg CountTransferRiskAssessmentsParams) (int64, error)
 	CountTransfersAdvanced(ctx context.Context, arg CountTransfersAdvancedParams) (int64, error)
 	CountTransfersByAccount(ctx context.Context, fromAccountID int32) (int64, error)
+	CountWebhookDeliveries(ctx context.Context, endpointID int32) (int64, error)
+	CountWebhookEndpointsByUser(ctx context.Context, userID int32) (int64, error)
+	CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) (ApiKey, error)
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error)
+	CreateAdminQueryLog(ctx context.Context, arg CreateAdminQueryLogParams) (AdminQueryLog, error)
 	CreateAlert(ctx context.Context, arg CreateAlertParams) (Alert, error)
+	CreateFeeSchedule(ctx context.Context, arg CreateFeeScheduleParams) (FeeSchedule, error)
+	CreateImportJob(ctx context.Context, arg CreateImportJobParams) (ImportJob, error)
+	CreateImportJobRows(ctx context.Context, arg CreateImportJobRowsParams) error
+	CreateLoginEvent(ctx context.Context, arg CreateLoginEventParams) (LoginEvent, error)
+	CreateOutboxMessage(ctx context.Context, arg CreateOutboxMessageParams) error
 	CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error)
+	CreateTransferBatch(ctx context.Context, arg CreateTransferBatchParams) (TransferBatch, error)
+	CreateTransferBatchItems(ctx context.Context, arg CreateTransferBatchItemsParams) error
+	CreateTransferFee(ctx context.Context, arg CreateTransferFeeParams) (TransferFee, error)
+	CreateTransferRiskAssessment(ctx context.Context, arg CreateTransferRiskAssessmentParams) (TransferRiskAssessment, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	// Returns no rows when the event was already dispatched to the endpoint
+	CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) (WebhookDelivery, error)
+	CreateWebhookEndpoint(ctx context.Context, arg CreateWebhookEndpointParams) (WebhookEndpoint, error)
 	DeleteAccount(ctx context.Context, id int32) error
+	DeleteDeliveredOutboxMessages(ctx context.Context, arg DeleteDeliveredOutboxMessagesParams) (int64, error)
+	DeleteFeeSchedule(ctx context.Context, id int32) error
+	DeleteLoginFailure(ctx context.Context, arg DeleteLoginFailureParams) error
 	DeleteOldResolvedAlerts(ctx context.Context, resolvedAt pgtype.Timestamptz) error
 	DeleteUser(ctx context.Context, id int32) error
+	DeleteUserTransferLimit(ctx context.Context, arg DeleteUserTransferLimitParams) error
+	DeleteWebhookEndpoint(ctx context.Context, id int32) error
+	DisableWebhookEndpoint(ctx context.Context, arg DisableWebhookEndpointParams) (WebhookEndpoint, error)
+	EnableWebhookEndpoint(ctx context.Context, id int32) (WebhookEndpoint, error)
+	EscalateAlert(ctx context.Context, arg EscalateAlertParams) (Alert, error)
+	// Gives up on a pending delivery without attempting it
+	FailWebhookDelivery(ctx context.Context, arg FailWebhookDeliveryParams) error
 	FreezeAccount(ctx context.Context, id int32) (Account, error)
+	// Looks a key up by prefix along with the owner needed to authenticate it
+	GetAPIKeyCredentials(ctx context.Context, prefix string) (GetAPIKeyCredentialsRow, error)
 	GetAccount(ctx context.Context, id int32) (Account, error)
 	GetAccountByUserAndCurrency(ctx context.Context, arg GetAccountByUserAndCurrencyParams) (Account, error)
 	GetAccountForUpdate(ctx context.Context, id int32) (Account, error)
 	GetAccountWithUser(ctx context.Context, id int32) (GetAccountWithUserRow, error)
 	GetAccountsWithBalance(ctx context.Context) ([]Account, error)
+	GetActiveFeeSchedule(ctx context.Context, currency string) (FeeSchedule, error)
 	GetAlert(ctx context.Context, id pgtype.UUID) (Alert, error)
 	GetAlertStatistics(ctx context.Context, arg GetAlertStatisticsParams) (GetAlertStatisticsRow, error)
 	GetAlertsBySource(ctx context.Context, arg GetAlertsBySourceParams) ([]Alert, error)
+	GetFeeSchedule(ctx context.Context, id int32) (FeeSchedule, error)
+	GetImportJob(ctx context.Context, id int32) (ImportJob, error)
+	// Returns the failure counters of an account and of the IP it is signing in from
+	GetLoginFailures(ctx context.Context, arg GetLoginFailuresParams) ([]LoginFailure, error)
+	// Tells whether a user has signed in successfully before, and whether from
+	// the device and IP range given
+	GetLoginOrigins(ctx context.Context, arg GetLoginOriginsParams) (GetLoginOriginsRow, error)
 	GetTransfer(ctx context.Context, id int32) (GetTransferRow, error)
+	GetTransferBatch(ctx context.Context, id int32) (TransferBatch, error)
+	GetTransferFee(ctx context.Context, transferID int32) (TransferFee, error)
+	GetTransferForUpdate(ctx context.Context, id int32) (Transfer, error)
+	GetTransferRiskAssessment(ctx context.Context, id int32) (TransferRiskAssessment, error)
+	GetTransferRiskAssessmentByTransfer(ctx context.Context, transferID pgtype.Int4) (TransferRiskAssessment, error)
+	GetTransferRiskAssessmentForUpdate(ctx context.Context, id int32) (TransferRiskAssessment, error)
+	GetTransferRiskStats(ctx context.Context, arg GetTransferRiskStatsParams) (GetTransferRiskStatsRow, error)
+	GetTransferUsage(ctx context.Context, arg GetTransferUsageParams) (GetTransferUsageRow, error)
 	GetTransfersByAccount(ctx context.Context, arg GetTransfersByAccountParams) ([]GetTransfersByAccountRow, error)
 	GetTransfersByDateRange(ctx context.Context, arg GetTransfersByDateRangeParams) ([]GetTransfersByDateRangeRow, error)
 	GetTransfersByStatus(ctx context.Context, arg GetTransfersByStatusParams) ([]GetTransfersByStatusRow, error)
@@ -51,21 +112,69 @@ type Querier interface {
 	GetUser(ctx context.Context, id int32) (User, error)
 	GetUserAccounts(ctx context.Context, userID int32) ([]Account, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
+	GetUserTransferLimit(ctx context.Context, arg GetUserTransferLimitParams) (UserTransferLimit, error)
+	GetWebhookDelivery(ctx context.Context, id int64) (WebhookDelivery, error)
+	GetWebhookEndpoint(ctx context.Context, id int32) (WebhookEndpoint, error)
+	ListAPIKeysByUser(ctx context.Context, userID int32) ([]ApiKey, error)
 	ListAccounts(ctx context.Context, arg ListAccountsParams) ([]ListAccountsRow, error)
+	ListAccountsByIDs(ctx context.Context, ids []int32) ([]Account, error)
+	ListAdminQueryLogs(ctx context.Context, arg ListAdminQueryLogsParams) ([]AdminQueryLog, error)
 	ListAlerts(ctx context.Context, arg ListAlertsParams) ([]Alert, error)
+	ListFeeSchedules(ctx context.Context) ([]FeeSchedule, error)
+	ListImportJobRows(ctx context.Context, jobID int32) ([]ImportJobRow, error)
+	ListImportJobs(ctx context.Context, arg ListImportJobsParams) ([]ImportJob, error)
+	ListLoginEventsByUser(ctx context.Context, arg ListLoginEventsByUserParams) ([]LoginEvent, error)
+	ListTransferBatchItems(ctx context.Context, batchID int32) ([]TransferBatchItem, error)
+	ListTransferFeesByTransferIDs(ctx context.Context, transferIds []int32) ([]TransferFee, error)
+	ListTransferRiskAssessments(ctx context.Context, arg ListTransferRiskAssessmentsParams) ([]TransferRiskAssessment, error)
 	ListTransfers(ctx context.Context, arg ListTransfersParams) ([]ListTransfersRow, error)
+	ListUserTransferLimits(ctx context.Context, userID int32) ([]UserTransferLimit, error)
+	// Keyset pages of a user's transfers ordered by (created_at, id). Zero and empty
+	// values disable the account, direction and counterparty filters.
+	ListUserTransfersNewer(ctx context.Context, arg ListUserTransfersNewerParams) ([]ListUserTransfersNewerRow, error)
+	// Keyset pages of a user's transfers ordered by (created_at, id). Zero and empty
+	// values disable the account, direction and counterparty filters.
+	ListUserTransfersOlder(ctx context.Context, arg ListUserTransfersOlderParams) ([]ListUserTransfersOlderRow, error)
 	ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error)
+	ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	ListWebhookEndpointsByUser(ctx context.Context, userID int32) ([]WebhookEndpoint, error)
+	ListWebhookEndpointsForEvent(ctx context.Context, arg ListWebhookEndpointsForEventParams) ([]WebhookEndpoint, error)
+	LockAccountsForUpdate(ctx context.Context, ids []int32) ([]Account, error)
+	LockLoginFailure(ctx context.Context, arg LockLoginFailureParams) error
+	MarkOutboxMessagesDelivered(ctx context.Context, ids []int64) error
 	MarkWelcomeEmailSent(ctx context.Context, id int32) error
+	ParkOutboxMessage(ctx context.Context, arg ParkOutboxMessageParams) error
+	// Counts a failed sign-in, starting over when the last one is older than window_start
+	RecordLoginFailure(ctx context.Context, arg RecordLoginFailureParams) (LoginFailure, error)
+	RecordOutboxFailure(ctx context.Context, arg RecordOutboxFailureParams) error
+	RecordWebhookDeliveryAttempt(ctx context.Context, arg RecordWebhookDeliveryAttemptParams) error
+	// Counts a failed attempt and disables the endpoint once disable_after
+	// attempts in a row have failed
+	RecordWebhookEndpointFailure(ctx context.Context, arg RecordWebhookEndpointFailureParams) (WebhookEndpoint, error)
+	RecordWebhookEndpointSuccess(ctx context.Context, id int32) error
+	RefundTransferFee(ctx context.Context, transferID int32) (TransferFee, error)
 	ResolveAlert(ctx context.Context, arg ResolveAlertParams) (Alert, error)
+	ReviewTransferRiskAssessment(ctx context.Context, arg ReviewTransferRiskAssessmentParams) (TransferRiskAssessment, error)
+	RevokeAPIKey(ctx context.Context, arg RevokeAPIKeyParams) (int64, error)
 	SearchAccounts(ctx context.Context, arg SearchAccountsParams) ([]SearchAccountsRow, error)
 	SearchAlerts(ctx context.Context, arg SearchAlertsParams) ([]Alert, error)
 	SearchTransfersAdvanced(ctx context.Context, arg SearchTransfersAdvancedParams) ([]SearchTransfersAdvancedRow, error)
+	// Records a successful sign-in; updated_at is left alone, as the profile
+	// did not change
+	SetUserLastLogin(ctx context.Context, arg SetUserLastLoginParams) error
 	SubtractFromBalance(ctx context.Context, arg SubtractFromBalanceParams) (Account, error)
+	// Records use of a key, at most once a minute to spare busy keys a write per request
+	TouchAPIKey(ctx context.Context, id int32) error
 	UnfreezeAccount(ctx context.Context, id int32) (Account, error)
 	UpdateAccount(ctx context.Context, id int32) (Account, error)
 	UpdateAccountBalance(ctx context.Context, arg UpdateAccountBalanceParams) (Account, error)
+	UpdateFeeSchedule(ctx context.Context, arg UpdateFeeScheduleParams) (FeeSchedule, error)
+	UpdateImportJobRow(ctx context.Context, arg UpdateImportJobRowParams) error
+	UpdateTransferBatchItems(ctx context.Context, arg UpdateTransferBatchItemsParams) error
 	UpdateTransferStatus(ctx context.Context, arg UpdateTransferStatusParams) (Transfer, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
+	UpdateWebhookEndpoint(ctx context.Context, arg UpdateWebhookEndpointParams) (WebhookEndpoint, error)
+	UpsertUserTransferLimit(ctx context.Context, arg UpsertUserTransferLimitParams) (UserTransferLimit, error)
 }
 
 var _ Querier = (*Queries)(nil)
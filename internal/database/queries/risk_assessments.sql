@@ -0,0 +1,61 @@
+-- name: GetTransferRiskStats :one
+SELECT
+    (SELECT COUNT(*) FROM transfers t
+     WHERE t.from_account_id = @from_account_id AND t.created_at >= @velocity_since)::int AS account_recent_count,
+    (SELECT COUNT(*) FROM transfers t
+     JOIN accounts a ON t.from_account_id = a.id
+     WHERE a.user_id = @user_id AND t.created_at >= @velocity_since)::int AS user_recent_count,
+    (SELECT COALESCE(AVG(t.amount), 0) FROM transfers t
+     JOIN accounts a ON t.from_account_id = a.id
+     WHERE a.user_id = @user_id AND t.status = 'completed' AND t.created_at >= @history_since)::numeric AS average_amount,
+    (SELECT COUNT(*) FROM transfers t
+     JOIN accounts a ON t.from_account_id = a.id
+     WHERE a.user_id = @user_id AND t.status = 'completed' AND t.created_at >= @history_since)::int AS history_count,
+    (SELECT COUNT(*) FROM transfers t
+     JOIN accounts a ON t.from_account_id = a.id
+     WHERE a.user_id = @user_id AND t.to_account_id = @to_account_id AND t.status = 'completed')::int AS prior_recipient_count,
+    (SELECT COUNT(DISTINCT t.to_account_id) FROM transfers t
+     JOIN accounts a ON t.from_account_id = a.id
+     WHERE a.user_id = @user_id AND t.to_account_id <> @to_account_id AND t.created_at >= @fan_out_since)::int AS other_recipient_count;
+
+-- name: CreateTransferRiskAssessment :one
+INSERT INTO transfer_risk_assessments (
+    transfer_id, user_id, from_account_id, to_account_id, amount, risk_score, decision, reasons, review_status
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING *;
+
+-- name: GetTransferRiskAssessment :one
+SELECT * FROM transfer_risk_assessments
+WHERE id = $1 LIMIT 1;
+
+-- name: GetTransferRiskAssessmentByTransfer :one
+SELECT * FROM transfer_risk_assessments
+WHERE transfer_id = $1 LIMIT 1;
+
+-- name: GetTransferRiskAssessmentForUpdate :one
+SELECT * FROM transfer_risk_assessments
+WHERE id = $1 LIMIT 1
+FOR UPDATE;
+
+-- name: ListTransferRiskAssessments :many
+SELECT * FROM transfer_risk_assessments
+WHERE (sqlc.narg(review_status)::text IS NULL OR review_status = sqlc.narg(review_status))
+  AND (sqlc.narg(decision)::text IS NULL OR decision = sqlc.narg(decision))
+ORDER BY created_at DESC
+LIMIT @limit_count OFFSET @offset_count;
+
+-- name: CountTransferRiskAssessments :one
+SELECT COUNT(*) FROM transfer_risk_assessments
+WHERE (sqlc.narg(review_status)::text IS NULL OR review_status = sqlc.narg(review_status))
+  AND (sqlc.narg(decision)::text IS NULL OR decision = sqlc.narg(decision));
+
+-- name: ReviewTransferRiskAssessment :one
+UPDATE transfer_risk_assessments
+SET 
+    review_status = $2,
+    reviewed_by = $3,
+    reviewed_at = NOW(),
+    review_notes = $4
+WHERE id = $1 AND review_status = 'pending'
+RETURNING *;
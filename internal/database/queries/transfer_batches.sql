@@ -0,0 +1,70 @@
+-- name: ClaimTransferBatch :one
+UPDATE transfer_batches
+SET
+    status = 'processing',
+    started_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1
+    AND (
+        status = 'pending'
+        OR (status = 'processing' AND started_at < NOW() - sqlc.arg(stale_after)::interval)
+    )
+RETURNING *;
+
+-- name: CompleteTransferBatch :one
+UPDATE transfer_batches
+SET
+    status = $2,
+    succeeded_items = $3,
+    failed_items = $4,
+    error_message = $5,
+    completed_at = NOW(),
+    updated_at = NOW()
+WHERE id = $1 AND status = 'processing' AND started_at = sqlc.arg(started_at)
+RETURNING *;
+
+-- name: CreateTransferBatch :one
+INSERT INTO transfer_batches (
+    user_id, mode, total_items
+) VALUES (
+    $1, $2, $3
+) RETURNING *;
+
+-- name: CreateTransferBatchItems :exec
+INSERT INTO transfer_batch_items (
+    batch_id, item_index, from_account_id, to_account_id, amount, description
+)
+SELECT
+    @batch_id::int,
+    unnest(@item_indexes::int[]),
+    unnest(@from_account_ids::int[]),
+    unnest(@to_account_ids::int[]),
+    unnest(@amounts::numeric[]),
+    unnest(@descriptions::text[]);
+
+-- name: GetTransferBatch :one
+SELECT * FROM transfer_batches
+WHERE id = $1 LIMIT 1;
+
+-- name: ListTransferBatchItems :many
+SELECT * FROM transfer_batch_items
+WHERE batch_id = $1
+ORDER BY item_index;
+
+-- name: UpdateTransferBatchItems :exec
+UPDATE transfer_batch_items AS i
+SET
+    status = u.status,
+    transfer_id = NULLIF(u.transfer_id, 0),
+    error_code = NULLIF(u.error_code, ''),
+    error_message = NULLIF(u.error_message, ''),
+    updated_at = NOW()
+FROM (
+    SELECT
+        unnest(@ids::int[]) AS id,
+        unnest(@statuses::text[]) AS status,
+        unnest(@transfer_ids::int[]) AS transfer_id,
+        unnest(@error_codes::text[]) AS error_code,
+        unnest(@error_messages::text[]) AS error_message
+) AS u
+WHERE i.id = u.id;
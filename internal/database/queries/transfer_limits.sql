@@ -0,0 +1,41 @@
+-- name: GetTransferUsage :one
+SELECT
+    COALESCE(SUM(t.amount) FILTER (WHERE t.created_at >= @day_start), 0)::numeric AS daily_amount,
+    COUNT(*) FILTER (WHERE t.created_at >= @day_start)::int AS daily_count,
+    COALESCE(SUM(t.amount), 0)::numeric AS monthly_amount,
+    COUNT(*)::int AS monthly_count
+FROM transfers t
+JOIN accounts a ON t.from_account_id = a.id
+WHERE a.user_id = @user_id
+  AND a.currency = @currency
+  AND t.status IN ('pending', 'completed')
+  AND t.created_at >= @month_start;
+
+-- name: GetUserTransferLimit :one
+SELECT * FROM user_transfer_limits
+WHERE user_id = $1 AND currency = $2 LIMIT 1;
+
+-- name: ListUserTransferLimits :many
+SELECT * FROM user_transfer_limits
+WHERE user_id = $1
+ORDER BY currency;
+
+-- name: UpsertUserTransferLimit :one
+INSERT INTO user_transfer_limits (
+    user_id, currency, daily_amount, monthly_amount, daily_count, monthly_count, updated_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+ON CONFLICT (user_id, currency) DO UPDATE
+SET
+    daily_amount = EXCLUDED.daily_amount,
+    monthly_amount = EXCLUDED.monthly_amount,
+    daily_count = EXCLUDED.daily_count,
+    monthly_count = EXCLUDED.monthly_count,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: DeleteUserTransferLimit :exec
+DELETE FROM user_transfer_limits
+WHERE user_id = $1 AND currency = $2;
@@ -18,6 +18,11 @@ JOIN users fu ON fa.user_id = fu.id
 JOIN users tu ON ta.user_id = tu.id
 WHERE t.id = $1 LIMIT 1;
 
+-- name: GetTransferForUpdate :one
+SELECT * FROM transfers
+WHERE id = $1 LIMIT 1
+FOR UPDATE;
+
 -- name: GetTransfersByAccount :many
 SELECT t.*, 
        fa.currency as from_currency,
@@ -125,4 +130,60 @@ WHERE ($1::int IS NULL OR fa.user_id = $1 OR ta.user_id = $1)
   AND ($6::numeric IS NULL OR t.amount <= $6)
   AND ($7::timestamp IS NULL OR t.created_at >= $7)
   AND ($8::timestamp IS NULL OR t.created_at <= $8)
-  AND ($9::text IS NULL OR t.description ILIKE '%' || $9 || '%');
\ No newline at end of file
+  AND ($9::text IS NULL OR t.description ILIKE '%' || $9 || '%');
+
+-- name: ListUserTransfersOlder :many
+-- Keyset pages of a user's transfers ordered by (created_at, id). Zero and empty
+-- values disable the account, direction and counterparty filters.
+SELECT t.*,
+       fa.currency as from_currency,
+       ta.currency as to_currency,
+       fa.user_id as from_user_id,
+       ta.user_id as to_user_id
+FROM transfers t
+JOIN accounts fa ON t.from_account_id = fa.id
+JOIN accounts ta ON t.to_account_id = ta.id
+WHERE (fa.user_id = @user_id OR ta.user_id = @user_id)
+  AND (@account_id::int = 0 OR t.from_account_id = @account_id OR t.to_account_id = @account_id)
+  AND (@direction::text = ''
+       OR (@direction = 'outgoing' AND fa.user_id = @user_id AND (@account_id = 0 OR t.from_account_id = @account_id))
+       OR (@direction = 'incoming' AND ta.user_id = @user_id AND (@account_id = 0 OR t.to_account_id = @account_id)))
+  AND (@counterparty_account_id::int = 0
+       OR (t.from_account_id = @counterparty_account_id AND ta.user_id = @user_id)
+       OR (t.to_account_id = @counterparty_account_id AND fa.user_id = @user_id))
+  AND (sqlc.narg(created_from)::timestamp IS NULL OR t.created_at >= sqlc.narg(created_from))
+  AND (sqlc.narg(created_to)::timestamp IS NULL OR t.created_at < sqlc.narg(created_to))
+  AND (sqlc.narg(min_amount)::numeric IS NULL OR t.amount >= sqlc.narg(min_amount))
+  AND (sqlc.narg(max_amount)::numeric IS NULL OR t.amount <= sqlc.narg(max_amount))
+  AND (sqlc.narg(cursor_created_at)::timestamp IS NULL
+       OR (t.created_at, t.id) < (sqlc.narg(cursor_created_at), @cursor_id::int))
+ORDER BY t.created_at DESC, t.id DESC
+LIMIT @limit_count;
+
+-- name: ListUserTransfersNewer :many
+-- Keyset pages of a user's transfers ordered by (created_at, id). Zero and empty
+-- values disable the account, direction and counterparty filters.
+SELECT t.*,
+       fa.currency as from_currency,
+       ta.currency as to_currency,
+       fa.user_id as from_user_id,
+       ta.user_id as to_user_id
+FROM transfers t
+JOIN accounts fa ON t.from_account_id = fa.id
+JOIN accounts ta ON t.to_account_id = ta.id
+WHERE (fa.user_id = @user_id OR ta.user_id = @user_id)
+  AND (@account_id::int = 0 OR t.from_account_id = @account_id OR t.to_account_id = @account_id)
+  AND (@direction::text = ''
+       OR (@direction = 'outgoing' AND fa.user_id = @user_id AND (@account_id = 0 OR t.from_account_id = @account_id))
+       OR (@direction = 'incoming' AND ta.user_id = @user_id AND (@account_id = 0 OR t.to_account_id = @account_id)))
+  AND (@counterparty_account_id::int = 0
+       OR (t.from_account_id = @counterparty_account_id AND ta.user_id = @user_id)
+       OR (t.to_account_id = @counterparty_account_id AND fa.user_id = @user_id))
+  AND (sqlc.narg(created_from)::timestamp IS NULL OR t.created_at >= sqlc.narg(created_from))
+  AND (sqlc.narg(created_to)::timestamp IS NULL OR t.created_at < sqlc.narg(created_to))
+  AND (sqlc.narg(min_amount)::numeric IS NULL OR t.amount >= sqlc.narg(min_amount))
+  AND (sqlc.narg(max_amount)::numeric IS NULL OR t.amount <= sqlc.narg(max_amount))
+  AND (sqlc.narg(cursor_created_at)::timestamp IS NULL
+       OR (t.created_at, t.id) > (sqlc.narg(cursor_created_at), @cursor_id::int))
+ORDER BY t.created_at ASC, t.id ASC
+LIMIT @limit_count;
@@ -154,6 +154,27 @@ func (q *Queries) GetTransfer(ctx context.Context, id int32) (GetTransferRow, er
 	return i, err
 }
 
+const getTransferForUpdate = `-- name: GetTransferForUpdate :one
+SELECT id, from_account_id, to_account_id, amount, description, status, created_at FROM transfers
+WHERE id = $1 LIMIT 1
+FOR UPDATE
+`
+
+func (q *Queries) GetTransferForUpdate(ctx context.Context, id int32) (Transfer, error) {
+	row := q.db.QueryRow(ctx, getTransferForUpdate, id)
+	var i Transfer
+	err := row.Scan(
+		&i.ID,
+		&i.FromAccountID,
+		&i.ToAccountID,
+		&i.Amount,
+		&i.Description,
+		&i.Status,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const getTransfersByAccount = `-- name: GetTransfersByAccount :many
 SELECT t.id, t.from_account_id, t.to_account_id, t.amount, t.description, t.status, t.created_at, 
        fa.currency as from_currency,
@@ -472,6 +493,208 @@ func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([
 	return items, nil
 }
 
+const listUserTransfersNewer = `-- name: ListUserTransfersNewer :many
+SELECT t.id, t.from_account_id, t.to_account_id, t.amount, t.description, t.status, t.created_at,
+       fa.currency as from_currency,
+       ta.currency as to_currency,
+       fa.user_id as from_user_id,
+       ta.user_id as to_user_id
+FROM transfers t
+JOIN accounts fa ON t.from_account_id = fa.id
+JOIN accounts ta ON t.to_account_id = ta.id
+WHERE (fa.user_id = $1 OR ta.user_id = $1)
+  AND ($2::int = 0 OR t.from_account_id = $2 OR t.to_account_id = $2)
+  AND ($3::text = ''
+       OR ($3 = 'outgoing' AND fa.user_id = $1 AND ($2 = 0 OR t.from_account_id = $2))
+       OR ($3 = 'incoming' AND ta.user_id = $1 AND ($2 = 0 OR t.to_account_id = $2)))
+  AND ($4::int = 0
+       OR (t.from_account_id = $4 AND ta.user_id = $1)
+       OR (t.to_account_id = $4 AND fa.user_id = $1))
+  AND ($5::timestamp IS NULL OR t.created_at >= $5)
+  AND ($6::timestamp IS NULL OR t.created_at < $6)
+  AND ($7::numeric IS NULL OR t.amount >= $7)
+  AND ($8::numeric IS NULL OR t.amount <= $8)
+  AND ($9::timestamp IS NULL
+       OR (t.created_at, t.id) > ($9, $10::int))
+ORDER BY t.created_at ASC, t.id ASC
+LIMIT $11
+`
+
+type ListUserTransfersNewerParams struct {
+	UserID                int32            `db:"user_id" json:"user_id"`
+	AccountID             int32            `db:"account_id" json:"account_id"`
+	Direction             string           `db:"direction" json:"direction"`
+	CounterpartyAccountID int32            `db:"counterparty_account_id" json:"counterparty_account_id"`
+	CreatedFrom           pgtype.Timestamp `db:"created_from" json:"created_from"`
+	CreatedTo             pgtype.Timestamp `db:"created_to" json:"created_to"`
+	MinAmount             pgtype.Numeric   `db:"min_amount" json:"min_amount"`
+	MaxAmount             pgtype.Numeric   `db:"max_amount" json:"max_amount"`
+	CursorCreatedAt       pgtype.Timestamp `db:"cursor_created_at" json:"cursor_created_at"`
+	CursorID              int32            `db:"cursor_id" json:"cursor_id"`
+	LimitCount            int32            `db:"limit_count" json:"limit_count"`
+}
+
+type ListUserTransfersNewerRow struct {
+	ID            int32            `db:"id" json:"id"`
+	FromAccountID int32            `db:"from_account_id" json:"from_account_id"`
+	ToAccountID   int32            `db:"to_account_id" json:"to_account_id"`
+	Amount        pgtype.Numeric   `db:"amount" json:"amount"`
+	Description   pgtype.Text      `db:"description" json:"description"`
+	Status        pgtype.Text      `db:"status" json:"status"`
+	CreatedAt     pgtype.Timestamp `db:"created_at" json:"created_at"`
+	FromCurrency  string           `db:"from_currency" json:"from_currency"`
+	ToCurrency    string           `db:"to_currency" json:"to_currency"`
+	FromUserID    int32            `db:"from_user_id" json:"from_user_id"`
+	ToUserID      int32            `db:"to_user_id" json:"to_user_id"`
+}
+
+// Keyset pages of a user's transfers ordered by (created_at, id). Zero and empty
+// values disable the account, direction and counterparty filters.
+func (q *Queries) ListUserTransfersNewer(ctx context.Context, arg ListUserTransfersNewerParams) ([]ListUserTransfersNewerRow, error) {
+	rows, err := q.db.Query(ctx, listUserTransfersNewer,
+		arg.UserID,
+		arg.AccountID,
+		arg.Direction,
+		arg.CounterpartyAccountID,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.LimitCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListUserTransfersNewerRow{}
+	for rows.Next() {
+		var i ListUserTransfersNewerRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.Description,
+			&i.Status,
+			&i.CreatedAt,
+			&i.FromCurrency,
+			&i.ToCurrency,
+			&i.FromUserID,
+			&i.ToUserID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserTransfersOlder = `-- name: ListUserTransfersOlder :many
+SELECT t.id, t.from_account_id, t.to_account_id, t.amount, t.description, t.status, t.created_at,
+       fa.currency as from_currency,
+       ta.currency as to_currency,
+       fa.user_id as from_user_id,
+       ta.user_id as to_user_id
+FROM transfers t
+JOIN accounts fa ON t.from_account_id = fa.id
+JOIN accounts ta ON t.to_account_id = ta.id
+WHERE (fa.user_id = $1 OR ta.user_id = $1)
+  AND ($2::int = 0 OR t.from_account_id = $2 OR t.to_account_id = $2)
+  AND ($3::text = ''
+       OR ($3 = 'outgoing' AND fa.user_id = $1 AND ($2 = 0 OR t.from_account_id = $2))
+       OR ($3 = 'incoming' AND ta.user_id = $1 AND ($2 = 0 OR t.to_account_id = $2)))
+  AND ($4::int = 0
+       OR (t.from_account_id = $4 AND ta.user_id = $1)
+       OR (t.to_account_id = $4 AND fa.user_id = $1))
+  AND ($5::timestamp IS NULL OR t.created_at >= $5)
+  AND ($6::timestamp IS NULL OR t.created_at < $6)
+  AND ($7::numeric IS NULL OR t.amount >= $7)
+  AND ($8::numeric IS NULL OR t.amount <= $8)
+  AND ($9::timestamp IS NULL
+       OR (t.created_at, t.id) < ($9, $10::int))
+ORDER BY t.created_at DESC, t.id DESC
+LIMIT $11
+`
+
+type ListUserTransfersOlderParams struct {
+	UserID                int32            `db:"user_id" json:"user_id"`
+	AccountID             int32            `db:"account_id" json:"account_id"`
+	Direction             string           `db:"direction" json:"direction"`
+	CounterpartyAccountID int32            `db:"counterparty_account_id" json:"counterparty_account_id"`
+	CreatedFrom           pgtype.Timestamp `db:"created_from" json:"created_from"`
+	CreatedTo             pgtype.Timestamp `db:"created_to" json:"created_to"`
+	MinAmount             pgtype.Numeric   `db:"min_amount" json:"min_amount"`
+	MaxAmount             pgtype.Numeric   `db:"max_amount" json:"max_amount"`
+	CursorCreatedAt       pgtype.Timestamp `db:"cursor_created_at" json:"cursor_created_at"`
+	CursorID              int32            `db:"cursor_id" json:"cursor_id"`
+	LimitCount            int32            `db:"limit_count" json:"limit_count"`
+}
+
+type ListUserTransfersOlderRow struct {
+	ID            int32            `db:"id" json:"id"`
+	FromAccountID int32            `db:"from_account_id" json:"from_account_id"`
+	ToAccountID   int32            `db:"to_account_id" json:"to_account_id"`
+	Amount        pgtype.Numeric   `db:"amount" json:"amount"`
+	Description   pgtype.Text      `db:"description" json:"description"`
+	Status        pgtype.Text      `db:"status" json:"status"`
+	CreatedAt     pgtype.Timestamp `db:"created_at" json:"created_at"`
+	FromCurrency  string           `db:"from_currency" json:"from_currency"`
+	ToCurrency    string           `db:"to_currency" json:"to_currency"`
+	FromUserID    int32            `db:"from_user_id" json:"from_user_id"`
+	ToUserID      int32            `db:"to_user_id" json:"to_user_id"`
+}
+
+// Keyset pages of a user's transfers ordered by (created_at, id). Zero and empty
+// values disable the account, direction and counterparty filters.
+func (q *Queries) ListUserTransfersOlder(ctx context.Context, arg ListUserTransfersOlderParams) ([]ListUserTransfersOlderRow, error) {
+	rows, err := q.db.Query(ctx, listUserTransfersOlder,
+		arg.UserID,
+		arg.AccountID,
+		arg.Direction,
+		arg.CounterpartyAccountID,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.LimitCount,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListUserTransfersOlderRow{}
+	for rows.Next() {
+		var i ListUserTransfersOlderRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromAccountID,
+			&i.ToAccountID,
+			&i.Amount,
+			&i.Description,
+			&i.Status,
+			&i.CreatedAt,
+			&i.FromCurrency,
+			&i.ToCurrency,
+			&i.FromUserID,
+			&i.ToUserID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const searchTransfersAdvanced = `-- name: SearchTransfersAdvanced :many
 SELECT t.id, t.from_account_id, t.to_account_id, t.amount, t.description, t.status, t.created_at,
        fa.currency as from_currency, ta.currency as to_currency,
@@ -29,6 +29,22 @@ SET
     updated_at = NOW()
 WHERE id = $1;
 
+-- name: ClaimWelcomeEmail :execrows
+-- Marks the welcome email as sent unless it already was; a claimed email is
+-- queued in the same transaction
+UPDATE users
+SET 
+    welcome_email_sent = true,
+    updated_at = NOW()
+WHERE id = $1 AND welcome_email_sent IS NOT TRUE;
+
+-- name: SetUserLastLogin :exec
+-- Records a successful sign-in; updated_at is left alone, as the profile
+-- did not change
+UPDATE users
+SET last_login_at = $2
+WHERE id = $1;
+
 -- name: DeleteUser :exec
 DELETE FROM users
 WHERE id = $1;
@@ -72,10 +88,13 @@ WHERE
     AND ($2::boolean IS NULL OR u.is_active = $2);
 
 -- name: AdminGetUserDetail :one
+-- version is the row's xmin, which changes on every write; admins send it
+-- back in If-Match to detect concurrent edits
 SELECT 
     u.*,
     COUNT(DISTINCT a.id) as account_count,
-    COUNT(DISTINCT t.id) as transfer_count
+    COUNT(DISTINCT t.id) as transfer_count,
+    u.xmin::text::bigint as version
 FROM users u
 LEFT JOIN accounts a ON u.id = a.user_id
 LEFT JOIN transfers t ON (a.id = t.from_account_id OR a.id = t.to_account_id)
@@ -97,6 +116,7 @@ SET
     is_active = COALESCE(sqlc.narg(is_active), is_active),
     updated_at = NOW()
 WHERE id = $1
+    AND (sqlc.narg(expected_version)::bigint IS NULL OR xmin::text::bigint = sqlc.narg(expected_version))
 RETURNING *;
 
 -- name: AdminDisableUser :exec
@@ -113,6 +133,7 @@ SET
     updated_at = NOW()
 WHERE id = $1;
 
--- name: AdminDeleteUser :exec
+-- name: AdminDeleteUser :execrows
 DELETE FROM users
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1
+    AND (sqlc.narg(expected_version)::bigint IS NULL OR xmin::text::bigint = sqlc.narg(expected_version));
\ No newline at end of file
@@ -36,7 +36,7 @@ INSERT INTO users (
     email, password_hash, first_name, last_name, is_active
 ) VALUES (
     $1, $2, $3, $4, COALESCE($5, true)
-) RETURNING id, email, password_hash, first_name, last_name, welcome_email_sent, created_at, updated_at, is_active
+) RETURNING id, email, password_hash, first_name, last_name, welcome_email_sent, created_at, updated_at, is_active, last_login_at
 `
 
 type AdminCreateUserParams struct {
@@ -66,18 +66,28 @@ func (q *Queries) AdminCreateUser(ctx context.Context, arg AdminCreateUserParams
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.IsActive,
+		&i.LastLoginAt,
 	)
 	return i, err
 }
 
-const adminDeleteUser = `-- name: AdminDeleteUser :exec
+const adminDeleteUser = `-- name: AdminDeleteUser :execrows
 DELETE FROM users
 WHERE id = $1
+    AND ($2::bigint IS NULL OR xmin::text::bigint = $2)
 `
 
-func (q *Queries) AdminDeleteUser(ctx context.Context, id int32) error {
-	_, err := q.db.Exec(ctx, adminDeleteUser, id)
-	return err
+type AdminDeleteUserParams struct {
+	ID              int32       `db:"id" json:"id"`
+	ExpectedVersion pgtype.Int8 `db:"expected_version" json:"expected_version"`
+}
+
+func (q *Queries) AdminDeleteUser(ctx context.Context, arg AdminDeleteUserParams) (int64, error) {
+	result, err := q.db.Exec(ctx, adminDeleteUser, arg.ID, arg.ExpectedVersion)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const adminDisableUser = `-- name: AdminDisableUser :exec
@@ -108,9 +118,10 @@ func (q *Queries) AdminEnableUser(ctx context.Context, id int32) error {
 
 const adminGetUserDetail = `-- name: AdminGetUserDetail :one
 SELECT 
-    u.id, u.email, u.password_hash, u.first_name, u.last_name, u.welcome_email_sent, u.created_at, u.updated_at, u.is_active,
+    u.id, u.email, u.password_hash, u.first_name, u.last_name, u.welcome_email_sent, u.created_at, u.updated_at, u.is_active, u.last_login_at,
     COUNT(DISTINCT a.id) as account_count,
-    COUNT(DISTINCT t.id) as transfer_count
+    COUNT(DISTINCT t.id) as transfer_count,
+    u.xmin::text::bigint as version
 FROM users u
 LEFT JOIN accounts a ON u.id = a.user_id
 LEFT JOIN transfers t ON (a.id = t.from_account_id OR a.id = t.to_account_id)
@@ -128,10 +139,14 @@ type AdminGetUserDetailRow struct {
 	CreatedAt        pgtype.Timestamp `db:"created_at" json:"created_at"`
 	UpdatedAt        pgtype.Timestamp `db:"updated_at" json:"updated_at"`
 	IsActive         pgtype.Bool      `db:"is_active" json:"is_active"`
+	LastLoginAt      pgtype.Timestamp `db:"last_login_at" json:"last_login_at"`
 	AccountCount     int64            `db:"account_count" json:"account_count"`
 	TransferCount    int64            `db:"transfer_count" json:"transfer_count"`
+	Version          int64            `db:"version" json:"version"`
 }
 
+// version is the row's xmin, which changes on every write; admins send it
+// back in If-Match to detect concurrent edits
 func (q *Queries) AdminGetUserDetail(ctx context.Context, id int32) (AdminGetUserDetailRow, error) {
 	row := q.db.QueryRow(ctx, adminGetUserDetail, id)
 	var i AdminGetUserDetailRow
@@ -145,8 +160,10 @@ func (q *Queries) AdminGetUserDetail(ctx context.Context, id int32) (AdminGetUse
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.IsActive,
+		&i.LastLoginAt,
 		&i.AccountCount,
 		&i.TransferCount,
+		&i.Version,
 	)
 	return i, err
 }
@@ -154,7 +171,7 @@ func (q *Queries) AdminGetUserDetail(ctx context.Context, id int32) (AdminGetUse
 const adminListUsers = `-- name: AdminListUsers :many
 
 SELECT 
-    u.id, u.email, u.password_hash, u.first_name, u.last_name, u.welcome_email_sent, u.created_at, u.updated_at, u.is_active,
+    u.id, u.email, u.password_hash, u.first_name, u.last_name, u.welcome_email_sent, u.created_at, u.updated_at, u.is_active, u.last_login_at,
     COUNT(DISTINCT a.id) as account_count,
     COUNT(DISTINCT t.id) as transfer_count
 FROM users u
@@ -196,6 +213,7 @@ type AdminListUsersRow struct {
 	CreatedAt        pgtype.Timestamp `db:"created_at" json:"created_at"`
 	UpdatedAt        pgtype.Timestamp `db:"updated_at" json:"updated_at"`
 	IsActive         pgtype.Bool      `db:"is_active" json:"is_active"`
+	LastLoginAt      pgtype.Timestamp `db:"last_login_at" json:"last_login_at"`
 	AccountCount     int64            `db:"account_count" json:"account_count"`
 	TransferCount    int64            `db:"transfer_count" json:"transfer_count"`
 }
@@ -227,6 +245,8 @@ func (q *Queries) AdminListUsers(ctx context.Context, arg AdminListUsersParams)
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.IsActive,
+			&i.LastLoginAt,
+		&i.LastLoginAt,
 			&i.AccountCount,
 			&i.TransferCount,
 		); err != nil {
@@ -248,14 +268,16 @@ SET
     is_active = COALESCE($4, is_active),
     updated_at = NOW()
 WHERE id = $1
-RETURNING id, email, password_hash, first_name, last_name, welcome_email_sent, created_at, updated_at, is_active
+    AND ($5::bigint IS NULL OR xmin::text::bigint = $5)
+RETURNING id, email, password_hash, first_name, last_name, welcome_email_sent, created_at, updated_at, is_active, last_login_at
 `
 
 type AdminUpdateUserParams struct {
-	ID        int32       `db:"id" json:"id"`
-	FirstName pgtype.Text `db:"first_name" json:"first_name"`
-	LastName  pgtype.Text `db:"last_name" json:"last_name"`
-	IsActive  pgtype.Bool `db:"is_active" json:"is_active"`
+	ID              int32       `db:"id" json:"id"`
+	FirstName       pgtype.Text `db:"first_name" json:"first_name"`
+	LastName        pgtype.Text `db:"last_name" json:"last_name"`
+	IsActive        pgtype.Bool `db:"is_active" json:"is_active"`
+	ExpectedVersion pgtype.Int8 `db:"expected_version" json:"expected_version"`
 }
 
 func (q *Queries) AdminUpdateUser(ctx context.Context, arg AdminUpdateUserParams) (User, error) {
@@ -264,6 +286,7 @@ func (q *Queries) AdminUpdateUser(ctx context.Context, arg AdminUpdateUserParams
 		arg.FirstName,
 		arg.LastName,
 		arg.IsActive,
+		arg.ExpectedVersion,
 	)
 	var i User
 	err := row.Scan(
@@ -276,16 +299,35 @@ func (q *Queries) AdminUpdateUser(ctx context.Context, arg AdminUpdateUserParams
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.IsActive,
+		&i.LastLoginAt,
 	)
 	return i, err
 }
 
+const claimWelcomeEmail = `-- name: ClaimWelcomeEmail :execrows
+UPDATE users
+SET 
+    welcome_email_sent = true,
+    updated_at = NOW()
+WHERE id = $1 AND welcome_email_sent IS NOT TRUE
+`
+
+// Marks the welcome email as sent unless it already was; a claimed email is
+// queued in the same transaction
+func (q *Queries) ClaimWelcomeEmail(ctx context.Context, id int32) (int64, error) {
+	result, err := q.db.Exec(ctx, claimWelcomeEmail, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (
     email, password_hash, first_name, last_name
 ) VALUES (
     $1, $2, $3, $4
-) RETURNING id, email, password_hash, first_name, last_name, welcome_email_sent, created_at, updated_at, is_active
+) RETURNING id, email, password_hash, first_name, last_name, welcome_email_sent, created_at, updated_at, is_active, last_login_at
 `
 
 type CreateUserParams struct {
@@ -313,6 +355,7 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.IsActive,
+		&i.LastLoginAt,
 	)
 	return i, err
 }
@@ -328,7 +371,7 @@ func (q *Queries) DeleteUser(ctx context.Context, id int32) error {
 }
 
 const getUser = `-- name: GetUser :one
-SELECT id, email, password_hash, first_name, last_name, welcome_email_sent, created_at, updated_at, is_active FROM users
+SELECT id, email, password_hash, first_name, last_name, welcome_email_sent, created_at, updated_at, is_active, last_login_at FROM users
 WHERE id = $1 LIMIT 1
 `
 
@@ -345,12 +388,13 @@ func (q *Queries) GetUser(ctx context.Context, id int32) (User, error) {
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.IsActive,
+		&i.LastLoginAt,
 	)
 	return i, err
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, email, password_hash, first_name, last_name, welcome_email_sent, created_at, updated_at, is_active FROM users
+SELECT id, email, password_hash, first_name, last_name, welcome_email_sent, created_at, updated_at, is_active, last_login_at FROM users
 WHERE email = $1 LIMIT 1
 `
 
@@ -367,12 +411,13 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.IsActive,
+		&i.LastLoginAt,
 	)
 	return i, err
 }
 
 const listUsers = `-- name: ListUsers :many
-SELECT id, email, password_hash, first_name, last_name, welcome_email_sent, created_at, updated_at, is_active FROM users
+SELECT id, email, password_hash, first_name, last_name, welcome_email_sent, created_at, updated_at, is_active, last_login_at FROM users
 ORDER BY created_at DESC
 LIMIT $1 OFFSET $2
 `
@@ -401,6 +446,8 @@ func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, e
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.IsActive,
+			&i.LastLoginAt,
+		&i.LastLoginAt,
 		); err != nil {
 			return nil, err
 		}
@@ -425,6 +472,24 @@ func (q *Queries) MarkWelcomeEmailSent(ctx context.Context, id int32) error {
 	return err
 }
 
+const setUserLastLogin = `-- name: SetUserLastLogin :exec
+UPDATE users
+SET last_login_at = $2
+WHERE id = $1
+`
+
+type SetUserLastLoginParams struct {
+	ID          int32            `db:"id" json:"id"`
+	LastLoginAt pgtype.Timestamp `db:"last_login_at" json:"last_login_at"`
+}
+
+// Records a successful sign-in; updated_at is left alone, as the profile
+// did not change
+func (q *Queries) SetUserLastLogin(ctx context.Context, arg SetUserLastLoginParams) error {
+	_, err := q.db.Exec(ctx, setUserLastLogin, arg.ID, arg.LastLoginAt)
+	return err
+}
+
 const updateUser = `-- name: UpdateUser :one
 UPDATE users
 SET 
@@ -432,7 +497,7 @@ SET
     last_name = COALESCE($3, last_name),
     updated_at = NOW()
 WHERE id = $1
-RETURNING id, email, password_hash, first_name, last_name, welcome_email_sent, created_at, updated_at, is_active
+RETURNING id, email, password_hash, first_name, last_name, welcome_email_sent, created_at, updated_at, is_active, last_login_at
 `
 
 type UpdateUserParams struct {
@@ -454,6 +519,7 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.IsActive,
+		&i.LastLoginAt,
 	)
 	return i, err
 }
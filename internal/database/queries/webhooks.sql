@@ -0,0 +1,117 @@
+-- name: CreateWebhookEndpoint :one
+INSERT INTO webhook_endpoints (
+    user_id, url, secret, event_types
+) VALUES (
+    $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetWebhookEndpoint :one
+SELECT * FROM webhook_endpoints
+WHERE id = $1 LIMIT 1;
+
+-- name: ListWebhookEndpointsByUser :many
+SELECT * FROM webhook_endpoints
+WHERE user_id = $1
+ORDER BY id;
+
+-- name: CountWebhookEndpointsByUser :one
+SELECT COUNT(*) FROM webhook_endpoints
+WHERE user_id = $1;
+
+-- name: ListWebhookEndpointsForEvent :many
+SELECT * FROM webhook_endpoints
+WHERE user_id = sqlc.arg(user_id)
+  AND enabled
+  AND sqlc.arg(event_type)::text = ANY(event_types)
+ORDER BY id;
+
+-- name: UpdateWebhookEndpoint :one
+UPDATE webhook_endpoints
+SET url = $2, event_types = $3, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: EnableWebhookEndpoint :one
+UPDATE webhook_endpoints
+SET enabled = TRUE,
+    consecutive_failures = 0,
+    disabled_reason = NULL,
+    disabled_at = NULL,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: DisableWebhookEndpoint :one
+UPDATE webhook_endpoints
+SET enabled = FALSE, disabled_reason = $2, disabled_at = NOW(), updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteWebhookEndpoint :exec
+DELETE FROM webhook_endpoints
+WHERE id = $1;
+
+-- name: RecordWebhookEndpointSuccess :exec
+UPDATE webhook_endpoints
+SET consecutive_failures = 0, updated_at = NOW()
+WHERE id = $1 AND consecutive_failures > 0;
+
+-- name: RecordWebhookEndpointFailure :one
+-- Counts a failed attempt and disables the endpoint once disable_after
+-- attempts in a row have failed
+UPDATE webhook_endpoints
+SET consecutive_failures = consecutive_failures + 1,
+    enabled = enabled AND consecutive_failures + 1 < sqlc.arg(disable_after)::int,
+    disabled_reason = CASE
+        WHEN enabled AND consecutive_failures + 1 >= sqlc.arg(disable_after)::int THEN sqlc.arg(disabled_reason)::text
+        ELSE disabled_reason
+    END,
+    disabled_at = CASE
+        WHEN enabled AND consecutive_failures + 1 >= sqlc.arg(disable_after)::int THEN NOW()
+        ELSE disabled_at
+    END,
+    updated_at = NOW()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: CreateWebhookDelivery :one
+-- Returns no rows when the event was already dispatched to the endpoint
+INSERT INTO webhook_deliveries (
+    endpoint_id, event_id, event_type, payload, redelivery_of
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT (endpoint_id, event_id) WHERE redelivery_of IS NULL DO NOTHING
+RETURNING *;
+
+-- name: GetWebhookDelivery :one
+SELECT * FROM webhook_deliveries
+WHERE id = $1 LIMIT 1;
+
+-- name: ListWebhookDeliveries :many
+SELECT * FROM webhook_deliveries
+WHERE endpoint_id = $1
+ORDER BY id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: CountWebhookDeliveries :one
+SELECT COUNT(*) FROM webhook_deliveries
+WHERE endpoint_id = $1;
+
+-- name: RecordWebhookDeliveryAttempt :exec
+UPDATE webhook_deliveries
+SET attempts = attempts + 1,
+    status = $2,
+    response_code = $3,
+    response_body = $4,
+    last_error = $5,
+    duration_ms = $6,
+    last_attempt_at = NOW(),
+    delivered_at = CASE WHEN $2 = 'succeeded' THEN NOW() ELSE delivered_at END
+WHERE id = $1;
+
+-- name: FailWebhookDelivery :exec
+-- Gives up on a pending delivery without attempting it
+UPDATE webhook_deliveries
+SET status = 'failed', last_error = $2
+WHERE id = $1 AND status = 'pending';
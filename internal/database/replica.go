@@ -0,0 +1,191 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/phantom-sage/bankgo/internal/config"
+)
+
+// replicaLagQuery measures how far the replica is behind the primary. A replica
+// that has replayed everything it received is not lagging even when the primary
+// has been idle, so the replay timestamp is only consulted while WAL is pending.
+const replicaLagQuery = `
+SELECT CASE
+    WHEN NOT pg_is_in_recovery() THEN 0
+    WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+    ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+END::float8`
+
+// ReplicaStatus describes the read replica as last seen by the lag monitor
+type ReplicaStatus struct {
+	Configured bool          `json:"configured"`
+	Healthy    bool          `json:"healthy"`
+	Lag        time.Duration `json:"lag"`
+	MaxLag     time.Duration `json:"max_lag"`
+	CheckedAt  time.Time     `json:"checked_at"`
+	Error      string        `json:"error,omitempty"`
+}
+
+// replicaMonitor tracks replica health so reads can be routed without querying
+// the replica on every request
+type replicaMonitor struct {
+	pool     *pgxpool.Pool
+	maxLag   time.Duration
+	interval time.Duration
+
+	mu     sync.RWMutex
+	status ReplicaStatus
+
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// newReplicaPool creates the replica connection pool with the same sizing as the primary
+func newReplicaPool(cfg config.DatabaseConfig) (*pgxpool.Pool, error) {
+	poolConfig, err := pgxpool.ParseConfig(cfg.ReplicaConnectionString())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse replica database config: %w", err)
+	}
+
+	poolConfig.MaxConns = int32(cfg.MaxOpenConns)
+	poolConfig.MinConns = int32(cfg.MaxIdleConns)
+	poolConfig.MaxConnLifetime = cfg.ConnMaxLifetime
+	poolConfig.MaxConnIdleTime = cfg.ConnMaxIdleTime
+
+	pool, err := pgxpool.NewWithConfig(context.Background(), poolConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create replica connection pool: %w", err)
+	}
+
+	return pool, nil
+}
+
+func newReplicaMonitor(pool *pgxpool.Pool, maxLag, interval time.Duration) *replicaMonitor {
+	return &replicaMonitor{
+		pool:     pool,
+		maxLag:   maxLag,
+		interval: interval,
+		status:   ReplicaStatus{Configured: true, MaxLag: maxLag, Error: "replica has not been checked yet"},
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// run checks the replica every interval until close is called
+func (m *replicaMonitor) run() {
+	defer close(m.done)
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.stop:
+			return
+		case <-ticker.C:
+			m.check(context.Background())
+		}
+	}
+}
+
+// check measures the replica's lag and records the result
+func (m *replicaMonitor) check(ctx context.Context) ReplicaStatus {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var lagSeconds float64
+	err := m.pool.QueryRow(ctx, replicaLagQuery).Scan(&lagSeconds)
+
+	status := evaluateReplica(time.Duration(lagSeconds*float64(time.Second)), err, m.maxLag)
+	status.CheckedAt = time.Now()
+
+	m.mu.Lock()
+	m.status = status
+	m.mu.Unlock()
+
+	return status
+}
+
+// current returns the result of the most recent check
+func (m *replicaMonitor) current() ReplicaStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.status
+}
+
+// close stops the monitor; it is safe to call more than once
+func (m *replicaMonitor) close() {
+	m.closeOnce.Do(func() {
+		close(m.stop)
+		<-m.done
+	})
+}
+
+// evaluateReplica decides whether a replica with the given lag may serve reads
+func evaluateReplica(lag time.Duration, err error, maxLag time.Duration) ReplicaStatus {
+	status := ReplicaStatus{Configured: true, MaxLag: maxLag}
+
+	switch {
+	case err != nil:
+		status.Error = fmt.Sprintf("replica check failed: %v", err)
+	case lag > maxLag:
+		status.Lag = lag
+		status.Error = fmt.Sprintf("replica lag %s exceeds maximum %s", lag.Round(time.Millisecond), maxLag)
+	default:
+		status.Lag = lag
+		status.Healthy = true
+	}
+
+	return status
+}
+
+// ReadPool returns the pool read-only queries should use. It is the replica when
+// one is configured and was within the allowed lag at its last check, and the
+// primary otherwise. Anything that writes, or must read its own writes, should
+// keep using Pool.
+func (db *DB) ReadPool() *pgxpool.Pool {
+	if db.replica != nil && db.replica.current().Healthy {
+		return db.Replica
+	}
+	return db.Pool
+}
+
+// HasReplica reports whether a read replica is configured
+func (db *DB) HasReplica() bool {
+	return db.replica != nil
+}
+
+// ReplicaStatus returns the replica state from the most recent lag check
+func (db *DB) ReplicaStatus() ReplicaStatus {
+	if db.replica == nil {
+		return ReplicaStatus{}
+	}
+	return db.replica.current()
+}
+
+// ReplicaHealthCheck checks the replica's connectivity and lag immediately and
+// updates read routing with the result. It returns nil when no replica is configured.
+func (db *DB) ReplicaHealthCheck(ctx context.Context) error {
+	if db.replica == nil {
+		return nil
+	}
+
+	status := db.replica.check(ctx)
+	if !status.Healthy {
+		return fmt.Errorf("%s", status.Error)
+	}
+	return nil
+}
+
+// ReplicaStats returns replica connection pool statistics, or nil without a replica
+func (db *DB) ReplicaStats() *pgxpool.Stat {
+	if db.Replica == nil {
+		return nil
+	}
+	return db.Replica.Stat()
+}
@@ -0,0 +1,86 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func TestEvaluateReplica(t *testing.T) {
+	tests := []struct {
+		name    string
+		lag     time.Duration
+		err     error
+		healthy bool
+		error   string
+	}{
+		{name: "caught up", lag: 0, healthy: true},
+		{name: "lag at maximum", lag: 10 * time.Second, healthy: true},
+		{name: "lag above maximum", lag: 11 * time.Second, error: "exceeds maximum 10s"},
+		{name: "unreachable", err: errors.New("connection refused"), error: "connection refused"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := evaluateReplica(tt.lag, tt.err, 10*time.Second)
+			if status.Healthy != tt.healthy {
+				t.Errorf("Expected healthy %v, got %v", tt.healthy, status.Healthy)
+			}
+			if !status.Configured {
+				t.Error("Expected replica to be reported as configured")
+			}
+			if !strings.Contains(status.Error, tt.error) {
+				t.Errorf("Expected error containing %q, got %q", tt.error, status.Error)
+			}
+		})
+	}
+}
+
+func TestReadPool(t *testing.T) {
+	// Pools with no minimum connections never dial until used
+	primary, err := pgxpool.New(context.Background(), "host=127.0.0.1 port=1 user=test dbname=primary")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	replica, err := pgxpool.New(context.Background(), "host=127.0.0.1 port=1 user=test dbname=replica")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	db := &DB{Pool: primary}
+	defer db.Close()
+
+	if db.ReadPool() != primary || db.HasReplica() || db.ReplicaStatus().Configured {
+		t.Fatal("Expected reads to use the primary without a replica")
+	}
+
+	db.Replica = replica
+	db.replica = newReplicaMonitor(replica, 10*time.Second, time.Hour)
+	go db.replica.run()
+
+	if db.ReadPool() != primary {
+		t.Error("Expected reads to use the primary before the replica is checked")
+	}
+
+	db.replica.status = evaluateReplica(2*time.Second, nil, 10*time.Second)
+	if db.ReadPool() != replica {
+		t.Error("Expected reads to use a healthy replica")
+	}
+
+	db.replica.status = evaluateReplica(time.Minute, nil, 10*time.Second)
+	if db.ReadPool() != primary {
+		t.Error("Expected reads to fall back to the primary when the replica lags")
+	}
+
+	// The replica is unreachable, so an explicit check marks it unhealthy
+	if err := db.ReplicaHealthCheck(context.Background()); err == nil {
+		t.Error("Expected replica health check to fail")
+	}
+	if db.ReadPool() != primary || db.ReplicaStatus().CheckedAt.IsZero() {
+		t.Error("Expected the failed check to be recorded and reads to use the primary")
+	}
+}
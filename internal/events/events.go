@@ -0,0 +1,95 @@
+// Package events carries domain events from the banking API to the services
+// that react to them, such as the admin dashboard's live feeds and counters.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Domain event types
+const (
+	TypeTransferCompleted = "transfer.completed"
+	TypeTransferFailed    = "transfer.failed"
+	TypeAccountCreated    = "account.created"
+	TypeAccountDeleted    = "account.deleted"
+	TypeUserRegistered    = "user.registered"
+)
+
+// Event is a domain event. Payload holds one of the payload types below,
+// chosen by Type.
+type Event struct {
+	// ID is assigned by the bus when the event is published
+	ID         string          `json:"id,omitempty"`
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+// TransferPayload describes a completed or failed transfer
+type TransferPayload struct {
+	TransferID    int32  `json:"transfer_id,omitempty"`
+	FromAccountID int32  `json:"from_account_id"`
+	ToAccountID   int32  `json:"to_account_id"`
+	UserID        int32  `json:"user_id,omitempty"`
+	Amount        string `json:"amount"`
+	Fee           string `json:"fee,omitempty"`
+	Currency      string `json:"currency,omitempty"`
+	BatchID       int32  `json:"batch_id,omitempty"`
+	// Reason says why a transfer failed, e.g. "transfer_blocked" or "limit_exceeded"
+	Reason string `json:"reason,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// AccountPayload describes a created or deleted account
+type AccountPayload struct {
+	AccountID int32  `json:"account_id"`
+	UserID    int32  `json:"user_id"`
+	Currency  string `json:"currency"`
+}
+
+// UserPayload describes a registered user
+type UserPayload struct {
+	UserID int32 `json:"user_id"`
+}
+
+// New creates an event of the given type occurring now
+func New(eventType string, payload interface{}) (Event, error) {
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return Event{}, fmt.Errorf("failed to encode %s event: %w", eventType, err)
+	}
+	return Event{Type: eventType, OccurredAt: time.Now().UTC(), Payload: encoded}, nil
+}
+
+// Decode decodes the event payload into v
+func (e Event) Decode(v interface{}) error {
+	if err := json.Unmarshal(e.Payload, v); err != nil {
+		return fmt.Errorf("failed to decode %s event: %w", e.Type, err)
+	}
+	return nil
+}
+
+// Publisher publishes domain events
+type Publisher interface {
+	Publish(ctx context.Context, event Event) error
+}
+
+// Handler processes one event; an error leaves the event to be retried
+type Handler func(ctx context.Context, event Event) error
+
+// Subscriber delivers published events to consumer groups
+type Subscriber interface {
+	// Consume delivers events to handler until ctx is done. Every group sees
+	// every event, and consumers sharing a group split the events between
+	// them, so each event is handled once per group.
+	Consume(ctx context.Context, group string, handler Handler) error
+}
+
+// Bus publishes and delivers domain events
+type Bus interface {
+	Publisher
+	Subscriber
+}
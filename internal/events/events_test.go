@@ -0,0 +1,138 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvent_NewAndDecode(t *testing.T) {
+	event, err := New(TypeAccountCreated, AccountPayload{AccountID: 7, UserID: 3, Currency: "EUR"})
+	require.NoError(t, err)
+	assert.Equal(t, TypeAccountCreated, event.Type)
+	assert.False(t, event.OccurredAt.IsZero())
+
+	var payload AccountPayload
+	require.NoError(t, event.Decode(&payload))
+	assert.Equal(t, AccountPayload{AccountID: 7, UserID: 3, Currency: "EUR"}, payload)
+}
+
+func TestMemoryBus_DeliversOncePerGroup(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	bus := NewMemoryBus()
+
+	var mu sync.Mutex
+	received := map[string]int{}
+	record := func(name string) Handler {
+		return func(ctx context.Context, event Event) error {
+			mu.Lock()
+			defer mu.Unlock()
+			received[name]++
+			return nil
+		}
+	}
+
+	require.NoError(t, bus.Consume(ctx, "dashboard", record("dashboard-1")))
+	require.NoError(t, bus.Consume(ctx, "dashboard", record("dashboard-2")))
+	require.NoError(t, bus.Consume(ctx, "audit", record("audit")))
+
+	for i := 0; i < 4; i++ {
+		event, err := New(TypeUserRegistered, UserPayload{UserID: int32(i)})
+		require.NoError(t, err)
+		require.NoError(t, bus.Publish(ctx, event))
+	}
+
+	// Consumers of a group split its events; every group sees all of them
+	assert.Equal(t, 2, received["dashboard-1"])
+	assert.Equal(t, 2, received["dashboard-2"])
+	assert.Equal(t, 4, received["audit"])
+}
+
+func TestMemoryBus_HandlerErrorDoesNotFailPublish(t *testing.T) {
+	ctx := context.Background()
+	bus := NewMemoryBus()
+	require.NoError(t, bus.Consume(ctx, "dashboard", func(ctx context.Context, event Event) error {
+		return errors.New("handler failed")
+	}))
+
+	event, err := New(TypeUserRegistered, UserPayload{UserID: 1})
+	require.NoError(t, err)
+	assert.NoError(t, bus.Publish(ctx, event))
+}
+
+func TestMemoryBus_StopsDeliveringWhenContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	bus := NewMemoryBus()
+
+	var mu sync.Mutex
+	count := 0
+	require.NoError(t, bus.Consume(ctx, "dashboard", func(ctx context.Context, event Event) error {
+		mu.Lock()
+		defer mu.Unlock()
+		count++
+		return nil
+	}))
+	cancel()
+
+	event, err := New(TypeUserRegistered, UserPayload{UserID: 1})
+	require.NoError(t, err)
+	delivered := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return count
+	}
+
+	// The consumer is removed asynchronously once ctx is done
+	assert.Eventually(t, func() bool {
+		before := delivered()
+		require.NoError(t, bus.Publish(context.Background(), event))
+		return delivered() == before
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestRedisBus_ConsumerGroup(t *testing.T) {
+	addr := os.Getenv("REDIS_ADDR")
+	if addr == "" {
+		addr = "localhost:6379"
+	}
+	client := redis.NewClient(&redis.Options{Addr: addr, DB: 15})
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		t.Skip("Redis not available for testing")
+	}
+	client.Del(ctx, StreamKey)
+	defer client.Del(context.Background(), StreamKey)
+
+	bus := NewRedisBus(client)
+	received := make(chan Event, 1)
+	require.NoError(t, bus.Consume(ctx, "test-group", func(ctx context.Context, event Event) error {
+		received <- event
+		return nil
+	}))
+
+	event, err := New(TypeTransferCompleted, TransferPayload{TransferID: 9, Amount: "10.00", Currency: "USD"})
+	require.NoError(t, err)
+	require.NoError(t, bus.Publish(ctx, event))
+
+	select {
+	case got := <-received:
+		assert.Equal(t, TypeTransferCompleted, got.Type)
+		assert.NotEmpty(t, got.ID)
+		var payload TransferPayload
+		require.NoError(t, got.Decode(&payload))
+		assert.Equal(t, int32(9), payload.TransferID)
+	case <-ctx.Done():
+		t.Fatal("event was not delivered")
+	}
+}
@@ -0,0 +1,90 @@
+package events
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+// memoryBus delivers events within one process, synchronously on Publish. It
+// is used in tests and when Redis is unavailable.
+type memoryBus struct {
+	mu       sync.Mutex
+	sequence int64
+	groups   map[string]*memoryGroup
+	// nextConsumer numbers consumers across all groups
+	nextConsumer int
+}
+
+// memoryGroup round-robins events between the consumers of a group
+type memoryGroup struct {
+	consumers map[int]Handler
+	order     []int
+	next      int
+}
+
+// NewMemoryBus creates an in-process event bus
+func NewMemoryBus() Bus {
+	return &memoryBus{groups: make(map[string]*memoryGroup)}
+}
+
+// Publish delivers the event to one consumer of every group. Handler errors
+// are logged; the in-process bus does not retry.
+func (b *memoryBus) Publish(ctx context.Context, event Event) error {
+	b.mu.Lock()
+	b.sequence++
+	event.ID = strconv.FormatInt(b.sequence, 10)
+
+	var handlers []Handler
+	for _, group := range b.groups {
+		if len(group.order) == 0 {
+			continue
+		}
+		id := group.order[group.next%len(group.order)]
+		group.next++
+		handlers = append(handlers, group.consumers[id])
+	}
+	b.mu.Unlock()
+
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			log.Warn().
+				Err(err).
+				Str("event_id", event.ID).
+				Str("event_type", event.Type).
+				Msg("Event handler failed")
+		}
+	}
+	return nil
+}
+
+// Consume registers handler in group for the lifetime of ctx
+func (b *memoryBus) Consume(ctx context.Context, group string, handler Handler) error {
+	b.mu.Lock()
+	g, ok := b.groups[group]
+	if !ok {
+		g = &memoryGroup{consumers: make(map[int]Handler)}
+		b.groups[group] = g
+	}
+	id := b.nextConsumer
+	b.nextConsumer++
+	g.consumers[id] = handler
+	g.order = append(g.order, id)
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(g.consumers, id)
+		for i, consumer := range g.order {
+			if consumer == id {
+				g.order = append(g.order[:i:i], g.order[i+1:]...)
+				break
+			}
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,182 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+)
+
+// Stream settings of the Redis event bus
+const (
+	// StreamKey is the Redis stream holding published events
+	StreamKey = "bank:events"
+	// streamMaxLen caps the stream; it only needs to outlast consumer downtime
+	streamMaxLen = 100000
+	// claimIdle is how long an event may stay unacknowledged, because its
+	// consumer died or its handler failed, before another attempt is made
+	claimIdle = time.Minute
+	// readBlock bounds each blocking read so ctx cancellation is noticed
+	readBlock = 5 * time.Second
+)
+
+// redisBus is an event bus on a Redis stream. Consumer groups track what each
+// group has processed, so events published while a consumer is down are
+// delivered when it comes back.
+type redisBus struct {
+	client   *redis.Client
+	consumer string
+}
+
+// NewRedisBus creates an event bus on Redis
+func NewRedisBus(client *redis.Client) Bus {
+	hostname, _ := os.Hostname()
+	return &redisBus{
+		client:   client,
+		consumer: fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+	}
+}
+
+// Publish appends the event to the stream
+func (b *redisBus) Publish(ctx context.Context, event Event) error {
+	encoded, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	err = b.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: StreamKey,
+		MaxLen: streamMaxLen,
+		Approx: true,
+		Values: map[string]interface{}{"event": encoded},
+	}).Err()
+	if err != nil {
+		return fmt.Errorf("failed to publish %s event: %w", event.Type, err)
+	}
+	return nil
+}
+
+// Consume creates the group if needed, starting at new events, and then
+// processes events in the background until ctx is done
+func (b *redisBus) Consume(ctx context.Context, group string, handler Handler) error {
+	err := b.client.XGroupCreateMkStream(ctx, StreamKey, group, "$").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return fmt.Errorf("failed to create consumer group %s: %w", group, err)
+	}
+
+	go b.consume(ctx, group, handler)
+	return nil
+}
+
+// consume reads new events for the group and periodically reclaims events
+// left unacknowledged by failed handlers or dead consumers
+func (b *redisBus) consume(ctx context.Context, group string, handler Handler) {
+	lastClaim := time.Time{}
+	for ctx.Err() == nil {
+		if time.Since(lastClaim) >= claimIdle {
+			b.reclaim(ctx, group, handler)
+			lastClaim = time.Now()
+		}
+
+		streams, err := b.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    group,
+			Consumer: b.consumer,
+			Streams:  []string{StreamKey, ">"},
+			Count:    100,
+			Block:    readBlock,
+		}).Result()
+		if errors.Is(err, redis.Nil) || ctx.Err() != nil {
+			continue
+		}
+		if err != nil {
+			log.Warn().Err(err).Str("group", group).Msg("Failed to read events, retrying")
+			sleep(ctx, time.Second)
+			continue
+		}
+
+		for _, stream := range streams {
+			b.handle(ctx, group, handler, stream.Messages)
+		}
+	}
+}
+
+// reclaim takes over events that have been pending longer than claimIdle
+func (b *redisBus) reclaim(ctx context.Context, group string, handler Handler) {
+	start := "0-0"
+	for {
+		messages, next, err := b.client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+			Stream:   StreamKey,
+			Group:    group,
+			Consumer: b.consumer,
+			MinIdle:  claimIdle,
+			Start:    start,
+			Count:    100,
+		}).Result()
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Warn().Err(err).Str("group", group).Msg("Failed to reclaim pending events")
+			}
+			return
+		}
+
+		b.handle(ctx, group, handler, messages)
+		if next == "0-0" || next == "" {
+			return
+		}
+		start = next
+	}
+}
+
+// handle runs the handler on each message and acknowledges the ones it
+// processed. Undecodable messages are acknowledged so they are not retried.
+func (b *redisBus) handle(ctx context.Context, group string, handler Handler, messages []redis.XMessage) {
+	for _, message := range messages {
+		event, err := decodeMessage(message)
+		if err != nil {
+			log.Error().Err(err).Str("message_id", message.ID).Msg("Dropped undecodable event")
+		} else if err := handler(ctx, event); err != nil {
+			log.Warn().
+				Err(err).
+				Str("group", group).
+				Str("event_id", event.ID).
+				Str("event_type", event.Type).
+				Msg("Event handler failed, will retry")
+			continue
+		}
+
+		if err := b.client.XAck(ctx, StreamKey, group, message.ID).Err(); err != nil {
+			log.Warn().Err(err).Str("message_id", message.ID).Msg("Failed to acknowledge event")
+		}
+	}
+}
+
+// decodeMessage decodes a stream message into an event identified by the message ID
+func decodeMessage(message redis.XMessage) (Event, error) {
+	encoded, ok := message.Values["event"].(string)
+	if !ok {
+		return Event{}, fmt.Errorf("event message has no event field")
+	}
+
+	var event Event
+	if err := json.Unmarshal([]byte(encoded), &event); err != nil {
+		return Event{}, fmt.Errorf("failed to decode event: %w", err)
+	}
+	event.ID = message.ID
+	return event, nil
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
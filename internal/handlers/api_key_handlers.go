@@ -0,0 +1,358 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/phantom-sage/bankgo/internal/services"
+	"github.com/phantom-sage/bankgo/pkg/auth"
+)
+
+// CreateAPIKeyRequest represents the request body for creating an API key
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" binding:"required"`
+	Scopes    []string   `json:"scopes" binding:"required"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// ScopedTokenRequest represents the request body for a reduced-scope token.
+// ExpiresIn is in seconds; zero asks for the default of one hour.
+type ScopedTokenRequest struct {
+	Scopes    []string `json:"scopes" binding:"required"`
+	ExpiresIn int      `json:"expires_in"`
+}
+
+// ScopedTokenResponse represents the response for a reduced-scope token
+type ScopedTokenResponse struct {
+	Token     string    `json:"token"`
+	Scopes    []string  `json:"scopes"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// defaultScopedTokenExpiration is how long reduced-scope tokens last when the
+// request does not say
+const defaultScopedTokenExpiration = time.Hour
+
+// OAuthTokenResponse is a successful OAuth2 token response (RFC 6749 section 5.1)
+type OAuthTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+	Scope       string `json:"scope"`
+}
+
+// OAuthErrorResponse is an OAuth2 error response (RFC 6749 section 5.2). The
+// token endpoint answers in this format rather than ErrorResponse so standard
+// OAuth2 clients understand it.
+type OAuthErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+// IssueClientToken handles the OAuth2 client-credentials grant. The client ID
+// and secret are the two halves of an API key, sent with HTTP Basic
+// authentication or in the form body; the token gets the requested scopes,
+// or all of the key's scopes when none are requested.
+// POST /oauth/token
+func (h *AuthHandlers) IssueClientToken(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	c.Header("Pragma", "no-cache")
+
+	if h.apiKeyService == nil {
+		c.JSON(http.StatusBadRequest, OAuthErrorResponse{
+			Error:            "unsupported_grant_type",
+			ErrorDescription: "client credentials are not enabled",
+		})
+		return
+	}
+
+	if grantType := c.PostForm("grant_type"); grantType != "client_credentials" {
+		c.JSON(http.StatusBadRequest, OAuthErrorResponse{
+			Error:            "unsupported_grant_type",
+			ErrorDescription: "only the client_credentials grant is supported",
+		})
+		return
+	}
+
+	clientID, clientSecret, basicAuth := c.Request.BasicAuth()
+	if !basicAuth {
+		clientID, clientSecret = c.PostForm("client_id"), c.PostForm("client_secret")
+	}
+
+	principal, err := h.apiKeyService.AuthenticateClient(c.Request.Context(), clientID, clientSecret)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidAPIKey) {
+			if basicAuth {
+				c.Header("WWW-Authenticate", `Basic realm="oauth"`)
+			}
+			c.JSON(http.StatusUnauthorized, OAuthErrorResponse{
+				Error:            "invalid_client",
+				ErrorDescription: "client authentication failed",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, OAuthErrorResponse{
+			Error:            "server_error",
+			ErrorDescription: "failed to authenticate client",
+		})
+		return
+	}
+
+	scopes := principal.Scopes
+	if requested := auth.UniqueScopes(strings.Fields(c.PostForm("scope"))); len(requested) > 0 {
+		for _, scope := range requested {
+			if !containsScope(principal.Scopes, scope) {
+				c.JSON(http.StatusBadRequest, OAuthErrorResponse{
+					Error:            "invalid_scope",
+					ErrorDescription: "scope " + scope + " is not granted to this client",
+				})
+				return
+			}
+		}
+		scopes = requested
+	}
+
+	token, err := h.tokenManager.GenerateClientToken(int(principal.UserID), principal.Email, principal.ClientID, scopes, h.clientTokenExpiration)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, OAuthErrorResponse{
+			Error:            "server_error",
+			ErrorDescription: "failed to generate access token",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, OAuthTokenResponse{
+		AccessToken: token,
+		TokenType:   "Bearer",
+		ExpiresIn:   int(h.clientTokenExpiration.Seconds()),
+		Scope:       strings.Join(scopes, " "),
+	})
+}
+
+// IssueScopedToken handles issuing a token with fewer scopes than the
+// caller's login token, to hand to a read-only integration or use for a
+// statement download. It cannot outlive a login token.
+// POST /auth/tokens
+func (h *AuthHandlers) IssueScopedToken(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "User not authenticated",
+			Code:    http.StatusUnauthorized,
+		})
+		return
+	}
+
+	var req ScopedTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "validation_error",
+			Message: "Invalid request data",
+			Code:    http.StatusBadRequest,
+			Details: map[string]string{"validation": err.Error()},
+		})
+		return
+	}
+
+	if len(req.Scopes) == 0 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_scope",
+			Message: "At least one scope is required",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+	for _, scope := range req.Scopes {
+		if !auth.IsScope(scope) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid_scope",
+				Message: "Unsupported scope " + scope,
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+	}
+
+	maxExpiration := h.tokenManager.GetTokenExpiration()
+	expiration := defaultScopedTokenExpiration
+	if req.ExpiresIn != 0 {
+		expiration = time.Duration(req.ExpiresIn) * time.Second
+	}
+	if expiration <= 0 || expiration > maxExpiration {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_expiration",
+			Message: "expires_in must be between 1 and " + strconv.Itoa(int(maxExpiration.Seconds())) + " seconds",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	email, _ := c.Get("user_email")
+	emailStr, _ := email.(string)
+	scopes := auth.UniqueScopes(req.Scopes)
+	token, err := h.tokenManager.GenerateScopedToken(userID, emailStr, scopes, expiration)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "token_error",
+			Message: "Failed to generate scoped token",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, ScopedTokenResponse{
+		Token:     token,
+		Scopes:    scopes,
+		ExpiresAt: time.Now().Add(expiration),
+	})
+}
+
+// APIKeyHandlers handles API key management HTTP requests
+type APIKeyHandlers struct {
+	apiKeyService services.APIKeyService
+}
+
+// NewAPIKeyHandlers creates a new API key handlers instance
+func NewAPIKeyHandlers(apiKeyService services.APIKeyService) *APIKeyHandlers {
+	return &APIKeyHandlers{
+		apiKeyService: apiKeyService,
+	}
+}
+
+// CreateAPIKey handles creating a key. The response holds the key itself,
+// which is not shown again.
+// POST /api-keys
+func (h *APIKeyHandlers) CreateAPIKey(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "User not authenticated",
+			Code:    http.StatusUnauthorized,
+		})
+		return
+	}
+
+	var req CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "validation_error",
+			Message: "Invalid request data",
+			Code:    http.StatusBadRequest,
+			Details: map[string]string{"validation": err.Error()},
+		})
+		return
+	}
+
+	key, err := h.apiKeyService.CreateAPIKey(c.Request.Context(), int32(userID), services.CreateAPIKeyRequest{
+		Name:      req.Name,
+		Scopes:    req.Scopes,
+		ExpiresAt: req.ExpiresAt,
+	})
+	if err != nil {
+		var validationErr *services.APIKeyValidationError
+		if errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid_api_key_request",
+				Message: validationErr.Message,
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to create API key",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, key)
+}
+
+// GetAPIKeys handles listing the user's keys
+// GET /api-keys
+func (h *APIKeyHandlers) GetAPIKeys(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "User not authenticated",
+			Code:    http.StatusUnauthorized,
+		})
+		return
+	}
+
+	keys, err := h.apiKeyService.ListAPIKeys(c.Request.Context(), int32(userID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to retrieve API keys",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, keys)
+}
+
+// RevokeAPIKey handles revoking a key
+// DELETE /api-keys/:id
+func (h *APIKeyHandlers) RevokeAPIKey(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "User not authenticated",
+			Code:    http.StatusUnauthorized,
+		})
+		return
+	}
+
+	keyID, err := ParseIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_api_key_id",
+			Message: "Invalid API key ID",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	if err := h.apiKeyService.RevokeAPIKey(c.Request.Context(), int32(userID), int32(keyID)); err != nil {
+		if errors.Is(err, services.ErrAPIKeyNotFound) {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "api_key_not_found",
+				Message: "API key not found",
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to revoke API key",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// containsScope reports whether scopes include scope
+func containsScope(scopes []string, scope string) bool {
+	for _, granted := range scopes {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
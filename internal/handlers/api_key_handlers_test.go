@@ -0,0 +1,461 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/phantom-sage/bankgo/internal/middleware"
+	"github.com/phantom-sage/bankgo/internal/services"
+	"github.com/phantom-sage/bankgo/pkg/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Mock API key service for testing
+type MockAPIKeyService struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyService) CreateAPIKey(ctx context.Context, userID int32, req services.CreateAPIKeyRequest) (*services.APIKey, error) {
+	args := m.Called(ctx, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyService) ListAPIKeys(ctx context.Context, userID int32) ([]services.APIKey, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]services.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyService) RevokeAPIKey(ctx context.Context, userID, keyID int32) error {
+	args := m.Called(ctx, userID, keyID)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyService) AuthenticateAPIKey(ctx context.Context, key string) (*services.APIKeyPrincipal, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.APIKeyPrincipal), args.Error(1)
+}
+
+func (m *MockAPIKeyService) AuthenticateClient(ctx context.Context, clientID, clientSecret string) (*services.APIKeyPrincipal, error) {
+	args := m.Called(ctx, clientID, clientSecret)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.APIKeyPrincipal), args.Error(1)
+}
+
+// Test setup helper for API key authentication
+func setupAPIKeyAuthTest() (*AuthHandlers, *MockAPIKeyService, *auth.PASETOManager) {
+	gin.SetMode(gin.TestMode)
+
+	mockAPIKeyService := &MockAPIKeyService{}
+	tokenManager, _ := auth.NewPASETOManager("test-secret-key-that-is-32-chars", time.Hour)
+	handlers := NewAuthHandlers(&MockUserService{}, tokenManager, WithAPIKeys(mockAPIKeyService, 15*time.Minute))
+
+	return handlers, mockAPIKeyService, tokenManager
+}
+
+func TestAuthHandlers_AuthMiddleware_APIKeys(t *testing.T) {
+	key, err := auth.GenerateAPIKey()
+	require.NoError(t, err)
+	readOnly := &services.APIKeyPrincipal{KeyID: 3, UserID: 1, Email: "test@example.com", ClientID: key.Prefix, Scopes: []string{auth.ScopeAccountsRead, auth.ScopeTransfersRead}}
+
+	tests := []struct {
+		name           string
+		method         string
+		headers        map[string]string
+		mockSetup      func(*MockAPIKeyService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:    "API key as bearer token",
+			method:  http.MethodGet,
+			headers: map[string]string{"Authorization": "Bearer " + key.Key},
+			mockSetup: func(m *MockAPIKeyService) {
+				m.On("AuthenticateAPIKey", mock.Anything, key.Key).Return(readOnly, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:    "API key in X-API-Key header",
+			method:  http.MethodGet,
+			headers: map[string]string{"X-API-Key": key.Key},
+			mockSetup: func(m *MockAPIKeyService) {
+				m.On("AuthenticateAPIKey", mock.Anything, key.Key).Return(readOnly, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:    "read-only key cannot transfer",
+			method:  http.MethodPost,
+			headers: map[string]string{"X-API-Key": key.Key},
+			mockSetup: func(m *MockAPIKeyService) {
+				m.On("AuthenticateAPIKey", mock.Anything, key.Key).Return(readOnly, nil)
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedError:  "insufficient_scope",
+		},
+		{
+			name:    "revoked or unknown key",
+			method:  http.MethodGet,
+			headers: map[string]string{"Authorization": "Bearer " + key.Key},
+			mockSetup: func(m *MockAPIKeyService) {
+				m.On("AuthenticateAPIKey", mock.Anything, key.Key).Return(nil, services.ErrInvalidAPIKey)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  "invalid_api_key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlers, mockAPIKeyService, _ := setupAPIKeyAuthTest()
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockAPIKeyService)
+			}
+
+			router := gin.New()
+			router.Use(handlers.AuthMiddleware())
+			checkKey := func(c *gin.Context) {
+				userID, _ := GetUserIDFromContext(c)
+				claims, ok := middleware.GetTokenClaimsFromContext(c)
+				assert.Equal(t, 1, userID)
+				require.True(t, ok)
+				assert.False(t, claims.IsLoginSession())
+				assert.Equal(t, []string{auth.ScopeAccountsRead, auth.ScopeTransfersRead}, claims.Scopes)
+				c.Status(http.StatusOK)
+			}
+			router.GET("/transfers", middleware.RequireScope(auth.ScopeTransfersRead), checkKey)
+			router.POST("/transfers", middleware.RequireScope(auth.ScopeTransfersWrite), checkKey)
+
+			req := httptest.NewRequest(tt.method, "/transfers", nil)
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				var response ErrorResponse
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedError, response.Error)
+			}
+			mockAPIKeyService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthHandlers_AuthMiddleware_APIKeysDisabled(t *testing.T) {
+	handlers, _, _ := setupAuthHandlersTest()
+	key, err := auth.GenerateAPIKey()
+	require.NoError(t, err)
+
+	router := gin.New()
+	router.Use(handlers.AuthMiddleware())
+	router.GET("/protected", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	req.Header.Set("Authorization", "Bearer "+key.Key)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestAuthHandlers_IssueClientToken(t *testing.T) {
+	principal := &services.APIKeyPrincipal{
+		KeyID:    3,
+		UserID:   1,
+		Email:    "test@example.com",
+		ClientID: "bgk_0123456789ab",
+		Scopes:   auth.AllScopes,
+	}
+
+	tests := []struct {
+		name           string
+		form           url.Values
+		basicAuth      bool
+		mockSetup      func(*MockAPIKeyService)
+		expectedStatus int
+		expectedError  string
+		expectedScope  string
+	}{
+		{
+			name:      "credentials with HTTP Basic",
+			form:      url.Values{"grant_type": {"client_credentials"}},
+			basicAuth: true,
+			mockSetup: func(m *MockAPIKeyService) {
+				m.On("AuthenticateClient", mock.Anything, "bgk_0123456789ab", "secret").Return(principal, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedScope:  "accounts:read transfers:read transfers:write",
+		},
+		{
+			name: "reduced scope with form credentials",
+			form: url.Values{
+				"grant_type":    {"client_credentials"},
+				"client_id":     {"bgk_0123456789ab"},
+				"client_secret": {"secret"},
+				"scope":         {"transfers:read"},
+			},
+			mockSetup: func(m *MockAPIKeyService) {
+				m.On("AuthenticateClient", mock.Anything, "bgk_0123456789ab", "secret").Return(principal, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedScope:  "transfers:read",
+		},
+		{
+			name:      "duplicate scopes are dropped",
+			form:      url.Values{"grant_type": {"client_credentials"}, "scope": {"accounts:read transfers:read accounts:read"}},
+			basicAuth: true,
+			mockSetup: func(m *MockAPIKeyService) {
+				m.On("AuthenticateClient", mock.Anything, "bgk_0123456789ab", "secret").Return(principal, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedScope:  "accounts:read transfers:read",
+		},
+		{
+			name:      "scope not granted to the key",
+			form:      url.Values{"grant_type": {"client_credentials"}, "scope": {"accounts:read admin"}},
+			basicAuth: true,
+			mockSetup: func(m *MockAPIKeyService) {
+				m.On("AuthenticateClient", mock.Anything, "bgk_0123456789ab", "secret").Return(principal, nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid_scope",
+		},
+		{
+			name:      "invalid client",
+			form:      url.Values{"grant_type": {"client_credentials"}},
+			basicAuth: true,
+			mockSetup: func(m *MockAPIKeyService) {
+				m.On("AuthenticateClient", mock.Anything, "bgk_0123456789ab", "secret").Return(nil, services.ErrInvalidAPIKey)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  "invalid_client",
+		},
+		{
+			name:           "unsupported grant",
+			form:           url.Values{"grant_type": {"password"}},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "unsupported_grant_type",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlers, mockAPIKeyService, tokenManager := setupAPIKeyAuthTest()
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockAPIKeyService)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/oauth/token", strings.NewReader(tt.form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			if tt.basicAuth {
+				req.SetBasicAuth("bgk_0123456789ab", "secret")
+			}
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+
+			handlers.IssueClientToken(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+			if tt.expectedError != "" {
+				var response OAuthErrorResponse
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedError, response.Error)
+			} else {
+				var response OAuthTokenResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "Bearer", response.TokenType)
+				assert.Equal(t, 900, response.ExpiresIn)
+				assert.Equal(t, tt.expectedScope, response.Scope)
+
+				claims, err := tokenManager.ValidateToken(response.AccessToken)
+				require.NoError(t, err)
+				assert.Equal(t, 1, claims.UserID)
+				assert.Equal(t, "bgk_0123456789ab", claims.ClientID)
+				assert.Equal(t, strings.Fields(tt.expectedScope), claims.Scopes)
+			}
+			mockAPIKeyService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAPIKeyHandlers_CreateAPIKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("created with the key shown once", func(t *testing.T) {
+		mockAPIKeyService := &MockAPIKeyService{}
+		handlers := NewAPIKeyHandlers(mockAPIKeyService)
+		mockAPIKeyService.On("CreateAPIKey", mock.Anything, int32(1), services.CreateAPIKeyRequest{
+			Name:   "reporting",
+			Scopes: []string{auth.ScopeAccountsRead, auth.ScopeTransfersRead},
+		}).Return(&services.APIKey{ID: 3, Name: "reporting", Prefix: "bgk_0123456789ab", Key: "bgk_0123456789ab_secret"}, nil)
+
+		c, w := createAuthenticatedContext(1)
+		c.Request = httptest.NewRequest(http.MethodPost, "/api-keys", bytes.NewBufferString(`{"name":"reporting","scopes":["accounts:read","transfers:read"]}`))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		handlers.CreateAPIKey(c)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var response services.APIKey
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "bgk_0123456789ab_secret", response.Key)
+		mockAPIKeyService.AssertExpectations(t)
+	})
+
+	t.Run("rejected by service", func(t *testing.T) {
+		mockAPIKeyService := &MockAPIKeyService{}
+		handlers := NewAPIKeyHandlers(mockAPIKeyService)
+		mockAPIKeyService.On("CreateAPIKey", mock.Anything, int32(1), mock.Anything).
+			Return(nil, &services.APIKeyValidationError{Message: `unsupported scope "admin"`})
+
+		c, w := createAuthenticatedContext(1)
+		c.Request = httptest.NewRequest(http.MethodPost, "/api-keys", bytes.NewBufferString(`{"name":"reporting","scopes":["admin"]}`))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		handlers.CreateAPIKey(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("keys cannot create keys", func(t *testing.T) {
+		authHandlers, mockAPIKeyService, _ := setupAPIKeyAuthTest()
+		handlers := NewAPIKeyHandlers(mockAPIKeyService)
+		key, err := auth.GenerateAPIKey()
+		require.NoError(t, err)
+		mockAPIKeyService.On("AuthenticateAPIKey", mock.Anything, key.Key).Return(&services.APIKeyPrincipal{
+			KeyID: 3, UserID: 1, Email: "test@example.com", ClientID: key.Prefix, Scopes: auth.AllScopes,
+		}, nil)
+
+		router := gin.New()
+		router.Use(authHandlers.AuthMiddleware())
+		router.POST("/api-keys", middleware.RequireLoginSession(), handlers.CreateAPIKey)
+
+		req := httptest.NewRequest(http.MethodPost, "/api-keys", bytes.NewBufferString(`{"name":"escalate","scopes":["transfers:write"]}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-API-Key", key.Key)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "login_required")
+		mockAPIKeyService.AssertNotCalled(t, "CreateAPIKey", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestAPIKeyHandlers_RevokeAPIKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockAPIKeyService := &MockAPIKeyService{}
+	handlers := NewAPIKeyHandlers(mockAPIKeyService)
+	mockAPIKeyService.On("RevokeAPIKey", mock.Anything, int32(1), int32(3)).Return(nil)
+	mockAPIKeyService.On("RevokeAPIKey", mock.Anything, int32(1), int32(4)).Return(services.ErrAPIKeyNotFound)
+
+	for id, expected := range map[string]int{"3": http.StatusNoContent, "4": http.StatusNotFound, "x": http.StatusBadRequest} {
+		c, w := createAuthenticatedContext(1)
+		c.Request = httptest.NewRequest(http.MethodDelete, "/api-keys/"+id, nil)
+		c.Params = gin.Params{{Key: "id", Value: id}}
+
+		handlers.RevokeAPIKey(c)
+		c.Writer.WriteHeaderNow()
+
+		assert.Equal(t, expected, w.Code, id)
+	}
+	mockAPIKeyService.AssertExpectations(t)
+}
+
+func TestAuthHandlers_IssueScopedToken(t *testing.T) {
+	tests := []struct {
+		name           string
+		requestBody    string
+		expectedStatus int
+		expectedError  string
+		expectedScopes []string
+	}{
+		{
+			name:           "read-only token",
+			requestBody:    `{"scopes":["accounts:read","transfers:read","accounts:read"]}`,
+			expectedStatus: http.StatusCreated,
+			expectedScopes: []string{auth.ScopeAccountsRead, auth.ScopeTransfersRead},
+		},
+		{
+			name:           "statement download token",
+			requestBody:    `{"scopes":["accounts:read"],"expires_in":300}`,
+			expectedStatus: http.StatusCreated,
+			expectedScopes: []string{auth.ScopeAccountsRead},
+		},
+		{
+			name:           "unknown scope",
+			requestBody:    `{"scopes":["admin"]}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid_scope",
+		},
+		{
+			name:           "outlives a login token",
+			requestBody:    `{"scopes":["accounts:read"],"expires_in":7200}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid_expiration",
+		},
+		{
+			name:           "no scopes",
+			requestBody:    `{"scopes":[]}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid_scope",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlers, _, tokenManager := setupAPIKeyAuthTest()
+
+			c, w := createAuthenticatedContext(1)
+			c.Set("user_email", "test@example.com")
+			c.Request = httptest.NewRequest(http.MethodPost, "/auth/tokens", bytes.NewBufferString(tt.requestBody))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			handlers.IssueScopedToken(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				var response ErrorResponse
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedError, response.Error)
+				return
+			}
+
+			var response ScopedTokenResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedScopes, response.Scopes)
+
+			claims, err := tokenManager.ValidateToken(response.Token)
+			require.NoError(t, err)
+			assert.Equal(t, 1, claims.UserID)
+			assert.Equal(t, tt.expectedScopes, claims.Scopes)
+			assert.False(t, claims.IsLoginSession(), "reduced tokens cannot manage credentials")
+		})
+	}
+}
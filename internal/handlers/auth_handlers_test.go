@@ -10,11 +10,13 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/phantom-sage/bankgo/internal/lockout"
 	"github.com/phantom-sage/bankgo/internal/models"
-	"github.com/phantom-sage/bankgo/internal/queue"
+	"github.com/phantom-sage/bankgo/internal/services"
 	"github.com/phantom-sage/bankgo/pkg/auth"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // Mock services for testing
@@ -51,37 +53,27 @@ func (m *MockUserService) MarkWelcomeEmailSent(ctx context.Context, userID int)
 	return args.Error(0)
 }
 
-// QueueManagerInterface defines the interface used by auth handlers
-type QueueManagerInterface interface {
-	QueueWelcomeEmail(ctx context.Context, payload queue.WelcomeEmailPayload) error
-}
-
-type MockQueueManager struct {
-	mock.Mock
-}
-
-func (m *MockQueueManager) QueueWelcomeEmail(ctx context.Context, payload queue.WelcomeEmailPayload) error {
-	args := m.Called(ctx, payload)
+func (m *MockUserService) ScheduleWelcomeEmail(ctx context.Context, user *models.User) error {
+	args := m.Called(ctx, user)
 	return args.Error(0)
 }
 
 // Test setup helper
-func setupAuthHandlersTest() (*AuthHandlers, *MockUserService, *MockQueueManager, *auth.PASETOManager) {
+func setupAuthHandlersTest() (*AuthHandlers, *MockUserService, *auth.PASETOManager) {
 	gin.SetMode(gin.TestMode)
 	
 	mockUserService := &MockUserService{}
-	mockQueueManager := &MockQueueManager{}
 	
 	// Create PASETO manager for testing
 	tokenManager, _ := auth.NewPASETOManager("test-secret-key-that-is-32-chars", time.Hour)
 	
-	handlers := NewAuthHandlers(mockUserService, tokenManager, mockQueueManager)
+	handlers := NewAuthHandlers(mockUserService, tokenManager)
 	
-	return handlers, mockUserService, mockQueueManager, tokenManager
+	return handlers, mockUserService, tokenManager
 }
 
 func TestAuthHandlers_Register(t *testing.T) {
-	handlers, mockUserService, _, _ := setupAuthHandlersTest()
+	handlers, mockUserService, _ := setupAuthHandlersTest()
 
 	tests := []struct {
 		name           string
@@ -206,12 +198,12 @@ func TestAuthHandlers_Register(t *testing.T) {
 }
 
 func TestAuthHandlers_Login(t *testing.T) {
-	handlers, mockUserService, mockQueueManager, _ := setupAuthHandlersTest()
+	handlers, mockUserService, _ := setupAuthHandlersTest()
 
 	tests := []struct {
 		name           string
 		requestBody    LoginRequest
-		mockSetup      func(*MockUserService, *MockQueueManager)
+		mockSetup      func(*MockUserService)
 		expectedStatus int
 		expectedError  string
 	}{
@@ -221,7 +213,7 @@ func TestAuthHandlers_Login(t *testing.T) {
 				Email:    "test@example.com",
 				Password: "password123",
 			},
-			mockSetup: func(m *MockUserService, q *MockQueueManager) {
+			mockSetup: func(m *MockUserService) {
 				user := &models.User{
 					ID:               1,
 					Email:            "test@example.com",
@@ -232,7 +224,7 @@ func TestAuthHandlers_Login(t *testing.T) {
 					UpdatedAt:        time.Now(),
 				}
 				m.On("AuthenticateUser", mock.Anything, "test@example.com", "password123").Return(user, nil)
-				q.On("QueueWelcomeEmail", mock.Anything, mock.Anything).Return(nil)
+				m.On("ScheduleWelcomeEmail", mock.Anything, user).Return(nil)
 			},
 			expectedStatus: http.StatusOK,
 		},
@@ -242,7 +234,7 @@ func TestAuthHandlers_Login(t *testing.T) {
 				Email:    "returning@example.com",
 				Password: "password123",
 			},
-			mockSetup: func(m *MockUserService, q *MockQueueManager) {
+			mockSetup: func(m *MockUserService) {
 				user := &models.User{
 					ID:               2,
 					Email:            "returning@example.com",
@@ -253,7 +245,7 @@ func TestAuthHandlers_Login(t *testing.T) {
 					UpdatedAt:        time.Now(),
 				}
 				m.On("AuthenticateUser", mock.Anything, "returning@example.com", "password123").Return(user, nil)
-				// No queue call expected for returning users
+				// No welcome email scheduled for returning users
 			},
 			expectedStatus: http.StatusOK,
 		},
@@ -263,7 +255,7 @@ func TestAuthHandlers_Login(t *testing.T) {
 				Email:    "test@example.com",
 				Password: "wrongpassword",
 			},
-			mockSetup: func(m *MockUserService, q *MockQueueManager) {
+			mockSetup: func(m *MockUserService) {
 				m.On("AuthenticateUser", mock.Anything, "test@example.com", "wrongpassword").
 					Return(nil, assert.AnError)
 			},
@@ -295,11 +287,9 @@ func TestAuthHandlers_Login(t *testing.T) {
 			// Reset mocks
 			mockUserService.ExpectedCalls = nil
 			mockUserService.Calls = nil
-			mockQueueManager.ExpectedCalls = nil
-			mockQueueManager.Calls = nil
 
 			if tt.mockSetup != nil {
-				tt.mockSetup(mockUserService, mockQueueManager)
+				tt.mockSetup(mockUserService)
 			}
 
 			// Create request
@@ -334,13 +324,12 @@ func TestAuthHandlers_Login(t *testing.T) {
 			}
 
 			mockUserService.AssertExpectations(t)
-			mockQueueManager.AssertExpectations(t)
 		})
 	}
 }
 
 func TestAuthHandlers_Logout(t *testing.T) {
-	handlers, _, _, _ := setupAuthHandlersTest()
+	handlers, _, _ := setupAuthHandlersTest()
 
 	req := httptest.NewRequest(http.MethodPost, "/auth/logout", nil)
 	w := httptest.NewRecorder()
@@ -359,7 +348,7 @@ func TestAuthHandlers_Logout(t *testing.T) {
 }
 
 func TestAuthHandlers_AuthMiddleware(t *testing.T) {
-	handlers, _, _, tokenManager := setupAuthHandlersTest()
+	handlers, _, tokenManager := setupAuthHandlersTest()
 
 	tests := []struct {
 		name           string
@@ -548,4 +537,122 @@ func TestParseIDParam(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+// MockLoginGuard is a mock implementation of LoginGuard
+type MockLoginGuard struct {
+	mock.Mock
+}
+
+func (m *MockLoginGuard) Check(ctx context.Context, account, ipAddress string) error {
+	args := m.Called(ctx, account, ipAddress)
+	return args.Error(0)
+}
+
+func (m *MockLoginGuard) RecordFailure(ctx context.Context, account, ipAddress string) error {
+	args := m.Called(ctx, account, ipAddress)
+	return args.Error(0)
+}
+
+func (m *MockLoginGuard) RecordSuccess(ctx context.Context, account string) error {
+	args := m.Called(ctx, account)
+	return args.Error(0)
+}
+
+func TestAuthHandlers_LoginGuard(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tokenManager, _ := auth.NewPASETOManager("test-secret-key-that-is-32-chars", time.Hour)
+
+	login := func(handlers *AuthHandlers, password string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(LoginRequest{Email: "test@example.com", Password: password})
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/auth/login", bytes.NewBuffer(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Request.RemoteAddr = "203.0.113.7:1234"
+		handlers.Login(c)
+		return w
+	}
+
+	t.Run("wrong password is counted", func(t *testing.T) {
+		userService, guard := &MockUserService{}, &MockLoginGuard{}
+		guard.On("Check", mock.Anything, "test@example.com", "203.0.113.7").Return(nil)
+		userService.On("AuthenticateUser", mock.Anything, "test@example.com", "wrong").Return(nil, services.ErrInvalidCredentials)
+		guard.On("RecordFailure", mock.Anything, "test@example.com", "203.0.113.7").Return(nil)
+
+		w := login(NewAuthHandlers(userService, tokenManager, WithLoginGuard(guard)), "wrong")
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		guard.AssertExpectations(t)
+	})
+
+	t.Run("successful sign-in clears failures", func(t *testing.T) {
+		userService, guard := &MockUserService{}, &MockLoginGuard{}
+		user := &models.User{ID: 1, Email: "test@example.com", WelcomeEmailSent: true}
+		guard.On("Check", mock.Anything, "test@example.com", "203.0.113.7").Return(nil)
+		userService.On("AuthenticateUser", mock.Anything, "test@example.com", "password123").Return(user, nil)
+		guard.On("RecordSuccess", mock.Anything, "test@example.com").Return(nil)
+
+		w := login(NewAuthHandlers(userService, tokenManager, WithLoginGuard(guard)), "password123")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		guard.AssertExpectations(t)
+	})
+
+	t.Run("locked account is refused before the password is checked", func(t *testing.T) {
+		userService, guard := &MockUserService{}, &MockLoginGuard{}
+		guard.On("Check", mock.Anything, "test@example.com", "203.0.113.7").
+			Return(&lockout.LockedError{Reason: lockout.ReasonAccountLocked, Until: time.Now().Add(15 * time.Minute)})
+
+		w := login(NewAuthHandlers(userService, tokenManager, WithLoginGuard(guard)), "password123")
+
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "900", w.Header().Get("Retry-After"))
+		var response ErrorResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "account_locked", response.Error)
+		assert.NotEmpty(t, response.Details["retry_at"])
+		userService.AssertNotCalled(t, "AuthenticateUser", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("delayed sign-in", func(t *testing.T) {
+		userService, guard := &MockUserService{}, &MockLoginGuard{}
+		guard.On("Check", mock.Anything, "test@example.com", "203.0.113.7").
+			Return(&lockout.LockedError{Reason: lockout.ReasonDelay, Until: time.Now().Add(2 * time.Second)})
+
+		w := login(NewAuthHandlers(userService, tokenManager, WithLoginGuard(guard)), "password123")
+
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Contains(t, w.Body.String(), `"error":"login_throttled"`)
+	})
+
+	t.Run("spoofed X-Forwarded-For does not change the counted IP", func(t *testing.T) {
+		userService, guard := &MockUserService{}, &MockLoginGuard{}
+		userService.On("AuthenticateUser", mock.Anything, "test@example.com", "wrong").Return(nil, services.ErrInvalidCredentials)
+		// Every attempt counts against the connecting address, never the
+		// rotated header value or the victim address it names
+		guard.On("Check", mock.Anything, "test@example.com", "203.0.113.7").Return(nil)
+		guard.On("RecordFailure", mock.Anything, "test@example.com", "203.0.113.7").Return(nil)
+
+		// Trust no proxies, as SetupRouter does by default
+		router := gin.New()
+		require.NoError(t, router.SetTrustedProxies(nil))
+		router.POST("/auth/login", NewAuthHandlers(userService, tokenManager, WithLoginGuard(guard)).Login)
+
+		for _, spoofed := range []string{"198.51.100.1", "198.51.100.2", "192.0.2.99"} {
+			body, _ := json.Marshal(LoginRequest{Email: "test@example.com", Password: "wrong"})
+			req := httptest.NewRequest(http.MethodPost, "/auth/login", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Forwarded-For", spoofed)
+			req.Header.Set("X-Real-IP", spoofed)
+			req.RemoteAddr = "203.0.113.7:1234"
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+		}
+
+		guard.AssertNumberOfCalls(t, "Check", 3)
+		guard.AssertNumberOfCalls(t, "RecordFailure", 3)
+		guard.AssertNotCalled(t, "RecordFailure", mock.Anything, mock.Anything, "192.0.2.99")
+	})
+}
@@ -1,13 +1,17 @@
 package handlers
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/phantom-sage/bankgo/internal/lockout"
 	"github.com/phantom-sage/bankgo/internal/models"
-	"github.com/phantom-sage/bankgo/internal/queue"
 	"github.com/phantom-sage/bankgo/internal/services"
 	"github.com/phantom-sage/bankgo/pkg/auth"
 	"github.com/shopspring/decimal"
@@ -47,16 +51,57 @@ type ErrorResponse struct {
 type AuthHandlers struct {
 	userService   services.UserService
 	tokenManager  *auth.PASETOManager
-	queueManager  *queue.QueueManager
+	apiKeyService services.APIKeyService
+	// clientTokenExpiration is how long client-credentials tokens last
+	clientTokenExpiration time.Duration
+	loginGuard            LoginGuard
+	loginHistory          services.LoginHistoryService
+}
+
+// LoginGuard counts failed sign-ins and refuses attempts while the account
+// or client IP has to wait; *lockout.Guard implements it
+type LoginGuard interface {
+	Check(ctx context.Context, account, ipAddress string) error
+	RecordFailure(ctx context.Context, account, ipAddress string) error
+	RecordSuccess(ctx context.Context, account string) error
+}
+
+// AuthHandlersOption configures optional authentication methods
+type AuthHandlersOption func(*AuthHandlers)
+
+// WithAPIKeys accepts API keys in AuthMiddleware and enables the OAuth2
+// client-credentials token endpoint, issuing tokens that last clientTokenExpiration
+func WithAPIKeys(apiKeyService services.APIKeyService, clientTokenExpiration time.Duration) AuthHandlersOption {
+	return func(h *AuthHandlers) {
+		h.apiKeyService = apiKeyService
+		h.clientTokenExpiration = clientTokenExpiration
+	}
+}
+
+// WithLoginGuard protects Login against brute force with guard
+func WithLoginGuard(guard LoginGuard) AuthHandlersOption {
+	return func(h *AuthHandlers) {
+		h.loginGuard = guard
+	}
+}
+
+// WithLoginHistory records every sign-in to an existing account in history
+func WithLoginHistory(history services.LoginHistoryService) AuthHandlersOption {
+	return func(h *AuthHandlers) {
+		h.loginHistory = history
+	}
 }
 
 // NewAuthHandlers creates a new authentication handlers instance
-func NewAuthHandlers(userService services.UserService, tokenManager *auth.PASETOManager, queueManager *queue.QueueManager) *AuthHandlers {
-	return &AuthHandlers{
+func NewAuthHandlers(userService services.UserService, tokenManager *auth.PASETOManager, opts ...AuthHandlersOption) *AuthHandlers {
+	h := &AuthHandlers{
 		userService:  userService,
 		tokenManager: tokenManager,
-		queueManager: queueManager,
 	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
 }
 
 // Register handles user registration
@@ -134,9 +179,27 @@ func (h *AuthHandlers) Login(c *gin.Context) {
 		return
 	}
 
+	// Refuse attempts while the account or client IP is delayed or locked
+	// out, before the password is checked
+	ctx := c.Request.Context()
+	if h.loginGuard != nil {
+		if err := h.loginGuard.Check(ctx, req.Email, c.ClientIP()); err != nil {
+			h.recordFailedLogin(c, req.Email, services.LoginOutcomeLocked)
+			respondSignInRefused(c, err)
+			return
+		}
+	}
+
 	// Authenticate user
-	user, err := h.userService.AuthenticateUser(c.Request.Context(), req.Email, req.Password)
+	user, err := h.userService.AuthenticateUser(ctx, req.Email, req.Password)
 	if err != nil {
+		if h.loginGuard != nil && errors.Is(err, services.ErrInvalidCredentials) {
+			// The guard logs failures to count; the sign-in fails either way
+			_ = h.loginGuard.RecordFailure(ctx, req.Email, c.ClientIP())
+		}
+		if errors.Is(err, services.ErrInvalidCredentials) {
+			h.recordFailedLogin(c, req.Email, services.LoginOutcomeFailed)
+		}
 		c.JSON(http.StatusUnauthorized, ErrorResponse{
 			Error:   "authentication_failed",
 			Message: "Invalid email or password",
@@ -145,21 +208,15 @@ func (h *AuthHandlers) Login(c *gin.Context) {
 		return
 	}
 
+	if h.loginGuard != nil {
+		_ = h.loginGuard.RecordSuccess(ctx, req.Email)
+	}
+
 	// Check if this is the first login (welcome email not sent)
 	if !user.WelcomeEmailSent {
-		// Queue welcome email task
-		payload := queue.WelcomeEmailPayload{
-			UserID:    user.ID,
-			Email:     user.Email,
-			FirstName: user.FirstName,
-			LastName:  user.LastName,
-		}
-
-		if err := h.queueManager.QueueWelcomeEmail(c.Request.Context(), payload); err != nil {
-			// Log error but don't fail the login
-			// In a production system, you might want to use a proper logger
-			// For now, we'll continue with the login process
-		}
+		// Schedule the welcome email through the outbox. The service logs
+		// failures and the next login retries, so the login itself proceeds.
+		_ = h.userService.ScheduleWelcomeEmail(c.Request.Context(), user)
 	}
 
 	// Generate token
@@ -173,12 +230,59 @@ func (h *AuthHandlers) Login(c *gin.Context) {
 		return
 	}
 
+	if h.loginHistory != nil {
+		// The service logs failures; a sign-in missing from the history
+		// does not fail the sign-in
+		_ = h.loginHistory.RecordLogin(ctx, user, c.ClientIP(), c.Request.UserAgent())
+	}
+
 	c.JSON(http.StatusOK, AuthResponse{
 		Token: token,
 		User:  user,
 	})
 }
 
+// recordFailedLogin records a sign-in as email that did not succeed, when
+// login history is enabled. Failures are logged by the service.
+func (h *AuthHandlers) recordFailedLogin(c *gin.Context, email, outcome string) {
+	if h.loginHistory == nil {
+		return
+	}
+	_ = h.loginHistory.RecordFailedLogin(c.Request.Context(), email, c.ClientIP(), c.Request.UserAgent(), outcome)
+}
+
+// respondSignInRefused answers a sign-in the login guard refused, telling
+// the client when to try again
+func respondSignInRefused(c *gin.Context, err error) {
+	var locked *lockout.LockedError
+	if !errors.As(err, &locked) {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to authenticate",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	response := ErrorResponse{
+		Error:   "login_throttled",
+		Message: "Too many failed sign-in attempts, try again later",
+		Code:    http.StatusTooManyRequests,
+		Details: map[string]string{"retry_at": locked.Until.UTC().Format(time.RFC3339)},
+	}
+	switch locked.Reason {
+	case lockout.ReasonAccountLocked:
+		response.Error = "account_locked"
+		response.Message = "Account temporarily locked after repeated failed sign-in attempts"
+	case lockout.ReasonIPLocked:
+		response.Error = "too_many_failed_logins"
+		response.Message = "Too many failed sign-in attempts from this address"
+	}
+
+	c.Header("Retry-After", strconv.Itoa(int(locked.RetryAfter(time.Now()).Seconds())))
+	c.JSON(http.StatusTooManyRequests, response)
+}
+
 // Logout handles user logout
 // POST /auth/logout
 func (h *AuthHandlers) Logout(c *gin.Context) {
@@ -190,11 +294,16 @@ func (h *AuthHandlers) Logout(c *gin.Context) {
 	})
 }
 
-// AuthMiddleware validates PASETO tokens and sets user context
+// AuthMiddleware validates PASETO tokens and API keys and sets user context,
+// including the token claims route scope requirements are checked against.
+// API keys are sent as bearer tokens or in the X-API-Key header.
 func (h *AuthHandlers) AuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get token from Authorization header
 		authHeader := c.GetHeader("Authorization")
+		if authHeader == "" && c.GetHeader("X-API-Key") != "" {
+			authHeader = "Bearer " + c.GetHeader("X-API-Key")
+		}
 		if authHeader == "" {
 			c.JSON(http.StatusUnauthorized, ErrorResponse{
 				Error:   "missing_token",
@@ -219,24 +328,76 @@ func (h *AuthHandlers) AuthMiddleware() gin.HandlerFunc {
 
 		token := tokenParts[1]
 
-		// Validate token
-		claims, err := h.tokenManager.ValidateToken(token)
-		if err != nil {
+		if auth.IsAPIKey(token) {
+			if !h.authenticateAPIKey(c, token) {
+				c.Abort()
+				return
+			}
+		} else {
+			// Validate token
+			claims, err := h.tokenManager.ValidateToken(token)
+			if err != nil {
+				c.JSON(http.StatusUnauthorized, ErrorResponse{
+					Error:   "invalid_token",
+					Message: "Invalid or expired token",
+					Code:    http.StatusUnauthorized,
+				})
+				c.Abort()
+				return
+			}
+
+			// Set user information in context
+			c.Set("user_id", claims.UserID)
+			c.Set("user_email", claims.Email)
+			c.Set("token_claims", claims)
+		}
+
+		c.Next()
+	}
+}
+
+// authenticateAPIKey checks an API key and sets user context, responding with
+// an error when it does not authenticate
+func (h *AuthHandlers) authenticateAPIKey(c *gin.Context, key string) bool {
+	if h.apiKeyService == nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "invalid_token",
+			Message: "Invalid or expired token",
+			Code:    http.StatusUnauthorized,
+		})
+		return false
+	}
+
+	principal, err := h.apiKeyService.AuthenticateAPIKey(c.Request.Context(), key)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidAPIKey) {
 			c.JSON(http.StatusUnauthorized, ErrorResponse{
-				Error:   "invalid_token",
-				Message: "Invalid or expired token",
+				Error:   "invalid_api_key",
+				Message: "Invalid, expired or revoked API key",
 				Code:    http.StatusUnauthorized,
 			})
-			c.Abort()
-			return
+			return false
 		}
 
-		// Set user information in context
-		c.Set("user_id", claims.UserID)
-		c.Set("user_email", claims.Email)
-
-		c.Next()
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to authenticate API key",
+			Code:    http.StatusInternalServerError,
+		})
+		return false
 	}
+
+	// A key acts like a client token with the key's scopes
+	c.Set("user_id", int(principal.UserID))
+	c.Set("user_email", principal.Email)
+	c.Set("api_key_id", principal.KeyID)
+	c.Set("token_claims", &auth.TokenClaims{
+		UserID:   int(principal.UserID),
+		Email:    principal.Email,
+		Scopes:   principal.Scopes,
+		ClientID: principal.ClientID,
+	})
+	return true
 }
 
 // GetCurrentUser returns the current authenticated user
@@ -713,6 +874,26 @@ func (h *TransferHandlers) CreateTransfer(c *gin.Context) {
 	transfer, err := h.transferService.TransferMoney(c.Request.Context(), transferReq)
 	if err != nil {
 		// Check for specific error types
+		if strings.Contains(err.Error(), "blocked by risk") {
+			c.JSON(http.StatusForbidden, ErrorResponse{
+				Error:   "transfer_blocked",
+				Message: "Transfer was blocked by fraud checks and has been flagged for review",
+				Code:    http.StatusForbidden,
+			})
+			return
+		}
+
+		var limitErr *services.LimitExceededError
+		if errors.As(err, &limitErr) {
+			c.JSON(http.StatusUnprocessableEntity, ErrorResponse{
+				Error:   "limit_exceeded",
+				Message: limitErr.Error(),
+				Code:    http.StatusUnprocessableEntity,
+				Details: limitErr.Details(),
+			})
+			return
+		}
+
 		if strings.Contains(err.Error(), "insufficient balance") {
 			c.JSON(http.StatusUnprocessableEntity, ErrorResponse{
 				Error:   "insufficient_balance",
@@ -760,9 +941,9 @@ func (h *TransferHandlers) CreateTransfer(c *gin.Context) {
 	c.JSON(http.StatusCreated, transfer)
 }
 
-// GetTransferHistory handles retrieving transfer history for user's accounts
-// GET /transfers
-func (h *TransferHandlers) GetTransferHistory(c *gin.Context) {
+// PreviewTransfer handles quoting the fee for a transfer before it is executed
+// POST /transfers/preview
+func (h *TransferHandlers) PreviewTransfer(c *gin.Context) {
 	// Get user ID from context (set by auth middleware)
 	userID, err := GetUserIDFromContext(c)
 	if err != nil {
@@ -774,26 +955,119 @@ func (h *TransferHandlers) GetTransferHistory(c *gin.Context) {
 		return
 	}
 
-	// Parse query parameters
-	limitStr := c.DefaultQuery("limit", "20")
-	offsetStr := c.DefaultQuery("offset", "0")
-	accountIDStr := c.Query("account_id")
+	var req TransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "validation_error",
+			Message: "Invalid request data",
+			Code:    http.StatusBadRequest,
+			Details: map[string]string{"validation": err.Error()},
+		})
+		return
+	}
 
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil || limit <= 0 {
-		limit = 20
+	if req.Amount.IsNegative() || req.Amount.IsZero() {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_amount",
+			Message: "Transfer amount must be positive",
+			Code:    http.StatusBadRequest,
+		})
+		return
 	}
-	if limit > 100 {
-		limit = 100 // Maximum limit
+
+	// Verify that the user owns the source account
+	if _, err := h.accountService.GetAccount(c.Request.Context(), int32(req.FromAccountID), int32(userID)); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "account_not_found",
+				Message: "Source account not found",
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+
+		if strings.Contains(err.Error(), "access denied") {
+			c.JSON(http.StatusForbidden, ErrorResponse{
+				Error:   "access_denied",
+				Message: "You can only transfer from your own accounts",
+				Code:    http.StatusForbidden,
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to verify source account",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	quote, err := h.transferService.PreviewTransferFee(c.Request.Context(), services.TransferMoneyRequest{
+		FromAccountID: int32(req.FromAccountID),
+		ToAccountID:   int32(req.ToAccountID),
+		Amount:        req.Amount,
+		Description:   req.Description,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "account_not_found",
+				Message: "Destination account not found",
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+
+		if strings.Contains(err.Error(), "validation failed") {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "validation_error",
+				Message: err.Error(),
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to preview transfer",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, quote)
+}
+
+// GetTransferHistory handles retrieving transfer history for user's accounts.
+// Pages are keyed on (created_at, id): pass next_cursor or prev_cursor from a
+// response as cursor to move between pages.
+// GET /transfers
+func (h *TransferHandlers) GetTransferHistory(c *gin.Context) {
+	// Get user ID from context (set by auth middleware)
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "User not authenticated",
+			Code:    http.StatusUnauthorized,
+		})
+		return
 	}
 
-	offset, err := strconv.Atoi(offsetStr)
-	if err != nil || offset < 0 {
-		offset = 0
+	req, err := parseTransferHistoryQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_query",
+			Message: err.Error(),
+			Code:    http.StatusBadRequest,
+		})
+		return
 	}
+	req.UserID = int32(userID)
 
-	// If account_id is specified, get history for that specific account
-	if accountIDStr != "" {
+	// If account_id is specified, verify that the user owns the account
+	if accountIDStr := c.Query("account_id"); accountIDStr != "" {
 		accountID, err := strconv.Atoi(accountIDStr)
 		if err != nil || accountID <= 0 {
 			c.JSON(http.StatusBadRequest, ErrorResponse{
@@ -803,9 +1077,9 @@ func (h *TransferHandlers) GetTransferHistory(c *gin.Context) {
 			})
 			return
 		}
+		req.AccountID = int32(accountID)
 
-		// Verify that the user owns the account
-		_, err = h.accountService.GetAccount(c.Request.Context(), int32(accountID), int32(userID))
+		_, err = h.accountService.GetAccount(c.Request.Context(), req.AccountID, int32(userID))
 		if err != nil {
 			if strings.Contains(err.Error(), "not found") {
 				c.JSON(http.StatusNotFound, ErrorResponse{
@@ -832,40 +1106,123 @@ func (h *TransferHandlers) GetTransferHistory(c *gin.Context) {
 			})
 			return
 		}
+	}
+
+	history, err := h.transferService.ListTransfers(c.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidTransferHistoryRequest) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid_query",
+				Message: err.Error(),
+				Code:    http.StatusBadRequest,
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to retrieve transfer history",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// parseTransferHistoryQuery reads the cursor, limit and filters of a transfer
+// history request; account_id is checked separately with ownership
+func parseTransferHistoryQuery(c *gin.Context) (services.ListTransfersRequest, error) {
+	req := services.ListTransfersRequest{
+		Cursor:    c.Query("cursor"),
+		Direction: c.Query("direction"),
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100 // Maximum limit
+	}
+	req.Limit = int32(limit)
 
-		// Get transfer history for the specific account
-		historyReq := services.GetTransferHistoryRequest{
-			AccountID: int32(accountID),
-			Limit:     int32(limit),
-			Offset:    int32(offset),
+	if value := c.Query("counterparty_account_id"); value != "" {
+		accountID, err := strconv.Atoi(value)
+		if err != nil || accountID <= 0 {
+			return req, errors.New("invalid counterparty_account_id parameter")
 		}
+		req.CounterpartyAccountID = int32(accountID)
+	}
 
-		history, err := h.transferService.GetTransferHistory(c.Request.Context(), historyReq)
+	for _, param := range []struct {
+		name   string
+		target **time.Time
+	}{{"from", &req.CreatedFrom}, {"to", &req.CreatedTo}} {
+		value := c.Query(param.name)
+		if value == "" {
+			continue
+		}
+		t, err := parseHistoryTime(value)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, ErrorResponse{
-				Error:   "internal_error",
-				Message: "Failed to retrieve transfer history",
-				Code:    http.StatusInternalServerError,
-			})
-			return
+			return req, fmt.Errorf("invalid %s parameter: use RFC 3339 or YYYY-MM-DD", param.name)
 		}
+		*param.target = &t
+	}
+
+	for _, param := range []struct {
+		name   string
+		target **decimal.Decimal
+	}{{"min_amount", &req.MinAmount}, {"max_amount", &req.MaxAmount}} {
+		value := c.Query(param.name)
+		if value == "" {
+			continue
+		}
+		amount, err := decimal.NewFromString(value)
+		if err != nil || amount.IsNegative() {
+			return req, fmt.Errorf("invalid %s parameter", param.name)
+		}
+		*param.target = &amount
+	}
+
+	return req, nil
+}
 
-		c.JSON(http.StatusOK, history)
+// parseHistoryTime accepts an RFC 3339 timestamp or a date, which means midnight UTC
+func parseHistoryTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
+
+// GetTransferLimits handles retrieving the user's transfer limits and current usage
+// GET /transfers/limits
+func (h *TransferHandlers) GetTransferLimits(c *gin.Context) {
+	// Get user ID from context (set by auth middleware)
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "User not authenticated",
+			Code:    http.StatusUnauthorized,
+		})
 		return
 	}
 
-	// If no specific account_id, get all transfers for the user
-	history, err := h.transferService.GetTransfersByUser(c.Request.Context(), int32(userID), int32(limit), int32(offset))
+	usage, err := h.transferService.GetTransferLimitUsage(c.Request.Context(), int32(userID))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error:   "internal_error",
-			Message: "Failed to retrieve transfer history",
+			Message: "Failed to retrieve transfer limits",
 			Code:    http.StatusInternalServerError,
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, history)
+	c.JSON(http.StatusOK, gin.H{
+		"limits": usage,
+	})
 }
 
 // GetTransfer handles retrieving transfer details
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"time"
 
@@ -64,6 +65,12 @@ func (h *HealthHandlers) HealthCheck(c *gin.Context) {
 		overallHealthy = false
 	}
 
+	// Report the read replica. Reads fall back to the primary, so a lagging or
+	// unreachable replica degrades performance but not availability.
+	if h.db != nil && h.db.HasReplica() {
+		response.Services["database_replica"] = h.checkReplicaHealth(ctx)
+	}
+
 	// Check Redis connectivity
 	redisStatus := h.checkRedisHealth(ctx)
 	response.Services["redis"] = redisStatus
@@ -126,6 +133,22 @@ func (h *HealthHandlers) checkDatabaseHealth(ctx context.Context) HealthStatus {
 	}
 }
 
+// checkReplicaHealth checks the read replica's connectivity and replication lag
+func (h *HealthHandlers) checkReplicaHealth(ctx context.Context) HealthStatus {
+	if err := h.db.ReplicaHealthCheck(ctx); err != nil {
+		return HealthStatus{
+			Status:  "degraded",
+			Message: err.Error() + "; reads are served by the primary",
+		}
+	}
+
+	status := h.db.ReplicaStatus()
+	return HealthStatus{
+		Status:  "healthy",
+		Message: fmt.Sprintf("replica serving reads with %s lag", status.Lag.Round(time.Millisecond)),
+	}
+}
+
 // checkRedisHealth performs Redis connectivity validation
 func (h *HealthHandlers) checkRedisHealth(ctx context.Context) HealthStatus {
 	if h.queueManager == nil {
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/phantom-sage/bankgo/internal/services"
+)
+
+// LoginHistoryHandlers handles login history HTTP requests
+type LoginHistoryHandlers struct {
+	loginHistory services.LoginHistoryService
+}
+
+// NewLoginHistoryHandlers creates a new login history handlers instance
+func NewLoginHistoryHandlers(loginHistory services.LoginHistoryService) *LoginHistoryHandlers {
+	return &LoginHistoryHandlers{
+		loginHistory: loginHistory,
+	}
+}
+
+// GetLoginHistory handles listing the user's recent sign-ins, successful or
+// not, newest first
+// GET /auth/logins
+func (h *LoginHistoryHandlers) GetLoginHistory(c *gin.Context) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "User not authenticated",
+			Code:    http.StatusUnauthorized,
+		})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(services.DefaultLoginHistoryLimit)))
+	if err != nil || limit <= 0 {
+		limit = services.DefaultLoginHistoryLimit
+	}
+	if limit > services.MaxLoginHistoryLimit {
+		limit = services.MaxLoginHistoryLimit // Maximum limit
+	}
+
+	events, err := h.loginHistory.ListLoginEvents(c.Request.Context(), int32(userID), int32(limit))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to retrieve login history",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, events)
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/phantom-sage/bankgo/internal/lockout"
+	"github.com/phantom-sage/bankgo/internal/models"
+	"github.com/phantom-sage/bankgo/internal/services"
+	"github.com/phantom-sage/bankgo/pkg/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockLoginHistoryService is a mock implementation of LoginHistoryService
+type MockLoginHistoryService struct {
+	mock.Mock
+}
+
+func (m *MockLoginHistoryService) RecordLogin(ctx context.Context, user *models.User, ipAddress, userAgent string) error {
+	args := m.Called(ctx, user, ipAddress, userAgent)
+	return args.Error(0)
+}
+
+func (m *MockLoginHistoryService) RecordFailedLogin(ctx context.Context, email, ipAddress, userAgent, outcome string) error {
+	args := m.Called(ctx, email, ipAddress, userAgent, outcome)
+	return args.Error(0)
+}
+
+func (m *MockLoginHistoryService) ListLoginEvents(ctx context.Context, userID int32, limit int32) ([]services.LoginEvent, error) {
+	args := m.Called(ctx, userID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]services.LoginEvent), args.Error(1)
+}
+
+func TestAuthHandlers_LoginHistory(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tokenManager, _ := auth.NewPASETOManager("test-secret-key-that-is-32-chars", time.Hour)
+	const userAgent = "Mozilla/5.0 (X11; Linux x86_64)"
+
+	login := func(handlers *AuthHandlers, password string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(LoginRequest{Email: "test@example.com", Password: password})
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/auth/login", bytes.NewBuffer(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Request.Header.Set("User-Agent", userAgent)
+		c.Request.RemoteAddr = "203.0.113.7:1234"
+		handlers.Login(c)
+		return w
+	}
+
+	t.Run("successful sign-in is recorded", func(t *testing.T) {
+		userService, history := &MockUserService{}, &MockLoginHistoryService{}
+		user := &models.User{ID: 1, Email: "test@example.com", WelcomeEmailSent: true}
+		userService.On("AuthenticateUser", mock.Anything, "test@example.com", "password123").Return(user, nil)
+		history.On("RecordLogin", mock.Anything, user, "203.0.113.7", userAgent).Return(nil)
+
+		w := login(NewAuthHandlers(userService, tokenManager, WithLoginHistory(history)), "password123")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		history.AssertExpectations(t)
+	})
+
+	t.Run("sign-in succeeds when it cannot be recorded", func(t *testing.T) {
+		userService, history := &MockUserService{}, &MockLoginHistoryService{}
+		user := &models.User{ID: 1, Email: "test@example.com", WelcomeEmailSent: true}
+		userService.On("AuthenticateUser", mock.Anything, "test@example.com", "password123").Return(user, nil)
+		history.On("RecordLogin", mock.Anything, user, "203.0.113.7", userAgent).Return(errors.New("connection refused"))
+
+		w := login(NewAuthHandlers(userService, tokenManager, WithLoginHistory(history)), "password123")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("wrong password is recorded as failed", func(t *testing.T) {
+		userService, history := &MockUserService{}, &MockLoginHistoryService{}
+		userService.On("AuthenticateUser", mock.Anything, "test@example.com", "wrong").Return(nil, services.ErrInvalidCredentials)
+		history.On("RecordFailedLogin", mock.Anything, "test@example.com", "203.0.113.7", userAgent, services.LoginOutcomeFailed).Return(nil)
+
+		w := login(NewAuthHandlers(userService, tokenManager, WithLoginHistory(history)), "wrong")
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		history.AssertExpectations(t)
+		history.AssertNotCalled(t, "RecordLogin", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("refused sign-in is recorded as locked", func(t *testing.T) {
+		userService, guard, history := &MockUserService{}, &MockLoginGuard{}, &MockLoginHistoryService{}
+		guard.On("Check", mock.Anything, "test@example.com", "203.0.113.7").
+			Return(&lockout.LockedError{Reason: lockout.ReasonAccountLocked, Until: time.Now().Add(15 * time.Minute)})
+		history.On("RecordFailedLogin", mock.Anything, "test@example.com", "203.0.113.7", userAgent, services.LoginOutcomeLocked).Return(nil)
+
+		w := login(NewAuthHandlers(userService, tokenManager, WithLoginGuard(guard), WithLoginHistory(history)), "password123")
+
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		history.AssertExpectations(t)
+	})
+}
+
+func TestLoginHistoryHandlers_GetLoginHistory(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	request := func(handlers *LoginHistoryHandlers, query string, userID interface{}) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/auth/logins"+query, nil)
+		if userID != nil {
+			c.Set("user_id", userID)
+		}
+		handlers.GetLoginHistory(c)
+		return w
+	}
+
+	t.Run("lists recent sign-ins", func(t *testing.T) {
+		history := &MockLoginHistoryService{}
+		history.On("ListLoginEvents", mock.Anything, int32(1), int32(services.DefaultLoginHistoryLimit)).Return([]services.LoginEvent{
+			{ID: 2, IPAddress: "203.0.113.7", UserAgent: "curl/8.0", Outcome: services.LoginOutcomeSuccess, CreatedAt: now},
+			{ID: 1, IPAddress: "198.51.100.2", UserAgent: "curl/8.0", Outcome: services.LoginOutcomeFailed, CreatedAt: now.Add(-time.Minute)},
+		}, nil)
+
+		w := request(NewLoginHistoryHandlers(history), "", 1)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var events []services.LoginEvent
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &events))
+		require.Len(t, events, 2)
+		assert.Equal(t, "203.0.113.7", events[0].IPAddress)
+		assert.Equal(t, services.LoginOutcomeFailed, events[1].Outcome)
+		history.AssertExpectations(t)
+	})
+
+	t.Run("limit is capped", func(t *testing.T) {
+		history := &MockLoginHistoryService{}
+		history.On("ListLoginEvents", mock.Anything, int32(1), int32(services.MaxLoginHistoryLimit)).Return([]services.LoginEvent{}, nil)
+
+		w := request(NewLoginHistoryHandlers(history), "?limit=1000", 1)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		history.AssertExpectations(t)
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		w := request(NewLoginHistoryHandlers(&MockLoginHistoryService{}), "", nil)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		history := &MockLoginHistoryService{}
+		history.On("ListLoginEvents", mock.Anything, int32(1), int32(services.DefaultLoginHistoryLimit)).Return(nil, errors.New("connection refused"))
+
+		w := request(NewLoginHistoryHandlers(history), "", 1)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
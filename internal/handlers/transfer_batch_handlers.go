@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/phantom-sage/bankgo/internal/services"
+)
+
+// TransferBatchRequest represents the request body for submitting a batch of transfers
+type TransferBatchRequest struct {
+	Mode      string            `json:"mode"`
+	Transfers []TransferRequest `json:"transfers" binding:"required"`
+}
+
+// TransferBatchHandlers handles batch transfer HTTP requests
+type TransferBatchHandlers struct {
+	batchService services.TransferBatchService
+}
+
+// NewTransferBatchHandlers creates a new batch transfer handlers instance
+func NewTransferBatchHandlers(batchService services.TransferBatchService) *TransferBatchHandlers {
+	return &TransferBatchHandlers{
+		batchService: batchService,
+	}
+}
+
+// CreateTransferBatch handles submitting up to the configured number of transfers at once.
+// Small batches are processed before responding; large ones are queued and
+// answered with 202 Accepted and the batch to poll.
+// POST /transfers/batch
+func (h *TransferBatchHandlers) CreateTransferBatch(c *gin.Context) {
+	// Get user ID from context (set by auth middleware)
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "User not authenticated",
+			Code:    http.StatusUnauthorized,
+		})
+		return
+	}
+
+	var req TransferBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "validation_error",
+			Message: "Invalid request data",
+			Code:    http.StatusBadRequest,
+			Details: map[string]string{"validation": err.Error()},
+		})
+		return
+	}
+
+	batchReq := services.SubmitTransferBatchRequest{
+		Mode:  req.Mode,
+		Items: make([]services.TransferMoneyRequest, len(req.Transfers)),
+	}
+	for i, transfer := range req.Transfers {
+		batchReq.Items[i] = services.TransferMoneyRequest{
+			FromAccountID: int32(transfer.FromAccountID),
+			ToAccountID:   int32(transfer.ToAccountID),
+			Amount:        transfer.Amount,
+			Description:   transfer.Description,
+		}
+	}
+
+	batch, err := h.batchService.SubmitBatch(c.Request.Context(), int32(userID), batchReq)
+	if err != nil {
+		var validationErr *services.TransferBatchValidationError
+		if errors.As(err, &validationErr) {
+			details := make(map[string]string, len(validationErr.Items))
+			for _, item := range validationErr.Items {
+				details[fmt.Sprintf("transfers[%d]", item.Index)] = item.Error
+			}
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "invalid_batch",
+				Message: validationErr.Message,
+				Code:    http.StatusBadRequest,
+				Details: details,
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "batch_failed",
+			Message: "Failed to process transfer batch",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	if batch.Status == services.TransferBatchStatusPending {
+		c.Header("Location", fmt.Sprintf("/api/v1/transfers/batch/%d", batch.ID))
+		c.JSON(http.StatusAccepted, batch)
+		return
+	}
+
+	c.JSON(http.StatusCreated, batch)
+}
+
+// GetTransferBatch handles polling the status and per-item results of a batch
+// GET /transfers/batch/:id
+func (h *TransferBatchHandlers) GetTransferBatch(c *gin.Context) {
+	// Get user ID from context (set by auth middleware)
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "User not authenticated",
+			Code:    http.StatusUnauthorized,
+		})
+		return
+	}
+
+	batchID, err := ParseIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_batch_id",
+			Message: "Invalid batch ID",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	batch, err := h.batchService.GetBatch(c.Request.Context(), int32(userID), int32(batchID))
+	if err != nil {
+		if errors.Is(err, services.ErrTransferBatchNotFound) {
+			c.JSON(http.StatusNotFound, ErrorResponse{
+				Error:   "batch_not_found",
+				Message: "Transfer batch not found",
+				Code:    http.StatusNotFound,
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: "Failed to retrieve transfer batch",
+			Code:    http.StatusInternalServerError,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, batch)
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -70,6 +71,30 @@ func (m *MockTransferService) GetTransfersByUser(ctx context.Context, userID int
 	return args.Get(0).(*services.TransferHistoryResponse), args.Error(1)
 }
 
+func (m *MockTransferService) ListTransfers(ctx context.Context, req services.ListTransfersRequest) (*services.TransferPageResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.TransferPageResponse), args.Error(1)
+}
+
+func (m *MockTransferService) GetTransferLimitUsage(ctx context.Context, userID int32) ([]services.TransferLimitUsage, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]services.TransferLimitUsage), args.Error(1)
+}
+
+func (m *MockTransferService) PreviewTransferFee(ctx context.Context, req services.TransferMoneyRequest) (*services.TransferFeeQuote, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.TransferFeeQuote), args.Error(1)
+}
+
 // Test setup helper for transfer handlers
 func setupTransferHandlersTest() (*TransferHandlers, *MockTransferService, *MockAccountService) {
 	gin.SetMode(gin.TestMode)
@@ -259,8 +284,7 @@ func TestTransferHandlers_GetTransferHistory(t *testing.T) {
 			name:   "get user transfers - no account specified",
 			userID: 1,
 			queryParams: map[string]string{
-				"limit":  "10",
-				"offset": "0",
+				"limit": "10",
 			},
 			mockSetup: func(mt *MockTransferService, ma *MockAccountService) {
 				transfers := []models.Transfer{
@@ -273,13 +297,12 @@ func TestTransferHandlers_GetTransferHistory(t *testing.T) {
 						CreatedAt:     time.Now(),
 					},
 				}
-				response := &services.TransferHistoryResponse{
-					Transfers: transfers,
-					Total:     1,
-					Limit:     10,
-					Offset:    0,
+				response := &services.TransferPageResponse{
+					Transfers:  transfers,
+					Limit:      10,
+					NextCursor: "next",
 				}
-				mt.On("GetTransfersByUser", mock.Anything, int32(1), int32(10), int32(0)).Return(response, nil)
+				mt.On("ListTransfers", mock.Anything, services.ListTransfersRequest{UserID: 1, Limit: 10}).Return(response, nil)
 			},
 			expectedStatus: http.StatusOK,
 		},
@@ -289,7 +312,8 @@ func TestTransferHandlers_GetTransferHistory(t *testing.T) {
 			queryParams: map[string]string{
 				"account_id": "1",
 				"limit":      "20",
-				"offset":     "0",
+				"cursor":     "abc",
+				"direction":  "outgoing",
 			},
 			mockSetup: func(mt *MockTransferService, ma *MockAccountService) {
 				// Mock account ownership verification
@@ -312,18 +336,19 @@ func TestTransferHandlers_GetTransferHistory(t *testing.T) {
 						CreatedAt:     time.Now(),
 					},
 				}
-				response := &services.TransferHistoryResponse{
-					Transfers: transfers,
-					Total:     1,
-					Limit:     20,
-					Offset:    0,
+				response := &services.TransferPageResponse{
+					Transfers:  transfers,
+					Limit:      20,
+					PrevCursor: "prev",
 				}
-				historyReq := services.GetTransferHistoryRequest{
+				historyReq := services.ListTransfersRequest{
+					UserID:    1,
 					AccountID: 1,
+					Cursor:    "abc",
 					Limit:     20,
-					Offset:    0,
+					Direction: "outgoing",
 				}
-				mt.On("GetTransferHistory", mock.Anything, historyReq).Return(response, nil)
+				mt.On("ListTransfers", mock.Anything, historyReq).Return(response, nil)
 			},
 			expectedStatus: http.StatusOK,
 		},
@@ -355,16 +380,36 @@ func TestTransferHandlers_GetTransferHistory(t *testing.T) {
 				// No limit/offset specified, should use defaults
 			},
 			mockSetup: func(mt *MockTransferService, ma *MockAccountService) {
-				response := &services.TransferHistoryResponse{
+				response := &services.TransferPageResponse{
 					Transfers: []models.Transfer{},
-					Total:     0,
 					Limit:     20, // Default limit
-					Offset:    0,  // Default offset
 				}
-				mt.On("GetTransfersByUser", mock.Anything, int32(1), int32(20), int32(0)).Return(response, nil)
+				mt.On("ListTransfers", mock.Anything, services.ListTransfersRequest{UserID: 1, Limit: 20}).Return(response, nil)
 			},
 			expectedStatus: http.StatusOK,
 		},
+		{
+			name:   "invalid amount filter",
+			userID: 1,
+			queryParams: map[string]string{
+				"min_amount": "ten",
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid_query",
+		},
+		{
+			name:   "invalid cursor",
+			userID: 1,
+			queryParams: map[string]string{
+				"cursor": "bogus",
+			},
+			mockSetup: func(mt *MockTransferService, ma *MockAccountService) {
+				mt.On("ListTransfers", mock.Anything, services.ListTransfersRequest{UserID: 1, Limit: 20, Cursor: "bogus"}).
+					Return(nil, fmt.Errorf("%w: invalid cursor", services.ErrInvalidTransferHistoryRequest))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid_query",
+		},
 	}
 
 	for _, tt := range tests {
@@ -409,11 +454,10 @@ func TestTransferHandlers_GetTransferHistory(t *testing.T) {
 				assert.NoError(t, err)
 				assert.Equal(t, tt.expectedError, response.Error)
 			} else if tt.expectedStatus == http.StatusOK {
-				var response services.TransferHistoryResponse
+				var response services.TransferPageResponse
 				err := json.Unmarshal(w.Body.Bytes(), &response)
 				assert.NoError(t, err)
-				assert.GreaterOrEqual(t, response.Limit, int32(0))
-				assert.GreaterOrEqual(t, response.Offset, int32(0))
+				assert.Greater(t, response.Limit, int32(0))
 			}
 
 			mockTransferService.AssertExpectations(t)
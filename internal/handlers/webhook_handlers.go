@@ -0,0 +1,294 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/phantom-sage/bankgo/internal/services"
+)
+
+// CreateWebhookEndpointRequest represents the request body for registering a webhook endpoint
+type CreateWebhookEndpointRequest struct {
+	URL        string   `json:"url" binding:"required"`
+	EventTypes []string `json:"event_types" binding:"required"`
+}
+
+// UpdateWebhookEndpointRequest represents the request body for changing a webhook endpoint
+type UpdateWebhookEndpointRequest struct {
+	URL        *string  `json:"url"`
+	EventTypes []string `json:"event_types"`
+	Enabled    *bool    `json:"enabled"`
+}
+
+// WebhookHandlers handles webhook endpoint HTTP requests
+type WebhookHandlers struct {
+	webhookService services.WebhookService
+}
+
+// NewWebhookHandlers creates a new webhook handlers instance
+func NewWebhookHandlers(webhookService services.WebhookService) *WebhookHandlers {
+	return &WebhookHandlers{
+		webhookService: webhookService,
+	}
+}
+
+// CreateWebhookEndpoint handles registering an endpoint. The response holds
+// the signing secret, which is not shown again.
+// POST /webhooks
+func (h *WebhookHandlers) CreateWebhookEndpoint(c *gin.Context) {
+	userID, ok := webhookUserID(c)
+	if !ok {
+		return
+	}
+
+	var req CreateWebhookEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "validation_error",
+			Message: "Invalid request data",
+			Code:    http.StatusBadRequest,
+			Details: map[string]string{"validation": err.Error()},
+		})
+		return
+	}
+
+	endpoint, err := h.webhookService.CreateEndpoint(c.Request.Context(), int32(userID), services.CreateWebhookEndpointRequest{
+		URL:        req.URL,
+		EventTypes: req.EventTypes,
+	})
+	if err != nil {
+		writeWebhookError(c, err, "Failed to create webhook endpoint")
+		return
+	}
+
+	c.JSON(http.StatusCreated, endpoint)
+}
+
+// GetWebhookEndpoints handles listing the user's endpoints
+// GET /webhooks
+func (h *WebhookHandlers) GetWebhookEndpoints(c *gin.Context) {
+	userID, ok := webhookUserID(c)
+	if !ok {
+		return
+	}
+
+	endpoints, err := h.webhookService.ListEndpoints(c.Request.Context(), int32(userID))
+	if err != nil {
+		writeWebhookError(c, err, "Failed to retrieve webhook endpoints")
+		return
+	}
+
+	c.JSON(http.StatusOK, endpoints)
+}
+
+// GetWebhookEndpoint handles retrieving an endpoint
+// GET /webhooks/:id
+func (h *WebhookHandlers) GetWebhookEndpoint(c *gin.Context) {
+	userID, ok := webhookUserID(c)
+	if !ok {
+		return
+	}
+	endpointID, ok := webhookEndpointID(c)
+	if !ok {
+		return
+	}
+
+	endpoint, err := h.webhookService.GetEndpoint(c.Request.Context(), int32(userID), endpointID)
+	if err != nil {
+		writeWebhookError(c, err, "Failed to retrieve webhook endpoint")
+		return
+	}
+
+	c.JSON(http.StatusOK, endpoint)
+}
+
+// UpdateWebhookEndpoint handles changing an endpoint's URL or event types and
+// disabling or re-enabling it
+// PATCH /webhooks/:id
+func (h *WebhookHandlers) UpdateWebhookEndpoint(c *gin.Context) {
+	userID, ok := webhookUserID(c)
+	if !ok {
+		return
+	}
+	endpointID, ok := webhookEndpointID(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateWebhookEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "validation_error",
+			Message: "Invalid request data",
+			Code:    http.StatusBadRequest,
+			Details: map[string]string{"validation": err.Error()},
+		})
+		return
+	}
+
+	endpoint, err := h.webhookService.UpdateEndpoint(c.Request.Context(), int32(userID), endpointID, services.UpdateWebhookEndpointRequest{
+		URL:        req.URL,
+		EventTypes: req.EventTypes,
+		Enabled:    req.Enabled,
+	})
+	if err != nil {
+		writeWebhookError(c, err, "Failed to update webhook endpoint")
+		return
+	}
+
+	c.JSON(http.StatusOK, endpoint)
+}
+
+// DeleteWebhookEndpoint handles deleting an endpoint and its delivery log
+// DELETE /webhooks/:id
+func (h *WebhookHandlers) DeleteWebhookEndpoint(c *gin.Context) {
+	userID, ok := webhookUserID(c)
+	if !ok {
+		return
+	}
+	endpointID, ok := webhookEndpointID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.webhookService.DeleteEndpoint(c.Request.Context(), int32(userID), endpointID); err != nil {
+		writeWebhookError(c, err, "Failed to delete webhook endpoint")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetWebhookDeliveries handles listing an endpoint's delivery log with the
+// response code of each delivery's latest attempt
+// GET /webhooks/:id/deliveries
+func (h *WebhookHandlers) GetWebhookDeliveries(c *gin.Context) {
+	userID, ok := webhookUserID(c)
+	if !ok {
+		return
+	}
+	endpointID, ok := webhookEndpointID(c)
+	if !ok {
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100 // Maximum limit
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	page, err := h.webhookService.ListDeliveries(c.Request.Context(), int32(userID), endpointID, int32(limit), int32(offset))
+	if err != nil {
+		writeWebhookError(c, err, "Failed to retrieve webhook deliveries")
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// RedeliverWebhook handles sending a logged delivery again
+// POST /webhooks/:id/deliveries/:delivery_id/redeliver
+func (h *WebhookHandlers) RedeliverWebhook(c *gin.Context) {
+	userID, ok := webhookUserID(c)
+	if !ok {
+		return
+	}
+	endpointID, ok := webhookEndpointID(c)
+	if !ok {
+		return
+	}
+
+	deliveryID, err := strconv.ParseInt(c.Param("delivery_id"), 10, 64)
+	if err != nil || deliveryID <= 0 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_delivery_id",
+			Message: "Invalid delivery ID",
+			Code:    http.StatusBadRequest,
+		})
+		return
+	}
+
+	delivery, err := h.webhookService.Redeliver(c.Request.Context(), int32(userID), endpointID, deliveryID)
+	if err != nil {
+		writeWebhookError(c, err, "Failed to redeliver webhook")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, delivery)
+}
+
+// webhookUserID gets the user ID set by the auth middleware, responding with
+// 401 when it is missing
+func webhookUserID(c *gin.Context) (int, bool) {
+	userID, err := GetUserIDFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "unauthorized",
+			Message: "User not authenticated",
+			Code:    http.StatusUnauthorized,
+		})
+		return 0, false
+	}
+	return userID, true
+}
+
+// webhookEndpointID parses the endpoint ID parameter, responding with 400
+// when it is invalid
+func webhookEndpointID(c *gin.Context) (int32, bool) {
+	endpointID, err := ParseIDParam(c, "id")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_webhook_id",
+			Message: "Invalid webhook endpoint ID",
+			Code:    http.StatusBadRequest,
+		})
+		return 0, false
+	}
+	return int32(endpointID), true
+}
+
+// writeWebhookError maps webhook service errors to responses
+func writeWebhookError(c *gin.Context, err error, message string) {
+	var validationErr *services.WebhookValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "invalid_webhook",
+			Message: validationErr.Message,
+			Code:    http.StatusBadRequest,
+		})
+	case errors.Is(err, services.ErrWebhookEndpointNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "webhook_not_found",
+			Message: "Webhook endpoint not found",
+			Code:    http.StatusNotFound,
+		})
+	case errors.Is(err, services.ErrWebhookDeliveryNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "delivery_not_found",
+			Message: "Webhook delivery not found",
+			Code:    http.StatusNotFound,
+		})
+	case errors.Is(err, services.ErrWebhookEndpointDisabled):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "webhook_disabled",
+			Message: "Webhook endpoint is disabled; enable it before redelivering",
+			Code:    http.StatusConflict,
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "internal_error",
+			Message: message,
+			Code:    http.StatusInternalServerError,
+		})
+	}
+}
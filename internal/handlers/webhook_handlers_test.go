@@ -0,0 +1,265 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/phantom-sage/bankgo/internal/events"
+	"github.com/phantom-sage/bankgo/internal/queue"
+	"github.com/phantom-sage/bankgo/internal/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// Mock webhook service for testing
+type MockWebhookService struct {
+	mock.Mock
+}
+
+func (m *MockWebhookService) CreateEndpoint(ctx context.Context, userID int32, req services.CreateWebhookEndpointRequest) (*services.WebhookEndpoint, error) {
+	args := m.Called(ctx, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.WebhookEndpoint), args.Error(1)
+}
+
+func (m *MockWebhookService) ListEndpoints(ctx context.Context, userID int32) ([]services.WebhookEndpoint, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]services.WebhookEndpoint), args.Error(1)
+}
+
+func (m *MockWebhookService) GetEndpoint(ctx context.Context, userID, endpointID int32) (*services.WebhookEndpoint, error) {
+	args := m.Called(ctx, userID, endpointID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.WebhookEndpoint), args.Error(1)
+}
+
+func (m *MockWebhookService) UpdateEndpoint(ctx context.Context, userID, endpointID int32, req services.UpdateWebhookEndpointRequest) (*services.WebhookEndpoint, error) {
+	args := m.Called(ctx, userID, endpointID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.WebhookEndpoint), args.Error(1)
+}
+
+func (m *MockWebhookService) DeleteEndpoint(ctx context.Context, userID, endpointID int32) error {
+	args := m.Called(ctx, userID, endpointID)
+	return args.Error(0)
+}
+
+func (m *MockWebhookService) ListDeliveries(ctx context.Context, userID, endpointID, limit, offset int32) (*services.WebhookDeliveryPage, error) {
+	args := m.Called(ctx, userID, endpointID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.WebhookDeliveryPage), args.Error(1)
+}
+
+func (m *MockWebhookService) Redeliver(ctx context.Context, userID, endpointID int32, deliveryID int64) (*services.WebhookDelivery, error) {
+	args := m.Called(ctx, userID, endpointID, deliveryID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookService) HandleEvent(ctx context.Context, event events.Event) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *MockWebhookService) ProcessWebhookDelivery(ctx context.Context, payload queue.WebhookDeliveryPayload) error {
+	args := m.Called(ctx, payload)
+	return args.Error(0)
+}
+
+// Test setup helper for webhook handlers
+func setupWebhookHandlersTest() (*WebhookHandlers, *MockWebhookService) {
+	gin.SetMode(gin.TestMode)
+
+	mockWebhookService := &MockWebhookService{}
+	return NewWebhookHandlers(mockWebhookService), mockWebhookService
+}
+
+func TestWebhookHandlers_CreateWebhookEndpoint(t *testing.T) {
+	tests := []struct {
+		name           string
+		requestBody    string
+		mockSetup      func(*MockWebhookService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:        "successful registration",
+			requestBody: `{"url":"https://example.com/hooks","event_types":["transfer.received"]}`,
+			mockSetup: func(m *MockWebhookService) {
+				m.On("CreateEndpoint", mock.Anything, int32(1), services.CreateWebhookEndpointRequest{
+					URL:        "https://example.com/hooks",
+					EventTypes: []string{"transfer.received"},
+				}).Return(&services.WebhookEndpoint{ID: 5, URL: "https://example.com/hooks", Secret: "whsec_abc"}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:        "rejected by service",
+			requestBody: `{"url":"http://example.com/hooks","event_types":["transfer.received"]}`,
+			mockSetup: func(m *MockWebhookService) {
+				m.On("CreateEndpoint", mock.Anything, int32(1), mock.Anything).
+					Return(nil, &services.WebhookValidationError{Message: "url must use https"})
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid_webhook",
+		},
+		{
+			name:           "missing url",
+			requestBody:    `{"event_types":["transfer.received"]}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "validation_error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlers, mockWebhookService := setupWebhookHandlersTest()
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockWebhookService)
+			}
+
+			c, w := createAuthenticatedContext(1)
+			c.Request = httptest.NewRequest(http.MethodPost, "/webhooks", bytes.NewBufferString(tt.requestBody))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			handlers.CreateWebhookEndpoint(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				var response ErrorResponse
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedError, response.Error)
+			} else {
+				var response services.WebhookEndpoint
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, "whsec_abc", response.Secret, "the secret is returned on creation")
+			}
+			mockWebhookService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWebhookHandlers_GetWebhookDeliveries(t *testing.T) {
+	handlers, mockWebhookService := setupWebhookHandlersTest()
+
+	code := int32(500)
+	mockWebhookService.On("ListDeliveries", mock.Anything, int32(1), int32(5), int32(100), int32(10)).
+		Return(&services.WebhookDeliveryPage{
+			Deliveries: []services.WebhookDelivery{{ID: 9, EndpointID: 5, Status: services.WebhookDeliveryFailed, ResponseCode: &code}},
+			Total:      11,
+			Limit:      100,
+			Offset:     10,
+		}, nil)
+
+	c, w := createAuthenticatedContext(1)
+	c.Request = httptest.NewRequest(http.MethodGet, "/webhooks/5/deliveries?limit=500&offset=10", nil)
+	c.Params = gin.Params{{Key: "id", Value: "5"}}
+
+	handlers.GetWebhookDeliveries(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response services.WebhookDeliveryPage
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response.Deliveries, 1)
+	assert.Equal(t, int32(500), *response.Deliveries[0].ResponseCode)
+	mockWebhookService.AssertExpectations(t)
+}
+
+func TestWebhookHandlers_RedeliverWebhook(t *testing.T) {
+	tests := []struct {
+		name           string
+		deliveryID     string
+		mockSetup      func(*MockWebhookService)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:       "successful redelivery",
+			deliveryID: "9",
+			mockSetup: func(m *MockWebhookService) {
+				original := int64(9)
+				m.On("Redeliver", mock.Anything, int32(1), int32(5), int64(9)).
+					Return(&services.WebhookDelivery{ID: 10, Status: services.WebhookDeliveryPending, RedeliveryOf: &original}, nil)
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:       "endpoint disabled",
+			deliveryID: "9",
+			mockSetup: func(m *MockWebhookService) {
+				m.On("Redeliver", mock.Anything, int32(1), int32(5), int64(9)).Return(nil, services.ErrWebhookEndpointDisabled)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedError:  "webhook_disabled",
+		},
+		{
+			name:       "delivery of another endpoint",
+			deliveryID: "9",
+			mockSetup: func(m *MockWebhookService) {
+				m.On("Redeliver", mock.Anything, int32(1), int32(5), int64(9)).Return(nil, services.ErrWebhookDeliveryNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "delivery_not_found",
+		},
+		{
+			name:           "invalid delivery id",
+			deliveryID:     "abc",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid_delivery_id",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlers, mockWebhookService := setupWebhookHandlersTest()
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockWebhookService)
+			}
+
+			c, w := createAuthenticatedContext(1)
+			c.Request = httptest.NewRequest(http.MethodPost, "/webhooks/5/deliveries/"+tt.deliveryID+"/redeliver", nil)
+			c.Params = gin.Params{{Key: "id", Value: "5"}, {Key: "delivery_id", Value: tt.deliveryID}}
+
+			handlers.RedeliverWebhook(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				var response ErrorResponse
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedError, response.Error)
+			}
+			mockWebhookService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWebhookHandlers_Unauthenticated(t *testing.T) {
+	handlers, mockWebhookService := setupWebhookHandlersTest()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/webhooks", nil)
+
+	handlers.GetWebhookEndpoints(c)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	mockWebhookService.AssertNotCalled(t, "ListEndpoints", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,335 @@
+// Package lockout protects sign-ins against brute force. Failed attempts are
+// counted per account and per client IP in the login_failures table, so every
+// replica of the API and the admin API see the same counts. Repeated failures
+// first delay an account's next attempt and then lock it for a while; an IP
+// failing across many accounts is locked out as a whole.
+package lockout
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/phantom-sage/bankgo/internal/config"
+	"github.com/phantom-sage/bankgo/internal/database/queries"
+	"github.com/phantom-sage/bankgo/internal/logging"
+	"github.com/rs/zerolog"
+)
+
+// Realm keeps the counters of one kind of sign-in apart from the others
+type Realm struct {
+	AccountScope string
+	IPScope      string
+}
+
+var (
+	// Customers sign in to the banking API with their email address
+	Customers = Realm{AccountScope: "account", IPScope: "ip"}
+
+	// Admins sign in to the admin API with their username
+	Admins = Realm{AccountScope: "admin_account", IPScope: "admin_ip"}
+)
+
+// Reasons a sign-in attempt is refused
+const (
+	// ReasonDelay is a short wait after a few failures
+	ReasonDelay = "delay"
+	// ReasonAccountLocked is a lockout of the account
+	ReasonAccountLocked = "account_locked"
+	// ReasonIPLocked is a lockout of the client IP
+	ReasonIPLocked = "ip_locked"
+)
+
+// maxSubjectLength is the length of login_failures.subject
+const maxSubjectLength = 255
+
+// LockedError is returned by Check when an attempt must wait until Until
+type LockedError struct {
+	Reason string
+	Until  time.Time
+}
+
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("sign-in refused (%s) until %s", e.Reason, e.Until.Format(time.RFC3339))
+}
+
+// RetryAfter returns how long from now the client has to wait, in whole
+// seconds rounded up
+func (e *LockedError) RetryAfter(now time.Time) time.Duration {
+	wait := e.Until.Sub(now)
+	if wait <= 0 {
+		return 0
+	}
+	return (wait + time.Second - 1).Truncate(time.Second)
+}
+
+// Unlocker clears the counters of a subject; *queries.Queries implements it
+type Unlocker interface {
+	DeleteLoginFailure(ctx context.Context, arg queries.DeleteLoginFailureParams) error
+}
+
+// Store is where failures are counted; *queries.Queries implements it
+type Store interface {
+	Unlocker
+	GetLoginFailures(ctx context.Context, arg queries.GetLoginFailuresParams) ([]queries.LoginFailure, error)
+	LockLoginFailure(ctx context.Context, arg queries.LockLoginFailureParams) error
+	RecordLoginFailure(ctx context.Context, arg queries.RecordLoginFailureParams) (queries.LoginFailure, error)
+}
+
+// Alerter raises a security alert when an account or IP is locked out.
+// AlertGeneratorService in the admin services implements it.
+type Alerter interface {
+	AuthenticationFailureAlert(ctx context.Context, username, ipAddress string, failureCount int) error
+}
+
+// Notifier tells the owner of an account it was locked out
+type Notifier interface {
+	AccountLocked(ctx context.Context, account, ipAddress string, until time.Time) error
+}
+
+// Guard counts the failed sign-ins of one realm and refuses attempts while
+// an account or IP has to wait
+type Guard struct {
+	store       Store
+	realm       Realm
+	cfg         config.LoginProtectionConfig
+	alerter     Alerter
+	notifier    Notifier
+	logger      zerolog.Logger
+	auditLogger *logging.AuditLogger
+	now         func() time.Time
+}
+
+// Option configures optional Guard dependencies
+type Option func(*Guard)
+
+// WithAlerter raises a security alert whenever a lockout begins
+func WithAlerter(alerter Alerter) Option {
+	return func(g *Guard) {
+		g.alerter = alerter
+	}
+}
+
+// WithNotifier tells account owners when their account is locked out
+func WithNotifier(notifier Notifier) Option {
+	return func(g *Guard) {
+		g.notifier = notifier
+	}
+}
+
+// NewGuard creates a guard counting the failures of realm in store
+func NewGuard(store Store, realm Realm, cfg config.LoginProtectionConfig, logger zerolog.Logger, opts ...Option) *Guard {
+	g := &Guard{
+		store:       store,
+		realm:       realm,
+		cfg:         cfg,
+		logger:      logger.With().Str("component", "login_guard").Str("scope", realm.AccountScope).Logger(),
+		auditLogger: logging.NewAuditLogger(logger),
+		now:         time.Now,
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// Check returns a *LockedError when account, or the IP it signs in from, has
+// to wait before trying again; the password is not to be checked then. When
+// the counters cannot be read the attempt is allowed, as refusing every
+// sign-in during a database outage would do more harm, and the login rate
+// limit still applies.
+func (g *Guard) Check(ctx context.Context, account, ipAddress string) error {
+	rows, err := g.store.GetLoginFailures(ctx, queries.GetLoginFailuresParams{
+		AccountScope: g.realm.AccountScope,
+		Account:      subject(account),
+		IpScope:      g.realm.IPScope,
+		IpAddress:    subject(ipAddress),
+	})
+	if err != nil {
+		g.logger.Error().Err(err).Msg("Failed to read login failures, allowing sign-in")
+		return nil
+	}
+
+	now := g.now()
+	var locked *LockedError
+	for _, row := range rows {
+		if !row.LockedUntil.Valid || !row.LockedUntil.Time.After(now) {
+			continue
+		}
+		if locked != nil && !row.LockedUntil.Time.After(locked.Until) {
+			continue
+		}
+
+		reason := ReasonIPLocked
+		if row.Scope == g.realm.AccountScope {
+			reason = ReasonDelay
+			if int(row.FailureCount) >= g.cfg.LockoutThreshold {
+				reason = ReasonAccountLocked
+			}
+		}
+		locked = &LockedError{Reason: reason, Until: row.LockedUntil.Time}
+	}
+	if locked != nil {
+		return locked
+	}
+	return nil
+}
+
+// RecordFailure counts a failed sign-in of account from ipAddress, delaying
+// or locking out either when they have failed too often. Failures are
+// counted for unknown accounts too, so lockouts do not reveal which exist.
+func (g *Guard) RecordFailure(ctx context.Context, account, ipAddress string) error {
+	now := g.now()
+
+	failures, err := g.record(ctx, g.realm.AccountScope, account, now)
+	if err != nil {
+		return err
+	}
+	if wait, begun := g.accountPenalty(failures); wait > 0 {
+		until := now.Add(wait)
+		if err := g.lock(ctx, g.realm.AccountScope, account, until); err != nil {
+			return err
+		}
+		if begun {
+			g.lockedOut(ctx, ReasonAccountLocked, account, ipAddress, failures, until)
+		}
+	}
+
+	if ipAddress == "" {
+		return nil
+	}
+	failures, err = g.record(ctx, g.realm.IPScope, ipAddress, now)
+	if err != nil {
+		return err
+	}
+	if failures >= g.cfg.IPLockoutThreshold {
+		until := now.Add(g.lockoutDuration(failures, g.cfg.IPLockoutThreshold))
+		if err := g.lock(ctx, g.realm.IPScope, ipAddress, until); err != nil {
+			return err
+		}
+		if (failures-g.cfg.IPLockoutThreshold)%g.cfg.IPLockoutThreshold == 0 {
+			g.lockedOut(ctx, ReasonIPLocked, account, ipAddress, failures, until)
+		}
+	}
+	return nil
+}
+
+// RecordSuccess forgets the failures of an account that signed in. Those of
+// its IP are kept, so one valid account does not reset an IP trying many.
+func (g *Guard) RecordSuccess(ctx context.Context, account string) error {
+	if err := Unlock(ctx, g.store, g.realm, account); err != nil {
+		g.logger.Error().Err(err).Str("account", account).Msg("Failed to clear login failures")
+		return err
+	}
+	return nil
+}
+
+// Unlock clears the failures and any lockout of an account in realm
+func Unlock(ctx context.Context, store Unlocker, realm Realm, account string) error {
+	err := store.DeleteLoginFailure(ctx, queries.DeleteLoginFailureParams{
+		Scope:   realm.AccountScope,
+		Subject: subject(account),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to clear login failures: %w", err)
+	}
+	return nil
+}
+
+// accountPenalty returns how long an account waits after failures, and
+// whether the wait is a lockout that has just begun. Once locked, every
+// further failure in the window locks the account again, for twice as long
+// every LockoutThreshold failures.
+func (g *Guard) accountPenalty(failures int) (time.Duration, bool) {
+	if failures >= g.cfg.LockoutThreshold {
+		begun := (failures-g.cfg.LockoutThreshold)%g.cfg.LockoutThreshold == 0
+		return g.lockoutDuration(failures, g.cfg.LockoutThreshold), begun
+	}
+	if failures >= g.cfg.DelayAfter {
+		return backoff(g.cfg.BaseDelay, failures-g.cfg.DelayAfter, g.cfg.MaxDelay), false
+	}
+	return 0, false
+}
+
+// lockoutDuration returns the lockout after failures past threshold
+func (g *Guard) lockoutDuration(failures, threshold int) time.Duration {
+	return backoff(g.cfg.LockoutDuration, (failures-threshold)/threshold, g.cfg.MaxLockoutDuration)
+}
+
+// record counts a failure of a subject and returns its failures in the window
+func (g *Guard) record(ctx context.Context, scope, name string, now time.Time) (int, error) {
+	row, err := g.store.RecordLoginFailure(ctx, queries.RecordLoginFailureParams{
+		Scope:       scope,
+		Subject:     subject(name),
+		FailedAt:    pgtype.Timestamp{Time: now.UTC(), Valid: true},
+		WindowStart: pgtype.Timestamp{Time: now.Add(-g.cfg.FailureWindow).UTC(), Valid: true},
+	})
+	if err != nil {
+		g.logger.Error().Err(err).Str("subject_scope", scope).Msg("Failed to record login failure")
+		return 0, fmt.Errorf("failed to record login failure: %w", err)
+	}
+	return int(row.FailureCount), nil
+}
+
+// lock refuses attempts of a subject until until
+func (g *Guard) lock(ctx context.Context, scope, name string, until time.Time) error {
+	err := g.store.LockLoginFailure(ctx, queries.LockLoginFailureParams{
+		Scope:       scope,
+		Subject:     subject(name),
+		LockedUntil: pgtype.Timestamp{Time: until.UTC(), Valid: true},
+	})
+	if err != nil {
+		g.logger.Error().Err(err).Str("subject_scope", scope).Msg("Failed to lock sign-in")
+		return fmt.Errorf("failed to lock sign-in: %w", err)
+	}
+	return nil
+}
+
+// lockedOut reports a lockout that has just begun. Alerting and notifying
+// are best effort; the lockout holds either way.
+func (g *Guard) lockedOut(ctx context.Context, reason, account, ipAddress string, failures int, until time.Time) {
+	g.logger.Warn().
+		Str("reason", reason).
+		Str("account", account).
+		Str("client_ip", ipAddress).
+		Int("failure_count", failures).
+		Time("locked_until", until).
+		Msg("Sign-in locked out after repeated failures")
+	g.auditLogger.LogSecurityEventWithIP(reason, g.realm.AccountScope,
+		fmt.Sprintf("%d failed sign-ins for %s, locked until %s", failures, account, until.UTC().Format(time.RFC3339)), ipAddress)
+
+	if g.alerter != nil {
+		if err := g.alerter.AuthenticationFailureAlert(ctx, account, ipAddress, failures); err != nil {
+			g.logger.Error().Err(err).Str("account", account).Msg("Failed to raise lockout alert")
+		}
+	}
+	if reason == ReasonAccountLocked && g.notifier != nil {
+		if err := g.notifier.AccountLocked(ctx, account, ipAddress, until); err != nil {
+			g.logger.Error().Err(err).Str("account", account).Msg("Failed to notify account owner of lockout")
+		}
+	}
+}
+
+// backoff doubles base once per step, up to max
+func backoff(base time.Duration, steps int, max time.Duration) time.Duration {
+	wait := base
+	for i := 0; i < steps && wait < max; i++ {
+		wait *= 2
+	}
+	if wait > max {
+		return max
+	}
+	return wait
+}
+
+// subject normalizes an account or IP to its login_failures key. Email
+// addresses are case-insensitive, so they are counted in lower case.
+func subject(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if runes := []rune(name); len(runes) > maxSubjectLength {
+		name = string(runes[:maxSubjectLength])
+	}
+	return name
+}
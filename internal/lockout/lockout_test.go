@@ -0,0 +1,266 @@
+package lockout
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/phantom-sage/bankgo/internal/config"
+	"github.com/phantom-sage/bankgo/internal/database/queries"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryStore mirrors the login_failures queries in memory
+type memoryStore struct {
+	rows map[[2]string]queries.LoginFailure
+	err  error
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{rows: make(map[[2]string]queries.LoginFailure)}
+}
+
+func (s *memoryStore) GetLoginFailures(ctx context.Context, arg queries.GetLoginFailuresParams) ([]queries.LoginFailure, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	var rows []queries.LoginFailure
+	for _, key := range [][2]string{{arg.AccountScope, arg.Account}, {arg.IpScope, arg.IpAddress}} {
+		if row, ok := s.rows[key]; ok {
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+func (s *memoryStore) RecordLoginFailure(ctx context.Context, arg queries.RecordLoginFailureParams) (queries.LoginFailure, error) {
+	key := [2]string{arg.Scope, arg.Subject}
+	row, ok := s.rows[key]
+	if !ok || row.LastFailedAt.Time.Before(arg.WindowStart.Time) {
+		row.FailureCount = 0
+	}
+	row.Scope, row.Subject = arg.Scope, arg.Subject
+	row.FailureCount++
+	row.LastFailedAt = arg.FailedAt
+	s.rows[key] = row
+	return row, nil
+}
+
+func (s *memoryStore) LockLoginFailure(ctx context.Context, arg queries.LockLoginFailureParams) error {
+	key := [2]string{arg.Scope, arg.Subject}
+	row := s.rows[key]
+	row.LockedUntil = arg.LockedUntil
+	s.rows[key] = row
+	return nil
+}
+
+func (s *memoryStore) DeleteLoginFailure(ctx context.Context, arg queries.DeleteLoginFailureParams) error {
+	delete(s.rows, [2]string{arg.Scope, arg.Subject})
+	return nil
+}
+
+// recordingAlerter records the alerts raised
+type recordingAlerter struct {
+	alerts []string
+}
+
+func (a *recordingAlerter) AuthenticationFailureAlert(ctx context.Context, username, ipAddress string, failureCount int) error {
+	a.alerts = append(a.alerts, username+" "+ipAddress)
+	return nil
+}
+
+// recordingNotifier records the accounts told about a lockout
+type recordingNotifier struct {
+	locked []string
+}
+
+func (n *recordingNotifier) AccountLocked(ctx context.Context, account, ipAddress string, until time.Time) error {
+	n.locked = append(n.locked, account)
+	return nil
+}
+
+func testConfig() config.LoginProtectionConfig {
+	return config.LoginProtectionConfig{
+		Enabled:            true,
+		FailureWindow:      time.Hour,
+		DelayAfter:         2,
+		BaseDelay:          time.Second,
+		MaxDelay:           4 * time.Second,
+		LockoutThreshold:   4,
+		IPLockoutThreshold: 6,
+		LockoutDuration:    15 * time.Minute,
+		MaxLockoutDuration: 24 * time.Hour,
+	}
+}
+
+type guardFixture struct {
+	guard    *Guard
+	store    *memoryStore
+	alerter  *recordingAlerter
+	notifier *recordingNotifier
+	now      time.Time
+}
+
+func newGuardFixture() *guardFixture {
+	f := &guardFixture{
+		store:    newMemoryStore(),
+		alerter:  &recordingAlerter{},
+		notifier: &recordingNotifier{},
+		now:      time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+	}
+	f.guard = NewGuard(f.store, Customers, testConfig(), zerolog.Nop(), WithAlerter(f.alerter), WithNotifier(f.notifier))
+	f.guard.now = func() time.Time { return f.now }
+	return f
+}
+
+// fail records a failure and returns what the next attempt is told
+func (f *guardFixture) fail(t *testing.T, account, ip string) *LockedError {
+	t.Helper()
+	require.NoError(t, f.guard.RecordFailure(context.Background(), account, ip))
+	err := f.guard.Check(context.Background(), account, ip)
+	if err == nil {
+		return nil
+	}
+	var locked *LockedError
+	require.ErrorAs(t, err, &locked)
+	return locked
+}
+
+func TestGuard_ProgressiveDelays(t *testing.T) {
+	f := newGuardFixture()
+
+	assert.Nil(t, f.fail(t, "alice@example.com", "10.0.0.1"), "a single failure is not delayed")
+
+	locked := f.fail(t, "alice@example.com", "10.0.0.1")
+	require.NotNil(t, locked)
+	assert.Equal(t, ReasonDelay, locked.Reason)
+	assert.Equal(t, time.Second, locked.RetryAfter(f.now))
+
+	f.now = f.now.Add(time.Second)
+	assert.NoError(t, f.guard.Check(context.Background(), "alice@example.com", "10.0.0.1"), "the delay passes")
+
+	locked = f.fail(t, "alice@example.com", "10.0.0.1")
+	require.NotNil(t, locked)
+	assert.Equal(t, ReasonDelay, locked.Reason)
+	assert.Equal(t, 2*time.Second, locked.RetryAfter(f.now), "the delay doubles")
+	assert.Empty(t, f.notifier.locked)
+	assert.Empty(t, f.alerter.alerts)
+}
+
+func TestGuard_LockoutNotifiesAndAlerts(t *testing.T) {
+	f := newGuardFixture()
+
+	for i := 0; i < 3; i++ {
+		f.fail(t, "Alice@Example.com", "10.0.0.1")
+		f.now = f.now.Add(time.Minute)
+	}
+	locked := f.fail(t, "alice@example.com", "10.0.0.1")
+	require.NotNil(t, locked)
+	assert.Equal(t, ReasonAccountLocked, locked.Reason, "failures are counted case-insensitively")
+	assert.Equal(t, 15*time.Minute, locked.RetryAfter(f.now))
+	assert.Equal(t, []string{"alice@example.com"}, f.notifier.locked)
+	assert.Equal(t, []string{"alice@example.com 10.0.0.1"}, f.alerter.alerts)
+
+	err := f.guard.Check(context.Background(), "alice@example.com", "10.0.0.2")
+	assert.Error(t, err, "the account is locked from every IP")
+
+	f.now = f.now.Add(15 * time.Minute)
+	locked = f.fail(t, "alice@example.com", "10.0.0.1")
+	require.NotNil(t, locked)
+	assert.Equal(t, 15*time.Minute, locked.RetryAfter(f.now), "a failure after the lockout locks again")
+	assert.Len(t, f.notifier.locked, 1, "only the start of a lockout is notified")
+}
+
+func TestGuard_LockoutGrows(t *testing.T) {
+	f := newGuardFixture()
+
+	var locked *LockedError
+	for i := 0; i < 8; i++ {
+		f.now = f.now.Add(time.Minute)
+		if locked != nil {
+			f.now = locked.Until
+		}
+		locked = f.fail(t, "alice@example.com", "")
+	}
+	require.NotNil(t, locked)
+	assert.Equal(t, 30*time.Minute, locked.RetryAfter(f.now), "the lockout doubles every threshold failures")
+	assert.Len(t, f.notifier.locked, 2)
+}
+
+func TestGuard_IPLockoutAcrossAccounts(t *testing.T) {
+	f := newGuardFixture()
+
+	for i := 0; i < 5; i++ {
+		assert.Nil(t, f.fail(t, string(rune('a'+i))+"@example.com", "10.0.0.1"))
+	}
+	locked := f.fail(t, "f@example.com", "10.0.0.1")
+	require.NotNil(t, locked)
+	assert.Equal(t, ReasonIPLocked, locked.Reason)
+	assert.Equal(t, []string{"f@example.com 10.0.0.1"}, f.alerter.alerts)
+	assert.Empty(t, f.notifier.locked, "IP lockouts are not sent to account owners")
+
+	assert.Error(t, f.guard.Check(context.Background(), "new@example.com", "10.0.0.1"))
+	assert.NoError(t, f.guard.Check(context.Background(), "new@example.com", "10.0.0.2"))
+}
+
+func TestGuard_SuccessAndUnlockClearFailures(t *testing.T) {
+	f := newGuardFixture()
+	ctx := context.Background()
+
+	f.fail(t, "alice@example.com", "10.0.0.1")
+	require.NoError(t, f.guard.RecordSuccess(ctx, "alice@example.com"))
+	assert.Nil(t, f.fail(t, "alice@example.com", "10.0.0.1"), "counting starts over after a sign-in")
+
+	for i := 0; i < 4; i++ {
+		f.fail(t, "bob@example.com", "10.0.0.3")
+		f.now = f.now.Add(time.Minute)
+	}
+	require.Error(t, f.guard.Check(ctx, "bob@example.com", "10.0.0.3"))
+	require.NoError(t, Unlock(ctx, f.store, Customers, "Bob@example.com"))
+	assert.NoError(t, f.guard.Check(ctx, "bob@example.com", "10.0.0.3"))
+}
+
+func TestGuard_WindowExpiresFailures(t *testing.T) {
+	f := newGuardFixture()
+
+	f.fail(t, "alice@example.com", "")
+	f.now = f.now.Add(2 * time.Hour)
+	assert.Nil(t, f.fail(t, "alice@example.com", ""), "failures outside the window are forgotten")
+	assert.Equal(t, int32(1), f.store.rows[[2]string{"account", "alice@example.com"}].FailureCount)
+}
+
+func TestGuard_RealmsAreSeparate(t *testing.T) {
+	f := newGuardFixture()
+	admins := NewGuard(f.store, Admins, testConfig(), zerolog.Nop())
+
+	for i := 0; i < 4; i++ {
+		require.NoError(t, admins.RecordFailure(context.Background(), "admin", "10.0.0.1"))
+	}
+	assert.Error(t, admins.Check(context.Background(), "admin", "10.0.0.1"))
+	assert.NoError(t, f.guard.Check(context.Background(), "admin", "10.0.0.1"))
+}
+
+func TestGuard_CheckFailsOpen(t *testing.T) {
+	f := newGuardFixture()
+	f.store.err = errors.New("connection refused")
+
+	assert.NoError(t, f.guard.Check(context.Background(), "alice@example.com", "10.0.0.1"))
+}
+
+func TestLockedError_RetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	locked := &LockedError{Reason: ReasonDelay, Until: now.Add(1500 * time.Millisecond)}
+
+	assert.Equal(t, 2*time.Second, locked.RetryAfter(now))
+	assert.Zero(t, locked.RetryAfter(now.Add(time.Minute)))
+	assert.Contains(t, locked.Error(), "delay")
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, time.Second, backoff(time.Second, 0, time.Minute))
+	assert.Equal(t, 8*time.Second, backoff(time.Second, 3, time.Minute))
+	assert.Equal(t, time.Minute, backoff(time.Second, 20, time.Minute))
+}
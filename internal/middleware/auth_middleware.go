@@ -98,4 +98,65 @@ func GetTokenClaimsFromContext(c *gin.Context) (*auth.TokenClaims, bool) {
 
 	tokenClaims, ok := claims.(*auth.TokenClaims)
 	return tokenClaims, ok
-}
\ No newline at end of file
+}
+
+// RequireScope creates a middleware that lets a request through only when the
+// token claims set by the auth middleware grant every one of scopes
+func RequireScope(scopes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, exists := GetTokenClaimsFromContext(c)
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error":   "unauthorized",
+				"message": "User not authenticated",
+				"code":    http.StatusUnauthorized,
+			})
+			c.Abort()
+			return
+		}
+
+		for _, scope := range scopes {
+			if !claims.HasScope(scope) {
+				c.JSON(http.StatusForbidden, gin.H{
+					"error":   "insufficient_scope",
+					"message": "The token does not grant the " + scope + " scope",
+					"code":    http.StatusForbidden,
+				})
+				c.Abort()
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// RequireLoginSession creates a middleware that only lets through requests
+// made with a token from signing in, keeping API keys, client tokens and
+// reduced-scope tokens away from credentials and settings
+func RequireLoginSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, exists := GetTokenClaimsFromContext(c)
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error":   "unauthorized",
+				"message": "User not authenticated",
+				"code":    http.StatusUnauthorized,
+			})
+			c.Abort()
+			return
+		}
+
+		if !claims.IsLoginSession() {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error":   "login_required",
+				"message": "This request needs a token from signing in",
+				"code":    http.StatusForbidden,
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
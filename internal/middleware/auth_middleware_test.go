@@ -303,4 +303,124 @@ func TestAuthMiddleware_Integration(t *testing.T) {
 		assert.Contains(t, w.Body.String(), `"claims_user_id":456`)
 		assert.Contains(t, w.Body.String(), `"claims_email":"integration@example.com"`)
 	})
-}
\ No newline at end of file
+}
+
+func TestRequireScope(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(claims *auth.TokenClaims) *gin.Engine {
+		router := gin.New()
+		router.Use(func(c *gin.Context) {
+			if claims != nil {
+				c.Set("token_claims", claims)
+			}
+			c.Next()
+		})
+		router.GET("/transfers", RequireScope(auth.ScopeTransfersRead), func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+		router.POST("/transfers", RequireScope(auth.ScopeTransfersWrite), func(c *gin.Context) {
+			c.Status(http.StatusCreated)
+		})
+		return router
+	}
+
+	tests := []struct {
+		name           string
+		claims         *auth.TokenClaims
+		method         string
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:           "login token has every scope",
+			claims:         &auth.TokenClaims{UserID: 1, Scopes: auth.AllScopes},
+			method:         http.MethodPost,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "read-only token can read",
+			claims:         &auth.TokenClaims{UserID: 1, Scopes: []string{auth.ScopeTransfersRead}, Reduced: true},
+			method:         http.MethodGet,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "read-only token cannot transfer",
+			claims:         &auth.TokenClaims{UserID: 1, Scopes: []string{auth.ScopeTransfersRead}, Reduced: true},
+			method:         http.MethodPost,
+			expectedStatus: http.StatusForbidden,
+			expectedError:  "insufficient_scope",
+		},
+		{
+			name:           "statement token cannot read transfers",
+			claims:         &auth.TokenClaims{UserID: 1, Scopes: []string{auth.ScopeAccountsRead}, ClientID: "abc"},
+			method:         http.MethodGet,
+			expectedStatus: http.StatusForbidden,
+			expectedError:  "insufficient_scope",
+		},
+		{
+			name:           "no claims",
+			method:         http.MethodGet,
+			expectedStatus: http.StatusUnauthorized,
+			expectedError:  "unauthorized",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, "/transfers", nil)
+			newRouter(tt.claims).ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedError != "" {
+				assert.Contains(t, w.Body.String(), `"error":"`+tt.expectedError+`"`)
+			}
+		})
+	}
+}
+
+func TestRequireLoginSession(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		claims         *auth.TokenClaims
+		expectedStatus int
+	}{
+		{
+			name:           "login token",
+			claims:         &auth.TokenClaims{UserID: 1, Scopes: auth.AllScopes},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "client token",
+			claims:         &auth.TokenClaims{UserID: 1, Scopes: auth.AllScopes, ClientID: "abc"},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "reduced token",
+			claims:         &auth.TokenClaims{UserID: 1, Scopes: auth.AllScopes, Reduced: true},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				c.Set("token_claims", tt.claims)
+				c.Next()
+			})
+			router.GET("/api-keys", RequireLoginSession(), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/api-keys", nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
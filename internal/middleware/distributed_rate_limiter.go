@@ -0,0 +1,390 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/phantom-sage/bankgo/internal/logging"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// rateLimitKeyPrefix namespaces rate limit windows in Redis
+	rateLimitKeyPrefix = "ratelimit:"
+
+	// rateLimitStoreTimeout bounds each Redis call so a slow or unreachable
+	// Redis falls back to memory instead of stalling requests
+	rateLimitStoreTimeout = 250 * time.Millisecond
+
+	// rateLimitAlertTimeout bounds raising an alert, which happens after the
+	// request has been answered
+	rateLimitAlertTimeout = 5 * time.Second
+
+	// memorySweepInterval is how often the memory store drops idle windows
+	memorySweepInterval = time.Minute
+)
+
+// RateLimitPolicy is a named limit of Requests in any sliding Window, and how
+// requests are attributed to the client they count against
+type RateLimitPolicy struct {
+	Name     string
+	Requests int
+	Window   time.Duration
+
+	// Key identifies the client a request counts against
+	Key func(c *gin.Context) string
+}
+
+// RateLimitKeyByIP counts requests against the client IP
+func RateLimitKeyByIP(c *gin.Context) string {
+	return "ip:" + c.ClientIP()
+}
+
+// RateLimitKeyByUser counts requests against the authenticated user, or the
+// client IP when the request is not authenticated
+func RateLimitKeyByUser(c *gin.Context) string {
+	if userID, ok := GetUserIDFromContext(c); ok {
+		return fmt.Sprintf("user:%d", userID)
+	}
+	return RateLimitKeyByIP(c)
+}
+
+// RateLimitResult is the outcome of counting a request
+type RateLimitResult struct {
+	Allowed bool
+	// Count is the number of requests in the window, including this one when allowed
+	Count int
+	// Remaining is how many more requests the window allows
+	Remaining int
+	// Reset is how long until the oldest request leaves the window
+	Reset time.Duration
+}
+
+// RateLimitStore counts requests in sliding windows
+type RateLimitStore interface {
+	Allow(ctx context.Context, key string, limit int, window time.Duration) (RateLimitResult, error)
+}
+
+// slidingWindowScript keeps a sorted set of request times per key, in Redis
+// server time so replicas with skewed clocks share one window. It drops
+// requests older than the window, then records this request if the window
+// has room.
+var slidingWindowScript = redis.NewScript(`
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000000 + tonumber(time[2])
+local window = tonumber(ARGV[1])
+local limit = tonumber(ARGV[2])
+
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+local count = redis.call('ZCARD', KEYS[1])
+local allowed = 0
+if count < limit then
+	redis.call('ZADD', KEYS[1], now, now .. '-' .. ARGV[3])
+	count = count + 1
+	allowed = 1
+end
+redis.call('PEXPIRE', KEYS[1], math.ceil(window / 1000))
+
+local reset = window
+local oldest = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+if oldest[2] then
+	reset = tonumber(oldest[2]) + window - now
+end
+return {allowed, count, reset}
+`)
+
+// RedisRateLimitStore keeps sliding windows in Redis, shared by every replica
+type RedisRateLimitStore struct {
+	client *redis.Client
+}
+
+// NewRedisRateLimitStore creates a rate limit store on Redis
+func NewRedisRateLimitStore(client *redis.Client) *RedisRateLimitStore {
+	return &RedisRateLimitStore{client: client}
+}
+
+// Allow counts a request against key's window
+func (s *RedisRateLimitStore) Allow(ctx context.Context, key string, limit int, window time.Duration) (RateLimitResult, error) {
+	values, err := slidingWindowScript.Run(ctx, s.client, []string{rateLimitKeyPrefix + key},
+		window.Microseconds(), limit, strconv.FormatUint(rand.Uint64(), 36)).Int64Slice()
+	if err != nil {
+		return RateLimitResult{}, fmt.Errorf("failed to count request: %w", err)
+	}
+	if len(values) != 3 {
+		return RateLimitResult{}, fmt.Errorf("unexpected rate limit script result %v", values)
+	}
+
+	count := int(values[1])
+	return RateLimitResult{
+		Allowed:   values[0] == 1,
+		Count:     count,
+		Remaining: max(limit-count, 0),
+		Reset:     time.Duration(values[2]) * time.Microsecond,
+	}, nil
+}
+
+// MemoryRateLimitStore keeps sliding windows in process memory. It backs the
+// limiter when Redis is not configured or not reachable.
+type MemoryRateLimitStore struct {
+	mutex     sync.Mutex
+	windows   map[string]*memoryWindow
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// memoryWindow holds the times of a key's requests, oldest first
+type memoryWindow struct {
+	requests []time.Time
+	window   time.Duration
+}
+
+// NewMemoryRateLimitStore creates an in-memory rate limit store
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		windows: make(map[string]*memoryWindow),
+		now:     time.Now,
+	}
+}
+
+// Allow counts a request against key's window
+func (s *MemoryRateLimitStore) Allow(_ context.Context, key string, limit int, window time.Duration) (RateLimitResult, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	w, exists := s.windows[key]
+	if !exists {
+		w = &memoryWindow{}
+		s.windows[key] = w
+	}
+	w.window = window
+	w.prune(now)
+
+	allowed := len(w.requests) < limit
+	if allowed {
+		w.requests = append(w.requests, now)
+	}
+
+	reset := window
+	if len(w.requests) > 0 {
+		reset = w.requests[0].Add(window).Sub(now)
+	}
+	return RateLimitResult{
+		Allowed:   allowed,
+		Count:     len(w.requests),
+		Remaining: max(limit-len(w.requests), 0),
+		Reset:     reset,
+	}, nil
+}
+
+// sweep drops windows with no requests left in them. Callers hold the mutex.
+func (s *MemoryRateLimitStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < memorySweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for key, w := range s.windows {
+		if w.prune(now); len(w.requests) == 0 {
+			delete(s.windows, key)
+		}
+	}
+}
+
+// prune drops requests that have left the window
+func (w *memoryWindow) prune(now time.Time) {
+	cutoff := now.Add(-w.window)
+	i := 0
+	for i < len(w.requests) && !w.requests[i].After(cutoff) {
+		i++
+	}
+	w.requests = w.requests[i:]
+}
+
+// RateLimitAlerter raises alerts when clients go over a rate limit
+type RateLimitAlerter interface {
+	RateLimitAlert(ctx context.Context, policy, identifier, ipAddress string, limit int, window time.Duration) error
+}
+
+// DistributedRateLimiter enforces rate limit policies shared by every replica.
+// Windows live in the store, normally Redis; while the store is failing they
+// are kept in memory, so limits hold per replica until it recovers.
+type DistributedRateLimiter struct {
+	store         RateLimitStore
+	fallback      *MemoryRateLimitStore
+	retryInterval time.Duration
+	logger        zerolog.Logger
+	auditLogger   *logging.AuditLogger
+	alerter       RateLimitAlerter
+
+	mutex          sync.Mutex
+	storeDownUntil time.Time
+	alerted        map[string]time.Time
+	now            func() time.Time
+}
+
+// DistributedRateLimiterOption configures a DistributedRateLimiter
+type DistributedRateLimiterOption func(*DistributedRateLimiter)
+
+// WithRateLimitAlerter raises an alert the first time a client goes over a
+// limit in each window
+func WithRateLimitAlerter(alerter RateLimitAlerter) DistributedRateLimiterOption {
+	return func(l *DistributedRateLimiter) {
+		l.alerter = alerter
+	}
+}
+
+// NewDistributedRateLimiter creates a rate limiter on store. A nil store keeps
+// every window in memory. After the store fails, memory is used for
+// retryInterval before the store is tried again.
+func NewDistributedRateLimiter(store RateLimitStore, retryInterval time.Duration, logger zerolog.Logger, opts ...DistributedRateLimiterOption) *DistributedRateLimiter {
+	l := &DistributedRateLimiter{
+		store:         store,
+		fallback:      NewMemoryRateLimitStore(),
+		retryInterval: retryInterval,
+		logger:        logger.With().Str("component", "rate_limiter").Logger(),
+		auditLogger:   logging.NewAuditLogger(logger),
+		alerted:       make(map[string]time.Time),
+		now:           time.Now,
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// Limit returns a middleware enforcing policy. It sets RateLimit-* headers
+// for the policy closest to its limit and answers 429 once a client is over.
+func (l *DistributedRateLimiter) Limit(policy RateLimitPolicy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := policy.Name + ":" + policy.Key(c)
+		result := l.allow(c.Request.Context(), key, policy)
+
+		setRateLimitHeaders(c, policy, result)
+
+		if !result.Allowed {
+			retryAfter := max(int(result.Reset.Round(time.Second).Seconds()), 1)
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+			l.reportViolation(c, key, policy, result)
+
+			c.JSON(http.StatusTooManyRequests, ErrorResponse{
+				Error:   "rate_limit_exceeded",
+				Message: "Too many requests. Please try again later.",
+				Code:    http.StatusTooManyRequests,
+				Details: map[string]string{
+					"policy":      policy.Name,
+					"retry_after": strconv.Itoa(retryAfter),
+					"limit":       strconv.Itoa(policy.Requests),
+					"window":      policy.Window.String(),
+				},
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// allow counts a request in the store, or in memory while the store is down
+func (l *DistributedRateLimiter) allow(ctx context.Context, key string, policy RateLimitPolicy) RateLimitResult {
+	if l.store != nil && l.storeAvailable() {
+		storeCtx, cancel := context.WithTimeout(ctx, rateLimitStoreTimeout)
+		result, err := l.store.Allow(storeCtx, key, policy.Requests, policy.Window)
+		cancel()
+		if err == nil {
+			return result
+		}
+
+		l.mutex.Lock()
+		l.storeDownUntil = l.now().Add(l.retryInterval)
+		l.mutex.Unlock()
+		l.logger.Warn().
+			Err(err).
+			Dur("retry_in", l.retryInterval).
+			Msg("Rate limit store unavailable, limiting in memory")
+	}
+
+	result, _ := l.fallback.Allow(ctx, key, policy.Requests, policy.Window)
+	return result
+}
+
+// storeAvailable reports whether the store should be tried
+func (l *DistributedRateLimiter) storeAvailable() bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return !l.now().Before(l.storeDownUntil)
+}
+
+// reportViolation writes every rejected request to the audit log and raises
+// an alert for the first one from each client in a window
+func (l *DistributedRateLimiter) reportViolation(c *gin.Context, key string, policy RateLimitPolicy, result RateLimitResult) {
+	endpoint := c.FullPath()
+	if endpoint == "" {
+		endpoint = c.Request.URL.Path
+	}
+	l.auditLogger.LogRateLimitExceeded(c.ClientIP(), endpoint, result.Count)
+
+	if l.alerter == nil || !l.firstViolation(key, policy.Window) {
+		return
+	}
+
+	identifier := policy.Key(c)
+	clientIP := c.ClientIP()
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), rateLimitAlertTimeout)
+		defer cancel()
+		if err := l.alerter.RateLimitAlert(ctx, policy.Name, identifier, clientIP, policy.Requests, policy.Window); err != nil {
+			l.logger.Warn().
+				Err(err).
+				Str("policy", policy.Name).
+				Msg("Failed to raise rate limit alert")
+		}
+	}()
+}
+
+// firstViolation reports whether key has not been alerted on within window,
+// and records that it now has
+func (l *DistributedRateLimiter) firstViolation(key string, window time.Duration) bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := l.now()
+	if until, ok := l.alerted[key]; ok && now.Before(until) {
+		return false
+	}
+
+	for k, until := range l.alerted {
+		if !now.Before(until) {
+			delete(l.alerted, k)
+		}
+	}
+	l.alerted[key] = now.Add(window)
+	return true
+}
+
+// setRateLimitHeaders sets the RateLimit-Limit, RateLimit-Remaining and
+// RateLimit-Reset headers, keeping those of an earlier policy on the route
+// with fewer requests remaining. RateLimit-Policy lists every policy.
+func setRateLimitHeaders(c *gin.Context, policy RateLimitPolicy, result RateLimitResult) {
+	header := c.Writer.Header()
+	header.Add("RateLimit-Policy", fmt.Sprintf("%d;w=%d", policy.Requests, int(policy.Window.Seconds())))
+
+	if current := header.Get("RateLimit-Remaining"); current != "" {
+		if remaining, err := strconv.Atoi(current); err == nil && remaining <= result.Remaining {
+			return
+		}
+	}
+	header.Set("RateLimit-Limit", strconv.Itoa(policy.Requests))
+	header.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	header.Set("RateLimit-Reset", strconv.Itoa(int(result.Reset.Round(time.Second).Seconds())))
+}
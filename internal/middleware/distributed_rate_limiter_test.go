@@ -0,0 +1,211 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingRateLimitStore stands in for an unreachable Redis
+type failingRateLimitStore struct {
+	mutex sync.Mutex
+	calls int
+}
+
+func (s *failingRateLimitStore) Allow(ctx context.Context, key string, limit int, window time.Duration) (RateLimitResult, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.calls++
+	return RateLimitResult{}, errors.New("connection refused")
+}
+
+func (s *failingRateLimitStore) Calls() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.calls
+}
+
+// recordingRateLimitAlerter records the alerts raised
+type recordingRateLimitAlerter struct {
+	alerts chan string
+}
+
+func (a *recordingRateLimitAlerter) RateLimitAlert(ctx context.Context, policy, identifier, ipAddress string, limit int, window time.Duration) error {
+	a.alerts <- policy + " " + identifier
+	return nil
+}
+
+func setupRateLimitedRouter(limiter *DistributedRateLimiter, policies ...RateLimitPolicy) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handlers := make([]gin.HandlerFunc, 0, len(policies)+1)
+	for _, policy := range policies {
+		handlers = append(handlers, limiter.Limit(policy))
+	}
+	handlers = append(handlers, func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.POST("/auth/login", handlers...)
+	return router
+}
+
+func sendLogin(router *gin.Engine, ip string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/auth/login", nil)
+	req.RemoteAddr = ip + ":1234"
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestMemoryRateLimitStore_SlidingWindow(t *testing.T) {
+	store := NewMemoryRateLimitStore()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		result, err := store.Allow(ctx, "login:ip:10.0.0.1", 3, time.Minute)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 2-i, result.Remaining)
+		now = now.Add(10 * time.Second)
+	}
+
+	result, _ := store.Allow(ctx, "login:ip:10.0.0.1", 3, time.Minute)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 3, result.Count)
+	assert.Equal(t, 30*time.Second, result.Reset, "the first request leaves the window a minute after it was made")
+
+	other, _ := store.Allow(ctx, "login:ip:10.0.0.2", 3, time.Minute)
+	assert.True(t, other.Allowed, "other clients have their own window")
+
+	now = now.Add(30 * time.Second)
+	result, _ = store.Allow(ctx, "login:ip:10.0.0.1", 3, time.Minute)
+	assert.True(t, result.Allowed, "the window slides as old requests leave it")
+}
+
+func TestDistributedRateLimiter_Limit(t *testing.T) {
+	limiter := NewDistributedRateLimiter(nil, time.Second, zerolog.Nop())
+	policy := RateLimitPolicy{Name: "login", Requests: 2, Window: time.Minute, Key: RateLimitKeyByIP}
+	router := setupRateLimitedRouter(limiter, policy)
+
+	w := sendLogin(router, "10.0.0.1")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "2", w.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "1", w.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "60", w.Header().Get("RateLimit-Reset"))
+	assert.Equal(t, "2;w=60", w.Header().Get("RateLimit-Policy"))
+
+	assert.Equal(t, http.StatusOK, sendLogin(router, "10.0.0.1").Code)
+
+	w = sendLogin(router, "10.0.0.1")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "60", w.Header().Get("Retry-After"))
+	assert.Contains(t, w.Body.String(), `"error":"rate_limit_exceeded"`)
+
+	assert.Equal(t, http.StatusOK, sendLogin(router, "10.0.0.2").Code)
+}
+
+func TestDistributedRateLimiter_TightestPolicyHeaders(t *testing.T) {
+	limiter := NewDistributedRateLimiter(nil, time.Second, zerolog.Nop())
+	router := setupRateLimitedRouter(limiter,
+		RateLimitPolicy{Name: "global", Requests: 100, Window: time.Minute, Key: RateLimitKeyByIP},
+		RateLimitPolicy{Name: "login", Requests: 5, Window: time.Minute, Key: RateLimitKeyByIP},
+	)
+
+	w := sendLogin(router, "10.0.0.1")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "5", w.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "4", w.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, []string{"100;w=60", "5;w=60"}, w.Header().Values("RateLimit-Policy"))
+}
+
+func TestDistributedRateLimiter_FallsBackToMemory(t *testing.T) {
+	store := &failingRateLimitStore{}
+	limiter := NewDistributedRateLimiter(store, time.Minute, zerolog.Nop())
+	router := setupRateLimitedRouter(limiter, RateLimitPolicy{Name: "login", Requests: 1, Window: time.Minute, Key: RateLimitKeyByIP})
+
+	assert.Equal(t, http.StatusOK, sendLogin(router, "10.0.0.1").Code)
+	assert.Equal(t, http.StatusTooManyRequests, sendLogin(router, "10.0.0.1").Code, "limits hold while the store is down")
+	assert.Equal(t, 1, store.Calls(), "the store is not retried until the retry interval passes")
+
+	limiter.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	sendLogin(router, "10.0.0.1")
+	assert.Equal(t, 2, store.Calls())
+}
+
+func TestDistributedRateLimiter_AlertsOncePerWindow(t *testing.T) {
+	alerter := &recordingRateLimitAlerter{alerts: make(chan string, 10)}
+	limiter := NewDistributedRateLimiter(nil, time.Second, zerolog.Nop(), WithRateLimitAlerter(alerter))
+	router := setupRateLimitedRouter(limiter, RateLimitPolicy{Name: "login", Requests: 1, Window: time.Minute, Key: RateLimitKeyByIP})
+
+	for i := 0; i < 4; i++ {
+		sendLogin(router, "10.0.0.1")
+	}
+
+	select {
+	case alert := <-alerter.alerts:
+		assert.Equal(t, "login ip:10.0.0.1", alert)
+	case <-time.After(time.Second):
+		t.Fatal("no alert was raised")
+	}
+	select {
+	case alert := <-alerter.alerts:
+		t.Fatalf("unexpected second alert %q", alert)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestRateLimitKeyByUser(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request, _ = http.NewRequest(http.MethodPost, "/transfers", nil)
+	c.Request.RemoteAddr = "10.0.0.1:1234"
+
+	assert.Equal(t, "ip:10.0.0.1", RateLimitKeyByUser(c))
+	c.Set("user_id", 42)
+	assert.Equal(t, "user:42", RateLimitKeyByUser(c))
+}
+
+func TestRedisRateLimitStore(t *testing.T) {
+	addr := os.Getenv("REDIS_ADDR")
+	if addr == "" {
+		addr = "localhost:6379"
+	}
+	client := redis.NewClient(&redis.Options{Addr: addr, DB: 15})
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		t.Skip("Redis not available for testing")
+	}
+	key := "test:" + t.Name()
+	client.Del(ctx, rateLimitKeyPrefix+key)
+	defer client.Del(context.Background(), rateLimitKeyPrefix+key)
+
+	store := NewRedisRateLimitStore(client)
+	for i := 0; i < 2; i++ {
+		result, err := store.Allow(ctx, key, 2, time.Minute)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 1-i, result.Remaining)
+	}
+
+	result, err := store.Allow(ctx, key, 2, time.Minute)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 2, result.Count)
+	assert.InDelta(t, time.Minute, result.Reset, float64(5*time.Second))
+}
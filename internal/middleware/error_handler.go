@@ -259,7 +259,7 @@ func ErrorHandler(errorLogger *logging.ErrorLogger) gin.HandlerFunc {
 			
 			// Log the error with structured context
 			if errorLogger != nil {
-				errorLogger.LogError(appErr.Err, errorCtx)
+				errorLogger.LogError(appErr, errorCtx)
 			}
 
 			// Don't override status if it's already set
@@ -381,7 +381,7 @@ func HandleErrorWithLogging(c *gin.Context, err error, errorLogger *logging.Erro
 	
 	// Log the error with structured context
 	if errorLogger != nil {
-		errorLogger.LogError(appErr.Err, errorCtx)
+		errorLogger.LogError(appErr, errorCtx)
 	}
 
 	c.JSON(appErr.Code, ErrorResponse{
@@ -10,7 +10,10 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-// RateLimiter represents a rate limiter with configurable limits
+// RateLimiter represents a rate limiter with configurable limits.
+//
+// Deprecated: RateLimiter counts requests per process, so limits multiply
+// with the number of replicas and reset on restart. Use DistributedRateLimiter.
 type RateLimiter struct {
 	requests map[string]*ClientInfo
 	mutex    sync.RWMutex
@@ -153,7 +156,9 @@ func (rl *RateLimiter) cleanupRoutine(blockDuration time.Duration) {
 	}
 }
 
-// RateLimit returns a rate limiting middleware
+// RateLimit returns a rate limiting middleware.
+//
+// Deprecated: use DistributedRateLimiter.Limit with RateLimitKeyByUser.
 func RateLimit(config RateLimiterConfig) gin.HandlerFunc {
 	limiter := NewRateLimiter(config)
 
@@ -195,7 +200,9 @@ func RateLimit(config RateLimiterConfig) gin.HandlerFunc {
 	}
 }
 
-// RateLimitByIP returns a rate limiting middleware that limits by IP address only
+// RateLimitByIP returns a rate limiting middleware that limits by IP address only.
+//
+// Deprecated: use DistributedRateLimiter.Limit with RateLimitKeyByIP.
 func RateLimitByIP(config RateLimiterConfig) gin.HandlerFunc {
 	limiter := NewRateLimiter(config)
 
@@ -185,6 +185,9 @@ type Transfer struct {
 	Description   string          `json:"description" db:"description"`
 	Status        string          `json:"status" db:"status"`
 	CreatedAt     time.Time       `json:"created_at" db:"created_at"`
+	RiskScore     *int            `json:"risk_score,omitempty" db:"risk_score"`
+	RiskDecision  string          `json:"risk_decision,omitempty" db:"risk_decision"`
+	Fee           decimal.Decimal `json:"fee" db:"fee"`
 }
 
 // Transfer validation errors
@@ -323,4 +326,172 @@ func (t *Transfer) MarkCompleted() {
 // MarkFailed marks the transfer as failed
 func (t *Transfer) MarkFailed() {
 	t.Status = "failed"
-}
\ No newline at end of file
+}
+
+// Fee schedule types
+const (
+	FeeTypeFlat       = "flat"
+	FeeTypePercentage = "percentage"
+	FeeTypeTiered     = "tiered"
+)
+
+// FeeSchedule describes how transfer fees are charged for a currency
+type FeeSchedule struct {
+	ID             int              `json:"id" db:"id"`
+	Name           string           `json:"name" db:"name"`
+	Currency       string           `json:"currency" db:"currency"`
+	FeeType        string           `json:"fee_type" db:"fee_type"`
+	FlatAmount     decimal.Decimal  `json:"flat_amount" db:"flat_amount"`
+	Percentage     decimal.Decimal  `json:"percentage" db:"percentage"`
+	MinFee         *decimal.Decimal `json:"min_fee,omitempty" db:"min_fee"`
+	MaxFee         *decimal.Decimal `json:"max_fee,omitempty" db:"max_fee"`
+	Tiers          []FeeTier        `json:"tiers,omitempty" db:"tiers"`
+	HouseAccountID int              `json:"house_account_id" db:"house_account_id"`
+	IsActive       bool             `json:"is_active" db:"is_active"`
+	CreatedAt      time.Time        `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time        `json:"updated_at" db:"updated_at"`
+}
+
+// FeeTier is one band of a tiered fee schedule. A nil UpTo makes the band
+// open-ended and is only allowed on the last tier.
+type FeeTier struct {
+	UpTo       *decimal.Decimal `json:"up_to,omitempty"`
+	FlatAmount decimal.Decimal  `json:"flat_amount"`
+	Percentage decimal.Decimal  `json:"percentage"`
+}
+
+// Fee schedule validation errors
+var (
+	ErrInvalidFeeType       = errors.New("fee type must be flat, percentage or tiered")
+	ErrInvalidFeeName       = errors.New("fee schedule name is required")
+	ErrNegativeFee          = errors.New("fee amounts cannot be negative")
+	ErrInvalidFeePercentage = errors.New("fee percentage must be between 0 and 100")
+	ErrInvalidFeeBounds     = errors.New("minimum fee cannot exceed maximum fee")
+	ErrInvalidFeeTiers      = errors.New("fee tiers must have increasing upper bounds with only the last tier open-ended")
+	ErrInvalidHouseAccount  = errors.New("house account ID must be positive")
+)
+
+var validFeeTypes = map[string]bool{
+	FeeTypeFlat:       true,
+	FeeTypePercentage: true,
+	FeeTypeTiered:     true,
+}
+
+var hundred = decimal.NewFromInt(100)
+
+// Validate validates the fee schedule configuration
+func (f *FeeSchedule) Validate() error {
+	if strings.TrimSpace(f.Name) == "" {
+		return ErrInvalidFeeName
+	}
+
+	account := Account{Currency: f.Currency}
+	if err := account.ValidateCurrency(); err != nil {
+		return err
+	}
+	f.Currency = account.Currency
+
+	if !validFeeTypes[f.FeeType] {
+		return ErrInvalidFeeType
+	}
+
+	if err := validateFeeComponents(f.FlatAmount, f.Percentage); err != nil {
+		return err
+	}
+
+	if (f.MinFee != nil && f.MinFee.IsNegative()) || (f.MaxFee != nil && f.MaxFee.IsNegative()) {
+		return ErrNegativeFee
+	}
+	if f.MinFee != nil && f.MaxFee != nil && f.MinFee.GreaterThan(*f.MaxFee) {
+		return ErrInvalidFeeBounds
+	}
+
+	if f.HouseAccountID <= 0 {
+		return ErrInvalidHouseAccount
+	}
+
+	if f.FeeType == FeeTypeTiered {
+		return f.validateTiers()
+	}
+
+	return nil
+}
+
+// validateTiers checks that tiers are ordered and only the last is open-ended
+func (f *FeeSchedule) validateTiers() error {
+	if len(f.Tiers) == 0 {
+		return ErrInvalidFeeTiers
+	}
+
+	var previous *decimal.Decimal
+	for i, tier := range f.Tiers {
+		if err := validateFeeComponents(tier.FlatAmount, tier.Percentage); err != nil {
+			return err
+		}
+
+		if tier.UpTo == nil {
+			if i != len(f.Tiers)-1 {
+				return ErrInvalidFeeTiers
+			}
+			continue
+		}
+
+		if !tier.UpTo.IsPositive() || (previous != nil && !tier.UpTo.GreaterThan(*previous)) {
+			return ErrInvalidFeeTiers
+		}
+		previous = tier.UpTo
+	}
+
+	return nil
+}
+
+// validateFeeComponents checks a flat amount and percentage pair
+func validateFeeComponents(flatAmount, percentage decimal.Decimal) error {
+	if flatAmount.IsNegative() {
+		return ErrNegativeFee
+	}
+	if percentage.IsNegative() || percentage.GreaterThan(hundred) {
+		return ErrInvalidFeePercentage
+	}
+	return nil
+}
+
+// Calculate returns the fee charged on a transfer of amount, clamped to the
+// schedule's minimum and maximum and rounded to two decimal places
+func (f *FeeSchedule) Calculate(amount decimal.Decimal) decimal.Decimal {
+	var fee decimal.Decimal
+	switch f.FeeType {
+	case FeeTypeFlat:
+		fee = f.FlatAmount
+	case FeeTypePercentage:
+		fee = amount.Mul(f.Percentage).Div(hundred)
+	case FeeTypeTiered:
+		tier := f.tierFor(amount)
+		fee = tier.FlatAmount.Add(amount.Mul(tier.Percentage).Div(hundred))
+	}
+
+	if f.MinFee != nil && fee.LessThan(*f.MinFee) {
+		fee = *f.MinFee
+	}
+	if f.MaxFee != nil && fee.GreaterThan(*f.MaxFee) {
+		fee = *f.MaxFee
+	}
+
+	return fee.Round(2)
+}
+
+// tierFor returns the first tier whose upper bound covers amount, falling
+// back to the last tier for amounts above every bound
+func (f *FeeSchedule) tierFor(amount decimal.Decimal) FeeTier {
+	if len(f.Tiers) == 0 {
+		return FeeTier{}
+	}
+
+	for _, tier := range f.Tiers {
+		if tier.UpTo == nil || amount.LessThanOrEqual(*tier.UpTo) {
+			return tier
+		}
+	}
+
+	return f.Tiers[len(f.Tiers)-1]
+}
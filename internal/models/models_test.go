@@ -969,4 +969,122 @@ func TestTransfer_StatusMethods(t *testing.T) {
 		transfer.MarkFailed()
 		assert.Equal(t, "failed", transfer.Status)
 	})
-}
\ No newline at end of file
+}
+func decimalPtr(value string) *decimal.Decimal {
+	d := decimal.RequireFromString(value)
+	return &d
+}
+
+func TestFeeSchedule_Calculate(t *testing.T) {
+	tiers := []FeeTier{
+		{UpTo: decimalPtr("100"), FlatAmount: decimal.RequireFromString("0.50")},
+		{UpTo: decimalPtr("1000"), FlatAmount: decimal.RequireFromString("1.00"), Percentage: decimal.RequireFromString("0.5")},
+		{Percentage: decimal.RequireFromString("0.25")},
+	}
+
+	tests := []struct {
+		name     string
+		schedule FeeSchedule
+		amount   string
+		expected string
+	}{
+		{
+			name:     "flat fee",
+			schedule: FeeSchedule{FeeType: FeeTypeFlat, FlatAmount: decimal.RequireFromString("2.50")},
+			amount:   "1000",
+			expected: "2.50",
+		},
+		{
+			name:     "percentage fee rounded to cents",
+			schedule: FeeSchedule{FeeType: FeeTypePercentage, Percentage: decimal.RequireFromString("1.5")},
+			amount:   "123.45",
+			expected: "1.85",
+		},
+		{
+			name:     "percentage fee raised to minimum",
+			schedule: FeeSchedule{FeeType: FeeTypePercentage, Percentage: decimal.RequireFromString("1"), MinFee: decimalPtr("1.00")},
+			amount:   "10",
+			expected: "1.00",
+		},
+		{
+			name:     "percentage fee capped at maximum",
+			schedule: FeeSchedule{FeeType: FeeTypePercentage, Percentage: decimal.RequireFromString("1"), MaxFee: decimalPtr("25.00")},
+			amount:   "10000",
+			expected: "25.00",
+		},
+		{
+			name:     "first tier",
+			schedule: FeeSchedule{FeeType: FeeTypeTiered, Tiers: tiers},
+			amount:   "100",
+			expected: "0.50",
+		},
+		{
+			name:     "middle tier combines flat and percentage",
+			schedule: FeeSchedule{FeeType: FeeTypeTiered, Tiers: tiers},
+			amount:   "500",
+			expected: "3.50",
+		},
+		{
+			name:     "open-ended tier",
+			schedule: FeeSchedule{FeeType: FeeTypeTiered, Tiers: tiers},
+			amount:   "5000",
+			expected: "12.50",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fee := tt.schedule.Calculate(decimal.RequireFromString(tt.amount))
+			assert.Equal(t, tt.expected, fee.StringFixed(2))
+		})
+	}
+}
+
+func TestFeeSchedule_Validate(t *testing.T) {
+	valid := func() FeeSchedule {
+		return FeeSchedule{
+			Name:           "Standard USD",
+			Currency:       "usd",
+			FeeType:        FeeTypePercentage,
+			Percentage:     decimal.RequireFromString("1"),
+			HouseAccountID: 1,
+		}
+	}
+
+	t.Run("valid schedule normalizes currency", func(t *testing.T) {
+		schedule := valid()
+		require.NoError(t, schedule.Validate())
+		assert.Equal(t, "USD", schedule.Currency)
+	})
+
+	tests := []struct {
+		name    string
+		modify  func(*FeeSchedule)
+		wantErr error
+	}{
+		{"missing name", func(f *FeeSchedule) { f.Name = " " }, ErrInvalidFeeName},
+		{"invalid currency", func(f *FeeSchedule) { f.Currency = "XXX" }, ErrInvalidCurrency},
+		{"invalid type", func(f *FeeSchedule) { f.FeeType = "bogus" }, ErrInvalidFeeType},
+		{"negative flat amount", func(f *FeeSchedule) { f.FlatAmount = decimal.NewFromInt(-1) }, ErrNegativeFee},
+		{"percentage above 100", func(f *FeeSchedule) { f.Percentage = decimal.NewFromInt(101) }, ErrInvalidFeePercentage},
+		{"min above max", func(f *FeeSchedule) { f.MinFee, f.MaxFee = decimalPtr("5"), decimalPtr("1") }, ErrInvalidFeeBounds},
+		{"missing house account", func(f *FeeSchedule) { f.HouseAccountID = 0 }, ErrInvalidHouseAccount},
+		{"tiered without tiers", func(f *FeeSchedule) { f.FeeType = FeeTypeTiered }, ErrInvalidFeeTiers},
+		{"open-ended tier before last", func(f *FeeSchedule) {
+			f.FeeType = FeeTypeTiered
+			f.Tiers = []FeeTier{{}, {UpTo: decimalPtr("100")}}
+		}, ErrInvalidFeeTiers},
+		{"decreasing tier bounds", func(f *FeeSchedule) {
+			f.FeeType = FeeTypeTiered
+			f.Tiers = []FeeTier{{UpTo: decimalPtr("100")}, {UpTo: decimalPtr("50")}}
+		}, ErrInvalidFeeTiers},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule := valid()
+			tt.modify(&schedule)
+			assert.Equal(t, tt.wantErr, schedule.Validate())
+		})
+	}
+}
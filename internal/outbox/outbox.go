@@ -0,0 +1,87 @@
+// Package outbox implements the transactional outbox. Tasks and domain events
+// are written to the outbox table in the same transaction as the change they
+// describe, and a relay delivers them to the task queue and the event bus once
+// that transaction has committed. Delivery is at least once, in order for the
+// messages of one aggregate.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/phantom-sage/bankgo/internal/database/queries"
+	"github.com/phantom-sage/bankgo/internal/events"
+)
+
+// Message destinations
+const (
+	// DestinationTask messages are enqueued as asynq tasks
+	DestinationTask = "task"
+	// DestinationEvent messages are published on the event bus
+	DestinationEvent = "event"
+)
+
+// Aggregate types messages are ordered by
+const (
+	AggregateUser            = "user"
+	AggregateAccount         = "account"
+	AggregateWebhookEndpoint = "webhook_endpoint"
+)
+
+// Message is a task or event waiting in the outbox
+type Message struct {
+	AggregateType string
+	AggregateID   string
+	Destination   string
+	// Type is the task type or event type
+	Type    string
+	Payload []byte
+}
+
+// Task builds a message enqueueing a task of taskType with payload
+func Task(aggregateType, aggregateID, taskType string, payload interface{}) (Message, error) {
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return Message{}, fmt.Errorf("failed to encode %s task: %w", taskType, err)
+	}
+	return Message{
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		Destination:   DestinationTask,
+		Type:          taskType,
+		Payload:       encoded,
+	}, nil
+}
+
+// Event builds a message publishing event on the event bus
+func Event(aggregateType, aggregateID string, event events.Event) (Message, error) {
+	encoded, err := json.Marshal(event)
+	if err != nil {
+		return Message{}, fmt.Errorf("failed to encode %s event: %w", event.Type, err)
+	}
+	return Message{
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		Destination:   DestinationEvent,
+		Type:          event.Type,
+		Payload:       encoded,
+	}, nil
+}
+
+// Add writes a message to the outbox. q should be bound to the transaction of
+// the change the message describes, as passed to repository.WithTx, so the
+// message is delivered if and only if the change commits.
+func Add(ctx context.Context, q *queries.Queries, message Message) error {
+	err := q.CreateOutboxMessage(ctx, queries.CreateOutboxMessageParams{
+		AggregateType: message.AggregateType,
+		AggregateID:   message.AggregateID,
+		Destination:   message.Destination,
+		MessageType:   message.Type,
+		Payload:       message.Payload,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write %s to outbox: %w", message.Type, err)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package outbox
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/phantom-sage/bankgo/internal/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTask(t *testing.T) {
+	message, err := Task(AggregateUser, "7", "email:welcome", map[string]interface{}{"user_id": 7})
+	require.NoError(t, err)
+
+	assert.Equal(t, AggregateUser, message.AggregateType)
+	assert.Equal(t, "7", message.AggregateID)
+	assert.Equal(t, DestinationTask, message.Destination)
+	assert.Equal(t, "email:welcome", message.Type)
+	assert.JSONEq(t, `{"user_id":7}`, string(message.Payload))
+}
+
+func TestTask_UnencodablePayload(t *testing.T) {
+	_, err := Task(AggregateUser, "7", "email:welcome", make(chan int))
+	assert.Error(t, err)
+}
+
+func TestEvent(t *testing.T) {
+	event, err := events.New(events.TypeAccountCreated, events.AccountPayload{AccountID: 3, UserID: 7, Currency: "USD"})
+	require.NoError(t, err)
+
+	message, err := Event(AggregateAccount, "3", event)
+	require.NoError(t, err)
+
+	assert.Equal(t, DestinationEvent, message.Destination)
+	assert.Equal(t, events.TypeAccountCreated, message.Type)
+
+	// The relay publishes the stored event as it was staged
+	var stored events.Event
+	require.NoError(t, json.Unmarshal(message.Payload, &stored))
+	assert.Equal(t, event.Type, stored.Type)
+	assert.True(t, event.OccurredAt.Equal(stored.OccurredAt))
+
+	var payload events.AccountPayload
+	require.NoError(t, stored.Decode(&payload))
+	assert.Equal(t, int32(3), payload.AccountID)
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, time.Second, retryDelay(1))
+	assert.Equal(t, 2*time.Second, retryDelay(2))
+	assert.Equal(t, 256*time.Second, retryDelay(9))
+	assert.Equal(t, maxRetryDelay, retryDelay(10))
+	assert.Equal(t, maxRetryDelay, retryDelay(1000))
+}
@@ -0,0 +1,245 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/phantom-sage/bankgo/internal/config"
+	"github.com/phantom-sage/bankgo/internal/database/queries"
+	"github.com/phantom-sage/bankgo/internal/events"
+	"github.com/phantom-sage/bankgo/internal/repository"
+	"github.com/rs/zerolog"
+)
+
+// maxRetryDelay caps the backoff between delivery attempts of a message
+const maxRetryDelay = 5 * time.Minute
+
+// cleanupBatchSize bounds each delete so cleanup never holds long locks
+const cleanupBatchSize = 1000
+
+// defaultMaxAttempts applies when no attempt limit is configured
+const defaultMaxAttempts = 20
+
+// TaskEnqueuer enqueues tasks relayed from the outbox. taskID identifies the
+// message, so a task relayed twice is only enqueued once while the queue
+// still remembers it.
+type TaskEnqueuer interface {
+	EnqueueTask(ctx context.Context, taskType string, payload []byte, taskID string) error
+}
+
+// Relay delivers outbox messages and deletes them once past retention
+type Relay struct {
+	repo   *repository.Repository
+	tasks  TaskEnqueuer
+	events events.Publisher
+	cfg    config.OutboxConfig
+	logger zerolog.Logger
+}
+
+// NewRelay creates a relay delivering tasks to tasks and events to publisher
+func NewRelay(repo *repository.Repository, tasks TaskEnqueuer, publisher events.Publisher, cfg config.OutboxConfig, logger zerolog.Logger) *Relay {
+	return &Relay{
+		repo:   repo,
+		tasks:  tasks,
+		events: publisher,
+		cfg:    cfg,
+		logger: logger.With().Str("component", "outbox_relay").Logger(),
+	}
+}
+
+// Start relays messages and cleans up delivered ones in the background until
+// ctx is done. Relays of several processes can run side by side.
+func (r *Relay) Start(ctx context.Context) {
+	go r.relayLoop(ctx)
+	go r.cleanupLoop(ctx)
+}
+
+// relayLoop relays batches back to back while there are messages, and polls
+// when the outbox is empty
+func (r *Relay) relayLoop(ctx context.Context) {
+	for ctx.Err() == nil {
+		claimed, err := r.RelayPending(ctx)
+		if err != nil && ctx.Err() == nil {
+			r.logger.Error().Err(err).Msg("Failed to relay outbox messages")
+		}
+		if err == nil && claimed > 0 {
+			continue
+		}
+		sleep(ctx, r.cfg.PollInterval)
+	}
+}
+
+// cleanupLoop deletes delivered messages past retention every cleanup interval
+func (r *Relay) cleanupLoop(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.CleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := r.Cleanup(ctx)
+			if err != nil {
+				r.logger.Error().Err(err).Msg("Failed to clean up outbox")
+				continue
+			}
+			if deleted > 0 {
+				r.logger.Info().Int64("deleted", deleted).Msg("Cleaned up delivered outbox messages")
+			}
+		}
+	}
+}
+
+// RelayPending claims a batch of messages, delivers them and records the
+// outcome, returning how many it claimed. Messages stay locked until the
+// outcome commits, so a crash in between delivers them again. A message that
+// fails its last allowed attempt is parked, and the aggregate's later
+// messages are relayed without it.
+func (r *Relay) RelayPending(ctx context.Context) (int, error) {
+	claimed := 0
+	err := r.repo.WithTx(ctx, func(qtx *queries.Queries) error {
+		messages, err := qtx.ClaimOutboxMessages(ctx, int32(r.cfg.BatchSize))
+		if err != nil {
+			return fmt.Errorf("failed to claim outbox messages: %w", err)
+		}
+		claimed = len(messages)
+
+		delivered := make([]int64, 0, len(messages))
+		for _, message := range messages {
+			if err := r.deliver(ctx, message); err != nil {
+				if message.Attempts+1 >= int32(r.maxAttempts()) {
+					if err := r.park(ctx, qtx, message, err); err != nil {
+						return err
+					}
+					continue
+				}
+
+				delay := retryDelay(message.Attempts + 1)
+				r.logger.Warn().
+					Err(err).
+					Int64("message_id", message.ID).
+					Str("message_type", message.MessageType).
+					Int32("attempts", message.Attempts+1).
+					Dur("retry_in", delay).
+					Msg("Failed to relay outbox message")
+
+				err = qtx.RecordOutboxFailure(ctx, queries.RecordOutboxFailureParams{
+					LastError:         pgtype.Text{String: err.Error(), Valid: true},
+					RetryAfterSeconds: int32(delay / time.Second),
+					ID:                message.ID,
+				})
+				if err != nil {
+					return fmt.Errorf("failed to record outbox failure: %w", err)
+				}
+				continue
+			}
+			delivered = append(delivered, message.ID)
+		}
+
+		if len(delivered) == 0 {
+			return nil
+		}
+		if err := qtx.MarkOutboxMessagesDelivered(ctx, delivered); err != nil {
+			return fmt.Errorf("failed to mark outbox messages delivered: %w", err)
+		}
+		return nil
+	})
+	return claimed, err
+}
+
+// park marks a message dead after its last failed attempt. Parked messages
+// are kept for inspection and must be replayed by hand.
+func (r *Relay) park(ctx context.Context, qtx *queries.Queries, message queries.Outbox, cause error) error {
+	r.logger.Error().
+		Err(cause).
+		Int64("message_id", message.ID).
+		Str("message_type", message.MessageType).
+		Str("aggregate_type", message.AggregateType).
+		Str("aggregate_id", message.AggregateID).
+		Int32("attempts", message.Attempts+1).
+		Msg("Parked outbox message after its last delivery attempt")
+
+	err := qtx.ParkOutboxMessage(ctx, queries.ParkOutboxMessageParams{
+		LastError: pgtype.Text{String: cause.Error(), Valid: true},
+		ID:        message.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to park outbox message: %w", err)
+	}
+	return nil
+}
+
+// maxAttempts returns how many attempts a message gets before it is parked
+func (r *Relay) maxAttempts() int {
+	if r.cfg.MaxAttempts > 0 {
+		return r.cfg.MaxAttempts
+	}
+	return defaultMaxAttempts
+}
+
+// Cleanup deletes delivered messages older than the retention period
+func (r *Relay) Cleanup(ctx context.Context) (int64, error) {
+	var total int64
+	for {
+		deleted, err := r.repo.DeleteDeliveredOutboxMessages(ctx, queries.DeleteDeliveredOutboxMessagesParams{
+			RetentionSeconds: int32(r.cfg.Retention / time.Second),
+			Limit:            cleanupBatchSize,
+		})
+		if err != nil {
+			return total, fmt.Errorf("failed to delete delivered outbox messages: %w", err)
+		}
+		total += deleted
+		if deleted < cleanupBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// deliver hands one message to its destination
+func (r *Relay) deliver(ctx context.Context, message queries.Outbox) error {
+	switch message.Destination {
+	case DestinationTask:
+		if r.tasks == nil {
+			return errors.New("no task queue to relay to")
+		}
+		return r.tasks.EnqueueTask(ctx, message.MessageType, message.Payload, fmt.Sprintf("outbox-%d", message.ID))
+	case DestinationEvent:
+		if r.events == nil {
+			return errors.New("no event bus to relay to")
+		}
+		var event events.Event
+		if err := json.Unmarshal(message.Payload, &event); err != nil {
+			return fmt.Errorf("failed to decode outbox event: %w", err)
+		}
+		return r.events.Publish(ctx, event)
+	default:
+		return fmt.Errorf("unknown outbox destination %q", message.Destination)
+	}
+}
+
+// retryDelay backs off exponentially from one second up to maxRetryDelay
+func retryDelay(attempts int32) time.Duration {
+	if attempts > 9 {
+		return maxRetryDelay
+	}
+	delay := time.Duration(1<<uint(attempts-1)) * time.Second
+	if delay > maxRetryDelay {
+		return maxRetryDelay
+	}
+	return delay
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
@@ -0,0 +1,171 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/phantom-sage/bankgo/internal/config"
+	"github.com/phantom-sage/bankgo/internal/database"
+	"github.com/phantom-sage/bankgo/internal/database/queries"
+	"github.com/phantom-sage/bankgo/internal/events"
+	"github.com/phantom-sage/bankgo/internal/repository"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEnqueuer records enqueued task IDs and fails while failing is set
+type fakeEnqueuer struct {
+	mu      sync.Mutex
+	failing bool
+	taskIDs []string
+}
+
+func (f *fakeEnqueuer) EnqueueTask(ctx context.Context, taskType string, payload []byte, taskID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.failing {
+		return errors.New("queue unavailable")
+	}
+	f.taskIDs = append(f.taskIDs, taskID)
+	return nil
+}
+
+// TestRelay_DeliversInOrderPerAggregate relays messages of one aggregate
+// through a failing and then recovered queue against a migrated database in
+// TEST_DATABASE_URL
+func TestRelay_DeliversInOrderPerAggregate(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" || testing.Short() {
+		t.Skip("Skipping outbox relay database test: TEST_DATABASE_URL not set")
+	}
+
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, dsn)
+	require.NoError(t, err)
+	defer pool.Close()
+
+	logger := zerolog.Nop()
+	repo := repository.New(&database.DB{Pool: pool}, logger)
+	tasks := &fakeEnqueuer{failing: true}
+	bus := events.NewMemoryBus()
+	relay := NewRelay(repo, tasks, bus, config.OutboxConfig{BatchSize: 100, Retention: time.Hour}, logger)
+
+	var published []string
+	require.NoError(t, bus.Consume(ctx, "test", func(ctx context.Context, event events.Event) error {
+		published = append(published, event.Type)
+		return nil
+	}))
+
+	aggregateID := fmt.Sprintf("relay-%d", time.Now().UnixNano())
+	task, err := Task(AggregateUser, aggregateID, "test:task", map[string]string{"id": aggregateID})
+	require.NoError(t, err)
+	event, err := events.New(events.TypeUserRegistered, events.UserPayload{UserID: 1})
+	require.NoError(t, err)
+	eventMessage, err := Event(AggregateUser, aggregateID, event)
+	require.NoError(t, err)
+
+	require.NoError(t, repo.WithTx(ctx, func(qtx *queries.Queries) error {
+		if err := Add(ctx, qtx, task); err != nil {
+			return err
+		}
+		return Add(ctx, qtx, eventMessage)
+	}))
+
+	// The task fails, and the event behind it waits for its retry
+	_, err = relay.RelayPending(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, tasks.taskIDs)
+	assert.NotContains(t, published, events.TypeUserRegistered)
+
+	// Once the retry is due the task goes out, then the event
+	tasks.mu.Lock()
+	tasks.failing = false
+	tasks.mu.Unlock()
+	_, err = pool.Exec(ctx, "UPDATE outbox SET available_at = NOW() WHERE aggregate_id = $1", aggregateID)
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		_, err = relay.RelayPending(ctx)
+		require.NoError(t, err)
+	}
+	require.Len(t, tasks.taskIDs, 1)
+	assert.Contains(t, published, events.TypeUserRegistered)
+
+	var pending int
+	require.NoError(t, pool.QueryRow(ctx,
+		"SELECT COUNT(*) FROM outbox WHERE aggregate_id = $1 AND delivered_at IS NULL", aggregateID).Scan(&pending))
+	assert.Zero(t, pending)
+}
+
+// TestRelay_ParksMessageAfterMaxAttempts checks that a message failing its
+// last attempt is parked and stops holding back later messages of its
+// aggregate, against a migrated database in TEST_DATABASE_URL
+func TestRelay_ParksMessageAfterMaxAttempts(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" || testing.Short() {
+		t.Skip("Skipping outbox relay database test: TEST_DATABASE_URL not set")
+	}
+
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, dsn)
+	require.NoError(t, err)
+	defer pool.Close()
+
+	logger := zerolog.Nop()
+	repo := repository.New(&database.DB{Pool: pool}, logger)
+	tasks := &fakeEnqueuer{failing: true}
+	bus := events.NewMemoryBus()
+	relay := NewRelay(repo, tasks, bus, config.OutboxConfig{BatchSize: 100, Retention: time.Hour, MaxAttempts: 2}, logger)
+
+	var published []string
+	require.NoError(t, bus.Consume(ctx, "test", func(ctx context.Context, event events.Event) error {
+		published = append(published, event.Type)
+		return nil
+	}))
+
+	aggregateID := fmt.Sprintf("park-%d", time.Now().UnixNano())
+	task, err := Task(AggregateUser, aggregateID, "test:task", map[string]string{"id": aggregateID})
+	require.NoError(t, err)
+	event, err := events.New(events.TypeUserRegistered, events.UserPayload{UserID: 1})
+	require.NoError(t, err)
+	eventMessage, err := Event(AggregateUser, aggregateID, event)
+	require.NoError(t, err)
+
+	require.NoError(t, repo.WithTx(ctx, func(qtx *queries.Queries) error {
+		if err := Add(ctx, qtx, task); err != nil {
+			return err
+		}
+		return Add(ctx, qtx, eventMessage)
+	}))
+
+	// The task fails both of its attempts and is parked
+	for i := 0; i < 2; i++ {
+		_, err = relay.RelayPending(ctx)
+		require.NoError(t, err)
+		_, err = pool.Exec(ctx, "UPDATE outbox SET available_at = NOW() WHERE aggregate_id = $1", aggregateID)
+		require.NoError(t, err)
+	}
+
+	var attempts int32
+	var dead bool
+	require.NoError(t, pool.QueryRow(ctx,
+		"SELECT attempts, dead_at IS NOT NULL FROM outbox WHERE aggregate_id = $1 AND destination = 'task'", aggregateID).Scan(&attempts, &dead))
+	assert.Equal(t, int32(2), attempts)
+	assert.True(t, dead)
+
+	// The event behind it is relayed, and the parked task is not tried again
+	tasks.mu.Lock()
+	tasks.failing = false
+	tasks.mu.Unlock()
+	_, err = relay.RelayPending(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, published, events.TypeUserRegistered)
+	assert.Empty(t, tasks.taskIDs)
+}
@@ -0,0 +1,112 @@
+// Package pagination implements opaque keyset cursors for lists ordered newest
+// first by (created_at, id).
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrInvalidCursor is returned when a cursor cannot be decoded
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Direction says which way a cursor pages from its position
+type Direction string
+
+const (
+	// Next pages to older items, after the cursor position
+	Next Direction = "next"
+	// Prev pages to newer items, before the cursor position
+	Prev Direction = "prev"
+)
+
+// Cursor marks a position in a list ordered by (created_at DESC, id DESC)
+type Cursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        int64     `json:"id"`
+	Direction Direction `json:"d"`
+}
+
+// Encode returns the cursor as an opaque URL-safe string
+func (c Cursor) Encode() string {
+	c.CreatedAt = c.CreatedAt.UTC()
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Backward reports whether the cursor pages to newer items
+func (c Cursor) Backward() bool {
+	return c.Direction == Prev
+}
+
+// Decode parses a cursor produced by Encode. An empty string yields nil, the
+// start of the list.
+func Decode(s string) (*Cursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if c.CreatedAt.IsZero() || c.ID <= 0 || (c.Direction != Next && c.Direction != Prev) {
+		return nil, ErrInvalidCursor
+	}
+
+	return &c, nil
+}
+
+// Page holds one page of items, newest first, and the cursors around it
+type Page[T any] struct {
+	Items      []T
+	NextCursor string
+	PrevCursor string
+}
+
+// Build assembles a page from rows fetched with a limit of limit+1. Rows read
+// for a forward (or first) page are ordered newest first; rows read for a
+// backward page are ordered oldest first, as the query walks away from the
+// cursor. key returns an item's (created_at, id).
+func Build[T any](rows []T, limit int, cursor *Cursor, key func(T) (time.Time, int64)) Page[T] {
+	hasMore := len(rows) > limit
+	if hasMore {
+		rows = rows[:limit]
+	}
+
+	backward := cursor != nil && cursor.Backward()
+	if backward {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+	}
+
+	page := Page[T]{Items: rows}
+	if len(rows) == 0 {
+		return page
+	}
+
+	at := func(item T, direction Direction) string {
+		createdAt, id := key(item)
+		return Cursor{CreatedAt: createdAt, ID: id, Direction: direction}.Encode()
+	}
+
+	// Older items exist when a forward read found more, or when we came back
+	// from an older page. Newer items exist when a backward read found more,
+	// or when we got here by paging forward.
+	if backward || hasMore {
+		page.NextCursor = at(rows[len(rows)-1], Next)
+	}
+	if (backward && hasMore) || (!backward && cursor != nil) {
+		page.PrevCursor = at(rows[0], Prev)
+	}
+
+	return page
+}
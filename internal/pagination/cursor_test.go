@@ -0,0 +1,109 @@
+package pagination
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type item struct {
+	id        int64
+	createdAt time.Time
+}
+
+func itemKey(i item) (time.Time, int64) {
+	return i.createdAt, i.id
+}
+
+func ids(items []item) []int64 {
+	result := []int64{}
+	for _, i := range items {
+		result = append(result, i.id)
+	}
+	return result
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	createdAt := time.Date(2024, 3, 1, 10, 30, 0, 123456000, time.FixedZone("EST", -5*3600))
+	cursor := Cursor{CreatedAt: createdAt, ID: 42, Direction: Prev}
+
+	decoded, err := Decode(cursor.Encode())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !decoded.CreatedAt.Equal(createdAt) || decoded.ID != 42 || !decoded.Backward() {
+		t.Errorf("Expected %+v, got %+v", cursor, decoded)
+	}
+
+	if decoded, err := Decode(""); err != nil || decoded != nil {
+		t.Errorf("Expected empty cursor to start the list, got %+v, %v", decoded, err)
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	for _, s := range []string{
+		"not base64!",
+		"bm90IGpzb24", // "not json"
+		"eyJ0IjoiMjAyNC0wMS0wMVQwMDowMDowMFoiLCJpZCI6MSwiZCI6InVwIn0", // unknown direction
+		Cursor{ID: 1, Direction: Next}.Encode(),                       // no timestamp
+	} {
+		if _, err := Decode(s); err != ErrInvalidCursor {
+			t.Errorf("Expected ErrInvalidCursor for %q, got %v", s, err)
+		}
+	}
+}
+
+func TestBuild(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	// Items 1..5, newest (5) first; 3 and 4 share a timestamp
+	all := []item{
+		{5, base.Add(4 * time.Minute)},
+		{4, base.Add(3 * time.Minute)},
+		{3, base.Add(3 * time.Minute)},
+		{2, base.Add(1 * time.Minute)},
+		{1, base},
+	}
+
+	// First page reads limit+1 rows newest first
+	first := Build(append([]item{}, all[:3]...), 2, nil, itemKey)
+	if !reflect.DeepEqual(ids(first.Items), []int64{5, 4}) || first.NextCursor == "" || first.PrevCursor != "" {
+		t.Fatalf("Unexpected first page %v next=%q prev=%q", ids(first.Items), first.NextCursor, first.PrevCursor)
+	}
+
+	next, _ := Decode(first.NextCursor)
+	if next.ID != 4 || next.Backward() {
+		t.Fatalf("Expected next cursor after item 4, got %+v", next)
+	}
+
+	// Second page: rows older than 4, again limit+1
+	second := Build(append([]item{}, all[2:5]...), 2, next, itemKey)
+	if !reflect.DeepEqual(ids(second.Items), []int64{3, 2}) || second.NextCursor == "" || second.PrevCursor == "" {
+		t.Fatalf("Unexpected second page %v next=%q prev=%q", ids(second.Items), second.NextCursor, second.PrevCursor)
+	}
+
+	// Last page has no next cursor
+	last := Build([]item{all[4]}, 2, next, itemKey)
+	if last.NextCursor != "" || last.PrevCursor == "" {
+		t.Errorf("Expected last page to only page back, got next=%q prev=%q", last.NextCursor, last.PrevCursor)
+	}
+
+	// Paging back from the second page reads newer rows oldest first
+	prev, _ := Decode(second.PrevCursor)
+	if prev.ID != 3 || !prev.Backward() {
+		t.Fatalf("Expected prev cursor before item 3, got %+v", prev)
+	}
+	back := Build([]item{all[1], all[0]}, 2, prev, itemKey)
+	if !reflect.DeepEqual(ids(back.Items), []int64{5, 4}) || back.PrevCursor != "" || back.NextCursor == "" {
+		t.Errorf("Unexpected page back %v next=%q prev=%q", ids(back.Items), back.NextCursor, back.PrevCursor)
+	}
+
+	// More newer rows than fit keeps a prev cursor
+	back = Build([]item{all[1], all[0], {6, base.Add(5 * time.Minute)}}, 2, prev, itemKey)
+	if !reflect.DeepEqual(ids(back.Items), []int64{5, 4}) || back.PrevCursor == "" {
+		t.Errorf("Expected a prev cursor when newer rows remain, got %v prev=%q", ids(back.Items), back.PrevCursor)
+	}
+
+	if empty := Build([]item{}, 2, next, itemKey); empty.NextCursor != "" || empty.PrevCursor != "" {
+		t.Errorf("Expected no cursors on an empty page, got %+v", empty)
+	}
+}
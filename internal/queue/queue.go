@@ -3,17 +3,31 @@ package queue
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/hibiken/asynq"
 	"github.com/phantom-sage/bankgo/internal/config"
+	"github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog"
 )
 
 // Task types
 const (
-	TypeWelcomeEmail = "email:welcome"
+	TypeWelcomeEmail        = "email:welcome"
+	TypeAccountLockedEmail  = "email:account_locked"
+	TypeNewDeviceLoginEmail = "email:new_device_login"
+	TypeTransferBatch       = "transfer:batch"
+	TypeWebhookDelivery     = "webhook:deliver"
+)
+
+// Webhook delivery retry policy. Deliveries back off from webhookRetryBase,
+// doubling up to webhookRetryMax, which spreads the attempts over about a day.
+const (
+	webhookMaxRetry  = 12
+	webhookRetryBase = 30 * time.Second
+	webhookRetryMax  = 6 * time.Hour
 )
 
 // WelcomeEmailPayload represents the payload for welcome email tasks
@@ -24,6 +38,40 @@ type WelcomeEmailPayload struct {
 	LastName  string `json:"last_name"`
 }
 
+// AccountLockedEmailPayload represents the payload for the email telling a
+// user their account was locked after repeated failed sign-ins
+type AccountLockedEmailPayload struct {
+	UserID      int       `json:"user_id"`
+	Email       string    `json:"email"`
+	FirstName   string    `json:"first_name"`
+	IPAddress   string    `json:"ip_address"`
+	LockedUntil time.Time `json:"locked_until"`
+}
+
+// NewDeviceLoginEmailPayload represents the payload for the email telling a
+// user about a sign-in from a device or IP range they had not used before
+type NewDeviceLoginEmailPayload struct {
+	UserID     int       `json:"user_id"`
+	Email      string    `json:"email"`
+	FirstName  string    `json:"first_name"`
+	IPAddress  string    `json:"ip_address"`
+	UserAgent  string    `json:"user_agent"`
+	NewDevice  bool      `json:"new_device"`
+	NewIPRange bool      `json:"new_ip_range"`
+	LoginAt    time.Time `json:"login_at"`
+}
+
+// TransferBatchPayload represents the payload for batch transfer tasks
+type TransferBatchPayload struct {
+	BatchID int32 `json:"batch_id"`
+	UserID  int32 `json:"user_id"`
+}
+
+// WebhookDeliveryPayload represents the payload for webhook delivery tasks
+type WebhookDeliveryPayload struct {
+	DeliveryID int64 `json:"delivery_id"`
+}
+
 // QueueManager manages task queuing and processing
 type QueueManager struct {
 	client           *AsyncqClient
@@ -68,6 +116,12 @@ func NewQueueManager(cfg config.RedisConfig, logger zerolog.Logger) (*QueueManag
 	}, nil
 }
 
+// RedisClient returns the Redis client shared with other Redis consumers,
+// such as the admin notification bus
+func (qm *QueueManager) RedisClient() *redis.Client {
+	return qm.redis.Client()
+}
+
 // QueueWelcomeEmail queues a welcome email task
 func (qm *QueueManager) QueueWelcomeEmail(ctx context.Context, payload WelcomeEmailPayload) error {
 	startTime := time.Now()
@@ -98,15 +152,7 @@ func (qm *QueueManager) QueueWelcomeEmail(ctx context.Context, payload WelcomeEm
 	// Create task with retry options
 	task := asynq.NewTask(TypeWelcomeEmail, payloadBytes)
 	
-	// Queue task with options
-	opts := []asynq.Option{
-		asynq.Queue("email"),           // Use email queue for high priority
-		asynq.MaxRetry(3),              // Retry up to 3 times
-		asynq.Timeout(30 * time.Second), // 30 second timeout
-		asynq.ProcessIn(5 * time.Second), // Process after 5 seconds to allow for immediate response
-	}
-
-	info, err := qm.client.Client().EnqueueContext(ctx, task, opts...)
+	info, err := qm.client.Client().EnqueueContext(ctx, task, taskOptions(TypeWelcomeEmail)...)
 	if err != nil {
 		logger.Error().
 			Err(err).
@@ -124,6 +170,300 @@ func (qm *QueueManager) QueueWelcomeEmail(ctx context.Context, payload WelcomeEm
 	return nil
 }
 
+// taskOptions returns the queueing options of a task type
+func taskOptions(taskType string) []asynq.Option {
+	switch taskType {
+	case TypeWelcomeEmail:
+		return []asynq.Option{
+			asynq.Queue("email"),           // Use email queue for high priority
+			asynq.MaxRetry(3),              // Retry up to 3 times
+			asynq.Timeout(30 * time.Second), // 30 second timeout
+			asynq.ProcessIn(5 * time.Second), // Process after 5 seconds to allow for immediate response
+		}
+	case TypeAccountLockedEmail, TypeNewDeviceLoginEmail:
+		return []asynq.Option{
+			asynq.Queue("email"),
+			asynq.MaxRetry(3),
+			asynq.Timeout(30 * time.Second),
+		}
+	case TypeTransferBatch:
+		return []asynq.Option{
+			asynq.Queue("transfers"),
+			asynq.MaxRetry(3),
+			asynq.Timeout(5 * time.Minute),
+		}
+	case TypeWebhookDelivery:
+		return []asynq.Option{
+			asynq.Queue("webhooks"),
+			asynq.MaxRetry(webhookMaxRetry),
+			asynq.Timeout(time.Minute),
+		}
+	default:
+		return []asynq.Option{asynq.MaxRetry(3)}
+	}
+}
+
+// EnqueueTask enqueues an already encoded task with the options of its type.
+// taskID makes enqueueing idempotent: a task whose ID is still known to the
+// queue is not enqueued again and no error is returned.
+func (qm *QueueManager) EnqueueTask(ctx context.Context, taskType string, payload []byte, taskID string) error {
+	logger := qm.logger.With().
+		Str("operation", "enqueue_task").
+		Str("job_type", taskType).
+		Str("task_id", taskID).
+		Str("correlation_id", getCorrelationID(ctx)).
+		Logger()
+
+	opts := append(taskOptions(taskType), asynq.TaskID(taskID))
+	info, err := qm.client.Client().EnqueueContext(ctx, asynq.NewTask(taskType, payload), opts...)
+	if errors.Is(err, asynq.ErrTaskIDConflict) {
+		logger.Debug().Msg("Task already enqueued")
+		return nil
+	}
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to enqueue task")
+		return fmt.Errorf("failed to enqueue %s task: %w", taskType, err)
+	}
+
+	logger.Info().Str("queue", info.Queue).Msg("Task enqueued successfully")
+	return nil
+}
+
+// QueueTransferBatch queues a batch of transfers for background processing
+func (qm *QueueManager) QueueTransferBatch(ctx context.Context, payload TransferBatchPayload) error {
+	startTime := time.Now()
+	correlationID := getCorrelationID(ctx)
+
+	logger := qm.logger.With().
+		Str("operation", "queue_transfer_batch").
+		Str("job_type", TypeTransferBatch).
+		Int32("batch_id", payload.BatchID).
+		Int32("user_id", payload.UserID).
+		Str("correlation_id", correlationID).
+		Logger()
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal transfer batch payload: %w", err)
+	}
+
+	// The batch ID doubles as the task ID so a batch can only be enqueued once.
+	// Batches are claimed before processing, so retries never book twice.
+	task := asynq.NewTask(TypeTransferBatch, payloadBytes)
+	opts := []asynq.Option{
+		asynq.Queue("transfers"),
+		asynq.TaskID(fmt.Sprintf("transfer-batch-%d", payload.BatchID)),
+		asynq.MaxRetry(3),
+		asynq.Timeout(5 * time.Minute),
+	}
+
+	info, err := qm.client.Client().EnqueueContext(ctx, task, opts...)
+	if err != nil {
+		logger.Error().
+			Err(err).
+			Dur("duration", time.Since(startTime)).
+			Msg("Failed to enqueue transfer batch task")
+		return fmt.Errorf("failed to enqueue transfer batch task: %w", err)
+	}
+
+	logger.Info().
+		Str("task_id", info.ID).
+		Str("queue", info.Queue).
+		Dur("duration", time.Since(startTime)).
+		Msg("Transfer batch task enqueued successfully")
+
+	return nil
+}
+
+// RegisterTransferBatchHandler registers the batch transfer task handler with the server
+func (qm *QueueManager) RegisterTransferBatchHandler(processor TransferBatchProcessor) {
+	qm.server.RegisterHandler(TypeTransferBatch, func(ctx context.Context, t *asynq.Task) error {
+		startTime := time.Now()
+		correlationID := generateCorrelationID()
+		ctx = context.WithValue(ctx, "correlation_id", correlationID)
+
+		var payload TransferBatchPayload
+		if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+			// A malformed payload will never succeed, so do not retry it
+			return fmt.Errorf("failed to unmarshal transfer batch payload: %v: %w", err, asynq.SkipRetry)
+		}
+
+		logger := qm.logger.With().
+			Str("operation", "process_transfer_batch").
+			Str("job_type", TypeTransferBatch).
+			Int32("batch_id", payload.BatchID).
+			Str("correlation_id", correlationID).
+			Logger()
+
+		logger.Info().Msg("Starting transfer batch task processing")
+
+		err := processor.ProcessTransferBatch(ctx, payload)
+		duration := time.Since(startTime)
+		qm.performanceLogger.LogJobExecution(TypeTransferBatch, correlationID, duration, err == nil, 0)
+
+		if err != nil {
+			logger.Error().
+				Err(err).
+				Dur("duration", duration).
+				Msg("Transfer batch task processing failed")
+			return err
+		}
+
+		logger.Info().
+			Dur("duration", duration).
+			Msg("Transfer batch task processing completed successfully")
+
+		return nil
+	})
+}
+
+// RegisterWebhookDeliveryHandler registers the webhook delivery task handler with the server
+func (qm *QueueManager) RegisterWebhookDeliveryHandler(processor WebhookDeliveryProcessor) {
+	qm.server.RegisterHandler(TypeWebhookDelivery, func(ctx context.Context, t *asynq.Task) error {
+		startTime := time.Now()
+		correlationID := generateCorrelationID()
+		ctx = context.WithValue(ctx, "correlation_id", correlationID)
+
+		var payload WebhookDeliveryPayload
+		if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+			// A malformed payload will never succeed, so do not retry it
+			return fmt.Errorf("failed to unmarshal webhook delivery payload: %v: %w", err, asynq.SkipRetry)
+		}
+
+		logger := qm.logger.With().
+			Str("operation", "process_webhook_delivery").
+			Str("job_type", TypeWebhookDelivery).
+			Int64("delivery_id", payload.DeliveryID).
+			Str("correlation_id", correlationID).
+			Logger()
+
+		retried, _ := asynq.GetRetryCount(ctx)
+		err := processor.ProcessWebhookDelivery(ctx, payload)
+		duration := time.Since(startTime)
+		qm.performanceLogger.LogJobExecution(TypeWebhookDelivery, correlationID, duration, err == nil, retried)
+
+		if err != nil {
+			// Failing endpoints are expected, so a failed attempt is not an error
+			logger.Warn().
+				Err(err).
+				Int("retry_count", retried).
+				Dur("duration", duration).
+				Msg("Webhook delivery attempt failed")
+			return err
+		}
+
+		logger.Debug().
+			Dur("duration", duration).
+			Msg("Webhook delivery task processed")
+
+		return nil
+	})
+}
+
+// RegisterSecurityEmailHandlers registers the handlers of the security emails
+// sent about a user's sign-ins with the server
+func (qm *QueueManager) RegisterSecurityEmailHandlers(processor SecurityEmailProcessor) {
+	qm.server.RegisterHandler(TypeAccountLockedEmail, func(ctx context.Context, t *asynq.Task) error {
+		startTime := time.Now()
+		correlationID := generateCorrelationID()
+		ctx = context.WithValue(ctx, "correlation_id", correlationID)
+
+		var payload AccountLockedEmailPayload
+		if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+			// A malformed payload will never succeed, so do not retry it
+			return fmt.Errorf("failed to unmarshal account locked email payload: %v: %w", err, asynq.SkipRetry)
+		}
+
+		logger := qm.logger.With().
+			Str("operation", "process_account_locked_email").
+			Str("job_type", TypeAccountLockedEmail).
+			Int("user_id", payload.UserID).
+			Str("correlation_id", correlationID).
+			Logger()
+
+		err := processor.ProcessAccountLockedEmail(ctx, payload)
+		duration := time.Since(startTime)
+		qm.performanceLogger.LogJobExecution(TypeAccountLockedEmail, correlationID, duration, err == nil, 0)
+
+		if err != nil {
+			logger.Error().
+				Err(err).
+				Dur("duration", duration).
+				Msg("Account locked email task processing failed")
+			return err
+		}
+
+		logger.Info().
+			Dur("duration", duration).
+			Msg("Account locked email task processing completed successfully")
+
+		return nil
+	})
+
+	qm.server.RegisterHandler(TypeNewDeviceLoginEmail, func(ctx context.Context, t *asynq.Task) error {
+		startTime := time.Now()
+		correlationID := generateCorrelationID()
+		ctx = context.WithValue(ctx, "correlation_id", correlationID)
+
+		var payload NewDeviceLoginEmailPayload
+		if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+			// A malformed payload will never succeed, so do not retry it
+			return fmt.Errorf("failed to unmarshal new device login email payload: %v: %w", err, asynq.SkipRetry)
+		}
+
+		logger := qm.logger.With().
+			Str("operation", "process_new_device_login_email").
+			Str("job_type", TypeNewDeviceLoginEmail).
+			Int("user_id", payload.UserID).
+			Str("correlation_id", correlationID).
+			Logger()
+
+		err := processor.ProcessNewDeviceLoginEmail(ctx, payload)
+		duration := time.Since(startTime)
+		qm.performanceLogger.LogJobExecution(TypeNewDeviceLoginEmail, correlationID, duration, err == nil, 0)
+
+		if err != nil {
+			logger.Error().
+				Err(err).
+				Dur("duration", duration).
+				Msg("New device login email task processing failed")
+			return err
+		}
+
+		logger.Info().
+			Dur("duration", duration).
+			Msg("New device login email task processing completed successfully")
+
+		return nil
+	})
+}
+
+// FinalAttempt reports whether the task running in ctx will not be retried
+// if it fails. Outside a task it is always the final attempt.
+func FinalAttempt(ctx context.Context) bool {
+	retried, ok := asynq.GetRetryCount(ctx)
+	if !ok {
+		return true
+	}
+	maxRetry, ok := asynq.GetMaxRetry(ctx)
+	if !ok {
+		return true
+	}
+	return retried >= maxRetry
+}
+
+// webhookRetryDelay returns the backoff before retry n of a webhook delivery
+func webhookRetryDelay(n int) time.Duration {
+	delay := webhookRetryBase
+	for i := 0; i < n && delay < webhookRetryMax; i++ {
+		delay *= 2
+	}
+	if delay > webhookRetryMax {
+		return webhookRetryMax
+	}
+	return delay
+}
+
 // RegisterHandlers registers task handlers with the server
 func (qm *QueueManager) RegisterHandlers(emailProcessor EmailProcessor) {
 	// Register welcome email handler
@@ -513,6 +853,22 @@ type EmailProcessor interface {
 	ProcessWelcomeEmail(ctx context.Context, payload WelcomeEmailPayload) error
 }
 
+// SecurityEmailProcessor interface for processing security email tasks
+type SecurityEmailProcessor interface {
+	ProcessAccountLockedEmail(ctx context.Context, payload AccountLockedEmailPayload) error
+	ProcessNewDeviceLoginEmail(ctx context.Context, payload NewDeviceLoginEmailPayload) error
+}
+
+// TransferBatchProcessor interface for processing batch transfer tasks
+type TransferBatchProcessor interface {
+	ProcessTransferBatch(ctx context.Context, payload TransferBatchPayload) error
+}
+
+// WebhookDeliveryProcessor interface for processing webhook delivery tasks
+type WebhookDeliveryProcessor interface {
+	ProcessWebhookDelivery(ctx context.Context, payload WebhookDeliveryPayload) error
+}
+
 // getCorrelationID extracts correlation ID from context, generates one if not present
 func getCorrelationID(ctx context.Context) string {
 	if id := ctx.Value("correlation_id"); id != nil {
@@ -146,4 +146,17 @@ func TestWelcomeEmailPayload(t *testing.T) {
 
 func TestTaskTypes(t *testing.T) {
 	assert.Equal(t, "email:welcome", TypeWelcomeEmail)
+	assert.Equal(t, "webhook:deliver", TypeWebhookDelivery)
+}
+
+func TestWebhookRetryDelay(t *testing.T) {
+	assert.Equal(t, 30*time.Second, webhookRetryDelay(0))
+	assert.Equal(t, time.Minute, webhookRetryDelay(1))
+	assert.Equal(t, 16*time.Minute, webhookRetryDelay(5))
+	assert.Equal(t, webhookRetryMax, webhookRetryDelay(webhookMaxRetry))
+	assert.Equal(t, webhookRetryMax, webhookRetryDelay(1000))
+}
+
+func TestFinalAttempt_OutsideTask(t *testing.T) {
+	assert.True(t, FinalAttempt(context.Background()))
 }
\ No newline at end of file
@@ -114,14 +114,20 @@ func NewAsyncqServer(cfg config.RedisConfig, logger zerolog.Logger) (*AsyncqServ
 	serverConfig := asynq.Config{
 		Concurrency: 10,
 		Queues: map[string]int{
-			"email":    6, // High priority for email tasks
-			"default":  3,
-			"low":      1,
+			"email":     6, // High priority for email tasks
+			"transfers": 4, // Batch transfers submitted by API clients
+			"webhooks":  2, // Customer webhook deliveries, mostly retries
+			"default":   3,
+			"low":       1,
 		},
 		// Retry policy for failed tasks
 		RetryDelayFunc: func(n int, e error, t *asynq.Task) time.Duration {
 			// Exponential backoff: 1s, 2s, 4s, 8s, 16s
 			delay := time.Duration(1<<uint(n)) * time.Second
+			if t.Type() == TypeWebhookDelivery {
+				// Customer endpoints can be down for hours
+				delay = webhookRetryDelay(n)
+			}
 			
 			serverLogger.Warn().
 				Str("task_type", t.Type()).
@@ -37,6 +37,16 @@ func New(db *database.DB, logger zerolog.Logger) *Repository {
 	return repo
 }
 
+// ReadQueries returns queries bound to the read pool, which is the replica when
+// one is configured and within its lag limit. Only use it for reads that can
+// tolerate slightly stale data, such as history listings.
+func (r *Repository) ReadQueries() *queries.Queries {
+	if r.db == nil || !r.db.HasReplica() {
+		return r.Queries
+	}
+	return queries.New(r.db.ReadPool())
+}
+
 // WithTx executes a function within a database transaction with logging
 func (r *Repository) WithTx(ctx context.Context, fn func(*queries.Queries) error) error {
 	startTime := time.Now()
@@ -219,6 +229,18 @@ func (r *Repository) LogConnectionPoolMetrics(ctx context.Context) {
 		Int64("canceled_acquire_count", stats.CanceledAcquireCount()).
 		Int64("empty_acquire_count", stats.EmptyAcquireCount()).
 		Msg("Database connection pool metrics")
+
+	if replicaStats := r.db.ReplicaStats(); replicaStats != nil {
+		replicaStatus := r.db.ReplicaStatus()
+		ctxLogger.Debug().
+			Int32("acquired_conns", replicaStats.AcquiredConns()).
+			Int32("idle_conns", replicaStats.IdleConns()).
+			Int32("max_conns", replicaStats.MaxConns()).
+			Int32("total_conns", replicaStats.TotalConns()).
+			Bool("healthy", replicaStatus.Healthy).
+			Int64("lag_ms", replicaStatus.Lag.Milliseconds()).
+			Msg("Database replica connection pool metrics")
+	}
 	
 	// Log performance metrics for connection pool
 	r.perfLogger.LogConnectionPoolMetrics(
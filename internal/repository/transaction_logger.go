@@ -2,10 +2,13 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand"
 	"strings"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/phantom-sage/bankgo/internal/database/queries"
 	"github.com/phantom-sage/bankgo/internal/logging"
 	"github.com/rs/zerolog"
@@ -29,6 +32,18 @@ func NewTransactionLogger(repo *Repository) *TransactionLogger {
 	}
 }
 
+// Retry backoff bounds for transactions aborted by deadlocks or serialization failures
+const (
+	retryBaseBackoff = 25 * time.Millisecond
+	retryMaxBackoff  = 1 * time.Second
+)
+
+// PostgreSQL error codes for transaction conflicts that are safe to retry
+const (
+	pgSerializationFailure = "40001"
+	pgDeadlockDetected     = "40P01"
+)
+
 // TransactionContext holds transaction execution context and metrics
 type TransactionContext struct {
 	TransactionID   string
@@ -54,7 +69,9 @@ func (tl *TransactionLogger) WithTxLogged(ctx context.Context, fn func(*queries.
 	return tl.WithTxLoggedWithRetry(ctx, fn, 3) // Default 3 retries for deadlocks
 }
 
-// WithTxLoggedWithRetry executes a function within a database transaction with deadlock retry logic
+// WithTxLoggedWithRetry executes a function within a database transaction, retrying
+// deadlocks and serialization failures with jittered exponential backoff.
+// fn runs once per attempt, so it must not leave side effects outside the transaction.
 func (tl *TransactionLogger) WithTxLoggedWithRetry(ctx context.Context, fn func(*queries.Queries, *TransactionContext) error, maxRetries int) error {
 	txCtx := &TransactionContext{
 		TransactionID:   tl.generateTransactionID(),
@@ -71,25 +88,30 @@ func (tl *TransactionLogger) WithTxLoggedWithRetry(ctx context.Context, fn func(
 	for attempt := 0; attempt <= maxRetries; attempt++ {
 		if attempt > 0 {
 			txCtx.DeadlockRetries = attempt
+			backoffDuration := retryBackoff(attempt)
 			ctxLogger.Warn().
 				Int("attempt", attempt).
 				Int("max_retries", maxRetries).
-				Msg("Retrying transaction due to deadlock")
-			
-			// Exponential backoff for retries
-			backoffDuration := time.Duration(attempt*attempt) * 100 * time.Millisecond
-			time.Sleep(backoffDuration)
+				Int64("backoff_ms", backoffDuration.Milliseconds()).
+				Msg("Retrying transaction after conflict")
+
+			// Jittered backoff keeps conflicting transactions from retrying in lockstep
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("transaction retry cancelled: %w", ctx.Err())
+			case <-time.After(backoffDuration):
+			}
 		}
 
 		err := tl.executeTransaction(ctx, ctxLogger, txCtx, fn)
 		
 		if err != nil {
-			// Check if this is a deadlock error that should be retried
-			if tl.isDeadlockError(err) && attempt < maxRetries {
+			// Check if this is a deadlock or serialization failure that should be retried
+			if isRetryableTxError(err) && attempt < maxRetries {
 				ctxLogger.Warn().
 					Err(err).
 					Int("attempt", attempt).
-					Msg("Transaction deadlock detected, will retry")
+					Msg("Transaction conflict detected, will retry")
 				continue
 			}
 			
@@ -274,19 +296,35 @@ func (tl *TransactionLogger) logOperationBreakdown(ctxLogger *logging.ContextLog
 	}
 }
 
-// isDeadlockError checks if an error is a deadlock that should be retried
-func (tl *TransactionLogger) isDeadlockError(err error) bool {
+// isRetryableTxError checks if an error is a deadlock or serialization failure that should be retried
+func isRetryableTxError(err error) bool {
 	if err == nil {
 		return false
 	}
-	
-	// Check for PostgreSQL deadlock error codes
-	// Note: This would need to be implemented with proper pgx error handling
-	// For now, we'll check the error message as a fallback
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == pgDeadlockDetected || pgErr.Code == pgSerializationFailure
+	}
+
+	// Fall back to the message for errors that were flattened with %v
 	errMsg := err.Error()
-	return strings.Contains(errMsg, "deadlock") || strings.Contains(errMsg, "40P01")
-	
-	return false
+	return strings.Contains(errMsg, "deadlock") ||
+		strings.Contains(errMsg, pgDeadlockDetected) ||
+		strings.Contains(errMsg, pgSerializationFailure) ||
+		strings.Contains(errMsg, "could not serialize access")
+}
+
+// retryBackoff returns the delay before a retry attempt: exponential in the
+// attempt number, capped, with the upper half randomized
+func retryBackoff(attempt int) time.Duration {
+	backoff := retryBaseBackoff << (attempt - 1)
+	if backoff <= 0 || backoff > retryMaxBackoff {
+		backoff = retryMaxBackoff
+	}
+
+	half := backoff / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
 }
 
 // generateTransactionID generates a unique transaction ID for logging
@@ -7,7 +7,9 @@ import (
 	"github.com/phantom-sage/bankgo/internal/database/queries"
 )
 
-// TransferRepository defines the interface for transfer database operations
+// TransferRepository defines the interface for transfer database operations.
+// Listings and counts read from the replica when one is configured; GetTransfer
+// reads from the primary so a transfer is visible as soon as it is booked.
 type TransferRepository interface {
 	CreateTransfer(ctx context.Context, arg queries.CreateTransferParams) (queries.Transfer, error)
 	GetTransfer(ctx context.Context, id int32) (queries.GetTransferRow, error)
@@ -18,6 +20,8 @@ type TransferRepository interface {
 	GetTransfersByStatus(ctx context.Context, arg queries.GetTransfersByStatusParams) ([]queries.GetTransfersByStatusRow, error)
 	GetTransfersByDateRange(ctx context.Context, arg queries.GetTransfersByDateRangeParams) ([]queries.GetTransfersByDateRangeRow, error)
 	CountTransfersByAccount(ctx context.Context, fromAccountID int32) (int64, error)
+	ListUserTransfersOlder(ctx context.Context, arg queries.ListUserTransfersOlderParams) ([]queries.ListUserTransfersOlderRow, error)
+	ListUserTransfersNewer(ctx context.Context, arg queries.ListUserTransfersNewerParams) ([]queries.ListUserTransfersNewerRow, error)
 }
 
 // TransferRepositoryImpl implements TransferRepository
@@ -56,7 +60,7 @@ func (r *TransferRepositoryImpl) GetTransfer(ctx context.Context, id int32) (que
 
 func (r *TransferRepositoryImpl) GetTransfersByAccount(ctx context.Context, arg queries.GetTransfersByAccountParams) ([]queries.GetTransfersByAccountRow, error) {
 	startTime := time.Now()
-	transfers, err := r.Queries.GetTransfersByAccount(ctx, arg)
+	transfers, err := r.ReadQueries().GetTransfersByAccount(ctx, arg)
 	
 	// Log the database operation
 	rowsAffected := int64(len(transfers))
@@ -67,7 +71,7 @@ func (r *TransferRepositoryImpl) GetTransfersByAccount(ctx context.Context, arg
 
 func (r *TransferRepositoryImpl) GetTransfersByUser(ctx context.Context, arg queries.GetTransfersByUserParams) ([]queries.GetTransfersByUserRow, error) {
 	startTime := time.Now()
-	transfers, err := r.Queries.GetTransfersByUser(ctx, arg)
+	transfers, err := r.ReadQueries().GetTransfersByUser(ctx, arg)
 	
 	// Log the database operation
 	rowsAffected := int64(len(transfers))
@@ -88,7 +92,7 @@ func (r *TransferRepositoryImpl) UpdateTransferStatus(ctx context.Context, arg q
 
 func (r *TransferRepositoryImpl) ListTransfers(ctx context.Context, arg queries.ListTransfersParams) ([]queries.ListTransfersRow, error) {
 	startTime := time.Now()
-	transfers, err := r.Queries.ListTransfers(ctx, arg)
+	transfers, err := r.ReadQueries().ListTransfers(ctx, arg)
 	
 	// Log the database operation
 	rowsAffected := int64(len(transfers))
@@ -99,7 +103,7 @@ func (r *TransferRepositoryImpl) ListTransfers(ctx context.Context, arg queries.
 
 func (r *TransferRepositoryImpl) GetTransfersByStatus(ctx context.Context, arg queries.GetTransfersByStatusParams) ([]queries.GetTransfersByStatusRow, error) {
 	startTime := time.Now()
-	transfers, err := r.Queries.GetTransfersByStatus(ctx, arg)
+	transfers, err := r.ReadQueries().GetTransfersByStatus(ctx, arg)
 	
 	// Log the database operation
 	rowsAffected := int64(len(transfers))
@@ -110,7 +114,7 @@ func (r *TransferRepositoryImpl) GetTransfersByStatus(ctx context.Context, arg q
 
 func (r *TransferRepositoryImpl) GetTransfersByDateRange(ctx context.Context, arg queries.GetTransfersByDateRangeParams) ([]queries.GetTransfersByDateRangeRow, error) {
 	startTime := time.Now()
-	transfers, err := r.Queries.GetTransfersByDateRange(ctx, arg)
+	transfers, err := r.ReadQueries().GetTransfersByDateRange(ctx, arg)
 	
 	// Log the database operation
 	rowsAffected := int64(len(transfers))
@@ -121,11 +125,33 @@ func (r *TransferRepositoryImpl) GetTransfersByDateRange(ctx context.Context, ar
 
 func (r *TransferRepositoryImpl) CountTransfersByAccount(ctx context.Context, fromAccountID int32) (int64, error) {
 	startTime := time.Now()
-	count, err := r.Queries.CountTransfersByAccount(ctx, fromAccountID)
+	count, err := r.ReadQueries().CountTransfersByAccount(ctx, fromAccountID)
 	
 	// Log the database operation
 	rowsAffected := int64(1) // COUNT queries return 1 row
 	r.LogDatabaseOperation(ctx, "SELECT COUNT", "transfers", startTime, rowsAffected, err)
 	
 	return count, err
+}
+
+func (r *TransferRepositoryImpl) ListUserTransfersOlder(ctx context.Context, arg queries.ListUserTransfersOlderParams) ([]queries.ListUserTransfersOlderRow, error) {
+	startTime := time.Now()
+	transfers, err := r.ReadQueries().ListUserTransfersOlder(ctx, arg)
+	
+	// Log the database operation
+	rowsAffected := int64(len(transfers))
+	r.LogDatabaseOperation(ctx, "SELECT", "transfers", startTime, rowsAffected, err)
+	
+	return transfers, err
+}
+
+func (r *TransferRepositoryImpl) ListUserTransfersNewer(ctx context.Context, arg queries.ListUserTransfersNewerParams) ([]queries.ListUserTransfersNewerRow, error) {
+	startTime := time.Now()
+	transfers, err := r.ReadQueries().ListUserTransfersNewer(ctx, arg)
+	
+	// Log the database operation
+	rowsAffected := int64(len(transfers))
+	r.LogDatabaseOperation(ctx, "SELECT", "transfers", startTime, rowsAffected, err)
+	
+	return transfers, err
 }
\ No newline at end of file
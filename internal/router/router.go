@@ -5,9 +5,11 @@ import (
 	"os"
 
 	"github.com/gin-gonic/gin"
+	"github.com/phantom-sage/bankgo/internal/alerts"
 	"github.com/phantom-sage/bankgo/internal/config"
 	"github.com/phantom-sage/bankgo/internal/database"
 	"github.com/phantom-sage/bankgo/internal/handlers"
+	"github.com/phantom-sage/bankgo/internal/lockout"
 	"github.com/phantom-sage/bankgo/internal/logging"
 	"github.com/phantom-sage/bankgo/internal/middleware"
 	"github.com/phantom-sage/bankgo/internal/queue"
@@ -17,11 +19,23 @@ import (
 	"github.com/rs/zerolog"
 )
 
-// SetupRouter configures and returns the main application router
-func SetupRouter(db *database.DB, queueManager *queue.QueueManager, cfg *config.Config, loggerManager *logging.LoggerManager, version string) *gin.Engine {
+// SetupRouter configures and returns the main application router.
+// alertPublisher raises anomaly, rate limit and lockout alerts for admins; it
+// may be nil, in which case those are only logged.
+func SetupRouter(db *database.DB, queueManager *queue.QueueManager, cfg *config.Config, loggerManager *logging.LoggerManager, alertPublisher alerts.Publisher, version string) *gin.Engine {
 	// Create Gin router
 	router := gin.New()
 
+	// Only configured proxies may name the client in X-Forwarded-For, since
+	// the client IP keys rate limits and sign-in lockouts
+	var trustedProxies []string
+	if cfg != nil {
+		trustedProxies = cfg.Server.TrustedProxies
+	}
+	if err := router.SetTrustedProxies(trustedProxies); err != nil {
+		log.Printf("Warning: Failed to set trusted proxies: %v", err)
+	}
+
 	// Add global middleware
 	router.Use(gin.Logger())
 	router.Use(gin.Recovery())
@@ -39,6 +53,19 @@ func SetupRouter(db *database.DB, queueManager *queue.QueueManager, cfg *config.
 	var authHandlers *handlers.AuthHandlers
 	var accountHandlers *handlers.AccountHandlers
 	var transferHandlers *handlers.TransferHandlers
+	var transferBatchHandlers *handlers.TransferBatchHandlers
+	var webhookHandlers *handlers.WebhookHandlers
+	var apiKeyHandlers *handlers.APIKeyHandlers
+	var loginHistoryHandlers *handlers.LoginHistoryHandlers
+	var rateLimitAlerter middleware.RateLimitAlerter
+
+	var logger zerolog.Logger
+	if loggerManager != nil {
+		logger = loggerManager.GetLogger()
+	} else {
+		// Fallback to a basic logger if LoggerManager is not available
+		logger = zerolog.New(os.Stdout).With().Timestamp().Logger()
+	}
 
 	if db != nil && cfg != nil {
 		// Create PASETO token manager instance
@@ -47,27 +74,113 @@ func SetupRouter(db *database.DB, queueManager *queue.QueueManager, cfg *config.
 			log.Printf("Warning: Failed to create PASETO token manager: %v", err)
 		} else {
 			// Initialize repository layer with logger
-			var logger zerolog.Logger
-			if loggerManager != nil {
-				logger = loggerManager.GetLogger()
-			} else {
-				// Fallback to a basic logger if LoggerManager is not available
-				logger = zerolog.New(os.Stdout).With().Timestamp().Logger()
-			}
-			
 			repo := repository.New(db, logger)
 			repos := repository.NewRepositories(repo)
 
-			// Initialize all services with proper dependencies
-			allServices := services.NewServices(repos, repo, logger)
+			rateLimitAlerter = alertPublisher
+			riskEngine := services.NewRiskEngine(cfg.Risk)
+			transferLimiter := services.NewTransferLimiter(cfg.Limits)
+
+			// Large batches run on the queue when Redis is available
+			var batchQueue services.TransferBatchQueue
+			if queueManager != nil {
+				batchQueue = queueManager
+			}
+
+			// Initialize all services with proper dependencies. Domain events and
+			// welcome emails go through the outbox, which the outbox relay delivers
+			// to Redis; without Redis there is no relay, so nothing is written to it.
+			newServices := services.NewServices
+			if queueManager != nil {
+				newServices = services.NewServicesWithOutbox
+			}
+			allServices := newServices(repos, repo, logger,
+				services.WithRiskEngine(riskEngine, alertPublisher),
+				services.WithTransferLimits(transferLimiter),
+				services.WithTransferBatches(cfg.Batch, batchQueue),
+			)
+			if queueManager != nil {
+				queueManager.RegisterTransferBatchHandler(allServices.TransferBatchService)
+			}
 
 			// Create all handler instances with services
-			authHandlers = handlers.NewAuthHandlers(allServices.UserService, tokenManager, queueManager)
+			apiKeyService := services.NewAPIKeyService(repo, cfg.APIKeys, logger)
+			authOpts := []handlers.AuthHandlersOption{
+				handlers.WithAPIKeys(apiKeyService, cfg.APIKeys.ClientTokenExpiration),
+			}
+
+			// Failed sign-ins are counted in the database, shared with the admin
+			// API that unlocks accounts. Lockouts raise security alerts and, when
+			// the outbox is relayed, email the account owner.
+			if cfg.Login.Enabled {
+				guardOpts := []lockout.Option{lockout.WithAlerter(alertPublisher)}
+				if queueManager != nil {
+					guardOpts = append(guardOpts, lockout.WithNotifier(services.NewLockoutNotifier(repo, logger)))
+				}
+				loginGuard := lockout.NewGuard(repo, lockout.Customers, cfg.Login, logger, guardOpts...)
+				authOpts = append(authOpts, handlers.WithLoginGuard(loginGuard))
+			}
+
+			// Every sign-in is recorded with where it came from. Sign-ins from a new
+			// device or IP range email the user through the outbox, so only when it
+			// is relayed.
+			loginHistory := services.NewLoginHistoryService(repo, logger)
+			if queueManager != nil {
+				loginHistory = services.NewLoginHistoryServiceWithAlerts(repo, logger)
+			}
+			authOpts = append(authOpts, handlers.WithLoginHistory(loginHistory))
+
+			authHandlers = handlers.NewAuthHandlers(allServices.UserService, tokenManager, authOpts...)
+			apiKeyHandlers = handlers.NewAPIKeyHandlers(apiKeyService)
+			loginHistoryHandlers = handlers.NewLoginHistoryHandlers(loginHistory)
 			accountHandlers = handlers.NewAccountHandlers(allServices.AccountService)
 			transferHandlers = handlers.NewTransferHandlers(allServices.TransferService, allServices.AccountService)
+			transferBatchHandlers = handlers.NewTransferBatchHandlers(allServices.TransferBatchService)
+
+			// Webhooks are delivered from the queue, so they need Redis
+			if queueManager != nil {
+				webhookService := services.NewWebhookService(repo, cfg.Webhooks, logger)
+				queueManager.RegisterWebhookDeliveryHandler(webhookService)
+				webhookHandlers = handlers.NewWebhookHandlers(webhookService)
+			}
 		}
 	}
 
+	// Rate limits are counted in Redis so every replica shares them, and in
+	// memory when Redis is not configured or is down. Every client IP has a
+	// global limit, sign-in attempts a strict one and each user one on transfers.
+	globalLimit, loginLimit, transferLimit := noRateLimit, noRateLimit, noRateLimit
+	if cfg != nil && cfg.RateLimit.Enabled {
+		var store middleware.RateLimitStore
+		if queueManager != nil {
+			store = middleware.NewRedisRateLimitStore(queueManager.RedisClient())
+		}
+		var opts []middleware.DistributedRateLimiterOption
+		if rateLimitAlerter != nil {
+			opts = append(opts, middleware.WithRateLimitAlerter(rateLimitAlerter))
+		}
+		rateLimiter := middleware.NewDistributedRateLimiter(store, cfg.RateLimit.RedisRetryInterval, logger, opts...)
+
+		globalLimit = rateLimiter.Limit(middleware.RateLimitPolicy{
+			Name:     "global",
+			Requests: cfg.RateLimit.Global.Requests,
+			Window:   cfg.RateLimit.Global.Window,
+			Key:      middleware.RateLimitKeyByIP,
+		})
+		loginLimit = rateLimiter.Limit(middleware.RateLimitPolicy{
+			Name:     "login",
+			Requests: cfg.RateLimit.Login.Requests,
+			Window:   cfg.RateLimit.Login.Window,
+			Key:      middleware.RateLimitKeyByIP,
+		})
+		transferLimit = rateLimiter.Limit(middleware.RateLimitPolicy{
+			Name:     "transfers",
+			Requests: cfg.RateLimit.Transfers.Requests,
+			Window:   cfg.RateLimit.Transfers.Window,
+			Key:      middleware.RateLimitKeyByUser,
+		})
+	}
+
 	// API v1 routes
 	v1 := router.Group("/api/v1")
 	{
@@ -76,33 +189,79 @@ func SetupRouter(db *database.DB, queueManager *queue.QueueManager, cfg *config.
 
 		// Authentication routes (no authentication required)
 		if authHandlers != nil {
-			auth := v1.Group("/auth")
+			authRoutes := v1.Group("/auth", globalLimit)
 			{
-				auth.POST("/register", authHandlers.Register)
-				auth.POST("/login", authHandlers.Login)
-				auth.POST("/logout", authHandlers.Logout)
+				authRoutes.POST("/register", authHandlers.Register)
+				authRoutes.POST("/login", loginLimit, authHandlers.Login)
+				authRoutes.POST("/logout", authHandlers.Logout)
 			}
 
-			// Protected routes (require authentication)
+			// OAuth2 client-credentials token endpoint for API keys
+			v1.POST("/oauth/token", globalLimit, authHandlers.IssueClientToken)
+
+			// Protected routes (require authentication). Each route names the
+			// scopes it needs; login tokens hold every scope, while API keys,
+			// client tokens and reduced-scope tokens only hold the ones granted.
+			// Managing credentials and settings needs a login token.
 			protected := v1.Group("")
-			protected.Use(authHandlers.AuthMiddleware())
+			protected.Use(globalLimit, authHandlers.AuthMiddleware())
 			{
+				readAccounts := middleware.RequireScope(auth.ScopeAccountsRead)
+				readTransfers := middleware.RequireScope(auth.ScopeTransfersRead)
+				writeTransfers := middleware.RequireScope(auth.ScopeTransfersWrite)
+				loginSession := middleware.RequireLoginSession()
+
+				// Reduced-scope tokens for read-only integrations and statement downloads
+				protected.POST("/auth/tokens", loginSession, authHandlers.IssueScopedToken)
+
+				// Recent sign-ins to the user's account
+				protected.GET("/auth/logins", loginSession, loginHistoryHandlers.GetLoginHistory)
+
 				// Account management routes
 				accounts := protected.Group("/accounts")
 				{
-					accounts.GET("", accountHandlers.GetUserAccounts)           // GET /accounts - List user accounts
-					accounts.POST("", accountHandlers.CreateAccount)           // POST /accounts - Create new account
-					accounts.GET("/:id", accountHandlers.GetAccount)           // GET /accounts/:id - Get account details
-					accounts.PUT("/:id", accountHandlers.UpdateAccount)        // PUT /accounts/:id - Update account
-					accounts.DELETE("/:id", accountHandlers.DeleteAccount)     // DELETE /accounts/:id - Delete account
+					accounts.GET("", readAccounts, accountHandlers.GetUserAccounts)           // GET /accounts - List user accounts
+					accounts.POST("", loginSession, accountHandlers.CreateAccount)           // POST /accounts - Create new account
+					accounts.GET("/:id", readAccounts, accountHandlers.GetAccount)           // GET /accounts/:id - Get account details
+					accounts.PUT("/:id", loginSession, accountHandlers.UpdateAccount)        // PUT /accounts/:id - Update account
+					accounts.DELETE("/:id", loginSession, accountHandlers.DeleteAccount)     // DELETE /accounts/:id - Delete account
 				}
 
 				// Transfer routes
-				transfers := protected.Group("/transfers")
+				transfers := protected.Group("/transfers", transferLimit)
+				{
+					transfers.POST("", writeTransfers, transferHandlers.CreateTransfer)        // POST /transfers - Create money transfer
+					transfers.GET("", readTransfers, transferHandlers.GetTransferHistory)     // GET /transfers - Get transfer history
+					transfers.GET("/limits", readTransfers, transferHandlers.GetTransferLimits) // GET /transfers/limits - Get transfer limits and usage
+					transfers.POST("/preview", readTransfers, transferHandlers.PreviewTransfer) // POST /transfers/preview - Quote transfer fee
+					transfers.POST("/batch", writeTransfers, transferBatchHandlers.CreateTransferBatch) // POST /transfers/batch - Submit a batch of transfers
+					transfers.GET("/batch/:id", readTransfers, transferBatchHandlers.GetTransferBatch) // GET /transfers/batch/:id - Get batch status and results
+					transfers.GET("/:id", readTransfers, transferHandlers.GetTransfer)        // GET /transfers/:id - Get transfer details
+				}
+
+				// API key routes
+				apiKeys := protected.Group("/api-keys", loginSession)
 				{
-					transfers.POST("", transferHandlers.CreateTransfer)        // POST /transfers - Create money transfer
-					transfers.GET("", transferHandlers.GetTransferHistory)     // GET /transfers - Get transfer history
-					transfers.GET("/:id", transferHandlers.GetTransfer)        // GET /transfers/:id - Get transfer details
+					apiKeys.POST("", apiKeyHandlers.CreateAPIKey)       // POST /api-keys - Create API key
+					apiKeys.GET("", apiKeyHandlers.GetAPIKeys)          // GET /api-keys - List API keys
+					apiKeys.DELETE("/:id", apiKeyHandlers.RevokeAPIKey) // DELETE /api-keys/:id - Revoke API key
+				}
+
+				// Webhook endpoint routes
+				if webhookHandlers != nil {
+					webhooks := protected.Group("/webhooks", loginSession)
+					{
+						webhooks.POST("", webhookHandlers.CreateWebhookEndpoint)        // POST /webhooks - Register endpoint
+						webhooks.GET("", webhookHandlers.GetWebhookEndpoints)           // GET /webhooks - List endpoints
+						webhooks.GET("/:id", webhookHandlers.GetWebhookEndpoint)        // GET /webhooks/:id - Get endpoint
+						webhooks.PATCH("/:id", webhookHandlers.UpdateWebhookEndpoint)   // PATCH /webhooks/:id - Update, disable or enable endpoint
+						webhooks.DELETE("/:id", webhookHandlers.DeleteWebhookEndpoint)  // DELETE /webhooks/:id - Delete endpoint
+						webhooks.GET("/:id/deliveries", webhookHandlers.GetWebhookDeliveries) // GET /webhooks/:id/deliveries - Delivery log
+						webhooks.POST("/:id/deliveries/:delivery_id/redeliver", webhookHandlers.RedeliverWebhook) // POST - Redeliver a logged delivery
+					}
+				} else {
+					protected.Any("/webhooks", serviceUnavailableHandler)
+					protected.Any("/webhooks/*path", serviceUnavailableHandler)
 				}
 			}
 		} else {
@@ -110,6 +269,12 @@ func SetupRouter(db *database.DB, queueManager *queue.QueueManager, cfg *config.
 			v1.POST("/auth/register", serviceUnavailableHandler)
 			v1.POST("/auth/login", serviceUnavailableHandler)
 			v1.POST("/auth/logout", serviceUnavailableHandler)
+			v1.POST("/oauth/token", serviceUnavailableHandler)
+			v1.POST("/auth/tokens", serviceUnavailableHandler)
+			v1.GET("/auth/logins", serviceUnavailableHandler)
+			v1.GET("/api-keys", serviceUnavailableHandler)
+			v1.POST("/api-keys", serviceUnavailableHandler)
+			v1.DELETE("/api-keys/:id", serviceUnavailableHandler)
 			v1.GET("/accounts", serviceUnavailableHandler)
 			v1.POST("/accounts", serviceUnavailableHandler)
 			v1.GET("/accounts/:id", serviceUnavailableHandler)
@@ -117,13 +282,24 @@ func SetupRouter(db *database.DB, queueManager *queue.QueueManager, cfg *config.
 			v1.DELETE("/accounts/:id", serviceUnavailableHandler)
 			v1.POST("/transfers", serviceUnavailableHandler)
 			v1.GET("/transfers", serviceUnavailableHandler)
+			v1.GET("/transfers/limits", serviceUnavailableHandler)
+			v1.POST("/transfers/preview", serviceUnavailableHandler)
+			v1.POST("/transfers/batch", serviceUnavailableHandler)
+			v1.GET("/transfers/batch/:id", serviceUnavailableHandler)
 			v1.GET("/transfers/:id", serviceUnavailableHandler)
+			v1.Any("/webhooks", serviceUnavailableHandler)
+			v1.Any("/webhooks/*path", serviceUnavailableHandler)
 		}
 	}
 
 	return router
 }
 
+// noRateLimit stands in for a rate limit when rate limiting is disabled
+func noRateLimit(c *gin.Context) {
+	c.Next()
+}
+
 // serviceUnavailableHandler returns a service unavailable response
 func serviceUnavailableHandler(c *gin.Context) {
 	c.JSON(503, gin.H{
@@ -7,7 +7,9 @@ import (
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/phantom-sage/bankgo/internal/config"
 	"github.com/phantom-sage/bankgo/internal/handlers"
+	"github.com/phantom-sage/bankgo/internal/middleware"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -16,7 +18,7 @@ func TestSetupRouter_HealthEndpoint(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	// Setup router with nil dependencies (simulating service unavailability)
-	router := SetupRouter(nil, nil, nil, "test-version")
+	router := SetupRouter(nil, nil, nil, nil, nil, "test-version")
 
 	t.Run("health_endpoint_registered", func(t *testing.T) {
 		// Test that the health endpoint is properly registered at /api/v1/health
@@ -113,13 +115,13 @@ func TestSetupRouter_Configuration(t *testing.T) {
 
 	t.Run("router_creation", func(t *testing.T) {
 		// Test that router is created successfully
-		router := SetupRouter(nil, nil, nil, "v1.0.0")
+		router := SetupRouter(nil, nil, nil, nil, nil, "v1.0.0")
 		assert.NotNil(t, router)
 	})
 
 	t.Run("api_v1_group", func(t *testing.T) {
 		// Test that API v1 group is properly configured
-		router := SetupRouter(nil, nil, nil, "v1.0.0")
+		router := SetupRouter(nil, nil, nil, nil, nil, "v1.0.0")
 
 		// Test a non-existent endpoint in the v1 group
 		req, err := http.NewRequest("GET", "/api/v1/nonexistent", nil)
@@ -131,4 +133,38 @@ func TestSetupRouter_Configuration(t *testing.T) {
 		// Should return 404 for non-existent endpoints, not 405
 		assert.Equal(t, http.StatusNotFound, w.Code)
 	})
-}
\ No newline at end of file
+}
+
+func TestSetupRouter_TrustedProxies(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	rateLimitKey := func(cfg *config.Config, remoteAddr, forwardedFor string) string {
+		router := SetupRouter(nil, nil, cfg, nil, nil, "test-version")
+		router.GET("/test/rate-limit-key", func(c *gin.Context) {
+			c.String(http.StatusOK, middleware.RateLimitKeyByIP(c))
+		})
+
+		req, err := http.NewRequest("GET", "/test/rate-limit-key", nil)
+		require.NoError(t, err)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		req.Header.Set("X-Real-IP", forwardedFor)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		return w.Body.String()
+	}
+
+	t.Run("spoofed X-Forwarded-For is ignored by default", func(t *testing.T) {
+		assert.Equal(t, "ip:203.0.113.7", rateLimitKey(nil, "203.0.113.7:5000", "198.51.100.1"))
+		assert.Equal(t, "ip:203.0.113.7", rateLimitKey(&config.Config{}, "203.0.113.7:5000", "198.51.100.2"))
+	})
+
+	t.Run("configured proxy forwards the client IP", func(t *testing.T) {
+		cfg := &config.Config{Server: config.ServerConfig{TrustedProxies: []string{"10.0.0.0/8"}}}
+
+		assert.Equal(t, "ip:198.51.100.1", rateLimitKey(cfg, "10.1.2.3:5000", "198.51.100.1"))
+		assert.Equal(t, "ip:203.0.113.7", rateLimitKey(cfg, "203.0.113.7:5000", "198.51.100.1"), "only the proxy may forward")
+	})
+}
@@ -9,8 +9,10 @@ import (
 
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/phantom-sage/bankgo/internal/database/queries"
+	"github.com/phantom-sage/bankgo/internal/events"
 	"github.com/phantom-sage/bankgo/internal/logging"
 	"github.com/phantom-sage/bankgo/internal/models"
+	"github.com/phantom-sage/bankgo/internal/outbox"
 	"github.com/phantom-sage/bankgo/internal/repository"
 	"github.com/rs/zerolog"
 	"github.com/shopspring/decimal"
@@ -32,10 +34,18 @@ type AccountServiceImpl struct {
 	logger          zerolog.Logger
 	auditLogger     *logging.AuditLogger
 	performanceLogger *logging.PerformanceLogger
+	outbox          *repository.Repository
 }
 
 // NewAccountService creates a new account service
 func NewAccountService(accountRepo repository.AccountRepository, transferRepo repository.TransferRepository, logger zerolog.Logger) AccountService {
+	return NewAccountServiceWithOutbox(accountRepo, transferRepo, nil, logger)
+}
+
+// NewAccountServiceWithOutbox creates an account service that writes
+// account.created and account.deleted events to the outbox in the transaction
+// that creates or deletes the account. A nil outbox disables events.
+func NewAccountServiceWithOutbox(accountRepo repository.AccountRepository, transferRepo repository.TransferRepository, outbox *repository.Repository, logger zerolog.Logger) AccountService {
 	auditLogger := logging.NewAuditLogger(logger)
 	performanceLogger := logging.NewPerformanceLogger(logger)
 	return &AccountServiceImpl{
@@ -44,6 +54,7 @@ func NewAccountService(accountRepo repository.AccountRepository, transferRepo re
 		logger:            logger.With().Str("component", "account_service").Logger(),
 		auditLogger:       auditLogger,
 		performanceLogger: performanceLogger,
+		outbox:            outbox,
 	}
 }
 
@@ -105,7 +116,7 @@ func (s *AccountServiceImpl) CreateAccount(ctx context.Context, userID int32, cu
 
 	// Create the account
 	dbStart = time.Now()
-	dbAccount, err := s.accountRepo.CreateAccount(ctx, queries.CreateAccountParams{
+	dbAccount, err := s.createAccount(ctx, queries.CreateAccountParams{
 		UserID:   userID,
 		Currency: tempAccount.Currency,
 		Column3:  nil, // Use default balance of 0.00
@@ -396,7 +407,11 @@ func (s *AccountServiceImpl) DeleteAccount(ctx context.Context, accountID int32,
 
 	// Delete the account (database constraint ensures balance is zero)
 	dbStart = time.Now()
-	err = s.accountRepo.DeleteAccount(ctx, accountID)
+	err = s.deleteAccount(ctx, accountID, events.AccountPayload{
+		AccountID: accountID,
+		UserID:    userID,
+		Currency:  account.Currency,
+	})
 	s.performanceLogger.LogDatabaseQuery("DELETE account", time.Since(dbStart), 1)
 	
 	if err != nil {
@@ -423,6 +438,44 @@ func (s *AccountServiceImpl) DeleteAccount(ctx context.Context, accountID int32,
 	return nil
 }
 
+// createAccount inserts an account, staging account.created in the same
+// transaction when the outbox is enabled
+func (s *AccountServiceImpl) createAccount(ctx context.Context, params queries.CreateAccountParams) (queries.Account, error) {
+	if s.outbox == nil {
+		return s.accountRepo.CreateAccount(ctx, params)
+	}
+
+	var dbAccount queries.Account
+	err := s.outbox.WithTx(ctx, func(qtx *queries.Queries) error {
+		var err error
+		dbAccount, err = qtx.CreateAccount(ctx, params)
+		if err != nil {
+			return err
+		}
+		return stageEvent(ctx, qtx, outbox.AggregateAccount, dbAccount.ID, events.TypeAccountCreated, events.AccountPayload{
+			AccountID: dbAccount.ID,
+			UserID:    dbAccount.UserID,
+			Currency:  dbAccount.Currency,
+		})
+	})
+	return dbAccount, err
+}
+
+// deleteAccount deletes an account, staging account.deleted in the same
+// transaction when the outbox is enabled
+func (s *AccountServiceImpl) deleteAccount(ctx context.Context, accountID int32, payload events.AccountPayload) error {
+	if s.outbox == nil {
+		return s.accountRepo.DeleteAccount(ctx, accountID)
+	}
+
+	return s.outbox.WithTx(ctx, func(qtx *queries.Queries) error {
+		if err := qtx.DeleteAccount(ctx, accountID); err != nil {
+			return err
+		}
+		return stageEvent(ctx, qtx, outbox.AggregateAccount, accountID, events.TypeAccountDeleted, payload)
+	})
+}
+
 // convertDBAccountToModel converts a database account to a model account
 func (s *AccountServiceImpl) convertDBAccountToModel(dbAccount queries.Account) (*models.Account, error) {
 	// Convert pgtype.Numeric to decimal.Decimal
@@ -8,6 +8,7 @@ import (
 
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/phantom-sage/bankgo/internal/database/queries"
+	"github.com/rs/zerolog"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -128,6 +129,16 @@ func (m *MockTransferRepository) CountTransfersByAccount(ctx context.Context, fr
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *MockTransferRepository) ListUserTransfersOlder(ctx context.Context, arg queries.ListUserTransfersOlderParams) ([]queries.ListUserTransfersOlderRow, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]queries.ListUserTransfersOlderRow), args.Error(1)
+}
+
+func (m *MockTransferRepository) ListUserTransfersNewer(ctx context.Context, arg queries.ListUserTransfersNewerParams) ([]queries.ListUserTransfersNewerRow, error) {
+	args := m.Called(ctx, arg)
+	return args.Get(0).([]queries.ListUserTransfersNewerRow), args.Error(1)
+}
+
 // Helper function to create a valid pgtype.Numeric
 func createPgNumeric(value string) pgtype.Numeric {
 	dec, _ := decimal.NewFromString(value)
@@ -152,7 +163,7 @@ func TestAccountService_CreateAccount(t *testing.T) {
 	t.Run("successful account creation", func(t *testing.T) {
 		mockAccountRepo := new(MockAccountRepository)
 		mockTransferRepo := new(MockTransferRepository)
-		service := NewAccountService(mockAccountRepo, mockTransferRepo)
+		service := NewAccountService(mockAccountRepo, mockTransferRepo, zerolog.Nop())
 
 		userID := int32(1)
 		currency := "USD"
@@ -193,7 +204,7 @@ func TestAccountService_CreateAccount(t *testing.T) {
 	t.Run("invalid currency format", func(t *testing.T) {
 		mockAccountRepo := new(MockAccountRepository)
 		mockTransferRepo := new(MockTransferRepository)
-		service := NewAccountService(mockAccountRepo, mockTransferRepo)
+		service := NewAccountService(mockAccountRepo, mockTransferRepo, zerolog.Nop())
 
 		userID := int32(1)
 		currency := "INVALID"
@@ -208,7 +219,7 @@ func TestAccountService_CreateAccount(t *testing.T) {
 	t.Run("duplicate currency for user", func(t *testing.T) {
 		mockAccountRepo := new(MockAccountRepository)
 		mockTransferRepo := new(MockTransferRepository)
-		service := NewAccountService(mockAccountRepo, mockTransferRepo)
+		service := NewAccountService(mockAccountRepo, mockTransferRepo, zerolog.Nop())
 
 		userID := int32(1)
 		currency := "USD"
@@ -239,7 +250,7 @@ func TestAccountService_GetAccount(t *testing.T) {
 	t.Run("successful account retrieval", func(t *testing.T) {
 		mockAccountRepo := new(MockAccountRepository)
 		mockTransferRepo := new(MockTransferRepository)
-		service := NewAccountService(mockAccountRepo, mockTransferRepo)
+		service := NewAccountService(mockAccountRepo, mockTransferRepo, zerolog.Nop())
 
 		accountID := int32(1)
 		userID := int32(1)
@@ -269,7 +280,7 @@ func TestAccountService_GetAccount(t *testing.T) {
 	t.Run("account not found", func(t *testing.T) {
 		mockAccountRepo := new(MockAccountRepository)
 		mockTransferRepo := new(MockTransferRepository)
-		service := NewAccountService(mockAccountRepo, mockTransferRepo)
+		service := NewAccountService(mockAccountRepo, mockTransferRepo, zerolog.Nop())
 
 		accountID := int32(999)
 		userID := int32(1)
@@ -287,7 +298,7 @@ func TestAccountService_GetAccount(t *testing.T) {
 	t.Run("access denied - wrong user", func(t *testing.T) {
 		mockAccountRepo := new(MockAccountRepository)
 		mockTransferRepo := new(MockTransferRepository)
-		service := NewAccountService(mockAccountRepo, mockTransferRepo)
+		service := NewAccountService(mockAccountRepo, mockTransferRepo, zerolog.Nop())
 
 		accountID := int32(1)
 		userID := int32(1)
@@ -314,7 +325,7 @@ func TestAccountService_GetUserAccounts(t *testing.T) {
 	t.Run("successful user accounts retrieval", func(t *testing.T) {
 		mockAccountRepo := new(MockAccountRepository)
 		mockTransferRepo := new(MockTransferRepository)
-		service := NewAccountService(mockAccountRepo, mockTransferRepo)
+		service := NewAccountService(mockAccountRepo, mockTransferRepo, zerolog.Nop())
 
 		userID := int32(1)
 		now := time.Now()
@@ -351,7 +362,7 @@ func TestAccountService_GetUserAccounts(t *testing.T) {
 	t.Run("no accounts found", func(t *testing.T) {
 		mockAccountRepo := new(MockAccountRepository)
 		mockTransferRepo := new(MockTransferRepository)
-		service := NewAccountService(mockAccountRepo, mockTransferRepo)
+		service := NewAccountService(mockAccountRepo, mockTransferRepo, zerolog.Nop())
 
 		userID := int32(1)
 
@@ -371,7 +382,7 @@ func TestAccountService_DeleteAccount(t *testing.T) {
 	t.Run("successful account deletion", func(t *testing.T) {
 		mockAccountRepo := new(MockAccountRepository)
 		mockTransferRepo := new(MockTransferRepository)
-		service := NewAccountService(mockAccountRepo, mockTransferRepo)
+		service := NewAccountService(mockAccountRepo, mockTransferRepo, zerolog.Nop())
 
 		accountID := int32(1)
 		userID := int32(1)
@@ -404,7 +415,7 @@ func TestAccountService_DeleteAccount(t *testing.T) {
 	t.Run("cannot delete account with non-zero balance", func(t *testing.T) {
 		mockAccountRepo := new(MockAccountRepository)
 		mockTransferRepo := new(MockTransferRepository)
-		service := NewAccountService(mockAccountRepo, mockTransferRepo)
+		service := NewAccountService(mockAccountRepo, mockTransferRepo, zerolog.Nop())
 
 		accountID := int32(1)
 		userID := int32(1)
@@ -431,7 +442,7 @@ func TestAccountService_DeleteAccount(t *testing.T) {
 	t.Run("cannot delete account with transaction history", func(t *testing.T) {
 		mockAccountRepo := new(MockAccountRepository)
 		mockTransferRepo := new(MockTransferRepository)
-		service := NewAccountService(mockAccountRepo, mockTransferRepo)
+		service := NewAccountService(mockAccountRepo, mockTransferRepo, zerolog.Nop())
 
 		accountID := int32(1)
 		userID := int32(1)
@@ -466,7 +477,7 @@ func TestAccountService_UpdateAccount(t *testing.T) {
 	t.Run("successful account update", func(t *testing.T) {
 		mockAccountRepo := new(MockAccountRepository)
 		mockTransferRepo := new(MockTransferRepository)
-		service := NewAccountService(mockAccountRepo, mockTransferRepo)
+		service := NewAccountService(mockAccountRepo, mockTransferRepo, zerolog.Nop())
 
 		accountID := int32(1)
 		userID := int32(1)
@@ -509,7 +520,7 @@ func TestAccountService_UpdateAccount(t *testing.T) {
 	t.Run("update account - access denied for wrong user", func(t *testing.T) {
 		mockAccountRepo := new(MockAccountRepository)
 		mockTransferRepo := new(MockTransferRepository)
-		service := NewAccountService(mockAccountRepo, mockTransferRepo)
+		service := NewAccountService(mockAccountRepo, mockTransferRepo, zerolog.Nop())
 
 		accountID := int32(1)
 		userID := int32(1)
@@ -534,7 +545,7 @@ func TestAccountService_UpdateAccount(t *testing.T) {
 	t.Run("update account - account not found", func(t *testing.T) {
 		mockAccountRepo := new(MockAccountRepository)
 		mockTransferRepo := new(MockTransferRepository)
-		service := NewAccountService(mockAccountRepo, mockTransferRepo)
+		service := NewAccountService(mockAccountRepo, mockTransferRepo, zerolog.Nop())
 
 		accountID := int32(999)
 		userID := int32(1)
@@ -0,0 +1,300 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/phantom-sage/bankgo/internal/config"
+	"github.com/phantom-sage/bankgo/internal/database/queries"
+	"github.com/phantom-sage/bankgo/internal/logging"
+	"github.com/phantom-sage/bankgo/internal/repository"
+	"github.com/phantom-sage/bankgo/internal/utils"
+	"github.com/phantom-sage/bankgo/pkg/auth"
+	"github.com/rs/zerolog"
+)
+
+// API key errors
+var (
+	// ErrAPIKeyNotFound is returned for unknown keys, already revoked keys and keys owned by another user
+	ErrAPIKeyNotFound = errors.New("API key not found")
+	// ErrInvalidAPIKey is returned for any key or client credentials that do not
+	// authenticate, without saying why
+	ErrInvalidAPIKey = errors.New("invalid API key")
+)
+
+// APIKeyValidationError is returned when a key request is rejected
+type APIKeyValidationError struct {
+	Message string
+}
+
+func (e *APIKeyValidationError) Error() string {
+	return fmt.Sprintf("invalid API key request: %s", e.Message)
+}
+
+// APIKeyService manages users' API keys and authenticates machine clients with them
+type APIKeyService interface {
+	CreateAPIKey(ctx context.Context, userID int32, req CreateAPIKeyRequest) (*APIKey, error)
+	ListAPIKeys(ctx context.Context, userID int32) ([]APIKey, error)
+	RevokeAPIKey(ctx context.Context, userID, keyID int32) error
+
+	// AuthenticateAPIKey checks a key presented on a request
+	AuthenticateAPIKey(ctx context.Context, key string) (*APIKeyPrincipal, error)
+	// AuthenticateClient checks OAuth2 client credentials, which are a key
+	// split into its prefix and secret
+	AuthenticateClient(ctx context.Context, clientID, clientSecret string) (*APIKeyPrincipal, error)
+}
+
+// CreateAPIKeyRequest asks for a key with some scopes. A nil ExpiresAt
+// creates a key that expires after the configured maximum lifetime.
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// APIKey is a user's API key. Key is only returned when the key is created.
+type APIKey struct {
+	ID         int32      `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	Key        string     `json:"key,omitempty"`
+}
+
+// APIKeyPrincipal is the user and scopes an authenticated key acts with
+type APIKeyPrincipal struct {
+	KeyID    int32
+	UserID   int32
+	Email    string
+	ClientID string
+	Scopes   []string
+}
+
+// APIKeyServiceImpl implements APIKeyService
+type APIKeyServiceImpl struct {
+	repo        *repository.Repository
+	cfg         config.APIKeyConfig
+	logger      zerolog.Logger
+	auditLogger *logging.AuditLogger
+	now         func() time.Time
+}
+
+// NewAPIKeyService creates a new API key service
+func NewAPIKeyService(repo *repository.Repository, cfg config.APIKeyConfig, logger zerolog.Logger) APIKeyService {
+	return &APIKeyServiceImpl{
+		repo:        repo,
+		cfg:         cfg,
+		logger:      logger.With().Str("component", "api_key_service").Logger(),
+		auditLogger: logging.NewAuditLogger(logger),
+		now:         time.Now,
+	}
+}
+
+// CreateAPIKey generates a key and returns it with the key itself, which is
+// not stored and cannot be shown again
+func (s *APIKeyServiceImpl) CreateAPIKey(ctx context.Context, userID int32, req CreateAPIKeyRequest) (*APIKey, error) {
+	contextLogger := logging.NewContextLogger(s.logger, ctx).WithOperation("create_api_key")
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > 100 {
+		return nil, &APIKeyValidationError{Message: "name must be between 1 and 100 characters"}
+	}
+	scopes, err := validateAPIKeyScopes(req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	expiresAt := now.Add(s.cfg.MaxLifetime)
+	if req.ExpiresAt != nil {
+		if !req.ExpiresAt.After(now) {
+			return nil, &APIKeyValidationError{Message: "expires_at must be in the future"}
+		}
+		if req.ExpiresAt.After(expiresAt) {
+			return nil, &APIKeyValidationError{Message: fmt.Sprintf("keys cannot be valid for more than %s", s.cfg.MaxLifetime)}
+		}
+		expiresAt = *req.ExpiresAt
+	}
+
+	count, err := s.repo.CountActiveAPIKeysByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count API keys: %w", err)
+	}
+	if count >= int64(s.cfg.MaxKeysPerUser) {
+		return nil, &APIKeyValidationError{Message: fmt.Sprintf("cannot hold more than %d active keys", s.cfg.MaxKeysPerUser)}
+	}
+
+	generated, err := auth.GenerateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	dbKey, err := s.repo.CreateAPIKey(ctx, queries.CreateAPIKeyParams{
+		UserID:    userID,
+		Name:      name,
+		Prefix:    generated.Prefix,
+		KeyHash:   generated.Hash,
+		Scopes:    scopes,
+		ExpiresAt: pgtype.Timestamp{Time: expiresAt.UTC(), Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create API key: %w", err)
+	}
+
+	s.auditLogger.LogSecurityEvent("api_key_created", "api_key_service",
+		fmt.Sprintf("user %d created API key %s with scopes %s", userID, dbKey.Prefix, strings.Join(scopes, ",")))
+	contextLogger.Info().
+		Int32("user_id", userID).
+		Int32("api_key_id", dbKey.ID).
+		Str("prefix", dbKey.Prefix).
+		Strs("scopes", scopes).
+		Msg("API key created")
+
+	key := convertDBAPIKeyToModel(dbKey)
+	key.Key = generated.Key
+	return key, nil
+}
+
+// ListAPIKeys returns the user's keys, including revoked and expired ones
+func (s *APIKeyServiceImpl) ListAPIKeys(ctx context.Context, userID int32) ([]APIKey, error) {
+	dbKeys, err := s.repo.ListAPIKeysByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+
+	keys := make([]APIKey, len(dbKeys))
+	for i, dbKey := range dbKeys {
+		keys[i] = *convertDBAPIKeyToModel(dbKey)
+	}
+	return keys, nil
+}
+
+// RevokeAPIKey stops a key from authenticating. Client tokens already issued
+// for it stay valid until they expire.
+func (s *APIKeyServiceImpl) RevokeAPIKey(ctx context.Context, userID, keyID int32) error {
+	revoked, err := s.repo.RevokeAPIKey(ctx, queries.RevokeAPIKeyParams{ID: keyID, UserID: userID})
+	if err != nil {
+		return fmt.Errorf("failed to revoke API key: %w", err)
+	}
+	if revoked == 0 {
+		return ErrAPIKeyNotFound
+	}
+
+	s.auditLogger.LogSecurityEvent("api_key_revoked", "api_key_service",
+		fmt.Sprintf("user %d revoked API key %d", userID, keyID))
+	logging.NewContextLogger(s.logger, ctx).WithOperation("revoke_api_key").Info().
+		Int32("user_id", userID).
+		Int32("api_key_id", keyID).
+		Msg("API key revoked")
+	return nil
+}
+
+// AuthenticateAPIKey checks a key against its stored hash, expiry,
+// revocation and owner, and records that it was used
+func (s *APIKeyServiceImpl) AuthenticateAPIKey(ctx context.Context, key string) (*APIKeyPrincipal, error) {
+	prefix, err := auth.ParseAPIKey(key)
+	if err != nil {
+		return nil, ErrInvalidAPIKey
+	}
+
+	credentials, err := s.repo.GetAPIKeyCredentials(ctx, prefix)
+	if errors.Is(err, pgx.ErrNoRows) {
+		s.auditLogger.LogFailedAuthentication(prefix, "api_key_not_found", "")
+		return nil, ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+
+	reason := ""
+	switch {
+	case !auth.VerifyAPIKey(key, credentials.KeyHash):
+		reason = "api_key_mismatch"
+	case credentials.RevokedAt.Valid:
+		reason = "api_key_revoked"
+	case credentials.ExpiresAt.Valid && !s.now().Before(credentials.ExpiresAt.Time):
+		reason = "api_key_expired"
+	case credentials.IsActive.Valid && !credentials.IsActive.Bool:
+		reason = "user_disabled"
+	}
+	if reason != "" {
+		s.auditLogger.LogFailedAuthentication(credentials.Email, reason, "")
+		return nil, ErrInvalidAPIKey
+	}
+
+	if err := s.repo.TouchAPIKey(ctx, credentials.ID); err != nil {
+		logging.NewContextLogger(s.logger, ctx).WithOperation("authenticate_api_key").Warn().
+			Err(err).
+			Int32("api_key_id", credentials.ID).
+			Msg("Failed to record API key use")
+	}
+
+	return &APIKeyPrincipal{
+		KeyID:    credentials.ID,
+		UserID:   credentials.UserID,
+		Email:    credentials.Email,
+		ClientID: prefix,
+		Scopes:   credentials.Scopes,
+	}, nil
+}
+
+// AuthenticateClient checks OAuth2 client credentials
+func (s *APIKeyServiceImpl) AuthenticateClient(ctx context.Context, clientID, clientSecret string) (*APIKeyPrincipal, error) {
+	if clientID == "" || clientSecret == "" {
+		return nil, ErrInvalidAPIKey
+	}
+	principal, err := s.AuthenticateAPIKey(ctx, auth.JoinAPIKey(clientID, clientSecret))
+	if err != nil {
+		return nil, err
+	}
+	s.auditLogger.LogAuthentication(int64(principal.UserID), principal.Email, "client_credentials", "success")
+	return principal, nil
+}
+
+// validateAPIKeyScopes checks scopes against the ones keys can be granted and
+// drops duplicates
+func validateAPIKeyScopes(scopes []string) ([]string, error) {
+	if len(scopes) == 0 {
+		return nil, &APIKeyValidationError{Message: "at least one scope is required"}
+	}
+
+	for _, scope := range scopes {
+		if !auth.IsScope(scope) {
+			return nil, &APIKeyValidationError{Message: fmt.Sprintf("unsupported scope %q", scope)}
+		}
+	}
+	return auth.UniqueScopes(scopes), nil
+}
+
+// convertDBAPIKeyToModel converts a database key, leaving out its hash
+func convertDBAPIKeyToModel(dbKey queries.ApiKey) *APIKey {
+	key := &APIKey{
+		ID:        dbKey.ID,
+		Name:      dbKey.Name,
+		Prefix:    dbKey.Prefix,
+		Scopes:    dbKey.Scopes,
+		CreatedAt: utils.ConvertPgTimestampToTime(dbKey.CreatedAt),
+	}
+	if dbKey.ExpiresAt.Valid {
+		expiresAt := utils.ConvertPgTimestampToTime(dbKey.ExpiresAt)
+		key.ExpiresAt = &expiresAt
+	}
+	if dbKey.LastUsedAt.Valid {
+		lastUsedAt := utils.ConvertPgTimestampToTime(dbKey.LastUsedAt)
+		key.LastUsedAt = &lastUsedAt
+	}
+	if dbKey.RevokedAt.Valid {
+		revokedAt := utils.ConvertPgTimestampToTime(dbKey.RevokedAt)
+		key.RevokedAt = &revokedAt
+	}
+	return key
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/phantom-sage/bankgo/internal/database/queries"
+	"github.com/phantom-sage/bankgo/internal/events"
+	"github.com/phantom-sage/bankgo/internal/outbox"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+)
+
+// WithEventOutbox writes transfer.completed and transfer.failed events to the
+// outbox, from where the relay publishes them
+func WithEventOutbox() TransferServiceOption {
+	return func(s *TransferServiceImpl) {
+		s.eventOutbox = true
+	}
+}
+
+// stageEvent writes a domain event to the outbox through q. When q is bound to
+// a transaction the event is published if and only if that transaction
+// commits. Events of one aggregate are published in the order staged.
+func stageEvent(ctx context.Context, q *queries.Queries, aggregateType string, aggregateID int32, eventType string, payload interface{}) error {
+	event, err := events.New(eventType, payload)
+	if err != nil {
+		return err
+	}
+	message, err := outbox.Event(aggregateType, strconv.Itoa(int(aggregateID)), event)
+	if err != nil {
+		return err
+	}
+	return outbox.Add(ctx, q, message)
+}
+
+// stageTransferCompleted writes a transfer.completed event in the transaction
+// that booked the transfer, ordered with the other events of its source account
+func (s *TransferServiceImpl) stageTransferCompleted(ctx context.Context, qtx *queries.Queries, payload events.TransferPayload) error {
+	if !s.eventOutbox {
+		return nil
+	}
+	return stageEvent(ctx, qtx, outbox.AggregateAccount, payload.FromAccountID, events.TypeTransferCompleted, payload)
+}
+
+// stageTransferFailed writes a transfer.failed event for a rejected request.
+// Nothing was booked, so there is no transaction to join; the event feeds
+// dashboards and a failure to stage it is logged rather than returned.
+func (s *TransferServiceImpl) stageTransferFailed(ctx context.Context, req TransferMoneyRequest, reason string, cause error, batchID int32) {
+	if !s.eventOutbox {
+		return
+	}
+
+	payload := events.TransferPayload{
+		FromAccountID: req.FromAccountID,
+		ToAccountID:   req.ToAccountID,
+		Amount:        req.Amount.StringFixed(2),
+		BatchID:       batchID,
+		Reason:        reason,
+	}
+	if cause != nil {
+		payload.Error = cause.Error()
+	}
+	err := stageEvent(ctx, s.repo.Queries, outbox.AggregateAccount, req.FromAccountID, events.TypeTransferFailed, payload)
+	logStageFailure(s.logger, err, events.TypeTransferFailed)
+}
+
+// logStageFailure logs an event that could not be written to the outbox
+func logStageFailure(logger zerolog.Logger, err error, eventType string) {
+	if err == nil {
+		return
+	}
+	logger.Warn().
+		Err(err).
+		Str("event_type", eventType).
+		Msg("Failed to write domain event to outbox")
+}
+
+// transferFailureReason names why a transfer transaction failed, using the
+// same codes as batch items
+func transferFailureReason(err error) string {
+	if code := transferBatchErrorCode(err); code != "" {
+		return code
+	}
+	return "transaction_error"
+}
+
+// feeString formats a fee for an event, leaving zero fees out
+func feeString(fee decimal.Decimal) string {
+	if fee.IsZero() {
+		return ""
+	}
+	return fee.StringFixed(2)
+}
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/phantom-sage/bankgo/internal/database/queries"
+	"github.com/phantom-sage/bankgo/internal/models"
+	"github.com/phantom-sage/bankgo/internal/utils"
+	"github.com/shopspring/decimal"
+)
+
+// TransferFeeQuote is the fee breakdown for a transfer before it is executed
+type TransferFeeQuote struct {
+	FromAccountID     int32           `json:"from_account_id"`
+	ToAccountID       int32           `json:"to_account_id"`
+	Currency          string          `json:"currency"`
+	Amount            decimal.Decimal `json:"amount"`
+	Fee               decimal.Decimal `json:"fee"`
+	TotalDebit        decimal.Decimal `json:"total_debit"`
+	FeeScheduleID     *int32          `json:"fee_schedule_id,omitempty"`
+	FeeScheduleName   string          `json:"fee_schedule_name,omitempty"`
+	SufficientBalance bool            `json:"sufficient_balance"`
+	houseAccountID    int32
+}
+
+// quoteTransferFee prices a transfer from fromAccount against the active fee
+// schedule for its currency. Currencies without a schedule are free, as are
+// transfers out of the schedule's own house account.
+func quoteTransferFee(ctx context.Context, q *queries.Queries, fromAccount *models.Account, toAccountID int32, amount decimal.Decimal) (*TransferFeeQuote, error) {
+	quote := &TransferFeeQuote{
+		FromAccountID: int32(fromAccount.ID),
+		ToAccountID:   toAccountID,
+		Currency:      fromAccount.Currency,
+		Amount:        amount,
+		Fee:           decimal.Zero,
+	}
+
+	dbSchedule, err := q.GetActiveFeeSchedule(ctx, fromAccount.Currency)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get fee schedule: %w", err)
+	}
+
+	if err == nil && dbSchedule.HouseAccountID != quote.FromAccountID {
+		schedule, err := convertDBFeeScheduleToModel(dbSchedule)
+		if err != nil {
+			return nil, err
+		}
+
+		quote.Fee = schedule.Calculate(amount)
+		quote.FeeScheduleID = &dbSchedule.ID
+		quote.FeeScheduleName = schedule.Name
+		quote.houseAccountID = dbSchedule.HouseAccountID
+	}
+
+	quote.TotalDebit = amount.Add(quote.Fee)
+	quote.SufficientBalance = fromAccount.Balance.GreaterThanOrEqual(quote.TotalDebit)
+
+	return quote, nil
+}
+
+// collectTransferFee credits the quoted fee to the house account and records
+// it against the transfer, inside the caller's transaction. The house account
+// is always locked after the transfer's own accounts; the rare cycle with a
+// transfer out of the house account itself is resolved by the transaction retry.
+func collectTransferFee(ctx context.Context, qtx *queries.Queries, transferID int32, quote *TransferFeeQuote) error {
+	if !quote.Fee.IsPositive() {
+		return nil
+	}
+
+	fee := utils.ConvertDecimalToPgNumeric(quote.Fee)
+	if _, err := qtx.AddToBalance(ctx, queries.AddToBalanceParams{
+		ID:      quote.houseAccountID,
+		Balance: fee,
+	}); err != nil {
+		return fmt.Errorf("failed to credit house account: %w", err)
+	}
+
+	if _, err := qtx.CreateTransferFee(ctx, queries.CreateTransferFeeParams{
+		TransferID:     transferID,
+		FeeScheduleID:  pgtype.Int4{Int32: *quote.FeeScheduleID, Valid: true},
+		HouseAccountID: quote.houseAccountID,
+		Amount:         fee,
+		Currency:       quote.Currency,
+	}); err != nil {
+		return fmt.Errorf("failed to record transfer fee: %w", err)
+	}
+
+	return nil
+}
+
+// attachTransferFees fills in the Fee of each transfer from the fee ledger
+func attachTransferFees(ctx context.Context, q *queries.Queries, transfers []models.Transfer) error {
+	if len(transfers) == 0 {
+		return nil
+	}
+
+	ids := make([]int32, len(transfers))
+	for i, transfer := range transfers {
+		ids[i] = int32(transfer.ID)
+	}
+
+	fees, err := q.ListTransferFeesByTransferIDs(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to get transfer fees: %w", err)
+	}
+
+	byTransfer := make(map[int]decimal.Decimal, len(fees))
+	for _, fee := range fees {
+		amount, err := utils.ConvertPgNumericToDecimal(fee.Amount)
+		if err != nil {
+			return fmt.Errorf("failed to convert fee amount: %w", err)
+		}
+		byTransfer[int(fee.TransferID)] = amount
+	}
+
+	for i := range transfers {
+		transfers[i].Fee = byTransfer[transfers[i].ID]
+	}
+
+	return nil
+}
+
+// convertDBFeeScheduleToModel converts a database fee schedule to business model
+func convertDBFeeScheduleToModel(dbSchedule queries.FeeSchedule) (*models.FeeSchedule, error) {
+	flatAmount, err := utils.ConvertPgNumericToDecimal(dbSchedule.FlatAmount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert flat amount: %w", err)
+	}
+
+	percentage, err := utils.ConvertPgNumericToDecimal(dbSchedule.Percentage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert percentage: %w", err)
+	}
+
+	schedule := &models.FeeSchedule{
+		ID:             int(dbSchedule.ID),
+		Name:           dbSchedule.Name,
+		Currency:       dbSchedule.Currency,
+		FeeType:        dbSchedule.FeeType,
+		FlatAmount:     flatAmount,
+		Percentage:     percentage,
+		HouseAccountID: int(dbSchedule.HouseAccountID),
+		IsActive:       dbSchedule.IsActive,
+		CreatedAt:      utils.ConvertPgTimestampToTime(dbSchedule.CreatedAt),
+		UpdatedAt:      utils.ConvertPgTimestampToTime(dbSchedule.UpdatedAt),
+	}
+
+	if dbSchedule.MinFee.Valid {
+		minFee, err := utils.ConvertPgNumericToDecimal(dbSchedule.MinFee)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert minimum fee: %w", err)
+		}
+		schedule.MinFee = &minFee
+	}
+
+	if dbSchedule.MaxFee.Valid {
+		maxFee, err := utils.ConvertPgNumericToDecimal(dbSchedule.MaxFee)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert maximum fee: %w", err)
+		}
+		schedule.MaxFee = &maxFee
+	}
+
+	if len(dbSchedule.Tiers) > 0 {
+		if err := json.Unmarshal(dbSchedule.Tiers, &schedule.Tiers); err != nil {
+			return nil, fmt.Errorf("failed to decode fee tiers: %w", err)
+		}
+	}
+
+	return schedule, nil
+}
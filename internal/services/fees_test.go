@@ -0,0 +1,44 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/phantom-sage/bankgo/internal/database/queries"
+	"github.com/phantom-sage/bankgo/internal/models"
+	"github.com/phantom-sage/bankgo/internal/utils"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertDBFeeScheduleToModel(t *testing.T) {
+	dbSchedule := queries.FeeSchedule{
+		ID:             4,
+		Name:           "Tiered USD",
+		Currency:       "USD",
+		FeeType:        models.FeeTypeTiered,
+		FlatAmount:     utils.ConvertDecimalToPgNumeric(decimal.Zero),
+		Percentage:     utils.ConvertDecimalToPgNumeric(decimal.Zero),
+		MaxFee:         utils.ConvertDecimalToPgNumeric(decimal.NewFromInt(20)),
+		Tiers:          []byte(`[{"up_to":"100","flat_amount":"1","percentage":"0"},{"flat_amount":"0","percentage":"1"}]`),
+		HouseAccountID: 9,
+		IsActive:       true,
+	}
+
+	schedule, err := convertDBFeeScheduleToModel(dbSchedule)
+	require.NoError(t, err)
+
+	assert.Nil(t, schedule.MinFee)
+	require.NotNil(t, schedule.MaxFee)
+	assert.Len(t, schedule.Tiers, 2)
+	assert.Equal(t, 9, schedule.HouseAccountID)
+
+	assert.Equal(t, "1.00", schedule.Calculate(decimal.NewFromInt(50)).StringFixed(2))
+	assert.Equal(t, "5.00", schedule.Calculate(decimal.NewFromInt(500)).StringFixed(2))
+	assert.Equal(t, "20.00", schedule.Calculate(decimal.NewFromInt(5000)).StringFixed(2))
+}
+
+func TestConvertDBFeeScheduleToModel_InvalidTiers(t *testing.T) {
+	_, err := convertDBFeeScheduleToModel(queries.FeeSchedule{Tiers: []byte(`{`)})
+	assert.Error(t, err)
+}
@@ -0,0 +1,267 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/phantom-sage/bankgo/internal/database/queries"
+	"github.com/phantom-sage/bankgo/internal/logging"
+	"github.com/phantom-sage/bankgo/internal/models"
+	"github.com/phantom-sage/bankgo/internal/outbox"
+	"github.com/phantom-sage/bankgo/internal/queue"
+	"github.com/phantom-sage/bankgo/internal/repository"
+	"github.com/rs/zerolog"
+)
+
+// Sign-in outcomes recorded in the login history
+const (
+	// LoginOutcomeSuccess is a sign-in that issued a token
+	LoginOutcomeSuccess = "success"
+	// LoginOutcomeFailed is a sign-in with a wrong password
+	LoginOutcomeFailed = "failed"
+	// LoginOutcomeLocked is a sign-in refused while the account or IP was
+	// delayed or locked out
+	LoginOutcomeLocked = "locked"
+)
+
+// Login history limits
+const (
+	// DefaultLoginHistoryLimit is how many sign-ins are listed by default
+	DefaultLoginHistoryLimit = 20
+	// MaxLoginHistoryLimit is the most sign-ins listed at once
+	MaxLoginHistoryLimit = 100
+
+	// maxUserAgentLength is the length of login_events.user_agent
+	maxUserAgentLength = 512
+)
+
+// LoginEvent is one sign-in in a user's login history
+type LoginEvent struct {
+	ID        int32     `json:"id"`
+	IPAddress string    `json:"ip_address"`
+	UserAgent string    `json:"user_agent"`
+	Outcome   string    `json:"outcome"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// LoginHistoryService records users' sign-ins and lists them
+type LoginHistoryService interface {
+	// RecordLogin records a successful sign-in of user and sets their last
+	// login, alerting them when it came from a new device or IP range
+	RecordLogin(ctx context.Context, user *models.User, ipAddress, userAgent string) error
+	// RecordFailedLogin records a sign-in as email that did not succeed.
+	// Attempts at emails with no account are not recorded.
+	RecordFailedLogin(ctx context.Context, email, ipAddress, userAgent, outcome string) error
+	// ListLoginEvents returns the user's most recent sign-ins, newest first
+	ListLoginEvents(ctx context.Context, userID int32, limit int32) ([]LoginEvent, error)
+}
+
+// LoginHistoryServiceImpl implements LoginHistoryService
+type LoginHistoryServiceImpl struct {
+	repo   *repository.Repository
+	alerts bool
+	logger zerolog.Logger
+	now    func() time.Time
+}
+
+// NewLoginHistoryService creates a login history service that records
+// sign-ins without alerting users about new devices
+func NewLoginHistoryService(repo *repository.Repository, logger zerolog.Logger) LoginHistoryService {
+	return newLoginHistoryService(repo, false, logger)
+}
+
+// NewLoginHistoryServiceWithAlerts creates a login history service that also
+// emails users signing in from a device or IP range they had not used
+// before. The email task is written to the outbox with the sign-in, so the
+// outbox must be relayed.
+func NewLoginHistoryServiceWithAlerts(repo *repository.Repository, logger zerolog.Logger) LoginHistoryService {
+	return newLoginHistoryService(repo, true, logger)
+}
+
+func newLoginHistoryService(repo *repository.Repository, alerts bool, logger zerolog.Logger) *LoginHistoryServiceImpl {
+	return &LoginHistoryServiceImpl{
+		repo:   repo,
+		alerts: alerts,
+		logger: logger.With().Str("component", "login_history_service").Logger(),
+		now:    time.Now,
+	}
+}
+
+// RecordLogin records a successful sign-in. A user's first sign-in is not
+// alerted on, as there is nothing to compare it with.
+func (s *LoginHistoryServiceImpl) RecordLogin(ctx context.Context, user *models.User, ipAddress, userAgent string) error {
+	contextLogger := logging.NewContextLogger(s.logger, ctx).
+		WithOperation("record_login").
+		WithUserID(int64(user.ID))
+
+	now := s.now()
+	userID := int32(user.ID)
+	userAgent = truncateUserAgent(userAgent)
+	network := ipRange(ipAddress)
+
+	var origins queries.GetLoginOriginsRow
+	var alerted bool
+	err := s.repo.WithTx(ctx, func(qtx *queries.Queries) error {
+		var err error
+		origins, err = qtx.GetLoginOrigins(ctx, queries.GetLoginOriginsParams{
+			UserID:    userID,
+			UserAgent: userAgent,
+			IpRange:   network,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to read login origins: %w", err)
+		}
+
+		_, err = qtx.CreateLoginEvent(ctx, queries.CreateLoginEventParams{
+			UserID:    userID,
+			IpAddress: ipAddress,
+			IpRange:   network,
+			UserAgent: userAgent,
+			Outcome:   LoginOutcomeSuccess,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to record login: %w", err)
+		}
+
+		err = qtx.SetUserLastLogin(ctx, queries.SetUserLastLoginParams{
+			ID:          userID,
+			LastLoginAt: pgtype.Timestamp{Time: now.UTC(), Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to set last login: %w", err)
+		}
+
+		if !s.alerts || !origins.HasLogins || (origins.KnownDevice && origins.KnownIpRange) {
+			return nil
+		}
+		message, err := outbox.Task(outbox.AggregateUser, strconv.Itoa(user.ID), queue.TypeNewDeviceLoginEmail, queue.NewDeviceLoginEmailPayload{
+			UserID:     user.ID,
+			Email:      user.Email,
+			FirstName:  user.FirstName,
+			IPAddress:  ipAddress,
+			UserAgent:  userAgent,
+			NewDevice:  !origins.KnownDevice,
+			NewIPRange: !origins.KnownIpRange,
+			LoginAt:    now.UTC(),
+		})
+		if err != nil {
+			return err
+		}
+		alerted = true
+		return outbox.Add(ctx, qtx, message)
+	})
+	if err != nil {
+		contextLogger.Error().
+			Err(err).
+			Str("client_ip", ipAddress).
+			Msg("Failed to record login")
+		return err
+	}
+
+	if alerted {
+		contextLogger.Info().
+			Str("client_ip", ipAddress).
+			Bool("new_device", !origins.KnownDevice).
+			Bool("new_ip_range", !origins.KnownIpRange).
+			Msg("New device login email scheduled")
+	}
+	return nil
+}
+
+// RecordFailedLogin records a sign-in that did not succeed against the
+// account signing in as email
+func (s *LoginHistoryServiceImpl) RecordFailedLogin(ctx context.Context, email, ipAddress, userAgent, outcome string) error {
+	contextLogger := logging.NewContextLogger(s.logger, ctx).
+		WithOperation("record_failed_login").
+		WithUserEmail(email)
+
+	user, err := s.repo.GetUserByEmail(ctx, email)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to look up user: %w", err)
+	}
+
+	_, err = s.repo.CreateLoginEvent(ctx, queries.CreateLoginEventParams{
+		UserID:    user.ID,
+		IpAddress: ipAddress,
+		IpRange:   ipRange(ipAddress),
+		UserAgent: truncateUserAgent(userAgent),
+		Outcome:   outcome,
+	})
+	if err != nil {
+		contextLogger.Error().
+			Err(err).
+			Int32("user_id", user.ID).
+			Str("outcome", outcome).
+			Msg("Failed to record failed login")
+		return fmt.Errorf("failed to record failed login: %w", err)
+	}
+	return nil
+}
+
+// ListLoginEvents returns up to limit of the user's most recent sign-ins
+func (s *LoginHistoryServiceImpl) ListLoginEvents(ctx context.Context, userID int32, limit int32) ([]LoginEvent, error) {
+	if limit <= 0 {
+		limit = DefaultLoginHistoryLimit
+	}
+	if limit > MaxLoginHistoryLimit {
+		limit = MaxLoginHistoryLimit
+	}
+
+	dbEvents, err := s.repo.ReadQueries().ListLoginEventsByUser(ctx, queries.ListLoginEventsByUserParams{
+		UserID: userID,
+		Limit:  limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list login events: %w", err)
+	}
+
+	events := make([]LoginEvent, len(dbEvents))
+	for i, dbEvent := range dbEvents {
+		events[i] = LoginEvent{
+			ID:        dbEvent.ID,
+			IPAddress: dbEvent.IpAddress,
+			UserAgent: dbEvent.UserAgent,
+			Outcome:   dbEvent.Outcome,
+			CreatedAt: dbEvent.CreatedAt.Time,
+		}
+	}
+	return events, nil
+}
+
+// ipRange returns the network a client address belongs to: its /24 for IPv4
+// and /48 for IPv6, roughly what one provider hands one customer. Addresses
+// that do not parse are their own range.
+func ipRange(ipAddress string) string {
+	addr, err := netip.ParseAddr(ipAddress)
+	if err != nil {
+		return ipAddress
+	}
+	addr = addr.Unmap()
+
+	bits := 48
+	if addr.Is4() {
+		bits = 24
+	}
+	prefix, err := addr.Prefix(bits)
+	if err != nil {
+		return ipAddress
+	}
+	return prefix.String()
+}
+
+// truncateUserAgent cuts a user agent to the length stored
+func truncateUserAgent(userAgent string) string {
+	if runes := []rune(userAgent); len(runes) > maxUserAgentLength {
+		return string(runes[:maxUserAgentLength])
+	}
+	return userAgent
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/phantom-sage/bankgo/internal/database/queries"
+	"github.com/phantom-sage/bankgo/internal/logging"
+	"github.com/phantom-sage/bankgo/internal/outbox"
+	"github.com/phantom-sage/bankgo/internal/queue"
+	"github.com/phantom-sage/bankgo/internal/repository"
+	"github.com/rs/zerolog"
+)
+
+// LockoutNotifier emails users whose account was locked after repeated failed
+// sign-ins. The email task is written to the outbox, which the outbox relay
+// delivers to the queue.
+type LockoutNotifier struct {
+	repo   *repository.Repository
+	logger zerolog.Logger
+}
+
+// NewLockoutNotifier creates a lockout notifier writing to the outbox in repo
+func NewLockoutNotifier(repo *repository.Repository, logger zerolog.Logger) *LockoutNotifier {
+	return &LockoutNotifier{
+		repo:   repo,
+		logger: logger.With().Str("component", "lockout_notifier").Logger(),
+	}
+}
+
+// AccountLocked schedules the lockout email for the user signing in as
+// account. Failures are counted for unknown emails too, so there may be no
+// one to tell.
+func (n *LockoutNotifier) AccountLocked(ctx context.Context, account, ipAddress string, until time.Time) error {
+	contextLogger := logging.NewContextLogger(n.logger, ctx).
+		WithOperation("notify_account_locked").
+		WithUserEmail(account)
+
+	user, err := n.repo.GetUserByEmail(ctx, account)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to look up locked account: %w", err)
+	}
+
+	message, err := outbox.Task(outbox.AggregateUser, strconv.Itoa(int(user.ID)), queue.TypeAccountLockedEmail, queue.AccountLockedEmailPayload{
+		UserID:      int(user.ID),
+		Email:       user.Email,
+		FirstName:   user.FirstName,
+		IPAddress:   ipAddress,
+		LockedUntil: until.UTC(),
+	})
+	if err != nil {
+		return err
+	}
+	err = n.repo.WithTx(ctx, func(qtx *queries.Queries) error {
+		return outbox.Add(ctx, qtx, message)
+	})
+	if err != nil {
+		contextLogger.Error().
+			Err(err).
+			Int32("user_id", user.ID).
+			Msg("Failed to schedule account locked email")
+		return fmt.Errorf("failed to schedule account locked email: %w", err)
+	}
+
+	contextLogger.Info().
+		Int32("user_id", user.ID).
+		Msg("Account locked email scheduled")
+	return nil
+}
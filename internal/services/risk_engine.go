@@ -0,0 +1,150 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/phantom-sage/bankgo/internal/config"
+	"github.com/shopspring/decimal"
+)
+
+// Risk decisions returned by the risk engine
+const (
+	RiskDecisionAllow  = "allow"
+	RiskDecisionReview = "review"
+	RiskDecisionBlock  = "block"
+)
+
+// Risk rule identifiers recorded with each assessment
+const (
+	RiskRuleAccountVelocity = "account_velocity"
+	RiskRuleUserVelocity    = "user_velocity"
+	RiskRuleAmountAnomaly   = "amount_anomaly"
+	RiskRuleNewRecipient    = "new_recipient_large_amount"
+	RiskRuleFanOut          = "rapid_fan_out"
+)
+
+// Rule weights on the 0-100 risk score
+const (
+	accountVelocityWeight = 30
+	userVelocityWeight    = 25
+	amountAnomalyWeight   = 40
+	newRecipientWeight    = 30
+	fanOutWeight          = 35
+	maxRiskScore          = 100
+)
+
+// ErrTransferBlocked is returned when the risk engine blocks a transfer
+var ErrTransferBlocked = errors.New("transfer blocked by risk checks")
+
+// RiskStats holds the transfer history used to evaluate a new transfer.
+// Counts exclude the transfer being evaluated.
+type RiskStats struct {
+	AccountRecentCount  int             `json:"account_recent_count"`
+	UserRecentCount     int             `json:"user_recent_count"`
+	AverageAmount       decimal.Decimal `json:"average_amount"`
+	HistoryCount        int             `json:"history_count"`
+	PriorRecipientCount int             `json:"prior_recipient_count"`
+	OtherRecipientCount int             `json:"other_recipient_count"`
+}
+
+// RiskReason describes a triggered rule and its contribution to the score
+type RiskReason struct {
+	Rule   string `json:"rule"`
+	Score  int    `json:"score"`
+	Detail string `json:"detail"`
+}
+
+// RiskAssessment is the outcome of evaluating a transfer
+type RiskAssessment struct {
+	Score    int          `json:"score"`
+	Decision string       `json:"decision"`
+	Reasons  []RiskReason `json:"reasons"`
+}
+
+// RiskEngine scores transfers against configurable fraud and anomaly rules
+type RiskEngine struct {
+	config config.RiskConfig
+}
+
+// NewRiskEngine creates a new rule-based risk engine
+func NewRiskEngine(cfg config.RiskConfig) *RiskEngine {
+	return &RiskEngine{config: cfg}
+}
+
+// Enabled reports whether risk checks should run
+func (e *RiskEngine) Enabled() bool {
+	return e != nil && e.config.Enabled
+}
+
+// Windows returns the start times of the velocity, history and fan-out windows
+func (e *RiskEngine) Windows(now time.Time) (velocitySince, historySince, fanOutSince time.Time) {
+	return now.Add(-e.config.VelocityWindow), now.Add(-e.config.HistoryWindow), now.Add(-e.config.FanOutWindow)
+}
+
+// Evaluate scores a transfer amount against the sender's recent activity
+func (e *RiskEngine) Evaluate(amount decimal.Decimal, stats RiskStats) *RiskAssessment {
+	assessment := &RiskAssessment{
+		Decision: RiskDecisionAllow,
+		Reasons:  []RiskReason{},
+	}
+
+	add := func(rule string, score int, detail string) {
+		assessment.Reasons = append(assessment.Reasons, RiskReason{Rule: rule, Score: score, Detail: detail})
+		assessment.Score += score
+	}
+
+	// Velocity per account and per user, counting this transfer
+	if stats.AccountRecentCount+1 > e.config.MaxAccountTransfers {
+		add(RiskRuleAccountVelocity, accountVelocityWeight,
+			fmt.Sprintf("%d transfers from account within %s (limit %d)", stats.AccountRecentCount+1, e.config.VelocityWindow, e.config.MaxAccountTransfers))
+	}
+	if stats.UserRecentCount+1 > e.config.MaxUserTransfers {
+		add(RiskRuleUserVelocity, userVelocityWeight,
+			fmt.Sprintf("%d transfers by user within %s (limit %d)", stats.UserRecentCount+1, e.config.VelocityWindow, e.config.MaxUserTransfers))
+	}
+
+	// Amount compared with the user's historical average
+	if stats.HistoryCount >= e.config.MinHistoryCount && stats.AverageAmount.IsPositive() {
+		threshold := stats.AverageAmount.Mul(e.config.AverageMultiplier)
+		if amount.GreaterThan(threshold) {
+			add(RiskRuleAmountAnomaly, amountAnomalyWeight,
+				fmt.Sprintf("amount %s exceeds %sx historical average %s", amount.StringFixed(2), e.config.AverageMultiplier.String(), stats.AverageAmount.StringFixed(2)))
+		}
+	}
+
+	// Large transfer to a recipient never paid before
+	if stats.PriorRecipientCount == 0 && amount.GreaterThanOrEqual(e.config.NewRecipientAmount) {
+		add(RiskRuleNewRecipient, newRecipientWeight,
+			fmt.Sprintf("first transfer to recipient is %s (threshold %s)", amount.StringFixed(2), e.config.NewRecipientAmount.StringFixed(2)))
+	}
+
+	// Rapid fan-out to many distinct recipients, counting this recipient
+	if stats.OtherRecipientCount+1 > e.config.MaxFanOutRecipients {
+		add(RiskRuleFanOut, fanOutWeight,
+			fmt.Sprintf("%d distinct recipients within %s (limit %d)", stats.OtherRecipientCount+1, e.config.FanOutWindow, e.config.MaxFanOutRecipients))
+	}
+
+	if assessment.Score > maxRiskScore {
+		assessment.Score = maxRiskScore
+	}
+
+	switch {
+	case assessment.Score >= e.config.BlockScore:
+		assessment.Decision = RiskDecisionBlock
+	case assessment.Score >= e.config.ReviewScore && assessment.Score > 0:
+		assessment.Decision = RiskDecisionReview
+	}
+
+	return assessment
+}
+
+// AmountThreshold returns the amount threshold most relevant to an assessment,
+// used when reporting anomalies
+func (e *RiskEngine) AmountThreshold(stats RiskStats) decimal.Decimal {
+	if stats.HistoryCount >= e.config.MinHistoryCount && stats.AverageAmount.IsPositive() {
+		return stats.AverageAmount.Mul(e.config.AverageMultiplier)
+	}
+	return e.config.NewRecipientAmount
+}
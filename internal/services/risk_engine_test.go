@@ -0,0 +1,149 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/phantom-sage/bankgo/internal/config"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRiskEngine() *RiskEngine {
+	return NewRiskEngine(config.DefaultRiskConfig())
+}
+
+func ruleNames(assessment *RiskAssessment) []string {
+	names := []string{}
+	for _, reason := range assessment.Reasons {
+		names = append(names, reason.Rule)
+	}
+	return names
+}
+
+func TestRiskEngine_AllowsOrdinaryTransfer(t *testing.T) {
+	engine := newTestRiskEngine()
+
+	assessment := engine.Evaluate(decimal.NewFromFloat(100), RiskStats{
+		AccountRecentCount:  1,
+		UserRecentCount:     1,
+		AverageAmount:       decimal.NewFromFloat(80),
+		HistoryCount:        10,
+		PriorRecipientCount: 3,
+	})
+
+	assert.Equal(t, RiskDecisionAllow, assessment.Decision)
+	assert.Equal(t, 0, assessment.Score)
+	assert.Empty(t, assessment.Reasons)
+}
+
+func TestRiskEngine_AmountAnomalyFlagsForReview(t *testing.T) {
+	engine := newTestRiskEngine()
+
+	assessment := engine.Evaluate(decimal.NewFromFloat(1000), RiskStats{
+		AverageAmount:       decimal.NewFromFloat(100),
+		HistoryCount:        10,
+		PriorRecipientCount: 2,
+	})
+
+	assert.Equal(t, RiskDecisionReview, assessment.Decision)
+	assert.Equal(t, amountAnomalyWeight, assessment.Score)
+	assert.Equal(t, []string{RiskRuleAmountAnomaly}, ruleNames(assessment))
+}
+
+func TestRiskEngine_AmountAnomalyNeedsHistory(t *testing.T) {
+	engine := newTestRiskEngine()
+
+	assessment := engine.Evaluate(decimal.NewFromFloat(1000), RiskStats{
+		AverageAmount:       decimal.NewFromFloat(100),
+		HistoryCount:        1,
+		PriorRecipientCount: 2,
+	})
+
+	assert.Equal(t, RiskDecisionAllow, assessment.Decision)
+	assert.NotContains(t, ruleNames(assessment), RiskRuleAmountAnomaly)
+}
+
+func TestRiskEngine_VelocityCountsCurrentTransfer(t *testing.T) {
+	cfg := config.DefaultRiskConfig()
+	engine := NewRiskEngine(cfg)
+
+	atLimit := engine.Evaluate(decimal.NewFromFloat(10), RiskStats{
+		AccountRecentCount:  cfg.MaxAccountTransfers - 1,
+		UserRecentCount:     cfg.MaxAccountTransfers - 1,
+		PriorRecipientCount: 1,
+	})
+	assert.NotContains(t, ruleNames(atLimit), RiskRuleAccountVelocity)
+
+	overLimit := engine.Evaluate(decimal.NewFromFloat(10), RiskStats{
+		AccountRecentCount:  cfg.MaxAccountTransfers,
+		UserRecentCount:     cfg.MaxAccountTransfers,
+		PriorRecipientCount: 1,
+	})
+	assert.Contains(t, ruleNames(overLimit), RiskRuleAccountVelocity)
+	assert.NotContains(t, ruleNames(overLimit), RiskRuleUserVelocity)
+}
+
+func TestRiskEngine_BlocksCombinedSignals(t *testing.T) {
+	engine := newTestRiskEngine()
+
+	assessment := engine.Evaluate(decimal.NewFromFloat(10000), RiskStats{
+		AccountRecentCount:  10,
+		UserRecentCount:     20,
+		AverageAmount:       decimal.NewFromFloat(50),
+		HistoryCount:        10,
+		PriorRecipientCount: 0,
+		OtherRecipientCount: 10,
+	})
+
+	assert.Equal(t, RiskDecisionBlock, assessment.Decision)
+	assert.Equal(t, maxRiskScore, assessment.Score)
+	assert.ElementsMatch(t, []string{
+		RiskRuleAccountVelocity,
+		RiskRuleUserVelocity,
+		RiskRuleAmountAnomaly,
+		RiskRuleNewRecipient,
+		RiskRuleFanOut,
+	}, ruleNames(assessment))
+}
+
+func TestRiskEngine_NewRecipientAndFanOut(t *testing.T) {
+	cfg := config.DefaultRiskConfig()
+	engine := NewRiskEngine(cfg)
+
+	assessment := engine.Evaluate(cfg.NewRecipientAmount, RiskStats{
+		OtherRecipientCount: cfg.MaxFanOutRecipients,
+	})
+
+	assert.ElementsMatch(t, []string{RiskRuleNewRecipient, RiskRuleFanOut}, ruleNames(assessment))
+	assert.Equal(t, newRecipientWeight+fanOutWeight, assessment.Score)
+	assert.Equal(t, RiskDecisionReview, assessment.Decision)
+}
+
+func TestRiskEngine_EnabledAndWindows(t *testing.T) {
+	var nilEngine *RiskEngine
+	assert.False(t, nilEngine.Enabled())
+
+	cfg := config.DefaultRiskConfig()
+	cfg.Enabled = false
+	assert.False(t, NewRiskEngine(cfg).Enabled())
+
+	engine := newTestRiskEngine()
+	assert.True(t, engine.Enabled())
+
+	now := time.Now()
+	velocitySince, historySince, fanOutSince := engine.Windows(now)
+	assert.Equal(t, now.Add(-config.DefaultRiskConfig().VelocityWindow), velocitySince)
+	assert.Equal(t, now.Add(-config.DefaultRiskConfig().HistoryWindow), historySince)
+	assert.Equal(t, now.Add(-config.DefaultRiskConfig().FanOutWindow), fanOutSince)
+}
+
+func TestRiskEngine_AmountThreshold(t *testing.T) {
+	engine := newTestRiskEngine()
+
+	withHistory := engine.AmountThreshold(RiskStats{AverageAmount: decimal.NewFromFloat(100), HistoryCount: 10})
+	assert.True(t, withHistory.Equal(decimal.NewFromFloat(500)))
+
+	withoutHistory := engine.AmountThreshold(RiskStats{})
+	assert.True(t, withoutHistory.Equal(config.DefaultRiskConfig().NewRecipientAmount))
+}
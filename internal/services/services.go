@@ -10,13 +10,33 @@ type Services struct {
 	UserService     UserService
 	AccountService  AccountService
 	TransferService TransferService
+
+	// TransferBatchService books batches through the same transfer service
+	TransferBatchService TransferBatchService
+}
+
+// NewServices creates a new services instance with all business logic services.
+// transferOpts configure optional transfer service dependencies such as the risk engine.
+func NewServices(repos *repository.Repositories, repo *repository.Repository, logger zerolog.Logger, transferOpts ...TransferServiceOption) *Services {
+	transferService := newTransferService(repo, repos.AccountRepo, repos.TransferRepo, logger, transferOpts...)
+	return &Services{
+		UserService:          NewUserService(repos.UserRepo, logger),
+		AccountService:       NewAccountService(repos.AccountRepo, repos.TransferRepo, logger),
+		TransferService:      transferService,
+		TransferBatchService: NewTransferBatchService(transferService),
+	}
 }
 
-// NewServices creates a new services instance with all business logic services
-func NewServices(repos *repository.Repositories, repo *repository.Repository, logger zerolog.Logger) *Services {
+// NewServicesWithOutbox creates the services with every service writing its
+// domain events and side-effect tasks to the outbox in repo, in the
+// transaction of the change that causes them
+func NewServicesWithOutbox(repos *repository.Repositories, repo *repository.Repository, logger zerolog.Logger, transferOpts ...TransferServiceOption) *Services {
+	transferOpts = append([]TransferServiceOption{WithEventOutbox()}, transferOpts...)
+	transferService := newTransferService(repo, repos.AccountRepo, repos.TransferRepo, logger, transferOpts...)
 	return &Services{
-		UserService:     NewUserService(repos.UserRepo, logger),
-		AccountService:  NewAccountService(repos.AccountRepo, repos.TransferRepo, logger),
-		TransferService: NewTransferService(repo, repos.AccountRepo, repos.TransferRepo, logger),
+		UserService:          NewUserServiceWithOutbox(repos.UserRepo, repo, logger),
+		AccountService:       NewAccountServiceWithOutbox(repos.AccountRepo, repos.TransferRepo, repo, logger),
+		TransferService:      transferService,
+		TransferBatchService: NewTransferBatchService(transferService),
 	}
-}
\ No newline at end of file
+}
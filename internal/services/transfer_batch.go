@@ -0,0 +1,920 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/phantom-sage/bankgo/internal/config"
+	"github.com/phantom-sage/bankgo/internal/database/queries"
+	"github.com/phantom-sage/bankgo/internal/events"
+	"github.com/phantom-sage/bankgo/internal/logging"
+	"github.com/phantom-sage/bankgo/internal/models"
+	"github.com/phantom-sage/bankgo/internal/queue"
+	"github.com/phantom-sage/bankgo/internal/repository"
+	"github.com/phantom-sage/bankgo/internal/utils"
+	"github.com/shopspring/decimal"
+)
+
+// Batch modes
+const (
+	// TransferBatchModeAtomic books every item of a batch or none of them
+	TransferBatchModeAtomic = "atomic"
+	// TransferBatchModeBestEffort books each item that passes its checks
+	TransferBatchModeBestEffort = "best_effort"
+)
+
+// Batch statuses
+const (
+	TransferBatchStatusPending            = "pending"
+	TransferBatchStatusProcessing         = "processing"
+	TransferBatchStatusCompleted          = "completed"
+	TransferBatchStatusPartiallyCompleted = "partially_completed"
+	TransferBatchStatusFailed             = "failed"
+)
+
+// Batch item statuses
+const (
+	TransferBatchItemPending   = "pending"
+	TransferBatchItemCompleted = "completed"
+	TransferBatchItemFailed    = "failed"
+	TransferBatchItemSkipped   = "skipped"
+)
+
+// ErrTransferBatchNotFound is returned for unknown batches and batches owned by another user
+var ErrTransferBatchNotFound = errors.New("transfer batch not found")
+
+// ErrTransferBatchInProgress is returned when another worker holds a batch's
+// claim, so a redelivered task is retried until that claim completes or goes stale
+var ErrTransferBatchInProgress = errors.New("transfer batch is being processed")
+
+// errTransferBatchClaimLost is returned when a batch was completed or claimed
+// again by another worker while this one was processing it
+var errTransferBatchClaimLost = errors.New("transfer batch claim lost")
+
+// defaultTransferBatchStaleAfter applies when no claim timeout is configured
+const defaultTransferBatchStaleAfter = 10 * time.Minute
+
+// TransferBatchService defines the interface for batch transfer business logic
+type TransferBatchService interface {
+	SubmitBatch(ctx context.Context, userID int32, req SubmitTransferBatchRequest) (*TransferBatch, error)
+	GetBatch(ctx context.Context, userID, batchID int32) (*TransferBatch, error)
+	ProcessTransferBatch(ctx context.Context, payload queue.TransferBatchPayload) error
+}
+
+// TransferBatchQueue enqueues batches for background processing
+type TransferBatchQueue interface {
+	QueueTransferBatch(ctx context.Context, payload queue.TransferBatchPayload) error
+}
+
+// SubmitTransferBatchRequest represents a batch of transfers submitted together
+type SubmitTransferBatchRequest struct {
+	Mode  string                 `json:"mode"`
+	Items []TransferMoneyRequest `json:"items"`
+}
+
+// TransferBatch represents a submitted batch and the outcome of each item
+type TransferBatch struct {
+	ID             int32               `json:"id"`
+	UserID         int32               `json:"user_id"`
+	Mode           string              `json:"mode"`
+	Status         string              `json:"status"`
+	TotalItems     int32               `json:"total_items"`
+	SucceededItems int32               `json:"succeeded_items"`
+	FailedItems    int32               `json:"failed_items"`
+	Error          string              `json:"error,omitempty"`
+	CreatedAt      time.Time           `json:"created_at"`
+	StartedAt      *time.Time          `json:"started_at,omitempty"`
+	CompletedAt    *time.Time          `json:"completed_at,omitempty"`
+	Items          []TransferBatchItem `json:"items"`
+}
+
+// TransferBatchItem is one transfer of a batch and its outcome
+type TransferBatchItem struct {
+	Index         int32           `json:"index"`
+	FromAccountID int32           `json:"from_account_id"`
+	ToAccountID   int32           `json:"to_account_id"`
+	Amount        decimal.Decimal `json:"amount"`
+	Description   string          `json:"description"`
+	Status        string          `json:"status"`
+	TransferID    *int32          `json:"transfer_id,omitempty"`
+	ErrorCode     string          `json:"error_code,omitempty"`
+	Error         string          `json:"error,omitempty"`
+	id            int32
+}
+
+// TransferBatchItemError describes why one item of a batch request is malformed
+type TransferBatchItemError struct {
+	Index int    `json:"index"`
+	Error string `json:"error"`
+}
+
+// TransferBatchValidationError is returned when a batch request is rejected
+// before anything is stored
+type TransferBatchValidationError struct {
+	Message string
+	Items   []TransferBatchItemError
+}
+
+func (e *TransferBatchValidationError) Error() string {
+	return fmt.Sprintf("invalid transfer batch: %s", e.Message)
+}
+
+// TransferBatchServiceImpl implements TransferBatchService on top of the
+// transfer service, so batch items go through the same fee, limit and risk
+// checks as single transfers
+type TransferBatchServiceImpl struct {
+	transfers *TransferServiceImpl
+}
+
+// NewTransferBatchService creates a new batch transfer service
+func NewTransferBatchService(transfers *TransferServiceImpl) TransferBatchService {
+	return &TransferBatchServiceImpl{
+		transfers: transfers,
+	}
+}
+
+// WithTransferBatches enables batch transfers. batchQueue may be nil, in which
+// case every batch is processed synchronously.
+func WithTransferBatches(cfg config.TransferBatchConfig, batchQueue TransferBatchQueue) TransferServiceOption {
+	return func(s *TransferServiceImpl) {
+		s.batchConfig = cfg
+		s.batchQueue = batchQueue
+	}
+}
+
+// SubmitBatch validates and stores a batch, then processes it right away or,
+// above the async threshold, hands it to the queue and returns it pending
+func (s *TransferBatchServiceImpl) SubmitBatch(ctx context.Context, userID int32, req SubmitTransferBatchRequest) (*TransferBatch, error) {
+	contextLogger := logging.NewContextLogger(s.transfers.logger, ctx).WithOperation("submit_transfer_batch")
+
+	if req.Mode == "" {
+		req.Mode = TransferBatchModeAtomic
+	}
+	if err := validateTransferBatch(req, s.transfers.batchConfig.MaxItems); err != nil {
+		contextLogger.Warn().
+			Err(err).
+			Int32("user_id", userID).
+			Int("items", len(req.Items)).
+			Msg("Transfer batch rejected")
+		return nil, err
+	}
+
+	batchID, err := s.createBatch(ctx, userID, req)
+	if err != nil {
+		contextLogger.Error().
+			Err(err).
+			Int32("user_id", userID).
+			Msg("Failed to store transfer batch")
+		return nil, err
+	}
+
+	contextLogger.Info().
+		Int32("batch_id", batchID).
+		Int32("user_id", userID).
+		Str("mode", req.Mode).
+		Int("items", len(req.Items)).
+		Msg("Transfer batch submitted")
+
+	payload := queue.TransferBatchPayload{BatchID: batchID, UserID: userID}
+	if len(req.Items) > s.transfers.batchConfig.AsyncThreshold && s.transfers.batchQueue != nil {
+		err := s.transfers.batchQueue.QueueTransferBatch(ctx, payload)
+		if err == nil {
+			return s.GetBatch(ctx, userID, batchID)
+		}
+
+		// The batch is still pending, so it can be processed here instead
+		contextLogger.Warn().
+			Err(err).
+			Int32("batch_id", batchID).
+			Msg("Failed to enqueue transfer batch, processing synchronously")
+	}
+
+	if err := s.ProcessTransferBatch(ctx, payload); err != nil {
+		return nil, err
+	}
+
+	return s.GetBatch(ctx, userID, batchID)
+}
+
+// validateTransferBatch checks the mode, size and fields of a batch request
+func validateTransferBatch(req SubmitTransferBatchRequest, maxItems int) error {
+	if maxItems <= 0 {
+		return &TransferBatchValidationError{Message: "batch transfers are disabled"}
+	}
+	if req.Mode != TransferBatchModeAtomic && req.Mode != TransferBatchModeBestEffort {
+		return &TransferBatchValidationError{Message: fmt.Sprintf("mode must be %s or %s", TransferBatchModeAtomic, TransferBatchModeBestEffort)}
+	}
+	if len(req.Items) == 0 {
+		return &TransferBatchValidationError{Message: "batch must contain at least one transfer"}
+	}
+	if len(req.Items) > maxItems {
+		return &TransferBatchValidationError{Message: fmt.Sprintf("batch cannot contain more than %d transfers", maxItems)}
+	}
+
+	var itemErrors []TransferBatchItemError
+	for i, item := range req.Items {
+		transfer := &models.Transfer{
+			FromAccountID: int(item.FromAccountID),
+			ToAccountID:   int(item.ToAccountID),
+			Amount:        item.Amount,
+		}
+		if err := transfer.ValidateFields(); err != nil {
+			itemErrors = append(itemErrors, TransferBatchItemError{Index: i, Error: err.Error()})
+			continue
+		}
+		if !item.Amount.Equal(item.Amount.Round(2)) {
+			itemErrors = append(itemErrors, TransferBatchItemError{Index: i, Error: "transfer amount cannot have more than 2 decimal places"})
+		}
+	}
+	if len(itemErrors) > 0 {
+		return &TransferBatchValidationError{
+			Message: fmt.Sprintf("%d of %d transfers are invalid", len(itemErrors), len(req.Items)),
+			Items:   itemErrors,
+		}
+	}
+
+	return nil
+}
+
+// createBatch stores a batch and its items as pending
+func (s *TransferBatchServiceImpl) createBatch(ctx context.Context, userID int32, req SubmitTransferBatchRequest) (int32, error) {
+	params := queries.CreateTransferBatchItemsParams{
+		ItemIndexes:    make([]int32, len(req.Items)),
+		FromAccountIds: make([]int32, len(req.Items)),
+		ToAccountIds:   make([]int32, len(req.Items)),
+		Amounts:        make([]pgtype.Numeric, len(req.Items)),
+		Descriptions:   make([]string, len(req.Items)),
+	}
+	for i, item := range req.Items {
+		params.ItemIndexes[i] = int32(i)
+		params.FromAccountIds[i] = item.FromAccountID
+		params.ToAccountIds[i] = item.ToAccountID
+		params.Amounts[i] = utils.ConvertDecimalToPgNumeric(item.Amount)
+		params.Descriptions[i] = item.Description
+	}
+
+	var batchID int32
+	err := s.transfers.repo.WithTx(ctx, func(qtx *queries.Queries) error {
+		batch, err := qtx.CreateTransferBatch(ctx, queries.CreateTransferBatchParams{
+			UserID:     userID,
+			Mode:       req.Mode,
+			TotalItems: int32(len(req.Items)),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create transfer batch: %w", err)
+		}
+
+		params.BatchID = batch.ID
+		if err := qtx.CreateTransferBatchItems(ctx, params); err != nil {
+			return fmt.Errorf("failed to create transfer batch items: %w", err)
+		}
+
+		batchID = batch.ID
+		return nil
+	})
+
+	return batchID, err
+}
+
+// GetBatch returns a batch with its item outcomes if it belongs to the user
+func (s *TransferBatchServiceImpl) GetBatch(ctx context.Context, userID, batchID int32) (*TransferBatch, error) {
+	q := s.transfers.repo.Queries
+
+	dbBatch, err := q.GetTransferBatch(ctx, batchID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrTransferBatchNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transfer batch: %w", err)
+	}
+	if dbBatch.UserID != userID {
+		return nil, ErrTransferBatchNotFound
+	}
+
+	dbItems, err := q.ListTransferBatchItems(ctx, batchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transfer batch items: %w", err)
+	}
+
+	return convertDBTransferBatchToModel(dbBatch, dbItems)
+}
+
+// ProcessTransferBatch executes a pending batch. It is called inline for
+// small batches and from the queue for large ones. A batch is claimed before
+// anything is booked, so a redelivered task for the same batch is a no-op. A
+// claim left behind by a worker that died is taken over once it is older
+// than the configured stale timeout; the batch is only completed under the
+// claim that booked it, so two workers can never both book it.
+func (s *TransferBatchServiceImpl) ProcessTransferBatch(ctx context.Context, payload queue.TransferBatchPayload) error {
+	start := time.Now()
+	contextLogger := logging.NewContextLogger(s.transfers.logger, ctx).WithOperation("process_transfer_batch")
+	q := s.transfers.repo.Queries
+
+	batch, err := q.ClaimTransferBatch(ctx, queries.ClaimTransferBatchParams{
+		ID:         payload.BatchID,
+		StaleAfter: pgtype.Interval{Microseconds: s.staleAfter().Microseconds(), Valid: true},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return s.skipClaimedBatch(ctx, payload.BatchID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to claim transfer batch: %w", err)
+	}
+
+	dbItems, err := q.ListTransferBatchItems(ctx, batch.ID)
+	if err != nil {
+		return s.failBatch(ctx, batch, nil, fmt.Errorf("failed to get transfer batch items: %w", err))
+	}
+
+	items := make([]TransferBatchItem, len(dbItems))
+	for i, dbItem := range dbItems {
+		item, err := convertDBTransferBatchItemToModel(dbItem)
+		if err != nil {
+			return s.failBatch(ctx, batch, nil, err)
+		}
+		items[i] = item
+	}
+
+	// Validate every item against its accounts before taking any locks
+	if err := s.validateBatchAccounts(ctx, batch.UserID, items); err != nil {
+		return s.failBatch(ctx, batch, items, err)
+	}
+
+	failed := countBatchItems(items, TransferBatchItemFailed)
+	if failed > 0 && batch.Mode == TransferBatchModeAtomic {
+		skipPendingBatchItems(items)
+		return s.completeBatch(ctx, batch, items, fmt.Sprintf("%d of %d transfers failed validation", failed, len(items)))
+	}
+	if failed == len(items) {
+		return s.completeBatch(ctx, batch, items, "no transfers passed validation")
+	}
+
+	outcome, err := s.bookBatch(ctx, batch, items)
+
+	var itemErr *transferBatchItemFailure
+	switch {
+	case errors.Is(err, errTransferBatchClaimLost):
+		contextLogger.Warn().
+			Int32("batch_id", batch.ID).
+			Msg("Transfer batch was taken over by another worker, discarding this run")
+		return nil
+	case errors.As(err, &itemErr):
+		// An atomic batch rolled back on its first failing item
+		items[itemErr.index].Status = TransferBatchItemFailed
+		items[itemErr.index].ErrorCode = itemErr.code
+		items[itemErr.index].Error = itemErr.err.Error()
+		skipPendingBatchItems(items)
+		failedReq := batchItemTransferRequest(items[itemErr.index])
+		if itemErr.risk != nil {
+			s.transfers.handleBlockedTransfer(ctx, failedReq, itemErr.risk)
+		}
+		s.transfers.stageTransferFailed(ctx, failedReq, itemErr.code, itemErr.err, batch.ID)
+		return s.completeBatch(ctx, batch, items, fmt.Sprintf("transfer %d failed: %s", itemErr.index, itemErr.err.Error()))
+	case err != nil:
+		return s.failBatch(ctx, batch, items, err)
+	}
+
+	// Report booked and blocked items only once the batch has committed
+	for i, item := range items {
+		req := batchItemTransferRequest(item)
+		risk := outcome.risks[i]
+		switch {
+		case item.Status == TransferBatchItemCompleted:
+			s.transfers.auditLogger.LogTransferWithDetails(int64(*item.TransferID), int64(item.FromAccountID), int64(item.ToAccountID),
+				item.Amount, outcome.currencies[i], item.Description, "success", int64(batch.UserID))
+			if risk != nil && risk.assessment.Decision == RiskDecisionReview {
+				s.transfers.raiseRiskAlert(ctx, req, risk)
+			}
+		case risk != nil && risk.assessment.Decision == RiskDecisionBlock:
+			s.transfers.handleBlockedTransfer(ctx, req, risk)
+			s.transfers.stageTransferFailed(ctx, req, item.ErrorCode, ErrTransferBlocked, batch.ID)
+		case item.Status == TransferBatchItemFailed:
+			s.transfers.auditLogger.LogTransfer(int64(item.FromAccountID), int64(item.ToAccountID), item.Amount, "failed_batch_"+item.ErrorCode)
+			s.transfers.stageTransferFailed(ctx, req, item.ErrorCode, errors.New(item.Error), batch.ID)
+		}
+	}
+
+	contextLogger.Info().
+		Int32("batch_id", batch.ID).
+		Str("mode", batch.Mode).
+		Str("status", outcome.batch.Status).
+		Int32("succeeded", outcome.batch.SucceededItems).
+		Int32("failed", outcome.batch.FailedItems).
+		Int64("duration_ms", time.Since(start).Milliseconds()).
+		Msg("Transfer batch processed")
+
+	return nil
+}
+
+// skipClaimedBatch handles a batch that could not be claimed. Finished and
+// unknown batches are skipped; a batch another worker is still processing
+// returns ErrTransferBatchInProgress so the queue tries again later.
+func (s *TransferBatchServiceImpl) skipClaimedBatch(ctx context.Context, batchID int32) error {
+	contextLogger := logging.NewContextLogger(s.transfers.logger, ctx).WithOperation("process_transfer_batch")
+
+	batch, err := s.transfers.repo.Queries.GetTransferBatch(ctx, batchID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("failed to get transfer batch: %w", err)
+	}
+	if err == nil && batch.Status == TransferBatchStatusProcessing {
+		contextLogger.Warn().
+			Int32("batch_id", batchID).
+			Time("started_at", batch.StartedAt.Time).
+			Msg("Transfer batch is claimed by another worker, retrying later")
+		return ErrTransferBatchInProgress
+	}
+
+	contextLogger.Warn().
+		Int32("batch_id", batchID).
+		Msg("Transfer batch is not pending, skipping")
+	return nil
+}
+
+// staleAfter returns how long a claim may go without completing before
+// another worker takes the batch over
+func (s *TransferBatchServiceImpl) staleAfter() time.Duration {
+	if s.transfers.batchConfig.StaleAfter > 0 {
+		return s.transfers.batchConfig.StaleAfter
+	}
+	return defaultTransferBatchStaleAfter
+}
+
+// validateBatchAccounts loads every account the batch names in one query and
+// fails items whose accounts are missing, not owned by the user, or in
+// different currencies
+func (s *TransferBatchServiceImpl) validateBatchAccounts(ctx context.Context, userID int32, items []TransferBatchItem) error {
+	ids := make([]int32, 0, len(items)*2)
+	for _, item := range items {
+		ids = append(ids, item.FromAccountID, item.ToAccountID)
+	}
+
+	dbAccounts, err := s.transfers.repo.Queries.ListAccountsByIDs(ctx, uniqueAccountIDs(ids))
+	if err != nil {
+		return fmt.Errorf("failed to load batch accounts: %w", err)
+	}
+
+	accounts := make(map[int32]queries.Account, len(dbAccounts))
+	for _, account := range dbAccounts {
+		accounts[account.ID] = account
+	}
+
+	for i := range items {
+		item := &items[i]
+		from, fromFound := accounts[item.FromAccountID]
+		to, toFound := accounts[item.ToAccountID]
+
+		switch {
+		case !fromFound:
+			item.fail("account_not_found", "source account not found")
+		case from.UserID != userID:
+			item.fail("access_denied", "you can only transfer from your own accounts")
+		case !toFound:
+			item.fail("account_not_found", "destination account not found")
+		case from.Currency != to.Currency:
+			item.fail("currency_mismatch", models.ErrCurrencyMismatch.Error())
+		}
+	}
+
+	return nil
+}
+
+// transferBatchOutcome carries what booking a batch produced for post-commit reporting
+type transferBatchOutcome struct {
+	batch      queries.TransferBatch
+	risks      map[int]*transferRisk
+	currencies map[int]string
+}
+
+// transferBatchItemFailure aborts an atomic batch on its first failing item
+type transferBatchItemFailure struct {
+	index int
+	code  string
+	err   error
+	risk  *transferRisk
+}
+
+func (e *transferBatchItemFailure) Error() string {
+	return fmt.Sprintf("transfer batch item %d failed: %v", e.index, e.err)
+}
+
+func (e *transferBatchItemFailure) Unwrap() error {
+	return e.err
+}
+
+// bookBatch books the pending items of a batch in a single transaction and
+// stores their outcomes with it. Every account involved, including the fee
+// house accounts, is locked up front in one ascending-ID query, so a batch
+// never waits on a lock while holding another taken out of order.
+func (s *TransferBatchServiceImpl) bookBatch(ctx context.Context, batch queries.TransferBatch, items []TransferBatchItem) (*transferBatchOutcome, error) {
+	contextLogger := logging.NewContextLogger(s.transfers.logger, ctx).WithOperation("process_transfer_batch")
+
+	lockIDs, err := s.batchLockIDs(ctx, items)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every attempt starts again from the validated items
+	validated := make([]TransferBatchItem, len(items))
+	copy(validated, items)
+
+	var outcome *transferBatchOutcome
+	err = s.transfers.txLogger.WithTxLoggedWithRetry(ctx, func(qtx *queries.Queries, txCtx *repository.TransactionContext) error {
+		copy(items, validated)
+		outcome = &transferBatchOutcome{
+			risks:      make(map[int]*transferRisk),
+			currencies: make(map[int]string),
+		}
+
+		lockStart := time.Now()
+		lockedAccounts, err := qtx.LockAccountsForUpdate(ctx, lockIDs)
+		if err != nil {
+			return fmt.Errorf("failed to lock batch accounts: %w", err)
+		}
+		accounts := make(map[int32]queries.Account, len(lockedAccounts))
+		for _, account := range lockedAccounts {
+			accounts[account.ID] = account
+		}
+		contextLogger.Debug().
+			Int32("batch_id", batch.ID).
+			Int("accounts", len(lockedAccounts)).
+			Int("attempt", txCtx.DeadlockRetries+1).
+			Int64("lock_duration_ms", time.Since(lockStart).Milliseconds()).
+			Msg("Batch account locks acquired")
+
+		for i := range items {
+			item := &items[i]
+			if item.Status != TransferBatchItemPending {
+				continue
+			}
+
+			req := batchItemTransferRequest(*item)
+			transfer := &models.Transfer{
+				FromAccountID: int(req.FromAccountID),
+				ToAccountID:   int(req.ToAccountID),
+				Amount:        req.Amount,
+				Description:   req.Description,
+				Status:        "completed",
+			}
+
+			booked, err := s.transfers.bookTransfer(ctx, qtx, contextLogger, transfer, req,
+				accounts[req.FromAccountID], accounts[req.ToAccountID])
+			if err != nil {
+				code := transferBatchErrorCode(err)
+				if code == "" {
+					return err
+				}
+
+				var risk *transferRisk
+				if booked != nil {
+					risk = booked.risk
+				}
+				if batch.Mode == TransferBatchModeAtomic {
+					return &transferBatchItemFailure{index: i, code: code, err: err, risk: risk}
+				}
+
+				item.fail(code, err.Error())
+				outcome.risks[i] = risk
+				continue
+			}
+
+			// Later items see the balances this item left behind
+			accounts[req.FromAccountID] = booked.fromAccount
+			accounts[req.ToAccountID] = booked.toAccount
+			if booked.fee.Fee.IsPositive() {
+				if house, ok := accounts[booked.fee.houseAccountID]; ok {
+					if accounts[house.ID], err = creditLockedAccount(house, booked.fee.Fee); err != nil {
+						return err
+					}
+				}
+			}
+
+			transferID := int32(booked.transfer.ID)
+			item.Status = TransferBatchItemCompleted
+			item.TransferID = &transferID
+			outcome.risks[i] = booked.risk
+			outcome.currencies[i] = booked.fee.Currency
+
+			err = s.transfers.stageTransferCompleted(ctx, qtx, events.TransferPayload{
+				TransferID:    transferID,
+				FromAccountID: item.FromAccountID,
+				ToAccountID:   item.ToAccountID,
+				UserID:        batch.UserID,
+				Amount:        item.Amount.StringFixed(2),
+				Fee:           feeString(booked.transfer.Fee),
+				Currency:      booked.fee.Currency,
+				BatchID:       batch.ID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := updateBatchItems(ctx, qtx, items); err != nil {
+			return err
+		}
+
+		outcome.batch, err = completeBatchParams(ctx, qtx, batch, items, "")
+		return err
+	}, transferMaxTxRetries)
+
+	if err != nil {
+		// Nothing was booked, so drop the outcomes of the rolled-back attempt
+		copy(items, validated)
+		return nil, err
+	}
+	return outcome, nil
+}
+
+// batchLockIDs returns the sorted IDs of every account the pending items
+// touch, plus the house account of each currency's active fee schedule
+func (s *TransferBatchServiceImpl) batchLockIDs(ctx context.Context, items []TransferBatchItem) ([]int32, error) {
+	ids := make([]int32, 0, len(items)*2)
+	sources := make(map[int32]bool)
+	for _, item := range items {
+		if item.Status == TransferBatchItemPending {
+			ids = append(ids, item.FromAccountID, item.ToAccountID)
+			sources[item.FromAccountID] = true
+		}
+	}
+
+	accounts, err := s.transfers.repo.Queries.ListAccountsByIDs(ctx, uniqueAccountIDs(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load batch accounts: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	for _, account := range accounts {
+		if !sources[account.ID] || seen[account.Currency] {
+			continue
+		}
+		seen[account.Currency] = true
+
+		schedule, err := s.transfers.repo.Queries.GetActiveFeeSchedule(ctx, account.Currency)
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get fee schedule: %w", err)
+		}
+		ids = append(ids, schedule.HouseAccountID)
+	}
+
+	return uniqueAccountIDs(ids), nil
+}
+
+// completeBatch stores item outcomes and the final batch status outside of a booking transaction
+func (s *TransferBatchServiceImpl) completeBatch(ctx context.Context, batch queries.TransferBatch, items []TransferBatchItem, errorMessage string) error {
+	err := s.transfers.repo.WithTx(ctx, func(qtx *queries.Queries) error {
+		if err := updateBatchItems(ctx, qtx, items); err != nil {
+			return err
+		}
+		_, err := completeBatchParams(ctx, qtx, batch, items, errorMessage)
+		return err
+	})
+	if errors.Is(err, errTransferBatchClaimLost) {
+		logging.NewContextLogger(s.transfers.logger, ctx).WithOperation("process_transfer_batch").Warn().
+			Int32("batch_id", batch.ID).
+			Msg("Transfer batch was taken over by another worker, discarding this run")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to complete transfer batch: %w", err)
+	}
+
+	logging.NewContextLogger(s.transfers.logger, ctx).WithOperation("process_transfer_batch").Warn().
+		Int32("batch_id", batch.ID).
+		Str("mode", batch.Mode).
+		Str("reason", errorMessage).
+		Msg("Transfer batch finished without booking")
+
+	return nil
+}
+
+// failBatch marks a batch failed after an unexpected error. Nothing was booked,
+// and the batch stays claimed so it is never processed twice.
+func (s *TransferBatchServiceImpl) failBatch(ctx context.Context, batch queries.TransferBatch, items []TransferBatchItem, cause error) error {
+	logging.NewContextLogger(s.transfers.logger, ctx).WithOperation("process_transfer_batch").Error().
+		Err(cause).
+		Int32("batch_id", batch.ID).
+		Msg("Transfer batch processing failed")
+
+	for i := range items {
+		if items[i].Status == TransferBatchItemPending {
+			items[i].fail("processing_error", "batch could not be processed")
+		}
+	}
+
+	if err := s.completeBatch(ctx, batch, items, "batch could not be processed"); err != nil {
+		return fmt.Errorf("%v: %w", cause, err)
+	}
+	return fmt.Errorf("transfer batch processing failed: %w", cause)
+}
+
+// fail marks an item failed with an error code and message
+func (i *TransferBatchItem) fail(code, message string) {
+	i.Status = TransferBatchItemFailed
+	i.ErrorCode = code
+	i.Error = message
+}
+
+// skipPendingBatchItems marks items that were never attempted as skipped
+func skipPendingBatchItems(items []TransferBatchItem) {
+	for i := range items {
+		if items[i].Status == TransferBatchItemPending {
+			items[i].Status = TransferBatchItemSkipped
+			items[i].ErrorCode = "batch_aborted"
+			items[i].Error = "not booked because another transfer in the atomic batch failed"
+		}
+	}
+}
+
+// countBatchItems counts items in the given status
+func countBatchItems(items []TransferBatchItem, status string) int {
+	count := 0
+	for _, item := range items {
+		if item.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// transferBatchStatus derives a batch status from its item outcomes
+func transferBatchStatus(items []TransferBatchItem) string {
+	succeeded := countBatchItems(items, TransferBatchItemCompleted)
+	switch {
+	case succeeded == len(items):
+		return TransferBatchStatusCompleted
+	case succeeded == 0:
+		return TransferBatchStatusFailed
+	default:
+		return TransferBatchStatusPartiallyCompleted
+	}
+}
+
+// transferBatchErrorCode maps an error booking one item to the code reported
+// for it. Errors without a code are not about the item and abort the batch.
+func transferBatchErrorCode(err error) string {
+	var limitErr *LimitExceededError
+	switch {
+	case errors.Is(err, ErrTransferBlocked):
+		return "transfer_blocked"
+	case errors.As(err, &limitErr):
+		return "limit_exceeded"
+	case errors.Is(err, models.ErrInsufficientBalance):
+		return "insufficient_balance"
+	case errors.Is(err, models.ErrCurrencyMismatch):
+		return "currency_mismatch"
+	case strings.Contains(err.Error(), "validation failed"):
+		return "validation_error"
+	default:
+		return ""
+	}
+}
+
+// updateBatchItems stores the outcome of every item in one statement
+func updateBatchItems(ctx context.Context, qtx *queries.Queries, items []TransferBatchItem) error {
+	params := queries.UpdateTransferBatchItemsParams{
+		Ids:           make([]int32, len(items)),
+		Statuses:      make([]string, len(items)),
+		TransferIds:   make([]int32, len(items)),
+		ErrorCodes:    make([]string, len(items)),
+		ErrorMessages: make([]string, len(items)),
+	}
+	for i, item := range items {
+		params.Ids[i] = item.id
+		params.Statuses[i] = item.Status
+		if item.TransferID != nil {
+			params.TransferIds[i] = *item.TransferID
+		}
+		params.ErrorCodes[i] = item.ErrorCode
+		params.ErrorMessages[i] = item.Error
+	}
+
+	if err := qtx.UpdateTransferBatchItems(ctx, params); err != nil {
+		return fmt.Errorf("failed to update transfer batch items: %w", err)
+	}
+	return nil
+}
+
+// completeBatchParams records the final status and counts of a batch. It only
+// matches while the batch still carries the claim it was processed under.
+func completeBatchParams(ctx context.Context, qtx *queries.Queries, claimed queries.TransferBatch, items []TransferBatchItem, errorMessage string) (queries.TransferBatch, error) {
+	succeeded := countBatchItems(items, TransferBatchItemCompleted)
+
+	batch, err := qtx.CompleteTransferBatch(ctx, queries.CompleteTransferBatchParams{
+		ID:             claimed.ID,
+		Status:         transferBatchStatus(items),
+		SucceededItems: int32(succeeded),
+		FailedItems:    int32(len(items) - succeeded),
+		ErrorMessage:   pgtype.Text{String: errorMessage, Valid: errorMessage != ""},
+		StartedAt:      claimed.StartedAt,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return queries.TransferBatch{}, errTransferBatchClaimLost
+	}
+	if err != nil {
+		return queries.TransferBatch{}, fmt.Errorf("failed to complete transfer batch: %w", err)
+	}
+	return batch, nil
+}
+
+// creditLockedAccount adds amount to the in-memory balance of a locked account
+func creditLockedAccount(account queries.Account, amount decimal.Decimal) (queries.Account, error) {
+	balance, err := utils.ConvertPgNumericToDecimal(account.Balance)
+	if err != nil {
+		return account, fmt.Errorf("failed to convert account balance: %w", err)
+	}
+	account.Balance = utils.ConvertDecimalToPgNumeric(balance.Add(amount))
+	return account, nil
+}
+
+// uniqueAccountIDs returns the distinct IDs in ascending order
+func uniqueAccountIDs(ids []int32) []int32 {
+	seen := make(map[int32]bool, len(ids))
+	unique := make([]int32, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	sort.Slice(unique, func(i, j int) bool { return unique[i] < unique[j] })
+	return unique
+}
+
+// batchItemTransferRequest converts a batch item to a single transfer request
+func batchItemTransferRequest(item TransferBatchItem) TransferMoneyRequest {
+	return TransferMoneyRequest{
+		FromAccountID: item.FromAccountID,
+		ToAccountID:   item.ToAccountID,
+		Amount:        item.Amount,
+		Description:   item.Description,
+	}
+}
+
+// convertDBTransferBatchToModel converts a database batch and its items to business models
+func convertDBTransferBatchToModel(dbBatch queries.TransferBatch, dbItems []queries.TransferBatchItem) (*TransferBatch, error) {
+	batch := &TransferBatch{
+		ID:             dbBatch.ID,
+		UserID:         dbBatch.UserID,
+		Mode:           dbBatch.Mode,
+		Status:         dbBatch.Status,
+		TotalItems:     dbBatch.TotalItems,
+		SucceededItems: dbBatch.SucceededItems,
+		FailedItems:    dbBatch.FailedItems,
+		Error:          dbBatch.ErrorMessage.String,
+		CreatedAt:      utils.ConvertPgTimestampToTime(dbBatch.CreatedAt),
+		Items:          make([]TransferBatchItem, 0, len(dbItems)),
+	}
+	if dbBatch.StartedAt.Valid {
+		startedAt := utils.ConvertPgTimestampToTime(dbBatch.StartedAt)
+		batch.StartedAt = &startedAt
+	}
+	if dbBatch.CompletedAt.Valid {
+		completedAt := utils.ConvertPgTimestampToTime(dbBatch.CompletedAt)
+		batch.CompletedAt = &completedAt
+	}
+
+	for _, dbItem := range dbItems {
+		item, err := convertDBTransferBatchItemToModel(dbItem)
+		if err != nil {
+			return nil, err
+		}
+		batch.Items = append(batch.Items, item)
+	}
+
+	return batch, nil
+}
+
+// convertDBTransferBatchItemToModel converts a database batch item to business model
+func convertDBTransferBatchItemToModel(dbItem queries.TransferBatchItem) (TransferBatchItem, error) {
+	amount, err := utils.ConvertPgNumericToDecimal(dbItem.Amount)
+	if err != nil {
+		return TransferBatchItem{}, fmt.Errorf("failed to convert batch item amount: %w", err)
+	}
+
+	item := TransferBatchItem{
+		Index:         dbItem.ItemIndex,
+		FromAccountID: dbItem.FromAccountID,
+		ToAccountID:   dbItem.ToAccountID,
+		Amount:        amount,
+		Description:   dbItem.Description,
+		Status:        dbItem.Status,
+		ErrorCode:     dbItem.ErrorCode.String,
+		Error:         dbItem.ErrorMessage.String,
+		id:            dbItem.ID,
+	}
+	if dbItem.TransferID.Valid {
+		transferID := dbItem.TransferID.Int32
+		item.TransferID = &transferID
+	}
+
+	return item, nil
+}
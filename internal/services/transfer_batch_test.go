@@ -0,0 +1,278 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/phantom-sage/bankgo/internal/config"
+	"github.com/phantom-sage/bankgo/internal/database"
+	"github.com/phantom-sage/bankgo/internal/database/queries"
+	"github.com/phantom-sage/bankgo/internal/models"
+	"github.com/phantom-sage/bankgo/internal/queue"
+	"github.com/phantom-sage/bankgo/internal/repository"
+	"github.com/phantom-sage/bankgo/internal/utils"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateTransferBatch(t *testing.T) {
+	valid := TransferMoneyRequest{FromAccountID: 1, ToAccountID: 2, Amount: decimal.NewFromInt(10)}
+
+	tests := []struct {
+		name       string
+		req        SubmitTransferBatchRequest
+		maxItems   int
+		wantErr    bool
+		itemErrors []int
+	}{
+		{"valid atomic batch", SubmitTransferBatchRequest{Mode: TransferBatchModeAtomic, Items: []TransferMoneyRequest{valid, valid}}, 5, false, nil},
+		{"valid best effort batch", SubmitTransferBatchRequest{Mode: TransferBatchModeBestEffort, Items: []TransferMoneyRequest{valid}}, 5, false, nil},
+		{"batches disabled", SubmitTransferBatchRequest{Mode: TransferBatchModeAtomic, Items: []TransferMoneyRequest{valid}}, 0, true, nil},
+		{"unknown mode", SubmitTransferBatchRequest{Mode: "eventually", Items: []TransferMoneyRequest{valid}}, 5, true, nil},
+		{"empty batch", SubmitTransferBatchRequest{Mode: TransferBatchModeAtomic}, 5, true, nil},
+		{"too many items", SubmitTransferBatchRequest{Mode: TransferBatchModeAtomic, Items: []TransferMoneyRequest{valid, valid, valid}}, 2, true, nil},
+		{
+			"invalid items are reported by index",
+			SubmitTransferBatchRequest{Mode: TransferBatchModeBestEffort, Items: []TransferMoneyRequest{
+				valid,
+				{FromAccountID: 1, ToAccountID: 1, Amount: decimal.NewFromInt(10)},
+				{FromAccountID: 1, ToAccountID: 2, Amount: decimal.Zero},
+				{FromAccountID: 1, ToAccountID: 2, Amount: decimal.RequireFromString("1.005")},
+			}},
+			5, true, []int{1, 2, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTransferBatch(tt.req, tt.maxItems)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+
+			var validationErr *TransferBatchValidationError
+			require.True(t, errors.As(err, &validationErr))
+
+			indexes := []int{}
+			for _, item := range validationErr.Items {
+				indexes = append(indexes, item.Index)
+			}
+			if tt.itemErrors == nil {
+				assert.Empty(t, indexes)
+			} else {
+				assert.Equal(t, tt.itemErrors, indexes)
+			}
+		})
+	}
+}
+
+func TestTransferBatchStatus(t *testing.T) {
+	completed := TransferBatchItem{Status: TransferBatchItemCompleted}
+	failed := TransferBatchItem{Status: TransferBatchItemFailed}
+	skipped := TransferBatchItem{Status: TransferBatchItemSkipped}
+
+	assert.Equal(t, TransferBatchStatusCompleted, transferBatchStatus([]TransferBatchItem{completed, completed}))
+	assert.Equal(t, TransferBatchStatusPartiallyCompleted, transferBatchStatus([]TransferBatchItem{completed, failed}))
+	assert.Equal(t, TransferBatchStatusFailed, transferBatchStatus([]TransferBatchItem{failed, skipped}))
+}
+
+func TestTransferBatchErrorCode(t *testing.T) {
+	usage := newLimitWindowUsage(decimal.NewFromInt(100), 0, decimal.NewFromInt(90), 0, time.Now())
+	limitErr := &LimitExceededError{Currency: "USD", Period: LimitPeriodDaily, Kind: LimitKindAmount,
+		Usage: &TransferLimitUsage{Currency: "USD", Daily: usage, Monthly: usage}}
+
+	tests := []struct {
+		err  error
+		code string
+	}{
+		{ErrTransferBlocked, "transfer_blocked"},
+		{fmt.Errorf("transfer transaction failed: %w", limitErr), "limit_exceeded"},
+		{fmt.Errorf("transfer validation failed: %w", models.ErrInsufficientBalance), "insufficient_balance"},
+		{fmt.Errorf("transfer validation failed: %w", models.ErrCurrencyMismatch), "currency_mismatch"},
+		{fmt.Errorf("transfer validation failed: %w", models.ErrSameAccount), "validation_error"},
+		{errors.New("failed to subtract from source account: conn closed"), ""},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.code, transferBatchErrorCode(tt.err), tt.err.Error())
+	}
+}
+
+func TestSkipPendingBatchItems(t *testing.T) {
+	items := []TransferBatchItem{
+		{Status: TransferBatchItemFailed, ErrorCode: "insufficient_balance"},
+		{Status: TransferBatchItemPending},
+	}
+
+	skipPendingBatchItems(items)
+
+	assert.Equal(t, TransferBatchItemFailed, items[0].Status)
+	assert.Equal(t, "insufficient_balance", items[0].ErrorCode)
+	assert.Equal(t, TransferBatchItemSkipped, items[1].Status)
+	assert.Equal(t, "batch_aborted", items[1].ErrorCode)
+}
+
+func TestUniqueAccountIDs(t *testing.T) {
+	assert.Equal(t, []int32{2, 5, 9}, uniqueAccountIDs([]int32{9, 2, 5, 2, 9}))
+	assert.Empty(t, uniqueAccountIDs(nil))
+}
+
+// TestTransferBatch_AtomicAndBestEffort books the same overdrawing batch in
+// both modes against a migrated database in TEST_DATABASE_URL
+func TestTransferBatch_AtomicAndBestEffort(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" || testing.Short() {
+		t.Skip("Skipping transfer batch database test: TEST_DATABASE_URL not set")
+	}
+
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, dsn)
+	require.NoError(t, err)
+	defer pool.Close()
+
+	logger := zerolog.Nop()
+	repo := repository.New(&database.DB{Pool: pool}, logger)
+	repos := repository.NewRepositories(repo)
+	all := NewServices(repos, repo, logger,
+		WithTransferBatches(config.TransferBatchConfig{MaxItems: 10, AsyncThreshold: 10, StaleAfter: time.Minute}, nil))
+	q := queries.New(pool)
+
+	createUser := func(t *testing.T) int32 {
+		user, err := q.CreateUser(ctx, queries.CreateUserParams{
+			Email:        fmt.Sprintf("batch-%d@example.com", time.Now().UnixNano()),
+			PasswordHash: "not-a-real-hash",
+			FirstName:    "Batch",
+			LastName:     "Test",
+		})
+		require.NoError(t, err)
+		return user.ID
+	}
+
+	createAccount := func(t *testing.T, userID int32, currency string, balance int64) int32 {
+		account, err := q.CreateAccount(ctx, queries.CreateAccountParams{
+			UserID:   userID,
+			Currency: currency,
+			Column3:  utils.ConvertDecimalToPgNumeric(decimal.NewFromInt(balance)),
+		})
+		require.NoError(t, err)
+		return account.ID
+	}
+
+	balance := func(t *testing.T, id int32) decimal.Decimal {
+		account, err := q.GetAccount(ctx, id)
+		require.NoError(t, err)
+		amount, err := utils.ConvertPgNumericToDecimal(account.Balance)
+		require.NoError(t, err)
+		return amount
+	}
+
+	// A payer with 100 in each of two test currencies sends 120 from the
+	// first, which overdraws it on the second item, and 25 from the other
+	batchFor := func(t *testing.T, mode string) (*TransferBatch, []int32) {
+		payer, payee := createUser(t), createUser(t)
+		sources := []int32{createAccount(t, payer, "XBA", 100), createAccount(t, payer, "XBB", 100)}
+		targets := []int32{createAccount(t, payee, "XBA", 0), createAccount(t, payee, "XBB", 0)}
+
+		batch, err := all.TransferBatchService.SubmitBatch(ctx, payer, SubmitTransferBatchRequest{
+			Mode: mode,
+			Items: []TransferMoneyRequest{
+				{FromAccountID: sources[0], ToAccountID: targets[0], Amount: decimal.NewFromInt(60)},
+				{FromAccountID: sources[0], ToAccountID: targets[0], Amount: decimal.NewFromInt(60)},
+				{FromAccountID: sources[1], ToAccountID: targets[1], Amount: decimal.NewFromInt(25)},
+			},
+		})
+		require.NoError(t, err)
+		return batch, sources
+	}
+
+	t.Run("atomic batch books nothing when one item fails", func(t *testing.T) {
+		batch, sources := batchFor(t, TransferBatchModeAtomic)
+
+		assert.Equal(t, TransferBatchStatusFailed, batch.Status)
+		assert.Equal(t, int32(0), batch.SucceededItems)
+		assert.Equal(t, "insufficient_balance", batch.Items[1].ErrorCode)
+		assert.Equal(t, TransferBatchItemSkipped, batch.Items[0].Status)
+		assert.True(t, balance(t, sources[0]).Equal(decimal.NewFromInt(100)))
+		assert.True(t, balance(t, sources[1]).Equal(decimal.NewFromInt(100)))
+	})
+
+	t.Run("best effort batch books every item that fits", func(t *testing.T) {
+		batch, sources := batchFor(t, TransferBatchModeBestEffort)
+
+		assert.Equal(t, TransferBatchStatusPartiallyCompleted, batch.Status)
+		assert.Equal(t, int32(2), batch.SucceededItems)
+		assert.Equal(t, TransferBatchItemCompleted, batch.Items[0].Status)
+		assert.NotNil(t, batch.Items[0].TransferID)
+		assert.Equal(t, "insufficient_balance", batch.Items[1].ErrorCode)
+		assert.True(t, balance(t, sources[0]).Equal(decimal.NewFromInt(40)))
+		assert.True(t, balance(t, sources[1]).Equal(decimal.NewFromInt(75)))
+	})
+	// A batch left processing by a worker that died mid-run
+	claimedBatch := func(t *testing.T, startedAgo time.Duration) (int32, []int32) {
+		payer, payee := createUser(t), createUser(t)
+		source := createAccount(t, payer, "XBA", 100)
+		target := createAccount(t, payee, "XBA", 0)
+
+		svc := all.TransferBatchService.(*TransferBatchServiceImpl)
+		batchID, err := svc.createBatch(ctx, payer, SubmitTransferBatchRequest{
+			Mode:  TransferBatchModeAtomic,
+			Items: []TransferMoneyRequest{{FromAccountID: source, ToAccountID: target, Amount: decimal.NewFromInt(30)}},
+		})
+		require.NoError(t, err)
+
+		_, err = pool.Exec(ctx, "UPDATE transfer_batches SET status = 'processing', started_at = NOW() - $2::interval WHERE id = $1",
+			batchID, fmt.Sprintf("%d seconds", int(startedAgo.Seconds())))
+		require.NoError(t, err)
+		return batchID, []int32{source, target}
+	}
+
+	t.Run("stale processing batch is claimed again", func(t *testing.T) {
+		batchID, accounts := claimedBatch(t, time.Hour)
+
+		err := all.TransferBatchService.ProcessTransferBatch(ctx, queue.TransferBatchPayload{BatchID: batchID})
+		require.NoError(t, err)
+
+		batch, err := q.GetTransferBatch(ctx, batchID)
+		require.NoError(t, err)
+		assert.Equal(t, TransferBatchStatusCompleted, batch.Status)
+		assert.True(t, balance(t, accounts[0]).Equal(decimal.NewFromInt(70)))
+		assert.True(t, balance(t, accounts[1]).Equal(decimal.NewFromInt(30)))
+	})
+
+	t.Run("recently claimed batch is retried later", func(t *testing.T) {
+		batchID, accounts := claimedBatch(t, 0)
+
+		err := all.TransferBatchService.ProcessTransferBatch(ctx, queue.TransferBatchPayload{BatchID: batchID})
+		assert.ErrorIs(t, err, ErrTransferBatchInProgress)
+
+		batch, err := q.GetTransferBatch(ctx, batchID)
+		require.NoError(t, err)
+		assert.Equal(t, TransferBatchStatusProcessing, batch.Status)
+		assert.True(t, balance(t, accounts[0]).Equal(decimal.NewFromInt(100)))
+	})
+
+	t.Run("completion requires the claim the batch was booked under", func(t *testing.T) {
+		batchID, _ := claimedBatch(t, time.Hour)
+		stale, err := q.GetTransferBatch(ctx, batchID)
+		require.NoError(t, err)
+
+		// Another worker takes the batch over before this one completes it
+		_, err = q.ClaimTransferBatch(ctx, queries.ClaimTransferBatchParams{
+			ID:         batchID,
+			StaleAfter: pgtype.Interval{Microseconds: time.Minute.Microseconds(), Valid: true},
+		})
+		require.NoError(t, err)
+
+		_, err = completeBatchParams(ctx, q, stale, nil, "")
+		assert.ErrorIs(t, err, errTransferBatchClaimLost)
+	})
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/phantom-sage/bankgo/internal/database"
+	"github.com/phantom-sage/bankgo/internal/database/queries"
+	"github.com/phantom-sage/bankgo/internal/repository"
+	"github.com/phantom-sage/bankgo/internal/utils"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lockRecorder is a DBTX that records which account IDs are locked, in order
+type lockRecorder struct {
+	locked []int32
+}
+
+func (r *lockRecorder) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, nil
+}
+
+func (r *lockRecorder) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (r *lockRecorder) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	id := args[0].(int32)
+	r.locked = append(r.locked, id)
+	return accountRow{id: id}
+}
+
+// accountRow scans an account with only its ID set
+type accountRow struct {
+	id int32
+}
+
+func (r accountRow) Scan(dest ...interface{}) error {
+	*dest[0].(*int32) = r.id
+	return nil
+}
+
+func TestLockTransferAccounts_AscendingOrder(t *testing.T) {
+	tests := []struct {
+		name   string
+		from   int32
+		to     int32
+		locked []int32
+	}{
+		{"lower ID is source", 3, 7, []int32{3, 7}},
+		{"lower ID is destination", 7, 3, []int32{3, 7}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &lockRecorder{}
+
+			from, to, err := lockTransferAccounts(context.Background(), queries.New(recorder), tt.from, tt.to)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.locked, recorder.locked)
+			assert.Equal(t, tt.from, from.ID)
+			assert.Equal(t, tt.to, to.ID)
+		})
+	}
+}
+
+// TestTransferMoney_ConcurrentTransfersConserveBalance runs thousands of
+// transfers in both directions between a small set of accounts and checks
+// that none fail on a deadlock and that money is neither created nor lost.
+// It needs a migrated database in TEST_DATABASE_URL.
+func TestTransferMoney_ConcurrentTransfersConserveBalance(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" || testing.Short() {
+		t.Skip("Skipping transfer concurrency stress test: TEST_DATABASE_URL not set")
+	}
+
+	const (
+		numAccounts  = 6
+		numTransfers = 3000
+		numWorkers   = 48
+	)
+
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, dsn)
+	require.NoError(t, err)
+	defer pool.Close()
+
+	logger := zerolog.Nop()
+	repo := repository.New(&database.DB{Pool: pool}, logger)
+	repos := repository.NewRepositories(repo)
+	service := NewTransferService(repo, repos.AccountRepo, repos.TransferRepo, logger)
+	q := queries.New(pool)
+
+	// Seed accounts owned by fresh users so reruns do not collide
+	initialBalance := decimal.NewFromInt(500)
+	suffix := time.Now().UnixNano()
+	accountIDs := make([]int32, 0, numAccounts)
+	for i := 0; i < numAccounts; i++ {
+		user, err := q.CreateUser(ctx, queries.CreateUserParams{
+			Email:        fmt.Sprintf("stress-%d-%d@example.com", suffix, i),
+			PasswordHash: "not-a-real-hash",
+			FirstName:    "Stress",
+			LastName:     "Test",
+		})
+		require.NoError(t, err)
+
+		account, err := q.CreateAccount(ctx, queries.CreateAccountParams{
+			UserID:   user.ID,
+			Currency: "SEK",
+			Column3:  utils.ConvertDecimalToPgNumeric(initialBalance),
+		})
+		require.NoError(t, err)
+		accountIDs = append(accountIDs, account.ID)
+	}
+
+	// Random pairs guarantee plenty of opposing A->B / B->A transfers
+	rng := rand.New(rand.NewSource(42))
+	requests := make(chan TransferMoneyRequest, numTransfers)
+	for i := 0; i < numTransfers; i++ {
+		from := rng.Intn(numAccounts)
+		to := (from + 1 + rng.Intn(numAccounts-1)) % numAccounts
+		requests <- TransferMoneyRequest{
+			FromAccountID: accountIDs[from],
+			ToAccountID:   accountIDs[to],
+			Amount:        decimal.NewFromInt(int64(1 + rng.Intn(40))),
+			Description:   "concurrency stress test",
+		}
+	}
+	close(requests)
+
+	var (
+		mu          sync.Mutex
+		transferIDs []int32
+		unexpected  []error
+	)
+
+	var wg sync.WaitGroup
+	for w := 0; w < numWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for req := range requests {
+				transfer, err := service.TransferMoney(ctx, req)
+
+				mu.Lock()
+				switch {
+				case err == nil:
+					transferIDs = append(transferIDs, int32(transfer.ID))
+				case !strings.Contains(err.Error(), "insufficient balance"):
+					unexpected = append(unexpected, err)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range unexpected {
+		t.Errorf("unexpected transfer error: %v", err)
+	}
+	require.NotEmpty(t, transferIDs)
+
+	// Money only leaves the seeded accounts as fees credited to a house account
+	total := decimal.Zero
+	for _, id := range accountIDs {
+		account, err := q.GetAccount(ctx, id)
+		require.NoError(t, err)
+
+		balance, err := utils.ConvertPgNumericToDecimal(account.Balance)
+		require.NoError(t, err)
+		assert.False(t, balance.IsNegative(), "account %d went negative", id)
+		total = total.Add(balance)
+	}
+
+	fees, err := q.ListTransferFeesByTransferIDs(ctx, transferIDs)
+	require.NoError(t, err)
+	for _, fee := range fees {
+		amount, err := utils.ConvertPgNumericToDecimal(fee.Amount)
+		require.NoError(t, err)
+		total = total.Add(amount)
+	}
+
+	expected := initialBalance.Mul(decimal.NewFromInt(numAccounts))
+	assert.True(t, expected.Equal(total), "expected total %s, got %s", expected, total)
+	t.Logf("%d of %d transfers completed", len(transferIDs), numTransfers)
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/phantom-sage/bankgo/internal/database/queries"
+	"github.com/phantom-sage/bankgo/internal/logging"
+	"github.com/phantom-sage/bankgo/internal/models"
+	"github.com/phantom-sage/bankgo/internal/pagination"
+	"github.com/phantom-sage/bankgo/internal/utils"
+	"github.com/shopspring/decimal"
+)
+
+// Transfer directions relative to the requesting user
+const (
+	TransferDirectionIncoming = "incoming"
+	TransferDirectionOutgoing = "outgoing"
+)
+
+// ErrInvalidTransferHistoryRequest is returned for malformed cursors and filters
+var ErrInvalidTransferHistoryRequest = errors.New("invalid transfer history request")
+
+// ListTransfersRequest selects one keyset page of a user's transfers. Zero
+// values leave a filter unset.
+type ListTransfersRequest struct {
+	UserID                int32
+	AccountID             int32
+	Cursor                string
+	Limit                 int32
+	CreatedFrom           *time.Time
+	CreatedTo             *time.Time
+	MinAmount             *decimal.Decimal
+	MaxAmount             *decimal.Decimal
+	Direction             string
+	CounterpartyAccountID int32
+}
+
+// TransferPageResponse is one page of transfers, newest first, with opaque
+// cursors to the neighbouring pages
+type TransferPageResponse struct {
+	Transfers  []models.Transfer `json:"transfers"`
+	Limit      int32             `json:"limit"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+	PrevCursor string            `json:"prev_cursor,omitempty"`
+}
+
+// ListTransfers returns a page of the user's transfers ordered by (created_at, id).
+// Unlike offset pagination, pages stay stable while new transfers arrive.
+func (s *TransferServiceImpl) ListTransfers(ctx context.Context, req ListTransfersRequest) (*TransferPageResponse, error) {
+	start := time.Now()
+	contextLogger := logging.NewContextLogger(s.logger, ctx).
+		WithOperation("list_transfers").
+		WithUserID(int64(req.UserID))
+
+	if req.Limit <= 0 {
+		req.Limit = 20
+	}
+	if req.Limit > 100 {
+		req.Limit = 100
+	}
+
+	cursor, err := pagination.Decode(req.Cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTransferHistoryRequest, err)
+	}
+
+	params, err := buildListTransfersParams(req, cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	// Rows are read one past the limit to tell whether another page follows
+	var rows []queries.GetTransfersByUserRow
+	dbStart := time.Now()
+	if cursor != nil && cursor.Backward() {
+		newer, err := s.transferRepo.ListUserTransfersNewer(ctx, queries.ListUserTransfersNewerParams(params))
+		if err != nil {
+			contextLogger.Error().Err(err).Msg("Failed to list newer transfers")
+			return nil, fmt.Errorf("failed to list transfers: %w", err)
+		}
+		for _, row := range newer {
+			rows = append(rows, queries.GetTransfersByUserRow(row))
+		}
+	} else {
+		older, err := s.transferRepo.ListUserTransfersOlder(ctx, params)
+		if err != nil {
+			contextLogger.Error().Err(err).Msg("Failed to list older transfers")
+			return nil, fmt.Errorf("failed to list transfers: %w", err)
+		}
+		for _, row := range older {
+			rows = append(rows, queries.GetTransfersByUserRow(row))
+		}
+	}
+	s.performanceLogger.LogDatabaseQuery("SELECT transfers page by user", time.Since(dbStart), int64(len(rows)))
+
+	page := pagination.Build(rows, int(req.Limit), cursor, func(row queries.GetTransfersByUserRow) (time.Time, int64) {
+		return utils.ConvertPgTimestampToTime(row.CreatedAt), int64(row.ID)
+	})
+
+	transfers := make([]models.Transfer, len(page.Items))
+	for i, row := range page.Items {
+		transfer, err := convertDBTransfersByUserRowToModel(row)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert transfer at index %d: %w", i, err)
+		}
+		transfers[i] = transfer
+	}
+
+	// Report fees collected on these transfers
+	if err := attachTransferFees(ctx, s.repo.ReadQueries(), transfers); err != nil {
+		contextLogger.Error().
+			Err(err).
+			Msg("Failed to attach transfer fees")
+		return nil, err
+	}
+
+	contextLogger.Info().
+		Int("transfer_count", len(transfers)).
+		Int32("limit", req.Limit).
+		Bool("has_next", page.NextCursor != "").
+		Bool("has_prev", page.PrevCursor != "").
+		Int64("duration_ms", time.Since(start).Milliseconds()).
+		Msg("Transfer page retrieved successfully")
+
+	return &TransferPageResponse{
+		Transfers:  transfers,
+		Limit:      req.Limit,
+		NextCursor: page.NextCursor,
+		PrevCursor: page.PrevCursor,
+	}, nil
+}
+
+// buildListTransfersParams validates the filters and converts them to query parameters
+func buildListTransfersParams(req ListTransfersRequest, cursor *pagination.Cursor) (queries.ListUserTransfersOlderParams, error) {
+	params := queries.ListUserTransfersOlderParams{
+		UserID:                req.UserID,
+		AccountID:             req.AccountID,
+		Direction:             req.Direction,
+		CounterpartyAccountID: req.CounterpartyAccountID,
+		LimitCount:            req.Limit + 1,
+	}
+
+	switch req.Direction {
+	case "", TransferDirectionIncoming, TransferDirectionOutgoing:
+	default:
+		return params, fmt.Errorf("%w: direction must be %s or %s", ErrInvalidTransferHistoryRequest, TransferDirectionIncoming, TransferDirectionOutgoing)
+	}
+
+	if req.CreatedFrom != nil && req.CreatedTo != nil && !req.CreatedFrom.Before(*req.CreatedTo) {
+		return params, fmt.Errorf("%w: from must be before to", ErrInvalidTransferHistoryRequest)
+	}
+	if req.CreatedFrom != nil {
+		params.CreatedFrom = pgtype.Timestamp{Time: req.CreatedFrom.UTC(), Valid: true}
+	}
+	if req.CreatedTo != nil {
+		params.CreatedTo = pgtype.Timestamp{Time: req.CreatedTo.UTC(), Valid: true}
+	}
+
+	if req.MinAmount != nil && req.MaxAmount != nil && req.MinAmount.GreaterThan(*req.MaxAmount) {
+		return params, fmt.Errorf("%w: min_amount cannot exceed max_amount", ErrInvalidTransferHistoryRequest)
+	}
+	if req.MinAmount != nil {
+		params.MinAmount = utils.ConvertDecimalToPgNumeric(*req.MinAmount)
+	}
+	if req.MaxAmount != nil {
+		params.MaxAmount = utils.ConvertDecimalToPgNumeric(*req.MaxAmount)
+	}
+
+	if cursor != nil {
+		params.CursorCreatedAt = pgtype.Timestamp{Time: cursor.CreatedAt.UTC(), Valid: true}
+		params.CursorID = int32(cursor.ID)
+	}
+
+	return params, nil
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/phantom-sage/bankgo/internal/database"
+	"github.com/phantom-sage/bankgo/internal/database/queries"
+	"github.com/phantom-sage/bankgo/internal/pagination"
+	"github.com/phantom-sage/bankgo/internal/repository"
+	"github.com/phantom-sage/bankgo/internal/utils"
+	"github.com/rs/zerolog"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildListTransfersParams(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.FixedZone("CET", 3600))
+	to := from.Add(24 * time.Hour)
+	minAmount, maxAmount := decimal.NewFromInt(10), decimal.NewFromInt(50)
+	cursor := &pagination.Cursor{CreatedAt: from, ID: 7, Direction: pagination.Next}
+
+	params, err := buildListTransfersParams(ListTransfersRequest{
+		UserID:      1,
+		Limit:       20,
+		CreatedFrom: &from,
+		CreatedTo:   &to,
+		MinAmount:   &minAmount,
+		MaxAmount:   &maxAmount,
+		Direction:   TransferDirectionIncoming,
+	}, cursor)
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(21), params.LimitCount, "one extra row tells whether another page follows")
+	assert.Equal(t, time.Date(2023, 12, 31, 23, 0, 0, 0, time.UTC), params.CreatedFrom.Time)
+	assert.True(t, params.MinAmount.Valid)
+	assert.True(t, params.CursorCreatedAt.Valid)
+	assert.Equal(t, int32(7), params.CursorID)
+
+	params, err = buildListTransfersParams(ListTransfersRequest{UserID: 1, Limit: 20}, nil)
+	require.NoError(t, err)
+	assert.False(t, params.CreatedFrom.Valid)
+	assert.False(t, params.MaxAmount.Valid)
+	assert.False(t, params.CursorCreatedAt.Valid)
+
+	invalid := []ListTransfersRequest{
+		{Direction: "sideways"},
+		{CreatedFrom: &to, CreatedTo: &from},
+		{MinAmount: &maxAmount, MaxAmount: &minAmount},
+	}
+	for _, req := range invalid {
+		_, err := buildListTransfersParams(req, nil)
+		assert.True(t, errors.Is(err, ErrInvalidTransferHistoryRequest), "expected invalid request for %+v", req)
+	}
+}
+
+// TestListTransfers_Pages walks a user's history forward and back against a
+// migrated database in TEST_DATABASE_URL
+func TestListTransfers_Pages(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" || testing.Short() {
+		t.Skip("Skipping transfer history database test: TEST_DATABASE_URL not set")
+	}
+
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, dsn)
+	require.NoError(t, err)
+	defer pool.Close()
+
+	logger := zerolog.Nop()
+	repo := repository.New(&database.DB{Pool: pool}, logger)
+	all := NewServices(repository.NewRepositories(repo), repo, logger)
+	q := queries.New(pool)
+
+	createAccount := func(t *testing.T, balance int64) (int32, int32) {
+		user, err := q.CreateUser(ctx, queries.CreateUserParams{
+			Email:        fmt.Sprintf("history-%d@example.com", time.Now().UnixNano()),
+			PasswordHash: "not-a-real-hash",
+			FirstName:    "History",
+			LastName:     "Test",
+		})
+		require.NoError(t, err)
+		account, err := q.CreateAccount(ctx, queries.CreateAccountParams{
+			UserID:   user.ID,
+			Currency: "XHI",
+			Column3:  utils.ConvertDecimalToPgNumeric(decimal.NewFromInt(balance)),
+		})
+		require.NoError(t, err)
+		return user.ID, account.ID
+	}
+
+	userID, accountID := createAccount(t, 1000)
+	_, otherAccountID := createAccount(t, 1000)
+
+	// Five transfers: 1..4 outgoing, 5 incoming
+	for i := 1; i <= 5; i++ {
+		req := TransferMoneyRequest{FromAccountID: accountID, ToAccountID: otherAccountID, Amount: decimal.NewFromInt(int64(i))}
+		if i == 5 {
+			req.FromAccountID, req.ToAccountID = otherAccountID, accountID
+		}
+		_, err := all.TransferService.TransferMoney(ctx, req)
+		require.NoError(t, err)
+	}
+
+	amounts := func(page *TransferPageResponse) []string {
+		result := []string{}
+		for _, transfer := range page.Transfers {
+			result = append(result, transfer.Amount.String())
+		}
+		return result
+	}
+
+	first, err := all.TransferService.ListTransfers(ctx, ListTransfersRequest{UserID: userID, Limit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"5", "4"}, amounts(first))
+	assert.Empty(t, first.PrevCursor)
+
+	second, err := all.TransferService.ListTransfers(ctx, ListTransfersRequest{UserID: userID, Limit: 2, Cursor: first.NextCursor})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"3", "2"}, amounts(second))
+
+	// A transfer booked while paging does not shift the next page
+	_, err = all.TransferService.TransferMoney(ctx, TransferMoneyRequest{FromAccountID: accountID, ToAccountID: otherAccountID, Amount: decimal.NewFromInt(6)})
+	require.NoError(t, err)
+
+	last, err := all.TransferService.ListTransfers(ctx, ListTransfersRequest{UserID: userID, Limit: 2, Cursor: second.NextCursor})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1"}, amounts(last))
+	assert.Empty(t, last.NextCursor)
+
+	back, err := all.TransferService.ListTransfers(ctx, ListTransfersRequest{UserID: userID, Limit: 2, Cursor: second.PrevCursor})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"5", "4"}, amounts(back))
+	assert.NotEmpty(t, back.PrevCursor, "the transfer booked while paging is newer")
+
+	minAmount := decimal.NewFromInt(2)
+	filtered, err := all.TransferService.ListTransfers(ctx, ListTransfersRequest{
+		UserID:    userID,
+		Limit:     10,
+		Direction: TransferDirectionOutgoing,
+		MinAmount: &minAmount,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"6", "4", "3", "2"}, amounts(filtered))
+}
@@ -0,0 +1,245 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/phantom-sage/bankgo/internal/config"
+	"github.com/phantom-sage/bankgo/internal/database/queries"
+	"github.com/phantom-sage/bankgo/internal/utils"
+	"github.com/shopspring/decimal"
+)
+
+// Transfer limit periods and kinds reported in limit errors
+const (
+	LimitPeriodDaily   = "daily"
+	LimitPeriodMonthly = "monthly"
+	LimitKindAmount    = "amount"
+	LimitKindCount     = "count"
+)
+
+// LimitWindowUsage describes usage against the limits of one period.
+// Nil limits and remaining allowances mean the period is unlimited.
+type LimitWindowUsage struct {
+	AmountLimit     *decimal.Decimal `json:"amount_limit"`
+	AmountUsed      decimal.Decimal  `json:"amount_used"`
+	AmountRemaining *decimal.Decimal `json:"amount_remaining"`
+	CountLimit      *int             `json:"count_limit"`
+	CountUsed       int              `json:"count_used"`
+	CountRemaining  *int             `json:"count_remaining"`
+	ResetsAt        time.Time        `json:"resets_at"`
+}
+
+// TransferLimitUsage describes a user's usage of their transfer limits in one currency
+type TransferLimitUsage struct {
+	Currency   string           `json:"currency"`
+	Overridden bool             `json:"overridden"`
+	Daily      LimitWindowUsage `json:"daily"`
+	Monthly    LimitWindowUsage `json:"monthly"`
+}
+
+// LimitExceededError is returned when a transfer would exceed a transfer limit
+type LimitExceededError struct {
+	Currency string
+	Period   string
+	Kind     string
+	Usage    *TransferLimitUsage
+}
+
+// Error implements the error interface
+func (e *LimitExceededError) Error() string {
+	window := e.Usage.window(e.Period)
+	if e.Kind == LimitKindCount {
+		return fmt.Sprintf("transfer limit exceeded: %s limit of %d %s transfers, %d remaining",
+			e.Period, *window.CountLimit, e.Currency, *window.CountRemaining)
+	}
+	return fmt.Sprintf("transfer limit exceeded: %s limit of %s %s, %s remaining",
+		e.Period, window.AmountLimit.StringFixed(2), e.Currency, window.AmountRemaining.StringFixed(2))
+}
+
+// Details returns the exceeded limit and the remaining allowance of every limit
+func (e *LimitExceededError) Details() map[string]string {
+	details := map[string]string{
+		"currency":   e.Currency,
+		"period":     e.Period,
+		"limit_type": e.Kind,
+	}
+
+	for period, window := range map[string]LimitWindowUsage{
+		LimitPeriodDaily:   e.Usage.Daily,
+		LimitPeriodMonthly: e.Usage.Monthly,
+	} {
+		details["remaining_"+period+"_amount"] = "unlimited"
+		if window.AmountRemaining != nil {
+			details["remaining_"+period+"_amount"] = window.AmountRemaining.StringFixed(2)
+		}
+		details["remaining_"+period+"_count"] = "unlimited"
+		if window.CountRemaining != nil {
+			details["remaining_"+period+"_count"] = strconv.Itoa(*window.CountRemaining)
+		}
+		details[period+"_resets_at"] = window.ResetsAt.Format(time.RFC3339)
+	}
+
+	return details
+}
+
+// TransferLimiter enforces daily and monthly transfer limits per user and currency
+type TransferLimiter struct {
+	config config.TransferLimitConfig
+}
+
+// NewTransferLimiter creates a new transfer limiter with the configured defaults
+func NewTransferLimiter(cfg config.TransferLimitConfig) *TransferLimiter {
+	return &TransferLimiter{config: cfg}
+}
+
+// Enabled reports whether transfer limits should be enforced
+func (l *TransferLimiter) Enabled() bool {
+	return l != nil && l.config.Enabled
+}
+
+// Usage returns the user's current usage of their limits in a currency.
+// Admin overrides replace the configured defaults field by field.
+func (l *TransferLimiter) Usage(ctx context.Context, q *queries.Queries, userID int32, currency string, now time.Time) (*TransferLimitUsage, error) {
+	currency = strings.ToUpper(currency)
+	limit := l.config.ForCurrency(currency)
+
+	override, err := q.GetUserTransferLimit(ctx, queries.GetUserTransferLimitParams{
+		UserID:   userID,
+		Currency: currency,
+	})
+	overridden := err == nil
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get transfer limit override: %w", err)
+	}
+	if overridden {
+		if limit, err = applyLimitOverride(limit, override); err != nil {
+			return nil, err
+		}
+	}
+
+	dayStart, monthStart := limitPeriodStarts(now)
+	row, err := q.GetTransferUsage(ctx, queries.GetTransferUsageParams{
+		DayStart:   utils.ConvertTimeToPgTimestamp(dayStart),
+		UserID:     userID,
+		Currency:   currency,
+		MonthStart: utils.ConvertTimeToPgTimestamp(monthStart),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transfer usage: %w", err)
+	}
+
+	dailyAmount, err := utils.ConvertPgNumericToDecimal(row.DailyAmount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert daily usage: %w", err)
+	}
+	monthlyAmount, err := utils.ConvertPgNumericToDecimal(row.MonthlyAmount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert monthly usage: %w", err)
+	}
+
+	return &TransferLimitUsage{
+		Currency:   currency,
+		Overridden: overridden,
+		Daily:      newLimitWindowUsage(limit.DailyAmount, limit.DailyCount, dailyAmount, int(row.DailyCount), dayStart.AddDate(0, 0, 1)),
+		Monthly:    newLimitWindowUsage(limit.MonthlyAmount, limit.MonthlyCount, monthlyAmount, int(row.MonthlyCount), monthStart.AddDate(0, 1, 0)),
+	}, nil
+}
+
+// Check returns a LimitExceededError when amount would exceed the user's limits.
+// Callers must hold a lock on the source account so the aggregate cannot change
+// underneath them; accounts are unique per user and currency, so that lock
+// serializes every outgoing transfer counted here.
+func (l *TransferLimiter) Check(ctx context.Context, q *queries.Queries, userID int32, currency string, amount decimal.Decimal, now time.Time) error {
+	usage, err := l.Usage(ctx, q, userID, currency, now)
+	if err != nil {
+		return err
+	}
+	return usage.Check(amount)
+}
+
+// Check returns a LimitExceededError when one more transfer of amount would exceed a limit
+func (u *TransferLimitUsage) Check(amount decimal.Decimal) error {
+	for _, period := range []string{LimitPeriodDaily, LimitPeriodMonthly} {
+		window := u.window(period)
+		if window.AmountRemaining != nil && amount.GreaterThan(*window.AmountRemaining) {
+			return &LimitExceededError{Currency: u.Currency, Period: period, Kind: LimitKindAmount, Usage: u}
+		}
+		if window.CountRemaining != nil && *window.CountRemaining < 1 {
+			return &LimitExceededError{Currency: u.Currency, Period: period, Kind: LimitKindCount, Usage: u}
+		}
+	}
+	return nil
+}
+
+// window returns the usage of a limit period
+func (u *TransferLimitUsage) window(period string) LimitWindowUsage {
+	if period == LimitPeriodMonthly {
+		return u.Monthly
+	}
+	return u.Daily
+}
+
+// newLimitWindowUsage builds usage for one period; zero limits are unlimited
+func newLimitWindowUsage(amountLimit decimal.Decimal, countLimit int, amountUsed decimal.Decimal, countUsed int, resetsAt time.Time) LimitWindowUsage {
+	window := LimitWindowUsage{
+		AmountUsed: amountUsed,
+		CountUsed:  countUsed,
+		ResetsAt:   resetsAt,
+	}
+
+	if amountLimit.IsPositive() {
+		remaining := decimal.Max(amountLimit.Sub(amountUsed), decimal.Zero)
+		window.AmountLimit = &amountLimit
+		window.AmountRemaining = &remaining
+	}
+
+	if countLimit > 0 {
+		remaining := countLimit - countUsed
+		if remaining < 0 {
+			remaining = 0
+		}
+		window.CountLimit = &countLimit
+		window.CountRemaining = &remaining
+	}
+
+	return window
+}
+
+// applyLimitOverride replaces default limits with the non-null fields of an override
+func applyLimitOverride(limit config.TransferLimit, override queries.UserTransferLimit) (config.TransferLimit, error) {
+	if override.DailyAmount.Valid {
+		amount, err := utils.ConvertPgNumericToDecimal(override.DailyAmount)
+		if err != nil {
+			return limit, fmt.Errorf("failed to convert daily amount override: %w", err)
+		}
+		limit.DailyAmount = amount
+	}
+	if override.MonthlyAmount.Valid {
+		amount, err := utils.ConvertPgNumericToDecimal(override.MonthlyAmount)
+		if err != nil {
+			return limit, fmt.Errorf("failed to convert monthly amount override: %w", err)
+		}
+		limit.MonthlyAmount = amount
+	}
+	if override.DailyCount.Valid {
+		limit.DailyCount = int(override.DailyCount.Int32)
+	}
+	if override.MonthlyCount.Valid {
+		limit.MonthlyCount = int(override.MonthlyCount.Int32)
+	}
+	return limit, nil
+}
+
+// limitPeriodStarts returns the UTC start of the current day and month
+func limitPeriodStarts(now time.Time) (dayStart, monthStart time.Time) {
+	now = now.UTC()
+	dayStart = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	monthStart = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return dayStart, monthStart
+}
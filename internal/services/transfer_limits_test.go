@@ -0,0 +1,126 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/phantom-sage/bankgo/internal/config"
+	"github.com/phantom-sage/bankgo/internal/database/queries"
+	"github.com/phantom-sage/bankgo/internal/utils"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLimitUsage(dailyUsed, monthlyUsed decimal.Decimal, dailyCount, monthlyCount int) *TransferLimitUsage {
+	resetsAt := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	return &TransferLimitUsage{
+		Currency: "USD",
+		Daily:    newLimitWindowUsage(decimal.NewFromInt(1000), 5, dailyUsed, dailyCount, resetsAt),
+		Monthly:  newLimitWindowUsage(decimal.NewFromInt(5000), 20, monthlyUsed, monthlyCount, resetsAt),
+	}
+}
+
+func TestTransferLimitUsage_CheckWithinLimits(t *testing.T) {
+	usage := newTestLimitUsage(decimal.NewFromInt(400), decimal.NewFromInt(400), 1, 1)
+
+	assert.NoError(t, usage.Check(decimal.NewFromInt(600)))
+	assert.True(t, usage.Daily.AmountRemaining.Equal(decimal.NewFromInt(600)))
+	assert.Equal(t, 4, *usage.Daily.CountRemaining)
+}
+
+func TestTransferLimitUsage_DailyAmountExceeded(t *testing.T) {
+	usage := newTestLimitUsage(decimal.NewFromInt(900), decimal.NewFromInt(900), 1, 1)
+
+	err := usage.Check(decimal.NewFromFloat(100.01))
+	require.Error(t, err)
+
+	var limitErr *LimitExceededError
+	require.True(t, errors.As(err, &limitErr))
+	assert.Equal(t, LimitPeriodDaily, limitErr.Period)
+	assert.Equal(t, LimitKindAmount, limitErr.Kind)
+	assert.Contains(t, err.Error(), "daily limit of 1000.00 USD, 100.00 remaining")
+
+	details := limitErr.Details()
+	assert.Equal(t, "100.00", details["remaining_daily_amount"])
+	assert.Equal(t, "4100.00", details["remaining_monthly_amount"])
+	assert.Equal(t, "4", details["remaining_daily_count"])
+	assert.Equal(t, "19", details["remaining_monthly_count"])
+}
+
+func TestTransferLimitUsage_CountExceeded(t *testing.T) {
+	usage := newTestLimitUsage(decimal.NewFromInt(10), decimal.NewFromInt(10), 5, 5)
+
+	err := usage.Check(decimal.NewFromInt(1))
+
+	var limitErr *LimitExceededError
+	require.True(t, errors.As(err, &limitErr))
+	assert.Equal(t, LimitPeriodDaily, limitErr.Period)
+	assert.Equal(t, LimitKindCount, limitErr.Kind)
+	assert.Contains(t, err.Error(), "daily limit of 5 USD transfers, 0 remaining")
+}
+
+func TestTransferLimitUsage_MonthlyAmountExceeded(t *testing.T) {
+	usage := newTestLimitUsage(decimal.Zero, decimal.NewFromInt(4800), 0, 10)
+
+	err := usage.Check(decimal.NewFromInt(500))
+
+	var limitErr *LimitExceededError
+	require.True(t, errors.As(err, &limitErr))
+	assert.Equal(t, LimitPeriodMonthly, limitErr.Period)
+	assert.Equal(t, LimitKindAmount, limitErr.Kind)
+}
+
+func TestTransferLimitUsage_ZeroLimitsAreUnlimited(t *testing.T) {
+	window := newLimitWindowUsage(decimal.Zero, 0, decimal.NewFromInt(1_000_000), 1000, time.Now())
+	usage := &TransferLimitUsage{Currency: "USD", Daily: window, Monthly: window}
+
+	assert.Nil(t, window.AmountLimit)
+	assert.Nil(t, window.CountRemaining)
+	assert.NoError(t, usage.Check(decimal.NewFromInt(1_000_000)))
+}
+
+func TestNewLimitWindowUsage_RemainingNeverNegative(t *testing.T) {
+	window := newLimitWindowUsage(decimal.NewFromInt(100), 2, decimal.NewFromInt(150), 3, time.Now())
+
+	assert.True(t, window.AmountRemaining.IsZero())
+	assert.Equal(t, 0, *window.CountRemaining)
+}
+
+func TestApplyLimitOverride(t *testing.T) {
+	defaults := config.TransferLimit{
+		DailyAmount:   decimal.NewFromInt(1000),
+		MonthlyAmount: decimal.NewFromInt(5000),
+		DailyCount:    5,
+		MonthlyCount:  20,
+	}
+
+	limit, err := applyLimitOverride(defaults, queries.UserTransferLimit{
+		DailyAmount: utils.ConvertDecimalToPgNumeric(decimal.NewFromInt(250)),
+		DailyCount:  pgtype.Int4{Int32: 0, Valid: true},
+	})
+	require.NoError(t, err)
+
+	assert.True(t, limit.DailyAmount.Equal(decimal.NewFromInt(250)))
+	assert.True(t, limit.MonthlyAmount.Equal(defaults.MonthlyAmount))
+	assert.Equal(t, 0, limit.DailyCount)
+	assert.Equal(t, 20, limit.MonthlyCount)
+}
+
+func TestLimitPeriodStarts(t *testing.T) {
+	now := time.Date(2024, 3, 15, 13, 45, 0, 0, time.FixedZone("UTC+2", 2*60*60))
+
+	dayStart, monthStart := limitPeriodStarts(now)
+
+	assert.Equal(t, time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC), dayStart)
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), monthStart)
+}
+
+func TestTransferLimiter_Enabled(t *testing.T) {
+	var nilLimiter *TransferLimiter
+	assert.False(t, nilLimiter.Enabled())
+	assert.False(t, NewTransferLimiter(config.TransferLimitConfig{}).Enabled())
+	assert.True(t, NewTransferLimiter(config.TransferLimitConfig{Enabled: true}).Enabled())
+}
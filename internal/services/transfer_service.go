@@ -2,10 +2,17 @@ package services
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/phantom-sage/bankgo/internal/config"
 	"github.com/phantom-sage/bankgo/internal/database/queries"
+	"github.com/phantom-sage/bankgo/internal/events"
 	"github.com/phantom-sage/bankgo/internal/logging"
 	"github.com/phantom-sage/bankgo/internal/models"
 	"github.com/phantom-sage/bankgo/internal/repository"
@@ -22,6 +29,9 @@ type TransferService interface {
 	UpdateTransferStatus(ctx context.Context, transferID int32, status string) (*models.Transfer, error)
 	GetTransfersByStatus(ctx context.Context, status string, limit, offset int32) (*TransferHistoryResponse, error)
 	GetTransfersByUser(ctx context.Context, userID int32, limit, offset int32) (*TransferHistoryResponse, error)
+	ListTransfers(ctx context.Context, req ListTransfersRequest) (*TransferPageResponse, error)
+	GetTransferLimitUsage(ctx context.Context, userID int32) ([]TransferLimitUsage, error)
+	PreviewTransferFee(ctx context.Context, req TransferMoneyRequest) (*TransferFeeQuote, error)
 }
 
 // TransferMoneyRequest represents the request to transfer money between accounts
@@ -55,13 +65,52 @@ type TransferServiceImpl struct {
 	logger            zerolog.Logger
 	auditLogger       *logging.AuditLogger
 	performanceLogger *logging.PerformanceLogger
+	riskEngine        *RiskEngine
+	anomalyAlerter    AnomalyAlerter
+	transferLimiter   *TransferLimiter
+	txLogger          *repository.TransactionLogger
+	batchConfig       config.TransferBatchConfig
+	batchQueue        TransferBatchQueue
+	eventOutbox       bool
+}
+
+// transferMaxTxRetries bounds retries of a transfer aborted by a deadlock or serialization failure
+const transferMaxTxRetries = 3
+
+// AnomalyAlerter raises alerts for suspicious transfers
+type AnomalyAlerter interface {
+	TransactionAnomalyAlert(ctx context.Context, userID, accountID string, amount, threshold float64, anomalyType string) error
+}
+
+// TransferServiceOption configures optional transfer service dependencies
+type TransferServiceOption func(*TransferServiceImpl)
+
+// WithRiskEngine enables fraud and anomaly checks on transfers.
+// alerter may be nil, in which case flagged transfers are only logged.
+func WithRiskEngine(engine *RiskEngine, alerter AnomalyAlerter) TransferServiceOption {
+	return func(s *TransferServiceImpl) {
+		s.riskEngine = engine
+		s.anomalyAlerter = alerter
+	}
+}
+
+// WithTransferLimits enables daily and monthly transfer limits
+func WithTransferLimits(limiter *TransferLimiter) TransferServiceOption {
+	return func(s *TransferServiceImpl) {
+		s.transferLimiter = limiter
+	}
 }
 
 // NewTransferService creates a new transfer service
-func NewTransferService(repo *repository.Repository, accountRepo repository.AccountRepository, transferRepo repository.TransferRepository, logger zerolog.Logger) TransferService {
+func NewTransferService(repo *repository.Repository, accountRepo repository.AccountRepository, transferRepo repository.TransferRepository, logger zerolog.Logger, opts ...TransferServiceOption) TransferService {
+	return newTransferService(repo, accountRepo, transferRepo, logger, opts...)
+}
+
+// newTransferService creates the concrete transfer service shared with the batch service
+func newTransferService(repo *repository.Repository, accountRepo repository.AccountRepository, transferRepo repository.TransferRepository, logger zerolog.Logger, opts ...TransferServiceOption) *TransferServiceImpl {
 	auditLogger := logging.NewAuditLogger(logger)
 	performanceLogger := logging.NewPerformanceLogger(logger)
-	return &TransferServiceImpl{
+	service := &TransferServiceImpl{
 		repo:              repo,
 		accountRepo:       accountRepo,
 		transferRepo:      transferRepo,
@@ -69,6 +118,13 @@ func NewTransferService(repo *repository.Repository, accountRepo repository.Acco
 		auditLogger:       auditLogger,
 		performanceLogger: performanceLogger,
 	}
+	if repo != nil {
+		service.txLogger = repository.NewTransactionLogger(repo)
+	}
+	for _, opt := range opts {
+		opt(service)
+	}
+	return service
 }
 
 // TransferMoney executes a money transfer between accounts with database transaction
@@ -101,35 +157,32 @@ func (s *TransferServiceImpl) TransferMoney(ctx context.Context, req TransferMon
 			Str("amount", req.Amount.StringFixed(2)).
 			Msg("Transfer validation failed")
 		s.auditLogger.LogTransfer(int64(req.FromAccountID), int64(req.ToAccountID), req.Amount, "failed_validation")
+		s.stageTransferFailed(ctx, req, "validation_error", err, 0)
 		return nil, fmt.Errorf("transfer validation failed: %w", err)
 	}
 
 	var result *models.Transfer
 	var txDuration time.Duration
+	var risk *transferRisk
 	
-	// Execute transfer within database transaction
+	// Execute transfer within database transaction, retrying deadlocks and
+	// serialization failures. Every attempt recomputes the closure's results.
 	txStart := time.Now()
-	err := s.repo.WithTx(ctx, func(qtx *queries.Queries) error {
-		contextLogger.Debug().Msg("Starting database transaction for transfer")
+	err := s.txLogger.WithTxLoggedWithRetry(ctx, func(qtx *queries.Queries, txCtx *repository.TransactionContext) error {
+		contextLogger.Debug().
+			Int("attempt", txCtx.DeadlockRetries+1).
+			Msg("Starting database transaction for transfer")
 
-		// 1. Get and lock both accounts for update to prevent race conditions
+		// Get and lock both accounts for update to prevent race conditions
 		lockStart := time.Now()
-		fromAccount, err := qtx.GetAccountForUpdate(ctx, req.FromAccountID)
+		fromAccount, toAccount, err := lockTransferAccounts(ctx, qtx, req.FromAccountID, req.ToAccountID)
 		if err != nil {
 			contextLogger.Error().
 				Err(err).
 				Int32("from_account_id", req.FromAccountID).
-				Msg("Failed to lock from account")
-			return fmt.Errorf("failed to get from account: %w", err)
-		}
-
-		toAccount, err := qtx.GetAccountForUpdate(ctx, req.ToAccountID)
-		if err != nil {
-			contextLogger.Error().
-				Err(err).
 				Int32("to_account_id", req.ToAccountID).
-				Msg("Failed to lock to account")
-			return fmt.Errorf("failed to get to account: %w", err)
+				Msg("Failed to lock transfer accounts")
+			return err
 		}
 		
 		lockDuration := time.Since(lockStart)
@@ -137,110 +190,49 @@ func (s *TransferServiceImpl) TransferMoney(ctx context.Context, req TransferMon
 			Int64("lock_duration_ms", lockDuration.Milliseconds()).
 			Msg("Account locks acquired")
 
-		// 2. Convert database models to business models for validation
-		fromAccountModel, err := convertDBAccountToModel(fromAccount)
-		if err != nil {
-			contextLogger.Error().
-				Err(err).
-				Int32("from_account_id", req.FromAccountID).
-				Msg("Failed to convert from account model")
-			return fmt.Errorf("failed to convert from account: %w", err)
+		booked, err := s.bookTransfer(ctx, qtx, contextLogger, transfer, req, fromAccount, toAccount)
+		if booked != nil {
+			risk = booked.risk
 		}
-
-		toAccountModel, err := convertDBAccountToModel(toAccount)
 		if err != nil {
-			contextLogger.Error().
-				Err(err).
-				Int32("to_account_id", req.ToAccountID).
-				Msg("Failed to convert to account model")
-			return fmt.Errorf("failed to convert to account: %w", err)
+			return err
 		}
+		result = booked.transfer
 
-		// 3. Validate currency matching and sufficient balance
-		if err := transfer.ValidateTransfer(fromAccountModel, toAccountModel); err != nil {
-			contextLogger.Error().
-				Err(err).
-				Int32("from_account_id", req.FromAccountID).
-				Int32("to_account_id", req.ToAccountID).
-				Str("from_currency", fromAccountModel.Currency).
-				Str("to_currency", toAccountModel.Currency).
-				Str("from_balance", fromAccountModel.Balance.StringFixed(2)).
-				Str("transfer_amount", req.Amount.StringFixed(2)).
-				Msg("Transfer business validation failed")
-			return fmt.Errorf("transfer validation failed: %w", err)
-		}
-
-		// 4. Subtract amount from source account
-		subtractStart := time.Now()
-		_, err = qtx.SubtractFromBalance(ctx, queries.SubtractFromBalanceParams{
-			ID:      req.FromAccountID,
-			Balance: utils.ConvertDecimalToPgNumeric(req.Amount),
-		})
-		if err != nil {
-			contextLogger.Error().
-				Err(err).
-				Int32("from_account_id", req.FromAccountID).
-				Str("amount", req.Amount.StringFixed(2)).
-				Msg("Failed to subtract from source account")
-			return fmt.Errorf("failed to subtract from source account: %w", err)
-		}
-		s.performanceLogger.LogDatabaseQuery("UPDATE subtract balance", time.Since(subtractStart), 1)
-
-		// 5. Add amount to destination account
-		addStart := time.Now()
-		_, err = qtx.AddToBalance(ctx, queries.AddToBalanceParams{
-			ID:      req.ToAccountID,
-			Balance: utils.ConvertDecimalToPgNumeric(req.Amount),
-		})
-		if err != nil {
-			contextLogger.Error().
-				Err(err).
-				Int32("to_account_id", req.ToAccountID).
-				Str("amount", req.Amount.StringFixed(2)).
-				Msg("Failed to add to destination account")
-			return fmt.Errorf("failed to add to destination account: %w", err)
-		}
-		s.performanceLogger.LogDatabaseQuery("UPDATE add balance", time.Since(addStart), 1)
-
-		// 6. Create transfer record
-		createStart := time.Now()
-		dbTransfer, err := qtx.CreateTransfer(ctx, queries.CreateTransferParams{
+		return s.stageTransferCompleted(ctx, qtx, events.TransferPayload{
+			TransferID:    int32(result.ID),
 			FromAccountID: req.FromAccountID,
 			ToAccountID:   req.ToAccountID,
-			Amount:        utils.ConvertDecimalToPgNumeric(req.Amount),
-			Column4:       req.Description,
-			Column5:       "completed",
+			UserID:        booked.fromAccount.UserID,
+			Amount:        req.Amount.StringFixed(2),
+			Fee:           feeString(result.Fee),
+			Currency:      booked.fromAccount.Currency,
 		})
-		if err != nil {
-			contextLogger.Error().
-				Err(err).
-				Int32("from_account_id", req.FromAccountID).
-				Int32("to_account_id", req.ToAccountID).
-				Msg("Failed to create transfer record")
-			return fmt.Errorf("failed to create transfer record: %w", err)
-		}
-		s.performanceLogger.LogDatabaseQuery("INSERT transfer", time.Since(createStart), 1)
-
-		// Convert database transfer to business model
-		result, err = convertDBTransferToModel(dbTransfer)
-		if err != nil {
-			contextLogger.Error().
-				Err(err).
-				Int32("transfer_id", dbTransfer.ID).
-				Msg("Failed to convert transfer result")
-			return fmt.Errorf("failed to convert transfer result: %w", err)
-		}
-		
-		contextLogger.Debug().
-			Int32("transfer_id", dbTransfer.ID).
-			Msg("Transfer record created successfully")
-		
-		return nil
-	})
+	}, transferMaxTxRetries)
 
 	txDuration = time.Since(txStart)
 	s.performanceLogger.LogDatabaseTransaction(txDuration, 4, err == nil) // 4 operations: 2 locks, 2 updates, 1 insert
 
+	if errors.Is(err, ErrTransferBlocked) {
+		s.handleBlockedTransfer(ctx, req, risk)
+		s.stageTransferFailed(ctx, req, "transfer_blocked", ErrTransferBlocked, 0)
+		return nil, ErrTransferBlocked
+	}
+
+	var limitErr *LimitExceededError
+	if errors.As(err, &limitErr) {
+		contextLogger.Warn().
+			Int32("from_account_id", req.FromAccountID).
+			Str("amount", req.Amount.StringFixed(2)).
+			Str("currency", limitErr.Currency).
+			Str("period", limitErr.Period).
+			Str("limit_type", limitErr.Kind).
+			Msg("Transfer rejected by transfer limits")
+		s.auditLogger.LogTransfer(int64(req.FromAccountID), int64(req.ToAccountID), req.Amount, "failed_limit_exceeded")
+		s.stageTransferFailed(ctx, req, "limit_exceeded", limitErr, 0)
+		return nil, limitErr
+	}
+
 	if err != nil {
 		contextLogger.Error().
 			Err(err).
@@ -250,6 +242,7 @@ func (s *TransferServiceImpl) TransferMoney(ctx context.Context, req TransferMon
 			Int64("tx_duration_ms", txDuration.Milliseconds()).
 			Msg("Transfer transaction failed")
 		s.auditLogger.LogTransfer(int64(req.FromAccountID), int64(req.ToAccountID), req.Amount, "failed_transaction_error")
+		s.stageTransferFailed(ctx, req, transferFailureReason(err), err, 0)
 		return nil, fmt.Errorf("transfer transaction failed: %w", err)
 	}
 
@@ -260,6 +253,7 @@ func (s *TransferServiceImpl) TransferMoney(ctx context.Context, req TransferMon
 		Int32("from_account_id", req.FromAccountID).
 		Int32("to_account_id", req.ToAccountID).
 		Str("amount", req.Amount.StringFixed(2)).
+		Str("fee", result.Fee.StringFixed(2)).
 		Str("description", req.Description).
 		Int64("duration_ms", duration.Milliseconds()).
 		Int64("tx_duration_ms", txDuration.Milliseconds()).
@@ -269,9 +263,472 @@ func (s *TransferServiceImpl) TransferMoney(ctx context.Context, req TransferMon
 	s.auditLogger.LogTransferWithDetails(int64(result.ID), int64(req.FromAccountID), int64(req.ToAccountID), 
 		req.Amount, "USD", req.Description, "success", 0) // Note: userID would need to be passed from context
 
+	// Flagged transfers complete but are queued for review and alerted on
+	if risk != nil && risk.assessment.Decision == RiskDecisionReview {
+		s.raiseRiskAlert(ctx, req, risk)
+	}
+
 	return result, nil
 }
 
+// bookedTransfer is the outcome of booking one transfer inside a transaction
+type bookedTransfer struct {
+	transfer    *models.Transfer
+	fromAccount queries.Account
+	toAccount   queries.Account
+	fee         *TransferFeeQuote
+	risk        *transferRisk
+}
+
+// bookTransfer validates and books a transfer between two accounts the caller
+// has already locked. It checks balance, fee, limits and risk, moves the money
+// and records the transfer, its fee and its risk assessment. The returned
+// accounts carry the updated balances. A blocked transfer returns
+// ErrTransferBlocked together with the booking holding the blocking assessment.
+func (s *TransferServiceImpl) bookTransfer(ctx context.Context, qtx *queries.Queries, contextLogger *logging.ContextLogger, transfer *models.Transfer, req TransferMoneyRequest, fromAccount, toAccount queries.Account) (*bookedTransfer, error) {
+	booked := &bookedTransfer{}
+
+	// 1. Convert database models to business models for validation
+	fromAccountModel, err := convertDBAccountToModel(fromAccount)
+	if err != nil {
+		contextLogger.Error().
+			Err(err).
+			Int32("from_account_id", req.FromAccountID).
+			Msg("Failed to convert from account model")
+		return nil, fmt.Errorf("failed to convert from account: %w", err)
+	}
+
+	toAccountModel, err := convertDBAccountToModel(toAccount)
+	if err != nil {
+		contextLogger.Error().
+			Err(err).
+			Int32("to_account_id", req.ToAccountID).
+			Msg("Failed to convert to account model")
+		return nil, fmt.Errorf("failed to convert to account: %w", err)
+	}
+
+	// 2. Validate currency matching and sufficient balance
+	if err := transfer.ValidateTransfer(fromAccountModel, toAccountModel); err != nil {
+		contextLogger.Error().
+			Err(err).
+			Int32("from_account_id", req.FromAccountID).
+			Int32("to_account_id", req.ToAccountID).
+			Str("from_currency", fromAccountModel.Currency).
+			Str("to_currency", toAccountModel.Currency).
+			Str("from_balance", fromAccountModel.Balance.StringFixed(2)).
+			Str("transfer_amount", req.Amount.StringFixed(2)).
+			Msg("Transfer business validation failed")
+		return nil, fmt.Errorf("transfer validation failed: %w", err)
+	}
+
+	// Price the transfer and make sure the balance also covers the fee
+	booked.fee, err = quoteTransferFee(ctx, qtx, fromAccountModel, req.ToAccountID, req.Amount)
+	if err != nil {
+		contextLogger.Error().
+			Err(err).
+			Int32("from_account_id", req.FromAccountID).
+			Msg("Failed to compute transfer fee")
+		return nil, fmt.Errorf("failed to compute transfer fee: %w", err)
+	}
+	if !booked.fee.SufficientBalance {
+		contextLogger.Error().
+			Int32("from_account_id", req.FromAccountID).
+			Str("from_balance", fromAccountModel.Balance.StringFixed(2)).
+			Str("transfer_amount", req.Amount.StringFixed(2)).
+			Str("fee", booked.fee.Fee.StringFixed(2)).
+			Msg("Balance does not cover transfer amount plus fee")
+		return nil, fmt.Errorf("transfer validation failed: %w: fee of %s brings the total to %s",
+			models.ErrInsufficientBalance, booked.fee.Fee.StringFixed(2), booked.fee.TotalDebit.StringFixed(2))
+	}
+
+	// Enforce transfer limits against usage aggregated under the source account lock
+	if s.transferLimiter.Enabled() {
+		if err := s.transferLimiter.Check(ctx, qtx, fromAccount.UserID, fromAccountModel.Currency, req.Amount, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+
+	// Score the transfer against fraud and anomaly rules while the source
+	// account is locked, so concurrent transfers see each other's history
+	if s.riskEngine.Enabled() {
+		booked.risk, err = s.assessTransferRisk(ctx, qtx, fromAccount.UserID, req)
+		if err != nil {
+			contextLogger.Error().
+				Err(err).
+				Int32("from_account_id", req.FromAccountID).
+				Msg("Failed to assess transfer risk")
+			return nil, fmt.Errorf("failed to assess transfer risk: %w", err)
+		}
+
+		if booked.risk.assessment.Decision == RiskDecisionBlock {
+			return booked, ErrTransferBlocked
+		}
+	}
+
+	// 3. Subtract amount plus fee from source account
+	subtractStart := time.Now()
+	booked.fromAccount, err = qtx.SubtractFromBalance(ctx, queries.SubtractFromBalanceParams{
+		ID:      req.FromAccountID,
+		Balance: utils.ConvertDecimalToPgNumeric(booked.fee.TotalDebit),
+	})
+	if err != nil {
+		contextLogger.Error().
+			Err(err).
+			Int32("from_account_id", req.FromAccountID).
+			Str("amount", req.Amount.StringFixed(2)).
+			Msg("Failed to subtract from source account")
+		return nil, fmt.Errorf("failed to subtract from source account: %w", err)
+	}
+	s.performanceLogger.LogDatabaseQuery("UPDATE subtract balance", time.Since(subtractStart), 1)
+
+	// 4. Add amount to destination account
+	addStart := time.Now()
+	booked.toAccount, err = qtx.AddToBalance(ctx, queries.AddToBalanceParams{
+		ID:      req.ToAccountID,
+		Balance: utils.ConvertDecimalToPgNumeric(req.Amount),
+	})
+	if err != nil {
+		contextLogger.Error().
+			Err(err).
+			Int32("to_account_id", req.ToAccountID).
+			Str("amount", req.Amount.StringFixed(2)).
+			Msg("Failed to add to destination account")
+		return nil, fmt.Errorf("failed to add to destination account: %w", err)
+	}
+	s.performanceLogger.LogDatabaseQuery("UPDATE add balance", time.Since(addStart), 1)
+
+	// 5. Create transfer record
+	createStart := time.Now()
+	dbTransfer, err := qtx.CreateTransfer(ctx, queries.CreateTransferParams{
+		FromAccountID: req.FromAccountID,
+		ToAccountID:   req.ToAccountID,
+		Amount:        utils.ConvertDecimalToPgNumeric(req.Amount),
+		Column4:       req.Description,
+		Column5:       "completed",
+	})
+	if err != nil {
+		contextLogger.Error().
+			Err(err).
+			Int32("from_account_id", req.FromAccountID).
+			Int32("to_account_id", req.ToAccountID).
+			Msg("Failed to create transfer record")
+		return nil, fmt.Errorf("failed to create transfer record: %w", err)
+	}
+	s.performanceLogger.LogDatabaseQuery("INSERT transfer", time.Since(createStart), 1)
+
+	// Convert database transfer to business model
+	booked.transfer, err = convertDBTransferToModel(dbTransfer)
+	if err != nil {
+		contextLogger.Error().
+			Err(err).
+			Int32("transfer_id", dbTransfer.ID).
+			Msg("Failed to convert transfer result")
+		return nil, fmt.Errorf("failed to convert transfer result: %w", err)
+	}
+	
+	contextLogger.Debug().
+		Int32("transfer_id", dbTransfer.ID).
+		Msg("Transfer record created successfully")
+
+	// 6. Collect the fee into the house account
+	if err := collectTransferFee(ctx, qtx, dbTransfer.ID, booked.fee); err != nil {
+		contextLogger.Error().
+			Err(err).
+			Int32("transfer_id", dbTransfer.ID).
+			Str("fee", booked.fee.Fee.StringFixed(2)).
+			Msg("Failed to collect transfer fee")
+		return nil, fmt.Errorf("failed to collect transfer fee: %w", err)
+	}
+	booked.transfer.Fee = booked.fee.Fee
+
+	// 7. Record the risk assessment against the transfer
+	if booked.risk != nil {
+		if err := s.recordRiskAssessment(ctx, qtx, &dbTransfer.ID, booked.risk); err != nil {
+			contextLogger.Error().
+				Err(err).
+				Int32("transfer_id", dbTransfer.ID).
+				Msg("Failed to record risk assessment")
+			return nil, fmt.Errorf("failed to record risk assessment: %w", err)
+		}
+
+		score := booked.risk.assessment.Score
+		booked.transfer.RiskScore = &score
+		booked.transfer.RiskDecision = booked.risk.assessment.Decision
+	}
+
+	return booked, nil
+}
+
+// lockTransferAccounts locks both accounts of a transfer in ascending ID order.
+// A fixed order means concurrent A->B and B->A transfers queue on the same
+// first lock instead of each holding one account and waiting for the other.
+func lockTransferAccounts(ctx context.Context, qtx *queries.Queries, fromAccountID, toAccountID int32) (queries.Account, queries.Account, error) {
+	firstID, secondID := fromAccountID, toAccountID
+	if secondID < firstID {
+		firstID, secondID = secondID, firstID
+	}
+
+	first, err := qtx.GetAccountForUpdate(ctx, firstID)
+	if err != nil {
+		return queries.Account{}, queries.Account{}, fmt.Errorf("failed to get %s account: %w", transferAccountRole(firstID, fromAccountID), err)
+	}
+
+	second, err := qtx.GetAccountForUpdate(ctx, secondID)
+	if err != nil {
+		return queries.Account{}, queries.Account{}, fmt.Errorf("failed to get %s account: %w", transferAccountRole(secondID, fromAccountID), err)
+	}
+
+	if firstID == fromAccountID {
+		return first, second, nil
+	}
+	return second, first, nil
+}
+
+// transferAccountRole names an account as the source or destination of a transfer
+func transferAccountRole(accountID, fromAccountID int32) string {
+	if accountID == fromAccountID {
+		return "from"
+	}
+	return "to"
+}
+
+// GetTransferLimitUsage returns the user's limit usage for each currency they hold an account in
+func (s *TransferServiceImpl) GetTransferLimitUsage(ctx context.Context, userID int32) ([]TransferLimitUsage, error) {
+	contextLogger := logging.NewContextLogger(s.logger, ctx).WithOperation("get_transfer_limit_usage")
+
+	usages := []TransferLimitUsage{}
+	if !s.transferLimiter.Enabled() {
+		return usages, nil
+	}
+
+	accounts, err := s.accountRepo.GetUserAccounts(ctx, userID)
+	if err != nil {
+		contextLogger.Error().
+			Err(err).
+			Int32("user_id", userID).
+			Msg("Failed to get user accounts for limit usage")
+		return nil, fmt.Errorf("failed to get user accounts: %w", err)
+	}
+
+	now := time.Now()
+	for _, account := range accounts {
+		usage, err := s.transferLimiter.Usage(ctx, s.repo.Queries, userID, account.Currency, now)
+		if err != nil {
+			contextLogger.Error().
+				Err(err).
+				Int32("user_id", userID).
+				Str("currency", account.Currency).
+				Msg("Failed to get transfer limit usage")
+			return nil, fmt.Errorf("failed to get transfer limit usage: %w", err)
+		}
+		usages = append(usages, *usage)
+	}
+
+	return usages, nil
+}
+
+// PreviewTransferFee quotes the fee for a transfer without executing it
+func (s *TransferServiceImpl) PreviewTransferFee(ctx context.Context, req TransferMoneyRequest) (*TransferFeeQuote, error) {
+	contextLogger := logging.NewContextLogger(s.logger, ctx).WithOperation("preview_transfer_fee")
+
+	transfer := &models.Transfer{
+		FromAccountID: int(req.FromAccountID),
+		ToAccountID:   int(req.ToAccountID),
+		Amount:        req.Amount,
+		Status:        "completed",
+	}
+	if err := transfer.ValidateFields(); err != nil {
+		return nil, fmt.Errorf("transfer validation failed: %w", err)
+	}
+
+	fromAccount, err := s.accountRepo.GetAccount(ctx, req.FromAccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get from account: %w", err)
+	}
+	toAccount, err := s.accountRepo.GetAccount(ctx, req.ToAccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get to account: %w", err)
+	}
+
+	fromAccountModel, err := convertDBAccountToModel(fromAccount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert from account: %w", err)
+	}
+	toAccountModel, err := convertDBAccountToModel(toAccount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert to account: %w", err)
+	}
+
+	if err := transfer.ValidateCurrencyMatch(fromAccountModel, toAccountModel); err != nil {
+		return nil, fmt.Errorf("transfer validation failed: %w", err)
+	}
+
+	quote, err := quoteTransferFee(ctx, s.repo.Queries, fromAccountModel, req.ToAccountID, req.Amount)
+	if err != nil {
+		contextLogger.Error().
+			Err(err).
+			Int32("from_account_id", req.FromAccountID).
+			Msg("Failed to compute transfer fee")
+		return nil, fmt.Errorf("failed to compute transfer fee: %w", err)
+	}
+
+	contextLogger.Debug().
+		Int32("from_account_id", req.FromAccountID).
+		Str("amount", req.Amount.StringFixed(2)).
+		Str("fee", quote.Fee.StringFixed(2)).
+		Msg("Transfer fee quoted")
+
+	return quote, nil
+}
+
+// transferRisk carries a risk assessment with the inputs used to compute it
+type transferRisk struct {
+	userID        int32
+	fromAccountID int32
+	toAccountID   int32
+	amount        decimal.Decimal
+	stats         RiskStats
+	assessment    *RiskAssessment
+}
+
+// rules returns the triggered rule names as a comma-separated list
+func (r *transferRisk) rules() string {
+	names := make([]string, 0, len(r.assessment.Reasons))
+	for _, reason := range r.assessment.Reasons {
+		names = append(names, reason.Rule)
+	}
+	return strings.Join(names, ",")
+}
+
+// assessTransferRisk loads the sender's recent activity and scores the transfer
+func (s *TransferServiceImpl) assessTransferRisk(ctx context.Context, qtx *queries.Queries, userID int32, req TransferMoneyRequest) (*transferRisk, error) {
+	statsStart := time.Now()
+	velocitySince, historySince, fanOutSince := s.riskEngine.Windows(time.Now())
+
+	row, err := qtx.GetTransferRiskStats(ctx, queries.GetTransferRiskStatsParams{
+		FromAccountID: req.FromAccountID,
+		VelocitySince: utils.ConvertTimeToPgTimestamp(velocitySince),
+		UserID:        userID,
+		HistorySince:  utils.ConvertTimeToPgTimestamp(historySince),
+		ToAccountID:   req.ToAccountID,
+		FanOutSince:   utils.ConvertTimeToPgTimestamp(fanOutSince),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load risk stats: %w", err)
+	}
+	s.performanceLogger.LogDatabaseQuery("SELECT transfer risk stats", time.Since(statsStart), 1)
+
+	averageAmount, err := utils.ConvertPgNumericToDecimal(row.AverageAmount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert average amount: %w", err)
+	}
+
+	stats := RiskStats{
+		AccountRecentCount:  int(row.AccountRecentCount),
+		UserRecentCount:     int(row.UserRecentCount),
+		AverageAmount:       averageAmount,
+		HistoryCount:        int(row.HistoryCount),
+		PriorRecipientCount: int(row.PriorRecipientCount),
+		OtherRecipientCount: int(row.OtherRecipientCount),
+	}
+
+	return &transferRisk{
+		userID:        userID,
+		fromAccountID: req.FromAccountID,
+		toAccountID:   req.ToAccountID,
+		amount:        req.Amount,
+		stats:         stats,
+		assessment:    s.riskEngine.Evaluate(req.Amount, stats),
+	}, nil
+}
+
+// recordRiskAssessment stores an assessment; transferID is nil for blocked transfers
+func (s *TransferServiceImpl) recordRiskAssessment(ctx context.Context, q *queries.Queries, transferID *int32, risk *transferRisk) error {
+	reasons, err := json.Marshal(risk.assessment.Reasons)
+	if err != nil {
+		return fmt.Errorf("failed to marshal risk reasons: %w", err)
+	}
+
+	// Flagged and blocked transfers both go to the analyst review queue
+	reviewStatus := "none"
+	if risk.assessment.Decision == RiskDecisionReview || risk.assessment.Decision == RiskDecisionBlock {
+		reviewStatus = "pending"
+	}
+
+	params := queries.CreateTransferRiskAssessmentParams{
+		UserID:        risk.userID,
+		Amount:        utils.ConvertDecimalToPgNumeric(risk.amount),
+		RiskScore:     int32(risk.assessment.Score),
+		Decision:      risk.assessment.Decision,
+		Reasons:       reasons,
+		ReviewStatus:  reviewStatus,
+		FromAccountID: risk.fromAccountID,
+		ToAccountID:   risk.toAccountID,
+	}
+	if transferID != nil {
+		params.TransferID = pgtype.Int4{Int32: *transferID, Valid: true}
+	}
+
+	_, err = q.CreateTransferRiskAssessment(ctx, params)
+	return err
+}
+
+// handleBlockedTransfer records and reports a transfer rejected by the risk engine.
+// The assessment is written outside the rolled-back transfer transaction.
+func (s *TransferServiceImpl) handleBlockedTransfer(ctx context.Context, req TransferMoneyRequest, risk *transferRisk) {
+	contextLogger := logging.NewContextLogger(s.logger, ctx).WithOperation("transfer_money")
+
+	contextLogger.Warn().
+		Int32("from_account_id", req.FromAccountID).
+		Int32("to_account_id", req.ToAccountID).
+		Str("amount", req.Amount.StringFixed(2)).
+		Int("risk_score", risk.assessment.Score).
+		Str("rules", risk.rules()).
+		Msg("Transfer blocked by risk checks")
+
+	s.auditLogger.LogTransfer(int64(req.FromAccountID), int64(req.ToAccountID), req.Amount, "blocked_risk")
+
+	if err := s.recordRiskAssessment(ctx, s.repo.Queries, nil, risk); err != nil {
+		contextLogger.Error().
+			Err(err).
+			Int32("from_account_id", req.FromAccountID).
+			Msg("Failed to record blocked transfer risk assessment")
+	}
+
+	s.raiseRiskAlert(ctx, req, risk)
+}
+
+// raiseRiskAlert reports a flagged or blocked transfer to audit logs and alerting
+func (s *TransferServiceImpl) raiseRiskAlert(ctx context.Context, req TransferMoneyRequest, risk *transferRisk) {
+	severity := "medium"
+	if risk.assessment.Decision == RiskDecisionBlock {
+		severity = "high"
+	}
+	s.auditLogger.LogSuspiciousActivity(int64(risk.userID), "transfer_"+risk.assessment.Decision,
+		fmt.Sprintf("transfer of %s from account %d to account %d scored %d (%s)",
+			req.Amount.StringFixed(2), req.FromAccountID, req.ToAccountID, risk.assessment.Score, risk.rules()),
+		severity)
+
+	if s.anomalyAlerter == nil {
+		return
+	}
+
+	amount, _ := req.Amount.Float64()
+	threshold, _ := s.riskEngine.AmountThreshold(risk.stats).Float64()
+	anomalyType := fmt.Sprintf("%s (%s)", risk.assessment.Decision, risk.rules())
+
+	if err := s.anomalyAlerter.TransactionAnomalyAlert(ctx,
+		strconv.Itoa(int(risk.userID)),
+		strconv.Itoa(int(req.FromAccountID)),
+		amount, threshold, anomalyType,
+	); err != nil {
+		logging.NewContextLogger(s.logger, ctx).WithOperation("transfer_money").Warn().
+			Err(err).
+			Int32("from_account_id", req.FromAccountID).
+			Msg("Failed to raise transaction anomaly alert")
+	}
+}
+
 // GetTransferHistory retrieves transfer history for an account with pagination
 func (s *TransferServiceImpl) GetTransferHistory(ctx context.Context, req GetTransferHistoryRequest) (*TransferHistoryResponse, error) {
 	start := time.Now()
@@ -325,6 +782,14 @@ func (s *TransferServiceImpl) GetTransferHistory(ctx context.Context, req GetTra
 		transfers[i] = transfer
 	}
 
+	// Report fees collected on these transfers
+	if err := attachTransferFees(ctx, s.repo.ReadQueries(), transfers); err != nil {
+		contextLogger.Error().
+			Err(err).
+			Msg("Failed to attach transfer fees")
+		return nil, err
+	}
+
 	// Get total count for pagination
 	countStart := time.Now()
 	total, err := s.transferRepo.CountTransfersByAccount(ctx, req.AccountID)
@@ -387,6 +852,16 @@ func (s *TransferServiceImpl) GetTransfer(ctx context.Context, transferID int32)
 		return nil, fmt.Errorf("failed to convert transfer: %w", err)
 	}
 
+	transfers := []models.Transfer{transfer}
+	if err := attachTransferFees(ctx, s.repo.Queries, transfers); err != nil {
+		contextLogger.Error().
+			Err(err).
+			Int32("transfer_id", transferID).
+			Msg("Failed to attach transfer fee")
+		return nil, err
+	}
+	transfer = transfers[0]
+
 	duration := time.Since(start)
 	contextLogger.Debug().
 		Int32("transfer_id", transferID).
@@ -522,6 +997,14 @@ func (s *TransferServiceImpl) GetTransfersByStatus(ctx context.Context, status s
 		transfers[i] = transfer
 	}
 
+	// Report fees collected on these transfers
+	if err := attachTransferFees(ctx, s.repo.ReadQueries(), transfers); err != nil {
+		contextLogger.Error().
+			Err(err).
+			Msg("Failed to attach transfer fees")
+		return nil, err
+	}
+
 	// For status-based queries, we'll return the count as the length of results
 	// In a real implementation, you might want a separate count query
 	total := int64(len(transfers))
@@ -599,6 +1082,14 @@ func (s *TransferServiceImpl) GetTransfersByUser(ctx context.Context, userID int
 		transfers[i] = transfer
 	}
 
+	// Report fees collected on these transfers
+	if err := attachTransferFees(ctx, s.repo.ReadQueries(), transfers); err != nil {
+		contextLogger.Error().
+			Err(err).
+			Msg("Failed to attach transfer fees")
+		return nil, err
+	}
+
 	// For user-based queries, we'll return the count as the length of results
 	total := int64(len(transfers))
 
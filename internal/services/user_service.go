@@ -4,21 +4,30 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/phantom-sage/bankgo/internal/database/queries"
+	"github.com/phantom-sage/bankgo/internal/events"
 	"github.com/phantom-sage/bankgo/internal/logging"
 	"github.com/phantom-sage/bankgo/internal/models"
+	"github.com/phantom-sage/bankgo/internal/outbox"
+	"github.com/phantom-sage/bankgo/internal/queue"
 	"github.com/phantom-sage/bankgo/internal/repository"
 	"github.com/rs/zerolog"
 )
 
+// ErrInvalidCredentials is returned when the email or password is wrong. It
+// does not say which, so sign-ins cannot be used to find registered emails.
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
 // UserService defines the interface for user business logic operations
 type UserService interface {
 	CreateUser(ctx context.Context, email, password, firstName, lastName string) (*models.User, error)
 	GetUser(ctx context.Context, userID int) (*models.User, error)
 	AuthenticateUser(ctx context.Context, email, password string) (*models.User, error)
 	MarkWelcomeEmailSent(ctx context.Context, userID int) error
+	ScheduleWelcomeEmail(ctx context.Context, user *models.User) error
 }
 
 // UserServiceImpl implements UserService
@@ -26,15 +35,24 @@ type UserServiceImpl struct {
 	userRepo    repository.UserRepository
 	logger      zerolog.Logger
 	auditLogger *logging.AuditLogger
+	outbox      *repository.Repository
 }
 
 // NewUserService creates a new user service
 func NewUserService(userRepo repository.UserRepository, logger zerolog.Logger) UserService {
+	return NewUserServiceWithOutbox(userRepo, nil, logger)
+}
+
+// NewUserServiceWithOutbox creates a user service that writes user.registered
+// events and welcome email tasks to the outbox in the transaction of the
+// change that causes them. A nil outbox disables both.
+func NewUserServiceWithOutbox(userRepo repository.UserRepository, outbox *repository.Repository, logger zerolog.Logger) UserService {
 	auditLogger := logging.NewAuditLogger(logger)
 	return &UserServiceImpl{
 		userRepo:    userRepo,
 		logger:      logger.With().Str("component", "user_service").Logger(),
 		auditLogger: auditLogger,
+		outbox:      outbox,
 	}
 }
 
@@ -87,7 +105,7 @@ func (s *UserServiceImpl) CreateUser(ctx context.Context, email, password, first
 	}
 
 	// Create user in database
-	dbUser, err := s.userRepo.CreateUser(ctx, queries.CreateUserParams{
+	dbUser, err := s.createUser(ctx, queries.CreateUserParams{
 		Email:        email,
 		PasswordHash: user.PasswordHash,
 		FirstName:    firstName,
@@ -188,7 +206,7 @@ func (s *UserServiceImpl) AuthenticateUser(ctx context.Context, email, password
 			Str("user_email", email).
 			Msg("User not found during authentication")
 		s.auditLogger.LogFailedAuthentication(email, "user_not_found", "")
-		return nil, errors.New("invalid credentials")
+		return nil, ErrInvalidCredentials
 	}
 
 	// Convert to model for password checking
@@ -201,7 +219,7 @@ func (s *UserServiceImpl) AuthenticateUser(ctx context.Context, email, password
 			Int64("user_id", int64(userModel.ID)).
 			Msg("Invalid password provided during authentication")
 		s.auditLogger.LogAuthentication(int64(userModel.ID), email, "login", "failed_invalid_password")
-		return nil, errors.New("invalid credentials")
+		return nil, ErrInvalidCredentials
 	}
 
 	// Log successful authentication
@@ -254,6 +272,76 @@ func (s *UserServiceImpl) MarkWelcomeEmailSent(ctx context.Context, userID int)
 	return nil
 }
 
+// ScheduleWelcomeEmail queues the welcome email for a user who has not been
+// sent one. The user is marked and the email task written to the outbox in one
+// transaction, so concurrent logins schedule it once and a failed commit
+// schedules nothing. Without an outbox it does nothing.
+func (s *UserServiceImpl) ScheduleWelcomeEmail(ctx context.Context, user *models.User) error {
+	if s.outbox == nil || user.WelcomeEmailSent {
+		return nil
+	}
+
+	contextLogger := logging.NewContextLogger(s.logger, ctx).
+		WithOperation("schedule_welcome_email").
+		WithUserID(int64(user.ID))
+
+	scheduled := false
+	err := s.outbox.WithTx(ctx, func(qtx *queries.Queries) error {
+		claimed, err := qtx.ClaimWelcomeEmail(ctx, int32(user.ID))
+		if err != nil {
+			return fmt.Errorf("failed to claim welcome email: %w", err)
+		}
+		if claimed == 0 {
+			return nil
+		}
+
+		message, err := outbox.Task(outbox.AggregateUser, strconv.Itoa(user.ID), queue.TypeWelcomeEmail, queue.WelcomeEmailPayload{
+			UserID:    user.ID,
+			Email:     user.Email,
+			FirstName: user.FirstName,
+			LastName:  user.LastName,
+		})
+		if err != nil {
+			return err
+		}
+		scheduled = true
+		return outbox.Add(ctx, qtx, message)
+	})
+	if err != nil {
+		contextLogger.Error().
+			Err(err).
+			Int("user_id", user.ID).
+			Msg("Failed to schedule welcome email")
+		return fmt.Errorf("failed to schedule welcome email: %w", err)
+	}
+
+	if scheduled {
+		contextLogger.Info().
+			Int("user_id", user.ID).
+			Msg("Welcome email scheduled")
+	}
+	return nil
+}
+
+// createUser inserts a user, staging user.registered in the same transaction
+// when the outbox is enabled
+func (s *UserServiceImpl) createUser(ctx context.Context, params queries.CreateUserParams) (queries.User, error) {
+	if s.outbox == nil {
+		return s.userRepo.CreateUser(ctx, params)
+	}
+
+	var dbUser queries.User
+	err := s.outbox.WithTx(ctx, func(qtx *queries.Queries) error {
+		var err error
+		dbUser, err = qtx.CreateUser(ctx, params)
+		if err != nil {
+			return err
+		}
+		return stageEvent(ctx, qtx, outbox.AggregateUser, dbUser.ID, events.TypeUserRegistered, events.UserPayload{UserID: dbUser.ID})
+	})
+	return dbUser, err
+}
+
 // dbUserToModel converts a database user to a model user
 func (s *UserServiceImpl) dbUserToModel(dbUser queries.User) *models.User {
 	return &models.User{
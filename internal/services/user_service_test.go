@@ -8,6 +8,7 @@ import (
 
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/phantom-sage/bankgo/internal/database/queries"
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"golang.org/x/crypto/bcrypt"
@@ -56,7 +57,7 @@ func (m *MockUserRepository) ListUsers(ctx context.Context, arg queries.ListUser
 func TestUserService_CreateUser(t *testing.T) {
 	ctx := context.Background()
 	mockRepo := new(MockUserRepository)
-	service := NewUserService(mockRepo)
+	service := NewUserService(mockRepo, zerolog.Nop())
 
 	t.Run("successful user creation", func(t *testing.T) {
 		email := "test@example.com"
@@ -196,7 +197,7 @@ func TestUserService_CreateUser(t *testing.T) {
 func TestUserService_GetUser(t *testing.T) {
 	ctx := context.Background()
 	mockRepo := new(MockUserRepository)
-	service := NewUserService(mockRepo)
+	service := NewUserService(mockRepo, zerolog.Nop())
 
 	t.Run("successful user retrieval", func(t *testing.T) {
 		userID := 1
@@ -262,7 +263,7 @@ func TestUserService_GetUser(t *testing.T) {
 func TestUserService_AuthenticateUser(t *testing.T) {
 	ctx := context.Background()
 	mockRepo := new(MockUserRepository)
-	service := NewUserService(mockRepo)
+	service := NewUserService(mockRepo, zerolog.Nop())
 
 	t.Run("successful authentication", func(t *testing.T) {
 		email := "test@example.com"
@@ -348,7 +349,7 @@ func TestUserService_AuthenticateUser(t *testing.T) {
 func TestUserService_MarkWelcomeEmailSent(t *testing.T) {
 	ctx := context.Background()
 	mockRepo := new(MockUserRepository)
-	service := NewUserService(mockRepo)
+	service := NewUserService(mockRepo, zerolog.Nop())
 
 	t.Run("successful welcome email marking", func(t *testing.T) {
 		userID := 1
@@ -0,0 +1,253 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/phantom-sage/bankgo/internal/database/queries"
+	"github.com/phantom-sage/bankgo/internal/events"
+	"github.com/phantom-sage/bankgo/internal/logging"
+	"github.com/phantom-sage/bankgo/internal/queue"
+	"github.com/phantom-sage/bankgo/internal/webhooks"
+)
+
+// webhookTransferData is the data of transfer.sent and transfer.received webhooks
+type webhookTransferData struct {
+	TransferID    int32  `json:"transfer_id"`
+	FromAccountID int32  `json:"from_account_id"`
+	ToAccountID   int32  `json:"to_account_id"`
+	Amount        string `json:"amount"`
+	Fee           string `json:"fee,omitempty"`
+	Currency      string `json:"currency,omitempty"`
+}
+
+// webhookAccountData is the data of account.created webhooks
+type webhookAccountData struct {
+	AccountID int32  `json:"account_id"`
+	Currency  string `json:"currency"`
+}
+
+// webhookEvent is a customer-facing event for one user's endpoints
+type webhookEvent struct {
+	userID  int32
+	payload webhooks.Payload
+}
+
+// HandleEvent turns a domain event into the customer events it implies and
+// logs a delivery for every endpoint subscribed to them. Deliveries are keyed
+// by endpoint and event ID, so handling the same event twice is harmless.
+func (s *WebhookServiceImpl) HandleEvent(ctx context.Context, event events.Event) error {
+	webhookEvents, err := s.webhookEvents(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	for _, webhookEvent := range webhookEvents {
+		if err := s.dispatch(ctx, webhookEvent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// webhookEvents maps a domain event to customer events. Events customers
+// cannot subscribe to map to none.
+func (s *WebhookServiceImpl) webhookEvents(ctx context.Context, event events.Event) ([]webhookEvent, error) {
+	switch event.Type {
+	case events.TypeTransferCompleted:
+		var payload events.TransferPayload
+		if err := event.Decode(&payload); err != nil {
+			return nil, err
+		}
+		objectID := fmt.Sprint(payload.TransferID)
+		if payload.TransferID == 0 {
+			objectID = event.ID
+		}
+		data := webhookTransferData{
+			TransferID:    payload.TransferID,
+			FromAccountID: payload.FromAccountID,
+			ToAccountID:   payload.ToAccountID,
+			Amount:        payload.Amount,
+			Currency:      payload.Currency,
+		}
+
+		var result []webhookEvent
+		if payload.UserID != 0 {
+			sent := data
+			sent.Fee = payload.Fee
+			result = append(result, newWebhookEvent(payload.UserID, webhooks.EventTransferSent, objectID, event, sent))
+		}
+
+		// The fee is the sender's business, so the recipient does not see it
+		toAccount, err := s.repo.GetAccount(ctx, payload.ToAccountID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return result, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get recipient account: %w", err)
+		}
+		return append(result, newWebhookEvent(toAccount.UserID, webhooks.EventTransferReceived, objectID, event, data)), nil
+
+	case events.TypeAccountCreated:
+		var payload events.AccountPayload
+		if err := event.Decode(&payload); err != nil {
+			return nil, err
+		}
+		return []webhookEvent{newWebhookEvent(payload.UserID, webhooks.EventAccountCreated, fmt.Sprint(payload.AccountID), event, webhookAccountData{
+			AccountID: payload.AccountID,
+			Currency:  payload.Currency,
+		})}, nil
+	}
+	return nil, nil
+}
+
+func newWebhookEvent(userID int32, eventType, objectID string, event events.Event, data interface{}) webhookEvent {
+	return webhookEvent{
+		userID: userID,
+		payload: webhooks.Payload{
+			ID:        eventType + ":" + objectID,
+			Type:      eventType,
+			CreatedAt: event.OccurredAt,
+			Data:      data,
+		},
+	}
+}
+
+// dispatch logs a delivery of the event to each of the user's subscribed
+// endpoints and enqueues it through the outbox
+func (s *WebhookServiceImpl) dispatch(ctx context.Context, event webhookEvent) error {
+	endpoints, err := s.repo.ListWebhookEndpointsForEvent(ctx, queries.ListWebhookEndpointsForEventParams{
+		UserID:    event.userID,
+		EventType: event.payload.Type,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list webhook endpoints: %w", err)
+	}
+	if len(endpoints) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(event.payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s webhook: %w", event.payload.Type, err)
+	}
+
+	for _, endpoint := range endpoints {
+		err := s.repo.WithTx(ctx, func(qtx *queries.Queries) error {
+			delivery, err := qtx.CreateWebhookDelivery(ctx, queries.CreateWebhookDeliveryParams{
+				EndpointID: endpoint.ID,
+				EventID:    event.payload.ID,
+				EventType:  event.payload.Type,
+				Payload:    body,
+			})
+			if errors.Is(err, pgx.ErrNoRows) {
+				// Already dispatched
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			return enqueueWebhookDelivery(ctx, qtx, delivery)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to dispatch webhook to endpoint %d: %w", endpoint.ID, err)
+		}
+	}
+	return nil
+}
+
+// ProcessWebhookDelivery sends a pending delivery to its endpoint and logs the
+// response. It returns an error while the delivery should be retried; once
+// the final attempt fails or the endpoint is disabled, the delivery is marked
+// failed.
+func (s *WebhookServiceImpl) ProcessWebhookDelivery(ctx context.Context, payload queue.WebhookDeliveryPayload) error {
+	contextLogger := logging.NewContextLogger(s.logger, ctx).WithOperation("process_webhook_delivery")
+
+	delivery, err := s.repo.GetWebhookDelivery(ctx, payload.DeliveryID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// The endpoint was deleted along with its deliveries
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+	if delivery.Status != WebhookDeliveryPending {
+		return nil
+	}
+
+	endpoint, err := s.repo.GetWebhookEndpoint(ctx, delivery.EndpointID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get webhook endpoint: %w", err)
+	}
+	if !endpoint.Enabled {
+		return s.repo.FailWebhookDelivery(ctx, queries.FailWebhookDeliveryParams{
+			ID:        delivery.ID,
+			LastError: pgtype.Text{String: "endpoint is disabled", Valid: true},
+		})
+	}
+
+	result := s.sender.Send(ctx, webhooks.Request{
+		URL:        endpoint.Url,
+		Secret:     endpoint.Secret,
+		DeliveryID: delivery.ID,
+		EventID:    delivery.EventID,
+		EventType:  delivery.EventType,
+		Body:       delivery.Payload,
+	})
+
+	attempt := queries.RecordWebhookDeliveryAttemptParams{
+		ID:           delivery.ID,
+		Status:       WebhookDeliverySucceeded,
+		ResponseCode: pgtype.Int4{Int32: int32(result.StatusCode), Valid: result.StatusCode != 0},
+		ResponseBody: pgtype.Text{String: result.Body, Valid: result.OK()},
+		DurationMs:   pgtype.Int4{Int32: int32(result.Duration.Milliseconds()), Valid: true},
+	}
+
+	if result.OK() {
+		if err := s.repo.RecordWebhookDeliveryAttempt(ctx, attempt); err != nil {
+			return fmt.Errorf("failed to record webhook delivery: %w", err)
+		}
+		if err := s.repo.RecordWebhookEndpointSuccess(ctx, endpoint.ID); err != nil {
+			contextLogger.Warn().Err(err).Int32("endpoint_id", endpoint.ID).Msg("Failed to reset webhook endpoint failures")
+		}
+		return nil
+	}
+
+	endpoint, err = s.repo.RecordWebhookEndpointFailure(ctx, queries.RecordWebhookEndpointFailureParams{
+		DisableAfter:   int32(s.cfg.DisableAfterFailures),
+		DisabledReason: fmt.Sprintf("disabled after %d consecutive failed deliveries", s.cfg.DisableAfterFailures),
+		ID:             endpoint.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record webhook endpoint failure: %w", err)
+	}
+	if !endpoint.Enabled {
+		contextLogger.Warn().
+			Int32("endpoint_id", endpoint.ID).
+			Int32("user_id", endpoint.UserID).
+			Int32("consecutive_failures", endpoint.ConsecutiveFailures).
+			Msg("Webhook endpoint disabled after repeated failures")
+	}
+
+	final := !endpoint.Enabled || queue.FinalAttempt(ctx)
+	attempt.Status = WebhookDeliveryPending
+	if final {
+		attempt.Status = WebhookDeliveryFailed
+	}
+	attempt.LastError = pgtype.Text{String: result.Error(), Valid: true}
+	if err := s.repo.RecordWebhookDeliveryAttempt(ctx, attempt); err != nil {
+		return fmt.Errorf("failed to record webhook delivery: %w", err)
+	}
+
+	if final {
+		return nil
+	}
+	return fmt.Errorf("webhook delivery %d failed: %s", delivery.ID, result.Error())
+}
@@ -0,0 +1,470 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/phantom-sage/bankgo/internal/config"
+	"github.com/phantom-sage/bankgo/internal/database/queries"
+	"github.com/phantom-sage/bankgo/internal/events"
+	"github.com/phantom-sage/bankgo/internal/logging"
+	"github.com/phantom-sage/bankgo/internal/outbox"
+	"github.com/phantom-sage/bankgo/internal/queue"
+	"github.com/phantom-sage/bankgo/internal/repository"
+	"github.com/phantom-sage/bankgo/internal/utils"
+	"github.com/phantom-sage/bankgo/internal/webhooks"
+	"github.com/rs/zerolog"
+)
+
+// Webhook delivery statuses
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// WebhookConsumerGroup is the event bus consumer group that dispatches webhooks
+const WebhookConsumerGroup = "webhooks"
+
+// Webhook errors
+var (
+	// ErrWebhookEndpointNotFound is returned for unknown endpoints and endpoints owned by another user
+	ErrWebhookEndpointNotFound = errors.New("webhook endpoint not found")
+	// ErrWebhookDeliveryNotFound is returned for unknown deliveries and deliveries of another endpoint
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+	// ErrWebhookEndpointDisabled is returned when redelivering to a disabled endpoint
+	ErrWebhookEndpointDisabled = errors.New("webhook endpoint is disabled")
+)
+
+// WebhookValidationError is returned when an endpoint request is rejected
+type WebhookValidationError struct {
+	Message string
+}
+
+func (e *WebhookValidationError) Error() string {
+	return fmt.Sprintf("invalid webhook endpoint: %s", e.Message)
+}
+
+// WebhookService manages users' webhook endpoints and delivers events to them
+type WebhookService interface {
+	CreateEndpoint(ctx context.Context, userID int32, req CreateWebhookEndpointRequest) (*WebhookEndpoint, error)
+	ListEndpoints(ctx context.Context, userID int32) ([]WebhookEndpoint, error)
+	GetEndpoint(ctx context.Context, userID, endpointID int32) (*WebhookEndpoint, error)
+	UpdateEndpoint(ctx context.Context, userID, endpointID int32, req UpdateWebhookEndpointRequest) (*WebhookEndpoint, error)
+	DeleteEndpoint(ctx context.Context, userID, endpointID int32) error
+	ListDeliveries(ctx context.Context, userID, endpointID, limit, offset int32) (*WebhookDeliveryPage, error)
+	Redeliver(ctx context.Context, userID, endpointID int32, deliveryID int64) (*WebhookDelivery, error)
+
+	// HandleEvent dispatches a domain event to the endpoints subscribed to it
+	HandleEvent(ctx context.Context, event events.Event) error
+	// ProcessWebhookDelivery makes one attempt at a delivery
+	ProcessWebhookDelivery(ctx context.Context, payload queue.WebhookDeliveryPayload) error
+}
+
+// CreateWebhookEndpointRequest registers an endpoint for some event types
+type CreateWebhookEndpointRequest struct {
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+}
+
+// UpdateWebhookEndpointRequest changes an endpoint. Nil fields are left as
+// they are; enabling an endpoint clears its failure count.
+type UpdateWebhookEndpointRequest struct {
+	URL        *string  `json:"url"`
+	EventTypes []string `json:"event_types"`
+	Enabled    *bool    `json:"enabled"`
+}
+
+// WebhookEndpoint is a URL a user has registered for webhooks. Secret is only
+// returned when the endpoint is created.
+type WebhookEndpoint struct {
+	ID                  int32      `json:"id"`
+	URL                 string     `json:"url"`
+	EventTypes          []string   `json:"event_types"`
+	Enabled             bool       `json:"enabled"`
+	ConsecutiveFailures int32      `json:"consecutive_failures"`
+	DisabledReason      string     `json:"disabled_reason,omitempty"`
+	DisabledAt          *time.Time `json:"disabled_at,omitempty"`
+	Secret              string     `json:"secret,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+// WebhookDelivery is the delivery log entry of an event sent to an endpoint,
+// with the outcome of its latest attempt
+type WebhookDelivery struct {
+	ID            int64           `json:"id"`
+	EndpointID    int32           `json:"endpoint_id"`
+	EventID       string          `json:"event_id"`
+	EventType     string          `json:"event_type"`
+	Status        string          `json:"status"`
+	Attempts      int32           `json:"attempts"`
+	ResponseCode  *int32          `json:"response_code,omitempty"`
+	ResponseBody  string          `json:"response_body,omitempty"`
+	Error         string          `json:"error,omitempty"`
+	DurationMs    *int32          `json:"duration_ms,omitempty"`
+	RedeliveryOf  *int64          `json:"redelivery_of,omitempty"`
+	Payload       json.RawMessage `json:"payload"`
+	CreatedAt     time.Time       `json:"created_at"`
+	LastAttemptAt *time.Time      `json:"last_attempt_at,omitempty"`
+	DeliveredAt   *time.Time      `json:"delivered_at,omitempty"`
+}
+
+// WebhookDeliveryPage is one page of an endpoint's delivery log, newest first
+type WebhookDeliveryPage struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+	Total      int64             `json:"total"`
+	Limit      int32             `json:"limit"`
+	Offset     int32             `json:"offset"`
+}
+
+// WebhookServiceImpl implements WebhookService
+type WebhookServiceImpl struct {
+	repo   *repository.Repository
+	cfg    config.WebhookConfig
+	sender *webhooks.Sender
+	logger zerolog.Logger
+}
+
+// NewWebhookService creates a new webhook service. Deliveries are enqueued
+// through the outbox in repo.
+func NewWebhookService(repo *repository.Repository, cfg config.WebhookConfig, logger zerolog.Logger) WebhookService {
+	return &WebhookServiceImpl{
+		repo:   repo,
+		cfg:    cfg,
+		sender: webhooks.NewSender(cfg.Timeout, cfg.AllowPrivateNetworks),
+		logger: logger.With().Str("component", "webhook_service").Logger(),
+	}
+}
+
+// CreateEndpoint registers an endpoint and returns it with its signing secret
+func (s *WebhookServiceImpl) CreateEndpoint(ctx context.Context, userID int32, req CreateWebhookEndpointRequest) (*WebhookEndpoint, error) {
+	contextLogger := logging.NewContextLogger(s.logger, ctx).WithOperation("create_webhook_endpoint")
+
+	if err := webhooks.ValidateURL(ctx, req.URL, s.cfg.AllowInsecureURLs, s.cfg.AllowPrivateNetworks); err != nil {
+		return nil, &WebhookValidationError{Message: err.Error()}
+	}
+	eventTypes, err := validateWebhookEventTypes(req.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.repo.CountWebhookEndpointsByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count webhook endpoints: %w", err)
+	}
+	if count >= int64(s.cfg.MaxEndpointsPerUser) {
+		return nil, &WebhookValidationError{Message: fmt.Sprintf("cannot register more than %d endpoints", s.cfg.MaxEndpointsPerUser)}
+	}
+
+	secret, err := webhooks.NewSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	dbEndpoint, err := s.repo.CreateWebhookEndpoint(ctx, queries.CreateWebhookEndpointParams{
+		UserID:     userID,
+		Url:        req.URL,
+		Secret:     secret,
+		EventTypes: eventTypes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook endpoint: %w", err)
+	}
+
+	contextLogger.Info().
+		Int32("user_id", userID).
+		Int32("endpoint_id", dbEndpoint.ID).
+		Strs("event_types", eventTypes).
+		Msg("Webhook endpoint registered")
+
+	endpoint := convertDBWebhookEndpointToModel(dbEndpoint)
+	endpoint.Secret = dbEndpoint.Secret
+	return endpoint, nil
+}
+
+// ListEndpoints returns the user's endpoints
+func (s *WebhookServiceImpl) ListEndpoints(ctx context.Context, userID int32) ([]WebhookEndpoint, error) {
+	dbEndpoints, err := s.repo.ListWebhookEndpointsByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook endpoints: %w", err)
+	}
+
+	endpoints := make([]WebhookEndpoint, len(dbEndpoints))
+	for i, dbEndpoint := range dbEndpoints {
+		endpoints[i] = *convertDBWebhookEndpointToModel(dbEndpoint)
+	}
+	return endpoints, nil
+}
+
+// GetEndpoint returns an endpoint if it belongs to the user
+func (s *WebhookServiceImpl) GetEndpoint(ctx context.Context, userID, endpointID int32) (*WebhookEndpoint, error) {
+	dbEndpoint, err := s.userEndpoint(ctx, userID, endpointID)
+	if err != nil {
+		return nil, err
+	}
+	return convertDBWebhookEndpointToModel(dbEndpoint), nil
+}
+
+// UpdateEndpoint changes an endpoint's URL, event types or enabled state
+func (s *WebhookServiceImpl) UpdateEndpoint(ctx context.Context, userID, endpointID int32, req UpdateWebhookEndpointRequest) (*WebhookEndpoint, error) {
+	contextLogger := logging.NewContextLogger(s.logger, ctx).WithOperation("update_webhook_endpoint")
+
+	dbEndpoint, err := s.userEndpoint(ctx, userID, endpointID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := queries.UpdateWebhookEndpointParams{
+		ID:         endpointID,
+		Url:        dbEndpoint.Url,
+		EventTypes: dbEndpoint.EventTypes,
+	}
+	if req.URL != nil {
+		if err := webhooks.ValidateURL(ctx, *req.URL, s.cfg.AllowInsecureURLs, s.cfg.AllowPrivateNetworks); err != nil {
+			return nil, &WebhookValidationError{Message: err.Error()}
+		}
+		params.Url = *req.URL
+	}
+	if req.EventTypes != nil {
+		if params.EventTypes, err = validateWebhookEventTypes(req.EventTypes); err != nil {
+			return nil, err
+		}
+	}
+
+	err = s.repo.WithTx(ctx, func(qtx *queries.Queries) error {
+		var err error
+		if dbEndpoint, err = qtx.UpdateWebhookEndpoint(ctx, params); err != nil {
+			return err
+		}
+		switch {
+		case req.Enabled == nil || *req.Enabled == dbEndpoint.Enabled:
+		case *req.Enabled:
+			dbEndpoint, err = qtx.EnableWebhookEndpoint(ctx, endpointID)
+		default:
+			dbEndpoint, err = qtx.DisableWebhookEndpoint(ctx, queries.DisableWebhookEndpointParams{
+				ID:             endpointID,
+				DisabledReason: pgtype.Text{String: "disabled by user", Valid: true},
+			})
+		}
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update webhook endpoint: %w", err)
+	}
+
+	contextLogger.Info().
+		Int32("user_id", userID).
+		Int32("endpoint_id", endpointID).
+		Bool("enabled", dbEndpoint.Enabled).
+		Msg("Webhook endpoint updated")
+
+	return convertDBWebhookEndpointToModel(dbEndpoint), nil
+}
+
+// DeleteEndpoint deletes an endpoint and its delivery log
+func (s *WebhookServiceImpl) DeleteEndpoint(ctx context.Context, userID, endpointID int32) error {
+	if _, err := s.userEndpoint(ctx, userID, endpointID); err != nil {
+		return err
+	}
+	if err := s.repo.DeleteWebhookEndpoint(ctx, endpointID); err != nil {
+		return fmt.Errorf("failed to delete webhook endpoint: %w", err)
+	}
+
+	logging.NewContextLogger(s.logger, ctx).WithOperation("delete_webhook_endpoint").Info().
+		Int32("user_id", userID).
+		Int32("endpoint_id", endpointID).
+		Msg("Webhook endpoint deleted")
+	return nil
+}
+
+// ListDeliveries returns a page of an endpoint's delivery log, newest first
+func (s *WebhookServiceImpl) ListDeliveries(ctx context.Context, userID, endpointID, limit, offset int32) (*WebhookDeliveryPage, error) {
+	if _, err := s.userEndpoint(ctx, userID, endpointID); err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	dbDeliveries, err := s.repo.ListWebhookDeliveries(ctx, queries.ListWebhookDeliveriesParams{
+		EndpointID: endpointID,
+		Limit:      limit,
+		Offset:     offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	total, err := s.repo.CountWebhookDeliveries(ctx, endpointID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count webhook deliveries: %w", err)
+	}
+
+	page := &WebhookDeliveryPage{
+		Deliveries: make([]WebhookDelivery, len(dbDeliveries)),
+		Total:      total,
+		Limit:      limit,
+		Offset:     offset,
+	}
+	for i, dbDelivery := range dbDeliveries {
+		page.Deliveries[i] = *convertDBWebhookDeliveryToModel(dbDelivery)
+	}
+	return page, nil
+}
+
+// Redeliver sends a logged delivery's event again as a new delivery, with the
+// same event ID so the receiver can tell it is a repeat
+func (s *WebhookServiceImpl) Redeliver(ctx context.Context, userID, endpointID int32, deliveryID int64) (*WebhookDelivery, error) {
+	dbEndpoint, err := s.userEndpoint(ctx, userID, endpointID)
+	if err != nil {
+		return nil, err
+	}
+	if !dbEndpoint.Enabled {
+		return nil, ErrWebhookEndpointDisabled
+	}
+
+	original, err := s.repo.GetWebhookDelivery(ctx, deliveryID)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && original.EndpointID != endpointID) {
+		return nil, ErrWebhookDeliveryNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+
+	var redelivery queries.WebhookDelivery
+	err = s.repo.WithTx(ctx, func(qtx *queries.Queries) error {
+		var err error
+		redelivery, err = qtx.CreateWebhookDelivery(ctx, queries.CreateWebhookDeliveryParams{
+			EndpointID:   endpointID,
+			EventID:      original.EventID,
+			EventType:    original.EventType,
+			Payload:      original.Payload,
+			RedeliveryOf: pgtype.Int8{Int64: original.ID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+		return enqueueWebhookDelivery(ctx, qtx, redelivery)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to redeliver webhook: %w", err)
+	}
+
+	logging.NewContextLogger(s.logger, ctx).WithOperation("redeliver_webhook").Info().
+		Int32("endpoint_id", endpointID).
+		Int64("delivery_id", redelivery.ID).
+		Int64("redelivery_of", original.ID).
+		Msg("Webhook redelivery scheduled")
+
+	return convertDBWebhookDeliveryToModel(redelivery), nil
+}
+
+// userEndpoint returns an endpoint if it belongs to the user
+func (s *WebhookServiceImpl) userEndpoint(ctx context.Context, userID, endpointID int32) (queries.WebhookEndpoint, error) {
+	dbEndpoint, err := s.repo.GetWebhookEndpoint(ctx, endpointID)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && dbEndpoint.UserID != userID) {
+		return queries.WebhookEndpoint{}, ErrWebhookEndpointNotFound
+	}
+	if err != nil {
+		return queries.WebhookEndpoint{}, fmt.Errorf("failed to get webhook endpoint: %w", err)
+	}
+	return dbEndpoint, nil
+}
+
+// enqueueWebhookDelivery writes the delivery task to the outbox in the
+// transaction that created the delivery
+func enqueueWebhookDelivery(ctx context.Context, qtx *queries.Queries, delivery queries.WebhookDelivery) error {
+	message, err := outbox.Task(outbox.AggregateWebhookEndpoint, strconv.Itoa(int(delivery.EndpointID)),
+		queue.TypeWebhookDelivery, queue.WebhookDeliveryPayload{DeliveryID: delivery.ID})
+	if err != nil {
+		return err
+	}
+	return outbox.Add(ctx, qtx, message)
+}
+
+// validateWebhookEventTypes checks event types against the supported ones and
+// drops duplicates
+func validateWebhookEventTypes(eventTypes []string) ([]string, error) {
+	if len(eventTypes) == 0 {
+		return nil, &WebhookValidationError{Message: "at least one event type is required"}
+	}
+
+	seen := make(map[string]bool, len(eventTypes))
+	unique := make([]string, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		if !webhooks.IsEventType(eventType) {
+			return nil, &WebhookValidationError{Message: fmt.Sprintf("unsupported event type %q", eventType)}
+		}
+		if !seen[eventType] {
+			seen[eventType] = true
+			unique = append(unique, eventType)
+		}
+	}
+	return unique, nil
+}
+
+// convertDBWebhookEndpointToModel converts a database endpoint, leaving out its secret
+func convertDBWebhookEndpointToModel(dbEndpoint queries.WebhookEndpoint) *WebhookEndpoint {
+	endpoint := &WebhookEndpoint{
+		ID:                  dbEndpoint.ID,
+		URL:                 dbEndpoint.Url,
+		EventTypes:          dbEndpoint.EventTypes,
+		Enabled:             dbEndpoint.Enabled,
+		ConsecutiveFailures: dbEndpoint.ConsecutiveFailures,
+		DisabledReason:      dbEndpoint.DisabledReason.String,
+		CreatedAt:           utils.ConvertPgTimestampToTime(dbEndpoint.CreatedAt),
+		UpdatedAt:           utils.ConvertPgTimestampToTime(dbEndpoint.UpdatedAt),
+	}
+	if dbEndpoint.DisabledAt.Valid {
+		disabledAt := utils.ConvertPgTimestampToTime(dbEndpoint.DisabledAt)
+		endpoint.DisabledAt = &disabledAt
+	}
+	return endpoint
+}
+
+// convertDBWebhookDeliveryToModel converts a database delivery
+func convertDBWebhookDeliveryToModel(dbDelivery queries.WebhookDelivery) *WebhookDelivery {
+	delivery := &WebhookDelivery{
+		ID:         dbDelivery.ID,
+		EndpointID: dbDelivery.EndpointID,
+		EventID:    dbDelivery.EventID,
+		EventType:  dbDelivery.EventType,
+		Status:     dbDelivery.Status,
+		Attempts:   dbDelivery.Attempts,
+		Error:      dbDelivery.LastError.String,
+		Payload:    json.RawMessage(dbDelivery.Payload),
+		CreatedAt:  utils.ConvertPgTimestampToTime(dbDelivery.CreatedAt),
+	}
+	if dbDelivery.ResponseCode.Valid {
+		delivery.ResponseCode = &dbDelivery.ResponseCode.Int32
+	}
+	// Only a successful endpoint's response is shown; rows written before
+	// failed responses stopped being stored may still hold one
+	if dbDelivery.Status == WebhookDeliverySucceeded {
+		delivery.ResponseBody = dbDelivery.ResponseBody.String
+	}
+	if dbDelivery.DurationMs.Valid {
+		delivery.DurationMs = &dbDelivery.DurationMs.Int32
+	}
+	if dbDelivery.RedeliveryOf.Valid {
+		delivery.RedeliveryOf = &dbDelivery.RedeliveryOf.Int64
+	}
+	if dbDelivery.LastAttemptAt.Valid {
+		lastAttemptAt := utils.ConvertPgTimestampToTime(dbDelivery.LastAttemptAt)
+		delivery.LastAttemptAt = &lastAttemptAt
+	}
+	if dbDelivery.DeliveredAt.Valid {
+		deliveredAt := utils.ConvertPgTimestampToTime(dbDelivery.DeliveredAt)
+		delivery.DeliveredAt = &deliveredAt
+	}
+	return delivery
+}
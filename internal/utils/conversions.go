@@ -2,6 +2,7 @@ package utils
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
@@ -27,6 +28,9 @@ func ConvertPgNumericToDecimal(pgNum pgtype.Numeric) (decimal.Decimal, error) {
 			pos := len(numStr) - exp
 			numStr = numStr[:pos] + "." + numStr[pos:]
 		}
+	} else if pgNum.Exp > 0 {
+		// Scale up values stored with a positive exponent (e.g. 250 as 25e1)
+		numStr += strings.Repeat("0", int(pgNum.Exp))
 	}
 
 	dec, err := decimal.NewFromString(numStr)
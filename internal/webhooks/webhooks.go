@@ -0,0 +1,323 @@
+// Package webhooks signs and sends outgoing webhooks. Every request carries
+// the event as JSON and an HMAC-SHA256 signature of the timestamp and body
+// made with the endpoint's secret, so receivers can check that it came from
+// the bank and is not a replay.
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Event types customers can subscribe to
+const (
+	EventTransferReceived = "transfer.received"
+	EventTransferSent     = "transfer.sent"
+	EventAccountCreated   = "account.created"
+)
+
+// EventTypes lists every event type customers can subscribe to
+var EventTypes = []string{EventTransferReceived, EventTransferSent, EventAccountCreated}
+
+// Request headers
+const (
+	HeaderEventID    = "X-Webhook-ID"
+	HeaderEventType  = "X-Webhook-Event"
+	HeaderDeliveryID = "X-Webhook-Delivery"
+	HeaderSignature  = "X-Webhook-Signature"
+)
+
+// secretPrefix marks webhook signing secrets
+const secretPrefix = "whsec_"
+
+// maxResponseBody caps how much of a response is kept in the delivery log
+const maxResponseBody = 1024
+
+// ErrBlockedAddress is returned for endpoints on loopback, private or other
+// non-public addresses, whether found when the URL is registered or when a
+// delivery connects
+var ErrBlockedAddress = errors.New("url must resolve to a public address")
+
+// blockedPrefixes are the ranges webhooks are never sent to: unspecified,
+// loopback, private, link-local (home of cloud metadata services such as
+// 169.254.169.254), carrier-grade NAT, benchmarking, NAT64, unique local,
+// multicast and reserved addresses
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("10.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("127.0.0.0/8"),
+	netip.MustParsePrefix("169.254.0.0/16"),
+	netip.MustParsePrefix("172.16.0.0/12"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("192.168.0.0/16"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("224.0.0.0/4"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("::/128"),
+	netip.MustParsePrefix("::1/128"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("fc00::/7"),
+	netip.MustParsePrefix("fe80::/10"),
+	netip.MustParsePrefix("ff00::/8"),
+}
+
+// lookupHost resolves endpoint host names; tests replace it
+var lookupHost = net.DefaultResolver.LookupNetIP
+
+// checkAddr rejects addresses in blockedPrefixes. IPv4-mapped IPv6 addresses
+// are checked as IPv4 so ::ffff:127.0.0.1 cannot slip through.
+func checkAddr(addr netip.Addr) error {
+	addr = addr.Unmap()
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(addr) {
+			return ErrBlockedAddress
+		}
+	}
+	return nil
+}
+
+// Payload is the body of a webhook request
+type Payload struct {
+	ID        string      `json:"id"`
+	Type      string      `json:"type"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// IsEventType reports whether customers can subscribe to eventType
+func IsEventType(eventType string) bool {
+	for _, known := range EventTypes {
+		if eventType == known {
+			return true
+		}
+	}
+	return false
+}
+
+// NewSecret generates a signing secret for a new endpoint
+func NewSecret() (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return secretPrefix + hex.EncodeToString(raw), nil
+}
+
+// ValidateURL checks that rawURL is an absolute https URL, or http when
+// allowInsecure is set, without credentials. Unless allowPrivate is set the
+// host must resolve only to public addresses; Sender checks again when it
+// connects, since DNS answers can change after registration.
+func ValidateURL(ctx context.Context, rawURL string, allowInsecure, allowPrivate bool) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return errors.New("url is not valid")
+	}
+	switch {
+	case parsed.Scheme == "https":
+	case parsed.Scheme == "http" && allowInsecure:
+	default:
+		return errors.New("url must use https")
+	}
+	if parsed.Host == "" {
+		return errors.New("url must include a host")
+	}
+	if parsed.User != nil {
+		return errors.New("url must not include credentials")
+	}
+	if allowPrivate {
+		return nil
+	}
+
+	host := parsed.Hostname()
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return checkAddr(addr)
+	}
+	addrs, err := lookupHost(ctx, "ip", host)
+	if err != nil || len(addrs) == 0 {
+		return errors.New("url host could not be resolved")
+	}
+	for _, addr := range addrs {
+		if err := checkAddr(addr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Sign returns the hex HMAC-SHA256 of "timestamp.body" under secret
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SignatureHeader returns the signature header value, "t=<unix>,v1=<hex>"
+func SignatureHeader(secret string, timestamp int64, body []byte) string {
+	return fmt.Sprintf("t=%d,v1=%s", timestamp, Sign(secret, timestamp, body))
+}
+
+// Verify checks a signature header against body, rejecting signatures older
+// than tolerance. It is what receivers are expected to do.
+func Verify(secret, header string, body []byte, tolerance time.Duration, now time.Time) error {
+	var timestamp int64
+	var signature string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(part, "=")
+		switch key {
+		case "t":
+			parsed, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return errors.New("invalid signature timestamp")
+			}
+			timestamp = parsed
+		case "v1":
+			signature = value
+		}
+	}
+	if timestamp == 0 || signature == "" {
+		return errors.New("malformed signature header")
+	}
+	if now.Sub(time.Unix(timestamp, 0)) > tolerance {
+		return errors.New("signature timestamp is too old")
+	}
+	if !hmac.Equal([]byte(signature), []byte(Sign(secret, timestamp, body))) {
+		return errors.New("signature does not match")
+	}
+	return nil
+}
+
+// Request is one attempt to deliver an event to an endpoint
+type Request struct {
+	URL        string
+	Secret     string
+	DeliveryID int64
+	EventID    string
+	EventType  string
+	Body       []byte
+}
+
+// Result is the outcome of a delivery attempt
+type Result struct {
+	// StatusCode is zero when no response was received
+	StatusCode int
+	// Body is the start of the response body. It is only kept for 2xx
+	// responses, so an error page is never echoed back to the customer.
+	Body     string
+	Err      error
+	Duration time.Duration
+}
+
+// OK reports whether the endpoint accepted the delivery with a 2xx response
+func (r Result) OK() bool {
+	return r.Err == nil && r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+// Error describes why a delivery attempt failed
+func (r Result) Error() string {
+	if r.Err != nil {
+		return r.Err.Error()
+	}
+	if !r.OK() {
+		return fmt.Sprintf("endpoint responded with status %d", r.StatusCode)
+	}
+	return ""
+}
+
+// Sender posts signed webhook requests
+type Sender struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewSender creates a sender whose requests time out after timeout. Redirects
+// are not followed, so an endpoint must answer at the URL it registered.
+// Unless allowPrivate is set, connections to non-public addresses are refused
+// at dial time, which covers hosts whose DNS changed after registration.
+func NewSender(timeout time.Duration, allowPrivate bool) *Sender {
+	dialer := &net.Dialer{
+		Timeout:   timeout,
+		KeepAlive: 30 * time.Second,
+	}
+	if !allowPrivate {
+		dialer.Control = refuseBlockedAddress
+	}
+
+	return &Sender{
+		client: &http.Client{
+			Timeout: timeout,
+			// No proxy: the dial check must see the endpoint's own address
+			Transport: &http.Transport{
+				DialContext:         dialer.DialContext,
+				ForceAttemptHTTP2:   true,
+				MaxIdleConns:        100,
+				IdleConnTimeout:     90 * time.Second,
+				TLSHandshakeTimeout: timeout,
+			},
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		now: time.Now,
+	}
+}
+
+// refuseBlockedAddress is a net.Dialer Control hook that refuses to connect
+// to addresses in blockedPrefixes. It runs after name resolution, once per
+// address tried.
+func refuseBlockedAddress(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("webhook dial to %q refused: %w", address, ErrBlockedAddress)
+	}
+	if err := checkAddr(addrPort.Addr()); err != nil {
+		return fmt.Errorf("webhook dial to %s refused: %w", addrPort.Addr(), err)
+	}
+	return nil
+}
+
+// Send posts the request and reports the endpoint's response
+func (s *Sender) Send(ctx context.Context, req Request) Result {
+	start := s.now()
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Body))
+	if err != nil {
+		return Result{Err: fmt.Errorf("failed to build webhook request: %w", err)}
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", "BankGo-Webhooks/1.0")
+	httpReq.Header.Set(HeaderEventID, req.EventID)
+	httpReq.Header.Set(HeaderEventType, req.EventType)
+	httpReq.Header.Set(HeaderDeliveryID, strconv.FormatInt(req.DeliveryID, 10))
+	httpReq.Header.Set(HeaderSignature, SignatureHeader(req.Secret, start.Unix(), req.Body))
+
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return Result{Err: err, Duration: s.now().Sub(start)}
+	}
+	defer resp.Body.Close()
+
+	result := Result{StatusCode: resp.StatusCode}
+	if result.OK() {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+		result.Body = string(body)
+	}
+	result.Duration = s.now().Sub(start)
+	return result
+}
@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// APIKeyPrefix starts every API key, so keys are recognizable in headers,
+// logs and secret scanners
+const APIKeyPrefix = "bgk_"
+
+const (
+	// apiKeyIDLength is the number of hex characters identifying a key
+	apiKeyIDLength = 12
+	// apiKeySecretLength is the number of hex characters of a key's secret
+	apiKeySecretLength = 64
+)
+
+// APIKey is a newly generated key. Key is shown to the user once; only
+// Prefix and Hash are stored.
+type APIKey struct {
+	Key    string
+	Prefix string
+	Hash   string
+}
+
+// IsAPIKey reports whether credential looks like an API key rather than a token
+func IsAPIKey(credential string) bool {
+	return strings.HasPrefix(credential, APIKeyPrefix)
+}
+
+// GenerateAPIKey generates a key of the form "bgk_<id>_<secret>". The prefix
+// "bgk_<id>" identifies the key and is its OAuth2 client ID; the secret is
+// its client secret.
+func GenerateAPIKey() (APIKey, error) {
+	raw := make([]byte, (apiKeyIDLength+apiKeySecretLength)/2)
+	if _, err := rand.Read(raw); err != nil {
+		return APIKey{}, fmt.Errorf("failed to generate API key: %w", err)
+	}
+	encoded := hex.EncodeToString(raw)
+
+	prefix := APIKeyPrefix + encoded[:apiKeyIDLength]
+	key := prefix + "_" + encoded[apiKeyIDLength:]
+	return APIKey{Key: key, Prefix: prefix, Hash: HashAPIKey(key)}, nil
+}
+
+// JoinAPIKey rebuilds a key from an OAuth2 client ID and secret
+func JoinAPIKey(clientID, clientSecret string) string {
+	return clientID + "_" + clientSecret
+}
+
+// ParseAPIKey checks a key's format and returns its prefix
+func ParseAPIKey(key string) (string, error) {
+	if !IsAPIKey(key) {
+		return "", errors.New("not an API key")
+	}
+	rest := strings.TrimPrefix(key, APIKeyPrefix)
+	id, secret, ok := strings.Cut(rest, "_")
+	if !ok || len(id) != apiKeyIDLength || len(secret) != apiKeySecretLength {
+		return "", errors.New("malformed API key")
+	}
+	if _, err := hex.DecodeString(id + secret); err != nil {
+		return "", errors.New("malformed API key")
+	}
+	return APIKeyPrefix + id, nil
+}
+
+// HashAPIKey returns the hex SHA-256 of a key. Keys are random and long, so a
+// fast hash is enough to make a leaked table useless.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// VerifyAPIKey reports whether key matches a stored hash, in constant time
+func VerifyAPIKey(key, hash string) bool {
+	return subtle.ConstantTimeCompare([]byte(HashAPIKey(key)), []byte(hash)) == 1
+}
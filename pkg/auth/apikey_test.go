@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateAPIKey(t *testing.T) {
+	first, err := GenerateAPIKey()
+	require.NoError(t, err)
+	second, err := GenerateAPIKey()
+	require.NoError(t, err)
+
+	assert.True(t, IsAPIKey(first.Key))
+	assert.True(t, strings.HasPrefix(first.Key, first.Prefix+"_"))
+	assert.NotEqual(t, first.Key, second.Key)
+	assert.NotEqual(t, first.Prefix, second.Prefix)
+
+	// Only the hash is stored, and it verifies the key
+	assert.NotContains(t, first.Hash, first.Key)
+	assert.True(t, VerifyAPIKey(first.Key, first.Hash))
+	assert.False(t, VerifyAPIKey(second.Key, first.Hash))
+
+	prefix, err := ParseAPIKey(first.Key)
+	require.NoError(t, err)
+	assert.Equal(t, first.Prefix, prefix)
+}
+
+func TestJoinAPIKey(t *testing.T) {
+	key, err := GenerateAPIKey()
+	require.NoError(t, err)
+
+	secret := strings.TrimPrefix(key.Key, key.Prefix+"_")
+	assert.Equal(t, key.Key, JoinAPIKey(key.Prefix, secret))
+}
+
+func TestParseAPIKey_Malformed(t *testing.T) {
+	key, err := GenerateAPIKey()
+	require.NoError(t, err)
+
+	for _, malformed := range []string{
+		"",
+		"v2.local.token",
+		"bgk_",
+		key.Prefix,
+		key.Key + "00",
+		strings.Replace(key.Key, "_", "-", 2),
+		key.Key[:len(key.Key)-1] + "z",
+	} {
+		_, err := ParseAPIKey(malformed)
+		assert.Error(t, err, malformed)
+	}
+}
@@ -14,6 +14,13 @@ type TokenClaims struct {
 	Email     string    `json:"email"`
 	IssuedAt  time.Time `json:"iat"`
 	ExpiresAt time.Time `json:"exp"`
+	// Scopes are what the token was granted; login tokens get all scopes
+	Scopes []string `json:"scopes,omitempty"`
+	// ClientID is set on tokens issued to an API key through the OAuth2
+	// client-credentials grant
+	ClientID string `json:"client_id,omitempty"`
+	// Reduced is set on tokens a signed-in user requested with fewer scopes
+	Reduced bool `json:"reduced,omitempty"`
 }
 
 // PASETOManager handles PASETO token operations
@@ -61,6 +68,77 @@ func (pm *PASETOManager) GenerateToken(userID int, email string) (string, error)
 		Email:     email,
 		IssuedAt:  now,
 		ExpiresAt: now.Add(pm.expiration),
+		Scopes:    AllScopes,
+	}
+
+	token, err := paseto.NewV2().Encrypt(pm.secretKey, claims, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	return token, nil
+}
+
+// GenerateClientToken generates a token for an OAuth2 client, limited to
+// scopes and expiring after expiration rather than the login expiration
+func (pm *PASETOManager) GenerateClientToken(userID int, email, clientID string, scopes []string, expiration time.Duration) (string, error) {
+	if userID <= 0 {
+		return "", errors.New("invalid user ID")
+	}
+
+	if email == "" {
+		return "", errors.New("email cannot be empty")
+	}
+
+	if clientID == "" {
+		return "", errors.New("client ID cannot be empty")
+	}
+
+	if len(scopes) == 0 {
+		return "", errors.New("client tokens need at least one scope")
+	}
+
+	now := time.Now()
+	claims := TokenClaims{
+		UserID:    userID,
+		Email:     email,
+		IssuedAt:  now,
+		ExpiresAt: now.Add(expiration),
+		ClientID:  clientID,
+		Scopes:    scopes,
+	}
+
+	token, err := paseto.NewV2().Encrypt(pm.secretKey, claims, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	return token, nil
+}
+
+// GenerateScopedToken generates a token for a signed-in user limited to
+// scopes, e.g. for a read-only integration or a statement download
+func (pm *PASETOManager) GenerateScopedToken(userID int, email string, scopes []string, expiration time.Duration) (string, error) {
+	if userID <= 0 {
+		return "", errors.New("invalid user ID")
+	}
+
+	if email == "" {
+		return "", errors.New("email cannot be empty")
+	}
+
+	if len(scopes) == 0 {
+		return "", errors.New("scoped tokens need at least one scope")
+	}
+
+	now := time.Now()
+	claims := TokenClaims{
+		UserID:    userID,
+		Email:     email,
+		IssuedAt:  now,
+		ExpiresAt: now.Add(expiration),
+		Scopes:    scopes,
+		Reduced:   true,
 	}
 
 	token, err := paseto.NewV2().Encrypt(pm.secretKey, claims, nil)
@@ -97,6 +175,11 @@ func (pm *PASETOManager) ValidateToken(token string) (*TokenClaims, error) {
 		return nil, errors.New("invalid email in token")
 	}
 
+	// Login tokens issued before scopes existed carry none but allow everything
+	if len(claims.Scopes) == 0 && claims.IsLoginSession() {
+		claims.Scopes = AllScopes
+	}
+
 	return &claims, nil
 }
 
@@ -107,6 +190,12 @@ func (pm *PASETOManager) RefreshToken(token string) (string, error) {
 		return "", fmt.Errorf("cannot refresh invalid token: %w", err)
 	}
 
+	// Refreshing would widen a client or reduced-scope token to a full login
+	// token; they are requested again instead
+	if !claims.IsLoginSession() {
+		return "", errors.New("only login tokens can be refreshed")
+	}
+
 	// Generate new token with same user info but new expiration
 	return pm.GenerateToken(claims.UserID, claims.Email)
 }
@@ -323,4 +323,87 @@ func TestPASETOManager_EdgeCases(t *testing.T) {
 		assert.Equal(t, userID, claims.UserID)
 		assert.Equal(t, email, claims.Email)
 	})
-}
\ No newline at end of file
+}
+func TestPASETOManager_GenerateClientToken(t *testing.T) {
+	secretKey := "this-is-a-very-long-secret-key-for-testing-purposes"
+	manager, _ := NewPASETOManager(secretKey, 24*time.Hour)
+
+	t.Run("scoped token with its own expiration", func(t *testing.T) {
+		token, err := manager.GenerateClientToken(123, "test@example.com", "bgk_0123456789ab", []string{ScopeAccountsRead, ScopeTransfersRead}, time.Hour)
+		assert.NoError(t, err)
+
+		claims, err := manager.ValidateToken(token)
+		assert.NoError(t, err)
+		assert.Equal(t, "bgk_0123456789ab", claims.ClientID)
+		assert.Equal(t, []string{ScopeAccountsRead, ScopeTransfersRead}, claims.Scopes)
+		assert.False(t, claims.IsLoginSession())
+		assert.WithinDuration(t, time.Now().Add(time.Hour), claims.ExpiresAt, time.Minute)
+	})
+
+	t.Run("login tokens carry every scope", func(t *testing.T) {
+		token, err := manager.GenerateToken(123, "test@example.com")
+		assert.NoError(t, err)
+
+		claims, err := manager.ValidateToken(token)
+		assert.NoError(t, err)
+		assert.Empty(t, claims.ClientID)
+		assert.Equal(t, AllScopes, claims.Scopes)
+		assert.True(t, claims.IsLoginSession())
+	})
+
+	t.Run("requires a client and scopes", func(t *testing.T) {
+		_, err := manager.GenerateClientToken(123, "test@example.com", "", []string{ScopeAccountsRead}, time.Hour)
+		assert.Error(t, err)
+		_, err = manager.GenerateClientToken(123, "test@example.com", "bgk_0123456789ab", nil, time.Hour)
+		assert.Error(t, err)
+	})
+
+	t.Run("client tokens cannot be refreshed", func(t *testing.T) {
+		token, err := manager.GenerateClientToken(123, "test@example.com", "bgk_0123456789ab", []string{ScopeAccountsRead}, time.Hour)
+		assert.NoError(t, err)
+
+		_, err = manager.RefreshToken(token)
+		assert.Error(t, err)
+	})
+}
+
+func TestPASETOManager_GenerateScopedToken(t *testing.T) {
+	secretKey := "this-is-a-very-long-secret-key-for-testing-purposes"
+	manager, _ := NewPASETOManager(secretKey, 24*time.Hour)
+
+	t.Run("reduced token keeps only its scopes", func(t *testing.T) {
+		token, err := manager.GenerateScopedToken(123, "test@example.com", []string{ScopeAccountsRead}, time.Hour)
+		assert.NoError(t, err)
+
+		claims, err := manager.ValidateToken(token)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{ScopeAccountsRead}, claims.Scopes)
+		assert.True(t, claims.HasScope(ScopeAccountsRead))
+		assert.False(t, claims.HasScope(ScopeTransfersWrite))
+		assert.False(t, claims.IsLoginSession())
+		assert.WithinDuration(t, time.Now().Add(time.Hour), claims.ExpiresAt, time.Minute)
+	})
+
+	t.Run("requires scopes", func(t *testing.T) {
+		_, err := manager.GenerateScopedToken(123, "test@example.com", nil, time.Hour)
+		assert.Error(t, err)
+	})
+
+	t.Run("reduced tokens cannot be refreshed", func(t *testing.T) {
+		token, err := manager.GenerateScopedToken(123, "test@example.com", []string{ScopeTransfersRead}, time.Hour)
+		assert.NoError(t, err)
+
+		_, err = manager.RefreshToken(token)
+		assert.Error(t, err)
+	})
+}
+
+func TestUniqueScopes(t *testing.T) {
+	assert.Equal(t, []string{ScopeTransfersRead, ScopeAccountsRead},
+		UniqueScopes([]string{ScopeTransfersRead, ScopeAccountsRead, ScopeTransfersRead}))
+	assert.Empty(t, UniqueScopes(nil))
+
+	assert.True(t, IsScope(ScopeTransfersWrite))
+	assert.False(t, IsScope("read"))
+	assert.False(t, IsScope("admin"))
+}
@@ -0,0 +1,49 @@
+package auth
+
+// Scopes limit what a token or API key can do on the user's behalf
+const (
+	ScopeAccountsRead   = "accounts:read"
+	ScopeTransfersRead  = "transfers:read"
+	ScopeTransfersWrite = "transfers:write"
+)
+
+// AllScopes lists every scope. Login tokens carry all of them.
+var AllScopes = []string{ScopeAccountsRead, ScopeTransfersRead, ScopeTransfersWrite}
+
+// IsScope reports whether scope can be granted
+func IsScope(scope string) bool {
+	return containsScope(AllScopes, scope)
+}
+
+// UniqueScopes drops duplicate scopes, keeping the order they are first
+// granted in
+func UniqueScopes(scopes []string) []string {
+	unique := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if !containsScope(unique, scope) {
+			unique = append(unique, scope)
+		}
+	}
+	return unique
+}
+
+// HasScope reports whether the token was granted scope
+func (c *TokenClaims) HasScope(scope string) bool {
+	return containsScope(c.Scopes, scope)
+}
+
+// IsLoginSession reports whether the token came from signing in, rather than
+// from an API key, the client-credentials grant or a reduced-scope request.
+// Only login sessions can manage credentials and settings.
+func (c *TokenClaims) IsLoginSession() bool {
+	return c.ClientID == "" && !c.Reduced
+}
+
+func containsScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -144,6 +144,245 @@ func (s *Service) SendWelcomeEmail(ctx context.Context, data WelcomeEmailData) e
 	return nil
 }
 
+// AccountLockedEmailData represents the data for account locked email template
+type AccountLockedEmailData struct {
+	FirstName   string
+	Email       string
+	IPAddress   string
+	LockedUntil string
+}
+
+// accountLockedEmailTemplate is the HTML template for the email sent when an
+// account is locked after repeated failed sign-ins
+const accountLockedEmailTemplate = `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>Your Bank API account was locked</title>
+    <style>
+        body {
+            font-family: Arial, sans-serif;
+            line-height: 1.6;
+            color: #333;
+            max-width: 600px;
+            margin: 0 auto;
+            padding: 20px;
+        }
+        .header {
+            background-color: #C62828;
+            color: white;
+            padding: 20px;
+            text-align: center;
+            border-radius: 5px 5px 0 0;
+        }
+        .content {
+            background-color: #f9f9f9;
+            padding: 30px;
+            border-radius: 0 0 5px 5px;
+        }
+        .footer {
+            text-align: center;
+            margin-top: 20px;
+            font-size: 12px;
+            color: #666;
+        }
+    </style>
+</head>
+<body>
+    <div class="header">
+        <h1>Account Temporarily Locked</h1>
+    </div>
+    <div class="content">
+        <h2>Hello {{.FirstName}},</h2>
+        <p>We locked sign-ins to your account <strong>{{.Email}}</strong> after several failed attempts to sign in with a wrong password.</p>
+
+        <p>The last attempt came from IP address <strong>{{.IPAddress}}</strong>. You can sign in again after <strong>{{.LockedUntil}}</strong>.</p>
+
+        <p>If this was you, there is nothing else to do. If it was not, someone may be trying to guess your password: choose a new, unique password once the lock expires and contact our support team.</p>
+
+        <p>Best regards,<br>
+        The Bank API Team</p>
+    </div>
+    <div class="footer">
+        <p>This is an automated message. Please do not reply to this email.</p>
+    </div>
+</body>
+</html>
+`
+
+// renderAccountLockedEmail executes the account locked email template
+func renderAccountLockedEmail(data AccountLockedEmailData) (string, error) {
+	tmpl, err := template.New("account_locked").Parse(accountLockedEmailTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse account locked email template: %w", err)
+	}
+
+	var body bytes.Buffer
+	if err := tmpl.Execute(&body, data); err != nil {
+		return "", fmt.Errorf("failed to execute account locked email template: %w", err)
+	}
+	return body.String(), nil
+}
+
+// SendAccountLockedEmail tells a user their account was locked after
+// repeated failed sign-ins
+func (s *Service) SendAccountLockedEmail(ctx context.Context, data AccountLockedEmailData) error {
+	body, err := renderAccountLockedEmail(data)
+	if err != nil {
+		return err
+	}
+
+	subject := "Bank API - Your account was temporarily locked"
+	msg := s.buildEmailMessage(data.Email, subject, body)
+
+	addr := fmt.Sprintf("%s:%d", s.config.SMTPHost, s.config.SMTPPort)
+	if err := smtp.SendMail(addr, s.auth, s.config.FromEmail, []string{data.Email}, []byte(msg)); err != nil {
+		return fmt.Errorf("failed to send account locked email to %s: %w", data.Email, err)
+	}
+
+	return nil
+}
+
+// ProcessAccountLockedEmail processes an account locked email task from the queue
+func (s *Service) ProcessAccountLockedEmail(ctx context.Context, payload queue.AccountLockedEmailPayload) error {
+	data := AccountLockedEmailData{
+		FirstName:   payload.FirstName,
+		Email:       payload.Email,
+		IPAddress:   payload.IPAddress,
+		LockedUntil: payload.LockedUntil.UTC().Format("2006-01-02 15:04 MST"),
+	}
+
+	if err := s.SendAccountLockedEmail(ctx, data); err != nil {
+		return fmt.Errorf("failed to process account locked email for user %d: %w", payload.UserID, err)
+	}
+	return nil
+}
+
+// NewDeviceLoginEmailData represents the data for new device login email template
+type NewDeviceLoginEmailData struct {
+	FirstName  string
+	Email      string
+	IPAddress  string
+	UserAgent  string
+	NewDevice  bool
+	NewIPRange bool
+	LoginAt    string
+}
+
+// newDeviceLoginEmailTemplate is the HTML template for the email sent when a
+// user signs in from a device or network they had not used before
+const newDeviceLoginEmailTemplate = `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>New sign-in to your Bank API account</title>
+    <style>
+        body {
+            font-family: Arial, sans-serif;
+            line-height: 1.6;
+            color: #333;
+            max-width: 600px;
+            margin: 0 auto;
+            padding: 20px;
+        }
+        .header {
+            background-color: #EF6C00;
+            color: white;
+            padding: 20px;
+            text-align: center;
+            border-radius: 5px 5px 0 0;
+        }
+        .content {
+            background-color: #f9f9f9;
+            padding: 30px;
+            border-radius: 0 0 5px 5px;
+        }
+        .footer {
+            text-align: center;
+            margin-top: 20px;
+            font-size: 12px;
+            color: #666;
+        }
+    </style>
+</head>
+<body>
+    <div class="header">
+        <h1>New Sign-in Detected</h1>
+    </div>
+    <div class="content">
+        <h2>Hello {{.FirstName}},</h2>
+        <p>Your account <strong>{{.Email}}</strong> was signed in to at <strong>{{.LoginAt}}</strong> from {{if and .NewDevice .NewIPRange}}a device and a network{{else if .NewDevice}}a device{{else}}a network{{end}} we had not seen you use before.</p>
+
+        <ul>
+            <li>IP address: <strong>{{.IPAddress}}</strong></li>
+            <li>Device: <strong>{{if .UserAgent}}{{.UserAgent}}{{else}}unknown{{end}}</strong></li>
+        </ul>
+
+        <p>If this was you, there is nothing else to do. If it was not, change your password right away and contact our support team.</p>
+
+        <p>Best regards,<br>
+        The Bank API Team</p>
+    </div>
+    <div class="footer">
+        <p>This is an automated message. Please do not reply to this email.</p>
+    </div>
+</body>
+</html>
+`
+
+// renderNewDeviceLoginEmail executes the new device login email template
+func renderNewDeviceLoginEmail(data NewDeviceLoginEmailData) (string, error) {
+	tmpl, err := template.New("new_device_login").Parse(newDeviceLoginEmailTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse new device login email template: %w", err)
+	}
+
+	var body bytes.Buffer
+	if err := tmpl.Execute(&body, data); err != nil {
+		return "", fmt.Errorf("failed to execute new device login email template: %w", err)
+	}
+	return body.String(), nil
+}
+
+// SendNewDeviceLoginEmail tells a user about a sign-in from a device or
+// network they had not used before
+func (s *Service) SendNewDeviceLoginEmail(ctx context.Context, data NewDeviceLoginEmailData) error {
+	body, err := renderNewDeviceLoginEmail(data)
+	if err != nil {
+		return err
+	}
+
+	subject := "Bank API - New sign-in to your account"
+	msg := s.buildEmailMessage(data.Email, subject, body)
+
+	addr := fmt.Sprintf("%s:%d", s.config.SMTPHost, s.config.SMTPPort)
+	if err := smtp.SendMail(addr, s.auth, s.config.FromEmail, []string{data.Email}, []byte(msg)); err != nil {
+		return fmt.Errorf("failed to send new device login email to %s: %w", data.Email, err)
+	}
+
+	return nil
+}
+
+// ProcessNewDeviceLoginEmail processes a new device login email task from the queue
+func (s *Service) ProcessNewDeviceLoginEmail(ctx context.Context, payload queue.NewDeviceLoginEmailPayload) error {
+	data := NewDeviceLoginEmailData{
+		FirstName:  payload.FirstName,
+		Email:      payload.Email,
+		IPAddress:  payload.IPAddress,
+		UserAgent:  payload.UserAgent,
+		NewDevice:  payload.NewDevice,
+		NewIPRange: payload.NewIPRange,
+		LoginAt:    payload.LoginAt.UTC().Format("2006-01-02 15:04 MST"),
+	}
+
+	if err := s.SendNewDeviceLoginEmail(ctx, data); err != nil {
+		return fmt.Errorf("failed to process new device login email for user %d: %w", payload.UserID, err)
+	}
+	return nil
+}
+
 // buildEmailMessage builds the complete email message with headers
 func (s *Service) buildEmailMessage(to, subject, body string) string {
 	var msg strings.Builder
@@ -4,6 +4,7 @@ import (
 	"context"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/phantom-sage/bankgo/internal/config"
 	"github.com/phantom-sage/bankgo/internal/queue"
@@ -160,4 +161,93 @@ func TestService_QueueWelcomeEmail_PayloadCreation(t *testing.T) {
 	
 	// We expect an error due to SMTP not being available, but the method should exist
 	assert.Error(t, err)
-}
\ No newline at end of file
+}
+
+func TestRenderAccountLockedEmail(t *testing.T) {
+	body, err := renderAccountLockedEmail(AccountLockedEmailData{
+		FirstName:   "Jane",
+		Email:       "jane@example.com",
+		IPAddress:   "203.0.113.7",
+		LockedUntil: "2025-01-01 12:15 UTC",
+	})
+
+	assert.NoError(t, err)
+	assert.Contains(t, body, "Hello Jane,")
+	assert.Contains(t, body, "jane@example.com")
+	assert.Contains(t, body, "203.0.113.7")
+	assert.Contains(t, body, "2025-01-01 12:15 UTC")
+}
+
+func TestService_ProcessAccountLockedEmail(t *testing.T) {
+	service := NewService(config.EmailConfig{
+		SMTPHost:  "smtp.example.com",
+		SMTPPort:  587,
+		FromEmail: "noreply@bankapi.com",
+		FromName:  "Bank API",
+	})
+
+	err := service.ProcessAccountLockedEmail(context.Background(), queue.AccountLockedEmailPayload{
+		UserID:      123,
+		Email:       "user@example.com",
+		FirstName:   "Jane",
+		IPAddress:   "203.0.113.7",
+		LockedUntil: time.Date(2025, 1, 1, 12, 15, 0, 0, time.UTC),
+	})
+
+	// We expect an error because SMTP server is not available
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to process account locked email")
+}
+
+func TestRenderNewDeviceLoginEmail(t *testing.T) {
+	data := NewDeviceLoginEmailData{
+		FirstName: "Jane",
+		Email:     "jane@example.com",
+		IPAddress: "203.0.113.7",
+		UserAgent: "Mozilla/5.0 (X11; Linux x86_64)",
+		NewDevice: true,
+		LoginAt:   "2025-01-01 12:00 UTC",
+	}
+
+	body, err := renderNewDeviceLoginEmail(data)
+	assert.NoError(t, err)
+	assert.Contains(t, body, "Hello Jane,")
+	assert.Contains(t, body, "from a device we had not seen")
+	assert.Contains(t, body, "203.0.113.7")
+	assert.Contains(t, body, "Mozilla/5.0 (X11; Linux x86_64)")
+	assert.Contains(t, body, "2025-01-01 12:00 UTC")
+
+	data.NewIPRange = true
+	body, err = renderNewDeviceLoginEmail(data)
+	assert.NoError(t, err)
+	assert.Contains(t, body, "from a device and a network we had not seen")
+
+	data.NewDevice, data.UserAgent = false, ""
+	body, err = renderNewDeviceLoginEmail(data)
+	assert.NoError(t, err)
+	assert.Contains(t, body, "from a network we had not seen")
+	assert.Contains(t, body, "<strong>unknown</strong>")
+}
+
+func TestService_ProcessNewDeviceLoginEmail(t *testing.T) {
+	service := NewService(config.EmailConfig{
+		SMTPHost:  "smtp.example.com",
+		SMTPPort:  587,
+		FromEmail: "noreply@bankapi.com",
+		FromName:  "Bank API",
+	})
+
+	err := service.ProcessNewDeviceLoginEmail(context.Background(), queue.NewDeviceLoginEmailPayload{
+		UserID:     123,
+		Email:      "user@example.com",
+		FirstName:  "Jane",
+		IPAddress:  "203.0.113.7",
+		UserAgent:  "curl/8.0",
+		NewIPRange: true,
+		LoginAt:    time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+	})
+
+	// We expect an error because SMTP server is not available
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to process new device login email")
+}
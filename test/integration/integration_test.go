@@ -156,7 +156,7 @@ func (suite *IntegrationTestSuite) setupRouter() {
 	suite.router.Use(middleware.RequestID())
 	
 	// Create handlers
-	authHandlers := handlers.NewAuthHandlers(suite.userService, suite.tokenManager, suite.queueManager.QueueManager)
+	authHandlers := handlers.NewAuthHandlers(suite.userService, suite.tokenManager)
 	accountHandlers := handlers.NewAccountHandlers(suite.accountService)
 	transferHandlers := handlers.NewTransferHandlers(suite.transferService, suite.accountService)
 	healthHandlers := handlers.NewHealthHandlers(suite.db, suite.queueManager.QueueManager, "test-v1.0.0")